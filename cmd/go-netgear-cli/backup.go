@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/gherlein/go-netgear/pkg/netgear"
+)
+
+// runBackup saves the configuration of a switch as a YAML or JSON export
+func runBackup(args []string) int {
+	cmd := newSwitchCommand("backup", "<address> <file>", "Saves the system info, port, POE and VLAN configuration to a file; names ending in .json are written as JSON, others as YAML.")
+	if !cmd.parse(args, 2) {
+		return ExitError
+	}
+
+	ctx := context.Background()
+	client, ok := cmd.connect(ctx)
+	if !ok {
+		return ExitError
+	}
+	export, err := client.Config().Export(ctx)
+	if err != nil {
+		return cmd.fail(err)
+	}
+	if err := export.Save(cmd.fs.Arg(1)); err != nil {
+		return cmd.fail(err)
+	}
+
+	cmd.printf("✅ Saved %s (%s) to %s\n", cmd.fs.Arg(0), export.Model, cmd.fs.Arg(1))
+	return ExitSuccess
+}
+
+// runRestore brings a switch back to a configuration saved by backup,
+// creating the VLANs it is missing first. A backup of another model is only
+// compared with --dry-run, or restored with --force, since port numbering and
+// POE capabilities differ between models.
+func runRestore(args []string) int {
+	cmd := newSwitchCommand("restore", "<address> <file>", "Applies a configuration saved by backup, listing the changes made.")
+	dryRun := cmd.fs.Bool("dry-run", false, "List the differences without changing the switch")
+	force := cmd.fs.Bool("force", false, "Restore a backup taken from a different model")
+	if !cmd.parse(args, 2) {
+		return ExitError
+	}
+	export, err := netgear.LoadExport(cmd.fs.Arg(1))
+	if err != nil {
+		cmd.printf("❌ %s\n", netgear.LocalizedError(err))
+		return ExitError
+	}
+
+	ctx := context.Background()
+	client, ok := cmd.connect(ctx)
+	if !ok {
+		return ExitError
+	}
+	if model := client.GetModel(); model != export.Model {
+		if !*dryRun && !*force {
+			cmd.printf("❌ %s is a %s, the backup was taken from a %s; use --force to restore it anyway\n", cmd.fs.Arg(0), model, export.Model)
+			return ExitError
+		}
+		cmd.printf("⚠️  %s is a %s, the backup was taken from a %s\n", cmd.fs.Arg(0), model, export.Model)
+	}
+
+	if *dryRun {
+		deviations, err := netgear.DiffConfig(ctx, client, *export.Spec())
+		if err != nil {
+			return cmd.fail(err)
+		}
+		var rows [][]string
+		for _, deviation := range deviations {
+			rows = append(rows, []string{strconv.Itoa(deviation.PortID), deviation.Field, deviation.Actual, deviation.Expected})
+		}
+		return cmd.show("deviations", []string{"Port", "Field", "Current", "Backup"}, rows)
+	}
+
+	if err := createMissingVLANs(ctx, client, export.VLANs); err != nil {
+		return cmd.fail(err)
+	}
+	report, err := netgear.ApplyConfig(ctx, client, *export.Spec())
+	if err != nil {
+		return cmd.fail(err)
+	}
+
+	var rows [][]string
+	for _, change := range report.Changes {
+		rows = append(rows, []string{strconv.Itoa(change.PortID), change.Field, change.Current, change.Desired})
+	}
+	return cmd.show("changes", []string{"Port", "Field", "Previous", "Restored"}, rows)
+}
+
+// createMissingVLANs creates the VLANs of a backup the switch does not have,
+// so restoring port membership can refer to them
+func createMissingVLANs(ctx context.Context, client *netgear.Client, vlans []netgear.VLAN) error {
+	if len(vlans) == 0 {
+		return nil
+	}
+	existing, err := client.VLANs().GetVLANs(ctx)
+	if err != nil {
+		return err
+	}
+	have := make(map[int]bool, len(existing))
+	for _, vlan := range existing {
+		have[vlan.ID] = true
+	}
+	for _, vlan := range vlans {
+		if have[vlan.ID] {
+			continue
+		}
+		if err := client.VLANs().CreateVLAN(ctx, vlan.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
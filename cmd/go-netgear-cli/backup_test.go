@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gherlein/go-netgear/pkg/netgear"
+)
+
+// fakeSwitch serves the interface, POE configuration and VLAN pages of a
+// GS316EP with two ports and records every write
+type fakeSwitch struct {
+	mu      sync.Mutex
+	members map[int]string // VLAN ID to one character per port: 1 untagged, 2 tagged, 3 none
+	pvids   map[int]int
+	writes  []string // path and encoded form of every POST
+}
+
+// newFakeSwitch starts a fake switch with every port untagged in VLAN 1 and
+// caches a session for it in a temporary cache directory
+func newFakeSwitch(t *testing.T) (*fakeSwitch, string) {
+	sw := &fakeSwitch{members: map[int]string{1: "11"}, pvids: map[int]int{1: 1, 2: 1}}
+	server := httptest.NewServer(http.HandlerFunc(sw.serve))
+	t.Cleanup(server.Close)
+	address := strings.TrimPrefix(server.URL, "http://")
+
+	dir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", dir)
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("NETGEAR_SWITCHES", "")
+	if err := netgear.NewFileTokenManager("").StoreToken(context.Background(), address, "token", netgear.ModelGS316EP); err != nil {
+		t.Fatal(err)
+	}
+	return sw, address
+}
+
+func (sw *fakeSwitch) serve(w http.ResponseWriter, r *http.Request) {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+
+	r.ParseForm()
+	if r.Method == "POST" {
+		sw.writes = append(sw.writes, r.URL.Path+" "+r.PostForm.Encode())
+	}
+	switch {
+	case r.URL.Path == "/iss/specific/interface.html":
+		fmt.Fprint(w, `<table><tr><th>Port</th></tr>
+			<tr><td>1</td><td>uplink</td><td>Auto</td><td>No Limit</td><td>No Limit</td><td>On</td><td>Up</td><td>1000M</td></tr>
+			<tr><td>2</td><td>camera</td><td>Auto</td><td>No Limit</td><td>No Limit</td><td>On</td><td>Up</td><td>100M</td></tr></table>`)
+	case r.URL.Path == "/iss/specific/poePortConf.html":
+		fmt.Fprint(w, `<input type="hidden" name="hash" value="h1">`)
+	case r.Method == "GET" && r.URL.Path == "/iss/specific/vlanConf.html":
+		var ids []int
+		for id := range sw.members {
+			ids = append(ids, id)
+		}
+		sort.Ints(ids)
+		for _, id := range ids {
+			fmt.Fprintf(w, `<input type="hidden" name="vlanId" value="%d">`, id)
+		}
+	case r.Method == "POST" && r.URL.Path == "/iss/specific/vlanConf.html":
+		if r.PostForm.Get("action") == "add" {
+			id, _ := strconv.Atoi(r.PostForm.Get("vlanId"))
+			sw.members[id] = "33"
+		}
+	case r.Method == "GET" && r.URL.Path == "/iss/specific/vlanMembership.html":
+		id, _ := strconv.Atoi(r.URL.Query().Get("vlanId"))
+		untagged := strings.NewReplacer("1", "1", "2", "0", "3", "0").Replace(sw.members[id])
+		tagged := strings.NewReplacer("1", "0", "2", "1", "3", "0").Replace(sw.members[id])
+		fmt.Fprintf(w, `<input type="hidden" name="untagPorts" value="%s"><input type="hidden" name="tagPorts" value="%s">`, untagged, tagged)
+	case r.Method == "POST" && r.URL.Path == "/iss/specific/vlanMembership.html":
+		id, _ := strconv.Atoi(r.PostForm.Get("vlanId"))
+		untagged, tagged := r.PostForm.Get("untagPorts"), r.PostForm.Get("tagPorts")
+		mem := []byte(strings.Repeat("3", len(untagged)))
+		for i := range mem {
+			if untagged[i] == '1' {
+				mem[i] = '1'
+			} else if tagged[i] == '1' {
+				mem[i] = '2'
+			}
+		}
+		sw.members[id] = string(mem)
+	case r.Method == "GET" && r.URL.Path == "/iss/specific/vlanPvid.html":
+		fmt.Fprint(w, "<table>")
+		for port, pvid := range sw.pvids {
+			fmt.Fprintf(w, "<tr><td>%d</td><td>%d</td></tr>", port, pvid)
+		}
+		fmt.Fprint(w, "</table>")
+	case r.Method == "POST" && r.URL.Path == "/iss/specific/vlanPvid.html":
+		port, _ := strconv.Atoi(r.PostForm.Get("port"))
+		pvid, _ := strconv.Atoi(r.PostForm.Get("pvid"))
+		sw.pvids[port] = pvid
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// writeBackup saves a backup of a switch whose port 1 carries VLAN 10
+// untagged and VLAN 20 tagged, neither of which the fake switch has
+func writeBackup(t *testing.T, model netgear.Model) string {
+	backup := fmt.Sprintf(`{"version": 1, "model": %q, "ports": [
+		{"port_id": 1, "port_name": "uplink", "flow_control": true, "pvid": 10, "untagged_vlans": [10], "tagged_vlans": [20]},
+		{"port_id": 2, "port_name": "camera", "flow_control": true, "pvid": 1, "untagged_vlans": [1]}
+	], "vlans": [
+		{"id": 1, "members": {"2": "untagged"}},
+		{"id": 10, "members": {"1": "untagged"}},
+		{"id": 20, "members": {"1": "tagged"}}
+	]}`, model)
+	filename := filepath.Join(t.TempDir(), "switch.json")
+	if err := os.WriteFile(filename, []byte(backup), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return filename
+}
+
+func TestRestoreDryRun(t *testing.T) {
+	sw, address := newFakeSwitch(t)
+	filename := writeBackup(t, netgear.ModelGS316EP)
+
+	var code int
+	out := captureStdout(t, func() { code = runRestore([]string{"--dry-run", "--output", "json", address, filename}) })
+	if code != ExitSuccess {
+		t.Fatalf("expected success, got exit code %d: %s", code, out)
+	}
+	if len(sw.writes) != 0 {
+		t.Errorf("expected a dry run not to write, got %v", sw.writes)
+	}
+	if !strings.Contains(out, `"Port": "1"`) || strings.Contains(out, `"Port": "2"`) {
+		t.Errorf("expected deviations of port 1 only, got %s", out)
+	}
+}
+
+func TestRestoreCreatesMissingVLANs(t *testing.T) {
+	sw, address := newFakeSwitch(t)
+	filename := writeBackup(t, netgear.ModelGS316EP)
+
+	var code int
+	out := captureStdout(t, func() { code = runRestore([]string{address, filename}) })
+	if code != ExitSuccess {
+		t.Fatalf("expected success, got exit code %d: %s", code, out)
+	}
+
+	// The VLANs are created before port 1 is moved into them
+	var created []string
+	for _, write := range sw.writes {
+		if strings.HasPrefix(write, "/iss/specific/vlanConf.html ") {
+			form := strings.TrimPrefix(write, "/iss/specific/vlanConf.html ")
+			if !strings.Contains(form, "action=add") {
+				t.Errorf("unexpected VLAN write %s", form)
+			}
+			created = append(created, form)
+		} else if len(created) < 2 {
+			t.Errorf("expected VLANs to be created before %s", write)
+		}
+	}
+	if len(created) != 2 || !strings.Contains(created[0], "vlanId=10") || !strings.Contains(created[1], "vlanId=20") {
+		t.Errorf("expected VLANs 10 and 20 to be created, got %v", created)
+	}
+	if sw.members[10][0] != '1' || sw.members[20][0] != '2' || sw.members[1][0] != '3' || sw.pvids[1] != 10 {
+		t.Errorf("expected port 1 in VLAN 10 untagged and VLAN 20 tagged, got %v, PVIDs %v", sw.members, sw.pvids)
+	}
+	if sw.members[1][1] != '1' || sw.pvids[2] != 1 {
+		t.Errorf("expected port 2 to stay in VLAN 1, got %v, PVIDs %v", sw.members, sw.pvids)
+	}
+}
+
+func TestRestoreRefusesOtherModel(t *testing.T) {
+	sw, address := newFakeSwitch(t)
+	filename := writeBackup(t, netgear.ModelGS308EPP)
+
+	var code int
+	out := captureStdout(t, func() { code = runRestore([]string{address, filename}) })
+	if code != ExitError || !strings.Contains(out, "--force") {
+		t.Errorf("expected the restore to be refused, got exit code %d: %s", code, out)
+	}
+	if len(sw.writes) != 0 {
+		t.Errorf("expected no writes, got %v", sw.writes)
+	}
+
+	// A dry run only compares, and --force restores anyway
+	captureStdout(t, func() { code = runRestore([]string{"--dry-run", address, filename}) })
+	if code != ExitSuccess || len(sw.writes) != 0 {
+		t.Errorf("expected a dry run to succeed without writes, got exit code %d and %v", code, sw.writes)
+	}
+	captureStdout(t, func() { code = runRestore([]string{"--force", address, filename}) })
+	if code != ExitSuccess || sw.pvids[1] != 10 {
+		t.Errorf("expected --force to restore, got exit code %d, PVIDs %v", code, sw.pvids)
+	}
+}
@@ -0,0 +1,294 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gherlein/go-netgear/pkg/i18n"
+	"github.com/gherlein/go-netgear/pkg/netgear"
+)
+
+// nagiosState is a Nagios plugin result; its value is the plugin's exit code
+type nagiosState int
+
+const (
+	nagiosOK nagiosState = iota
+	nagiosWarning
+	nagiosCritical
+	nagiosUnknown
+)
+
+func (s nagiosState) String() string {
+	switch s {
+	case nagiosOK:
+		return "OK"
+	case nagiosWarning:
+		return "WARNING"
+	case nagiosCritical:
+		return "CRITICAL"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// nagiosResult is the single status line a plugin prints
+type nagiosResult struct {
+	Check    string
+	State    nagiosState
+	Summary  string
+	Perfdata []string
+}
+
+// print writes the result in plugin format and returns the exit code
+func (r nagiosResult) print() int {
+	line := fmt.Sprintf("NETGEAR %s %s - %s", strings.ToUpper(r.Check), r.State, r.Summary)
+	if len(r.Perfdata) > 0 {
+		line += " | " + strings.Join(r.Perfdata, " ")
+	}
+	fmt.Println(line)
+	return int(r.State)
+}
+
+// nagiosThreshold classifies value against warning and critical thresholds,
+// where higher values are worse; a zero threshold disables that level
+func nagiosThreshold(value, warning, critical float64) nagiosState {
+	switch {
+	case critical > 0 && value >= critical:
+		return nagiosCritical
+	case warning > 0 && value >= warning:
+		return nagiosWarning
+	default:
+		return nagiosOK
+	}
+}
+
+// nagiosThresholdField renders a threshold for perfdata, leaving disabled thresholds empty
+func nagiosThresholdField(threshold float64) string {
+	if threshold <= 0 {
+		return ""
+	}
+	return strconv.FormatFloat(threshold, 'f', -1, 64)
+}
+
+func runCheck(args []string) int {
+	if len(args) == 0 {
+		fmt.Printf("NETGEAR UNKNOWN - %s\n", i18n.T("check.requires_subcommand"))
+		fmt.Printf("Usage: go-netgear-cli check <poe-budget|port-status|reachable> [options] <address>\n")
+		return int(nagiosUnknown)
+	}
+
+	var result nagiosResult
+	switch args[0] {
+	case "poe-budget":
+		result = runCheckPOEBudget(args[1:])
+	case "port-status":
+		result = runCheckPortStatus(args[1:])
+	case "reachable":
+		result = runCheckReachable(args[1:])
+	default:
+		result = nagiosResult{Check: args[0], State: nagiosUnknown, Summary: i18n.T("check.unknown_subcommand", args[0])}
+	}
+	return result.print()
+}
+
+// newCheckFlagSet creates the flag set shared by every check, including the
+// connection options; the caller adds check-specific thresholds. Parse errors
+// are returned rather than exiting so they map to UNKNOWN instead of CRITICAL.
+func newCheckFlagSet(name, usage string) (*flag.FlagSet, *string, *time.Duration) {
+	fs := flag.NewFlagSet("check "+name, flag.ContinueOnError)
+	password := fs.String("password", "", "Admin password (defaults to NETGEAR_PASSWORD_<HOST> / NETGEAR_SWITCHES)")
+	timeout := fs.Duration("timeout", 10*time.Second, "Timeout for each network request")
+	fs.StringVar(password, "p", "", "Admin password (short)")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: go-netgear-cli check %s [options] <address>\n\n%s\n\n", name, usage)
+		fs.PrintDefaults()
+	}
+	return fs, password, timeout
+}
+
+// checkAddress returns the single address argument of a check
+func checkAddress(fs *flag.FlagSet) (string, bool) {
+	if fs.NArg() != 1 {
+		return "", false
+	}
+	return fs.Arg(0), true
+}
+
+// runCheckPOEBudget alerts on the share of the POE budget in use
+func runCheckPOEBudget(args []string) nagiosResult {
+	fs, password, timeout := newCheckFlagSet("poe-budget", "Alerts when the POE power in use reaches a percentage of the switch's budget.")
+	warning := fs.Float64("warning", 80, "Warning threshold in percent of the budget used (0 disables)")
+	critical := fs.Float64("critical", 95, "Critical threshold in percent of the budget used (0 disables)")
+	result := nagiosResult{Check: "poe-budget", State: nagiosUnknown}
+	if err := fs.Parse(args); err != nil {
+		result.Summary = err.Error()
+		return result
+	}
+	address, ok := checkAddress(fs)
+	if !ok {
+		result.Summary = i18n.T("check.requires_address")
+		return result
+	}
+
+	ctx := context.Background()
+	client, err := connectSwitch(ctx, address, *password, *timeout)
+	if err != nil {
+		result.Summary = netgear.LocalizedError(err)
+		return result
+	}
+	budget, err := client.POE().GetBudget(ctx)
+	if err != nil {
+		result.Summary = netgear.LocalizedError(err)
+		return result
+	}
+	return poeBudgetResult(*budget, *warning, *critical)
+}
+
+// poeBudgetResult classifies the share of the budget in use against warning
+// and critical thresholds in percent
+func poeBudgetResult(budget netgear.POEBudget, warning, critical float64) nagiosResult {
+	result := nagiosResult{Check: "poe-budget"}
+	usedPct := budget.UsedPercent()
+	result.State = nagiosThreshold(usedPct, warning, critical)
+	result.Summary = fmt.Sprintf("%.1f W of %.1f W used (%.1f%%), %.1f W free",
+		budget.ConsumedW, budget.TotalW, usedPct, budget.RemainingW)
+
+	warningW, criticalW := 0.0, 0.0
+	if warning > 0 {
+		warningW = budget.TotalW * warning / 100
+	}
+	if critical > 0 {
+		criticalW = budget.TotalW * critical / 100
+	}
+	result.Perfdata = []string{
+		fmt.Sprintf("consumed=%.1fW;%s;%s;0;%.1f", budget.ConsumedW,
+			nagiosThresholdField(warningW), nagiosThresholdField(criticalW), budget.TotalW),
+		fmt.Sprintf("used=%.1f%%;%s;%s;0;100", usedPct,
+			nagiosThresholdField(warning), nagiosThresholdField(critical)),
+	}
+	return result
+}
+
+// runCheckPortStatus alerts when any of the watched ports has no link
+func runCheckPortStatus(args []string) nagiosResult {
+	fs, password, timeout := newCheckFlagSet("port-status", "Alerts when any of the given ports has no link.")
+	portList := fs.String("ports", "", "Comma-separated port numbers that must have link (default: all enabled ports)")
+	warnOnly := fs.Bool("warn-only", false, "Report ports without link as WARNING instead of CRITICAL")
+	result := nagiosResult{Check: "port-status", State: nagiosUnknown}
+	if err := fs.Parse(args); err != nil {
+		result.Summary = err.Error()
+		return result
+	}
+	address, ok := checkAddress(fs)
+	if !ok {
+		result.Summary = i18n.T("check.requires_address")
+		return result
+	}
+
+	watched := make(map[int]bool)
+	if *portList != "" {
+		for _, field := range strings.Split(*portList, ",") {
+			portID, err := strconv.Atoi(strings.TrimSpace(field))
+			if err != nil {
+				result.Summary = i18n.T("check.invalid_port", field)
+				return result
+			}
+			watched[portID] = true
+		}
+	}
+
+	ctx := context.Background()
+	client, err := connectSwitch(ctx, address, *password, *timeout)
+	if err != nil {
+		result.Summary = netgear.LocalizedError(err)
+		return result
+	}
+	ports, err := client.Ports().GetSettings(ctx)
+	if err != nil {
+		result.Summary = netgear.LocalizedError(err)
+		return result
+	}
+	return portStatusResult(ports, watched, *warnOnly)
+}
+
+// portStatusResult reports the watched ports without link, or all enabled
+// ports when none are watched; a watched port the switch lacks is UNKNOWN
+func portStatusResult(ports []netgear.PortSettings, watched map[int]bool, warnOnly bool) nagiosResult {
+	result := nagiosResult{Check: "port-status", State: nagiosUnknown}
+	var down []string
+	up, total := 0, 0
+	filtered := len(watched) > 0
+	for _, port := range ports {
+		if filtered {
+			if !watched[port.PortID] {
+				continue
+			}
+			delete(watched, port.PortID)
+		} else if port.Status == netgear.PortStatusDisabled {
+			continue
+		}
+		total++
+		if port.Status == netgear.PortStatusConnected {
+			up++
+			continue
+		}
+		down = append(down, strconv.Itoa(port.PortID))
+	}
+	for portID := range watched {
+		result.Summary = i18n.T("check.invalid_port", strconv.Itoa(portID))
+		return result
+	}
+
+	result.Perfdata = []string{fmt.Sprintf("up=%d;;;0;%d", up, total)}
+	if len(down) == 0 {
+		result.State = nagiosOK
+		result.Summary = fmt.Sprintf("%d of %d ports up", up, total)
+		return result
+	}
+
+	result.State = nagiosCritical
+	if warnOnly {
+		result.State = nagiosWarning
+	}
+	result.Summary = fmt.Sprintf("no link on port %s (%d of %d up)", strings.Join(down, ", "), up, total)
+	return result
+}
+
+// runCheckReachable alerts when the management web server is slow or unreachable
+func runCheckReachable(args []string) nagiosResult {
+	fs, _, timeout := newCheckFlagSet("reachable", "Alerts when the switch's management web server is slow to accept connections or unreachable.")
+	warning := fs.Duration("warning", time.Second, "Warning threshold for the connect time (0 disables)")
+	critical := fs.Duration("critical", 3*time.Second, "Critical threshold for the connect time (0 disables)")
+	result := nagiosResult{Check: "reachable", State: nagiosUnknown}
+	if err := fs.Parse(args); err != nil {
+		result.Summary = err.Error()
+		return result
+	}
+	address, ok := checkAddress(fs)
+	if !ok {
+		result.Summary = i18n.T("check.requires_address")
+		return result
+	}
+
+	host := managementHostPort(address)
+	start := time.Now()
+	conn, err := net.DialTimeout("tcp", host, *timeout)
+	if err != nil {
+		result.State = nagiosCritical
+		result.Summary = fmt.Sprintf("%s unreachable: %v", host, err)
+		return result
+	}
+	conn.Close()
+	elapsed := time.Since(start)
+
+	result.State = nagiosThreshold(elapsed.Seconds(), warning.Seconds(), critical.Seconds())
+	result.Summary = fmt.Sprintf("%s answered in %s", host, elapsed.Round(time.Millisecond))
+	result.Perfdata = []string{fmt.Sprintf("time=%.3fs;%s;%s;0",
+		elapsed.Seconds(), nagiosThresholdField(warning.Seconds()), nagiosThresholdField(critical.Seconds()))}
+	return result
+}
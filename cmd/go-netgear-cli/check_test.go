@@ -0,0 +1,190 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gherlein/go-netgear/pkg/netgear"
+)
+
+// captureStdout returns what fn prints to standard output
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	done := make(chan string)
+	go func() {
+		out, _ := io.ReadAll(r)
+		done <- string(out)
+	}()
+	fn()
+	w.Close()
+	return <-done
+}
+
+func TestNagiosThreshold(t *testing.T) {
+	tests := []struct {
+		value, warning, critical float64
+		want                     nagiosState
+	}{
+		{value: 10, warning: 80, critical: 95, want: nagiosOK},
+		{value: 80, warning: 80, critical: 95, want: nagiosWarning},
+		{value: 94.9, warning: 80, critical: 95, want: nagiosWarning},
+		{value: 95, warning: 80, critical: 95, want: nagiosCritical},
+		{value: 120, warning: 80, critical: 95, want: nagiosCritical},
+		{value: 90, warning: 0, critical: 95, want: nagiosOK},
+		{value: 99, warning: 80, critical: 0, want: nagiosWarning},
+		{value: 99, warning: 0, critical: 0, want: nagiosOK},
+	}
+	for _, test := range tests {
+		if got := nagiosThreshold(test.value, test.warning, test.critical); got != test.want {
+			t.Errorf("nagiosThreshold(%v, %v, %v) = %s, expected %s", test.value, test.warning, test.critical, got, test.want)
+		}
+	}
+}
+
+func TestNagiosThresholdField(t *testing.T) {
+	for threshold, want := range map[float64]string{0: "", -1: "", 80: "80", 49.6: "49.6", 0.25: "0.25"} {
+		if got := nagiosThresholdField(threshold); got != want {
+			t.Errorf("nagiosThresholdField(%v) = %q, expected %q", threshold, got, want)
+		}
+	}
+}
+
+func TestNagiosResultPrint(t *testing.T) {
+	tests := []struct {
+		result nagiosResult
+		line   string
+	}{
+		{nagiosResult{Check: "poe-budget", State: nagiosOK, Summary: "fine"}, "NETGEAR POE-BUDGET OK - fine"},
+		{nagiosResult{Check: "port-status", State: nagiosWarning, Summary: "no link", Perfdata: []string{"up=7;;;0;8"}}, "NETGEAR PORT-STATUS WARNING - no link | up=7;;;0;8"},
+		{nagiosResult{Check: "reachable", State: nagiosCritical, Summary: "down", Perfdata: []string{"a=1", "b=2"}}, "NETGEAR REACHABLE CRITICAL - down | a=1 b=2"},
+		{nagiosResult{Check: "x", State: nagiosUnknown, Summary: "?"}, "NETGEAR X UNKNOWN - ?"},
+	}
+	for _, test := range tests {
+		var code int
+		out := captureStdout(t, func() { code = test.result.print() })
+		if out != test.line+"\n" {
+			t.Errorf("expected %q, got %q", test.line, out)
+		}
+		if code != int(test.result.State) {
+			t.Errorf("%s: expected exit code %d, got %d", test.line, test.result.State, code)
+		}
+	}
+	if int(nagiosOK) != 0 || int(nagiosWarning) != 1 || int(nagiosCritical) != 2 || int(nagiosUnknown) != 3 {
+		t.Error("states must map to the Nagios plugin exit codes 0 to 3")
+	}
+}
+
+func TestPOEBudgetResult(t *testing.T) {
+	budget := netgear.POEBudget{TotalW: 62, ConsumedW: 49.6, RemainingW: 12.4}
+	tests := []struct {
+		warning, critical float64
+		state             nagiosState
+		perfdata          []string
+	}{
+		{80, 95, nagiosWarning, []string{"consumed=49.6W;49.6;58.9;0;62.0", "used=80.0%;80;95;0;100"}},
+		{90, 95, nagiosOK, []string{"consumed=49.6W;55.8;58.9;0;62.0", "used=80.0%;90;95;0;100"}},
+		{50, 75, nagiosCritical, []string{"consumed=49.6W;31;46.5;0;62.0", "used=80.0%;50;75;0;100"}},
+		{0, 0, nagiosOK, []string{"consumed=49.6W;;;0;62.0", "used=80.0%;;;0;100"}},
+	}
+	for _, test := range tests {
+		result := poeBudgetResult(budget, test.warning, test.critical)
+		if result.State != test.state || strings.Join(result.Perfdata, " ") != strings.Join(test.perfdata, " ") {
+			t.Errorf("thresholds %v/%v: got %s %q, expected %s %q", test.warning, test.critical, result.State, result.Perfdata, test.state, test.perfdata)
+		}
+	}
+	if summary := poeBudgetResult(budget, 80, 95).Summary; summary != "49.6 W of 62.0 W used (80.0%), 12.4 W free" {
+		t.Errorf("unexpected summary %q", summary)
+	}
+}
+
+func TestPortStatusResult(t *testing.T) {
+	ports := []netgear.PortSettings{
+		{PortID: 1, Status: netgear.PortStatusConnected},
+		{PortID: 2, Status: netgear.PortStatusAvailable},
+		{PortID: 3, Status: netgear.PortStatusDisabled},
+		{PortID: 4, Status: netgear.PortStatusConnected},
+	}
+	tests := []struct {
+		name     string
+		watched  map[int]bool
+		warnOnly bool
+		state    nagiosState
+		perfdata string
+	}{
+		{"enabled ports", nil, false, nagiosCritical, "up=2;;;0;3"},
+		{"warn only", nil, true, nagiosWarning, "up=2;;;0;3"},
+		{"watched up", map[int]bool{1: true, 4: true}, false, nagiosOK, "up=2;;;0;2"},
+		{"watched disabled", map[int]bool{3: true}, false, nagiosCritical, "up=0;;;0;1"},
+		{"unknown port", map[int]bool{1: true, 9: true}, false, nagiosUnknown, ""},
+	}
+	for _, test := range tests {
+		result := portStatusResult(ports, test.watched, test.warnOnly)
+		if result.State != test.state || strings.Join(result.Perfdata, " ") != test.perfdata {
+			t.Errorf("%s: got %s %q, expected %s %q", test.name, result.State, result.Perfdata, test.state, test.perfdata)
+		}
+	}
+	if summary := portStatusResult(ports, nil, false).Summary; summary != "no link on port 2 (2 of 3 up)" {
+		t.Errorf("unexpected summary %q", summary)
+	}
+}
+
+func TestCheckArguments(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"no subcommand", nil},
+		{"unknown subcommand", []string{"fan-speed", "10.0.0.1"}},
+		{"no address", []string{"poe-budget"}},
+		{"two addresses", []string{"reachable", "10.0.0.1", "10.0.0.2"}},
+		{"invalid threshold", []string{"poe-budget", "--warning", "lots", "10.0.0.1"}},
+		{"invalid duration", []string{"reachable", "--critical", "3", "10.0.0.1"}},
+		{"invalid port", []string{"port-status", "--ports", "1,x", "10.0.0.1"}},
+	}
+	for _, test := range tests {
+		var code int
+		out := captureStdout(t, func() {
+			stderr := os.Stderr
+			os.Stderr, _ = os.Open(os.DevNull)
+			defer func() { os.Stderr = stderr }()
+			code = runCheck(test.args)
+		})
+		if code != int(nagiosUnknown) || !strings.Contains(out, "UNKNOWN") {
+			t.Errorf("%s: expected UNKNOWN with exit code 3, got %d %q", test.name, code, out)
+		}
+	}
+}
+
+func TestCheckReachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	result := runCheckReachable([]string{"--warning", "1h", "--critical", "2h", server.URL})
+	if result.State != nagiosOK {
+		t.Errorf("expected OK, got %s: %s", result.State, result.Summary)
+	}
+	if len(result.Perfdata) != 1 || !regexp.MustCompile(`^time=\d+\.\d{3}s;3600;7200;0$`).MatchString(result.Perfdata[0]) {
+		t.Errorf("unexpected perfdata %q", result.Perfdata)
+	}
+	if result := runCheckReachable([]string{"--warning", "1ns", "--critical", "0", server.URL}); result.State != nagiosWarning {
+		t.Errorf("expected WARNING above the warning threshold, got %s", result.State)
+	}
+
+	server.Close()
+	if result := runCheckReachable([]string{server.URL}); result.State != nagiosCritical {
+		t.Errorf("expected CRITICAL for a closed port, got %s: %s", result.State, result.Summary)
+	}
+}
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gherlein/go-netgear/pkg/i18n"
+)
+
+// completeCommand is the hidden command the completion scripts call with
+// the words typed so far; it prints one candidate per line. Printing
+// nothing makes the shell fall back to completing file names.
+const completeCommand = "__complete"
+
+// completionArg is what a positional argument of a command holds
+type completionArg int
+
+const (
+	argOther completionArg = iota
+	argSwitch
+	argShell
+)
+
+// completionCommand describes a command for completion
+type completionCommand struct {
+	name        string
+	subcommands []completionCommand
+	// args are the positional arguments; the last one repeats when repeat is set
+	args   []completionArg
+	repeat bool
+}
+
+// completionCommands mirrors the commands dispatched by main
+var completionCommands = []completionCommand{
+	{name: "login", args: []completionArg{argSwitch}},
+	{name: "logout", args: []completionArg{argSwitch}},
+	{name: "poe", subcommands: []completionCommand{
+		{name: "status", args: []completionArg{argSwitch}},
+		{name: "settings", args: []completionArg{argSwitch}},
+		{name: "set", args: []completionArg{argSwitch}},
+		{name: "cycle", args: []completionArg{argSwitch}},
+		{name: "budget", args: []completionArg{argSwitch}, repeat: true},
+	}},
+	{name: "port", subcommands: []completionCommand{
+		{name: "status", args: []completionArg{argSwitch}},
+		{name: "set", args: []completionArg{argSwitch}},
+		{name: "stats", args: []completionArg{argSwitch}},
+		{name: "reset-stats", args: []completionArg{argSwitch}},
+		{name: "neighbors", args: []completionArg{argSwitch}},
+	}},
+	{name: "vlan", subcommands: []completionCommand{
+		{name: "list", args: []completionArg{argSwitch}},
+		{name: "create", args: []completionArg{argSwitch}},
+		{name: "delete", args: []completionArg{argSwitch}},
+		{name: "access", args: []completionArg{argSwitch}},
+		{name: "trunk", args: []completionArg{argSwitch}},
+	}},
+	{name: "backup", args: []completionArg{argSwitch}},
+	{name: "restore", args: []completionArg{argSwitch}},
+	{name: "reboot", args: []completionArg{argSwitch}},
+	{name: "check", subcommands: []completionCommand{
+		{name: "poe-budget", args: []completionArg{argSwitch}},
+		{name: "port-status", args: []completionArg{argSwitch}},
+		{name: "reachable", args: []completionArg{argSwitch}},
+	}},
+	{name: "doctor"},
+	{name: "zabbix", subcommands: []completionCommand{
+		{name: "discovery", args: []completionArg{argSwitch}, repeat: true},
+		{name: "get", args: []completionArg{argSwitch}},
+	}},
+	{name: "version"},
+	{name: "completion", args: []completionArg{argShell}},
+}
+
+// boolFlags are the flags that take no value, so the word after them is an argument
+var boolFlags = map[string]bool{
+	"json": true, "enabled": true, "flow-control": true, "dry-run": true, "force": true,
+	"warn-only": true, "validate-config": true, "help": true, "h": true,
+}
+
+// completionShells are the shells completion scripts are generated for
+var completionShells = []string{"bash", "zsh", "fish"}
+
+// outputFormatNames are the --output values offered for completion
+var outputFormatNames = []string{"table", "markdown", "json", "yaml"}
+
+// runCompletion prints the completion script for a shell
+func runCompletion(args []string) int {
+	if len(args) != 1 {
+		fmt.Printf("❌ %s\n\n", i18n.T("completion.requires_shell"))
+		fmt.Printf("Usage: go-netgear-cli completion <bash|zsh|fish>\n\n")
+		fmt.Printf("  bash: source <(go-netgear-cli completion bash)\n")
+		fmt.Printf("  zsh:  go-netgear-cli completion zsh > \"${fpath[1]}/_go-netgear-cli\"\n")
+		fmt.Printf("  fish: go-netgear-cli completion fish > ~/.config/fish/completions/go-netgear-cli.fish\n")
+		return ExitError
+	}
+
+	prog := filepath.Base(os.Args[0])
+	function := "__" + strings.NewReplacer("-", "_", ".", "_").Replace(prog) + "_complete"
+	switch args[0] {
+	case "bash":
+		fmt.Printf(bashCompletion, prog, function, completeCommand)
+	case "zsh":
+		fmt.Printf(zshCompletion, prog, function, completeCommand)
+	case "fish":
+		fmt.Printf(fishCompletion, prog, function, completeCommand)
+	default:
+		fmt.Printf("❌ %s\n", i18n.T("completion.unknown_shell", args[0]))
+		return ExitError
+	}
+	return ExitSuccess
+}
+
+// runComplete prints the candidates for the last of the words typed so far
+func runComplete(words []string) int {
+	if len(words) == 0 {
+		words = []string{""}
+	}
+	for _, candidate := range completeWords(words[:len(words)-1], words[len(words)-1]) {
+		fmt.Println(candidate)
+	}
+	return ExitSuccess
+}
+
+// completeWords returns the candidates for current after the words before it
+func completeWords(before []string, current string) []string {
+	level := completionCommands
+	var command *completionCommand
+	positional := 0
+	flagValue := ""
+
+	for _, word := range before {
+		if flagValue != "" {
+			if flagValue == "s" || flagValue == "switch" {
+				positional++ // the switch given by name replaces the address
+			}
+			flagValue = ""
+			continue
+		}
+		if strings.HasPrefix(word, "-") {
+			name := strings.TrimLeft(word, "-")
+			if !strings.Contains(name, "=") && !boolFlags[name] {
+				flagValue = name
+			}
+			continue
+		}
+		if level != nil {
+			found := findCommand(level, word)
+			if found == nil {
+				return nil
+			}
+			command, level = found, found.subcommands
+			continue
+		}
+		positional++
+	}
+
+	var candidates []string
+	switch {
+	case flagValue == "o" || flagValue == "output":
+		candidates = outputFormatNames
+	case flagValue == "s" || flagValue == "switch" || flagValue == "a" || flagValue == "address":
+		candidates = profileNames()
+	case flagValue != "" || strings.HasPrefix(current, "-"):
+		return nil
+	case level != nil:
+		for _, c := range level {
+			candidates = append(candidates, c.name)
+		}
+	default:
+		switch command.arg(positional) {
+		case argSwitch:
+			candidates = profileNames()
+		case argShell:
+			candidates = completionShells
+		}
+	}
+
+	var matches []string
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, current) {
+			matches = append(matches, candidate)
+		}
+	}
+	return matches
+}
+
+// findCommand returns the named command of a level, or nil
+func findCommand(level []completionCommand, name string) *completionCommand {
+	for i := range level {
+		if level[i].name == name {
+			return &level[i]
+		}
+	}
+	return nil
+}
+
+// arg returns what the positional argument at index holds
+func (c *completionCommand) arg(index int) completionArg {
+	if index < len(c.args) {
+		return c.args[index]
+	}
+	if c.repeat && len(c.args) > 0 {
+		return c.args[len(c.args)-1]
+	}
+	return argOther
+}
+
+// bashCompletion is the bash script; %[1]s is the program, %[2]s the
+// function name and %[3]s the hidden completion command
+const bashCompletion = `# bash completion for %[1]s
+%[2]s() {
+    local IFS=$'\n'
+    local candidates
+    candidates=$("${COMP_WORDS[0]}" %[3]s "${COMP_WORDS[@]:1:COMP_CWORD}" 2>/dev/null)
+    if [ -z "$candidates" ]; then
+        compopt -o default 2>/dev/null
+        COMPREPLY=()
+        return
+    fi
+    COMPREPLY=($(compgen -W "$candidates" -- "${COMP_WORDS[COMP_CWORD]}"))
+}
+complete -F %[2]s %[1]s
+`
+
+// zshCompletion is the zsh script
+const zshCompletion = `#compdef %[1]s
+%[2]s() {
+    local -a candidates
+    candidates=("${(@f)$("${words[1]}" %[3]s "${(@)words[2,CURRENT]}" 2>/dev/null)}")
+    if [[ -z "${candidates[1]}" ]]; then
+        _files
+    else
+        compadd -a candidates
+    fi
+}
+if [ "$funcstack[1]" = "_%[1]s" ]; then
+    %[2]s "$@"
+else
+    compdef %[2]s %[1]s
+fi
+`
+
+// fishCompletion is the fish script
+const fishCompletion = `# fish completion for %[1]s
+function %[2]s
+    set -l words (commandline -opc) (commandline -ct)
+    $words[1] %[3]s $words[2..-1] 2>/dev/null
+end
+complete -c %[1]s -f -n 'count (%[2]s) >/dev/null' -a '(%[2]s)'
+`
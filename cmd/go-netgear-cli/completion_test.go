@@ -0,0 +1,142 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// dispatchedCommands reads the commands main dispatches, and for each the
+// subcommands its run function dispatches, from the package source
+func dispatchedCommands(t *testing.T) map[string][]string {
+	t.Helper()
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fset := token.NewFileSet()
+	funcs := make(map[string]*ast.FuncDecl)
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		parsed, err := parser.ParseFile(fset, file, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, decl := range parsed.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil {
+				funcs[fn.Name.Name] = fn
+			}
+		}
+	}
+
+	commands := make(map[string][]string)
+	for name, body := range switchCases(funcs["main"], "os.Args[1]") {
+		var subcommands []string
+		ast.Inspect(body, func(n ast.Node) bool {
+			if call, ok := n.(*ast.CallExpr); ok {
+				if ident, ok := call.Fun.(*ast.Ident); ok && funcs[ident.Name] != nil && ident.Name != "main" {
+					for subcommand := range switchCases(funcs[ident.Name], "args[0]") {
+						subcommands = append(subcommands, subcommand)
+					}
+				}
+			}
+			return true
+		})
+		commands[name] = subcommands
+	}
+	return commands
+}
+
+// switchCases returns the string cases of the first switch on tag in fn
+// together with their bodies
+func switchCases(fn *ast.FuncDecl, tag string) map[string]ast.Node {
+	cases := make(map[string]ast.Node)
+	if fn == nil {
+		return cases
+	}
+	found := false
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		sw, ok := n.(*ast.SwitchStmt)
+		if found || !ok || sw.Tag == nil || exprString(sw.Tag) != tag {
+			return !found
+		}
+		found = true
+		for _, stmt := range sw.Body.List {
+			clause := stmt.(*ast.CaseClause)
+			for _, expr := range clause.List {
+				if lit, ok := expr.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+					value, _ := strconv.Unquote(lit.Value)
+					cases[value] = clause
+				}
+			}
+		}
+		return false
+	})
+	return cases
+}
+
+// exprString renders the simple expressions used as switch tags
+func exprString(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e.Name
+	case *ast.SelectorExpr:
+		return exprString(e.X) + "." + e.Sel.Name
+	case *ast.IndexExpr:
+		return exprString(e.X) + "[" + exprString(e.Index) + "]"
+	case *ast.BasicLit:
+		return e.Value
+	}
+	return ""
+}
+
+func TestCompletionCoversDispatchedCommands(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	commands := dispatchedCommands(t)
+	if len(commands) < 10 || len(commands["poe"]) == 0 {
+		t.Fatalf("expected to find the commands main dispatches, got %v", commands)
+	}
+
+	topLevel := strings.Fields(captureStdout(t, func() { runComplete([]string{""}) }))
+	for command, subcommands := range commands {
+		if !slices.Contains(topLevel, command) {
+			t.Errorf("command %q is dispatched by main but not completed", command)
+		}
+		offered := strings.Fields(captureStdout(t, func() { runComplete([]string{command, ""}) }))
+		for _, subcommand := range subcommands {
+			if !slices.Contains(offered, subcommand) {
+				t.Errorf("subcommand %q of %q is dispatched but not completed", subcommand, command)
+			}
+		}
+		for _, subcommand := range offered {
+			if len(subcommands) > 0 && !slices.Contains(subcommands, subcommand) {
+				t.Errorf("subcommand %q of %q is completed but not dispatched", subcommand, command)
+			}
+		}
+	}
+	for _, command := range topLevel {
+		if _, ok := commands[command]; !ok {
+			t.Errorf("command %q is completed but not dispatched by main", command)
+		}
+	}
+}
+
+func TestCompletionScriptsCallHiddenCommand(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh"} {
+		var code int
+		script := captureStdout(t, func() { code = runCompletion([]string{shell}) })
+		if code != ExitSuccess {
+			t.Fatalf("%s: expected success, got exit code %d", shell, code)
+		}
+		if !strings.Contains(script, " "+completeCommand+" ") {
+			t.Errorf("%s: expected the script to ask the %s command for candidates:\n%s", shell, completeCommand, script)
+		}
+	}
+}
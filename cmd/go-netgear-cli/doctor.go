@@ -0,0 +1,418 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gherlein/go-netgear/pkg/i18n"
+	"github.com/gherlein/go-netgear/pkg/netgear"
+)
+
+// checkStatus is the outcome of a single doctor check
+type checkStatus int
+
+const (
+	checkPass checkStatus = iota
+	checkWarn
+	checkFail
+	checkSkip
+)
+
+// doctorCheck holds the result of a single diagnostic check
+type doctorCheck struct {
+	Name   string
+	Status checkStatus
+	Detail string
+	Hint   string
+}
+
+// maxClockSkew is the difference between switch and host clock considered worth reporting
+const maxClockSkew = 2 * time.Minute
+
+// loginPaths are the login pages used by the supported switch families
+var loginPaths = []string{"/login.cgi", "/wmi/login", "/redirect.html"}
+
+func runDoctor(args []string) int {
+	fs := flag.NewFlagSet("doctor", flag.ExitOnError)
+	address := fs.String("address", "", "Switch IP address or host name to diagnose")
+	password := fs.String("password", "", "Admin password (defaults to NETGEAR_PASSWORD_<HOST> / NETGEAR_SWITCHES)")
+	timeout := fs.Duration("timeout", 10*time.Second, "Timeout for each network request")
+	login := fs.Bool("login", false, "Log in to check parser coverage when no cached session exists; this ends other sessions on the switch")
+	fs.StringVar(address, "a", "", "Switch IP address or host name to diagnose (short)")
+	fs.StringVar(password, "p", "", "Admin password (short)")
+	fs.Parse(args)
+
+	if *address == "" {
+		fmt.Printf("❌ %s\n\n", i18n.T("doctor.requires_address"))
+		fs.Usage()
+		return ExitError
+	}
+	// Diagnose the address behind a profile name
+	if profile, ok := loadProfiles().Lookup(*address); ok {
+		*address = profile.Address
+	}
+
+	fmt.Printf("Running diagnostics against %s\n\n", *address)
+
+	var checks []doctorCheck
+	httpClient := &http.Client{
+		Timeout: *timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	baseURL := "http://" + *address
+	if strings.HasPrefix(*address, "http://") || strings.HasPrefix(*address, "https://") {
+		baseURL = *address
+	}
+
+	reachable := checkReachability(*address, *timeout)
+	checks = append(checks, reachable)
+	if reachable.Status == checkFail {
+		printDoctorReport(checks)
+		return ExitError
+	}
+
+	checks = append(checks, checkLoginPaths(httpClient, baseURL))
+	checks = append(checks, checkClockSkew(httpClient, baseURL))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 4**timeout)
+	defer cancel()
+
+	detection, model := checkModelDetection(ctx, *address, *timeout)
+	checks = append(checks, detection)
+
+	session, client := checkSessionState(ctx, *address, *timeout)
+	checks = append(checks, session)
+
+	if model != "" {
+		checks = append(checks, checkParserCoverage(ctx, client, *address, *password, *login, *timeout)...)
+	}
+
+	printDoctorReport(checks)
+
+	for _, check := range checks {
+		if check.Status == checkFail {
+			return ExitError
+		}
+	}
+	return ExitSuccess
+}
+
+// checkReachability verifies the management web server accepts TCP connections
+func checkReachability(address string, timeout time.Duration) doctorCheck {
+	check := doctorCheck{Name: "Reachability"}
+	host := managementHostPort(address)
+
+	start := time.Now()
+	conn, err := net.DialTimeout("tcp", host, timeout)
+	if err != nil {
+		check.Status = checkFail
+		check.Detail = err.Error()
+		check.Hint = i18n.T("doctor.hint.unreachable")
+		return check
+	}
+	conn.Close()
+
+	check.Status = checkPass
+	check.Detail = fmt.Sprintf("connected to %s in %s", host, time.Since(start).Round(time.Millisecond))
+	return check
+}
+
+// managementHostPort returns the host:port of the switch's management web server
+func managementHostPort(address string) string {
+	host := strings.TrimPrefix(strings.TrimPrefix(address, "http://"), "https://")
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		port := "80"
+		if strings.HasPrefix(address, "https://") {
+			port = "443"
+		}
+		host = net.JoinHostPort(host, port)
+	}
+	return host
+}
+
+// checkLoginPaths discovers which login pages the switch serves and whether they carry a seed value
+func checkLoginPaths(httpClient *http.Client, baseURL string) doctorCheck {
+	check := doctorCheck{Name: "Login path discovery"}
+
+	var found []string
+	for _, path := range loginPaths {
+		resp, err := httpClient.Get(baseURL + path)
+		if err != nil {
+			continue
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if resp.StatusCode == http.StatusOK {
+			entry := path
+			if strings.Contains(string(body), `id="rand"`) || strings.Contains(string(body), `id='rand'`) {
+				entry += " (seed present)"
+			}
+			found = append(found, entry)
+		}
+	}
+
+	if len(found) == 0 {
+		check.Status = checkFail
+		check.Detail = "none of " + strings.Join(loginPaths, ", ") + " answered with 200 OK"
+		check.Hint = i18n.T("doctor.hint.no_login_page")
+		return check
+	}
+
+	check.Status = checkPass
+	check.Detail = strings.Join(found, ", ")
+	return check
+}
+
+// checkClockSkew compares the switch's HTTP Date header with the host clock
+func checkClockSkew(httpClient *http.Client, baseURL string) doctorCheck {
+	check := doctorCheck{Name: "Clock skew"}
+
+	resp, err := httpClient.Get(baseURL + "/")
+	if err != nil {
+		check.Status = checkSkip
+		check.Detail = err.Error()
+		return check
+	}
+	resp.Body.Close()
+
+	dateHeader := resp.Header.Get("Date")
+	if dateHeader == "" {
+		check.Status = checkSkip
+		check.Detail = "switch does not send a Date header"
+		return check
+	}
+
+	switchTime, err := http.ParseTime(dateHeader)
+	if err != nil {
+		check.Status = checkSkip
+		check.Detail = fmt.Sprintf("unparseable Date header %q", dateHeader)
+		return check
+	}
+
+	skew := time.Since(switchTime).Round(time.Second)
+	if skew > maxClockSkew || skew < -maxClockSkew {
+		check.Status = checkWarn
+		check.Detail = fmt.Sprintf("switch clock differs from host by %s", skew)
+		check.Hint = i18n.T("doctor.hint.clock_skew")
+		return check
+	}
+
+	check.Status = checkPass
+	check.Detail = fmt.Sprintf("within %s of host clock", skew)
+	return check
+}
+
+// checkModelDetection runs the library's model detection without authenticating
+func checkModelDetection(ctx context.Context, address string, timeout time.Duration) (doctorCheck, netgear.Model) {
+	check := doctorCheck{Name: "Model detection"}
+
+	client, err := netgear.NewClientContext(ctx, address,
+		netgear.WithTimeout(timeout),
+		netgear.WithTokenManager(netgear.NewMemoryTokenManager()),
+		netgear.WithEnvironmentAuth(false))
+	if err != nil {
+		check.Status = checkFail
+		check.Detail = err.Error()
+		check.Hint = i18n.T("doctor.hint.unknown_model", supportedModelList())
+		var unmanaged *netgear.UnmanagedDeviceError
+		if errors.As(err, &unmanaged) {
+			check.Hint = i18n.T("doctor.hint.unmanaged")
+		}
+		return check, ""
+	}
+
+	check.Status = checkPass
+	check.Detail = string(client.GetModel())
+	return check, client.GetModel()
+}
+
+// checkSessionState inspects the cached token and whether the switch still
+// honors it, returning a client on the cached session when it does. It never
+// logs in, so the session of the user or another tool is left alone.
+func checkSessionState(ctx context.Context, address string, timeout time.Duration) (doctorCheck, *netgear.Client) {
+	check := doctorCheck{Name: "Session state"}
+
+	tokenMgr := netgear.NewFileTokenManager("")
+	if _, _, err := tokenMgr.GetToken(ctx, address); err != nil {
+		check.Status = checkPass
+		check.Detail = "no cached session for this switch"
+		return check, nil
+	}
+
+	client, err := netgear.NewClientContext(ctx, address,
+		netgear.WithTimeout(timeout),
+		netgear.WithTokenManager(tokenMgr),
+		netgear.WithEnvironmentAuth(false))
+	if err != nil {
+		check.Status = checkWarn
+		check.Detail = err.Error()
+		return check, nil
+	}
+
+	if err := probeSession(ctx, client); err != nil {
+		check.Status = checkWarn
+		check.Detail = "cached session is no longer accepted by the switch"
+		check.Hint = i18n.T("doctor.hint.session_taken")
+		return check, nil
+	}
+
+	check.Status = checkPass
+	check.Detail = fmt.Sprintf("cached session is valid (cache dir %s)", tokenMgr.GetCacheDir())
+	return check, client
+}
+
+// probeSession makes a cheap read every model of the client's family
+// offers: the POE status on POE models, the dashboard on the others
+func probeSession(ctx context.Context, client *netgear.Client) error {
+	if caps, ok := client.GetModel().Capabilities(); ok && caps.HasPOE() {
+		statuses, err := client.POE().GetStatus(ctx)
+		if err == nil && len(statuses) == 0 {
+			return netgear.ErrSessionExpired
+		}
+		return err
+	}
+	_, err := client.System().GetInfo(ctx)
+	return err
+}
+
+// checkParserCoverage reports which read operations parse successfully. It
+// reads through the cached session's client; without one it only logs in
+// when login is set, as a login ends every other session on the switch.
+func checkParserCoverage(ctx context.Context, client *netgear.Client, address, password string, login bool, timeout time.Duration) []doctorCheck {
+	if client == nil && !login {
+		return []doctorCheck{{Name: "Parser coverage", Status: checkSkip, Detail: "no valid cached session", Hint: i18n.T("doctor.hint.no_session")}}
+	}
+
+	if client == nil {
+		opts := []netgear.ClientOption{
+			netgear.WithTimeout(timeout),
+			netgear.WithTokenManager(netgear.NewMemoryTokenManager()),
+		}
+		if password != "" {
+			// The password given with -p is the one to check, not the environment's
+			opts = append(opts, netgear.WithEnvironmentAuth(false))
+		}
+		var err error
+		client, err = netgear.NewClientContext(ctx, address, opts...)
+		if err != nil {
+			return []doctorCheck{{Name: "Parser coverage", Status: checkFail, Detail: err.Error()}}
+		}
+	}
+
+	if !client.IsAuthenticated() {
+		if err := client.Login(ctx, password); err != nil {
+			check := doctorCheck{Name: "Parser coverage", Status: checkSkip, Detail: err.Error()}
+			if errors.Is(err, netgear.ErrInvalidCredentials) {
+				check.Status = checkFail
+				check.Hint = i18n.T("doctor.hint.bad_password")
+			} else {
+				check.Hint = i18n.T("doctor.hint.no_password")
+			}
+			return []doctorCheck{check}
+		}
+		defer client.Logout(ctx)
+	}
+
+	reads := []struct {
+		name string
+		read func() (int, error)
+	}{
+		{"POE status", func() (int, error) {
+			result, err := client.POE().GetStatus(ctx)
+			return len(result), err
+		}},
+		{"POE settings", func() (int, error) {
+			result, err := client.POE().GetSettings(ctx)
+			return len(result), err
+		}},
+		{"Port settings", func() (int, error) {
+			result, err := client.Ports().GetSettings(ctx)
+			return len(result), err
+		}},
+		{"DoS settings", func() (int, error) {
+			_, err := client.Security().GetDoS(ctx)
+			return 1, err
+		}},
+	}
+
+	var checks []doctorCheck
+	for _, read := range reads {
+		check := doctorCheck{Name: "Parser coverage: " + read.name}
+		count, err := read.read()
+		switch {
+		case errors.Is(err, netgear.ErrOperationNotSupported):
+			check.Status = checkSkip
+			check.Detail = "not supported on " + string(client.GetModel())
+		case err != nil:
+			check.Status = checkFail
+			check.Detail = err.Error()
+			check.Hint = i18n.T("doctor.hint.parser_failed")
+		case count == 0:
+			check.Status = checkWarn
+			check.Detail = "request succeeded but no entries were parsed"
+			check.Hint = i18n.T("doctor.hint.parser_empty")
+		default:
+			check.Status = checkPass
+			check.Detail = fmt.Sprintf("%d entries parsed", count)
+		}
+		checks = append(checks, check)
+	}
+
+	return checks
+}
+
+func printDoctorReport(checks []doctorCheck) {
+	for _, check := range checks {
+		var icon string
+		switch check.Status {
+		case checkPass:
+			icon = "✅"
+		case checkWarn:
+			icon = "⚠️ "
+		case checkFail:
+			icon = "❌"
+		case checkSkip:
+			icon = "⏭️ "
+		}
+		fmt.Printf("%s %s: %s\n", icon, check.Name, check.Detail)
+		if check.Hint != "" && check.Status != checkPass {
+			fmt.Printf("   → %s\n", check.Hint)
+		}
+	}
+	fmt.Println()
+
+	var passed, warned, failed int
+	for _, check := range checks {
+		switch check.Status {
+		case checkPass:
+			passed++
+		case checkWarn:
+			warned++
+		case checkFail:
+			failed++
+		}
+	}
+	fmt.Printf("%d passed, %d warnings, %d failed\n", passed, warned, failed)
+}
+
+// supportedModelList names the specific models the library manages, leaving
+// out placeholders for partially detected switches
+func supportedModelList() string {
+	var names []string
+	for _, model := range netgear.SupportedModels() {
+		if model.PortCount() > 0 {
+			names = append(names, model.String())
+		}
+	}
+	return strings.Join(names, ", ")
+}
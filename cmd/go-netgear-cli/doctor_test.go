@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gherlein/go-netgear/pkg/netgear"
+)
+
+func TestDoctorChecksKeepTheSession(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+		if r.URL.Path == "/base/system/management/sysInfo.html" && r.Header.Get("Cookie") == "SID=pro" {
+			fmt.Fprint(w, `<table><tr><td>System Name</td><td>lab-pro</td></tr>
+				<tr><td>Base MAC Address</td><td>A0:21:B7:00:11:22</td></tr>
+				<tr><td>Software Version</td><td>6.0.1.16</td></tr></table>`)
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+	address := strings.TrimPrefix(server.URL, "http://")
+
+	ctx := context.Background()
+	tokenMgr := netgear.NewMemoryTokenManager()
+	tokenMgr.StoreToken(ctx, address, "pro", netgear.ModelGS108Tv3)
+	client, err := netgear.NewClient(address, netgear.WithTokenManager(tokenMgr), netgear.WithPasswordManager(nil))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	// A model without POE is probed through its dashboard
+	if err := probeSession(ctx, client); err != nil {
+		t.Errorf("expected the cached session to be accepted, got %v", err)
+	}
+	if len(requests) != 1 || requests[0] != "GET /base/system/management/sysInfo.html" {
+		t.Errorf("expected a single dashboard read, got %v", requests)
+	}
+
+	// Without a session, parser coverage is skipped unless logging in is allowed
+	requests = nil
+	checks := checkParserCoverage(ctx, nil, address, "", false, time.Second)
+	if len(checks) != 1 || checks[0].Status != checkSkip || len(requests) != 0 {
+		t.Errorf("expected a skipped check without requests, got %+v and %v", checks, requests)
+	}
+
+	// Reads the model does not offer are skipped, and the session is kept
+	checks = checkParserCoverage(ctx, client, address, "", false, time.Second)
+	for _, check := range checks {
+		if strings.HasPrefix(check.Name, "Parser coverage: POE") && check.Status != checkSkip {
+			t.Errorf("expected %s to be skipped on a GS108Tv3, got %+v", check.Name, check)
+		}
+	}
+	for _, request := range requests {
+		if strings.HasPrefix(request, "POST") || strings.Contains(request, "login") || strings.Contains(request, "logout") {
+			t.Errorf("expected no login or logout, got %s", request)
+		}
+	}
+	if !client.IsAuthenticated() {
+		t.Error("expected the cached session to remain")
+	}
+}
+
+func TestDoctorLoginUsesGivenPassword(t *testing.T) {
+	var mu sync.Mutex
+	var logins []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch {
+		case r.URL.Path == "/":
+			fmt.Fprint(w, `<html><title>NETGEAR GS308EPP</title></html>`)
+		case r.Method == "GET" && r.URL.Path == "/login.cgi":
+			fmt.Fprint(w, `<input id="rand" value="1234">`)
+		case r.URL.Path == "/login.cgi":
+			mu.Lock()
+			logins = append(logins, r.PostForm.Get("password"))
+			mu.Unlock()
+			fmt.Fprint(w, `<html>login</html>`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	address := strings.TrimPrefix(server.URL, "http://")
+
+	dir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", dir)
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	ctx := context.Background()
+
+	// Record how the switch sees the given password without an environment password
+	t.Setenv("NETGEAR_SWITCHES", "")
+	checkParserCoverage(ctx, nil, address, "given", true, time.Second)
+	if len(logins) == 0 {
+		t.Fatal("expected a login attempt")
+	}
+	given := logins[0]
+
+	// The environment holds another password for the same switch
+	logins = nil
+	t.Setenv("NETGEAR_SWITCHES", address+"=environment")
+	checks := checkParserCoverage(ctx, nil, address, "given", true, time.Second)
+	if len(checks) != 1 || checks[0].Status != checkFail {
+		t.Errorf("expected the rejected password to fail the check, got %+v", checks)
+	}
+	// Only the password given with -p is tried
+	for _, login := range logins {
+		if login != given {
+			t.Errorf("expected only the given password to be tried, got %q", logins)
+			break
+		}
+	}
+}
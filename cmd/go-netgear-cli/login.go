@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+
+	"github.com/gherlein/go-netgear/pkg/netgear"
+)
+
+// runLogin logs in and caches the session token, so the following commands
+// against the switch need no password until the switch ends the session
+func runLogin(args []string) int {
+	cmd := newSwitchCommand("login", "<address>", "Logs in and caches the session token for later commands.")
+	if !cmd.parse(args, 1) {
+		return ExitError
+	}
+
+	ctx := context.Background()
+	client, err := newClient(cmd.fs.Arg(0), netgear.WithTimeout(*cmd.timeout))
+	if err != nil {
+		return cmd.fail(err)
+	}
+	if err := client.Login(ctx, *cmd.password); err != nil {
+		return cmd.fail(err)
+	}
+
+	cmd.printf("✅ Logged in to %s (%s)\n", cmd.fs.Arg(0), client.GetModel())
+	return ExitSuccess
+}
+
+// runLogout ends the session and removes the cached token
+func runLogout(args []string) int {
+	cmd := newSwitchCommand("logout", "<address>", "Removes the cached session token of a switch.")
+	if !cmd.parse(args, 1) {
+		return ExitError
+	}
+
+	client, err := newClient(cmd.fs.Arg(0), netgear.WithTimeout(*cmd.timeout))
+	if err != nil {
+		return cmd.fail(err)
+	}
+	if err := client.Logout(context.Background()); err != nil {
+		return cmd.fail(err)
+	}
+
+	cmd.printf("✅ Logged out of %s\n", cmd.fs.Arg(0))
+	return ExitSuccess
+}
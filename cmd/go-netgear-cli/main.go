@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"regexp"
 
+	"github.com/gherlein/go-netgear/pkg/netgear"
 	"github.com/gherlein/go-netgear/test"
 )
 
@@ -17,6 +18,39 @@ const (
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "check":
+			os.Exit(runCheck(os.Args[2:]))
+		case "doctor":
+			os.Exit(runDoctor(os.Args[2:]))
+		case "login":
+			os.Exit(runLogin(os.Args[2:]))
+		case "logout":
+			os.Exit(runLogout(os.Args[2:]))
+		case "poe":
+			os.Exit(runPOE(os.Args[2:]))
+		case "port":
+			os.Exit(runPort(os.Args[2:]))
+		case "vlan":
+			os.Exit(runVLAN(os.Args[2:]))
+		case "backup":
+			os.Exit(runBackup(os.Args[2:]))
+		case "restore":
+			os.Exit(runRestore(os.Args[2:]))
+		case "reboot":
+			os.Exit(runReboot(os.Args[2:]))
+		case "zabbix":
+			os.Exit(runZabbix(os.Args[2:]))
+		case "version":
+			os.Exit(runVersion(os.Args[2:]))
+		case "completion":
+			os.Exit(runCompletion(os.Args[2:]))
+		case completeCommand:
+			os.Exit(runComplete(os.Args[2:]))
+		}
+	}
+
 	var (
 		validateConfig = flag.Bool("validate-config", false, "Validate the test configuration file and exit")
 		configPath     = flag.String("config", "test/test_config.json", "Path to test configuration file")
@@ -144,19 +178,10 @@ func validateSwitchConfig(switchConfig test.SwitchConfig, index int) bool {
 		valid = false
 	} else {
 		// Validate model is supported
-		validModels := []string{"GS305EP", "GS305EPP", "GS308EP", "GS308EPP", "GS308EEP", "GS316EP", "GS316EPP"}
-		modelValid := false
-		for _, validModel := range validModels {
-			if switchConfig.Model == validModel {
-				modelValid = true
-				break
-			}
-		}
-
-		if modelValid {
+		if netgear.Model(switchConfig.Model).IsSupported() {
 			fmt.Printf("   ✅ Model: %s (supported)\n", switchConfig.Model)
 		} else {
-			fmt.Printf("   ❌ Model: %s (unsupported - valid: %v)\n", switchConfig.Model, validModels)
+			fmt.Printf("   ❌ Model: %s (unsupported - valid: %v)\n", switchConfig.Model, netgear.SupportedModels())
 			valid = false
 		}
 	}
@@ -230,7 +255,7 @@ func printConfigErrorHelp(err error) {
 	if contains(errStr, "model is required") {
 		fmt.Printf("Missing Model:\n")
 		fmt.Printf("   • Each switch must specify a 'model'\n")
-		fmt.Printf("   • Supported: GS305EP, GS305EPP, GS308EP, GS308EPP, GS316EP, GS316EPP\n\n")
+		fmt.Printf("   • Supported: %s\n\n", supportedModelList())
 	}
 
 	fmt.Printf("Example valid configuration:\n")
@@ -258,14 +283,50 @@ func printConfigErrorHelp(err error) {
 func printHelp() {
 	fmt.Printf("go-netgear - Netgear Switch Management Library\n\n")
 	fmt.Printf("Usage:\n")
-	fmt.Printf("  go run main.go [options]\n\n")
+	fmt.Printf("  go run main.go [options]\n")
+	fmt.Printf("  go run main.go <command> [command options]\n\n")
+	fmt.Printf("Switch commands (--output table|markdown|json|yaml, --help for options):\n")
+	fmt.Printf("  login <host>             Log in and cache the session token\n")
+	fmt.Printf("  logout <host>            Remove the cached session token\n")
+	fmt.Printf("  poe status <host>        Show POE status and power draw per port\n")
+	fmt.Printf("  poe settings <host>      Show POE configuration per port\n")
+	fmt.Printf("  poe set <host> <ports>   Change POE settings (--enabled, --mode, --priority, --limit-type, --limit)\n")
+	fmt.Printf("  poe cycle <host> <ports> Power cycle the devices on ports\n")
+	fmt.Printf("  poe budget <host>...     Show POE budget vs consumption per switch and port\n")
+	fmt.Printf("  port status <host>       Show link state, speed and VLANs per port\n")
+	fmt.Printf("  port set <host> <ports>  Change port settings (--name, --speed, --flow-control, limits)\n")
+	fmt.Printf("  port stats <host>        Show traffic and CRC error counters per port\n")
+	fmt.Printf("  port reset-stats <host> <ports>  Reset the traffic counters of ports\n")
+	fmt.Printf("  port neighbors <host>    Show LLDP neighbors (or learned MACs and vendors) per port\n")
+	fmt.Printf("  vlan list <host>         Show VLANs and their member ports\n")
+	fmt.Printf("  vlan create|delete <host> <id>       Create or delete a VLAN\n")
+	fmt.Printf("  vlan access <host> <id> <ports>      Make ports untagged members of one VLAN\n")
+	fmt.Printf("  vlan trunk <host> <ports> --tagged <ids>  Tag VLANs on ports over a native VLAN\n")
+	fmt.Printf("  backup <host> <file>     Save the switch configuration as YAML (or JSON for .json)\n")
+	fmt.Printf("  restore <host> <file>    Apply a saved configuration (--dry-run lists differences, --force restores another model's backup)\n")
+	fmt.Printf("  reboot <host>            Restart the switch (--wait 3m waits for it)\n\n")
+	fmt.Printf("Commands:\n")
+	fmt.Printf("  check <name> <host>      Nagios/Icinga plugin: poe-budget, port-status or reachable\n")
+	fmt.Printf("  doctor --address <host>  Run non-destructive diagnostics against a switch\n")
+	fmt.Printf("  zabbix discovery <host>... Emit Zabbix low-level discovery JSON for switch ports\n")
+	fmt.Printf("  zabbix get <host> <key>  Print one Zabbix item value (e.g. poe.power[3])\n")
+	fmt.Printf("  version [--output json]  Print the version, commit and build date\n")
+	fmt.Printf("  completion <shell>       Print the bash, zsh or fish completion script\n\n")
+	fmt.Printf("Switches can be given by their name in %s, or with --switch <name>,\n", netgear.DefaultProfilesPath())
+	fmt.Printf("and are completed by the shell. Commands taking ports offer a picker in a terminal when none are given.\n\n")
 	fmt.Printf("Options:\n")
 	fmt.Printf("  --validate-config        Validate test configuration file and exit\n")
 	fmt.Printf("  --config <path>          Path to test configuration file (default: test/test_config.json)\n")
 	fmt.Printf("  --help, -h               Show this help information\n\n")
 	fmt.Printf("Examples:\n")
 	fmt.Printf("  go run main.go --validate-config\n")
-	fmt.Printf("  go run main.go --validate-config --config /path/to/config.json\n\n")
+	fmt.Printf("  go run main.go --validate-config --config /path/to/config.json\n")
+	fmt.Printf("  go run main.go doctor --address 192.168.1.10\n")
+	fmt.Printf("  go run main.go poe set --priority high 192.168.1.10 1-4\n")
+	fmt.Printf("  go run main.go backup 192.168.1.10 switch.yaml\n")
+	fmt.Printf("  go run main.go poe budget --json 192.168.1.10 192.168.1.11\n")
+	fmt.Printf("  go run main.go check poe-budget --warning 75 --critical 90 192.168.1.10\n")
+	fmt.Printf("  go run main.go zabbix get 192.168.1.10 'port.link[1]'\n\n")
 	fmt.Printf("For running tests:\n")
 	fmt.Printf("  make run-tests           Run comprehensive test suite\n")
 	fmt.Printf("  make test-offline        Run tests without network dependencies\n")
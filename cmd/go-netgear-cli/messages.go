@@ -0,0 +1,79 @@
+package main
+
+import "github.com/gherlein/go-netgear/pkg/i18n"
+
+// CLI messages; set NETGEAR_LANG (or LANG) to choose the language
+func init() {
+	i18n.Register(i18n.English, map[string]string{
+		"doctor.requires_address":           "doctor requires --address",
+		"doctor.hint.unreachable":           "Verify the address, that the switch is powered, and that no firewall or VLAN boundary blocks HTTP to its management IP",
+		"doctor.hint.no_login_page":         "The device may not be a supported Netgear switch, or a proxy/captive portal is intercepting requests",
+		"doctor.hint.clock_skew":            "Configure SNTP on the switch; PoE schedules and log timestamps depend on a correct clock",
+		"doctor.hint.unknown_model":         "Supported models are %s; if this is one of them, open an issue with the switch's login page HTML",
+		"doctor.hint.unmanaged":             "This Netgear device has no supported management interface (unmanaged or other product family) and cannot be controlled by this tool",
+		"doctor.hint.session_taken":         "Another client or browser session probably logged in since; the switch allows one session at a time. Log in again or share one client per switch",
+		"doctor.hint.bad_password":          "The password was rejected; verify it in the switch web UI",
+		"doctor.hint.no_password":           "Pass --password or export NETGEAR_PASSWORD_<HOST> to include parser coverage",
+		"doctor.hint.no_session":            "Log in with the login command first to include parser coverage, or pass --login to let doctor log in; that ends other sessions on the switch",
+		"doctor.hint.parser_failed":         "The firmware's page layout may differ from the parser's expectations; open an issue with the model and firmware version",
+		"doctor.hint.parser_empty":          "The firmware's page layout may differ from the parser's expectations",
+		"check.requires_subcommand":         "check requires a subcommand",
+		"check.unknown_subcommand":          "unknown check %q",
+		"check.requires_address":            "check requires exactly one switch address",
+		"check.invalid_port":                "invalid or unknown port %q",
+		"poe.requires_subcommand":           "poe requires a subcommand",
+		"poe.unknown_subcommand":            "unknown poe subcommand %q",
+		"poe.budget.requires_address":       "poe budget requires at least one switch address",
+		"zabbix.requires_subcommand":        "zabbix requires a subcommand",
+		"zabbix.unknown_subcommand":         "unknown zabbix subcommand %q",
+		"zabbix.discovery.requires_address": "zabbix discovery requires at least one switch address",
+		"zabbix.get.requires_key":           "zabbix get requires a switch address and an item key",
+		"zabbix.get.unknown_key":            "unknown zabbix item key %q",
+		"cli.missing_arguments":             "%s: missing arguments",
+		"cli.invalid_port":                  "invalid port %q",
+		"cli.nothing_to_set":                "%s: no settings given",
+		"port.requires_subcommand":          "port requires a subcommand",
+		"port.unknown_subcommand":           "unknown port subcommand %q",
+		"vlan.requires_subcommand":          "vlan requires a subcommand",
+		"vlan.unknown_subcommand":           "unknown vlan subcommand %q",
+		"vlan.invalid_id":                   "invalid VLAN ID %q",
+		"completion.requires_shell":         "completion requires a shell",
+		"completion.unknown_shell":          "unknown shell %q, use bash, zsh or fish",
+	})
+	i18n.Register(i18n.German, map[string]string{
+		"doctor.requires_address":           "doctor benötigt --address",
+		"doctor.hint.unreachable":           "Adresse prüfen, ob der Switch eingeschaltet ist und ob eine Firewall oder VLAN-Grenze HTTP zur Management-IP blockiert",
+		"doctor.hint.no_login_page":         "Das Gerät ist möglicherweise kein unterstützter Netgear-Switch, oder ein Proxy/Captive Portal fängt die Anfragen ab",
+		"doctor.hint.clock_skew":            "SNTP auf dem Switch einrichten; PoE-Zeitpläne und Log-Zeitstempel hängen von einer korrekten Uhrzeit ab",
+		"doctor.hint.unknown_model":         "Unterstützt werden %s; falls es eines davon ist, bitte ein Issue mit dem HTML der Login-Seite eröffnen",
+		"doctor.hint.unmanaged":             "Dieses Netgear-Gerät hat keine unterstützte Management-Oberfläche (unmanaged oder andere Produktfamilie) und kann mit diesem Tool nicht gesteuert werden",
+		"doctor.hint.session_taken":         "Vermutlich hat sich seitdem ein anderer Client oder Browser angemeldet; der Switch erlaubt nur eine Sitzung. Erneut anmelden oder einen Client pro Switch gemeinsam nutzen",
+		"doctor.hint.bad_password":          "Das Passwort wurde abgelehnt; bitte in der Weboberfläche des Switches prüfen",
+		"doctor.hint.no_password":           "--password angeben oder NETGEAR_PASSWORD_<HOST> exportieren, um die Parser-Abdeckung zu prüfen",
+		"doctor.hint.no_session":            "Zuerst mit dem Befehl login anmelden, um die Parser-Abdeckung zu prüfen, oder --login angeben, damit doctor sich anmeldet; das beendet andere Sitzungen am Switch",
+		"doctor.hint.parser_failed":         "Das Seitenlayout der Firmware weicht möglicherweise vom Parser ab; bitte ein Issue mit Modell und Firmware-Version eröffnen",
+		"doctor.hint.parser_empty":          "Das Seitenlayout der Firmware weicht möglicherweise vom Parser ab",
+		"check.requires_subcommand":         "check benötigt einen Unterbefehl",
+		"check.unknown_subcommand":          "unbekannter Check %q",
+		"check.requires_address":            "check benötigt genau eine Switch-Adresse",
+		"check.invalid_port":                "ungültiger oder unbekannter Port %q",
+		"poe.requires_subcommand":           "poe benötigt einen Unterbefehl",
+		"poe.unknown_subcommand":            "unbekannter poe-Unterbefehl %q",
+		"poe.budget.requires_address":       "poe budget benötigt mindestens eine Switch-Adresse",
+		"zabbix.requires_subcommand":        "zabbix benötigt einen Unterbefehl",
+		"zabbix.unknown_subcommand":         "unbekannter zabbix-Unterbefehl %q",
+		"zabbix.discovery.requires_address": "zabbix discovery benötigt mindestens eine Switch-Adresse",
+		"zabbix.get.requires_key":           "zabbix get benötigt eine Switch-Adresse und einen Item-Key",
+		"zabbix.get.unknown_key":            "unbekannter zabbix-Item-Key %q",
+		"cli.missing_arguments":             "%s: Argumente fehlen",
+		"cli.invalid_port":                  "ungültiger Port %q",
+		"cli.nothing_to_set":                "%s: keine Einstellungen angegeben",
+		"port.requires_subcommand":          "port benötigt einen Unterbefehl",
+		"port.unknown_subcommand":           "unbekannter port-Unterbefehl %q",
+		"vlan.requires_subcommand":          "vlan benötigt einen Unterbefehl",
+		"vlan.unknown_subcommand":           "unbekannter vlan-Unterbefehl %q",
+		"vlan.invalid_id":                   "ungültige VLAN-ID %q",
+		"completion.requires_shell":         "completion benötigt eine Shell",
+		"completion.unknown_shell":          "unbekannte Shell %q, bash, zsh oder fish verwenden",
+	})
+}
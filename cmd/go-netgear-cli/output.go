@@ -0,0 +1,86 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/gherlein/go-netgear/internal/formatter"
+)
+
+// outputFlags are the --output and --json flags shared by the commands
+// printing data
+type outputFlags struct {
+	output *string
+	json   *bool
+	format formatter.OutputFormat
+}
+
+// addOutputFlags adds --output (-o) with the given default and --json as
+// its shorthand for JSON
+func addOutputFlags(fs *flag.FlagSet, defaultFormat string) *outputFlags {
+	o := &outputFlags{
+		output: fs.String("output", defaultFormat, "Output format: "+formatter.OutputFormatNames),
+		json:   fs.Bool("json", false, "Shorthand for --output json"),
+	}
+	fs.StringVar(o.output, "o", defaultFormat, "Output format (short)")
+	return o
+}
+
+// resolve checks the format given on the command line; call it after
+// parsing. An empty --output leaves the format empty for commands with an
+// output of their own, such as the budget bars.
+func (o *outputFlags) resolve() error {
+	if *o.json {
+		o.format = formatter.JsonFormat
+		return nil
+	}
+	if *o.output == "" {
+		o.format = ""
+		return nil
+	}
+	format, err := formatter.ParseOutputFormat(*o.output)
+	if err != nil {
+		return err
+	}
+	o.format = format
+	return nil
+}
+
+// machineReadable reports whether the output is meant for other programs
+func (o *outputFlags) machineReadable() bool {
+	return o.format == formatter.JsonFormat || o.format == formatter.YamlFormat
+}
+
+// messages is where progress and result messages go: stdout for people,
+// stderr when stdout carries JSON or YAML for jq and scripts
+func (o *outputFlags) messages() io.Writer {
+	if o.machineReadable() {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// printTable prints rows in the chosen format
+func (o *outputFlags) printTable(item string, header []string, rows [][]string) error {
+	return formatter.PrintDataTable(o.format, item, header, rows)
+}
+
+// printValue prints a value that is not a table as JSON or YAML; it reports
+// false for the other formats so the caller renders the value itself
+func (o *outputFlags) printValue(v any) (bool, error) {
+	switch o.format {
+	case formatter.JsonFormat:
+		return true, formatter.PrintJson(v)
+	case formatter.YamlFormat:
+		return true, formatter.PrintYaml(v)
+	default:
+		return false, nil
+	}
+}
+
+// printf writes a message for people to o.messages()
+func (o *outputFlags) printf(format string, args ...any) {
+	fmt.Fprintf(o.messages(), format, args...)
+}
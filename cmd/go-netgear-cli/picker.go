@@ -0,0 +1,133 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// errPickCancelled is returned when the picker is left without a choice
+var errPickCancelled = errors.New("port selection cancelled")
+
+// pickItem is one choice offered by the port picker
+type pickItem struct {
+	Port  int
+	Label string
+}
+
+// portPicker is the state of the interactive port picker
+type portPicker struct {
+	title    string
+	items    []pickItem
+	cursor   int
+	selected map[int]bool
+}
+
+// interactive reports whether a person can answer the picker: stdin and
+// stderr, where the picker draws, are terminals
+func interactive() bool {
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stderr.Fd()))
+}
+
+// pickPorts lets the user choose ports on the terminal with the arrow keys
+// (or j/k), space to toggle, a to toggle all and enter to confirm; q, escape
+// or ctrl-c cancel. Enter without a selection picks the port under the cursor.
+func pickPorts(title string, items []pickItem) ([]int, error) {
+	if len(items) == 0 {
+		return nil, errors.New("the switch reported no ports")
+	}
+	fd := int(os.Stdin.Fd())
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return nil, err
+	}
+	defer term.Restore(fd, state)
+
+	picker := &portPicker{title: title, items: items, selected: make(map[int]bool)}
+	out := os.Stderr
+	picker.render(out)
+	defer fmt.Fprint(out, "\x1b[?25h")
+
+	buf := make([]byte, 8)
+	for {
+		n, err := os.Stdin.Read(buf)
+		if err != nil {
+			return nil, err
+		}
+		ports, done, err := picker.handle(string(buf[:n]))
+		picker.clear(out)
+		if done || err != nil {
+			return ports, err
+		}
+		picker.render(out)
+	}
+}
+
+// handle applies one key press and reports the chosen ports once confirmed
+func (p *portPicker) handle(key string) ([]int, bool, error) {
+	switch key {
+	case "\x1b[A", "k":
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case "\x1b[B", "j":
+		if p.cursor < len(p.items)-1 {
+			p.cursor++
+		}
+	case " ":
+		port := p.items[p.cursor].Port
+		p.selected[port] = !p.selected[port]
+	case "a":
+		all := len(p.chosen()) < len(p.items)
+		for _, item := range p.items {
+			p.selected[item.Port] = all
+		}
+	case "\r", "\n":
+		ports := p.chosen()
+		if len(ports) == 0 {
+			ports = []int{p.items[p.cursor].Port}
+		}
+		return ports, true, nil
+	case "q", "\x1b", "\x03":
+		return nil, true, errPickCancelled
+	}
+	return nil, false, nil
+}
+
+// chosen returns the selected ports in list order
+func (p *portPicker) chosen() []int {
+	var ports []int
+	for _, item := range p.items {
+		if p.selected[item.Port] {
+			ports = append(ports, item.Port)
+		}
+	}
+	return ports
+}
+
+// render draws the picker; the terminal is in raw mode, so lines end in \r\n
+func (p *portPicker) render(w io.Writer) {
+	var b strings.Builder
+	b.WriteString("\x1b[?25l")
+	fmt.Fprintf(&b, "%s (↑/↓ move, space select, a all, enter confirm, q cancel)\r\n", p.title)
+	for i, item := range p.items {
+		cursor, mark := " ", " "
+		if i == p.cursor {
+			cursor = ">"
+		}
+		if p.selected[item.Port] {
+			mark = "x"
+		}
+		fmt.Fprintf(&b, "%s [%s] %s\r\n", cursor, mark, item.Label)
+	}
+	fmt.Fprint(w, b.String())
+}
+
+// clear removes the lines drawn by render
+func (p *portPicker) clear(w io.Writer) {
+	fmt.Fprintf(w, "\x1b[%dA\x1b[J", len(p.items)+1)
+}
@@ -0,0 +1,359 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gherlein/go-netgear/pkg/i18n"
+	"github.com/gherlein/go-netgear/pkg/netgear"
+)
+
+// budgetBarWidth is the number of characters in a full budget bar
+const budgetBarWidth = 40
+
+// poeBudgetReport is the budget of one switch with each port's contribution
+type poeBudgetReport struct {
+	Address    string                  `json:"address"`
+	Model      netgear.Model           `json:"model"`
+	TotalW     float64                 `json:"total_w"`
+	ConsumedW  float64                 `json:"consumed_w"`
+	RemainingW float64                 `json:"remaining_w"`
+	Source     netgear.POEBudgetSource `json:"source,omitempty"`
+	Ports      []poePortConsumption    `json:"ports"`
+	Error      string                  `json:"error,omitempty"`
+}
+
+// poePortConsumption is the power drawn by a single port
+type poePortConsumption struct {
+	PortID   int     `json:"port_id"`
+	PortName string  `json:"port_name,omitempty"`
+	PowerW   float64 `json:"power_w"`
+}
+
+func runPOE(args []string) int {
+	if len(args) == 0 {
+		fmt.Printf("❌ %s\n\n", i18n.T("poe.requires_subcommand"))
+		fmt.Printf("Usage: go-netgear-cli poe <status|settings|set|cycle|budget> [options] <address> ...\n")
+		return ExitError
+	}
+
+	switch args[0] {
+	case "status":
+		return runPOEStatus(args[1:])
+	case "settings":
+		return runPOESettings(args[1:])
+	case "set":
+		return runPOESet(args[1:])
+	case "cycle":
+		return runPOECycle(args[1:])
+	case "budget":
+		return runPOEBudget(args[1:])
+	default:
+		fmt.Printf("❌ %s\n", i18n.T("poe.unknown_subcommand", args[0]))
+		return ExitError
+	}
+}
+
+// runPOEStatus prints the live POE readings of every port
+func runPOEStatus(args []string) int {
+	cmd := newSwitchCommand("poe status", "<address>", "Shows the POE status, class and power draw of every port.")
+	if !cmd.parse(args, 1) {
+		return ExitError
+	}
+
+	ctx := context.Background()
+	client, ok := cmd.connect(ctx)
+	if !ok {
+		return ExitError
+	}
+	statuses, err := client.POE().GetStatus(ctx)
+	if err != nil {
+		return cmd.fail(err)
+	}
+
+	var rows [][]string
+	for _, status := range statuses {
+		rows = append(rows, []string{
+			strconv.Itoa(status.PortID),
+			status.PortName,
+			status.Status,
+			status.PowerClass,
+			strconv.FormatFloat(status.VoltageV, 'f', 1, 64),
+			strconv.FormatFloat(status.CurrentMA, 'f', 0, 64),
+			strconv.FormatFloat(status.PowerW, 'f', 1, 64),
+			strconv.FormatFloat(status.TemperatureC, 'f', 0, 64),
+			status.ErrorStatus,
+		})
+	}
+	return cmd.show("poe_status", []string{"Port", "Name", "Status", "Class", "Voltage (V)", "Current (mA)", "Power (W)", "Temperature (°C)", "Error"}, rows)
+}
+
+// runPOESettings prints the POE configuration of every port
+func runPOESettings(args []string) int {
+	cmd := newSwitchCommand("poe settings", "<address>", "Shows the POE configuration of every port.")
+	if !cmd.parse(args, 1) {
+		return ExitError
+	}
+
+	ctx := context.Background()
+	client, ok := cmd.connect(ctx)
+	if !ok {
+		return ExitError
+	}
+	settings, err := client.POE().GetSettings(ctx)
+	if err != nil {
+		return cmd.fail(err)
+	}
+
+	var rows [][]string
+	for _, setting := range settings {
+		rows = append(rows, []string{
+			strconv.Itoa(setting.PortID),
+			setting.PortName,
+			formatBool(setting.Enabled),
+			string(setting.Mode),
+			string(setting.Priority),
+			string(setting.PowerLimitType),
+			strconv.FormatFloat(setting.PowerLimitW, 'f', 1, 64),
+			setting.DetectionType,
+		})
+	}
+	return cmd.show("poe_settings", []string{"Port", "Name", "Enabled", "Mode", "Priority", "Limit Type", "Limit (W)", "Detection"}, rows)
+}
+
+// runPOESet changes the POE configuration of one or more ports; only the
+// settings given on the command line are changed
+func runPOESet(args []string) int {
+	cmd := newSwitchCommand("poe set", "<address> [port...]", "Changes the POE settings of the given ports (e.g. 3, 1,2 or 5-8).")
+	enabled := cmd.fs.Bool("enabled", true, "Supply power to the ports")
+	mode := cmd.fs.String("mode", "", "Power mode: 802.3af, 802.3at, legacy or pre-802.3at")
+	priority := cmd.fs.String("priority", "", "Priority: low, high or critical")
+	limitType := cmd.fs.String("limit-type", "", "Power limit type: none, class or user")
+	limitW := cmd.fs.Float64("limit", 0, "Power limit in watts (with --limit-type user)")
+	if !cmd.parse(args, 1) || !cmd.checkPorts(1) {
+		return ExitError
+	}
+
+	var template netgear.POEPortUpdate
+	if flagSet(cmd.fs, "enabled") {
+		template.Enabled = enabled
+	}
+	if *mode != "" {
+		template.Mode = (*netgear.POEMode)(mode)
+	}
+	if *priority != "" {
+		template.Priority = (*netgear.POEPriority)(priority)
+	}
+	if *limitType != "" {
+		template.PowerLimitType = (*netgear.POELimitType)(limitType)
+	}
+	if flagSet(cmd.fs, "limit") {
+		template.PowerLimitW = limitW
+	}
+	if template == (netgear.POEPortUpdate{}) {
+		cmd.printf("❌ %s\n\n", i18n.T("cli.nothing_to_set", cmd.fs.Name()))
+		cmd.fs.Usage()
+		return ExitError
+	}
+
+	ctx := context.Background()
+	client, ok := cmd.connect(ctx)
+	if !ok {
+		return ExitError
+	}
+	ports, ok := cmd.ports(ctx, client, 1)
+	if !ok {
+		return ExitError
+	}
+	updates := make([]netgear.POEPortUpdate, len(ports))
+	for i, port := range ports {
+		updates[i] = template
+		updates[i].PortID = port
+	}
+	if err := client.POE().UpdatePort(ctx, updates...); err != nil {
+		return cmd.fail(err)
+	}
+
+	cmd.printf("✅ Updated POE settings of port(s) %s on %s\n", formatPorts(ports), cmd.fs.Arg(0))
+	return ExitSuccess
+}
+
+// runPOECycle power cycles the devices on one or more ports
+func runPOECycle(args []string) int {
+	cmd := newSwitchCommand("poe cycle", "<address> [port...]", "Power cycles the devices on the given ports (e.g. 3, 1,2 or 5-8).")
+	if !cmd.parse(args, 1) || !cmd.checkPorts(1) {
+		return ExitError
+	}
+
+	ctx := context.Background()
+	client, ok := cmd.connect(ctx)
+	if !ok {
+		return ExitError
+	}
+	ports, ok := cmd.ports(ctx, client, 1)
+	if !ok {
+		return ExitError
+	}
+	if err := client.POE().CyclePower(ctx, ports...); err != nil {
+		return cmd.fail(err)
+	}
+
+	cmd.printf("✅ Power cycled port(s) %s on %s\n", formatPorts(ports), cmd.fs.Arg(0))
+	return ExitSuccess
+}
+
+func runPOEBudget(args []string) int {
+	fs := flag.NewFlagSet("poe budget", flag.ExitOnError)
+	out := addOutputFlags(fs, "")
+	password := fs.String("password", "", "Admin password (defaults to NETGEAR_PASSWORD_<HOST> / NETGEAR_SWITCHES)")
+	timeout := fs.Duration("timeout", 10*time.Second, "Timeout for each network request")
+	fs.StringVar(password, "p", "", "Admin password (short)")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: go-netgear-cli poe budget [options] <address>...\n\nDraws bars unless --output is given.\n\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if err := out.resolve(); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return ExitError
+	}
+
+	addresses := fs.Args()
+	if len(addresses) == 0 {
+		fmt.Printf("❌ %s\n\n", i18n.T("poe.budget.requires_address"))
+		fs.Usage()
+		return ExitError
+	}
+
+	ctx := context.Background()
+	exitCode := ExitSuccess
+	var reports []poeBudgetReport
+	for _, address := range addresses {
+		report := collectPOEBudget(ctx, address, *password, *timeout)
+		if report.Error != "" {
+			exitCode = ExitError
+		}
+		reports = append(reports, report)
+	}
+
+	if printed, err := out.printValue(reports); printed {
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to encode %s: %v\n", out.format, err)
+			return ExitError
+		}
+		return exitCode
+	}
+
+	if out.format != "" {
+		var rows [][]string
+		for _, report := range reports {
+			rows = append(rows, []string{
+				report.Address,
+				string(report.Model),
+				strconv.FormatFloat(report.TotalW, 'f', 1, 64),
+				strconv.FormatFloat(report.ConsumedW, 'f', 1, 64),
+				strconv.FormatFloat(report.RemainingW, 'f', 1, 64),
+				report.Error,
+			})
+		}
+		if err := out.printTable("poe_budget", []string{"Switch", "Model", "Total (W)", "Consumed (W)", "Remaining (W)", "Error"}, rows); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			return ExitError
+		}
+		return exitCode
+	}
+
+	for _, report := range reports {
+		printPOEBudget(report)
+	}
+	return exitCode
+}
+
+// collectPOEBudget connects to a switch and reads its budget and per-port draw
+func collectPOEBudget(ctx context.Context, address, password string, timeout time.Duration) poeBudgetReport {
+	report := poeBudgetReport{Address: address}
+
+	client, err := newClient(address, netgear.WithTimeout(timeout))
+	if err != nil {
+		report.Error = netgear.LocalizedError(err)
+		return report
+	}
+	report.Model = client.GetModel()
+
+	if !client.IsAuthenticated() {
+		if err := client.Login(ctx, password); err != nil {
+			report.Error = netgear.LocalizedError(err)
+			return report
+		}
+	}
+
+	budget, err := client.POE().GetBudget(ctx)
+	if err != nil {
+		report.Error = netgear.LocalizedError(err)
+		return report
+	}
+	report.TotalW = budget.TotalW
+	report.ConsumedW = budget.ConsumedW
+	report.RemainingW = budget.RemainingW
+	report.Source = budget.Source
+
+	statuses, err := client.POE().GetStatus(ctx)
+	if err != nil {
+		report.Error = netgear.LocalizedError(err)
+		return report
+	}
+	for _, status := range statuses {
+		report.Ports = append(report.Ports, poePortConsumption{
+			PortID:   status.PortID,
+			PortName: status.PortName,
+			PowerW:   status.PowerW,
+		})
+	}
+
+	return report
+}
+
+// printPOEBudget renders one switch's budget as a bar followed by one bar per drawing port
+func printPOEBudget(report poeBudgetReport) {
+	if report.Error != "" {
+		fmt.Printf("❌ %s: %s\n\n", report.Address, report.Error)
+		return
+	}
+
+	fmt.Printf("%s (%s)\n", report.Address, report.Model)
+	estimated := ""
+	if report.Source == netgear.POEBudgetEstimated {
+		estimated = " (estimated)"
+	}
+	fmt.Printf("  %s %6.1f / %.1f W used, %.1f W free%s\n",
+		budgetBar(report.ConsumedW, report.TotalW), report.ConsumedW, report.TotalW, report.RemainingW, estimated)
+
+	for _, port := range report.Ports {
+		if port.PowerW <= 0 {
+			continue
+		}
+		label := fmt.Sprintf("port %d", port.PortID)
+		if port.PortName != "" {
+			label += " " + port.PortName
+		}
+		fmt.Printf("    %-24s %s %6.1f W\n", label, budgetBar(port.PowerW, report.TotalW), port.PowerW)
+	}
+	fmt.Println()
+}
+
+// budgetBar draws value as a fraction of total
+func budgetBar(value, total float64) string {
+	filled := 0
+	if total > 0 {
+		filled = int(value/total*budgetBarWidth + 0.5)
+	}
+	if filled > budgetBarWidth {
+		filled = budgetBarWidth
+	}
+	return "[" + strings.Repeat("█", filled) + strings.Repeat("░", budgetBarWidth-filled) + "]"
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestPOESet(t *testing.T) {
+	sw, address := newFakeSwitch(t)
+
+	var code int
+	out := captureStdout(t, func() {
+		code = runPOESet([]string{"--enabled=false", "--priority", "high", address, "1-2"})
+	})
+	if code != ExitSuccess {
+		t.Fatalf("expected success, got exit code %d: %s", code, out)
+	}
+
+	// Only the settings given are posted, one port at a time
+	want := []string{
+		"/iss/specific/poePortConf.html Gambit=token&enabled=0&hash=h1&port=1&priority=high",
+		"/iss/specific/poePortConf.html Gambit=token&enabled=0&hash=h1&port=2&priority=high",
+	}
+	if got := strings.Join(sw.writes, "\n"); got != strings.Join(want, "\n") {
+		t.Errorf("unexpected writes\n got: %s\nwant: %s", got, strings.Join(want, "\n"))
+	}
+
+	// Nothing to change is refused before connecting
+	sw.writes = nil
+	out = captureStdout(t, func() {
+		stderr := os.Stderr
+		os.Stderr, _ = os.Open(os.DevNull)
+		defer func() { os.Stderr = stderr }()
+		code = runPOESet([]string{address, "1"})
+	})
+	if code != ExitError || len(sw.writes) != 0 {
+		t.Errorf("expected an error without writes, got exit code %d, writes %v: %s", code, sw.writes, out)
+	}
+}
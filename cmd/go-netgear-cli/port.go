@@ -0,0 +1,235 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/gherlein/go-netgear/pkg/i18n"
+	"github.com/gherlein/go-netgear/pkg/netgear"
+)
+
+func runPort(args []string) int {
+	if len(args) == 0 {
+		fmt.Printf("❌ %s\n\n", i18n.T("port.requires_subcommand"))
+		fmt.Printf("Usage: go-netgear-cli port <status|set|stats|reset-stats|neighbors> [options] <address> ...\n")
+		return ExitError
+	}
+
+	switch args[0] {
+	case "status":
+		return runPortStatus(args[1:])
+	case "set":
+		return runPortSet(args[1:])
+	case "stats":
+		return runPortStats(args[1:])
+	case "reset-stats":
+		return runPortResetStats(args[1:])
+	case "neighbors":
+		return runPortNeighbors(args[1:])
+	default:
+		fmt.Printf("❌ %s\n", i18n.T("port.unknown_subcommand", args[0]))
+		return ExitError
+	}
+}
+
+// runPortStatus prints the link state and configuration of every port
+func runPortStatus(args []string) int {
+	cmd := newSwitchCommand("port status", "<address>", "Shows the link state, speed, flow control and VLANs of every port.")
+	if !cmd.parse(args, 1) {
+		return ExitError
+	}
+
+	ctx := context.Background()
+	client, ok := cmd.connect(ctx)
+	if !ok {
+		return ExitError
+	}
+	settings, err := portSettings(ctx, client)
+	if err != nil {
+		return cmd.fail(err)
+	}
+
+	var rows [][]string
+	for _, setting := range settings {
+		pvid := ""
+		if setting.PVID > 0 {
+			pvid = strconv.Itoa(setting.PVID)
+		}
+		rows = append(rows, []string{
+			strconv.Itoa(setting.PortID),
+			setting.PortName,
+			string(setting.Status),
+			setting.LinkSpeed,
+			string(setting.Speed),
+			formatBool(setting.FlowControl),
+			pvid,
+			formatPorts(setting.TaggedVLANs),
+		})
+	}
+	return cmd.show("ports", []string{"Port", "Name", "Status", "Link Speed", "Speed", "Flow Control", "PVID", "Tagged VLANs"}, rows)
+}
+
+// portSettings reads the ports and their VLAN assignment from the port
+// settings page, or from the dashboard on models without one
+func portSettings(ctx context.Context, client *netgear.Client) ([]netgear.PortSettings, error) {
+	if netgear.NewEndpointRegistry(client.GetModel()).IsEndpointSupported(netgear.EndpointPortSettings) {
+		return client.Ports().GetSettingsWithVLANs(ctx)
+	}
+	state, err := client.FetchAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return state.Ports, nil
+}
+
+// runPortSet changes the configuration of one or more ports; only the
+// settings given on the command line are changed
+func runPortSet(args []string) int {
+	cmd := newSwitchCommand("port set", "<address> [port...]", "Changes the settings of the given ports (e.g. 3, 1,2 or 5-8).")
+	name := cmd.fs.String("name", "", "Port description")
+	speed := cmd.fs.String("speed", "", "Speed: auto, 10M half, 10M full, 100M half, 100M full or disable")
+	flowControl := cmd.fs.Bool("flow-control", false, "Enable flow control")
+	ingress := cmd.fs.String("ingress-limit", "", "Ingress rate limit as shown by the switch UI")
+	egress := cmd.fs.String("egress-limit", "", "Egress rate limit as shown by the switch UI")
+	if !cmd.parse(args, 1) || !cmd.checkPorts(1) {
+		return ExitError
+	}
+
+	var template netgear.PortUpdate
+	if flagSet(cmd.fs, "name") {
+		template.Name = name
+	}
+	if *speed != "" {
+		parsed, err := netgear.ParsePortSpeed(*speed)
+		if err != nil {
+			cmd.printf("❌ %v\n", err)
+			return ExitError
+		}
+		template.Speed = &parsed
+	}
+	if flagSet(cmd.fs, "flow-control") {
+		template.FlowControl = flowControl
+	}
+	if *ingress != "" {
+		template.IngressLimit = ingress
+	}
+	if *egress != "" {
+		template.EgressLimit = egress
+	}
+	if template == (netgear.PortUpdate{}) {
+		cmd.printf("❌ %s\n\n", i18n.T("cli.nothing_to_set", cmd.fs.Name()))
+		cmd.fs.Usage()
+		return ExitError
+	}
+
+	ctx := context.Background()
+	client, ok := cmd.connect(ctx)
+	if !ok {
+		return ExitError
+	}
+	ports, ok := cmd.ports(ctx, client, 1)
+	if !ok {
+		return ExitError
+	}
+	updates := make([]netgear.PortUpdate, len(ports))
+	for i, port := range ports {
+		updates[i] = template
+		updates[i].PortID = port
+	}
+	if err := client.Ports().UpdatePort(ctx, updates...); err != nil {
+		return cmd.fail(err)
+	}
+
+	cmd.printf("✅ Updated port(s) %s on %s\n", formatPorts(ports), cmd.fs.Arg(0))
+	return ExitSuccess
+}
+
+// runPortStats prints the traffic counters of every port
+func runPortStats(args []string) int {
+	cmd := newSwitchCommand("port stats", "<address>", "Shows the bytes received and sent and the CRC errors of every port.")
+	if !cmd.parse(args, 1) {
+		return ExitError
+	}
+
+	ctx := context.Background()
+	client, ok := cmd.connect(ctx)
+	if !ok {
+		return ExitError
+	}
+	statistics, err := client.Ports().GetStatistics(ctx)
+	if err != nil {
+		return cmd.fail(err)
+	}
+
+	var rows [][]string
+	for _, stats := range statistics {
+		rows = append(rows, []string{
+			strconv.Itoa(stats.PortID),
+			strconv.FormatUint(stats.RxBytes, 10),
+			strconv.FormatUint(stats.TxBytes, 10),
+			strconv.FormatUint(stats.RxPackets, 10),
+			strconv.FormatUint(stats.TxPackets, 10),
+			strconv.FormatUint(stats.CRCErrors, 10),
+		})
+	}
+	return cmd.show("statistics", []string{"Port", "RX Bytes", "TX Bytes", "RX Packets", "TX Packets", "CRC Errors"}, rows)
+}
+
+// runPortResetStats sets the traffic counters of the given ports back to zero
+func runPortResetStats(args []string) int {
+	cmd := newSwitchCommand("port reset-stats", "<address> [port...]", "Resets the traffic counters of the given ports (e.g. 3, 1,2 or 5-8).")
+	if !cmd.parse(args, 1) || !cmd.checkPorts(1) {
+		return ExitError
+	}
+
+	ctx := context.Background()
+	client, ok := cmd.connect(ctx)
+	if !ok {
+		return ExitError
+	}
+	ports, ok := cmd.ports(ctx, client, 1)
+	if !ok {
+		return ExitError
+	}
+	for _, port := range ports {
+		if err := client.Ports().ResetStatistics(ctx, port); err != nil {
+			return cmd.fail(err)
+		}
+	}
+
+	cmd.printf("✅ Reset statistics of port(s) %s on %s\n", formatPorts(ports), cmd.fs.Arg(0))
+	return ExitSuccess
+}
+
+// runPortNeighbors prints the devices connected to each port
+func runPortNeighbors(args []string) int {
+	cmd := newSwitchCommand("port neighbors", "<address>", "Shows the LLDP neighbors of every port, or the learned MAC addresses and their vendors on firmware without LLDP.")
+	if !cmd.parse(args, 1) {
+		return ExitError
+	}
+
+	ctx := context.Background()
+	client, ok := cmd.connect(ctx)
+	if !ok {
+		return ExitError
+	}
+	neighbors, err := client.Ports().GetNeighbors(ctx)
+	if err != nil {
+		return cmd.fail(err)
+	}
+
+	var rows [][]string
+	for _, neighbor := range neighbors {
+		rows = append(rows, []string{
+			strconv.Itoa(neighbor.PortID),
+			neighbor.ChassisID,
+			neighbor.RemotePortID,
+			neighbor.SystemName,
+			neighbor.ManagementAddress,
+			neighbor.Vendor,
+			string(neighbor.Source),
+		})
+	}
+	return cmd.show("neighbors", []string{"Port", "Chassis ID", "Remote Port", "System Name", "Management Address", "Vendor", "Source"}, rows)
+}
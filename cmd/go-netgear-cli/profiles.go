@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gherlein/go-netgear/pkg/netgear"
+)
+
+// loadProfiles reads the profiles file, warning about and ignoring a broken one
+func loadProfiles() *netgear.Profiles {
+	profiles, err := netgear.LoadProfiles("")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  %v\n", err)
+		return &netgear.Profiles{}
+	}
+	return profiles
+}
+
+// newClient creates a client for a switch given by its address or by the
+// name of a profile in the profiles file, which then supplies the address,
+// model and credentials
+func newClient(target string, opts ...netgear.ClientOption) (*netgear.Client, error) {
+	profiles := loadProfiles()
+	if _, ok := profiles.Lookup(target); ok {
+		return profiles.NewClient(target, opts...)
+	}
+	return netgear.NewClient(target, opts...)
+}
+
+// profileNames returns the names of the configured switches, sorted
+func profileNames() []string {
+	profiles, err := netgear.LoadProfiles("")
+	if err != nil {
+		return nil
+	}
+	return profiles.Names()
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"time"
+)
+
+// runReboot restarts a switch and, with --wait, waits for it to come back
+func runReboot(args []string) int {
+	cmd := newSwitchCommand("reboot", "<address>", "Restarts the switch. The session ends; later commands log in again.")
+	wait := cmd.fs.Duration("wait", 0, "Wait up to this long for the switch to answer again (0 returns once it went down)")
+	if !cmd.parse(args, 1) {
+		return ExitError
+	}
+
+	ctx := context.Background()
+	client, ok := cmd.connect(ctx)
+	if !ok {
+		return ExitError
+	}
+	if err := client.System().Reboot(ctx); err != nil {
+		return cmd.fail(err)
+	}
+	cmd.printf("✅ %s is restarting\n", cmd.fs.Arg(0))
+
+	if *wait > 0 {
+		start := time.Now()
+		if err := client.WaitForOnline(ctx, *wait); err != nil {
+			return cmd.fail(err)
+		}
+		cmd.printf("✅ %s is back after %s\n", cmd.fs.Arg(0), time.Since(start).Round(time.Second))
+	}
+	return ExitSuccess
+}
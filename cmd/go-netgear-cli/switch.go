@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gherlein/go-netgear/internal/formatter"
+	"github.com/gherlein/go-netgear/pkg/i18n"
+	"github.com/gherlein/go-netgear/pkg/netgear"
+)
+
+// switchCommand holds the options shared by the commands that manage a switch
+type switchCommand struct {
+	fs       *flag.FlagSet
+	target   *string
+	password *string
+	timeout  *time.Duration
+	*outputFlags
+}
+
+// newSwitchCommand creates the flag set of a switch management command with
+// the connection and output options; the caller adds command-specific flags
+func newSwitchCommand(name, args, usage string) *switchCommand {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	cmd := &switchCommand{
+		fs:       fs,
+		target:   fs.String("switch", "", "Name of a switch in the profiles file, given instead of <address>"),
+		password: fs.String("password", "", "Admin password (defaults to NETGEAR_PASSWORD_<HOST> / NETGEAR_SWITCHES)"),
+		timeout:  fs.Duration("timeout", 10*time.Second, "Timeout for each network request"),
+	}
+	cmd.outputFlags = addOutputFlags(fs, string(formatter.TableFormat))
+	fs.StringVar(cmd.password, "p", "", "Admin password (short)")
+	fs.StringVar(cmd.target, "s", "", "Name of a switch in the profiles file (short)")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: go-netgear-cli %s [options] %s\n\n%s\n\n", name, args, usage)
+		if strings.Contains(args, "[port...]") {
+			fmt.Fprintf(fs.Output(), "Without ports, a picker lists the switch's ports when run in a terminal.\n\n")
+		}
+		fs.PrintDefaults()
+	}
+	return cmd
+}
+
+// parse parses the arguments and checks the output format and that at
+// least minArgs positional arguments, the first being the switch address,
+// were given. A switch named with --switch becomes the first positional
+// argument.
+func (c *switchCommand) parse(args []string, minArgs int) bool {
+	c.fs.Parse(args)
+	if *c.target != "" {
+		// "--" keeps the remaining arguments from being parsed as flags again
+		c.fs.Parse(append([]string{"--", *c.target}, c.fs.Args()...))
+	}
+	if err := c.resolve(); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return false
+	}
+	if c.fs.NArg() < minArgs {
+		c.printf("❌ %s\n\n", i18n.T("cli.missing_arguments", c.fs.Name()))
+		c.fs.Usage()
+		return false
+	}
+	return true
+}
+
+// checkPorts reports whether the ports expected at position at were given,
+// or can be picked interactively once connected
+func (c *switchCommand) checkPorts(at int) bool {
+	if c.fs.NArg() > at || interactive() {
+		return true
+	}
+	c.printf("❌ %s\n\n", i18n.T("cli.missing_arguments", c.fs.Name()))
+	c.fs.Usage()
+	return false
+}
+
+// ports parses the ports given from position at on, or lets the user pick
+// them from the switch's ports when none were given
+func (c *switchCommand) ports(ctx context.Context, client *netgear.Client, at int) ([]int, bool) {
+	if c.fs.NArg() > at {
+		ports, err := parsePorts(c.fs.Args()[at:])
+		if err != nil {
+			c.printf("❌ %v\n", err)
+			return nil, false
+		}
+		return ports, true
+	}
+
+	settings, err := portSettings(ctx, client)
+	if err != nil {
+		c.fail(err)
+		return nil, false
+	}
+	items := make([]pickItem, len(settings))
+	for i, setting := range settings {
+		items[i] = pickItem{Port: setting.PortID, Label: fmt.Sprintf("%2d  %-20s %s", setting.PortID, setting.PortName, setting.Status)}
+	}
+	ports, err := pickPorts(fmt.Sprintf("%s: ports of %s", c.fs.Name(), c.fs.Arg(0)), items)
+	if err != nil {
+		c.printf("❌ %v\n", err)
+		return nil, false
+	}
+	return ports, true
+}
+
+// connect logs in to the switch named by the first positional argument
+func (c *switchCommand) connect(ctx context.Context) (*netgear.Client, bool) {
+	client, err := connectSwitch(ctx, c.fs.Arg(0), *c.password, *c.timeout)
+	if err != nil {
+		c.fail(err)
+		return nil, false
+	}
+	return client, true
+}
+
+// fail reports an error returned by the library
+func (c *switchCommand) fail(err error) int {
+	c.printf("❌ %s: %s\n", c.fs.Arg(0), netgear.LocalizedError(err))
+	return ExitError
+}
+
+// show prints rows in the chosen output format
+func (c *switchCommand) show(item string, header []string, rows [][]string) int {
+	if err := c.printTable(item, header, rows); err != nil {
+		c.printf("❌ %v\n", err)
+		return ExitError
+	}
+	return ExitSuccess
+}
+
+// parsePorts parses port arguments such as "1", "1,3,5" and "2-4"
+func parsePorts(args []string) ([]int, error) {
+	var ports []int
+	for _, arg := range args {
+		for _, field := range strings.Split(arg, ",") {
+			field = strings.TrimSpace(field)
+			first, last, isRange := strings.Cut(field, "-")
+			from, err := strconv.Atoi(first)
+			if err != nil || from < 1 {
+				return nil, errors.New(i18n.T("cli.invalid_port", field))
+			}
+			to := from
+			if isRange {
+				if to, err = strconv.Atoi(last); err != nil || to < from {
+					return nil, errors.New(i18n.T("cli.invalid_port", field))
+				}
+			}
+			for port := from; port <= to; port++ {
+				ports = append(ports, port)
+			}
+		}
+	}
+	return ports, nil
+}
+
+// flagSet reports whether a flag was given on the command line
+func flagSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// formatBool renders a setting as shown by the switch UI
+func formatBool(enabled bool) string {
+	if enabled {
+		return "on"
+	}
+	return "off"
+}
+
+// formatPorts renders a port list for messages, e.g. "1, 3, 5"
+func formatPorts(ports []int) string {
+	fields := make([]string, len(ports))
+	for i, port := range ports {
+		fields[i] = strconv.Itoa(port)
+	}
+	return strings.Join(fields, ", ")
+}
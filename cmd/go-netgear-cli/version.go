@@ -0,0 +1,33 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/gherlein/go-netgear/pkg/version"
+)
+
+// runVersion prints the build information, as JSON or YAML with --output
+// for scripts and version handshakes
+func runVersion(args []string) int {
+	fs := flag.NewFlagSet("version", flag.ExitOnError)
+	out := addOutputFlags(fs, "")
+	fs.Parse(args)
+	if err := out.resolve(); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return ExitError
+	}
+
+	info := version.Get()
+	if printed, err := out.printValue(info); printed {
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to encode version: %v\n", err)
+			return ExitError
+		}
+		return ExitSuccess
+	}
+
+	fmt.Printf("go-netgear-cli %s\n", info)
+	return ExitSuccess
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/gherlein/go-netgear/pkg/i18n"
+	"github.com/gherlein/go-netgear/pkg/netgear"
+)
+
+func runVLAN(args []string) int {
+	if len(args) == 0 {
+		fmt.Printf("❌ %s\n\n", i18n.T("vlan.requires_subcommand"))
+		fmt.Printf("Usage: go-netgear-cli vlan <list|create|delete|access|trunk> [options] <address> ...\n")
+		return ExitError
+	}
+
+	switch args[0] {
+	case "list":
+		return runVLANList(args[1:])
+	case "create", "delete":
+		return runVLANChange(args[0], args[1:])
+	case "access":
+		return runVLANAccess(args[1:])
+	case "trunk":
+		return runVLANTrunk(args[1:])
+	default:
+		fmt.Printf("❌ %s\n", i18n.T("vlan.unknown_subcommand", args[0]))
+		return ExitError
+	}
+}
+
+// runVLANList prints every VLAN with its untagged and tagged ports
+func runVLANList(args []string) int {
+	cmd := newSwitchCommand("vlan list", "<address>", "Shows the 802.1Q VLANs and their member ports.")
+	if !cmd.parse(args, 1) {
+		return ExitError
+	}
+
+	ctx := context.Background()
+	client, ok := cmd.connect(ctx)
+	if !ok {
+		return ExitError
+	}
+	vlans, err := client.VLANs().GetVLANs(ctx)
+	if err != nil {
+		return cmd.fail(err)
+	}
+
+	var rows [][]string
+	for _, vlan := range vlans {
+		var untagged, tagged []int
+		for port, membership := range vlan.Members {
+			switch membership {
+			case netgear.VLANMemberUntagged:
+				untagged = append(untagged, port)
+			case netgear.VLANMemberTagged:
+				tagged = append(tagged, port)
+			}
+		}
+		sort.Ints(untagged)
+		sort.Ints(tagged)
+		rows = append(rows, []string{strconv.Itoa(vlan.ID), formatPorts(untagged), formatPorts(tagged)})
+	}
+	return cmd.show("vlans", []string{"VLAN", "Untagged Ports", "Tagged Ports"}, rows)
+}
+
+// runVLANChange creates or deletes a VLAN
+func runVLANChange(action string, args []string) int {
+	usage, verb := "Creates an 802.1Q VLAN.", "Created"
+	if action == "delete" {
+		usage, verb = "Deletes an 802.1Q VLAN.", "Deleted"
+	}
+	cmd := newSwitchCommand("vlan "+action, "<address> <vlan-id>", usage)
+	if !cmd.parse(args, 2) {
+		return ExitError
+	}
+	vlanID, err := strconv.Atoi(cmd.fs.Arg(1))
+	if err != nil {
+		cmd.printf("❌ %s\n", i18n.T("vlan.invalid_id", cmd.fs.Arg(1)))
+		return ExitError
+	}
+
+	ctx := context.Background()
+	client, ok := cmd.connect(ctx)
+	if !ok {
+		return ExitError
+	}
+	change := client.VLANs().CreateVLAN
+	if action == "delete" {
+		change = client.VLANs().DeleteVLAN
+	}
+	if err := change(ctx, vlanID); err != nil {
+		return cmd.fail(err)
+	}
+
+	cmd.printf("✅ %s VLAN %d on %s\n", verb, vlanID, cmd.fs.Arg(0))
+	return ExitSuccess
+}
+
+// runVLANAccess makes ports untagged members of a single VLAN
+func runVLANAccess(args []string) int {
+	cmd := newSwitchCommand("vlan access", "<address> <vlan-id> [port...]", "Makes the given ports untagged members of one VLAN, which becomes their PVID.")
+	if !cmd.parse(args, 2) || !cmd.checkPorts(2) {
+		return ExitError
+	}
+	vlanID, err := strconv.Atoi(cmd.fs.Arg(1))
+	if err != nil {
+		cmd.printf("❌ %s\n", i18n.T("vlan.invalid_id", cmd.fs.Arg(1)))
+		return ExitError
+	}
+
+	ctx := context.Background()
+	client, ok := cmd.connect(ctx)
+	if !ok {
+		return ExitError
+	}
+	ports, ok := cmd.ports(ctx, client, 2)
+	if !ok {
+		return ExitError
+	}
+	for _, port := range ports {
+		if err := client.VLANs().MakeAccessPort(ctx, port, vlanID); err != nil {
+			return cmd.fail(err)
+		}
+	}
+
+	cmd.printf("✅ Port(s) %s on %s are access ports of VLAN %d\n", formatPorts(ports), cmd.fs.Arg(0), vlanID)
+	return ExitSuccess
+}
+
+// runVLANTrunk makes ports carry tagged VLANs on top of a native VLAN
+func runVLANTrunk(args []string) int {
+	cmd := newSwitchCommand("vlan trunk", "<address> [port...]", "Makes the given ports untagged members of the native VLAN and tagged members of --tagged.")
+	native := cmd.fs.Int("native", 1, "Native (untagged) VLAN, which becomes the PVID")
+	taggedList := cmd.fs.String("tagged", "", "Comma-separated tagged VLANs, e.g. 10,20 or 10-19")
+	if !cmd.parse(args, 1) || !cmd.checkPorts(1) {
+		return ExitError
+	}
+	var tagged []int
+	if *taggedList != "" {
+		var err error
+		if tagged, err = parsePorts([]string{*taggedList}); err != nil {
+			cmd.printf("❌ %s\n", i18n.T("vlan.invalid_id", *taggedList))
+			return ExitError
+		}
+	}
+
+	ctx := context.Background()
+	client, ok := cmd.connect(ctx)
+	if !ok {
+		return ExitError
+	}
+	ports, ok := cmd.ports(ctx, client, 1)
+	if !ok {
+		return ExitError
+	}
+	for _, port := range ports {
+		if err := client.VLANs().MakeTrunkPort(ctx, port, *native, tagged...); err != nil {
+			return cmd.fail(err)
+		}
+	}
+
+	cmd.printf("✅ Port(s) %s on %s are trunk ports of native VLAN %d\n", formatPorts(ports), cmd.fs.Arg(0), *native)
+	return ExitSuccess
+}
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/gherlein/go-netgear/pkg/i18n"
+	"github.com/gherlein/go-netgear/pkg/netgear"
+)
+
+// zabbixDiscovery is the low-level discovery document Zabbix expects from a discovery rule
+type zabbixDiscovery struct {
+	Data []map[string]string `json:"data"`
+}
+
+// zabbixItemKey matches item keys such as "poe.power[3]" or "poe.budget.total"
+var zabbixItemKey = regexp.MustCompile(`^([a-z.]+)(?:\[(\d+)\])?$`)
+
+func runZabbix(args []string) int {
+	if len(args) == 0 {
+		fmt.Printf("❌ %s\n\n", i18n.T("zabbix.requires_subcommand"))
+		fmt.Printf("Usage: go-netgear-cli zabbix discovery <address>...\n")
+		fmt.Printf("       go-netgear-cli zabbix get <address> <key>\n")
+		return ExitError
+	}
+
+	switch args[0] {
+	case "discovery":
+		return runZabbixDiscovery(args[1:])
+	case "get":
+		return runZabbixGet(args[1:])
+	default:
+		fmt.Printf("❌ %s\n", i18n.T("zabbix.unknown_subcommand", args[0]))
+		return ExitError
+	}
+}
+
+// runZabbixDiscovery prints one LLD row per port of every reachable switch.
+// Unreachable switches are reported on stderr and omitted so that Zabbix
+// keeps the ports it already knows about until the rule's lost-resource period.
+func runZabbixDiscovery(args []string) int {
+	fs := flag.NewFlagSet("zabbix discovery", flag.ExitOnError)
+	password := fs.String("password", "", "Admin password (defaults to NETGEAR_PASSWORD_<HOST> / NETGEAR_SWITCHES)")
+	timeout := fs.Duration("timeout", 10*time.Second, "Timeout for each network request")
+	fs.StringVar(password, "p", "", "Admin password (short)")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: go-netgear-cli zabbix discovery [options] <address>...\n\n")
+		fmt.Fprintf(fs.Output(), "Emits Zabbix LLD JSON with the macros {#SWITCH}, {#MODEL}, {#PORT}, {#PORTNAME} and {#POE}.\n\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	addresses := fs.Args()
+	if len(addresses) == 0 {
+		fmt.Fprintf(os.Stderr, "%s\n", i18n.T("zabbix.discovery.requires_address"))
+		return ExitError
+	}
+
+	ctx := context.Background()
+	exitCode := ExitSuccess
+	discovery := zabbixDiscovery{Data: []map[string]string{}}
+
+	for _, address := range addresses {
+		rows, err := discoverZabbixPorts(ctx, address, *password, *timeout)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", address, netgear.LocalizedError(err))
+			exitCode = ExitError
+			continue
+		}
+		discovery.Data = append(discovery.Data, rows...)
+	}
+
+	if err := json.NewEncoder(os.Stdout).Encode(discovery); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to encode JSON: %v\n", err)
+		return ExitError
+	}
+	return exitCode
+}
+
+// discoverZabbixPorts builds the LLD rows for one switch
+func discoverZabbixPorts(ctx context.Context, address, password string, timeout time.Duration) ([]map[string]string, error) {
+	client, err := connectSwitch(ctx, address, password, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	ports, err := client.Ports().GetSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	poePorts := make(map[int]bool)
+	if statuses, err := client.POE().GetStatus(ctx); err == nil {
+		for _, status := range statuses {
+			poePorts[status.PortID] = true
+		}
+	}
+
+	rows := make([]map[string]string, 0, len(ports))
+	for _, port := range ports {
+		poe := "0"
+		if poePorts[port.PortID] {
+			poe = "1"
+		}
+		rows = append(rows, map[string]string{
+			"{#SWITCH}":   address,
+			"{#MODEL}":    client.GetModel().String(),
+			"{#PORT}":     strconv.Itoa(port.PortID),
+			"{#PORTNAME}": port.PortName,
+			"{#POE}":      poe,
+		})
+	}
+	return rows, nil
+}
+
+// runZabbixGet prints the current value of a single item key, suitable for
+// an external check or a UserParameter on the Zabbix agent
+func runZabbixGet(args []string) int {
+	fs := flag.NewFlagSet("zabbix get", flag.ExitOnError)
+	password := fs.String("password", "", "Admin password (defaults to NETGEAR_PASSWORD_<HOST> / NETGEAR_SWITCHES)")
+	timeout := fs.Duration("timeout", 10*time.Second, "Timeout for each network request")
+	fs.StringVar(password, "p", "", "Admin password (short)")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: go-netgear-cli zabbix get [options] <address> <key>\n\n")
+		fmt.Fprintf(fs.Output(), "Keys:\n")
+		fmt.Fprintf(fs.Output(), "  port.link[N]          1 when port N has link, 0 otherwise\n")
+		fmt.Fprintf(fs.Output(), "  port.speed[N]         Negotiated link speed of port N\n")
+		fmt.Fprintf(fs.Output(), "  poe.power[N]          POE power drawn by port N in watts\n")
+		fmt.Fprintf(fs.Output(), "  poe.status[N]         POE status text of port N\n")
+		fmt.Fprintf(fs.Output(), "  poe.budget.total      Total POE budget in watts\n")
+		fmt.Fprintf(fs.Output(), "  poe.budget.consumed   POE power currently drawn in watts\n")
+		fmt.Fprintf(fs.Output(), "  poe.budget.remaining  POE power still available in watts\n\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if fs.NArg() != 2 {
+		fmt.Fprintf(os.Stderr, "%s\n", i18n.T("zabbix.get.requires_key"))
+		return ExitError
+	}
+	address, key := fs.Arg(0), fs.Arg(1)
+
+	ctx := context.Background()
+	client, err := connectSwitch(ctx, address, *password, *timeout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", netgear.LocalizedError(err))
+		return ExitError
+	}
+
+	value, err := zabbixItemValue(ctx, client, key)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", netgear.LocalizedError(err))
+		return ExitError
+	}
+	fmt.Println(value)
+	return ExitSuccess
+}
+
+// zabbixItemValue reads the value behind an item key
+func zabbixItemValue(ctx context.Context, client *netgear.Client, key string) (string, error) {
+	match := zabbixItemKey.FindStringSubmatch(key)
+	if match == nil {
+		return "", errors.New(i18n.T("zabbix.get.unknown_key", key))
+	}
+	name := match[1]
+	portID, _ := strconv.Atoi(match[2])
+
+	switch name {
+	case "port.link", "port.speed":
+		if match[2] == "" {
+			break
+		}
+		port, err := client.Ports().GetPortSettings(ctx, portID)
+		if err != nil {
+			return "", err
+		}
+		if name == "port.speed" {
+			return port.LinkSpeed, nil
+		}
+		if port.Status == netgear.PortStatusConnected {
+			return "1", nil
+		}
+		return "0", nil
+	case "poe.power", "poe.status":
+		if match[2] == "" {
+			break
+		}
+		status, err := client.POE().GetPortStatus(ctx, portID)
+		if err != nil {
+			return "", err
+		}
+		if name == "poe.status" {
+			return status.Status, nil
+		}
+		return strconv.FormatFloat(status.PowerW, 'f', -1, 64), nil
+	case "poe.budget.total", "poe.budget.consumed", "poe.budget.remaining":
+		if match[2] != "" {
+			break
+		}
+		budget, err := client.POE().GetBudget(ctx)
+		if err != nil {
+			return "", err
+		}
+		watts := budget.TotalW
+		switch name {
+		case "poe.budget.consumed":
+			watts = budget.ConsumedW
+		case "poe.budget.remaining":
+			watts = budget.RemainingW
+		}
+		return strconv.FormatFloat(watts, 'f', -1, 64), nil
+	}
+	return "", errors.New(i18n.T("zabbix.get.unknown_key", key))
+}
+
+// connectSwitch creates a client for address, which may name a profile, and
+// logs in unless a cached token is still valid
+func connectSwitch(ctx context.Context, address, password string, timeout time.Duration) (*netgear.Client, error) {
+	client, err := newClient(address, netgear.WithTimeout(timeout))
+	if err != nil {
+		return nil, err
+	}
+	if !client.IsAuthenticated() {
+		if err := client.Login(ctx, password); err != nil {
+			return nil, err
+		}
+	}
+	return client, nil
+}
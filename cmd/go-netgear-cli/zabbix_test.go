@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gherlein/go-netgear/pkg/netgear"
+)
+
+// newZabbixSwitch serves the port and POE status pages of a GS316EP with
+// three ports, of which ports 1 and 2 deliver POE, and caches a session for
+// it in a temporary cache directory
+func newZabbixSwitch(t *testing.T) string {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/iss/specific/interface.html":
+			fmt.Fprint(w, `<table><tr><th>Port</th></tr>
+				<tr><td>1</td><td>camera</td><td>Auto</td><td>No Limit</td><td>No Limit</td><td>Off</td><td>Up</td><td>1000M</td></tr>
+				<tr><td>2</td><td>access point</td><td>Auto</td><td>No Limit</td><td>No Limit</td><td>Off</td><td>Up</td><td>100M</td></tr>
+				<tr><td>3</td><td></td><td>Auto</td><td>No Limit</td><td>No Limit</td><td>Off</td><td>Down</td><td></td></tr></table>`)
+		case "/iss/specific/poePortStatus.html":
+			for port, power := range map[int]float64{1: 4.2, 2: 12.5} {
+				fmt.Fprintf(w, `<li class="poePortStatusListItem"><input type="hidden" class="port" value="%d"><span class="poe-power-mode"><span>Delivering Power</span></span><div class="poe_port_status"><div><div><span>%.1fW</span></div></div></div></li>`, port, power)
+			}
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	address := strings.TrimPrefix(server.URL, "http://")
+
+	dir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", dir)
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("NETGEAR_SWITCHES", "")
+	if err := netgear.NewFileTokenManager("").StoreToken(context.Background(), address, "token", netgear.ModelGS316EP); err != nil {
+		t.Fatal(err)
+	}
+	return address
+}
+
+func TestZabbixDiscoveryOutput(t *testing.T) {
+	address := newZabbixSwitch(t)
+
+	var code int
+	out := captureStdout(t, func() { code = runZabbixDiscovery([]string{address}) })
+	if code != ExitSuccess {
+		t.Fatalf("expected success, got exit code %d", code)
+	}
+
+	const golden = `{"data":[` +
+		`{"{#MODEL}":"GS316EP","{#POE}":"1","{#PORTNAME}":"camera","{#PORT}":"1","{#SWITCH}":"SWITCH"},` +
+		`{"{#MODEL}":"GS316EP","{#POE}":"1","{#PORTNAME}":"access point","{#PORT}":"2","{#SWITCH}":"SWITCH"},` +
+		`{"{#MODEL}":"GS316EP","{#POE}":"0","{#PORTNAME}":"","{#PORT}":"3","{#SWITCH}":"SWITCH"}]}` + "\n"
+	if got := strings.ReplaceAll(out, address, "SWITCH"); got != golden {
+		t.Errorf("unexpected discovery output\n got: %s\nwant: %s", got, golden)
+	}
+}
+
+func TestZabbixDiscoveryOmitsUnreachableSwitches(t *testing.T) {
+	address := newZabbixSwitch(t)
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+	unreachable := strings.TrimPrefix(closed.URL, "http://")
+
+	var code int
+	out := captureStdout(t, func() {
+		stderr := os.Stderr
+		os.Stderr, _ = os.Open(os.DevNull)
+		defer func() { os.Stderr = stderr }()
+		code = runZabbixDiscovery([]string{"--timeout", "1s", unreachable, address})
+	})
+	if code != ExitError {
+		t.Errorf("expected an error exit code for the unreachable switch, got %d", code)
+	}
+	if strings.Contains(out, unreachable) || strings.Count(out, `"{#PORT}"`) != 3 {
+		t.Errorf("expected only the reachable switch's ports, got %s", out)
+	}
+}
+
+func TestZabbixGetOutput(t *testing.T) {
+	address := newZabbixSwitch(t)
+
+	tests := map[string]string{
+		"port.link[1]":         "1\n",
+		"port.link[3]":         "0\n",
+		"port.speed[2]":        "100M\n",
+		"poe.power[2]":         "12.5\n",
+		"poe.status[1]":        "Delivering Power\n",
+		"poe.budget.total":     "180\n",
+		"poe.budget.consumed":  "16.7\n",
+		"poe.budget.remaining": "163.3\n",
+	}
+	for key, golden := range tests {
+		var code int
+		out := captureStdout(t, func() { code = runZabbixGet([]string{address, key}) })
+		if code != ExitSuccess || out != golden {
+			t.Errorf("%s: expected %q, got %q (exit code %d)", key, golden, out, code)
+		}
+	}
+
+	for _, key := range []string{"port.link", "poe.power[x]", "poe.budget.total[1]", "fan.speed[1]"} {
+		var code int
+		out := captureStdout(t, func() {
+			stderr := os.Stderr
+			os.Stderr, _ = os.Open(os.DevNull)
+			defer func() { os.Stderr = stderr }()
+			code = runZabbixGet([]string{address, key})
+		})
+		if code != ExitError || out != "" {
+			t.Errorf("%s: expected an error without output, got %q (exit code %d)", key, out, code)
+		}
+	}
+}
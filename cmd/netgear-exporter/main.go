@@ -0,0 +1,111 @@
+// Command netgear-exporter publishes POE and port metrics of the switches in
+// an inventory file for Prometheus to scrape:
+//
+//	netgear-exporter -inventory fleet.json -listen :9494 -interval 30s
+//
+// Each switch is polled in the background every -interval, or every
+// poll_interval set on its inventory entry, and /metrics serves the results
+// of the latest polls. The published series are listed in docs/exporter.md.
+// Passwords come from the inventory, a credentials file or the environment,
+// in that order.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/gherlein/go-netgear/pkg/exporter"
+	"github.com/gherlein/go-netgear/pkg/netgear"
+	"github.com/gherlein/go-netgear/pkg/version"
+)
+
+func main() {
+	inventoryFile := flag.String("inventory", "fleet.json", "Inventory file")
+	listen := flag.String("listen", ":9494", "Address to serve /metrics on")
+	interval := flag.Duration("interval", 30*time.Second, "Default poll interval of a switch")
+	credentials := flag.String("credentials", "", "Credentials file (JSON or YAML) with switch passwords")
+	verbose := flag.Bool("verbose", false, "Log every switch request")
+	showVersion := flag.Bool("version", false, "Print the version and exit")
+	flag.Parse()
+
+	if *showVersion {
+		fmt.Println(version.Get())
+		return
+	}
+
+	level := slog.LevelInfo
+	if *verbose {
+		level = slog.LevelDebug
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level}))
+
+	if err := run(*inventoryFile, *listen, *interval, *credentials, logger); err != nil {
+		logger.Error("exporter failed", "error", err)
+		os.Exit(1)
+	}
+}
+
+func run(inventoryFile, listen string, interval time.Duration, credentials string, logger *slog.Logger) error {
+	inventory, err := netgear.LoadInventory(inventoryFile)
+	if err != nil {
+		return err
+	}
+
+	var providers []netgear.PasswordProvider
+	if credentials != "" {
+		providers = append(providers, netgear.NewFilePasswordProvider(credentials))
+	}
+	providers = append(providers, netgear.NewEnvironmentPasswordProvider())
+	fleet := netgear.NewFleet(inventory,
+		netgear.WithPasswordProvider(netgear.ChainPasswordProvider(providers...)),
+		netgear.WithLogger(logger),
+	)
+
+	schedule, err := exporter.ScheduleInventory(inventory, interval, time.Now())
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	collector := exporter.NewCollector(inventory, fleet, logger)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		collector.Run(ctx, schedule)
+	}()
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", collector)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintln(w, `<html><body><h1>Netgear exporter</h1><a href="/metrics">Metrics</a></body></html>`)
+	})
+	server := &http.Server{Addr: listen, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	logger.Info("serving metrics", "listen", listen, "switches", len(fleet.Names()), "version", version.Get().String())
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	<-done
+	return nil
+}
@@ -0,0 +1,101 @@
+// Command camera_fleet keeps a fleet of POE cameras healthy, showing how the
+// library's fleet subsystems work together:
+//
+//   - the switches come from an inventory file (see netgear.LoadInventory);
+//     those tagged "cameras" list their camera ports in the camera_ports
+//     variable, e.g. "vars": {"camera_ports": "1-4,7"}
+//   - passwords come from the inventory, the OS keyring, a credentials file
+//     or the environment, in that order
+//   - every poll records the cameras' POE draw and alerts when a draw is
+//     anomalous or a switch stops answering
+//   - every hour the switch clocks are checked for drift
+//   - every night the camera ports are power cycled
+//
+// Alerts are POSTed as JSON to the webhook and logged:
+//
+//	go run ./examples/camera_fleet -inventory fleet.json -webhook https://hooks.example.com/netgear -cycle-at 03:30
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/gherlein/go-netgear/examples/internal/live"
+	"github.com/gherlein/go-netgear/pkg/alerts"
+	"github.com/gherlein/go-netgear/pkg/netgear"
+)
+
+func main() {
+	inventoryFile := flag.String("inventory", "fleet.json", "Inventory file")
+	tag := flag.String("tag", "cameras", "Inventory tag of the camera switches")
+	webhook := flag.String("webhook", "", "URL alerts are POSTed to (log only if empty)")
+	credentials := flag.String("credentials", "", "Credentials file (JSON or YAML) with switch passwords")
+	interval := flag.Duration("interval", time.Minute, "POE polling interval")
+	driftEvery := flag.Duration("drift-every", time.Hour, "Clock drift check interval")
+	drift := flag.Duration("drift", time.Minute, "Clock drift that raises an alert")
+	cycleAt := flag.String("cycle-at", "03:00", "Local time of the nightly camera power cycle (HH:MM)")
+	flag.Parse()
+	live.Require("camera_fleet")
+
+	var hour, minute int
+	if _, err := fmt.Sscanf(*cycleAt, "%d:%d", &hour, &minute); err != nil || hour > 23 || minute > 59 {
+		log.Fatalf("invalid -cycle-at %q, want HH:MM", *cycleAt)
+	}
+
+	inventory, err := netgear.LoadInventory(*inventoryFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	providers := []netgear.PasswordProvider{netgear.NewKeyringPasswordProvider("")}
+	if *credentials != "" {
+		providers = append(providers, netgear.NewFilePasswordProvider(*credentials))
+	}
+	providers = append(providers, netgear.NewEnvironmentPasswordProvider())
+	fleet := netgear.NewFleet(inventory, netgear.WithPasswordProvider(netgear.ChainPasswordProvider(providers...)))
+
+	// Alerts are logged by the recipe; without a webhook that is all
+	var notifier alerts.Notifier = alerts.NotifierFunc(func(ctx context.Context, alert alerts.Alert) error { return nil })
+	if *webhook != "" {
+		notifier = alerts.Webhook(*webhook, nil)
+	}
+	r := newRecipe(inventory, fleet, *tag, *drift, notifier)
+	r.logf = log.Printf
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	poll := time.NewTicker(*interval)
+	defer poll.Stop()
+	driftCheck := time.NewTicker(*driftEvery)
+	defer driftCheck.Stop()
+	nightly := time.NewTimer(time.Until(nextDaily(time.Now(), hour, minute)))
+	defer nightly.Stop()
+
+	report := func(what string, err error) {
+		if err != nil {
+			log.Printf("%s: %v", what, err)
+		}
+	}
+	report("poll", r.poll(ctx, time.Now()))
+	report("drift check", r.checkDrift(ctx))
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-poll.C:
+			report("poll", r.poll(ctx, now))
+		case <-driftCheck.C:
+			report("drift check", r.checkDrift(ctx))
+		case <-nightly.C:
+			report("power cycle", r.cycleCameras(ctx))
+			nightly.Reset(time.Until(nextDaily(time.Now(), hour, minute)))
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gherlein/go-netgear/pkg/alerts"
+	"github.com/gherlein/go-netgear/pkg/netgear"
+)
+
+// cameraPortsVar is the inventory variable listing a switch's camera ports
+const cameraPortsVar = "camera_ports"
+
+// recipe is the camera fleet automation: it polls the POE draw of camera
+// switches, alerts on anomalies and clock drift, and power cycles the cameras
+type recipe struct {
+	inventory *netgear.Inventory
+	fleet     *netgear.Fleet
+	tag       string
+	engine    *alerts.Engine
+	histories map[string]*netgear.POEHistory
+	logf      func(format string, args ...any)
+}
+
+// newRecipe creates the automation for the switches of inventory tagged with
+// tag, sending alerts to notifier
+func newRecipe(inventory *netgear.Inventory, fleet *netgear.Fleet, tag string, drift time.Duration, notifier alerts.Notifier) *recipe {
+	rules := &alerts.RuleSet{Rules: []alerts.Rule{
+		alerts.AnomalyRule(),
+		alerts.DriftRule(drift),
+		{Name: "switch-down", Metric: alerts.MetricReachable, Operator: "==", Threshold: 0, Polls: 3, Severity: "critical"},
+	}}
+	return &recipe{
+		inventory: inventory,
+		fleet:     fleet,
+		tag:       tag,
+		engine:    alerts.NewEngine(rules, notifier),
+		histories: make(map[string]*netgear.POEHistory),
+		logf:      func(string, ...any) {},
+	}
+}
+
+// cameraSwitch is an enabled switch with camera ports
+type cameraSwitch struct {
+	name  string
+	ports []int
+}
+
+// cameraSwitches returns the enabled switches tagged for the recipe, with the
+// ports listed in their camera_ports variable
+func (r *recipe) cameraSwitches() ([]cameraSwitch, error) {
+	var switches []cameraSwitch
+	for _, name := range r.fleet.Names() {
+		entry, _ := r.inventory.Lookup(name)
+		if !hasTag(entry.Tags, r.tag) {
+			continue
+		}
+		ports, err := parsePorts(entry.Vars[cameraPortsVar])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", name, cameraPortsVar, err)
+		}
+		switches = append(switches, cameraSwitch{name: name, ports: ports})
+	}
+	return switches, nil
+}
+
+// poll records the POE draw of every camera switch and raises alerts for
+// anomalous draws and unreachable switches
+func (r *recipe) poll(ctx context.Context, now time.Time) error {
+	switches, err := r.cameraSwitches()
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, sw := range switches {
+		statuses, err := r.pollSwitch(ctx, sw.name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sw.name, err), r.observe(ctx, alerts.ReachabilitySample(sw.name, false, now)))
+			continue
+		}
+
+		history := r.histories[sw.name]
+		if history == nil {
+			history = netgear.NewPOEHistory(0)
+			r.histories[sw.name] = history
+		}
+		history.Record(now, cameraStatuses(statuses, sw.ports))
+
+		samples := append(alerts.POEAnomalySamples(sw.name, history, now), alerts.ReachabilitySample(sw.name, true, now))
+		errs = append(errs, r.observe(ctx, samples...))
+	}
+	return errors.Join(errs...)
+}
+
+// pollSwitch reads a switch's POE status. On failure the client is dropped,
+// so the next poll reconnects and fails over to another address if needed.
+func (r *recipe) pollSwitch(ctx context.Context, name string) ([]netgear.POEPortStatus, error) {
+	client, err := r.fleet.Client(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := client.POE().GetStatus(ctx)
+	if err != nil {
+		r.fleet.Invalidate(name)
+	}
+	return statuses, err
+}
+
+// checkDrift measures the clock of every camera switch, since the switches'
+// own POE schedules misfire on a wrong clock
+func (r *recipe) checkDrift(ctx context.Context) error {
+	switches, err := r.cameraSwitches()
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, sw := range switches {
+		client, err := r.fleet.Client(ctx, sw.name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sw.name, err))
+			continue
+		}
+		skew, err := client.CheckClockDrift(ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sw.name, err))
+			continue
+		}
+		errs = append(errs, r.observe(ctx, alerts.ClockDriftSample(sw.name, skew)))
+	}
+	return errors.Join(errs...)
+}
+
+// cycleCameras power cycles the camera ports of every camera switch. A
+// failing switch does not keep the others' cameras from being cycled.
+func (r *recipe) cycleCameras(ctx context.Context) error {
+	switches, err := r.cameraSwitches()
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, sw := range switches {
+		if len(sw.ports) == 0 {
+			continue
+		}
+		client, err := r.fleet.Client(ctx, sw.name)
+		if err == nil {
+			err = client.POE().CyclePower(ctx, sw.ports...)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sw.name, err))
+			continue
+		}
+		r.logf("%s: power cycled camera ports %v", sw.name, sw.ports)
+	}
+	return errors.Join(errs...)
+}
+
+// observe feeds samples to the alert engine, which notifies the webhook of
+// every alert that fired or resolved, and logs those alerts
+func (r *recipe) observe(ctx context.Context, samples ...alerts.Sample) error {
+	fired, err := r.engine.Observe(ctx, samples...)
+	for _, alert := range fired {
+		r.logf("%s", alert)
+	}
+	return err
+}
+
+// cameraStatuses returns the statuses of the given ports
+func cameraStatuses(statuses []netgear.POEPortStatus, ports []int) []netgear.POEPortStatus {
+	var cameras []netgear.POEPortStatus
+	for _, status := range statuses {
+		for _, port := range ports {
+			if status.PortID == port {
+				cameras = append(cameras, status)
+			}
+		}
+	}
+	return cameras
+}
+
+// nextDaily returns the first time at hour:minute local time after now
+func nextDaily(now time.Time, hour, minute int) time.Time {
+	next := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, now.Location())
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+// parsePorts parses a port list such as "1-4,7"
+func parsePorts(list string) ([]int, error) {
+	var ports []int
+	for _, field := range strings.Split(list, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		first, last, isRange := strings.Cut(field, "-")
+		from, err := strconv.Atoi(first)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port %q", field)
+		}
+		to := from
+		if isRange {
+			if to, err = strconv.Atoi(last); err != nil || to < from {
+				return nil, fmt.Errorf("invalid port range %q", field)
+			}
+		}
+		for port := from; port <= to; port++ {
+			ports = append(ports, port)
+		}
+	}
+	sort.Ints(ports)
+	return ports, nil
+}
+
+// hasTag reports whether tags contains tag
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
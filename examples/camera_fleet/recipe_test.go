@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gherlein/go-netgear/pkg/alerts"
+	"github.com/gherlein/go-netgear/pkg/netgear"
+)
+
+// fakeSwitch fakes a GS308EPP with cameras on ports 1 and 2, whose POE
+// draw the test controls
+type fakeSwitch struct {
+	mu     sync.Mutex
+	power  map[int]float64
+	cycled []int
+}
+
+func (s *fakeSwitch) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Answering with a skewed clock exercises the drift check
+	w.Header().Set("Date", time.Now().Add(-2*time.Hour).UTC().Format(http.TimeFormat))
+	switch r.URL.Path {
+	case "/dashboard.cgi":
+		fmt.Fprint(w, `<table><tr><td>Switch Name</td><td>cam-switch</td></tr></table>`)
+	case "/getPoePortStatus.cgi":
+		fmt.Fprint(w, "<ul>")
+		for port := 1; port <= 3; port++ {
+			fmt.Fprintf(w, `<li class="poePortStatusListItem"><input type="hidden" class="port" value="%d"><span class="poe-power-mode"><span>Delivering Power</span></span><div class="poe_port_status"><div><div><span>%.1fW</span></div></div></div></li>`, port, s.power[port])
+		}
+		fmt.Fprint(w, "</ul>")
+	case "/PoEPortConfig.cgi":
+		if r.Method == http.MethodPost && r.FormValue("action") == "cycle" {
+			var port int
+			fmt.Sscan(r.FormValue("port"), &port)
+			s.cycled = append(s.cycled, port)
+		}
+		fmt.Fprint(w, "<html></html>")
+	default:
+		fmt.Fprint(w, "<html></html>")
+	}
+}
+
+func (s *fakeSwitch) setPower(port int, watts float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.power[port] = watts
+}
+
+func TestCameraFleetRecipe(t *testing.T) {
+	sw := &fakeSwitch{power: map[int]float64{1: 4.5, 2: 6.0, 3: 12.0}}
+	switchServer := httptest.NewServer(sw)
+	defer switchServer.Close()
+	address := strings.TrimPrefix(switchServer.URL, "http://")
+
+	var mu sync.Mutex
+	var received []alerts.Alert
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var alert alerts.Alert
+		json.NewDecoder(r.Body).Decode(&alert)
+		mu.Lock()
+		received = append(received, alert)
+		mu.Unlock()
+	}))
+	defer hook.Close()
+
+	inventory := &netgear.Inventory{Switches: []netgear.InventoryEntry{
+		{Name: "cams", Addresses: []string{address}, Tags: []string{"cameras"}, Vars: map[string]string{cameraPortsVar: "1-2"}},
+		{Name: "office", Addresses: []string{"127.0.0.1:1"}, Tags: []string{"office"}},
+	}}
+	tokens := netgear.NewMemoryTokenManager()
+	tokens.StoreToken(context.Background(), address, "token", netgear.ModelGS308EPP)
+	fleet := netgear.NewFleet(inventory, netgear.WithTokenManager(tokens), netgear.WithPasswordManager(nil))
+
+	r := newRecipe(inventory, fleet, "cameras", time.Minute, alerts.Webhook(hook.URL, nil))
+	ctx := context.Background()
+	start := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+
+	// A steady draw builds the baseline without alerts; the untagged office
+	// switch is never contacted
+	for i := 0; i < 10; i++ {
+		if err := r.poll(ctx, start.Add(time.Duration(i)*time.Minute)); err != nil {
+			t.Fatalf("poll %d failed: %v", i, err)
+		}
+	}
+	if len(received) != 0 {
+		t.Fatalf("expected no alerts for a steady draw, got %v", received)
+	}
+
+	// A camera drawing three times its usual power is reported, the
+	// non-camera port 3 is not watched
+	sw.setPower(1, 13.5)
+	sw.setPower(3, 30)
+	if err := r.poll(ctx, start.Add(10*time.Minute)); err != nil {
+		t.Fatalf("poll failed: %v", err)
+	}
+	if len(received) != 1 || received[0].Rule != alerts.RulePowerAnomaly || received[0].Switch != "cams" || received[0].Port != 1 || received[0].State != alerts.StateFiring {
+		t.Fatalf("expected a POEPowerAnomaly alert for cams port 1, got %+v", received)
+	}
+	if got := r.histories["cams"].Ports(); !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Errorf("expected only the camera ports recorded, got %v", got)
+	}
+
+	if err := r.checkDrift(ctx); err != nil {
+		t.Fatalf("checkDrift failed: %v", err)
+	}
+	if last := received[len(received)-1]; last.Rule != alerts.RuleDriftDetected || last.Switch != "cams" {
+		t.Errorf("expected a clock drift alert for the skewed switch, got %+v", last)
+	}
+
+	if err := r.cycleCameras(ctx); err != nil {
+		t.Fatalf("cycleCameras failed: %v", err)
+	}
+	if !reflect.DeepEqual(sw.cycled, []int{1, 2}) {
+		t.Errorf("expected camera ports 1 and 2 cycled, got %v", sw.cycled)
+	}
+}
+
+func TestNextDaily(t *testing.T) {
+	now := time.Date(2026, 10, 17, 2, 30, 0, 0, time.UTC)
+	if got := nextDaily(now, 3, 0); !got.Equal(time.Date(2026, 10, 17, 3, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected later today, got %v", got)
+	}
+	if got := nextDaily(now, 2, 30); !got.Equal(time.Date(2026, 10, 18, 2, 30, 0, 0, time.UTC)) {
+		t.Errorf("expected tomorrow, got %v", got)
+	}
+}
+
+func TestParsePorts(t *testing.T) {
+	ports, err := parsePorts("7, 1-3")
+	if err != nil || !reflect.DeepEqual(ports, []int{1, 2, 3, 7}) {
+		t.Errorf("parsePorts = %v, %v", ports, err)
+	}
+	for _, list := range []string{"a", "3-1", "1-x"} {
+		if _, err := parsePorts(list); err == nil {
+			t.Errorf("expected an error for %q", list)
+		}
+	}
+}
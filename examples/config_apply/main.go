@@ -0,0 +1,90 @@
+// Command config_apply brings a switch in line with a declarative spec. It
+// prints the plan and only writes to the switch with -apply:
+//
+//	go run ./examples/config_apply -address 192.168.1.10 -spec switch.yaml
+//	go run ./examples/config_apply -address 192.168.1.10 -spec switch.yaml -apply
+//
+// A spec lists the desired settings of each managed port; ports and fields it
+// leaves out are not touched:
+//
+//	version: 1
+//	ports:
+//	  - port: 3
+//	    name: camera
+//	    poe: {enabled: true, priority: high}
+//	    vlan: {native: 20}
+//	  - port: 8
+//	    vlan: {native: 1, tagged: [20, 30]}
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/gherlein/go-netgear/examples/internal/live"
+	"github.com/gherlein/go-netgear/pkg/netgear"
+)
+
+func main() {
+	address := flag.String("address", "", "Switch IP address or host name")
+	specFile := flag.String("spec", "", "Spec file (YAML or JSON)")
+	apply := flag.Bool("apply", false, "Apply the plan instead of only printing it")
+	planFile := flag.String("save-plan", "", "Also write the plan to this JSON file for review")
+	flag.Parse()
+	live.Require("config_apply")
+
+	if *address == "" || *specFile == "" {
+		flag.Usage()
+		return
+	}
+
+	spec, err := netgear.LoadConfigSpec(*specFile)
+	if err != nil {
+		log.Fatalf("Failed to load spec: %v", err)
+	}
+
+	client, err := netgear.NewClient(*address)
+	if err != nil {
+		log.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	if !client.IsAuthenticated() {
+		if err := client.LoginAuto(ctx); err != nil {
+			log.Fatalf("Login failed: %v", err)
+		}
+	}
+
+	// Plan reads only the state the spec manages and lists what differs
+	plan, err := client.Config().Plan(ctx, spec)
+	if err != nil {
+		log.Fatalf("Failed to plan: %v", err)
+	}
+	if plan.Empty() {
+		fmt.Println("Switch already matches the spec")
+		return
+	}
+	for _, change := range plan.Changes {
+		fmt.Println(change)
+	}
+	if *planFile != "" {
+		if err := plan.Save(*planFile); err != nil {
+			log.Fatalf("Failed to save plan: %v", err)
+		}
+	}
+
+	if !*apply {
+		fmt.Println("Run again with -apply to make these changes")
+		return
+	}
+
+	// Apply re-reads the switch and refuses to write if it changed since the
+	// plan was made, so a reviewed plan is applied exactly
+	if err := client.Config().Apply(ctx, plan); err != nil {
+		log.Fatalf("Failed to apply: %v", err)
+	}
+	fmt.Printf("Applied %d changes\n", len(plan.Changes))
+}
@@ -0,0 +1,71 @@
+// Command exporter_embed serves the Prometheus metrics of an inventory's
+// switches from an application's own HTTP server, next to its other handlers,
+// instead of running the netgear-exporter binary:
+//
+//	go run ./examples/exporter_embed -inventory fleet.json -listen :8080
+//
+// The metrics are on /metrics; the published series are listed in
+// docs/exporter.md.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/gherlein/go-netgear/examples/internal/live"
+	"github.com/gherlein/go-netgear/pkg/exporter"
+	"github.com/gherlein/go-netgear/pkg/netgear"
+)
+
+func main() {
+	inventoryFile := flag.String("inventory", "fleet.json", "Inventory file")
+	listen := flag.String("listen", ":8080", "Address to serve HTTP on")
+	interval := flag.Duration("interval", 30*time.Second, "Default polling interval")
+	flag.Parse()
+	live.Require("exporter_embed")
+
+	inventory, err := netgear.LoadInventory(*inventoryFile)
+	if err != nil {
+		log.Fatalf("Failed to load inventory: %v", err)
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	fleet := netgear.NewFleet(inventory,
+		netgear.WithPasswordProvider(netgear.NewEnvironmentPasswordProvider()),
+		netgear.WithLogger(logger),
+	)
+	schedule, err := exporter.ScheduleInventory(inventory, *interval, time.Now())
+	if err != nil {
+		log.Fatalf("Invalid poll interval: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	// The collector polls in the background; scrapes only read its cache
+	collector := exporter.NewCollector(inventory, fleet, logger)
+	go collector.Run(ctx, schedule)
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", collector)
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "ok")
+	})
+	server := &http.Server{Addr: *listen, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		server.Shutdown(context.Background())
+	}()
+
+	log.Printf("Serving metrics of %d switches on %s/metrics", len(fleet.Names()), *listen)
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Fatal(err)
+	}
+}
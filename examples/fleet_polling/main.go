@@ -0,0 +1,103 @@
+// Command fleet_polling polls POE status from several switches concurrently.
+//
+// Passwords are resolved from the environment (NETGEAR_PASSWORD_<HOST> or
+// NETGEAR_SWITCHES), so the program only needs the switch addresses:
+//
+//	go run ./examples/fleet_polling -interval 30s 192.168.1.10 192.168.1.11
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+
+	"github.com/gherlein/go-netgear/examples/internal/live"
+	"github.com/gherlein/go-netgear/pkg/netgear"
+)
+
+func main() {
+	interval := flag.Duration("interval", 30*time.Second, "Polling interval")
+	once := flag.Bool("once", false, "Poll a single time and exit")
+	flag.Parse()
+	live.Require("fleet_polling")
+
+	addresses := flag.Args()
+	if len(addresses) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: fleet_polling [-interval 30s] [-once] <address>...")
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	// One client per switch; each client auto-authenticates from the environment
+	clients := make(map[string]*netgear.Client)
+	for _, address := range addresses {
+		client, err := netgear.NewClient(address)
+		if err != nil {
+			log.Printf("%s: failed to create client: %v", address, err)
+			continue
+		}
+		if !client.IsAuthenticated() {
+			log.Printf("%s: no password found in environment, skipping", address)
+			continue
+		}
+		clients[address] = client
+	}
+
+	if len(clients) == 0 {
+		log.Fatal("no switches could be authenticated")
+	}
+
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+
+	for {
+		pollFleet(ctx, clients)
+		if *once {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// pollFleet reads POE status from every switch in parallel and prints the total draw
+func pollFleet(ctx context.Context, clients map[string]*netgear.Client) {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+
+	for address, client := range clients {
+		wg.Add(1)
+		go func(address string, client *netgear.Client) {
+			defer wg.Done()
+
+			statuses, err := client.POE().GetStatus(ctx)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				log.Printf("%s: %v", address, err)
+				return
+			}
+
+			var totalW float64
+			for _, status := range statuses {
+				totalW += status.PowerW
+			}
+			fmt.Printf("%s %-15s %-9s %2d ports %6.2fW\n",
+				time.Now().Format(time.RFC3339), address, client.GetModel(), len(statuses), totalW)
+		}(address, client)
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,21 @@
+// Package live keeps the example programs that talk to real switches from
+// running by accident. They build and vet offline like any other package, but
+// only run once NETGEAR_EXAMPLES_LIVE=1 confirms a switch is there to talk to.
+package live
+
+import (
+	"fmt"
+	"os"
+)
+
+// EnvVar must be set to 1 for the networked examples to run
+const EnvVar = "NETGEAR_EXAMPLES_LIVE"
+
+// Require exits unless EnvVar is set to 1
+func Require(example string) {
+	if os.Getenv(EnvVar) == "1" {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "%s talks to real switches; set %s=1 to run it\n", example, EnvVar)
+	os.Exit(2)
+}
@@ -8,10 +8,13 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/gherlein/go-netgear/examples/internal/live"
 	"github.com/gherlein/go-netgear/pkg/netgear"
 )
 
 func main() {
+	live.Require("token_cache_example")
+
 	// Example 1: Use default cache location (~/.cache/go-netgear)
 	defaultCacheExample()
 
@@ -0,0 +1,79 @@
+// Command vlan_ports configures access and trunk ports with the VLAN helpers.
+//
+//	go run ./examples/vlan_ports -address 192.168.1.10 -access 3:20 -trunk 8:1:20,30
+//
+// -access takes port:vlan, -trunk takes port:native:tagged[,tagged...].
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/gherlein/go-netgear/examples/internal/live"
+	"github.com/gherlein/go-netgear/pkg/netgear"
+)
+
+func main() {
+	address := flag.String("address", "", "Switch IP address or host name")
+	access := flag.String("access", "", "Access port as port:vlan")
+	trunk := flag.String("trunk", "", "Trunk port as port:native:tagged[,tagged...]")
+	flag.Parse()
+	live.Require("vlan_ports")
+
+	if *address == "" || (*access == "" && *trunk == "") {
+		flag.Usage()
+		return
+	}
+
+	client, err := netgear.NewClient(*address)
+	if err != nil {
+		log.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	if !client.IsAuthenticated() {
+		if err := client.LoginAuto(ctx); err != nil {
+			log.Fatalf("Login failed: %v", err)
+		}
+	}
+
+	if *access != "" {
+		fields := mustInts(strings.Split(*access, ":"))
+		if len(fields) != 2 {
+			log.Fatalf("invalid -access %q, expected port:vlan", *access)
+		}
+		if err := client.VLANs().MakeAccessPort(ctx, fields[0], fields[1]); err != nil {
+			log.Fatalf("Failed to configure access port: %v", err)
+		}
+		fmt.Printf("Port %d is now an access port in VLAN %d\n", fields[0], fields[1])
+	}
+
+	if *trunk != "" {
+		parts := strings.SplitN(*trunk, ":", 3)
+		if len(parts) != 3 {
+			log.Fatalf("invalid -trunk %q, expected port:native:tagged[,tagged...]", *trunk)
+		}
+		head := mustInts(parts[:2])
+		tagged := mustInts(strings.Split(parts[2], ","))
+		if err := client.VLANs().MakeTrunkPort(ctx, head[0], head[1], tagged...); err != nil {
+			log.Fatalf("Failed to configure trunk port: %v", err)
+		}
+		fmt.Printf("Port %d is now a trunk port (native VLAN %d, tagged %v)\n", head[0], head[1], tagged)
+	}
+}
+
+func mustInts(values []string) []int {
+	result := make([]int, 0, len(values))
+	for _, value := range values {
+		n, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			log.Fatalf("invalid number %q", value)
+		}
+		result = append(result, n)
+	}
+	return result
+}
@@ -0,0 +1,89 @@
+// Command watch_events follows switches as they change. Link and POE state
+// changes of every switch arrive as typed events, and the POE draw of the
+// first switch is watched port by port:
+//
+//	go run ./examples/watch_events -interval 10s 192.168.1.10 192.168.1.11
+//
+// Passwords are resolved from the environment (NETGEAR_PASSWORD_<HOST> or
+// NETGEAR_SWITCHES).
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/gherlein/go-netgear/examples/internal/live"
+	"github.com/gherlein/go-netgear/pkg/netgear"
+)
+
+func main() {
+	interval := flag.Duration("interval", 10*time.Second, "Polling interval")
+	flag.Parse()
+	live.Require("watch_events")
+
+	addresses := flag.Args()
+	if len(addresses) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: watch_events [-interval 10s] <address>...")
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	events := netgear.NewEvents(*interval)
+	var first *netgear.Client
+	for _, address := range addresses {
+		client, err := netgear.NewClientContext(ctx, address)
+		if err != nil {
+			log.Fatalf("%s: failed to create client: %v", address, err)
+		}
+		defer client.Close()
+		if !client.IsAuthenticated() {
+			log.Fatalf("%s: no password found in environment", address)
+		}
+		events.Add(address, client)
+		if first == nil {
+			first = client
+		}
+	}
+
+	// Handlers run on the polling goroutine; a subscription decouples a
+	// slower consumer and counts what it misses
+	events.On(netgear.EventPOEFault, func(event netgear.Event) {
+		log.Printf("ALERT %s", event)
+	})
+	subscription := events.Subscribe(64, netgear.OverflowCoalesce)
+	go events.Run(ctx)
+
+	// Only ports whose POE values changed since the previous poll are sent
+	statuses, err := first.POE().WatchStatus(ctx, *interval,
+		netgear.WithDeltaOnly(),
+		netgear.WithWatchErrors(func(err error) { log.Printf("%s: poll failed: %v", addresses[0], err) }),
+		netgear.WithWatchDropped(func(dropped uint64) { log.Printf("%s: %d snapshots dropped", addresses[0], dropped) }),
+	)
+	if err != nil {
+		log.Fatalf("Failed to watch POE status: %v", err)
+	}
+
+	for {
+		select {
+		case event, ok := <-subscription:
+			if !ok {
+				return
+			}
+			fmt.Printf("%s %s\n", event.Time.Format(time.TimeOnly), event)
+		case changed, ok := <-statuses:
+			if !ok {
+				return
+			}
+			for _, status := range changed {
+				fmt.Printf("%s %s port %d: %s, %.1f W\n", time.Now().Format(time.TimeOnly), addresses[0], status.PortID, status.Status, status.PowerW)
+			}
+		}
+	}
+}
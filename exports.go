@@ -7,6 +7,7 @@ import (
 	"github.com/gherlein/go-netgear/internal/formatter"
 	"github.com/gherlein/go-netgear/internal/models"
 	"github.com/gherlein/go-netgear/internal/types"
+	"github.com/gherlein/go-netgear/pkg/version"
 )
 
 // Export types
@@ -41,6 +42,8 @@ type PortSetting = models.PortSetting
 const (
 	MarkdownFormat = formatter.MarkdownFormat
 	JsonFormat     = formatter.JsonFormat
+	YamlFormat     = formatter.YamlFormat
+	TableFormat    = formatter.TableFormat
 )
 
 // Export model constants
@@ -55,7 +58,8 @@ const (
 )
 
 // Export version
-var VERSION = cli.VERSION
+var VERSION = version.Version
 
 // Export utility functions
-var DetectNetgearModel = models.DetectNetgearModel
\ No newline at end of file
+var DetectNetgearModel = models.DetectNetgearModel
+var DetectNetgearModelContext = models.DetectNetgearModelContext
\ No newline at end of file
@@ -1,10 +1,12 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"github.com/gherlein/go-netgear/internal/client"
 	"github.com/gherlein/go-netgear/internal/common"
 	"github.com/gherlein/go-netgear/internal/types"
+	"github.com/gherlein/go-netgear/pkg/redact"
 )
 
 type DebugReportCommand struct {
@@ -12,17 +14,24 @@ type DebugReportCommand struct {
 }
 
 func (drc *DebugReportCommand) Run(args *types.GlobalOptions) error {
+	ctx, cancel := args.Context()
+	defer cancel()
+	return drc.RunContext(ctx, args)
+}
+
+// RunContext runs the command until it finishes or ctx is done
+func (drc *DebugReportCommand) RunContext(ctx context.Context, args *types.GlobalOptions) error {
 	args.Verbose = true
 	model, _, err := client.ReadTokenAndModel2GlobalOptions(args, drc.Address)
 	if err != nil {
 		fmt.Println("Warning, prior error: " + err.Error())
-		printDebugNotLoggedIn(args, drc.Address, err)
+		printDebugNotLoggedIn(ctx, args, drc.Address, err)
 	}
-	printDebugLoggedIn(args, model, drc.Address)
+	printDebugLoggedIn(ctx, args, model, drc.Address)
 	return nil
 }
 
-func printDebugNotLoggedIn(args *types.GlobalOptions, host string, err error) {
+func printDebugNotLoggedIn(ctx context.Context, args *types.GlobalOptions, host string, err error) {
 	fmt.Println("---[DEBUG: not logged in]---")
 	fmt.Println(fmt.Sprintf("Not logged in error: %s", err))
 	fmt.Println("Please try to login and run `debug-report` command again, in order to detect the model and get even more debug information")
@@ -33,19 +42,19 @@ func printDebugNotLoggedIn(args *types.GlobalOptions, host string, err error) {
 		fmt.Sprintf("http://%s/redirect.html", host),
 	}
 	for _, reqUrl := range reqUrls {
-		body, err := client.DoUnauthenticatedHttpRequestAndReadResponse(args, "GET", reqUrl, "")
+		body, err := client.DoUnauthenticatedHttpRequestAndReadResponse(ctx, args, "GET", reqUrl, "")
 		fmt.Println(fmt.Sprintf("---[RESPONSE: %s]---", reqUrl))
 		if err != nil {
-			fmt.Println("ERROR: " + err.Error())
+			fmt.Println("ERROR: " + redact.String(err.Error()))
 		} else {
-			fmt.Println(body)
+			fmt.Println(redact.String(body))
 		}
 		fmt.Println("---[/RESPONSE]---")
 	}
 	fmt.Println("---[/DEBUG]---")
 }
 
-func printDebugLoggedIn(args *types.GlobalOptions, model types.NetgearModel, host string) {
+func printDebugLoggedIn(ctx context.Context, args *types.GlobalOptions, model types.NetgearModel, host string) {
 	var reqUrls []string
 	if !common.IsModel30x(model) {
 		reqUrls = append(reqUrls,
@@ -71,14 +80,14 @@ func printDebugLoggedIn(args *types.GlobalOptions, model types.NetgearModel, hos
 	if len(reqUrls) > 0 {
 		fmt.Println(fmt.Sprintf("---[DEBUG: model '%s']---", model))
 		for _, reqUrl := range reqUrls {
-			body, err := client.DoHttpRequestAndReadResponse(args, "GET", host, reqUrl, "")
+			body, err := client.DoHttpRequestAndReadResponse(ctx, args, "GET", host, reqUrl, "")
 			fmt.Println(fmt.Sprintf("---[RESPONSE: %s]---", reqUrl))
 			if err != nil {
-				fmt.Println("ERROR: " + err.Error())
+				fmt.Println("ERROR: " + redact.String(err.Error()))
 			} else if client.CheckIsLoginRequired(body) {
 				fmt.Println("WARN: it seems the session token expired, please re-login")
 			} else {
-				fmt.Println(body)
+				fmt.Println(redact.String(body))
 			}
 			fmt.Println("---[/RESPONSE]---")
 		}
@@ -2,16 +2,15 @@ package cli
 
 import (
 	"fmt"
+
 	"github.com/gherlein/go-netgear/internal/types"
+	"github.com/gherlein/go-netgear/pkg/version"
 )
 
-// VERSION will be set at compile time - see Github actions...
-var VERSION = "dev"
-
 type VersionCommand struct {
 }
 
-func (version *VersionCommand) Run(args *types.GlobalOptions) error {
-	fmt.Println(VERSION)
+func (cmd *VersionCommand) Run(args *types.GlobalOptions) error {
+	fmt.Println(version.Get())
 	return nil
 }
@@ -12,18 +12,6 @@ import (
 
 const separator = ":"
 
-func storeToken(args *types.GlobalOptions, host string, token string) error {
-	err := ensureConfigPathExists(args.TokenDir)
-	if err != nil {
-		return err
-	}
-	if args.Verbose {
-		fmt.Println("Storing login token " + tokenFilename(args.TokenDir, host))
-	}
-	data := fmt.Sprintf("%s%s%s", args.Model, separator, token)
-	return os.WriteFile(tokenFilename(args.TokenDir, host), []byte(data), 0644)
-}
-
 func tokenFilename(configDir string, host string) string {
 	hash32 := adler32.New()
 	io.WriteString(hash32, host)
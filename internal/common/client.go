@@ -0,0 +1,86 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/gherlein/go-netgear/internal/types"
+	"github.com/gherlein/go-netgear/pkg/netgear"
+)
+
+// ErrNoSession is returned by commands run before logging in
+var ErrNoSession = errors.New("no session (token) exists. please login first")
+
+// legacyTokenManager keeps the library client's session in the token files
+// of the legacy commands, so sessions from earlier logins keep working
+type legacyTokenManager struct {
+	args *types.GlobalOptions
+}
+
+func (tm *legacyTokenManager) GetToken(ctx context.Context, address string) (string, netgear.Model, error) {
+	model, token, err := ReadTokenAndModel2GlobalOptions(tm.args, address)
+	return token, model, err
+}
+
+func (tm *legacyTokenManager) StoreToken(ctx context.Context, address string, token string, model netgear.Model) error {
+	tm.args.Model = model
+	tm.args.Token = token
+	return StoreToken(tm.args, address, token)
+}
+
+func (tm *legacyTokenManager) DeleteToken(ctx context.Context, address string) error {
+	tm.args.Token = ""
+	err := os.Remove(tokenFilename(tm.args.TokenDir, address))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// NewClient returns a pkg/netgear client for the switch sharing the session
+// of the legacy commands; args.Model, when set, skips model detection, which
+// otherwise runs with ctx
+func NewClient(ctx context.Context, args *types.GlobalOptions, host string) (*netgear.Client, error) {
+	opts := []netgear.ClientOption{
+		netgear.WithTokenManager(&legacyTokenManager{args: args}),
+		netgear.WithEnvironmentAuth(false),
+		netgear.WithVerbose(args.Verbose),
+	}
+	if len(args.Model) > 0 {
+		opts = append(opts, netgear.WithModel(args.Model))
+	}
+	if args.Timeout > 0 {
+		opts = append(opts, netgear.WithTimeout(args.Timeout))
+	}
+	return netgear.NewClientContext(ctx, host, opts...)
+}
+
+// NewAuthenticatedClient is NewClient for commands that need a session
+func NewAuthenticatedClient(ctx context.Context, args *types.GlobalOptions, host string) (*netgear.Client, error) {
+	client, err := NewClient(ctx, args, host)
+	if err != nil {
+		return nil, err
+	}
+	if !client.IsAuthenticated() {
+		return nil, ErrNoSession
+	}
+	args.Model = client.GetModel()
+	return client, nil
+}
+
+// CheckPorts rejects port numbers the model does not have
+func CheckPorts(model types.NetgearModel, ports []int) error {
+	count := model.PortCount()
+	for _, port := range ports {
+		if port < 1 && count == 0 {
+			return fmt.Errorf("given port id %d, port numbers start with 1", port)
+		}
+		if port < 1 || (count > 0 && port > count) {
+			return fmt.Errorf("given port id %d, doesn't fit in range 1..%d", port, count)
+		}
+	}
+	return nil
+}
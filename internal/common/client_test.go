@@ -0,0 +1,69 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gherlein/go-netgear/internal/types"
+	"github.com/gherlein/go-netgear/pkg/netgear"
+)
+
+func TestLegacyTokenManagerSharesTokenFiles(t *testing.T) {
+	args := &types.GlobalOptions{TokenDir: t.TempDir()}
+	tm := &legacyTokenManager{args: args}
+	ctx := context.Background()
+
+	if err := tm.StoreToken(ctx, "192.168.0.10", "token-1", netgear.ModelGS316EP); err != nil {
+		t.Fatalf("StoreToken: %v", err)
+	}
+
+	// a later command reads the session written by the library client
+	later := &types.GlobalOptions{TokenDir: args.TokenDir}
+	model, token, err := ReadTokenAndModel2GlobalOptions(later, "192.168.0.10")
+	if err != nil || model != netgear.ModelGS316EP || token != "token-1" {
+		t.Fatalf("got %q, %q, %v", model, token, err)
+	}
+
+	if err := tm.DeleteToken(ctx, "192.168.0.10"); err != nil {
+		t.Fatalf("DeleteToken: %v", err)
+	}
+	if _, _, err := tm.GetToken(ctx, "192.168.0.10"); !errors.Is(err, ErrNoSession) {
+		t.Errorf("expected ErrNoSession after DeleteToken, got %v", err)
+	}
+	if err := tm.DeleteToken(ctx, "192.168.0.10"); err != nil {
+		t.Errorf("deleting a missing token: %v", err)
+	}
+}
+
+func TestNewAuthenticatedClient(t *testing.T) {
+	args := &types.GlobalOptions{TokenDir: t.TempDir(), Model: netgear.ModelGS308EP}
+	if _, err := NewAuthenticatedClient(context.Background(), args, "192.168.0.10"); !errors.Is(err, ErrNoSession) {
+		t.Fatalf("expected ErrNoSession without a login, got %v", err)
+	}
+
+	if err := StoreToken(args, "192.168.0.10", "token-1"); err != nil {
+		t.Fatalf("StoreToken: %v", err)
+	}
+	client, err := NewAuthenticatedClient(context.Background(), &types.GlobalOptions{TokenDir: args.TokenDir}, "192.168.0.10")
+	if err != nil {
+		t.Fatalf("NewAuthenticatedClient: %v", err)
+	}
+	if client.GetModel() != netgear.ModelGS308EP || !client.IsAuthenticated() {
+		t.Errorf("got model %s, authenticated %v", client.GetModel(), client.IsAuthenticated())
+	}
+}
+
+func TestCheckPorts(t *testing.T) {
+	if err := CheckPorts(netgear.ModelGS305EP, []int{1, 5}); err != nil {
+		t.Errorf("valid ports rejected: %v", err)
+	}
+	for _, ports := range [][]int{{0}, {6}, {1, 9}} {
+		if err := CheckPorts(netgear.ModelGS305EP, ports); err == nil {
+			t.Errorf("ports %v accepted on a GS305EP", ports)
+		}
+	}
+	if err := CheckPorts(netgear.ModelGS30xEPx, []int{0}); err == nil {
+		t.Error("port 0 accepted on a model with unknown port count")
+	}
+}
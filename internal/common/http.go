@@ -1,18 +1,20 @@
 package common
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
 	"github.com/gherlein/go-netgear/internal/types"
+	"github.com/gherlein/go-netgear/pkg/redact"
 )
 
-func RequestPage(args *types.GlobalOptions, host string, url string) (string, error) {
-	return DoHttpRequestAndReadResponse(args, http.MethodGet, host, url, "")
+func RequestPage(ctx context.Context, args *types.GlobalOptions, host string, url string) (string, error) {
+	return DoHttpRequestAndReadResponse(ctx, args, http.MethodGet, host, url, "")
 }
 
-func DoHttpRequestAndReadResponse(args *types.GlobalOptions, httpMethod string, host string, requestUrl string, requestBody string) (string, error) {
+func DoHttpRequestAndReadResponse(ctx context.Context, args *types.GlobalOptions, httpMethod string, host string, requestUrl string, requestBody string) (string, error) {
 	model, token, err := ReadTokenAndModel2GlobalOptions(args, host)
 	if err != nil {
 		return "", err
@@ -31,7 +33,7 @@ func DoHttpRequestAndReadResponse(args *types.GlobalOptions, httpMethod string,
 		}
 	}
 
-	req, err := http.NewRequest(httpMethod, requestUrl, strings.NewReader(requestBody))
+	req, err := http.NewRequestWithContext(ctx, httpMethod, requestUrl, strings.NewReader(requestBody))
 	if err != nil {
 		return "", err
 	}
@@ -57,12 +59,12 @@ func DoHttpRequestAndReadResponse(args *types.GlobalOptions, httpMethod string,
 	return string(bytes), err
 }
 
-func DoUnauthenticatedHttpRequestAndReadResponse(args *types.GlobalOptions, httpMethod string, requestUrl string, requestBody string) (string, error) {
+func DoUnauthenticatedHttpRequestAndReadResponse(ctx context.Context, args *types.GlobalOptions, httpMethod string, requestUrl string, requestBody string) (string, error) {
 	if args.Verbose {
 		fmt.Println("Fetching data from: " + requestUrl)
 	}
 
-	req, err := http.NewRequest(httpMethod, requestUrl, strings.NewReader(requestBody))
+	req, err := http.NewRequestWithContext(ctx, httpMethod, requestUrl, strings.NewReader(requestBody))
 	if err != nil {
 		return "", err
 	}
@@ -75,7 +77,7 @@ func DoUnauthenticatedHttpRequestAndReadResponse(args *types.GlobalOptions, http
 	defer resp.Body.Close()
 	if args.Verbose {
 		fmt.Println(resp.Status)
-		for name, values := range resp.Header {
+		for name, values := range redact.Default().Header(resp.Header) {
 			for _, value := range values {
 				fmt.Println(fmt.Sprintf("Response header: '%s' -- '%s'", name, value))
 			}
@@ -0,0 +1,64 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gherlein/go-netgear/internal/types"
+	"github.com/gherlein/go-netgear/pkg/netgear"
+)
+
+func TestRequestsHonorContextDeadline(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	args := &types.GlobalOptions{Model: netgear.ModelGS308EP, Token: "token-1"}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := RequestPage(ctx, args, server.Listener.Addr().String(), server.URL+"/dashboard.cgi")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected a deadline error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("request returned after %v, long past its deadline", elapsed)
+	}
+
+	cancelled, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+	if _, err := DoUnauthenticatedHttpRequestAndReadResponse(cancelled, args, http.MethodGet, server.URL+"/", ""); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected a cancellation error, got %v", err)
+	}
+}
+
+func TestGlobalOptionsContextTimeout(t *testing.T) {
+	args := &types.GlobalOptions{Timeout: 10 * time.Millisecond}
+	ctx, cancel := args.Context()
+	defer cancel()
+	select {
+	case <-ctx.Done():
+	case <-time.After(2 * time.Second):
+		t.Fatal("context not done after the command timeout")
+	}
+
+	ctx, cancel = (&types.GlobalOptions{}).Context()
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("expected no deadline without a timeout")
+	}
+	cancel()
+	if ctx.Err() == nil {
+		t.Error("expected cancel to end the context")
+	}
+}
@@ -5,15 +5,15 @@ import (
 )
 
 func IsModel30x(nm types.NetgearModel) bool {
-	return nm == types.GS305EP || nm == types.GS305EPP || nm == types.GS308EP || nm == types.GS308EPP || nm == types.GS30xEPx
+	return nm.IsModel30x()
 }
 
 func IsModel316(nm types.NetgearModel) bool {
-	return nm == types.GS316EP || nm == types.GS316EPP
+	return nm.IsModel316()
 }
 
 func IsSupportedModel(modelName string) bool {
-	return IsModel30x(types.NetgearModel(modelName)) || IsModel316(types.NetgearModel(modelName))
+	return types.NetgearModel(modelName).IsSupported()
 }
 
 func Filter[T any](ss []T, test func(T) bool) (ret []T) {
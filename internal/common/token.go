@@ -25,7 +25,7 @@ func ReadTokenAndModel2GlobalOptions(args *types.GlobalOptions, host string) (ty
 	}
 	bytes, err := os.ReadFile(tokenFilename(args.TokenDir, host))
 	if errors.Is(err, fs.ErrNotExist) {
-		return "", "", errors.New("no session (token) exists. please login first")
+		return "", "", ErrNoSession
 	}
 	data := strings.SplitN(string(bytes), separator, 2)
 	if len(data) != 2 {
@@ -39,6 +39,18 @@ func ReadTokenAndModel2GlobalOptions(args *types.GlobalOptions, host string) (ty
 	return args.Model, args.Token, err
 }
 
+func StoreToken(args *types.GlobalOptions, host string, token string) error {
+	err := os.MkdirAll(dotConfigDirName(args.TokenDir), os.ModeDir|0700)
+	if err != nil {
+		return err
+	}
+	if args.Verbose {
+		fmt.Println("Storing login token " + tokenFilename(args.TokenDir, host))
+	}
+	data := fmt.Sprintf("%s%s%s", args.Model, separator, token)
+	return os.WriteFile(tokenFilename(args.TokenDir, host), []byte(data), 0644)
+}
+
 func tokenFilename(configDir string, host string) string {
 	hash32 := adler32.New()
 	io.WriteString(hash32, host)
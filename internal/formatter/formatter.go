@@ -1,8 +1,54 @@
 package formatter
 
+import (
+	"fmt"
+	"strings"
+)
+
 type OutputFormat string
 
 const (
 	MarkdownFormat OutputFormat = "md"
 	JsonFormat     OutputFormat = "json"
+	YamlFormat     OutputFormat = "yaml"
+	TableFormat    OutputFormat = "table"
 )
+
+// OutputFormatNames lists the names ParseOutputFormat accepts, for flag help
+const OutputFormatNames = "table, markdown, json or yaml"
+
+// outputFormatNames maps the accepted names, including aliases, to formats
+var outputFormatNames = map[string]OutputFormat{
+	"table":    TableFormat,
+	"markdown": MarkdownFormat,
+	"md":       MarkdownFormat,
+	"json":     JsonFormat,
+	"yaml":     YamlFormat,
+	"yml":      YamlFormat,
+}
+
+// ParseOutputFormat converts a format name given on the command line
+func ParseOutputFormat(name string) (OutputFormat, error) {
+	if format, ok := outputFormatNames[strings.ToLower(strings.TrimSpace(name))]; ok {
+		return format, nil
+	}
+	return "", fmt.Errorf("unknown output format %q, use %s", name, OutputFormatNames)
+}
+
+// PrintDataTable prints rows in the given format. JSON and YAML list the rows
+// under item as objects keyed by the header.
+func PrintDataTable(format OutputFormat, item string, header []string, content [][]string) error {
+	switch format {
+	case MarkdownFormat:
+		PrintMarkdownTable(header, content)
+	case JsonFormat:
+		PrintJsonDataTable(item, header, content)
+	case YamlFormat:
+		PrintYamlDataTable(item, header, content)
+	case TableFormat:
+		PrintAlignedTable(header, content)
+	default:
+		return fmt.Errorf("not implemented format: %s", format)
+	}
+	return nil
+}
@@ -36,3 +36,13 @@ func PrintJsonDataTable(item string, header []string, content [][]string) {
 	
 	fmt.Println(string(jsonData))
 }
+
+// PrintJson prints a value as indented JSON
+func PrintJson(v any) error {
+	jsonData, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(jsonData))
+	return nil
+}
@@ -0,0 +1,53 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// columnGap separates the columns of an aligned table
+const columnGap = "  "
+
+// PrintAlignedTable prints a plain table for terminals: the header in upper
+// case and every column padded to its widest value
+func PrintAlignedTable(header []string, content [][]string) {
+	var lengths = make([]int, len(header))
+	for i, h := range header {
+		lengths[i] = len([]rune(h))
+	}
+	for _, row := range content {
+		for i, value := range row {
+			if i < len(lengths) {
+				lengths[i] = max(lengths[i], len([]rune(value)))
+			}
+		}
+	}
+
+	printAlignedRow(lengths, header, strings.ToUpper)
+	for _, row := range content {
+		printAlignedRow(lengths, row, nil)
+	}
+}
+
+// printAlignedRow prints one row, leaving the last column unpadded so lines
+// carry no trailing spaces
+func printAlignedRow(lengths []int, row []string, transform func(string) string) {
+	line := strings.Builder{}
+	for i, length := range lengths {
+		value := ""
+		if i < len(row) {
+			value = row[i]
+		}
+		if transform != nil {
+			value = transform(value)
+		}
+		if i > 0 {
+			line.WriteString(columnGap)
+		}
+		if i < len(lengths)-1 {
+			value = suffixToLength(value, length)
+		}
+		line.WriteString(value)
+	}
+	fmt.Println(strings.TrimRight(line.String(), " "))
+}
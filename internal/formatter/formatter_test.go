@@ -93,7 +93,7 @@ func TestPrintMarkdownTable(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// Capture output
 			output := captureOutput(func() {
-				printMarkdownTable(tt.header, tt.content)
+				PrintMarkdownTable(tt.header, tt.content)
 			})
 
 			// Verify expected lines
@@ -224,7 +224,7 @@ func TestPrintJsonDataTable(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// Capture output
 			output := captureOutput(func() {
-				printJsonDataTable(tt.item, tt.header, tt.content)
+				PrintJsonDataTable(tt.item, tt.header, tt.content)
 			})
 
 			// Validate JSON structure
@@ -297,7 +297,7 @@ func TestMarkdownTableAlignment(t *testing.T) {
 	}
 
 	output := captureOutput(func() {
-		printMarkdownTable(header, content)
+		PrintMarkdownTable(header, content)
 	})
 
 	lines := strings.Split(output, "\n")
@@ -350,7 +350,7 @@ func TestJsonOutputFormatting(t *testing.T) {
 	}
 
 	output := captureOutput(func() {
-		printJsonDataTable("test_items", header, content)
+		PrintJsonDataTable("test_items", header, content)
 	})
 
 	// Should be valid JSON
@@ -377,4 +377,67 @@ func captureOutput(f func()) string {
 	os.Stdout = oldStdout
 
 	return string(output[:n])
-}
\ No newline at end of file
+}
+func TestPrintAlignedTable(t *testing.T) {
+	output := captureOutput(func() {
+		PrintAlignedTable([]string{"Port", "Name", "Status"}, [][]string{
+			{"1", "uplink", "connected"},
+			{"10", "", "available"},
+		})
+	})
+
+	expected := "PORT  NAME    STATUS\n" +
+		"1     uplink  connected\n" +
+		"10            available\n"
+	then.AssertThat(t, output, is.EqualTo(expected))
+}
+
+func TestPrintYamlDataTable(t *testing.T) {
+	output := captureOutput(func() {
+		PrintYamlDataTable("ports", []string{"Port", "Name", "Power"}, [][]string{
+			{"1", "camera: lobby", "4.5"},
+			{"2"},
+		})
+	})
+
+	expected := "ports:\n" +
+		"  - Port: \"1\"\n" +
+		"    Name: 'camera: lobby'\n" +
+		"    Power: \"4.5\"\n" +
+		"  - Port: \"2\"\n" +
+		"    Name: \"\"\n" +
+		"    Power: \"\"\n"
+	then.AssertThat(t, output, is.EqualTo(expected))
+
+	output = captureOutput(func() {
+		PrintYamlDataTable("ports", []string{"Port"}, nil)
+	})
+	then.AssertThat(t, output, is.EqualTo("ports: []\n"))
+}
+
+func TestPrintYaml(t *testing.T) {
+	value := struct {
+		Address string  `json:"address"`
+		TotalW  float64 `json:"total_w"`
+	}{Address: "10.0.0.2", TotalW: 123}
+
+	output := captureOutput(func() {
+		then.AssertThat(t, PrintYaml(value), is.Nil())
+	})
+	then.AssertThat(t, output, is.EqualTo("address: 10.0.0.2\ntotal_w: 123\n"))
+}
+
+func TestParseOutputFormat(t *testing.T) {
+	for name, expected := range map[string]OutputFormat{
+		"table": TableFormat, "markdown": MarkdownFormat, "md": MarkdownFormat,
+		"JSON": JsonFormat, "yaml": YamlFormat, "yml": YamlFormat,
+	} {
+		format, err := ParseOutputFormat(name)
+		then.AssertThat(t, err, is.Nil())
+		then.AssertThat(t, format, is.EqualTo(expected))
+	}
+
+	_, err := ParseOutputFormat("xml")
+	then.AssertThat(t, err, is.Not(is.Nil()))
+	then.AssertThat(t, PrintDataTable("xml", "items", nil, nil), is.Not(is.Nil()))
+}
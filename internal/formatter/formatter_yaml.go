@@ -0,0 +1,75 @@
+package formatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PrintYamlDataTable prints rows as YAML, with the same structure as
+// PrintJsonDataTable but keeping the columns in header order
+func PrintYamlDataTable(item string, header []string, content [][]string) {
+	items := &yaml.Node{Kind: yaml.SequenceNode}
+	for _, row := range content {
+		rowNode := &yaml.Node{Kind: yaml.MappingNode}
+		for i, headerName := range header {
+			value := ""
+			if i < len(row) {
+				value = row[i]
+			}
+			rowNode.Content = append(rowNode.Content, stringNode(headerName), stringNode(value))
+		}
+		items.Content = append(items.Content, rowNode)
+	}
+	if len(content) == 0 {
+		items.Style = yaml.FlowStyle
+	}
+
+	doc := &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{stringNode(item), items}}
+	printYamlNode(doc)
+}
+
+// PrintYaml prints a value as YAML using its JSON field names, so JSON and
+// YAML output of the same value have the same keys
+func PrintYaml(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	plainStyle(&doc)
+	printYamlNode(&doc)
+	return nil
+}
+
+// printYamlNode encodes a YAML tree to stdout with two-space indentation
+func printYamlNode(node *yaml.Node) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(node); err != nil {
+		fmt.Printf("Error marshaling YAML: %v\n", err)
+		return
+	}
+	encoder.Close()
+	fmt.Print(buf.String())
+}
+
+// stringNode is a scalar holding a string, quoted by the encoder when it
+// would otherwise read as another type
+func stringNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+// plainStyle clears the quoting of a YAML tree decoded from JSON
+func plainStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		plainStyle(child)
+	}
+}
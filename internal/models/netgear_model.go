@@ -1,53 +1,39 @@
 package models
 
 import (
-	"errors"
+	"context"
 	"fmt"
-	"io"
-	"net/http"
-	"strings"
 	"github.com/gherlein/go-netgear/internal/types"
+	"github.com/gherlein/go-netgear/pkg/netgear"
 )
 
+// DetectNetgearModel asks the switch for its model, using the library's detection
 func DetectNetgearModel(args *types.GlobalOptions, host string) (types.NetgearModel, error) {
-	url := fmt.Sprintf("http://%s/", host)
+	ctx, cancel := args.Context()
+	defer cancel()
+	return DetectNetgearModelContext(ctx, args, host)
+}
+
+// DetectNetgearModelContext is DetectNetgearModel bounded by ctx
+func DetectNetgearModelContext(ctx context.Context, args *types.GlobalOptions, host string) (types.NetgearModel, error) {
 	if args.Verbose {
-		fmt.Println("detecting Netgear switch model: " + url)
-	}
-	resp, err := http.Get(url)
-	if err != nil {
-		return "", err
+		fmt.Println("detecting Netgear switch model: " + host)
 	}
-	if args.Verbose {
-		fmt.Println(fmt.Sprintf("HTTP response code %d", resp.StatusCode))
+	opts := []netgear.ClientOption{
+		netgear.WithTokenManager(netgear.NewMemoryTokenManager()),
+		netgear.WithEnvironmentAuth(false),
+		netgear.WithVerbose(args.Verbose),
 	}
-	if resp.StatusCode != 200 {
-		fmt.Println(fmt.Sprintf("Warning: response code was not 200; unusual, but will attempt detection anyway"))
+	if args.Timeout > 0 {
+		opts = append(opts, netgear.WithTimeout(args.Timeout))
 	}
-	responseBody, err := io.ReadAll(resp.Body)
-	_ = resp.Body.Close()
+	client, err := netgear.NewClientContext(ctx, host, opts...)
 	if err != nil {
-		return "", err
-	}
-	model := detectNetgearModelFromResponse(string(responseBody))
-	if model == "" {
-		return "", errors.New("Can't auto-detect Netgear model from response. You may try using --model parameter ")
+		return "", fmt.Errorf("can't auto-detect Netgear model, you may try using --model parameter: %w", err)
 	}
+	model := client.GetModel()
 	if args.Verbose {
 		fmt.Println(fmt.Sprintf("Detected model %s", model))
 	}
 	return model, nil
 }
-
-func detectNetgearModelFromResponse(body string) types.NetgearModel {
-	if strings.Contains(strings.ToLower(body), "<title>") && strings.Contains(body, "GS316EPP") {
-		return types.GS316EPP
-	}
-	if strings.Contains(strings.ToLower(body), "<title>") && strings.Contains(body, "GS316EP") {
-		return types.GS316EP
-	}
-	if strings.Contains(strings.ToLower(body), "<title>") && strings.Contains(body, "Redirect to Login") {
-		return types.GS30xEPx
-	}
-	return ""
-}
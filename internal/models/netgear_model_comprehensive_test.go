@@ -82,57 +82,6 @@ func TestIsSupportedModelComprehensive(t *testing.T) {
 	}
 }
 
-func TestDetectNetgearModelFromResponseComprehensive(t *testing.T) {
-	tests := []struct {
-		name     string
-		body     string
-		expected types.NetgearModel
-	}{
-		{
-			name:     "GS316EPP detection",
-			body:     `<html><title>GS316EPP Switch</title></html>`,
-			expected: GS316EPP,
-		},
-		{
-			name:     "GS316EP detection",
-			body:     `<html><title>GS316EP Management</title></html>`,
-			expected: GS316EP,
-		},
-		{
-			name:     "GS30x series detection",
-			body:     `<html><title>Redirect to Login</title></html>`,
-			expected: GS30xEPx,
-		},
-		{
-			name:     "Case insensitive title tag",
-			body:     `<html><TITLE>GS316EP</TITLE></html>`,
-			expected: GS316EP,
-		},
-		{
-			name:     "No model detected",
-			body:     `<html><title>Unknown Switch</title></html>`,
-			expected: "",
-		},
-		{
-			name:     "Empty response",
-			body:     "",
-			expected: "",
-		},
-		{
-			name:     "GS316EPP appears before GS316EP in content",
-			body:     `<html><title>GS316EPP appears before GS316EP</title></html>`,
-			expected: GS316EPP,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := detectNetgearModelFromResponse(tt.body)
-			then.AssertThat(t, result, is.EqualTo(tt.expected))
-		})
-	}
-}
-
 func TestDetectNetgearModel(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -329,4 +278,4 @@ func TestDetectNetgearModel_ErrorPropagation(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
@@ -3,27 +3,14 @@ package models
 import (
 	"github.com/gherlein/go-netgear/internal/common"
 	"github.com/gherlein/go-netgear/internal/types"
-	"errors"
+	"github.com/gherlein/go-netgear/pkg/netgear"
+	"context"
 	"fmt"
-	"github.com/PuerkitoBio/goquery"
-	"io"
-	"net/url"
 	"slices"
 	"strconv"
 	"strings"
 )
 
-type PoeSettingKey string
-
-const (
-	PortPrio     PoeSettingKey = "PortPrio"
-	PwrMode      PoeSettingKey = "PwrMode"
-	LimitType    PoeSettingKey = "LimitType"
-	PwrLimit     PoeSettingKey = "PwrLimit"
-	DetecType    PoeSettingKey = "DetecType"
-	LongerDetect PoeSettingKey = "LongerDetect"
-)
-
 type PoeSetConfigCommand struct {
 	Address      string `required:"" help:"the Netgear switch's IP address or host name to connect to" short:"a"`
 	Ports        []int  `required:"" help:"port number (starting with 1), use multiple times for setting multiple ports at once" short:"p" name:"port"`
@@ -36,232 +23,118 @@ type PoeSetConfigCommand struct {
 	LongerDetect string `optional:"" help:"longer detection time [enable, disable]" name:"longer-detection-time"`
 }
 
-type PoeExt struct {
-	Hash         string
-	PortMaxPower string
-}
+var poeModes = []netgear.POEMode{netgear.POEMode8023af, netgear.POEModeLegacy, netgear.POEModePre8023at, netgear.POEMode8023at}
+var poePriorities = []netgear.POEPriority{netgear.POEPriorityLow, netgear.POEPriorityHigh, netgear.POEPriorityCritical}
+var poeLimitTypes = []netgear.POELimitType{netgear.POELimitTypeNone, netgear.POELimitTypeClass, netgear.POELimitTypeUser}
 
 func (poe *PoeSetConfigCommand) Run(args *types.GlobalOptions) error {
-	model := args.Model
-	if len(model) == 0 {
-		var err error
-		model, _, err = common.ReadTokenAndModel2GlobalOptions(args, poe.Address)
-		if err != nil {
-			return err
-		}
-	}
-	args.Model = model // TODO: make the invariant of this variable consistent in the whole app
-
-	if common.IsModel30x(model) {
-		return poe.runPoeSetConfigGs30x(args)
-	}
-	if common.IsModel316(model) {
-		return poe.runPoeSetConfigGs316(args)
-	}
-
-	panic(fmt.Sprintf("model %s not supported", model))
+	ctx, cancel := args.Context()
+	defer cancel()
+	return poe.RunContext(ctx, args)
 }
 
-func (poe *PoeSetConfigCommand) runPoeSetConfigGs30x(args *types.GlobalOptions) error {
-	poeExt := &PoeExt{}
-	var adminMode string
-
-	currentPoeConfigs, err := requestPoeConfiguration(args, poe.Address, poeExt)
+// RunContext runs the command until it finishes or ctx is done
+func (poe *PoeSetConfigCommand) RunContext(ctx context.Context, args *types.GlobalOptions) error {
+	client, err := common.NewAuthenticatedClient(ctx, args, poe.Address)
 	if err != nil {
 		return err
 	}
-
-	for _, portId := range poe.Ports {
-		if portId > len(currentPoeConfigs) || portId < 1 {
-			return errors.New(fmt.Sprintf("given port id %d, doesn't fit in range 1..%d", portId, len(currentPoeConfigs)))
-		}
-
-		poeConfig := currentPoeConfigs[portId-1]
-
-		if poe.PortPwr == "enabled" || poe.PortPwr == "enable" {
-			adminMode = "1"
-		} else if poe.PortPwr == "disabled" || poe.PortPwr == "disable" {
-			adminMode = "0"
-		} else {
-			if poeConfig.PortPwr {
-				adminMode = "1"
-			} else {
-				adminMode = "0"
-			}
-		}
-
-		portPrio, err := comparePoeSettings(PortPrio, poeConfig.PortPrio, poe.PortPrio, poeExt)
-		if err != nil {
-			return err
-		}
-
-		pwrMode, err := comparePoeSettings(PwrMode, poeConfig.PwrMode, poe.PwrMode, poeExt)
-		if err != nil {
-			return err
-		}
-
-		pwrLimitType, err := comparePoeSettings(LimitType, poeConfig.LimitType, poe.LimitType, poeExt)
-		if err != nil {
-			return err
-		}
-
-		pwrLimit, err := comparePoeSettings(PwrLimit, poeConfig.PwrLimit, poe.PwrLimit, poeExt)
-		if err != nil {
-			return err
-		}
-
-		detecType, err := comparePoeSettings(DetecType, poeConfig.DetecType, poe.DetecType, poeExt)
-		if err != nil {
-			return err
-		}
-
-		longerDetect, err := comparePoeSettings(LongerDetect, poeConfig.LongerDetect, poe.LongerDetect, poeExt)
-
-		poeSettings := url.Values{
-			"hash":           {poeExt.Hash},
-			"ACTION":         {"Apply"},
-			"portID":         {strconv.Itoa(int(portId - 1))},
-			"ADMIN_MODE":     {adminMode},
-			"PORT_PRIO":      {portPrio},
-			"POW_MOD":        {pwrMode},
-			"POW_LIMT_TYP":   {pwrLimitType},
-			"POW_LIMT":       {pwrLimit},
-			"DETEC_TYP":      {detecType},
-			"DISCONNECT_TYP": {longerDetect},
-		}
-
-		result, err := requestPoeSettingsUpdate(args, poe.Address, poeSettings.Encode())
-		if err != nil {
-			return err
-		}
-
-		if result != "SUCCESS" {
-			return errors.New(result)
-		}
+	if err := common.CheckPorts(args.Model, poe.Ports); err != nil {
+		return err
 	}
 
-	updatedPoeConfigs, err := requestPoeConfiguration(args, poe.Address, poeExt)
-	changedPorts := collectChangedPoePortConfiguration(poe.Ports, updatedPoeConfigs)
-	prettyPrintPoePortSettings(args.Model, args.OutputFormat, changedPorts)
-	return err
-}
-
-func (poe *PoeSetConfigCommand) runPoeSetConfigGs316(args *types.GlobalOptions) error {
-	_, token, err := common.ReadTokenAndModel2GlobalOptions(args, poe.Address)
+	update, err := poe.createPoePortUpdate()
 	if err != nil {
 		return err
 	}
-
+	var updates []netgear.POEPortUpdate
 	for _, portId := range poe.Ports {
-		if portId < 1 || portId > gs316NoPoePorts {
-			return errors.New(fmt.Sprintf("given port id %d, doesn't fit in range 1..%d", portId, gs316NoPoePorts))
-		}
-
-		newPoeConfig, err := poe.createPoeSetConfigPayloadGs316(token, portId)
-		if err != nil {
-			return err
-		}
-
-		urlStr := fmt.Sprintf("http://%s/iss/specific/poePortConf.html", poe.Address)
-		result, err := common.DoHttpRequestAndReadResponse(args, "POST", poe.Address, urlStr, newPoeConfig)
-		if err != nil {
-			return err
-		}
+		update.PortID = portId
+		updates = append(updates, update)
+	}
 
-		if result != "SUCCESS" {
-			return errors.New(result)
-		}
+	if err := client.POE().UpdatePort(ctx, updates...); err != nil {
+		return err
 	}
 
-	poeExt := &PoeExt{}
-	updatedPoeConf, err := requestPoeConfiguration(args, poe.Address, poeExt)
-	updatedPoeConf = common.Filter(updatedPoeConf, func(status PoePortSetting) bool {
-		return slices.Contains(poe.Ports, int(status.PortIndex))
-	})
-	prettyPrintPoePortSettings(args.Model, args.OutputFormat, updatedPoeConf)
-	return err
+	updatedPoeConfigs, err := requestPoeConfiguration(ctx, client)
+	if err != nil {
+		return fmt.Errorf("ports updated, but reading back their settings failed: %w", err)
+	}
+	changedPorts := collectChangedPoePortConfiguration(poe.Ports, updatedPoeConfigs)
+	prettyPrintPoePortSettings(args.OutputFormat, changedPorts)
+	return nil
 }
 
-func (poe *PoeSetConfigCommand) createPoeSetConfigPayloadGs316(token string, portId int) (string, error) {
-	// it seems the ORDER IS IMPORTANT, so we craft the payload by hand.
-	newPoeConfig := fmt.Sprintf("Gambit=%s&TYPE=%s&PORT_NO=%s", token, "submitPoe", strconv.Itoa(portId))
+// createPoePortUpdate turns the command's flags into a library update; flags
+// left empty keep the port's current value
+func (poe *PoeSetConfigCommand) createPoePortUpdate() (netgear.POEPortUpdate, error) {
+	update := netgear.POEPortUpdate{}
+
+	switch strings.ToLower(poe.PortPwr) {
+	case "":
+	case "enable", "enabled":
+		update.Enabled = ptr(true)
+	case "disable", "disabled":
+		update.Enabled = ptr(false)
+	default:
+		return update, fmt.Errorf("power state %s not supported; allowed values: enable, disable", poe.PortPwr)
+	}
 
+	var err error
+	if update.Mode, err = parseChoice("power mode", poe.PwrMode, poeModes); err != nil {
+		return update, err
+	}
+	if update.Priority, err = parseChoice("port priority", poe.PortPrio, poePriorities); err != nil {
+		return update, err
+	}
 	if poe.PwrLimit != "" {
-		poe.LimitType = "user" // must be set, else nothing happens
 		pwrLimit, err := strconv.ParseFloat(poe.PwrLimit, 64)
 		if err != nil {
-			return "", fmt.Errorf("invalid power limit value: '%s', allowed are: 3.0, 3.2, 3.4, 3.6, and so on", poe.PwrLimit)
+			return update, fmt.Errorf("invalid power limit value: '%s', allowed are: 3.0, 3.2, 3.4, 3.6, and so on", poe.PwrLimit)
 		}
-		newPoeConfig += fmt.Sprintf("&POWER_LIMIT_VALUE=%s", strconv.Itoa(int(pwrLimit*10)))
-	} else {
-		newPoeConfig += fmt.Sprintf("&POWER_LIMIT_VALUE=%s", "NOTSET")
-	}
-
-	if poe.PortPrio != "" {
-		portPrio, err := mapPoePrioGs316(poe.PortPrio)
-		if err != nil {
-			return "", err
+		update.PowerLimitW = &pwrLimit
+		if poe.LimitType == "" {
+			poe.LimitType = string(netgear.POELimitTypeUser) // must be set, else nothing happens
 		}
-		newPoeConfig += fmt.Sprintf("&PRIORITY=%s", portPrio)
-	} else {
-		newPoeConfig += fmt.Sprintf("&PRIORITY=%s", "NOTSET")
 	}
-
-	if poe.PwrMode != "" {
-		pwerMode := bidiMapLookup(strings.ToLower(poe.PwrMode), pwrModeMap)
-		if pwerMode == unknown {
-			return "", errors.New(fmt.Sprintf("power mode %s not supported; allowed values: %s", poe.PwrMode, valuesAsString(pwrModeMap)))
-		}
-		newPoeConfig += fmt.Sprintf("&POWER_MODE=%s", pwerMode)
-	} else {
-		newPoeConfig += fmt.Sprintf("&POWER_MODE=%s", "NOTSET")
+	if update.PowerLimitType, err = parseChoice("limit type", poe.LimitType, poeLimitTypes); err != nil {
+		return update, err
 	}
-
-	if poe.LimitType != "" {
-		limitType := bidiMapLookup(poe.LimitType, limitTypeMap)
-		if limitType == unknown {
-			return "", errors.New(fmt.Sprintf("limit type %s not supported; allowed values: %s", poe.LimitType, valuesAsString(limitTypeMap)))
-		}
-		newPoeConfig += fmt.Sprintf("&POWER_LIMIT_TYPE=%s", limitType)
-	} else {
-		newPoeConfig += fmt.Sprintf("&POWER_LIMIT_TYPE=%s", "NOTSET")
-	}
-
 	if poe.DetecType != "" {
-		if poe.DetecType == "IEEE802" {
-			// the GS316EP series does not use a space,
-			// hence we created make it compatible to GS30x, by adding a space
-			poe.DetecType = "IEEE 802"
-		}
-		detecType := bidiMapLookup(poe.DetecType, detecTypeMap)
-		if detecType == unknown {
-			return "", errors.New(fmt.Sprintf("detection type %s not supported; allowed values: %s", poe.DetecType, valuesAsString(detecTypeMap)))
-		}
-		newPoeConfig += fmt.Sprintf("&DETECTION=%s", detecType)
-	} else {
-		newPoeConfig += fmt.Sprintf("&DETECTION=%s", "NOTSET")
+		update.DetectionType = &poe.DetecType
+	}
+	switch strings.ToLower(poe.LongerDetect) {
+	case "":
+	case "enable", "enabled":
+		update.LongerDetectionTime = ptr(true)
+	case "disable", "disabled":
+		update.LongerDetectionTime = ptr(false)
+	default:
+		return update, fmt.Errorf("longer detection time %s not supported; allowed values: disable, enable", poe.LongerDetect)
 	}
+	return update, nil
+}
 
-	if poe.PortPwr != "" {
-		adminState := "0"
-		if strings.Contains(strings.ToLower(poe.PortPwr), "enable") {
-			adminState = "1"
-		}
-		newPoeConfig += fmt.Sprintf("&ADMIN_STATE=%s", adminState)
-	} else {
-		newPoeConfig += fmt.Sprintf("&ADMIN_STATE=%s", "NOTSET")
+// parseChoice returns the allowed value matching text case-insensitively, or
+// nil when text is empty
+func parseChoice[T ~string](name string, text string, allowed []T) (*T, error) {
+	if text == "" {
+		return nil, nil
 	}
-
-	if poe.LongerDetect != "" {
-		disconnectType := bidiMapLookup(poe.LongerDetect, longerDetectMap)
-		if disconnectType == unknown {
-			return "", errors.New(fmt.Sprintf("detection type %s not supported; allowed values: %s", poe.LongerDetect, valuesAsString(longerDetectMap)))
+	var names []string
+	for _, value := range allowed {
+		if strings.EqualFold(string(value), text) {
+			return &value, nil
 		}
-		newPoeConfig += fmt.Sprintf("&DISCONNECT_TYPE=%s", disconnectType)
-	} else {
-		newPoeConfig += fmt.Sprintf("&DISCONNECT_TYPE=%s", "NOTSET")
+		names = append(names, string(value))
 	}
-	return newPoeConfig, nil
+	slices.Sort(names)
+	return nil, fmt.Errorf("%s %s not supported; allowed values: %s", name, text, strings.Join(names, ", "))
+}
+
+func ptr[T any](value T) *T {
+	return &value
 }
 
 func collectChangedPoePortConfiguration(poePorts []int, settings []PoePortSetting) (changedPorts []PoePortSetting) {
@@ -275,138 +148,3 @@ func collectChangedPoePortConfiguration(poePorts []int, settings []PoePortSettin
 
 	return changedPorts
 }
-
-func requestPoeConfiguration(args *types.GlobalOptions, host string, poeExt *PoeExt) ([]PoePortSetting, error) {
-
-	var settings []PoePortSetting
-
-	settingsPage, err := requestPoePortConfigPage(args, host)
-	if err != nil {
-		return settings, err
-	}
-
-	if common.CheckIsLoginRequired(settingsPage) {
-		return settings, errors.New("no content. please, (re-)login first")
-	}
-
-	settings, err = findPoePortConfInHtml(args.Model, strings.NewReader(settingsPage))
-	if err != nil {
-		return settings, err
-	}
-
-	poeExt.Hash, err = findHashInHtml(args.Model, strings.NewReader(settingsPage))
-	if err != nil {
-		return settings, err
-	}
-
-	poeExt.PortMaxPower, err = findMaxPwrLimitInHtml(args.Model, strings.NewReader(settingsPage))
-	if err != nil {
-		return settings, err
-	}
-
-	return settings, nil
-}
-
-func requestPoeSettingsUpdate(args *types.GlobalOptions, host string, data string) (string, error) {
-	url := fmt.Sprintf("http://%s/PoEPortConfig.cgi", host)
-	return common.DoHttpRequestAndReadResponse(args, "POST", host, url, data)
-}
-
-func findHashInHtml(model types.NetgearModel, reader io.Reader) (string, error) {
-	if common.IsModel316(model) {
-		// no hash present
-		return "", nil
-	}
-
-	doc, err := goquery.NewDocumentFromReader(reader)
-	if err != nil {
-		return "", err
-	}
-
-	hash, exists := doc.Find("input#hash").Attr("value")
-	if !exists {
-		return "", errors.New("could not find hash")
-	}
-	return hash, err
-}
-
-func findMaxPwrLimitInHtml(model types.NetgearModel, reader io.Reader) (string, error) {
-	if common.IsModel316(model) {
-		return "", nil
-	}
-	doc, err := goquery.NewDocumentFromReader(reader)
-	if err != nil {
-		return "", err
-	}
-
-	limit, exists := doc.Find("input.pwrLimit").Attr("value")
-	if !exists {
-		return "", errors.New("could not find power limit")
-	}
-	return limit, err
-}
-
-func comparePoeSettings(name PoeSettingKey, defaultValue string, newValue string, poeExt *PoeExt) (string, error) {
-	if len(newValue) == 0 {
-		return defaultValue, nil
-	}
-
-	switch name {
-	case PortPrio:
-		portPrio := bidiMapLookup(newValue, portPrioMap)
-		if portPrio == unknown {
-			return portPrio, errors.New("port priority could not be set. Accepted values are: " + valuesAsString(portPrioMap))
-		}
-		return portPrio, nil
-	case PwrMode:
-		pwrMode := bidiMapLookup(newValue, pwrModeMap)
-		if pwrMode == unknown {
-			return pwrMode, errors.New("power mode could not be set. Accepted values are: " + valuesAsString(pwrModeMap))
-		}
-		return pwrMode, nil
-	case LimitType:
-		limitType := bidiMapLookup(newValue, limitTypeMap)
-		if limitType == unknown {
-			return limitType, errors.New("limit type could not be set. Accepted values are: " + valuesAsString(limitTypeMap))
-		}
-		return limitType, nil
-	case PwrLimit:
-		if defaultValue != newValue {
-			value, err := strconv.Atoi(strings.Replace(newValue, ".", "", -1))
-			if err != nil {
-				return defaultValue, errors.New("unable to check power limit")
-			}
-
-			limit, err := strconv.Atoi(strings.Replace(poeExt.PortMaxPower, ".", "", -1))
-			if err != nil {
-				return defaultValue, errors.New("unable to check power limit")
-			}
-
-			if value < 100 {
-				value = value * 10
-			}
-
-			if value > limit || value < 30 {
-				return defaultValue, errors.New(fmt.Sprintf("provided power limit (W) is out of range. Minimum: %s <> Maximum: %s", "3.0", poeExt.PortMaxPower))
-			}
-
-			return newValue, nil
-		}
-		return defaultValue, nil
-	case DetecType:
-		detecType := bidiMapLookup(newValue, detecTypeMap)
-		if detecType == unknown {
-			return detecType, errors.New("detection type could not be set. Accepted values are: " + valuesAsString(detecTypeMap))
-		}
-		return detecType, nil
-	case LongerDetect:
-		longerDetect := bidiMapLookup(newValue, longerDetectMap)
-		if longerDetect == unknown {
-			return longerDetect, errors.New("longer detection type value could not be set. Accepted values are: " + valuesAsString(longerDetectMap))
-		}
-		return longerDetect, nil
-	default:
-		return defaultValue, errors.New("could not find port setting")
-	}
-
-}
@@ -4,17 +4,11 @@ import (
 	"github.com/gherlein/go-netgear/internal/formatter"
 	"github.com/gherlein/go-netgear/internal/common"
 	"github.com/gherlein/go-netgear/internal/types"
-	"errors"
+	"github.com/gherlein/go-netgear/pkg/netgear"
+	"context"
 	"fmt"
-	"io"
-	"strconv"
-	"strings"
-
-	"github.com/PuerkitoBio/goquery"
 )
 
-const gs316NoPoePorts = 15
-
 type PoePortSetting struct {
 	PortIndex    int8
 	PortName     string
@@ -32,33 +26,48 @@ type PoeShowSettingsCommand struct {
 }
 
 func (poe *PoeShowSettingsCommand) Run(args *types.GlobalOptions) error {
-	model := args.Model
-	if len(model) == 0 {
-		var err error
-		model, _, err = common.ReadTokenAndModel2GlobalOptions(args, poe.Address)
-		if err != nil {
-			return err
-		}
-	}
-	args.Model = model // TODO: make the invariant of this variable consistent in the whole app
+	ctx, cancel := args.Context()
+	defer cancel()
+	return poe.RunContext(ctx, args)
+}
 
-	confPage, err := requestPoePortConfigPage(args, poe.Address)
+// RunContext runs the command until it finishes or ctx is done
+func (poe *PoeShowSettingsCommand) RunContext(ctx context.Context, args *types.GlobalOptions) error {
+	client, err := common.NewAuthenticatedClient(ctx, args, poe.Address)
 	if err != nil {
 		return err
 	}
-	if common.CheckIsLoginRequired(confPage) {
-		return errors.New("no content. please, (re-)login first")
-	}
-	var settings []PoePortSetting
-	settings, err = findPoePortConfInHtml(args.Model, strings.NewReader(confPage))
+	settings, err := requestPoeConfiguration(ctx, client)
 	if err != nil {
 		return err
 	}
-	prettyPrintPoePortSettings(args.Model, args.OutputFormat, settings)
+	prettyPrintPoePortSettings(args.OutputFormat, settings)
 	return nil
 }
 
-func prettyPrintPoePortSettings(model types.NetgearModel, format formatter.OutputFormat, settings []PoePortSetting) {
+func requestPoeConfiguration(ctx context.Context, client *netgear.Client) ([]PoePortSetting, error) {
+	settings, err := client.POE().GetSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]PoePortSetting, 0, len(settings))
+	for _, setting := range settings {
+		result = append(result, PoePortSetting{
+			PortIndex:    int8(setting.PortID),
+			PortName:     setting.PortName,
+			PortPwr:      setting.Enabled,
+			PwrMode:      string(setting.Mode),
+			PortPrio:     string(setting.Priority),
+			LimitType:    string(setting.PowerLimitType),
+			PwrLimit:     fmt.Sprintf("%.1f", setting.PowerLimitW),
+			DetecType:    setting.DetectionType,
+			LongerDetect: asTextEnabled(setting.LongerDetectionTime),
+		})
+	}
+	return result, nil
+}
+
+func prettyPrintPoePortSettings(format formatter.OutputFormat, settings []PoePortSetting) {
 	var header = []string{"Port ID", "Port Name", "Port Power", "Mode", "Priority", "Limit Type", "Limit (W)", "Type", "Longer Detection Time"}
 	var content [][]string
 	for _, setting := range settings {
@@ -66,41 +75,16 @@ func prettyPrintPoePortSettings(model types.NetgearModel, format formatter.Outpu
 		row = append(row, fmt.Sprintf("%d", setting.PortIndex))
 		row = append(row, setting.PortName)
 		row = append(row, asTextPortPower(setting.PortPwr))
-		if common.IsModel316(model) {
-			row = append(row, setting.PwrMode)
-		} else {
-			row = append(row, bidiMapLookup(setting.PwrMode, pwrModeMap))
-		}
-		if common.IsModel316(model) {
-			row = append(row, setting.PortPrio)
-		} else {
-			row = append(row, bidiMapLookup(setting.PortPrio, portPrioMap))
-		}
-		if common.IsModel316(model) {
-			row = append(row, setting.LimitType)
-		} else {
-			row = append(row, bidiMapLookup(setting.LimitType, limitTypeMap))
-		}
+		row = append(row, setting.PwrMode)
+		row = append(row, setting.PortPrio)
+		row = append(row, setting.LimitType)
 		row = append(row, setting.PwrLimit)
-		if common.IsModel316(model) {
-			row = append(row, setting.DetecType)
-		} else {
-			row = append(row, bidiMapLookup(setting.DetecType, detecTypeMap))
-		}
-		if common.IsModel316(model) {
-			row = append(row, setting.LongerDetect)
-		} else {
-			row = append(row, bidiMapLookup(setting.LongerDetect, longerDetectMap))
-		}
+		row = append(row, setting.DetecType)
+		row = append(row, setting.LongerDetect)
 		content = append(content, row)
 	}
-	switch format {
-	case formatter.MarkdownFormat:
-		formatter.PrintMarkdownTable(header, content)
-	case formatter.JsonFormat:
-		formatter.PrintJsonDataTable("poe_settings", header, content)
-	default:
-		panic("not implemented format: " + format)
+	if err := formatter.PrintDataTable(format, "poe_settings", header, content); err != nil {
+		panic(err.Error())
 	}
 }
 
@@ -111,73 +95,9 @@ func asTextPortPower(portPwr bool) string {
 	return "disabled"
 }
 
-func requestPoePortConfigPage(args *types.GlobalOptions, host string) (string, error) {
-	if common.IsModel30x(args.Model) {
-		url := fmt.Sprintf("http://%s/PoEPortConfig.cgi", host)
-		return common.RequestPage(args, host, url)
+func asTextEnabled(enabled bool) string {
+	if enabled {
+		return "enable"
 	}
-	if common.IsModel316(args.Model) {
-		url := fmt.Sprintf("http://%s/iss/specific/poePortConf.html", host)
-		return common.RequestPage(args, host, url)
-	}
-	panic(fmt.Sprintf("model '%s' not supported", args.Model))
-}
-
-func findPoePortConfInHtml(model types.NetgearModel, reader io.Reader) ([]PoePortSetting, error) {
-	if common.IsModel30x(model) {
-		return findPortPortConfInHtmlGs30x(reader)
-	}
-	if common.IsModel316(model) {
-		return findPortPortConfInHtmlGs316(reader)
-	}
-	panic("model not supported")
-}
-
-func findPortPortConfInHtmlGs30x(reader io.Reader) ([]PoePortSetting, error) {
-	doc, err := goquery.NewDocumentFromReader(reader)
-	if err != nil {
-		return nil, err
-	}
-
-	var configs []PoePortSetting
-	doc.Find("li.poePortSettingListItem").Each(func(i int, s *goquery.Selection) {
-		config := PoePortSetting{}
-		id, _ := s.Find("input[type=hidden].port").Attr("value")
-		var id64, _ = strconv.ParseInt(id, 10, 8)
-		config.PortIndex = int8(id64)
-		config.PortName, _ = s.Find("input[type=hidden].portName").Attr("value")
-		portWr, exists := s.Find("input#hidPortPwr").Attr("value")
-		config.PortPwr = exists && portWr == "1"
-		config.PwrMode, _ = s.Find("input#hidPwrMode").Attr("value")
-		config.PortPrio, _ = s.Find("input#hidPortPrio").Attr("value")
-		config.LimitType, _ = s.Find("input#hidLimitType").Attr("value")
-		config.PwrLimit, _ = s.Find("input.pwrLimit").Attr("value")
-		config.DetecType, _ = s.Find("input#hidDetecType").Attr("value")
-		config.LongerDetect, _ = s.Find("input.longerDetect").Attr("value")
-		configs = append(configs, config)
-	})
-	return configs, nil
-}
-
-func findPortPortConfInHtmlGs316(reader io.Reader) ([]PoePortSetting, error) {
-	doc, err := goquery.NewDocumentFromReader(reader)
-	if err != nil {
-		return nil, err
-	}
-
-	var configs []PoePortSetting
-	doc.Find("div#POE_SETTING div.port-wrap").Each(func(i int, s *goquery.Selection) {
-		config := PoePortSetting{}
-		idAndName := strings.TrimSpace(s.Find("span.port-number").Text())
-		config.PortIndex, config.PortName = parsePortIdAndName(idAndName)
-		config.PortPwr = strings.ToLower(s.Find("span.admin-state").Text()) == "enable"
-		config.PwrMode = s.Find("span.Power-Mode-text").Text()
-		config.PortPrio = s.Find("p.port-priority").Text()
-		config.LimitType = s.Find("p.Power-Limit-Type-text").Text()
-		config.PwrLimit = s.Find("p.Power-Limit-text").Text()
-		config.DetecType = s.Find("p.Detection-Type-text").Text()
-		config.LongerDetect = s.Find("p.Longer-Detection-text").Text()
-		configs = append(configs, config)
-	})
-	return configs, nil
+	return "disable"
 }
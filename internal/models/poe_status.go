@@ -1,15 +1,13 @@
 package models
 
 import (
-	"errors"
+	"context"
 	"fmt"
-	"github.com/PuerkitoBio/goquery"
-	"io"
-	"strconv"
-	"strings"
+	"math"
 	"github.com/gherlein/go-netgear/internal/common"
 	"github.com/gherlein/go-netgear/internal/formatter"
 	"github.com/gherlein/go-netgear/internal/types"
+	"github.com/gherlein/go-netgear/pkg/netgear"
 )
 
 type PoePortStatus struct {
@@ -36,7 +34,18 @@ type PoeStatusCommand struct {
 }
 
 func (poe *PoeStatusCommand) Run(args *types.GlobalOptions) error {
-	statuses, err := requestPoeStatus(args, poe.Address)
+	ctx, cancel := args.Context()
+	defer cancel()
+	return poe.RunContext(ctx, args)
+}
+
+// RunContext runs the command until it finishes or ctx is done
+func (poe *PoeStatusCommand) RunContext(ctx context.Context, args *types.GlobalOptions) error {
+	client, err := common.NewAuthenticatedClient(ctx, args, poe.Address)
+	if err != nil {
+		return err
+	}
+	statuses, err := requestPoeStatus(ctx, client)
 	if err != nil {
 		return err
 	}
@@ -45,18 +54,24 @@ func (poe *PoeStatusCommand) Run(args *types.GlobalOptions) error {
 
 }
 
-func requestPoeStatus(args *types.GlobalOptions, address string) ([]PoePortStatus, error) {
-	var result []PoePortStatus
-	statusPage, err := requestPoePortStatusPage(args, address)
+func requestPoeStatus(ctx context.Context, client *netgear.Client) ([]PoePortStatus, error) {
+	statuses, err := client.POE().GetStatus(ctx)
 	if err != nil {
-		return result, err
-	}
-	if common.CheckIsLoginRequired(statusPage) {
-		return result, errors.New("no content. please, (re-)login first")
+		return nil, err
 	}
-	result, err = findPortStatusInHtml(args.Model, strings.NewReader(statusPage))
-	if err != nil {
-		return result, err
+	result := make([]PoePortStatus, 0, len(statuses))
+	for _, status := range statuses {
+		result = append(result, PoePortStatus{
+			PortIndex:            int8(status.PortID),
+			PortName:             status.PortName,
+			PoePowerClass:        status.PowerClass,
+			PoePortStatus:        status.Status,
+			ErrorStatus:          status.ErrorStatus,
+			VoltageInVolt:        int32(math.Round(status.VoltageV)),
+			CurrentInMilliAmps:   int32(math.Round(status.CurrentMA)),
+			PowerInWatt:          float32(status.PowerW),
+			TemperatureInCelsius: int32(math.Round(status.TemperatureC)),
+		})
 	}
 	return result, nil
 }
@@ -77,125 +92,7 @@ func prettyPrintPoePortStatus(format formatter.OutputFormat, statuses []PoePortS
 		row = append(row, status.ErrorStatus)
 		content = append(content, row)
 	}
-	switch format {
-	case formatter.MarkdownFormat:
-		formatter.PrintMarkdownTable(header, content)
-	case formatter.JsonFormat:
-		formatter.PrintJsonDataTable("poe_status", header, content)
-	default:
-		panic("not implemented format: " + format)
-	}
-}
-
-func requestPoePortStatusPage(args *types.GlobalOptions, host string) (string, error) {
-	model, _, err := common.ReadTokenAndModel2GlobalOptions(args, host)
-	if err != nil {
-		return "", err
-	}
-	if common.IsModel30x(model) {
-		url := fmt.Sprintf("http://%s/getPoePortStatus.cgi", host)
-		return common.RequestPage(args, host, url)
-	}
-	if common.IsModel316(model) {
-		url := fmt.Sprintf("http://%s/iss/specific/poePortStatus.html?GetData=TRUE", host)
-		return common.RequestPage(args, host, url)
-	}
-	panic("model not supported")
-}
-
-func findPortStatusInHtml(model types.NetgearModel, reader io.Reader) ([]PoePortStatus, error) {
-	if common.IsModel30x(model) {
-		return findPortStatusInGs30xEPxHtml(reader)
-	}
-	if common.IsModel316(model) {
-		return findPortStatusInGs316EPxHtml(reader)
+	if err := formatter.PrintDataTable(format, "poe_status", header, content); err != nil {
+		panic(err.Error())
 	}
-	panic("model not supported")
-}
-
-func findPortStatusInGs30xEPxHtml(reader io.Reader) ([]PoePortStatus, error) {
-	doc, err := goquery.NewDocumentFromReader(reader)
-	if err != nil {
-		return nil, err
-	}
-
-	var statuses []PoePortStatus
-	doc.Find("li.poePortStatusListItem").Each(func(i int, s *goquery.Selection) {
-		stat := PoePortStatus{}
-
-		id, _ := s.Find("input[type=hidden].port").Attr("value")
-		var id64, _ = strconv.ParseInt(id, 10, 8)
-		stat.PortIndex = int8(id64)
-
-		portData := s.Find("span.poe-port-index span").Text()
-		_, stat.PortName = parsePortIdAndName(portData)
-
-		stat.PoePortStatus = s.Find("span.poe-power-mode span").Text()
-		powerClassText := s.Find("span.poe-portPwr-width span").Text()
-		stat.PoePowerClass = getPowerClassFromI18nString(powerClassText)
-
-		s.Find("div.poe_port_status div div span").Each(func(i int, s *goquery.Selection) {
-			switch i {
-			case 1:
-				stat.VoltageInVolt = common.ParseInt32(s.Text())
-			case 3:
-				stat.CurrentInMilliAmps = common.ParseInt32(s.Text())
-			case 5:
-				stat.PowerInWatt = common.ParseFloat32(s.Text())
-			case 7:
-				stat.TemperatureInCelsius = common.ParseInt32(s.Text())
-			case 9:
-				stat.ErrorStatus = strings.TrimSpace(s.Text())
-			}
-		})
-		statuses = append(statuses, stat)
-	})
-
-	return statuses, nil
-}
-
-func findPortStatusInGs316EPxHtml(reader io.Reader) ([]PoePortStatus, error) {
-	doc, err := goquery.NewDocumentFromReader(reader)
-	if err != nil {
-		return nil, err
-	}
-
-	var statuses []PoePortStatus
-	doc.Find("div.port-wrap").Each(func(i int, s *goquery.Selection) {
-		stat := PoePortStatus{}
-
-		stat.PortIndex, stat.PortName = parsePortIdAndName(s.Find("span.port-number").Text())
-		stat.PoePortStatus = s.Find("span.Status-text").Text()
-		stat.PoePowerClass = getPowerClassFromI18nString(s.Find("span.Class-text").Text())
-		stat.VoltageInVolt = common.ParseInt32(s.Find("p.OutputVoltage-text").Text())
-		stat.CurrentInMilliAmps = common.ParseInt32(s.Find("p.OutputCurrent-text").Text())
-		stat.PowerInWatt = common.ParseFloat32(s.Find("p.OutputPower-text").Text())
-		stat.TemperatureInCelsius = common.ParseInt32(s.Find("p.Temperature-text").Text())
-		stat.ErrorStatus = s.Find("p.Fault-Status-text").Text()
-		statuses = append(statuses, stat)
-	})
-
-	return statuses, nil
-}
-
-// getPowerClassFromI18nString parses the POE power class from a string, like e.g. "ml003@0@"
-func getPowerClassFromI18nString(class string) string {
-	split := strings.Split(class, "@")
-	if len(split) > 1 {
-		return split[1]
-	}
-	return ""
-}
-
-// parsePortIdAndName parses the port number and port name on the status page
-func parsePortIdAndName(str string) (int8, string) {
-	str = strings.ReplaceAll(str, "\u00a0", " ")
-	index := strings.Index(str, " - ")
-	if index >= 0 {
-		portId, _ := strconv.ParseInt(str[:index], 10, 8)
-		return int8(portId), strings.TrimSpace(str[index+3:])
-	}
-
-	portId, _ := strconv.ParseInt(str, 10, 8)
-	return int8(portId), ""
 }
@@ -3,25 +3,13 @@ package models
 import (
 	"github.com/gherlein/go-netgear/internal/common"
 	"github.com/gherlein/go-netgear/internal/types"
+	"github.com/gherlein/go-netgear/pkg/netgear"
+	"context"
 	"errors"
 	"fmt"
-	"net/url"
-	"slices"
-	"strconv"
 	"strings"
 )
 
-type PortSettingKey string
-
-const (
-	Index            PortSettingKey = "Index"
-	Name             PortSettingKey = "Name"
-	Speed            PortSettingKey = "Speed"
-	IngressRateLimit PortSettingKey = "IngressRateLimit"
-	EgressRateLimit  PortSettingKey = "EgressRateLimit"
-	FlowControl      PortSettingKey = "FlowControl"
-)
-
 type PortSetting struct {
 	Index            int8
 	Name             string
@@ -45,232 +33,83 @@ type PortSetCommand struct {
 }
 
 func (portSet *PortSetCommand) Run(args *types.GlobalOptions) error {
-	model := args.Model
-	if len(model) == 0 {
-		var err error
-		model, err = DetectNetgearModel(args, portSet.Address)
-		if err != nil {
-			return err
-		}
-		args.Model = model
-
-	}
-	if common.IsModel30x(model) {
-		return portSet.runPortSetGs30xEPx(args)
-	}
-	if common.IsModel316(model) {
-		return portSet.runPortSetGs316EPx(args)
-	}
-	panic(fmt.Sprintf("model '%s' not supported", model))
+	ctx, cancel := args.Context()
+	defer cancel()
+	return portSet.RunContext(ctx, args)
 }
 
-func (portSet *PortSetCommand) runPortSetGs30xEPx(args *types.GlobalOptions) error {
-	settings, hash, err := requestPortSettings(args, portSet.Address)
+// RunContext runs the command until it finishes or ctx is done
+func (portSet *PortSetCommand) RunContext(ctx context.Context, args *types.GlobalOptions) error {
+	client, err := common.NewAuthenticatedClient(ctx, args, portSet.Address)
 	if err != nil {
 		return err
 	}
-
-	for _, switchPort := range portSet.Ports {
-
-		if switchPort > len(settings) || switchPort < 1 {
-			return errors.New(fmt.Sprintf("given port id %d, doesn't fit in range 1..%d", switchPort, len(settings)))
-		}
-
-		portSetting := settings[switchPort-1]
-
-		// If the port name was not set by the user, set it to the existing name (otherwise an empty port name is always considered to be the
-		// "new" value which blanks the port name on the setting next update)
-		if portSet.Name == nil {
-			portSet.Name = &portSetting.Name
-		}
-
-		name, err := comparePortSettings(Name, portSetting.Name, *portSet.Name)
-		if err != nil {
-			return err
-		}
-
-		speed, err := comparePortSettings(Speed, portSetting.Speed, portSet.Speed)
-		if err != nil {
-			return err
-		}
-
-		ingressRateLimit, err := comparePortSettings(IngressRateLimit, portSetting.IngressRateLimit, portSet.IngressRateLimit)
-		if err != nil {
-			return err
-		}
-
-		egressRateLimit, err := comparePortSettings(EgressRateLimit, portSetting.EgressRateLimit, portSet.EgressRateLimit)
-		if err != nil {
-			return err
-		}
-
-		flowControl, err := comparePortSettings(FlowControl, portSetting.FlowControl, portSet.FlowControl)
-		if err != nil {
-			return err
-		}
-
-		portUpdateValues := url.Values{
-			"hash": {hash},
-			fmt.Sprintf("%s%d", "port", portSetting.Index): {"checked"},
-			"SPEED":        {speed},
-			"FLOW_CONTROL": {flowControl},
-			"DESCRIPTION":  {name},
-			"IngressRate":  {ingressRateLimit},
-			"EgressRate":   {egressRateLimit},
-			"priority":     {"0"},
-		}
-
-		requestUrl := fmt.Sprintf("http://%s/port_status.cgi", portSet.Address)
-		result, err := common.DoHttpRequestAndReadResponse(args, "POST", portSet.Address, requestUrl, portUpdateValues.Encode())
-		if err != nil {
-			return err
-		}
-
-		if result != "SUCCESS" {
-			return errors.New(result)
-		}
-	}
-
-	settings, _, err = requestPortSettings(args, portSet.Address)
-	if err != nil {
+	if err := common.CheckPorts(args.Model, portSet.Ports); err != nil {
 		return err
 	}
 
-	changedPorts := collectChangedPortConfiguration(portSet.Ports, settings)
-	prettyPrintPortSettings(args.Model, args.OutputFormat, changedPorts)
-
-	return err
-}
-
-func (portSet *PortSetCommand) runPortSetGs316EPx(args *types.GlobalOptions) (err error) {
-	_, token, err := common.ReadTokenAndModel2GlobalOptions(args, portSet.Address)
+	update, err := portSet.createPortUpdate()
 	if err != nil {
 		return err
 	}
-
-	currentSettings, _, err := requestPortSettings(args, portSet.Address)
-	if err != nil {
-		return err
-	}
-
+	var updates []netgear.PortUpdate
 	for _, portId := range portSet.Ports {
-		const gs316MaxPorts = 16
-		if portId < 1 || portId > gs316MaxPorts {
-			return errors.New(fmt.Sprintf("given port id %d, doesn't fit in range 1..%d", portId, gs316MaxPorts))
-		}
-
-		currentSetting := currentSettings[portId-1]
-
-		newSetting, err := createPortSettingUpdatePayloadGs316ep(portSet, currentSetting, token, strconv.Itoa(portId))
-		if err != nil {
-			return err
-		}
-
-		requestUrl := fmt.Sprintf("http://%s/iss/specific/dashboard.html", portSet.Address)
-		result, err := common.DoHttpRequestAndReadResponse(args, "POST", portSet.Address, requestUrl, newSetting.Encode())
-		if err != nil {
-			return err
-		}
+		update.PortID = portId
+		updates = append(updates, update)
+	}
 
-		if result != "SUCCESS" {
-			return errors.New(result)
-		}
+	if err := client.Ports().UpdatePort(ctx, updates...); err != nil {
+		return err
 	}
 
-	updatedSettings, _, err := requestPortSettings(args, portSet.Address)
+	settings, err := requestPortSettings(ctx, client)
 	if err != nil {
 		return err
 	}
 
-	updatedSettings = common.Filter(updatedSettings, func(status PortSetting) bool {
-		return slices.Contains(portSet.Ports, int(status.Index))
-	})
-	prettyPrintPortSettings(args.Model, args.OutputFormat, updatedSettings)
+	changedPorts := collectChangedPortConfiguration(portSet.Ports, settings)
+	prettyPrintPortSettings(args.OutputFormat, changedPorts)
 
 	return err
 }
 
-func createPortSettingUpdatePayloadGs316ep(portSet *PortSetCommand, currentSetting PortSetting, token string, portId string) (url.Values, error) {
-	// If the port name was not set by the user, set it to the existing name (otherwise an empty port name is always considered to be the
-	// "new" value which blanks the port name on the setting next update)
-	if portSet.Name == nil {
-		portSet.Name = &currentSetting.Name
-	}
-
-	newSetting := url.Values{
-		"Gambit":    {token},
-		"TYPE":      {"portInfo"},
-		"PORT_NO":   {portId},
-		"PORT_NAME": {*portSet.Name},
-		// default values, for all requests (not entirely sure about the meaning)
-		"COLOR1G":    {"NOTSET"},
-		"COLOR100M":  {"NOTSET"},
-		"FREQUENCY":  {"-1"},
-		"BRIGHTNESS": {"undefined"},
-		"STATUS":     {"0"},
-	}
+// createPortUpdate turns the command's flags into a library update; flags
+// left empty keep the port's current value
+func (portSet *PortSetCommand) createPortUpdate() (netgear.PortUpdate, error) {
+	update := netgear.PortUpdate{}
 
-	if portSet.IngressRateLimit != "" {
-		newVal := bidiMapLookup(portSet.IngressRateLimit, portRateLimitMap)
-		if newVal == unknown {
-			return nil, errors.New(fmt.Sprintf("port ingres setting '%s' could not be set. Accepted values are: %s", portSet.IngressRateLimit, valuesAsString(portRateLimitMap)))
+	if portSet.Name != nil {
+		if len(*portSet.Name) > 16 {
+			return update, errors.New("port name could not be set. PortSetting name must be 16 characters or less")
 		}
-		newSetting.Add("INGRESS", newVal)
-	} else {
-		newSetting.Add("INGRESS", "NOTSET")
+		update.Name = portSet.Name
 	}
 
-	if portSet.EgressRateLimit != "" {
-		newVal := bidiMapLookup(portSet.EgressRateLimit, portRateLimitMap)
-		if newVal == unknown {
-			return nil, errors.New(fmt.Sprintf("port egress setting '%s' could not be set. Accepted values are: %s", portSet.EgressRateLimit, valuesAsString(portRateLimitMap)))
+	if portSet.Speed != "" {
+		speed, err := netgear.ParsePortSpeed(portSet.Speed)
+		if err != nil {
+			return update, fmt.Errorf("port speed setting '%s' could not be set: %w", portSet.Speed, err)
 		}
-		newSetting.Add("EGRESS", newVal)
-	} else {
-		newSetting.Add("EGRESS", "NOTSET")
+		update.Speed = &speed
 	}
 
-	if portSet.FlowControl != "" {
-		flowControlValue := "4"
-		if strings.ToLower(portSet.FlowControl) == "off" {
-			flowControlValue = "1"
-		}
-		newSetting.Add("FLOW_CONTROL", flowControlValue)
-	} else {
-		newSetting.Add("FLOW_CONTROL", "NOTSET")
+	if portSet.IngressRateLimit != "" {
+		update.IngressLimit = &portSet.IngressRateLimit
+	}
+	if portSet.EgressRateLimit != "" {
+		update.EgressLimit = &portSet.EgressRateLimit
 	}
 
-	if portSet.Speed != "" {
-		switch portSet.Speed {
-		case portSpeedAuto:
-			newSetting.Add("PORT_CTRL_MODE", "1")
-		case portSpeedDisable:
-			newSetting.Add("PORT_CTRL_MODE", "3")
-		case portSpeed10Mhalf:
-			newSetting.Add("PORT_CTRL_MODE", "2")
-			newSetting.Add("PORT_CTRL_SPEED", "1")
-			newSetting.Add("PORT_CTRL_DUPLEX", "2")
-		case portSpeed10Mfull:
-			newSetting.Add("PORT_CTRL_MODE", "2")
-			newSetting.Add("PORT_CTRL_SPEED", "1")
-			newSetting.Add("PORT_CTRL_DUPLEX", "1")
-		case portSpeed100Nhalf:
-			newSetting.Add("PORT_CTRL_MODE", "2")
-			newSetting.Add("PORT_CTRL_SPEED", "2")
-			newSetting.Add("PORT_CTRL_DUPLEX", "2")
-		case portSpeed100Mfull:
-			newSetting.Add("PORT_CTRL_MODE", "2")
-			newSetting.Add("PORT_CTRL_SPEED", "2")
-			newSetting.Add("PORT_CTRL_DUPLEX", "1")
-		default:
-			return nil, errors.New(fmt.Sprintf("port speed setting '%s' could not be set. Accepted values are: %s", portSet.Speed, valuesAsString(portSpeedMap)))
-		}
-	} else {
-		newSetting.Add("PORT_CTRL_MODE", "NOTSET")
-		newSetting.Add("PORT_CTRL_SPEED", "NOTSET")
-		newSetting.Add("PORT_CTRL_DUPLEX", "NOTSET")
+	switch strings.ToLower(portSet.FlowControl) {
+	case "":
+	case "on":
+		update.FlowControl = ptr(true)
+	case "off":
+		update.FlowControl = ptr(false)
+	default:
+		return update, errors.New("flow control could not be set. Accepted values are: Off, On")
 	}
-	return newSetting, nil
+	return update, nil
 }
 
 func collectChangedPortConfiguration(ports []int, settings []PortSetting) (changedPorts []PortSetting) {
@@ -284,48 +123,3 @@ func collectChangedPortConfiguration(ports []int, settings []PortSetting) (chang
 
 	return changedPorts
 }
-
-func comparePortSettings(name PortSettingKey, defaultValue string, newValue string) (string, error) {
-	if len(newValue) == 0 && name != Name {
-		return defaultValue, nil
-	}
-
-	switch name {
-	case Name:
-		if defaultValue != newValue {
-			if len(newValue) <= 16 {
-				return newValue, nil
-			} else {
-				return defaultValue, errors.New("port name could not be set. PortSetting name must be 16 characters or less")
-			}
-		}
-		return defaultValue, nil
-	case Speed:
-		speed := bidiMapLookup(newValue, portSpeedMap)
-		if speed == unknown {
-			return speed, errors.New("port speed could not be set. Accepted values are: " + valuesAsString(portSpeedMap))
-		}
-		return speed, nil
-	case IngressRateLimit:
-		inRateLimit := bidiMapLookup(newValue, portRateLimitMap)
-		if inRateLimit == unknown {
-			return inRateLimit, errors.New("ingress rate limit could not be set. Accepted values are: " + valuesAsString(portRateLimitMap))
-		}
-		return inRateLimit, nil
-	case EgressRateLimit:
-		outRateLimit := bidiMapLookup(newValue, portRateLimitMap)
-		if outRateLimit == unknown {
-			return outRateLimit, errors.New("egress rate limit could not be set. Accepted values are: " + valuesAsString(portRateLimitMap))
-		}
-		return outRateLimit, nil
-	case FlowControl:
-		flowControl := bidiMapLookup(newValue, portFlowControlMap)
-		if flowControl == unknown {
-			return flowControl, errors.New("flow control could not be set. Accepted values are: " + valuesAsString(portFlowControlMap))
-		}
-		return flowControl, nil
-	default:
-		return defaultValue, errors.New("could not find port setting")
-	}
-
-}
@@ -4,12 +4,9 @@ import (
 	"github.com/gherlein/go-netgear/internal/formatter"
 	"github.com/gherlein/go-netgear/internal/common"
 	"github.com/gherlein/go-netgear/internal/types"
-	"errors"
+	"github.com/gherlein/go-netgear/pkg/netgear"
+	"context"
 	"fmt"
-	"github.com/PuerkitoBio/goquery"
-	"io"
-	"strconv"
-	"strings"
 )
 
 type PortCommand struct {
@@ -22,53 +19,54 @@ type PortSettingsCommand struct {
 }
 
 func (port *PortSettingsCommand) Run(args *types.GlobalOptions) error {
-	settings, _, err := requestPortSettings(args, port.Address)
-	if err != nil {
-		return err
-	}
-	prettyPrintPortSettings(args.Model, args.OutputFormat, settings)
-	return nil
+	ctx, cancel := args.Context()
+	defer cancel()
+	return port.RunContext(ctx, args)
 }
 
-func requestPortSettings(args *types.GlobalOptions, host string) (portSettings []PortSetting, hash string, err error) {
-	model, _, err := common.ReadTokenAndModel2GlobalOptions(args, host)
+// RunContext runs the command until it finishes or ctx is done
+func (port *PortSettingsCommand) RunContext(ctx context.Context, args *types.GlobalOptions) error {
+	client, err := common.NewAuthenticatedClient(ctx, args, port.Address)
 	if err != nil {
-		return portSettings, hash, err
-	}
-
-	var requestUrl string
-	if common.IsModel30x(model) {
-		requestUrl = fmt.Sprintf("http://%s/dashboard.cgi", host)
-	} else if common.IsModel316(model) {
-		requestUrl = fmt.Sprintf("http://%s/iss/specific/dashboard.html", host)
-	} else {
-		panic("model not supported")
+		return err
 	}
-
-	dashboardData, err := common.RequestPage(args, host, requestUrl)
+	settings, err := requestPortSettings(ctx, client)
 	if err != nil {
-		return portSettings, hash, err
-	}
-
-	if common.CheckIsLoginRequired(dashboardData) {
-		return portSettings, hash, errors.New("no content. please, (re-)login first")
+		return err
 	}
+	prettyPrintPortSettings(args.OutputFormat, settings)
+	return nil
+}
 
-	hash, err = findHashInHtml(model, strings.NewReader(dashboardData))
+func requestPortSettings(ctx context.Context, client *netgear.Client) ([]PortSetting, error) {
+	settings, err := client.Ports().GetSettings(ctx)
 	if err != nil {
-		return portSettings, hash, err
+		return nil, err
 	}
-
-	portSettings, err = findPortSettingsInHtml(model, strings.NewReader(dashboardData))
-
-	if err != nil {
-		return portSettings, hash, err
+	portSettings := make([]PortSetting, 0, len(settings))
+	for _, setting := range settings {
+		portSettings = append(portSettings, PortSetting{
+			Index:            int8(setting.PortID),
+			Name:             setting.PortName,
+			Speed:            setting.Speed.String(),
+			IngressRateLimit: setting.IngressLimit,
+			EgressRateLimit:  setting.EgressLimit,
+			FlowControl:      asTextOnOff(setting.FlowControl),
+			LinkSpeed:        setting.LinkSpeed,
+			PortStatus:       setting.Status.String(),
+		})
 	}
+	return portSettings, nil
+}
 
-	return portSettings, hash, err
+func asTextOnOff(on bool) string {
+	if on {
+		return "On"
+	}
+	return "Off"
 }
 
-func prettyPrintPortSettings(model types.NetgearModel, format formatter.OutputFormat, settings []PortSetting) {
+func prettyPrintPortSettings(format formatter.OutputFormat, settings []PortSetting) {
 
 	var header = []string{"Port ID", "Port Name", "Speed", "Ingress Limit", "Egress Limit", "Flow Control", "Port Status", "Link Speed"}
 	var content [][]string
@@ -77,111 +75,16 @@ func prettyPrintPortSettings(model types.NetgearModel, format formatter.OutputFo
 		var row []string
 		row = append(row, fmt.Sprintf("%d", setting.Index))
 		row = append(row, setting.Name)
-		if common.IsModel30x(model) {
-			setting.Speed = bidiMapLookup(setting.Speed, portSpeedMap)
-		}
 		row = append(row, setting.Speed)
-		if common.IsModel30x(model) {
-			setting.IngressRateLimit = bidiMapLookup(setting.IngressRateLimit, portRateLimitMap)
-		}
 		row = append(row, setting.IngressRateLimit)
-		if common.IsModel30x(model) {
-			setting.EgressRateLimit = bidiMapLookup(setting.EgressRateLimit, portRateLimitMap)
-		}
 		row = append(row, setting.EgressRateLimit)
-		if common.IsModel30x(model) {
-			setting.FlowControl = bidiMapLookup(setting.FlowControl, portFlowControlMap)
-		}
 		row = append(row, setting.FlowControl)
 		row = append(row, setting.PortStatus)
 		row = append(row, setting.LinkSpeed)
 		content = append(content, row)
 	}
-	switch format {
-	case formatter.MarkdownFormat:
-		formatter.PrintMarkdownTable(header, content)
-	case formatter.JsonFormat:
-		formatter.PrintJsonDataTable("port_settings", header, content)
-	default:
-		panic("not implemented format: " + format)
-	}
-
-}
-
-func findPortSettingsInHtml(model types.NetgearModel, reader io.Reader) ([]PortSetting, error) {
-	if common.IsModel30x(model) {
-		return findPortSettingsInGs30xEPxHtml(reader)
-	}
-	if common.IsModel316(model) {
-		return findPortSettingsInGs316EPxHtml(reader)
+	if err := formatter.PrintDataTable(format, "port_settings", header, content); err != nil {
+		panic(err.Error())
 	}
-	panic("model not supported")
-}
-
-func findPortSettingsInGs30xEPxHtml(reader io.Reader) (ports []PortSetting, err error) {
-
-	doc, err := goquery.NewDocumentFromReader(reader)
-	if err != nil {
-		return ports, err
-	}
-
-	doc.Find("li.list_item").Each(func(i int, s *goquery.Selection) {
-		portCfg := PortSetting{}
-
-		id, _ := s.Find("input[type=hidden].port").Attr("value")
-		var id64, _ = strconv.ParseInt(id, 10, 8)
-		portCfg.Index = int8(id64)
-		portCfg.Name, _ = s.Find("input[type=hidden].portName").Attr("value")
-		portCfg.Speed, _ = s.Find("input[type=hidden].Speed").Attr("value")
-		portCfg.IngressRateLimit, _ = s.Find("input[type=hidden].ingressRate").Attr("value")
-		portCfg.EgressRateLimit, _ = s.Find("input[type=hidden].egressRate").Attr("value")
-		portCfg.FlowControl, _ = s.Find("input[type=hidden].flowCtr").Attr("value")
-		portCfg.LinkSpeed, _ = s.Find("input[type=hidden].LinkedSpeed").Attr("value")
-		portCfg.PortStatus = strings.TrimSpace(s.Find("span.pull-right").Text())
-		ports = append(ports, portCfg)
-	})
-
-	return ports, nil
-}
-
-func findPortSettingsInGs316EPxHtml(reader io.Reader) (ports []PortSetting, err error) {
-
-	doc, err := goquery.NewDocumentFromReader(reader)
-	if err != nil {
-		return ports, err
-	}
-
-	doc.Find("div.dashboard-port-status").Each(func(i int, s *goquery.Selection) {
-		s.Find("span.port-number").Each(func(i int, selection *goquery.Selection) {
-			ports = append(ports, PortSetting{})
-		})
-
-		s.Find("span.port-number").Each(func(i int, selection *goquery.Selection) {
-			var id64, _ = strconv.ParseInt(strings.TrimSpace(selection.Text()), 10, 8)
-			ports[i].Index = int8(id64)
-		})
-		s.Find("span.port-name span.name").Each(func(i int, selection *goquery.Selection) {
-			ports[i].Name = strings.TrimSpace(selection.Text())
-		})
-		s.Find("p.speed-text").Each(func(i int, selection *goquery.Selection) {
-			ports[i].Speed = strings.TrimSpace(selection.Text())
-		})
-		s.Find("p.ingress-text").Each(func(i int, selection *goquery.Selection) {
-			ports[i].IngressRateLimit = strings.TrimSpace(selection.Text())
-		})
-		s.Find("p.egress-text").Each(func(i int, selection *goquery.Selection) {
-			ports[i].EgressRateLimit = strings.TrimSpace(selection.Text())
-		})
-		s.Find("p.flow-text").Each(func(i int, selection *goquery.Selection) {
-			ports[i].FlowControl = strings.TrimSpace(selection.Text())
-		})
-		s.Find("span.status-on-port").Each(func(i int, selection *goquery.Selection) {
-			ports[i].PortStatus = strings.TrimSpace(selection.Text())
-		})
-		s.Find("p.link-speed-text").Each(func(i int, selection *goquery.Selection) {
-			ports[i].LinkSpeed = strings.TrimSpace(selection.Text())
-		})
-	})
 
-	return ports, nil
 }
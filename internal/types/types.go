@@ -1,19 +1,26 @@
 package types
 
 import (
+	"context"
+	"os"
+	"os/signal"
+	"time"
+
 	"github.com/gherlein/go-netgear/internal/formatter"
+	"github.com/gherlein/go-netgear/pkg/netgear"
 )
 
-type NetgearModel string
+// NetgearModel is the library's model type, so both share one list of models
+type NetgearModel = netgear.Model
 
 const (
-	GS30xEPx NetgearModel = "GS30xEPx"
-	GS305EP  NetgearModel = "GS305EP"
-	GS305EPP NetgearModel = "GS305EPP"
-	GS308EP  NetgearModel = "GS308EP"
-	GS308EPP NetgearModel = "GS308EPP"
-	GS316EP  NetgearModel = "GS316EP"
-	GS316EPP NetgearModel = "GS316EPP"
+	GS30xEPx = netgear.ModelGS30xEPx
+	GS305EP  = netgear.ModelGS305EP
+	GS305EPP = netgear.ModelGS305EPP
+	GS308EP  = netgear.ModelGS308EP
+	GS308EPP = netgear.ModelGS308EPP
+	GS316EP  = netgear.ModelGS316EP
+	GS316EPP = netgear.ModelGS316EPP
 )
 
 type GlobalOptions struct {
@@ -23,4 +30,21 @@ type GlobalOptions struct {
 	TokenDir     string
 	Model        NetgearModel
 	Token        string
+	// Timeout bounds a whole command, including login and model detection;
+	// 0 waits until the command finishes or is interrupted
+	Timeout time.Duration `optional:"" help:"abort the command after this long, e.g. '30s'; 0 waits until it finishes" name:"timeout"`
+}
+
+// Context returns the context a command runs with: it is cancelled on an
+// interrupt and when Timeout elapses
+func (args *GlobalOptions) Context() (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	if args.Timeout <= 0 {
+		return ctx, stop
+	}
+	ctx, cancel := context.WithTimeout(ctx, args.Timeout)
+	return ctx, func() {
+		cancel()
+		stop()
+	}
 }
\ No newline at end of file
@@ -0,0 +1,37 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alecthomas/kong"
+)
+
+func TestTimeoutFlag(t *testing.T) {
+	var cli struct {
+		GlobalOptions `embed:""`
+	}
+	parser, err := kong.New(&cli)
+	if err != nil {
+		t.Fatalf("kong.New: %v", err)
+	}
+	if _, err := parser.Parse([]string{"--timeout", "30s"}); err != nil {
+		t.Fatalf("parsing --timeout: %v", err)
+	}
+	if cli.Timeout != 30*time.Second {
+		t.Errorf("expected a 30s timeout, got %v", cli.Timeout)
+	}
+
+	ctx, cancel := cli.Context()
+	defer cancel()
+	if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) > 30*time.Second {
+		t.Errorf("expected the command context to end within 30s, got %v (%v)", deadline, ok)
+	}
+
+	cli.Timeout = 0
+	ctx, cancel = cli.Context()
+	defer cancel()
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("expected no deadline without a timeout")
+	}
+}
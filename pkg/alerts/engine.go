@@ -0,0 +1,273 @@
+package alerts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/gherlein/go-netgear/pkg/netgear"
+)
+
+// Sample is one polled value
+type Sample struct {
+	Switch string
+	Port   int // 0 for switch-wide metrics
+	Metric Metric
+	Value  float64
+	Time   time.Time
+}
+
+// State is the state an alert transitioned to
+type State string
+
+const (
+	StateFiring   State = "firing"
+	StateResolved State = "resolved"
+)
+
+// Alert is emitted when a rule starts or stops matching for a switch/port
+type Alert struct {
+	Rule      string    `json:"rule"`
+	Severity  string    `json:"severity,omitempty"`
+	State     State     `json:"state"`
+	Switch    string    `json:"switch"`
+	Port      int       `json:"port,omitempty"`
+	Metric    Metric    `json:"metric"`
+	Value     float64   `json:"value"`
+	Threshold float64   `json:"threshold"`
+	Since     time.Time `json:"since"`
+	Time      time.Time `json:"time"`
+}
+
+// String returns a one-line description suitable for logs and chat messages
+func (a Alert) String() string {
+	target := a.Switch
+	if a.Port != 0 {
+		target = fmt.Sprintf("%s port %d", a.Switch, a.Port)
+	}
+	return fmt.Sprintf("[%s] %s on %s: %s = %g (threshold %g)", a.State, a.Rule, target, a.Metric, a.Value, a.Threshold)
+}
+
+// Notifier delivers alerts to a backend (webhook, chat, log)
+type Notifier interface {
+	Notify(ctx context.Context, alert Alert) error
+}
+
+// NotifierFunc adapts a function to the Notifier interface
+type NotifierFunc func(ctx context.Context, alert Alert) error
+
+// Notify calls f
+func (f NotifierFunc) Notify(ctx context.Context, alert Alert) error {
+	return f(ctx, alert)
+}
+
+// ruleState tracks one rule for one switch/port
+type ruleState struct {
+	matches int
+	since   time.Time
+	firing  bool
+}
+
+// Engine evaluates rules against samples and routes alert transitions to notifiers
+type Engine struct {
+	mu        sync.Mutex
+	rules     []Rule
+	notifiers []Notifier
+	states    map[string]*ruleState
+}
+
+// NewEngine creates an engine for a validated rule set
+func NewEngine(rules *RuleSet, notifiers ...Notifier) *Engine {
+	return &Engine{
+		rules:     rules.Rules,
+		notifiers: notifiers,
+		states:    make(map[string]*ruleState),
+	}
+}
+
+// Observe evaluates samples from one poll, notifies every notifier of each
+// alert that fired or resolved, and returns those alerts. Notification errors
+// are joined and returned alongside the alerts.
+func (e *Engine) Observe(ctx context.Context, samples ...Sample) ([]Alert, error) {
+	alerts := e.evaluate(samples)
+
+	var errs []error
+	for _, alert := range alerts {
+		for _, notifier := range e.notifiers {
+			if err := notifier.Notify(ctx, alert); err != nil {
+				errs = append(errs, fmt.Errorf("notify %s: %w", alert.Rule, err))
+			}
+		}
+	}
+
+	return alerts, errors.Join(errs...)
+}
+
+// evaluate updates rule states and returns the resulting transitions
+func (e *Engine) evaluate(samples []Sample) []Alert {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var alerts []Alert
+	for _, sample := range samples {
+		for _, rule := range e.rules {
+			if !rule.matches(sample) {
+				continue
+			}
+
+			key := fmt.Sprintf("%s|%s|%d", rule.Name, sample.Switch, sample.Port)
+			state, exists := e.states[key]
+			if !exists {
+				state = &ruleState{}
+				e.states[key] = state
+			}
+
+			breached, _ := compare(rule.Operator, sample.Value, rule.Threshold)
+			if !breached {
+				if state.firing {
+					alerts = append(alerts, newAlert(rule, sample, StateResolved, state.since))
+				}
+				*state = ruleState{}
+				continue
+			}
+
+			if state.matches == 0 {
+				state.since = sample.Time
+			}
+			state.matches++
+
+			polls := rule.Polls
+			if polls == 0 {
+				polls = 1
+			}
+			if !state.firing && state.matches >= polls && sample.Time.Sub(state.since) >= rule.For {
+				state.firing = true
+				alerts = append(alerts, newAlert(rule, sample, StateFiring, state.since))
+			}
+		}
+	}
+
+	return alerts
+}
+
+// Firing returns the number of rule/switch/port combinations currently firing
+func (e *Engine) Firing() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	count := 0
+	for _, state := range e.states {
+		if state.firing {
+			count++
+		}
+	}
+	return count
+}
+
+func newAlert(rule Rule, sample Sample, state State, since time.Time) Alert {
+	return Alert{
+		Rule:      rule.Name,
+		Severity:  rule.Severity,
+		State:     state,
+		Switch:    sample.Switch,
+		Port:      sample.Port,
+		Metric:    sample.Metric,
+		Value:     sample.Value,
+		Threshold: rule.Threshold,
+		Since:     since,
+		Time:      sample.Time,
+	}
+}
+
+// POEStatusSamples converts a POE status poll into samples
+func POEStatusSamples(switchName string, statuses []netgear.POEPortStatus, at time.Time) []Sample {
+	var samples []Sample
+	for _, status := range statuses {
+		samples = append(samples,
+			Sample{Switch: switchName, Port: status.PortID, Metric: MetricPowerW, Value: status.PowerW, Time: at},
+			Sample{Switch: switchName, Port: status.PortID, Metric: MetricVoltageV, Value: status.VoltageV, Time: at},
+			Sample{Switch: switchName, Port: status.PortID, Metric: MetricCurrentMA, Value: status.CurrentMA, Time: at},
+			Sample{Switch: switchName, Port: status.PortID, Metric: MetricTemperatureC, Value: status.TemperatureC, Time: at},
+		)
+	}
+	return samples
+}
+
+// PortLinkSamples converts a port settings poll into link samples
+func PortLinkSamples(switchName string, settings []netgear.PortSettings, at time.Time) []Sample {
+	var samples []Sample
+	for _, setting := range settings {
+		value := 0.0
+		if setting.Status == netgear.PortStatusConnected {
+			value = 1
+		}
+		samples = append(samples, Sample{Switch: switchName, Port: setting.PortID, Metric: MetricLinkUp, Value: value, Time: at})
+	}
+	return samples
+}
+
+// ReachabilitySample records whether a switch answered a poll
+func ReachabilitySample(switchName string, reachable bool, at time.Time) Sample {
+	value := 0.0
+	if reachable {
+		value = 1
+	}
+	return Sample{Switch: switchName, Metric: MetricReachable, Value: value, Time: at}
+}
+
+// RuleDriftDetected names the rule created by DriftRule
+const RuleDriftDetected = "DriftDetected"
+
+// DriftRule returns a rule firing DriftDetected for switches whose clock is
+// off by more than threshold, for use with ClockDriftSample. POE schedules
+// silently misfire on switches with a wrong clock.
+func DriftRule(threshold time.Duration) Rule {
+	return Rule{
+		Name:      RuleDriftDetected,
+		Metric:    MetricClockDriftS,
+		Operator:  ">",
+		Threshold: threshold.Seconds(),
+		Severity:  "warning",
+	}
+}
+
+// ClockDriftSample converts a clock skew measurement (see
+// netgear.Client.CheckClockDrift) into a sample
+func ClockDriftSample(switchName string, skew netgear.SkewEstimate) Sample {
+	return Sample{Switch: switchName, Metric: MetricClockDriftS, Value: skew.Drift().Seconds(), Time: skew.MeasuredAt}
+}
+
+// RulePowerAnomaly names the rule created by AnomalyRule
+const RulePowerAnomaly = "POEPowerAnomaly"
+
+// AnomalyRule returns a rule firing POEPowerAnomaly for ports whose newest
+// draw is an anomaly of their netgear.POEHistory, for use with
+// POEAnomalySamples. It resolves once the draw is back to normal.
+func AnomalyRule() Rule {
+	return Rule{
+		Name:      RulePowerAnomaly,
+		Metric:    MetricPowerAnomaly,
+		Operator:  ">",
+		Threshold: 0,
+		Severity:  "warning",
+	}
+}
+
+// POEAnomalySamples converts the newest sample of every port in a POE
+// history into an anomaly sample: its absolute z-score if it is an anomaly,
+// 0 otherwise
+func POEAnomalySamples(switchName string, history *netgear.POEHistory, at time.Time) []Sample {
+	var samples []Sample
+	for _, portID := range history.Ports() {
+		value := 0.0
+		// A window of a nanosecond holds only the newest sample
+		if anomalies := history.Anomalies(portID, time.Nanosecond); len(anomalies) > 0 {
+			value = math.Abs(anomalies[len(anomalies)-1].ZScore)
+		}
+		samples = append(samples, Sample{Switch: switchName, Port: portID, Metric: MetricPowerAnomaly, Value: value, Time: at})
+	}
+	return samples
+}
@@ -0,0 +1,157 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gherlein/go-netgear/pkg/netgear"
+)
+
+const testRules = `
+rules:
+  - name: camera-overdraw
+    switch: sw1
+    port: 7
+    metric: power_w
+    op: ">"
+    threshold: 25
+    for: 5m
+  - name: switch-down
+    metric: reachable
+    op: "=="
+    threshold: 0
+    polls: 3
+`
+
+func TestParseRules(t *testing.T) {
+	rules, err := ParseRules([]byte(testRules))
+	if err != nil {
+		t.Fatalf("ParseRules failed: %v", err)
+	}
+	if len(rules.Rules) != 2 {
+		t.Fatalf("Expected 2 rules, got %d", len(rules.Rules))
+	}
+	if rules.Rules[0].For != 5*time.Minute {
+		t.Errorf("Expected for 5m, got %v", rules.Rules[0].For)
+	}
+
+	if _, err := ParseRules([]byte("rules:\n  - name: bad\n    metric: power_w\n    op: '~'\n")); err == nil {
+		t.Error("Expected error for unknown operator")
+	}
+}
+
+func TestEngineForDuration(t *testing.T) {
+	rules, _ := ParseRules([]byte(testRules))
+	engine := NewEngine(rules)
+	ctx := context.Background()
+	start := time.Now()
+
+	sample := func(offset time.Duration, watts float64) Sample {
+		return Sample{Switch: "sw1", Port: 7, Metric: MetricPowerW, Value: watts, Time: start.Add(offset)}
+	}
+
+	if alerts, _ := engine.Observe(ctx, sample(0, 30)); len(alerts) != 0 {
+		t.Fatalf("Expected no alert before 5m, got %v", alerts)
+	}
+	if alerts, _ := engine.Observe(ctx, sample(4*time.Minute, 30)); len(alerts) != 0 {
+		t.Fatalf("Expected no alert before 5m, got %v", alerts)
+	}
+	alerts, _ := engine.Observe(ctx, sample(5*time.Minute, 31))
+	if len(alerts) != 1 || alerts[0].State != StateFiring || !alerts[0].Since.Equal(start) {
+		t.Fatalf("Expected firing alert since start, got %v", alerts)
+	}
+	if alerts, _ := engine.Observe(ctx, sample(6*time.Minute, 31)); len(alerts) != 0 {
+		t.Fatalf("Expected no repeated alert, got %v", alerts)
+	}
+	alerts, _ = engine.Observe(ctx, sample(7*time.Minute, 10))
+	if len(alerts) != 1 || alerts[0].State != StateResolved {
+		t.Fatalf("Expected resolved alert, got %v", alerts)
+	}
+}
+
+func TestEngineConsecutivePolls(t *testing.T) {
+	rules, _ := ParseRules([]byte(testRules))
+
+	var notified []Alert
+	engine := NewEngine(rules, NotifierFunc(func(ctx context.Context, alert Alert) error {
+		notified = append(notified, alert)
+		return nil
+	}))
+	ctx := context.Background()
+	now := time.Now()
+
+	engine.Observe(ctx, ReachabilitySample("sw2", false, now))
+	engine.Observe(ctx, ReachabilitySample("sw2", true, now))
+	engine.Observe(ctx, ReachabilitySample("sw2", false, now))
+	engine.Observe(ctx, ReachabilitySample("sw2", false, now))
+	if len(notified) != 0 {
+		t.Fatalf("Expected no alert after interrupted run, got %v", notified)
+	}
+
+	engine.Observe(ctx, ReachabilitySample("sw2", false, now))
+	if len(notified) != 1 || notified[0].Rule != "switch-down" || engine.Firing() != 1 {
+		t.Fatalf("Expected switch-down to fire on third consecutive poll, got %v", notified)
+	}
+}
+
+func TestDriftRule(t *testing.T) {
+	engine := NewEngine(&RuleSet{Rules: []Rule{DriftRule(time.Minute)}})
+	ctx := context.Background()
+	now := time.Now()
+
+	inSync := netgear.SkewEstimate{Offset: 2 * time.Second, Uncertainty: time.Second, MeasuredAt: now}
+	if alerts, _ := engine.Observe(ctx, ClockDriftSample("sw1", inSync)); len(alerts) != 0 {
+		t.Fatalf("expected no alert for a clock 2s off, got %v", alerts)
+	}
+
+	drifted := netgear.SkewEstimate{Offset: -2 * time.Hour, Uncertainty: time.Second, MeasuredAt: now.Add(time.Minute)}
+	alerts, _ := engine.Observe(ctx, ClockDriftSample("sw1", drifted))
+	if len(alerts) != 1 || alerts[0].Rule != RuleDriftDetected || alerts[0].State != StateFiring || alerts[0].Value < 7000 {
+		t.Fatalf("expected DriftDetected to fire, got %v", alerts)
+	}
+}
+
+func TestAnomalyRule(t *testing.T) {
+	engine := NewEngine(&RuleSet{Rules: []Rule{AnomalyRule()}})
+	history := netgear.NewPOEHistory(0)
+	ctx := context.Background()
+	start := time.Now()
+
+	poll := func(i int, watts float64) []Alert {
+		at := start.Add(time.Duration(i) * time.Minute)
+		history.Record(at, []netgear.POEPortStatus{{PortID: 3, PowerW: watts}})
+		alerts, _ := engine.Observe(ctx, POEAnomalySamples("sw1", history, at)...)
+		return alerts
+	}
+
+	for i := 0; i < 10; i++ {
+		if alerts := poll(i, 4.0); len(alerts) != 0 {
+			t.Fatalf("expected no alert for a steady draw, got %v", alerts)
+		}
+	}
+	alerts := poll(10, 12.5)
+	if len(alerts) != 1 || alerts[0].Rule != RulePowerAnomaly || alerts[0].State != StateFiring || alerts[0].Port != 3 {
+		t.Fatalf("expected POEPowerAnomaly to fire on port 3, got %v", alerts)
+	}
+	alerts = poll(11, 4.0)
+	if len(alerts) != 1 || alerts[0].State != StateResolved {
+		t.Fatalf("expected the anomaly to resolve, got %v", alerts)
+	}
+}
+
+func TestAlertJSONFieldNames(t *testing.T) {
+	// Webhook receivers parse these names; keep them in step with docs/json-output.md
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	alert := Alert{Rule: RulePowerAnomaly, Severity: "warning", State: StateFiring, Switch: "cams", Port: 1, Metric: MetricPowerAnomaly, Value: 4.2, Threshold: 0, Since: at, Time: at}
+	want := `{"rule":"POEPowerAnomaly","severity":"warning","state":"firing","switch":"cams","port":1,"metric":"power_anomaly_z","value":4.2,"threshold":0,"since":"2026-01-02T03:04:05Z","time":"2026-01-02T03:04:05Z"}`
+
+	data, err := json.Marshal(alert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != want {
+		t.Errorf("Alert JSON changed:\n got %s\nwant %s", data, want)
+	}
+}
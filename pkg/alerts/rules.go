@@ -0,0 +1,153 @@
+// Package alerts evaluates threshold rules against values polled from switches
+// and routes the resulting alerts to notifiers.
+//
+// Rules are defined in YAML:
+//
+//	rules:
+//	  - name: camera-overdraw
+//	    switch: 192.168.1.10
+//	    port: 7
+//	    metric: power_w
+//	    op: ">"
+//	    threshold: 25
+//	    for: 5m
+//	  - name: switch-down
+//	    metric: reachable
+//	    op: "=="
+//	    threshold: 0
+//	    polls: 3
+//	  - name: DriftDetected
+//	    metric: clock_drift_s
+//	    op: ">"
+//	    threshold: 60
+package alerts
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Metric names a polled value a rule can test
+type Metric string
+
+const (
+	MetricPowerW       Metric = "power_w"
+	MetricVoltageV     Metric = "voltage_v"
+	MetricCurrentMA    Metric = "current_ma"
+	MetricTemperatureC Metric = "temperature_c"
+	MetricLinkUp       Metric = "link_up"         // 1 when the port has link, 0 otherwise
+	MetricReachable    Metric = "reachable"       // 1 when the switch answered the poll, 0 otherwise
+	MetricClockDriftS  Metric = "clock_drift_s"   // seconds the switch clock is off, beyond measurement uncertainty
+	MetricPowerAnomaly Metric = "power_anomaly_z" // absolute z-score of an anomalous POE draw, 0 for a normal one
+)
+
+// knownMetrics lists the metrics rules may reference
+var knownMetrics = map[Metric]bool{
+	MetricPowerW:       true,
+	MetricVoltageV:     true,
+	MetricCurrentMA:    true,
+	MetricTemperatureC: true,
+	MetricLinkUp:       true,
+	MetricReachable:    true,
+	MetricClockDriftS:  true,
+	MetricPowerAnomaly: true,
+}
+
+// Rule is a threshold condition on one metric
+type Rule struct {
+	Name string `yaml:"name"`
+	// Switch restricts the rule to one switch address or name; empty matches all switches
+	Switch string `yaml:"switch,omitempty"`
+	// Port restricts the rule to one port; 0 matches every port
+	Port      int     `yaml:"port,omitempty"`
+	Metric    Metric  `yaml:"metric"`
+	Operator  string  `yaml:"op"`
+	Threshold float64 `yaml:"threshold"`
+	// For is how long the condition must hold before the alert fires
+	For time.Duration `yaml:"for,omitempty"`
+	// Polls is how many consecutive polls must match before the alert fires
+	Polls    int    `yaml:"polls,omitempty"`
+	Severity string `yaml:"severity,omitempty"`
+}
+
+// RuleSet is the contents of a rules file
+type RuleSet struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// LoadRules reads and validates a YAML rules file
+func LoadRules(filename string) (*RuleSet, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rules file: %w", err)
+	}
+	return ParseRules(data)
+}
+
+// ParseRules parses and validates YAML rules
+func ParseRules(data []byte) (*RuleSet, error) {
+	var rules RuleSet
+	if err := yaml.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse rules: %w", err)
+	}
+
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &rules, nil
+}
+
+// Validate checks every rule for a name, a known metric and a valid operator
+func (rs *RuleSet) Validate() error {
+	seen := make(map[string]bool)
+	for i, rule := range rs.Rules {
+		if rule.Name == "" {
+			return fmt.Errorf("rule %d has no name", i)
+		}
+		if seen[rule.Name] {
+			return fmt.Errorf("duplicate rule name %q", rule.Name)
+		}
+		seen[rule.Name] = true
+		if !knownMetrics[rule.Metric] {
+			return fmt.Errorf("rule %q: unknown metric %q", rule.Name, rule.Metric)
+		}
+		if _, err := compare(rule.Operator, 0, 0); err != nil {
+			return fmt.Errorf("rule %q: %w", rule.Name, err)
+		}
+		if rule.For < 0 || rule.Polls < 0 {
+			return fmt.Errorf("rule %q: for and polls must not be negative", rule.Name)
+		}
+	}
+	return nil
+}
+
+// matches reports whether the rule applies to a sample
+func (r Rule) matches(s Sample) bool {
+	return r.Metric == s.Metric &&
+		(r.Switch == "" || r.Switch == s.Switch) &&
+		(r.Port == 0 || r.Port == s.Port)
+}
+
+// compare applies a comparison operator
+func compare(op string, value, threshold float64) (bool, error) {
+	switch op {
+	case ">":
+		return value > threshold, nil
+	case ">=":
+		return value >= threshold, nil
+	case "<":
+		return value < threshold, nil
+	case "<=":
+		return value <= threshold, nil
+	case "==":
+		return value == threshold, nil
+	case "!=":
+		return value != threshold, nil
+	default:
+		return false, fmt.Errorf("unknown operator %q", op)
+	}
+}
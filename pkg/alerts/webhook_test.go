@@ -0,0 +1,38 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWebhook(t *testing.T) {
+	var received []Alert
+	status := http.StatusNoContent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var alert Alert
+		if r.Header.Get("Content-Type") != "application/json" || json.NewDecoder(r.Body).Decode(&alert) != nil {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		received = append(received, alert)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	notifier := Webhook(server.URL, nil)
+	alert := Alert{Rule: "camera-overdraw", State: StateFiring, Switch: "sw1", Port: 7, Metric: MetricPowerW, Value: 31}
+	if err := notifier.Notify(context.Background(), alert); err != nil {
+		t.Fatalf("Notify failed: %v", err)
+	}
+	if len(received) != 1 || received[0].Rule != "camera-overdraw" || received[0].Port != 7 {
+		t.Errorf("unexpected delivery %+v", received)
+	}
+
+	status = http.StatusInternalServerError
+	if err := notifier.Notify(context.Background(), alert); err == nil {
+		t.Error("expected an error for a failed delivery")
+	}
+}
@@ -0,0 +1,126 @@
+package exporter
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/gherlein/go-netgear/pkg/netgear"
+)
+
+// Collect reads POE status, port settings and request metrics from a switch
+// and returns them as samples labeled with switchName. The POE budget series
+// are omitted for models of unknown budget, and the port link series for
+// models without a port settings page (the GS30x series).
+func Collect(ctx context.Context, switchName string, client *netgear.Client) ([]Sample, error) {
+	var samples []Sample
+	switchLabel := Label{LabelSwitch, switchName}
+
+	statuses, err := client.POE().GetStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var consumedW float64
+	for _, status := range statuses {
+		labels := portLabelValues(switchName, status.PortID, status.PortName)
+		samples = append(samples,
+			Sample{Metric: MetricPOEPower, Labels: labels, Value: status.PowerW},
+			Sample{Metric: MetricPOEVoltage, Labels: labels, Value: status.VoltageV},
+			Sample{Metric: MetricPOECurrent, Labels: labels, Value: status.CurrentMA / 1000},
+			Sample{Metric: MetricPOETemperature, Labels: labels, Value: status.TemperatureC},
+			Sample{Metric: MetricPOEDelivering, Labels: labels, Value: boolValue(status.Delivering())},
+		)
+		consumedW += status.PowerW
+	}
+	if totalW, ok := netgear.POEBudgetW(client.GetModel()); ok {
+		samples = append(samples,
+			Sample{Metric: MetricPOEBudgetTotal, Labels: []Label{switchLabel}, Value: totalW},
+			Sample{Metric: MetricPOEBudgetConsumed, Labels: []Label{switchLabel}, Value: consumedW},
+		)
+	}
+
+	if netgear.NewEndpointRegistry(client.GetModel()).IsEndpointSupported(netgear.EndpointPortSettings) {
+		settings, err := client.Ports().GetSettings(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, setting := range settings {
+			labels := portLabelValues(switchName, setting.PortID, setting.PortName)
+			linkUp := setting.Status == netgear.PortStatusConnected
+			samples = append(samples, Sample{Metric: MetricPortLinkUp, Labels: labels, Value: boolValue(linkUp)})
+			if !linkUp {
+				samples = append(samples, Sample{Metric: MetricPortLinkSpeed, Labels: labels, Value: 0})
+			} else if speed, ok := ParseLinkSpeed(setting.LinkSpeed); ok {
+				samples = append(samples, Sample{Metric: MetricPortLinkSpeed, Labels: labels, Value: speed})
+			}
+		}
+	}
+
+	return append(samples, requestSamples(switchName, client.Metrics())...), nil
+}
+
+// requestSamples converts a client's per-endpoint request metrics
+func requestSamples(switchName string, metrics []netgear.EndpointMetrics) []Sample {
+	var samples []Sample
+	for _, m := range metrics {
+		labels := []Label{{LabelSwitch, switchName}, {LabelEndpoint, m.Endpoint}}
+		for _, bucket := range m.Buckets {
+			samples = append(samples, Sample{
+				Metric: MetricRequestDuration,
+				Suffix: SuffixBucket,
+				Labels: withLabel(labels, "le", formatValue(bucket.UpperBound.Seconds())),
+				Value:  float64(bucket.Count),
+			})
+		}
+		samples = append(samples,
+			Sample{Metric: MetricRequestDuration, Suffix: SuffixBucket, Labels: withLabel(labels, "le", "+Inf"), Value: float64(m.Requests)},
+			Sample{Metric: MetricRequestDuration, Suffix: SuffixSum, Labels: labels, Value: m.TotalLatency.Seconds()},
+			Sample{Metric: MetricRequestDuration, Suffix: SuffixCount, Labels: labels, Value: float64(m.Requests)},
+			Sample{Metric: MetricRequestErrors, Labels: labels, Value: float64(m.Errors)},
+			Sample{Metric: MetricRequestBytesSaved, Labels: labels, Value: float64(m.BytesSaved)},
+		)
+	}
+	return samples
+}
+
+// portLabelValues returns the labels of a per-port series
+func portLabelValues(switchName string, port int, portName string) []Label {
+	return []Label{{LabelSwitch, switchName}, {LabelPort, strconv.Itoa(port)}, {LabelPortName, portName}}
+}
+
+// withLabel returns a copy of labels with one more label appended
+func withLabel(labels []Label, name, value string) []Label {
+	return append(append([]Label(nil), labels...), Label{name, value})
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// ParseLinkSpeed converts a link speed as switches display it, such as
+// "1000M", "100 Mbps" or "2.5G", to bits per second. ok is false for values
+// that carry no speed, such as "Link Down".
+func ParseLinkSpeed(s string) (bitsPerSecond float64, ok bool) {
+	s = strings.ToLower(strings.ReplaceAll(s, " ", ""))
+	end := strings.IndexFunc(s, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+	if end <= 0 {
+		return 0, false
+	}
+	value, err := strconv.ParseFloat(s[:end], 64)
+	if err != nil || math.IsNaN(value) {
+		return 0, false
+	}
+	switch s[end] {
+	case 'g':
+		return value * 1e9, true
+	case 'm':
+		return value * 1e6, true
+	case 'k':
+		return value * 1e3, true
+	}
+	return 0, false
+}
@@ -0,0 +1,106 @@
+package exporter
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gherlein/go-netgear/pkg/netgear"
+)
+
+// poeSwitch fakes a GS308EPP powering a device on port 1 only
+func poeSwitch(t *testing.T) string {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/getPoePortStatus.cgi":
+			fmt.Fprint(w, "<ul>")
+			for port, power := range []float64{4.5, 0} {
+				status := "Searching"
+				if power > 0 {
+					status = "Delivering Power"
+				}
+				fmt.Fprintf(w, `<li class="poePortStatusListItem"><input type="hidden" class="port" value="%d"><span class="poe-power-mode"><span>%s</span></span><div class="poe_port_status"><div><div><span>%.1fW</span></div></div></div></li>`, port+1, status, power)
+			}
+			fmt.Fprint(w, "</ul>")
+		default:
+			fmt.Fprint(w, "<html></html>")
+		}
+	}))
+	t.Cleanup(server.Close)
+	return strings.TrimPrefix(server.URL, "http://")
+}
+
+func TestCollectorPoll(t *testing.T) {
+	address := poeSwitch(t)
+	inventory := &netgear.Inventory{Switches: []netgear.InventoryEntry{
+		{Name: "poe-core", Addresses: []string{address}},
+		{Name: "gone", Addresses: []string{"127.0.0.1:1"}, Model: netgear.ModelGS316EP},
+	}}
+	tokens := netgear.NewMemoryTokenManager()
+	tokens.StoreToken(context.Background(), address, "token", netgear.ModelGS308EPP)
+	fleet := netgear.NewFleet(inventory, netgear.WithTokenManager(tokens), netgear.WithPasswordManager(nil))
+
+	collector := NewCollector(inventory, fleet, nil)
+	ctx := context.Background()
+	if err := collector.Poll(ctx, "poe-core"); err != nil {
+		t.Fatalf("Poll failed: %v", err)
+	}
+	if err := collector.Poll(ctx, "gone"); err == nil {
+		t.Fatal("expected polling an unreachable switch to fail")
+	}
+
+	recorder := httptest.NewRecorder()
+	collector.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	if got := recorder.Header().Get("Content-Type"); got != ContentType {
+		t.Errorf("Content-Type = %q", got)
+	}
+	body := recorder.Body.String()
+
+	for _, want := range []string{
+		"# TYPE netgear_up gauge\n",
+		`netgear_up{switch="gone",model="GS316EP"} 0` + "\n",
+		`netgear_up{switch="poe-core",model="GS308EPP"} 1` + "\n",
+		`netgear_poe_power_watts{switch="poe-core",port="1",port_name=""} 4.5` + "\n",
+		`netgear_poe_delivering{switch="poe-core",port="1",port_name=""} 1` + "\n",
+		`netgear_poe_delivering{switch="poe-core",port="2",port_name=""} 0` + "\n",
+		`netgear_poe_budget_watts{switch="poe-core"} 123` + "\n",
+		`netgear_poe_consumed_watts{switch="poe-core"} 4.5` + "\n",
+		`netgear_request_duration_seconds_bucket{switch="poe-core",endpoint="/getPoePortStatus.cgi",le="+Inf"} 1` + "\n",
+		`netgear_request_duration_seconds_count{switch="poe-core",endpoint="/getPoePortStatus.cgi"} 1` + "\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics lack %q:\n%s", want, body)
+		}
+	}
+
+	// The GS308EPP has no port settings page, so no link series are published
+	if strings.Contains(body, MetricPortLinkUp) {
+		t.Errorf("unexpected port link series for a GS30x switch:\n%s", body)
+	}
+	// A switch that is down publishes no stale POE readings
+	if strings.Contains(body, `netgear_poe_power_watts{switch="gone"`) {
+		t.Errorf("unexpected POE series for an unreachable switch:\n%s", body)
+	}
+}
+
+func TestParseLinkSpeed(t *testing.T) {
+	tests := map[string]float64{
+		"1000M":     1e9,
+		"100M":      1e8,
+		"10 Mbps":   1e7,
+		"2.5G":      2.5e9,
+		"10Gbps":    1e10,
+		"Link Down": 0,
+		"":          0,
+		"1000":      0,
+	}
+	for input, want := range tests {
+		got, ok := ParseLinkSpeed(input)
+		if ok != (want > 0) || got != want {
+			t.Errorf("ParseLinkSpeed(%q) = %v, %v; want %v", input, got, ok, want)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package exporter
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gherlein/go-netgear/pkg/netgear"
+)
+
+// Collector polls the switches of a fleet and serves their latest samples on
+// /metrics. Scrapes by Prometheus never contact the switches: they read what
+// the last poll of each switch cached, so a slow switch cannot stall the
+// scrape and switches are polled at their own intervals, not Prometheus'.
+type Collector struct {
+	inventory *netgear.Inventory
+	fleet     *netgear.Fleet
+	logger    *slog.Logger
+	now       func() time.Time
+
+	mu      sync.Mutex
+	samples map[string][]Sample
+	polling map[string]bool
+}
+
+// NewCollector creates a collector for the switches of an inventory, reached
+// through fleet
+func NewCollector(inventory *netgear.Inventory, fleet *netgear.Fleet, logger *slog.Logger) *Collector {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Collector{
+		inventory: inventory,
+		fleet:     fleet,
+		logger:    logger,
+		now:       time.Now,
+		samples:   make(map[string][]Sample),
+		polling:   make(map[string]bool),
+	}
+}
+
+// Poll reads one switch and replaces its cached samples. A failed poll still
+// publishes the switch as down, and drops its client so the next poll fails
+// over to another address.
+func (c *Collector) Poll(ctx context.Context, name string) error {
+	start := c.now()
+	model := c.modelOf(name)
+	switchLabel := Label{LabelSwitch, name}
+
+	client, err := c.fleet.Client(ctx, name)
+	var samples []Sample
+	if err == nil {
+		model = client.GetModel()
+		samples, err = Collect(ctx, name, client)
+		if err != nil {
+			// Request metrics show which page failed
+			samples = requestSamples(name, client.Metrics())
+			c.fleet.Invalidate(name)
+		}
+	}
+
+	samples = append(samples,
+		Sample{Metric: MetricUp, Labels: []Label{switchLabel, {LabelModel, string(model)}}, Value: boolValue(err == nil)},
+		Sample{Metric: MetricScrapeDuration, Labels: []Label{switchLabel}, Value: c.now().Sub(start).Seconds()},
+	)
+
+	c.mu.Lock()
+	c.samples[name] = samples
+	c.mu.Unlock()
+
+	if err != nil {
+		c.logger.Warn("failed to poll switch", "switch", name, "error", err)
+	}
+	return err
+}
+
+// modelOf returns the model the inventory lists for a switch, if any
+func (c *Collector) modelOf(name string) netgear.Model {
+	entry, _ := c.inventory.Lookup(name)
+	return entry.Model
+}
+
+// Run polls the switches on the schedule until ctx is done. Each due switch
+// is polled in its own goroutine; a switch whose previous poll is still
+// running is skipped.
+func (c *Collector) Run(ctx context.Context, schedule *Scheduler) {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		for _, name := range schedule.Due(c.now()) {
+			if !c.startPoll(name) {
+				continue
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer c.endPoll(name)
+				c.Poll(ctx, name)
+			}()
+		}
+
+		wait := time.Second
+		if next, ok := schedule.Next(); ok {
+			wait = max(next.Sub(c.now()), 10*time.Millisecond)
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// startPoll marks a switch as being polled; false if it already is
+func (c *Collector) startPoll(name string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.polling[name] {
+		return false
+	}
+	c.polling[name] = true
+	return true
+}
+
+func (c *Collector) endPoll(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.polling, name)
+}
+
+// Samples returns the cached samples of every polled switch, ordered by
+// switch name
+func (c *Collector) Samples() []Sample {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	names := make([]string, 0, len(c.samples))
+	for name := range c.samples {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var samples []Sample
+	for _, name := range names {
+		samples = append(samples, c.samples[name]...)
+	}
+	return samples
+}
+
+// ServeHTTP writes the cached samples in the Prometheus text format
+func (c *Collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", ContentType)
+	if err := WriteText(w, c.Samples()); err != nil {
+		c.logger.Error("failed to write metrics", "error", err)
+	}
+}
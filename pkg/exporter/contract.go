@@ -0,0 +1,103 @@
+// Package exporter implements the Prometheus exporter (cmd/netgear-exporter):
+// it polls fleet switches on a schedule (Scheduler), turns their readings into
+// samples (Collect, Collector) and writes them in the text format (WriteText).
+// It also defines the metric naming contract: the name, type, unit and labels
+// of every published series.
+//
+// The contract is frozen. Dashboards and alert rules in the field query these
+// names, so a metric may be added but never renamed, retyped or relabeled;
+// TestContractFrozen fails on any such change. The Grafana dashboard shipped
+// in contrib/grafana is generated from this contract (see Dashboard).
+package exporter
+
+//go:generate go run ./gendashboard -o ../../contrib/grafana/netgear-dashboard.json
+
+// MetricType is the Prometheus type of a metric
+type MetricType string
+
+const (
+	Gauge     MetricType = "gauge"
+	Counter   MetricType = "counter"
+	Histogram MetricType = "histogram"
+)
+
+// Label names used by the exporter
+const (
+	LabelSwitch   = "switch"
+	LabelModel    = "model"
+	LabelPort     = "port"
+	LabelPortName = "port_name"
+	LabelEndpoint = "endpoint"
+)
+
+// Metric names published by the exporter
+const (
+	MetricUp                = "netgear_up"
+	MetricScrapeDuration    = "netgear_scrape_duration_seconds"
+	MetricPOEPower          = "netgear_poe_power_watts"
+	MetricPOEVoltage        = "netgear_poe_voltage_volts"
+	MetricPOECurrent        = "netgear_poe_current_amperes"
+	MetricPOETemperature    = "netgear_poe_temperature_celsius"
+	MetricPOEDelivering     = "netgear_poe_delivering"
+	MetricPOEBudgetTotal    = "netgear_poe_budget_watts"
+	MetricPOEBudgetConsumed = "netgear_poe_consumed_watts"
+	MetricPortLinkUp        = "netgear_port_link_up"
+	MetricPortLinkSpeed     = "netgear_port_link_speed_bits_per_second"
+	MetricRequestDuration   = "netgear_request_duration_seconds"
+	MetricRequestErrors     = "netgear_request_errors_total"
+	MetricRequestBytesSaved = "netgear_request_bytes_saved_total"
+)
+
+// Metric describes one published metric
+type Metric struct {
+	Name   string
+	Title  string // panel title in the generated dashboard
+	Help   string
+	Type   MetricType
+	Unit   string // Grafana unit id used for panels
+	Labels []string
+}
+
+var (
+	switchLabels   = []string{LabelSwitch}
+	portLabels     = []string{LabelSwitch, LabelPort, LabelPortName}
+	endpointLabels = []string{LabelSwitch, LabelEndpoint}
+)
+
+// contract lists the published metrics in dashboard order
+var contract = []Metric{
+	{MetricUp, "Switch up", "Whether the last scrape of the switch succeeded (1) or failed (0).", Gauge, "none", []string{LabelSwitch, LabelModel}},
+	{MetricScrapeDuration, "Scrape duration", "Time taken to scrape the switch.", Gauge, "s", switchLabels},
+	{MetricPOEPower, "POE power", "Power drawn by the device on a POE port.", Gauge, "watt", portLabels},
+	{MetricPOEVoltage, "POE voltage", "Output voltage of a POE port.", Gauge, "volt", portLabels},
+	{MetricPOECurrent, "POE current", "Output current of a POE port.", Gauge, "amp", portLabels},
+	{MetricPOETemperature, "POE temperature", "Temperature reported for a POE port.", Gauge, "celsius", portLabels},
+	{MetricPOEDelivering, "POE delivering", "Whether a POE port is delivering power (1) or not (0).", Gauge, "none", portLabels},
+	{MetricPOEBudgetTotal, "POE budget", "Total POE power budget of the switch.", Gauge, "watt", switchLabels},
+	{MetricPOEBudgetConsumed, "POE consumed", "POE power currently drawn from the switch budget.", Gauge, "watt", switchLabels},
+	{MetricPortLinkUp, "Port link", "Whether a port has link (1) or not (0).", Gauge, "none", portLabels},
+	{MetricPortLinkSpeed, "Port link speed", "Negotiated link speed of a port.", Gauge, "bps", portLabels},
+	{MetricRequestDuration, "Request latency (p95)", "Latency of requests to switch pages.", Histogram, "s", endpointLabels},
+	{MetricRequestErrors, "Request errors", "Failed requests to switch pages.", Counter, "short", endpointLabels},
+	{MetricRequestBytesSaved, "Bytes saved by conditional requests", "Response bytes saved by conditional requests answered 304 Not Modified.", Counter, "bytes", endpointLabels},
+}
+
+// Metrics returns the contract, in dashboard order
+func Metrics() []Metric {
+	metrics := make([]Metric, len(contract))
+	for i, metric := range contract {
+		metric.Labels = append([]string(nil), metric.Labels...)
+		metrics[i] = metric
+	}
+	return metrics
+}
+
+// Lookup returns the contract entry for a metric name
+func Lookup(name string) (Metric, bool) {
+	for _, metric := range Metrics() {
+		if metric.Name == name {
+			return metric, true
+		}
+	}
+	return Metric{}, false
+}
@@ -0,0 +1,97 @@
+package exporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// frozen is the published contract. Adding a metric means appending a line;
+// changing or removing an existing line breaks users' dashboards and alerts.
+var frozen = []string{
+	"netgear_up gauge switch,model",
+	"netgear_scrape_duration_seconds gauge switch",
+	"netgear_poe_power_watts gauge switch,port,port_name",
+	"netgear_poe_voltage_volts gauge switch,port,port_name",
+	"netgear_poe_current_amperes gauge switch,port,port_name",
+	"netgear_poe_temperature_celsius gauge switch,port,port_name",
+	"netgear_poe_delivering gauge switch,port,port_name",
+	"netgear_poe_budget_watts gauge switch",
+	"netgear_poe_consumed_watts gauge switch",
+	"netgear_port_link_up gauge switch,port,port_name",
+	"netgear_port_link_speed_bits_per_second gauge switch,port,port_name",
+	"netgear_request_duration_seconds histogram switch,endpoint",
+	"netgear_request_errors_total counter switch,endpoint",
+	"netgear_request_bytes_saved_total counter switch,endpoint",
+}
+
+func TestContractFrozen(t *testing.T) {
+	published := make(map[string]string)
+	for _, metric := range Metrics() {
+		published[metric.Name] = metric.Name + " " + string(metric.Type) + " " + strings.Join(metric.Labels, ",")
+	}
+
+	for _, want := range frozen {
+		name, _, _ := strings.Cut(want, " ")
+		got, exists := published[name]
+		if !exists {
+			t.Errorf("frozen metric %s was removed or renamed", name)
+			continue
+		}
+		if got != want {
+			t.Errorf("frozen metric changed: got %q, want %q", got, want)
+		}
+	}
+	if len(published) != len(frozen) {
+		t.Errorf("contract has %d metrics but %d are frozen; append new metrics to the frozen list", len(published), len(frozen))
+	}
+}
+
+func TestContractNaming(t *testing.T) {
+	validName := regexp.MustCompile(`^netgear_[a-z0-9_]+$`)
+	for _, metric := range Metrics() {
+		if !validName.MatchString(metric.Name) {
+			t.Errorf("%s: metric names must be lower snake case with the netgear_ prefix", metric.Name)
+		}
+		if (metric.Type == Counter) != strings.HasSuffix(metric.Name, "_total") {
+			t.Errorf("%s: counters, and only counters, end in _total", metric.Name)
+		}
+		if metric.Title == "" || metric.Help == "" || metric.Unit == "" {
+			t.Errorf("%s: title, help and unit are required", metric.Name)
+		}
+		if len(metric.Labels) == 0 || metric.Labels[0] != LabelSwitch {
+			t.Errorf("%s: every metric is labeled by switch first", metric.Name)
+		}
+	}
+
+	if _, ok := Lookup(MetricPOEPower); !ok {
+		t.Errorf("Lookup(%q) failed", MetricPOEPower)
+	}
+}
+
+func TestDashboardUpToDate(t *testing.T) {
+	generated, err := Dashboard()
+	if err != nil {
+		t.Fatalf("Dashboard failed: %v", err)
+	}
+	if !json.Valid(generated) {
+		t.Fatal("generated dashboard is not valid JSON")
+	}
+
+	shipped, err := os.ReadFile("../../contrib/grafana/netgear-dashboard.json")
+	if err != nil {
+		t.Fatalf("failed to read shipped dashboard: %v", err)
+	}
+	if !bytes.Equal(generated, shipped) {
+		t.Error("contrib/grafana/netgear-dashboard.json is stale; run go generate ./pkg/exporter")
+	}
+
+	for _, metric := range Metrics() {
+		if !bytes.Contains(generated, []byte(metric.Name)) {
+			t.Errorf("dashboard has no panel for %s", metric.Name)
+		}
+	}
+}
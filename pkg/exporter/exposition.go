@@ -0,0 +1,102 @@
+package exporter
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ContentType is the media type of the text exposition format WriteText writes
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Histogram series suffixes
+const (
+	SuffixBucket = "_bucket"
+	SuffixSum    = "_sum"
+	SuffixCount  = "_count"
+)
+
+// Label is a label name and value of a sample
+type Label struct {
+	Name  string
+	Value string
+}
+
+// Sample is one value of a published series
+type Sample struct {
+	// Metric is the contract name of the metric the sample belongs to
+	Metric string
+	// Suffix is SuffixBucket, SuffixSum or SuffixCount for histogram series
+	Suffix string
+	Labels []Label
+	Value  float64
+}
+
+// WriteText writes samples in the Prometheus text exposition format. Samples
+// are grouped by metric in contract order, each group preceded by the HELP
+// and TYPE lines of its contract entry; samples of metrics outside the
+// contract are an error.
+func WriteText(w io.Writer, samples []Sample) error {
+	order := make(map[string]int, len(contract))
+	for i, metric := range contract {
+		order[metric.Name] = i
+	}
+	for _, sample := range samples {
+		if _, ok := order[sample.Metric]; !ok {
+			return fmt.Errorf("metric %q is not part of the exporter contract", sample.Metric)
+		}
+	}
+
+	sorted := append([]Sample(nil), samples...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return order[sorted[i].Metric] < order[sorted[j].Metric]
+	})
+
+	out := bufio.NewWriter(w)
+	for i, sample := range sorted {
+		if i == 0 || sample.Metric != sorted[i-1].Metric {
+			metric := contract[order[sample.Metric]]
+			fmt.Fprintf(out, "# HELP %s %s\n", metric.Name, metric.Help)
+			fmt.Fprintf(out, "# TYPE %s %s\n", metric.Name, metric.Type)
+		}
+		out.WriteString(sample.Metric + sample.Suffix)
+		if len(sample.Labels) > 0 {
+			out.WriteByte('{')
+			for j, label := range sample.Labels {
+				if j > 0 {
+					out.WriteByte(',')
+				}
+				fmt.Fprintf(out, "%s=\"%s\"", label.Name, escapeLabelValue(label.Value))
+			}
+			out.WriteByte('}')
+		}
+		out.WriteByte(' ')
+		out.WriteString(formatValue(sample.Value))
+		out.WriteByte('\n')
+	}
+	return out.Flush()
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// escapeLabelValue escapes a label value for the text format
+func escapeLabelValue(value string) string {
+	return labelValueEscaper.Replace(value)
+}
+
+// formatValue formats a sample value for the text format
+func formatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,48 @@
+package exporter
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestWriteText(t *testing.T) {
+	samples := []Sample{
+		{Metric: MetricPOEPower, Labels: []Label{{LabelSwitch, "a"}, {LabelPort, "1"}, {LabelPortName, `cam "lobby"`}}, Value: 4.5},
+		{Metric: MetricUp, Labels: []Label{{LabelSwitch, "a"}, {LabelModel, "GS308EPP"}}, Value: 1},
+		{Metric: MetricRequestDuration, Suffix: SuffixBucket, Labels: []Label{{LabelSwitch, "a"}, {LabelEndpoint, "/x"}, {"le", "+Inf"}}, Value: 3},
+		{Metric: MetricRequestDuration, Suffix: SuffixSum, Labels: []Label{{LabelSwitch, "a"}, {LabelEndpoint, "/x"}}, Value: 0.25},
+		{Metric: MetricPOEPower, Labels: []Label{{LabelSwitch, "a"}, {LabelPort, "2"}, {LabelPortName, ""}}, Value: math.Inf(1)},
+	}
+
+	var out strings.Builder
+	if err := WriteText(&out, samples); err != nil {
+		t.Fatalf("WriteText failed: %v", err)
+	}
+
+	want := `# HELP netgear_up Whether the last scrape of the switch succeeded (1) or failed (0).
+# TYPE netgear_up gauge
+netgear_up{switch="a",model="GS308EPP"} 1
+# HELP netgear_poe_power_watts Power drawn by the device on a POE port.
+# TYPE netgear_poe_power_watts gauge
+netgear_poe_power_watts{switch="a",port="1",port_name="cam \"lobby\""} 4.5
+netgear_poe_power_watts{switch="a",port="2",port_name=""} +Inf
+# HELP netgear_request_duration_seconds Latency of requests to switch pages.
+# TYPE netgear_request_duration_seconds histogram
+netgear_request_duration_seconds_bucket{switch="a",endpoint="/x",le="+Inf"} 3
+netgear_request_duration_seconds_sum{switch="a",endpoint="/x"} 0.25
+`
+	if out.String() != want {
+		t.Errorf("WriteText wrote:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+func TestWriteTextRejectsUnknownMetric(t *testing.T) {
+	var out strings.Builder
+	if err := WriteText(&out, []Sample{{Metric: "netgear_unknown", Value: 1}}); err == nil {
+		t.Fatal("expected an error for a metric outside the contract")
+	}
+	if out.Len() != 0 {
+		t.Errorf("expected nothing written, got %q", out.String())
+	}
+}
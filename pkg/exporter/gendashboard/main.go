@@ -0,0 +1,31 @@
+// Command gendashboard writes the Grafana dashboard generated from the
+// exporter metric contract. Run it through go generate in pkg/exporter.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/gherlein/go-netgear/pkg/exporter"
+)
+
+func main() {
+	output := flag.String("o", "", "file to write (default: standard output)")
+	flag.Parse()
+
+	dashboard, err := exporter.Dashboard()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if *output == "" {
+		os.Stdout.Write(dashboard)
+		return
+	}
+	if err := os.WriteFile(*output, dashboard, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
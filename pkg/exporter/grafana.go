@@ -0,0 +1,175 @@
+package exporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// DashboardUID is the stable uid of the generated dashboard, so re-importing
+// it replaces the previous version instead of creating a copy
+const DashboardUID = "netgear-switches"
+
+// datasource refers to the Prometheus data source chosen at import time
+var datasource = grafanaDatasource{Type: "prometheus", UID: "${DS_PROMETHEUS}"}
+
+type grafanaDashboard struct {
+	Inputs        []grafanaInput    `json:"__inputs"`
+	UID           string            `json:"uid"`
+	Title         string            `json:"title"`
+	Tags          []string          `json:"tags"`
+	SchemaVersion int               `json:"schemaVersion"`
+	Version       int               `json:"version"`
+	Editable      bool              `json:"editable"`
+	Refresh       string            `json:"refresh"`
+	Time          grafanaTimeRange  `json:"time"`
+	Templating    grafanaTemplating `json:"templating"`
+	Panels        []grafanaPanel    `json:"panels"`
+}
+
+type grafanaInput struct {
+	Name       string `json:"name"`
+	Label      string `json:"label"`
+	Type       string `json:"type"`
+	PluginID   string `json:"pluginId"`
+	PluginName string `json:"pluginName"`
+}
+
+type grafanaDatasource struct {
+	Type string `json:"type"`
+	UID  string `json:"uid"`
+}
+
+type grafanaTimeRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type grafanaTemplating struct {
+	List []grafanaVariable `json:"list"`
+}
+
+type grafanaVariable struct {
+	Name       string            `json:"name"`
+	Label      string            `json:"label"`
+	Type       string            `json:"type"`
+	Datasource grafanaDatasource `json:"datasource"`
+	Query      string            `json:"query"`
+	Multi      bool              `json:"multi"`
+	IncludeAll bool              `json:"includeAll"`
+	Refresh    int               `json:"refresh"`
+}
+
+type grafanaPanel struct {
+	ID          int                `json:"id"`
+	Type        string             `json:"type"`
+	Title       string             `json:"title"`
+	Description string             `json:"description"`
+	Datasource  grafanaDatasource  `json:"datasource"`
+	GridPos     grafanaGridPos     `json:"gridPos"`
+	FieldConfig grafanaFieldConfig `json:"fieldConfig"`
+	Targets     []grafanaTarget    `json:"targets"`
+}
+
+type grafanaGridPos struct {
+	H int `json:"h"`
+	W int `json:"w"`
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+type grafanaFieldConfig struct {
+	Defaults  grafanaFieldDefaults `json:"defaults"`
+	Overrides []struct{}           `json:"overrides"`
+}
+
+type grafanaFieldDefaults struct {
+	Unit string `json:"unit"`
+}
+
+type grafanaTarget struct {
+	RefID        string            `json:"refId"`
+	Datasource   grafanaDatasource `json:"datasource"`
+	Expr         string            `json:"expr"`
+	LegendFormat string            `json:"legendFormat"`
+}
+
+// Dashboard generates a Grafana dashboard with one panel per contract metric,
+// ready to import with a Prometheus data source chosen at import time
+func Dashboard() ([]byte, error) {
+	dashboard := grafanaDashboard{
+		Inputs: []grafanaInput{{
+			Name:       "DS_PROMETHEUS",
+			Label:      "Prometheus",
+			Type:       "datasource",
+			PluginID:   "prometheus",
+			PluginName: "Prometheus",
+		}},
+		UID:           DashboardUID,
+		Title:         "Netgear Switches",
+		Tags:          []string{"netgear", "poe"},
+		SchemaVersion: 39,
+		Version:       1,
+		Editable:      true,
+		Refresh:       "1m",
+		Time:          grafanaTimeRange{From: "now-6h", To: "now"},
+		Templating: grafanaTemplating{List: []grafanaVariable{{
+			Name:       LabelSwitch,
+			Label:      "Switch",
+			Type:       "query",
+			Datasource: datasource,
+			Query:      fmt.Sprintf("label_values(%s, %s)", MetricUp, LabelSwitch),
+			Multi:      true,
+			IncludeAll: true,
+			Refresh:    2,
+		}}},
+	}
+
+	const width, height = 12, 8
+	for i, metric := range contract {
+		dashboard.Panels = append(dashboard.Panels, grafanaPanel{
+			ID:          i + 1,
+			Type:        "timeseries",
+			Title:       metric.Title,
+			Description: metric.Help,
+			Datasource:  datasource,
+			GridPos:     grafanaGridPos{H: height, W: width, X: (i % 2) * width, Y: (i / 2) * height},
+			FieldConfig: grafanaFieldConfig{Defaults: grafanaFieldDefaults{Unit: metric.Unit}, Overrides: []struct{}{}},
+			Targets: []grafanaTarget{{
+				RefID:        "A",
+				Datasource:   datasource,
+				Expr:         panelQuery(metric),
+				LegendFormat: legendFormat(metric),
+			}},
+		})
+	}
+
+	data, err := json.MarshalIndent(dashboard, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode dashboard: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// panelQuery returns the PromQL expression graphing metric for the selected switches
+func panelQuery(metric Metric) string {
+	selector := fmt.Sprintf(`{%s=~"$%s"}`, LabelSwitch, LabelSwitch)
+	switch metric.Type {
+	case Counter:
+		return fmt.Sprintf("rate(%s%s[$__rate_interval])", metric.Name, selector)
+	case Histogram:
+		return fmt.Sprintf("histogram_quantile(0.95, sum by (%s, le) (rate(%s_bucket%s[$__rate_interval])))",
+			strings.Join(metric.Labels, ", "), metric.Name, selector)
+	default:
+		return metric.Name + selector
+	}
+}
+
+// legendFormat names a series by its labels
+func legendFormat(metric Metric) string {
+	parts := make([]string, len(metric.Labels))
+	for i, label := range metric.Labels {
+		parts[i] = "{{" + label + "}}"
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,130 @@
+package exporter
+
+import (
+	"math/rand/v2"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gherlein/go-netgear/pkg/netgear"
+)
+
+// DefaultJitter is the fraction of a switch's interval by which each poll is
+// randomly moved earlier or later
+const DefaultJitter = 0.1
+
+// Scheduler decides when each switch of a fleet is polled. Every switch has
+// its own interval, so POE-critical switches can be read every few seconds
+// while closet switches are read every few minutes. Polls are spread out:
+// the first poll of each switch lands at a random point of its first
+// interval and every later one is shifted by up to Jitter of the interval,
+// so a large fleet never polls in synchronized bursts.
+type Scheduler struct {
+	// Jitter is the fraction of the interval a poll may move, between 0 and 1
+	Jitter float64
+
+	mu      sync.Mutex
+	targets map[string]*scheduledSwitch
+	// random returns a uniformly distributed value in [0, 1)
+	random func() float64
+}
+
+// scheduledSwitch is the poll interval and next due time of one switch
+type scheduledSwitch struct {
+	interval time.Duration
+	next     time.Time
+}
+
+// NewScheduler creates an empty scheduler with DefaultJitter
+func NewScheduler() *Scheduler {
+	return &Scheduler{
+		Jitter:  DefaultJitter,
+		targets: make(map[string]*scheduledSwitch),
+		random:  rand.Float64,
+	}
+}
+
+// ScheduleInventory creates a scheduler for the enabled switches of an
+// inventory, using each entry's PollInterval or defaultInterval
+func ScheduleInventory(inventory *netgear.Inventory, defaultInterval time.Duration, now time.Time) (*Scheduler, error) {
+	s := NewScheduler()
+	for _, entry := range inventory.Switches {
+		if entry.Disabled != nil {
+			continue
+		}
+		interval, err := entry.PollEvery(defaultInterval)
+		if err != nil {
+			return nil, err
+		}
+		s.Add(entry.Name, interval, now)
+	}
+	return s, nil
+}
+
+// Add schedules a switch to be polled every interval, replacing any earlier
+// schedule of the same name. The first poll is due at a random time within
+// one interval from now.
+func (s *Scheduler) Add(name string, interval time.Duration, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	offset := time.Duration(s.random() * float64(interval))
+	s.targets[name] = &scheduledSwitch{interval: interval, next: now.Add(offset)}
+}
+
+// Remove stops polling a switch
+func (s *Scheduler) Remove(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.targets, name)
+}
+
+// Interval returns the poll interval of a switch
+func (s *Scheduler) Interval(name string) (time.Duration, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	target, ok := s.targets[name]
+	if !ok {
+		return 0, false
+	}
+	return target.interval, true
+}
+
+// Due returns the switches whose poll is due at now, sorted by name, and
+// schedules their next poll one jittered interval after now. A poller that
+// fell behind therefore polls each late switch once rather than catching up.
+func (s *Scheduler) Due(now time.Time) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var due []string
+	for name, target := range s.targets {
+		if target.next.After(now) {
+			continue
+		}
+		due = append(due, name)
+		target.next = now.Add(s.jittered(target.interval))
+	}
+	sort.Strings(due)
+	return due
+}
+
+// Next returns when the earliest poll is due; ok is false if nothing is scheduled
+func (s *Scheduler) Next() (next time.Time, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, target := range s.targets {
+		if !ok || target.next.Before(next) {
+			next, ok = target.next, true
+		}
+	}
+	return next, ok
+}
+
+// jittered moves interval by a random amount of up to Jitter of it either way
+func (s *Scheduler) jittered(interval time.Duration) time.Duration {
+	jitter := min(max(s.Jitter, 0), 1)
+	return interval + time.Duration((2*s.random()-1)*jitter*float64(interval))
+}
@@ -0,0 +1,84 @@
+package exporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gherlein/go-netgear/pkg/netgear"
+)
+
+func TestScheduleInventory(t *testing.T) {
+	inventory := &netgear.Inventory{Switches: []netgear.InventoryEntry{
+		{Name: "poe-core", Addresses: []string{"10.0.0.2"}, PollInterval: "5s"},
+		{Name: "closet", Addresses: []string{"10.0.0.3"}},
+		{Name: "rma", Addresses: []string{"10.0.0.4"}, Disabled: &netgear.SwitchDisable{Reason: "RMA"}},
+	}}
+	start := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+
+	s, err := ScheduleInventory(inventory, 2*time.Minute, start)
+	if err != nil {
+		t.Fatalf("ScheduleInventory failed: %v", err)
+	}
+	if interval, _ := s.Interval("poe-core"); interval != 5*time.Second {
+		t.Errorf("poe-core interval = %v, want 5s", interval)
+	}
+	if interval, _ := s.Interval("closet"); interval != 2*time.Minute {
+		t.Errorf("closet interval = %v, want the default", interval)
+	}
+	if _, ok := s.Interval("rma"); ok {
+		t.Error("disabled switch was scheduled")
+	}
+
+	inventory.Switches[0].PollInterval = "often"
+	if _, err := ScheduleInventory(inventory, time.Minute, start); err == nil {
+		t.Error("expected an error for an invalid poll interval")
+	}
+}
+
+func TestSchedulerJitter(t *testing.T) {
+	start := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	s := NewScheduler()
+	for _, name := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		s.Add(name, time.Minute, start)
+	}
+
+	// First polls are spread over the first interval rather than all due at once
+	if due := s.Due(start.Add(-time.Nanosecond)); len(due) != 0 {
+		t.Errorf("polls due before the start: %v", due)
+	}
+	if due := s.Due(start.Add(time.Minute)); len(due) != 8 {
+		t.Fatalf("expected every switch due within one interval, got %v", due)
+	}
+
+	polls := 0
+	now := start.Add(time.Minute)
+	for step := 0; step < 600; step++ {
+		now = now.Add(time.Second)
+		for _, name := range s.Due(now) {
+			polls++
+			next, _ := s.Next()
+			if next.Before(now) {
+				t.Fatalf("%s: next poll %v scheduled in the past", name, next)
+			}
+		}
+	}
+	// Ten minutes of eight switches at one minute +/- 10%
+	if polls < 8*9 || polls > 8*12 {
+		t.Errorf("unexpected number of polls %d", polls)
+	}
+}
+
+func TestSchedulerJitterBounds(t *testing.T) {
+	s := NewScheduler()
+	for _, r := range []float64{0, 0.5, 0.999} {
+		s.random = func() float64 { return r }
+		got := s.jittered(100 * time.Second)
+		if got < 90*time.Second || got > 110*time.Second {
+			t.Errorf("random %v: jittered interval %v outside +/-10%%", r, got)
+		}
+	}
+	s.Jitter = 0
+	if got := s.jittered(time.Minute); got != time.Minute {
+		t.Errorf("expected no jitter, got %v", got)
+	}
+}
@@ -0,0 +1,96 @@
+// Package i18n is a minimal message catalog for user-facing text. Messages are
+// looked up by ID in the current language, falling back to English and then to
+// the ID itself, so a missing translation never hides a message.
+//
+// The language defaults to NETGEAR_LANG, then LC_ALL, LC_MESSAGES and LANG.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Language is an ISO 639-1 language code
+type Language string
+
+const (
+	English Language = "en"
+	German  Language = "de"
+)
+
+var (
+	mu       sync.RWMutex
+	current  = DetectLanguage()
+	catalogs = make(map[Language]map[string]string)
+)
+
+// Register adds messages for a language, replacing any with the same ID
+func Register(lang Language, messages map[string]string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	catalog := catalogs[lang]
+	if catalog == nil {
+		catalog = make(map[string]string)
+		catalogs[lang] = catalog
+	}
+	for id, text := range messages {
+		catalog[id] = text
+	}
+}
+
+// SetLanguage selects the language T uses
+func SetLanguage(lang Language) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = lang
+}
+
+// CurrentLanguage returns the language T uses
+func CurrentLanguage() Language {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// DetectLanguage derives the language from the environment, defaulting to English
+func DetectLanguage() Language {
+	for _, name := range []string{"NETGEAR_LANG", "LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := os.Getenv(name)
+		if value == "" || value == "C" || value == "POSIX" {
+			continue
+		}
+		// "de_DE.UTF-8" -> "de"
+		code, _, _ := strings.Cut(value, ".")
+		code, _, _ = strings.Cut(code, "_")
+		code, _, _ = strings.Cut(code, "-")
+		return Language(strings.ToLower(code))
+	}
+	return English
+}
+
+// T returns the message with the given ID in the current language, formatted
+// with args when any are given
+func T(id string, args ...any) string {
+	return TIn(CurrentLanguage(), id, args...)
+}
+
+// TIn returns the message with the given ID in lang
+func TIn(lang Language, id string, args ...any) string {
+	mu.RLock()
+	text, ok := catalogs[lang][id]
+	if !ok {
+		text, ok = catalogs[English][id]
+	}
+	mu.RUnlock()
+
+	if !ok {
+		text = id
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(text, args...)
+	}
+	return text
+}
@@ -0,0 +1,33 @@
+package i18n
+
+import "testing"
+
+func TestTFallsBack(t *testing.T) {
+	Register(English, map[string]string{"greeting": "hello %s", "only.en": "english only"})
+	Register(German, map[string]string{"greeting": "hallo %s"})
+
+	if got := TIn(German, "greeting", "welt"); got != "hallo welt" {
+		t.Errorf("expected German message, got %q", got)
+	}
+	if got := TIn(German, "only.en"); got != "english only" {
+		t.Errorf("expected English fallback, got %q", got)
+	}
+	if got := TIn(German, "missing.id"); got != "missing.id" {
+		t.Errorf("expected ID fallback, got %q", got)
+	}
+}
+
+func TestDetectLanguage(t *testing.T) {
+	t.Setenv("NETGEAR_LANG", "")
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "de_DE.UTF-8")
+	if got := DetectLanguage(); got != German {
+		t.Errorf("expected de, got %q", got)
+	}
+
+	t.Setenv("NETGEAR_LANG", "en")
+	if got := DetectLanguage(); got != English {
+		t.Errorf("expected NETGEAR_LANG to take precedence, got %q", got)
+	}
+}
@@ -24,19 +24,23 @@ type TokenManager interface {
 
 // MemoryTokenManager stores tokens in memory
 type MemoryTokenManager struct {
-	tokens map[string]tokenData
-	mu     sync.RWMutex
+	tokens   map[string]tokenData
+	quirks   map[string]Quirks
+	metadata map[string]*SwitchMetadata
+	mu       sync.RWMutex
 }
 
 type tokenData struct {
 	token string
 	model Model
+	info  TokenInfo
 }
 
 // NewMemoryTokenManager creates a new in-memory token manager
 func NewMemoryTokenManager() *MemoryTokenManager {
 	return &MemoryTokenManager{
 		tokens: make(map[string]tokenData),
+		quirks: make(map[string]Quirks),
 	}
 }
 
@@ -150,7 +154,7 @@ func (m *FileTokenManager) StoreToken(ctx context.Context, address string, token
 	content := fmt.Sprintf("%s:%s", string(model), token)
 
 	// Write token with secure permissions (readable by owner only)
-	err := os.WriteFile(tokenFile, []byte(content), 0600)
+	err := writeFileAtomic(tokenFile, []byte(content), 0600)
 	if err != nil {
 		return NewAuthError("failed to write token file", err)
 	}
@@ -158,6 +162,29 @@ func (m *FileTokenManager) StoreToken(ctx context.Context, address string, token
 	return nil
 }
 
+// writeFileAtomic writes data to a temporary file and renames it into place, so
+// concurrent writers and readers never see a partially written file
+func writeFileAtomic(filename string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(filename), filepath.Base(filename)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filename)
+}
+
 // DeleteToken removes a stored token file
 func (m *FileTokenManager) DeleteToken(ctx context.Context, address string) error {
 	tokenFile := m.getTokenFilename(address)
@@ -166,6 +193,7 @@ func (m *FileTokenManager) DeleteToken(ctx context.Context, address string) erro
 	if err != nil && !os.IsNotExist(err) {
 		return NewAuthError("failed to delete token file", err)
 	}
+	os.Remove(m.getTokenInfoFilename(address))
 
 	return nil
 }
@@ -193,6 +221,8 @@ func (m *FileTokenManager) ClearAllTokens() error {
 	if err != nil {
 		return NewAuthError("failed to list token files", err)
 	}
+	infoFiles, _ := filepath.Glob(filepath.Join(m.cacheDir, "netgear-token-*.info.json"))
+	files = append(files, infoFiles...)
 
 	// Remove each token file
 	var lastErr error
@@ -213,14 +243,14 @@ func (m *FileTokenManager) ClearAllTokens() error {
 type AuthenticationType string
 
 const (
-	AuthTypeSession AuthenticationType = "session" // Cookie-based (30x series)
+	AuthTypeSession AuthenticationType = "session" // Cookie-based (30x series, smart managed pro)
 	AuthTypeGambit  AuthenticationType = "gambit"  // URL parameter-based (316 series)
 )
 
 // GetAuthenticationType returns the authentication type for a model
 func GetAuthenticationType(model Model) AuthenticationType {
-	if model.IsModel316() {
-		return AuthTypeGambit
+	if spec, ok := knownModels[model]; ok {
+		return spec.family.authType
 	}
 	return AuthTypeSession
 }
\ No newline at end of file
@@ -0,0 +1,114 @@
+package netgear
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PortResult is the outcome of one port in a batch operation
+type PortResult struct {
+	PortID int
+	Err    error // nil on success, ErrNotAttempted if skipped after a fail-fast stop
+}
+
+// MultiError is returned by batch operations (multi-port UpdatePort, CyclePower)
+// when at least one port failed. Every requested port has a result, so callers
+// know exactly which changes were applied. errors.Is and errors.As match
+// against the individual port errors.
+type MultiError struct {
+	Operation string
+	Results   []PortResult
+}
+
+func (e *MultiError) Error() string {
+	failed := e.Failed()
+	parts := make([]string, 0, len(failed))
+	for _, result := range failed {
+		parts = append(parts, fmt.Sprintf("port %d: %v", result.PortID, result.Err))
+	}
+	return fmt.Sprintf("%s failed for %d of %d ports: %s",
+		e.Operation, len(failed), len(e.Results), strings.Join(parts, "; "))
+}
+
+// Unwrap returns the individual port errors
+func (e *MultiError) Unwrap() []error {
+	var errs []error
+	for _, result := range e.Results {
+		if result.Err != nil {
+			errs = append(errs, result.Err)
+		}
+	}
+	return errs
+}
+
+// Failed returns the results of ports that failed or were not attempted
+func (e *MultiError) Failed() []PortResult {
+	var failed []PortResult
+	for _, result := range e.Results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Succeeded returns the ports whose change was applied
+func (e *MultiError) Succeeded() []int {
+	var succeeded []int
+	for _, result := range e.Results {
+		if result.Err == nil {
+			succeeded = append(succeeded, result.PortID)
+		}
+	}
+	return succeeded
+}
+
+// WithFailFast makes batch operations stop at the first failing port instead of
+// attempting every port. Remaining ports are reported with ErrNotAttempted.
+func WithFailFast(enabled bool) ClientOption {
+	return func(c *Client) {
+		c.failFast = enabled
+	}
+}
+
+// batch collects per-port results of a multi-port operation
+type batch struct {
+	operation string
+	failFast  bool
+	failed    bool
+	results   []PortResult
+}
+
+// newBatch starts collecting results for an operation
+func (c *Client) newBatch(operation string) *batch {
+	return &batch{operation: operation, failFast: c.failFast}
+}
+
+// stopped reports whether remaining ports should be skipped
+func (b *batch) stopped() bool {
+	return b.failFast && b.failed
+}
+
+// record stores the outcome for a port; skip records a port not attempted
+func (b *batch) record(portID int, err error) {
+	if err != nil {
+		b.failed = true
+	}
+	b.results = append(b.results, PortResult{PortID: portID, Err: err})
+}
+
+func (b *batch) skip(portID int) {
+	b.results = append(b.results, PortResult{PortID: portID, Err: ErrNotAttempted.WithPort(portID)})
+}
+
+// err returns nil if every port succeeded. A single-port operation returns the
+// port's error directly; larger batches return a *MultiError.
+func (b *batch) err() error {
+	if !b.failed {
+		return nil
+	}
+	if len(b.results) == 1 {
+		return b.results[0].Err
+	}
+	return &MultiError{Operation: b.operation, Results: b.results}
+}
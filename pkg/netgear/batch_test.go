@@ -0,0 +1,87 @@
+package netgear
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newBatchTestClient returns a client whose switch rejects any POST for port 2
+func newBatchTestClient(t *testing.T, opts ...ClientOption) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Method == "POST" && r.Form.Get("port") == "2" {
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte("<html></html>"))
+	}))
+	t.Cleanup(server.Close)
+
+	ctx := context.Background()
+	address := strings.TrimPrefix(server.URL, "http://")
+	tokenMgr := NewMemoryTokenManager()
+	tokenMgr.StoreToken(ctx, address, "token", ModelGS308EPP)
+
+	opts = append([]ClientOption{WithTokenManager(tokenMgr), WithPasswordManager(nil)}, opts...)
+	client, err := NewClient(address, opts...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	return client
+}
+
+func TestBatchAttemptsAllPorts(t *testing.T) {
+	client := newBatchTestClient(t)
+
+	err := client.POE().CyclePower(context.Background(), 1, 2, 3)
+	var multiErr *MultiError
+	if !errors.As(err, &multiErr) {
+		t.Fatalf("Expected *MultiError, got %v", err)
+	}
+
+	if succeeded := multiErr.Succeeded(); len(succeeded) != 2 || succeeded[0] != 1 || succeeded[1] != 3 {
+		t.Errorf("Expected ports 1 and 3 to succeed, got %v", succeeded)
+	}
+	failed := multiErr.Failed()
+	if len(failed) != 1 || failed[0].PortID != 2 {
+		t.Fatalf("Expected port 2 to fail, got %v", failed)
+	}
+
+	var netgearErr *Error
+	if !errors.As(err, &netgearErr) || netgearErr.PortID != 2 || netgearErr.HTTPStatus != http.StatusInternalServerError {
+		t.Errorf("Expected port error context via errors.As, got %+v", netgearErr)
+	}
+}
+
+func TestBatchFailFast(t *testing.T) {
+	client := newBatchTestClient(t, WithFailFast(true))
+
+	err := client.POE().CyclePower(context.Background(), 1, 2, 3)
+	var multiErr *MultiError
+	if !errors.As(err, &multiErr) {
+		t.Fatalf("Expected *MultiError, got %v", err)
+	}
+
+	if len(multiErr.Results) != 3 || !errors.Is(multiErr.Results[2].Err, ErrNotAttempted) {
+		t.Errorf("Expected port 3 to be reported as not attempted, got %v", multiErr.Results)
+	}
+}
+
+func TestBatchSinglePortReturnsPortError(t *testing.T) {
+	client := newBatchTestClient(t)
+
+	err := client.POE().CyclePower(context.Background(), 2)
+	var multiErr *MultiError
+	if errors.As(err, &multiErr) {
+		t.Fatal("Expected the port error itself for a single-port operation")
+	}
+	if err == nil {
+		t.Fatal("Expected an error for port 2")
+	}
+}
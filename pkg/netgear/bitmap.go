@@ -0,0 +1,27 @@
+package netgear
+
+import "github.com/gherlein/go-netgear/pkg/netgear/internal"
+
+// GS316PortCount is the number of positions in the port bitmaps posted to GS316 forms
+const GS316PortCount = 16
+
+// PortsToBitmap builds the '0'/'1' port bitmap used by GS316 forms (PoePort,
+// untagPorts, tagPorts), where the first character is port 1. It fails if a
+// port is outside 1..portCount.
+func PortsToBitmap(ports []int, portCount int) (string, error) {
+	bitmap, err := internal.PortsToBitmap(ports, portCount)
+	if err != nil {
+		return "", NewOperationError("invalid port bitmap", err)
+	}
+	return bitmap, nil
+}
+
+// BitmapToPorts returns the sorted port IDs selected in a '0'/'1' port bitmap.
+// It fails on any character other than '0' or '1'.
+func BitmapToPorts(bitmap string) ([]int, error) {
+	ports, err := internal.BitmapToPorts(bitmap)
+	if err != nil {
+		return nil, NewParsingError("invalid port bitmap", err)
+	}
+	return ports, nil
+}
@@ -0,0 +1,107 @@
+package netgear
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BusyRetryPolicy bounds how a client waits out a switch that answers HTTP
+// 503 or a "busy" page, as firmware does while it commits configuration
+type BusyRetryPolicy struct {
+	// MaxAttempts is the number of times a request is sent, including the
+	// first; 1 or less disables retries
+	MaxAttempts int
+	// DefaultWait is the wait before a retry when the switch gave no Retry-After
+	DefaultWait time.Duration
+	// MaxWait caps a single wait. A Retry-After longer than MaxWait ends the
+	// retries, since the switch asked not to be contacted sooner.
+	MaxWait time.Duration
+}
+
+// DefaultBusyRetryPolicy is the policy of clients without WithBusyRetry
+var DefaultBusyRetryPolicy = BusyRetryPolicy{
+	MaxAttempts: 4,
+	DefaultWait: 2 * time.Second,
+	MaxWait:     30 * time.Second,
+}
+
+// WithBusyRetry sets how requests answered with HTTP 503 or a busy page are
+// retried. Requests that stay busy fail with an error matching ErrSwitchBusy.
+func WithBusyRetry(policy BusyRetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.busyRetry = policy
+	}
+}
+
+// wait returns how long to wait before retrying after a busy answer with
+// the given Retry-After hint (0 if none), and false if the policy gives up
+func (p BusyRetryPolicy) wait(attempt int, hint time.Duration) (time.Duration, bool) {
+	if attempt >= p.MaxAttempts {
+		return 0, false
+	}
+	wait := p.DefaultWait
+	if hint > 0 {
+		wait = hint
+	}
+	if p.MaxWait > 0 && wait > p.MaxWait {
+		return 0, false
+	}
+	return wait, true
+}
+
+// retryAfter is the cause of a busy error, carrying the switch's Retry-After hint
+type retryAfter time.Duration
+
+func (r retryAfter) Error() string {
+	if r <= 0 {
+		return "no retry hint"
+	}
+	return fmt.Sprintf("retry after %s", time.Duration(r))
+}
+
+// newBusyError returns an error matching ErrSwitchBusy for a busy answer
+func newBusyError(status int, hint time.Duration) *Error {
+	return NewNetworkError(ErrSwitchBusy.Message, retryAfter(hint)).WithHTTPStatus(status)
+}
+
+// busyMarkers are phrases of the pages firmware serves instead of the
+// requested one while it is busy
+var busyMarkers = []string{
+	"system is busy",
+	"device is busy",
+	"server is busy",
+	"switch is busy",
+}
+
+// isBusyPage reports whether body is a busy page rather than the requested page
+func isBusyPage(body string) bool {
+	body = strings.ToLower(body)
+	for _, marker := range busyMarkers {
+		if strings.Contains(body, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseRetryAfter returns the wait a Retry-After header asks for, given in
+// seconds or as an HTTP date relative to now, or 0 if there is none
+func parseRetryAfter(resp *http.Response, now time.Time) time.Duration {
+	value := strings.TrimSpace(resp.Header.Get("Retry-After"))
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
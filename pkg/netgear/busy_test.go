@@ -0,0 +1,122 @@
+package netgear
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sleepRecorder is a real clock whose sleeps return at once and are recorded
+type sleepRecorder struct {
+	realClock
+	sleeps []time.Duration
+}
+
+func (c *sleepRecorder) Sleep(ctx context.Context, d time.Duration) error {
+	c.sleeps = append(c.sleeps, d)
+	return ctx.Err()
+}
+
+func TestBusyRetry(t *testing.T) {
+	// Each path answers busy as often as its count says, then the page
+	busyFor := map[string]int{"/503": 2, "/page": 1, "/date": 1, "/always": 100, "/long": 1}
+	requests := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		if requests[r.URL.Path] > busyFor[r.URL.Path] {
+			fmt.Fprint(w, "<html>ok</html>")
+			return
+		}
+		switch r.URL.Path {
+		case "/page":
+			fmt.Fprint(w, `<html><body>The system is busy, please try again later.</body></html>`)
+		case "/date":
+			w.Header().Set("Retry-After", time.Now().Add(20*time.Second).UTC().Format(http.TimeFormat))
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case "/long":
+			w.Header().Set("Retry-After", "600")
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.Header().Set("Retry-After", "5")
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+	address := strings.TrimPrefix(server.URL, "http://")
+
+	tests := []struct {
+		path     string
+		method   string
+		wantErr  bool
+		attempts int
+		sleeps   []time.Duration
+	}{
+		{"/503", "GET", false, 3, []time.Duration{5 * time.Second, 5 * time.Second}},
+		{"/page", "POST", false, 2, []time.Duration{DefaultBusyRetryPolicy.DefaultWait}},
+		{"/always", "GET", true, DefaultBusyRetryPolicy.MaxAttempts, []time.Duration{5 * time.Second, 5 * time.Second, 5 * time.Second}},
+		{"/long", "GET", true, 1, nil},
+	}
+	for _, tt := range tests {
+		clock := &sleepRecorder{}
+		client, err := NewClient(address, append(factoryClientOptions(address), WithClock(clock))...)
+		if err != nil {
+			t.Fatalf("NewClient failed: %v", err)
+		}
+
+		body, err := client.makeAuthenticatedRequest(context.Background(), tt.method, tt.path, nil)
+		if tt.wantErr {
+			if !errors.Is(err, ErrSwitchBusy) {
+				t.Errorf("%s: expected ErrSwitchBusy, got %v", tt.path, err)
+			}
+		} else if err != nil || body != "<html>ok</html>" {
+			t.Errorf("%s: expected the page after retrying, got %q (%v)", tt.path, body, err)
+		}
+		if requests[tt.path] != tt.attempts {
+			t.Errorf("%s: expected %d attempts, got %d", tt.path, tt.attempts, requests[tt.path])
+		}
+		if fmt.Sprint(clock.sleeps) != fmt.Sprint(tt.sleeps) {
+			t.Errorf("%s: expected waits %v, got %v", tt.path, tt.sleeps, clock.sleeps)
+		}
+	}
+
+	// An HTTP date is converted to a wait relative to now
+	clock := &sleepRecorder{}
+	client, err := NewClient(address, append(factoryClientOptions(address), WithClock(clock))...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	if _, err := client.makeAuthenticatedRequest(context.Background(), "GET", "/date", nil); err != nil {
+		t.Fatalf("expected /date to succeed after one retry, got %v", err)
+	}
+	if len(clock.sleeps) != 1 || clock.sleeps[0] < 10*time.Second || clock.sleeps[0] > 20*time.Second {
+		t.Errorf("expected a wait of about 20s, got %v", clock.sleeps)
+	}
+}
+
+func TestBusyRetryDisabled(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	address := strings.TrimPrefix(server.URL, "http://")
+
+	client, err := NewClient(address, append(factoryClientOptions(address), WithBusyRetry(BusyRetryPolicy{MaxAttempts: 1}))...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	_, err = client.makeAuthenticatedRequest(context.Background(), "GET", "/dashboard.cgi", nil)
+	var netgearErr *Error
+	if !errors.Is(err, ErrSwitchBusy) || !errors.As(err, &netgearErr) || netgearErr.HTTPStatus != http.StatusServiceUnavailable {
+		t.Errorf("expected ErrSwitchBusy with HTTP 503, got %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("expected a single attempt, got %d", requests)
+	}
+}
@@ -0,0 +1,150 @@
+package netgear
+
+// FormStyle describes how a model's configuration forms address ports
+type FormStyle string
+
+const (
+	// FormStylePerPort forms carry one port per request plus the page's security hash (GS30x, smart managed pro)
+	FormStylePerPort FormStyle = "per_port"
+	// FormStyleBitmap forms select ports with a PortList bitmap and carry no hash (GS316)
+	FormStyleBitmap FormStyle = "bitmap"
+)
+
+// ModelCapabilities describes what a supported model offers; it is the single
+// source the library and the test suite consult for model differences
+type ModelCapabilities struct {
+	Model Model `json:"model"`
+	// Family names the firmware family sharing pages and authentication
+	Family string `json:"family"`
+	// PortCount is the number of switch ports, 0 when unknown
+	PortCount int `json:"port_count"`
+	// POEBudgetW is the datasheet total POE budget, 0 when unknown or without POE
+	POEBudgetW float64 `json:"poe_budget_w"`
+	// MaxPortPowerW is the highest power limit of a single POE port, 0 without POE
+	MaxPortPowerW float64                       `json:"max_port_power_w"`
+	AuthType      AuthenticationType            `json:"auth_type"`
+	FormStyle     FormStyle                     `json:"form_style"`
+	Endpoints     map[EndpointType]EndpointInfo `json:"endpoints"`
+}
+
+// HasPOE returns true if the model has POE ports
+func (mc ModelCapabilities) HasPOE() bool {
+	return mc.MaxPortPowerW > 0
+}
+
+// HasVLANs returns true if the model's 802.1Q VLAN configuration, membership
+// and PVID pages are known
+func (mc ModelCapabilities) HasVLANs() bool {
+	_, config := mc.Endpoints[EndpointVLANConfig]
+	_, membership := mc.Endpoints[EndpointVLANMembership]
+	_, pvid := mc.Endpoints[EndpointVLANPVID]
+	return config && membership && pvid
+}
+
+// modelFamily groups models that share endpoints and authentication
+type modelFamily struct {
+	name      string
+	authType  AuthenticationType
+	formStyle FormStyle
+	endpoints map[EndpointType]EndpointInfo
+}
+
+var (
+	familyGS30x = &modelFamily{
+		name:      "gs30x",
+		authType:  AuthTypeSession,
+		formStyle: FormStylePerPort,
+		endpoints: gs30xEndpoints,
+	}
+	familyGS316 = &modelFamily{
+		name:      "gs316",
+		authType:  AuthTypeGambit,
+		formStyle: FormStyleBitmap,
+		endpoints: gs316Endpoints,
+	}
+	// familySmartPro is the "smart managed pro" GS108T/GS110TP family
+	familySmartPro = &modelFamily{
+		name:      "smart_pro",
+		authType:  AuthTypeSession,
+		formStyle: FormStylePerPort,
+		endpoints: smartProEndpoints,
+	}
+)
+
+// modelSpec describes a supported model
+type modelSpec struct {
+	family  *modelFamily
+	ports   int     // 0 when unknown
+	budgetW float64 // datasheet POE budget, 0 when unknown
+	portW   float64 // highest per-port POE power, 0 for models without POE
+}
+
+// knownModels lists every supported model; adding a model only requires an
+// entry here. Budgets are the total POE budgets of the NETGEAR datasheets.
+var knownModels = map[Model]modelSpec{
+	ModelGS305EP:  {family: familyGS30x, ports: 5, budgetW: 63, portW: POEPlusPortPowerW},
+	ModelGS305EPP: {family: familyGS30x, ports: 5, budgetW: 83, portW: POEPlusPortPowerW},
+	ModelGS308EP:  {family: familyGS30x, ports: 8, budgetW: 62, portW: POEPlusPortPowerW},
+	ModelGS308EPP: {family: familyGS30x, ports: 8, budgetW: 123, portW: POEPlusPortPowerW},
+	ModelGS30xEPx: {family: familyGS30x, portW: POEPlusPortPowerW}, // 30x switch whose exact model is not yet known
+	ModelGS316EP:  {family: familyGS316, ports: 16, budgetW: 180, portW: POEPlusPortPowerW},
+	ModelGS316EPP: {family: familyGS316, ports: 16, budgetW: 231, portW: POEPlusPortPowerW},
+	ModelGS108Tv3: {family: familySmartPro, ports: 8},
+	ModelGS110TP:  {family: familySmartPro, ports: 10, budgetW: 50, portW: POEPlusPortPowerW}, // 8 POE ports and 2 SFP ports
+	ModelMS108EUP: {family: familyGS30x, ports: 8, budgetW: 230, portW: Ultra60PortPowerW},
+	ModelMS108TUP: {family: familySmartPro, ports: 8, budgetW: 480, portW: Ultra60PortPowerW},
+}
+
+// poeEndpoints are the endpoint types that only exist on models with POE
+var poeEndpoints = map[EndpointType]bool{
+	EndpointPOEStatus:   true,
+	EndpointPOESettings: true,
+	EndpointPOEUpdate:   true,
+	EndpointPOEOverview: true,
+}
+
+// endpoint returns the model's page for an operation
+func (s modelSpec) endpoint(endpointType EndpointType) EndpointInfo {
+	if s.family == nil || (s.portW == 0 && poeEndpoints[endpointType]) {
+		return EndpointInfo{URL: "", Supported: false}
+	}
+	info, ok := s.family.endpoints[endpointType]
+	if !ok {
+		return EndpointInfo{URL: "", Supported: false}
+	}
+	return info
+}
+
+// Capabilities returns the capabilities of a supported model; ok is false for
+// unknown models
+func (m Model) Capabilities() (caps ModelCapabilities, ok bool) {
+	spec, ok := knownModels[m]
+	if !ok {
+		return ModelCapabilities{}, false
+	}
+	endpoints := make(map[EndpointType]EndpointInfo, len(spec.family.endpoints))
+	for endpointType := range spec.family.endpoints {
+		if info := spec.endpoint(endpointType); info.Supported {
+			endpoints[endpointType] = info
+		}
+	}
+	return ModelCapabilities{
+		Model:         m,
+		Family:        spec.family.name,
+		PortCount:     spec.ports,
+		POEBudgetW:    spec.budgetW,
+		MaxPortPowerW: spec.portW,
+		AuthType:      spec.family.authType,
+		FormStyle:     spec.family.formStyle,
+		Endpoints:     endpoints,
+	}, true
+}
+
+// FormStyle returns how the model's configuration forms address ports,
+// FormStylePerPort for unknown models
+func (m Model) FormStyle() FormStyle {
+	if spec, ok := knownModels[m]; ok {
+		return spec.family.formStyle
+	}
+	return FormStylePerPort
+}
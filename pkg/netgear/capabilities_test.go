@@ -0,0 +1,62 @@
+package netgear
+
+import "testing"
+
+func TestModelCapabilities(t *testing.T) {
+	tests := []struct {
+		model     Model
+		ports     int
+		budgetW   float64
+		portW     float64
+		authType  AuthenticationType
+		formStyle FormStyle
+	}{
+		{ModelGS305EPP, 5, 83, POEPlusPortPowerW, AuthTypeSession, FormStylePerPort},
+		{ModelGS316EP, 16, 180, POEPlusPortPowerW, AuthTypeGambit, FormStyleBitmap},
+		{ModelGS108Tv3, 8, 0, 0, AuthTypeSession, FormStylePerPort},
+		{ModelMS108TUP, 8, 480, Ultra60PortPowerW, AuthTypeSession, FormStylePerPort},
+	}
+	for _, tt := range tests {
+		caps, ok := tt.model.Capabilities()
+		if !ok {
+			t.Fatalf("%s: expected capabilities", tt.model)
+		}
+		if caps.PortCount != tt.ports || caps.POEBudgetW != tt.budgetW || caps.MaxPortPowerW != tt.portW {
+			t.Errorf("%s: got %d ports, %vW budget, %vW per port", tt.model, caps.PortCount, caps.POEBudgetW, caps.MaxPortPowerW)
+		}
+		if caps.AuthType != tt.authType || GetAuthenticationType(tt.model) != tt.authType {
+			t.Errorf("%s: auth type = %s, want %s", tt.model, caps.AuthType, tt.authType)
+		}
+		if caps.FormStyle != tt.formStyle || tt.model.FormStyle() != tt.formStyle {
+			t.Errorf("%s: form style = %s, want %s", tt.model, caps.FormStyle, tt.formStyle)
+		}
+		if _, hasPOE := caps.Endpoints[EndpointPOEStatus]; hasPOE != caps.HasPOE() {
+			t.Errorf("%s: POE status endpoint listed = %v, HasPOE = %v", tt.model, hasPOE, caps.HasPOE())
+		}
+		if !caps.HasVLANs() {
+			t.Errorf("%s: expected the VLAN pages to be known", tt.model)
+		}
+		if len(caps.Endpoints) != len(NewEndpointRegistry(tt.model).GetSupportedEndpoints()) {
+			t.Errorf("%s: capabilities and endpoint registry list different endpoints", tt.model)
+		}
+	}
+
+	if _, ok := Model("GS724T").Capabilities(); ok {
+		t.Error("expected no capabilities for an unknown model")
+	}
+	if NewEndpointRegistry(Model("GS724T")).IsEndpointSupported(EndpointLogin) {
+		t.Error("expected no endpoints for an unknown model")
+	}
+}
+
+func TestEveryModelHasAFamily(t *testing.T) {
+	for _, model := range SupportedModels() {
+		caps, _ := model.Capabilities()
+		if caps.Family == "" || !NewEndpointRegistry(model).IsEndpointSupported(EndpointLogin) {
+			t.Errorf("%s: missing family or login endpoint", model)
+		}
+		if _, ok := POEBudgetW(model); ok && !model.HasPOE() {
+			t.Errorf("%s: POE budget on a model without POE", model)
+		}
+	}
+}
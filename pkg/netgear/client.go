@@ -2,26 +2,71 @@ package netgear
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/gherlein/go-netgear/pkg/netgear/fixture"
 	"github.com/gherlein/go-netgear/pkg/netgear/internal"
+	"github.com/gherlein/go-netgear/pkg/store"
 )
 
-// Client represents a connection to a Netgear switch
+// Client represents a connection to a Netgear switch.
+//
+// A Client is safe for concurrent use by multiple goroutines. Logins are
+// serialized, and the session token, discovered quirks, firmware version and
+// metrics are guarded internally. Use Clone for a per-goroutine client that
+// shares the session but nothing else.
+//
+// A switch accepts a single management session at a time, so a login by one
+// Client invalidates the session of every other Client talking to the same
+// switch. Use WithSharedSession for clients in one process that target the
+// same address.
 type Client struct {
-	address     string
-	model       Model
-	httpClient  *internal.HTTPClient
-	token       string
-	tokenMgr    TokenManager
-	passwordMgr PasswordManager
-	detector    *internal.ModelDetector
-	endpoints   *EndpointRegistry
-	verbose     bool
+	mu            sync.RWMutex // guards quirks, hashes and firmware
+	closeOnce     sync.Once    // releases a shared session once
+	address       string
+	model         Model
+	httpClient    *internal.HTTPClient
+	session       *session
+	sharedSession bool
+	tokenMgr      TokenManager
+	passwordMgr   PasswordManager
+	passwords     PasswordProvider // takes precedence over passwordMgr
+	detector      *internal.ModelDetector
+	modelHint     Model // used instead of detecting the model, empty to detect
+	endpoints     *EndpointRegistry
+	quirks        *Quirks
+	hashes        map[string]string // CSRF hash per form page
+	metrics       *metricsRecorder
+	history       *historyRecorder
+	pages         *pageCache // validated GET responses, nil when conditional requests are off
+	skew          skewTracker
+	clock         Clock
+	failFast      bool
+	window        *MaintenanceWindow
+	windowPolicy  WindowPolicy
+	faults        FaultInjector
+	recordDir     string // fixture directory, empty when not recording
+	lockOwner     string
+	tlsSettings   tlsSettings
+	maxInFlight   int // request limit, 0 for none
+	busyRetry     BusyRetryPolicy
+	retry         retryPolicy
+	tokenMaxAge   time.Duration // refresh threshold, 0 to never refresh
+	store         store.Store   // nil to use the token manager's storage
+	firmware      string
+	verbose       bool
+	logger        *slog.Logger
+	requestHooks  []RequestHook
+	responseHooks []ResponseHook
 }
 
 // ClientOption configures a Client
@@ -58,16 +103,19 @@ func WithTokenCacheFile(filepath string) ClientOption {
 // WithTimeout sets the HTTP timeout
 func WithTimeout(timeout time.Duration) ClientOption {
 	return func(c *Client) {
-		c.httpClient = internal.NewHTTPClient(c.address, timeout, c.verbose)
+		c.httpClient = internal.NewHTTPClient(c.address, timeout, c.logger)
 	}
 }
 
-// WithVerbose enables verbose logging
+// WithVerbose enables verbose logging: debug records as text on standard
+// output. A logger set with WithLogger takes precedence.
 func WithVerbose(verbose bool) ClientOption {
 	return func(c *Client) {
 		c.verbose = verbose
-		if c.httpClient != nil {
-			c.httpClient.SetVerbose(verbose)
+		if verbose && c.logger == discardLogger {
+			c.logger = verboseLogger
+		} else if !verbose && c.logger == verboseLogger {
+			c.logger = discardLogger
 		}
 		if c.passwordMgr != nil {
 			if envMgr, ok := c.passwordMgr.(*EnvironmentPasswordManager); ok {
@@ -95,15 +143,36 @@ func WithEnvironmentAuth(enabled bool) ClientOption {
 	}
 }
 
+// WithModel skips model detection and treats the switch as the given model,
+// e.g. when the root page is unreachable or detection picks the wrong model
+func WithModel(model Model) ClientOption {
+	return func(c *Client) {
+		c.modelHint = model
+	}
+}
+
 // NewClient creates a new Netgear switch client
 func NewClient(address string, opts ...ClientOption) (*Client, error) {
+	return NewClientContext(context.Background(), address, opts...)
+}
+
+// NewClientContext is NewClient with a context bounding the model detection
+// and automatic login it may perform
+func NewClientContext(ctx context.Context, address string, opts ...ClientOption) (_ *Client, err error) {
 	client := &Client{
 		address:     address,
-		httpClient:  internal.NewHTTPClient(address, 10*time.Second, false),
-		tokenMgr:    NewFileTokenManager(""), // Default to file-based token manager with default cache dir
+		httpClient:  internal.NewHTTPClient(address, 10*time.Second, discardLogger),
+		tokenMgr:    NewFileTokenManager(""),         // Default to file-based token manager with default cache dir
 		passwordMgr: NewEnvironmentPasswordManager(), // Default to environment password manager
-		detector:    internal.NewModelDetector(),
+		detector:    internal.NewModelDetector(detectableModels()...),
+		metrics:     newMetricsRecorder(),
+		history:     newHistoryRecorder(DefaultHistorySize),
+		pages:       newPageCache(),
+		clock:       realClock{},
+		maxInFlight: DefaultMaxConcurrentRequests,
+		busyRetry:   DefaultBusyRetryPolicy,
 		verbose:     false,
+		logger:      discardLogger,
 	}
 
 	// Apply options (may override defaults)
@@ -111,44 +180,105 @@ func NewClient(address string, opts ...ClientOption) (*Client, error) {
 		opt(client)
 	}
 
+	if client.tlsSettings.err != nil {
+		return nil, client.tlsSettings.err
+	}
+	if client.tlsSettings.https && !strings.Contains(address, "://") {
+		client.httpClient.UseHTTPS()
+	}
+	if config := client.tlsSettings.config(); config != nil {
+		client.httpClient.SetTransport(tlsTransport(config))
+	}
+
+	// Wrap the transport last so options replacing the HTTP client keep the
+	// recorder, innermost so it sees what the switch really answered, the
+	// injector, the logging and hooks, and the request limit, which is
+	// outermost so faulted requests hold a slot too and waiting for a slot
+	// does not count towards a request's duration
+	client.httpClient.SetLogger(client.logger)
+	if envMgr, ok := client.passwordMgr.(*EnvironmentPasswordManager); ok && client.logger != discardLogger {
+		envMgr.SetLogger(client.logger)
+	}
+	if client.recordDir != "" {
+		recorder, err := fixture.NewRecorder(client.recordDir, client.httpClient.Transport())
+		if err != nil {
+			return nil, err
+		}
+		client.httpClient.SetTransport(recorder)
+	}
+	if client.faults != nil {
+		client.httpClient.SetTransport(&faultTransport{next: client.httpClient.Transport(), injector: client.faults})
+	}
+	client.httpClient.SetTransport(&observeTransport{next: client.httpClient.Transport(), client: client})
+	if client.maxInFlight > 0 {
+		client.httpClient.SetTransport(newLimitTransport(client.httpClient.Transport(), client.maxInFlight))
+	}
+
+	// Apply quirks discovered by earlier clients for this switch
+	client.loadQuirks(ctx)
+
+	if client.sharedSession {
+		client.session = acquireSession(address)
+		defer func() {
+			if err != nil {
+				releaseSession(address, client.session)
+			}
+		}()
+	} else {
+		client.session = &session{}
+	}
+
+	// Reuse the session of another client sharing it, if it is already logged in
+	if token, model := client.session.get(); token != "" {
+		client.model = model
+		client.endpoints = NewEndpointRegistry(model)
+		client.logger.Debug("joined shared session", "address", address, "model", model)
+		return client, nil
+	}
+
 	// Try to load existing cached token first
-	ctx := context.Background()
 	token, model, err := client.tokenMgr.GetToken(ctx, address)
-	if err == nil {
-		client.token = token
+	info := client.cachedTokenInfo(ctx)
+	info.TTL = client.tokenMaxAge
+	expired := err == nil && info.IsExpired(client.clock.Now())
+	if err == nil && !expired {
 		client.model = model
+		client.session.set(token, model, info.IssuedAt)
 		client.endpoints = NewEndpointRegistry(model)
-		if client.verbose {
-			fmt.Printf("Loaded existing token for model %s\n", model)
-		}
+		client.logger.Debug("loaded cached token", "address", address, "model", model)
 		return client, nil
 	}
 
-	// No cached token, check for environment password and auto-authenticate
-	if client.passwordMgr != nil {
-		if config, found := client.passwordMgr.GetSwitchConfig(address); found {
-			// Always detect model from the actual switch (ignore config model)
-			model, err := client.detectModel(ctx)
-			if err != nil {
-				return nil, NewModelError("failed to detect switch model", err)
-			}
-			client.model = model
-			client.endpoints = NewEndpointRegistry(model)
-			if client.verbose {
-				fmt.Printf("Detected model: %s\n", model)
-			}
+	// No usable cached token, look up a password and auto-authenticate
+	password, found, err := client.lookupPassword(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("password lookup failed: %w", err)
+	}
+	if expired && !found {
+		// Without a password the old token is the best there is
+		client.model = model
+		client.session.set(token, model, info.IssuedAt)
+		client.endpoints = NewEndpointRegistry(model)
+		return client, nil
+	}
+	if found {
+		// Always detect model from the actual switch (ignore config model)
+		model, err := client.detectModel(ctx)
+		if err != nil {
+			return nil, NewModelError("failed to detect switch model", err)
+		}
+		client.model = model
+		client.endpoints = NewEndpointRegistry(model)
+		client.logger.Debug("detected model", "address", address, "model", model)
 
-			// Perform authentication automatically
-			if client.verbose {
-				fmt.Printf("Auto-authenticating with environment password for %s\n", address)
-			}
-			err = client.Login(ctx, config.Password)
-			if err != nil {
-				return nil, fmt.Errorf("auto-authentication failed: %w", err)
-			}
-			
-			return client, nil
+		// Perform authentication automatically
+		client.logger.Debug("logging in with stored password", "address", address)
+		err = client.Login(ctx, password)
+		if err != nil {
+			return nil, fmt.Errorf("auto-authentication failed: %w", err)
 		}
+
+		return client, nil
 	}
 
 	// No environment password found, detect model for later manual authentication
@@ -158,15 +288,32 @@ func NewClient(address string, opts ...ClientOption) (*Client, error) {
 	}
 	client.model = model
 	client.endpoints = NewEndpointRegistry(model)
-	if client.verbose {
-		fmt.Printf("Detected model: %s (no auto-authentication - call Login() explicitly)\n", model)
-	}
+	client.logger.Debug("detected model; no password found, call Login explicitly", "address", address, "model", model)
 
 	return client, nil
 }
 
-// detectModel attempts to detect the switch model by making a request to the root page
+// detectModel returns the model given with WithModel or recorded in the
+// switch's quirks, or detects it
 func (c *Client) detectModel(ctx context.Context) (Model, error) {
+	if c.modelHint != "" {
+		return c.modelHint, nil
+	}
+	if quirks := c.GetQuirks(); quirks.Model.IsSupported() {
+		return quirks.Model, nil
+	}
+
+	model, err := c.detectModelFromSwitch(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	c.rememberQuirks(ctx, func(q *Quirks) { q.Model = model })
+	return model, nil
+}
+
+// detectModelFromSwitch attempts to detect the switch model by making a request to the root page
+func (c *Client) detectModelFromSwitch(ctx context.Context) (Model, error) {
 	// First try the root page
 	resp, err := c.httpClient.Get(ctx, "/", nil)
 	if err != nil {
@@ -179,23 +326,28 @@ func (c *Client) detectModel(ctx context.Context) (Model, error) {
 	}
 
 	modelString := c.detector.DetectFromHTML(body)
-	
+	if modelString == "" && !internal.HasNetgearMarkers(body+resp.Header.Get("Location")) {
+		return "", &NotANetgearSwitchError{Fingerprint: fingerprintResponse(resp, body)}
+	}
+
 	// If we only got the generic GS30xEPx from the redirect page,
 	// try to get more specific model info from the login page
-	if modelString == "GS30xEPx" {
-		loginResp, err := c.httpClient.Get(ctx, "/login.cgi", nil)
-		if err == nil {
-			loginBody, err := c.httpClient.ReadBody(loginResp)
-			if err == nil {
-				specificModel := c.detector.DetectFromHTML(loginBody)
-				if specificModel != "" && specificModel != "GS30xEPx" {
-					modelString = specificModel
-				}
-			}
+	if modelString == string(ModelGS30xEPx) {
+		if specificModel := c.detectFromPage(ctx, "/login.cgi"); specificModel != "" {
+			modelString = specificModel
 		}
 	}
-	
+
+	// Smart managed pro models redirect to a login page that names the model
+	if modelString == "" && strings.Contains(body, smartProLoginPath) {
+		modelString = c.detectFromPage(ctx, smartProLoginPath)
+	}
+
 	if modelString == "" {
+		// A Netgear page naming a model we don't manage is an unsupported device, not a detection failure
+		if guess := internal.GuessModel(body); guess != "" {
+			return "", &UnmanagedDeviceError{ModelGuess: guess, Fingerprint: fingerprintResponse(resp, body)}
+		}
 		return "", ErrModelNotDetected
 	}
 
@@ -207,52 +359,95 @@ func (c *Client) detectModel(ctx context.Context) (Model, error) {
 	return model, nil
 }
 
+// detectFromPage returns the specific model named on a page, or "" when the
+// page cannot be loaded or names none
+func (c *Client) detectFromPage(ctx context.Context, path string) string {
+	resp, err := c.httpClient.Get(ctx, path, nil)
+	if err != nil {
+		return ""
+	}
+	body, err := c.httpClient.ReadBody(resp)
+	if err != nil {
+		return ""
+	}
+	if model := c.detector.DetectFromHTML(body); model != string(ModelGS30xEPx) {
+		return model
+	}
+	return ""
+}
+
 // Login authenticates with the switch
 func (c *Client) Login(ctx context.Context, password string) error {
-	// If no password provided, try environment variables
+	c.session.loginMu.Lock()
+	defer c.session.loginMu.Unlock()
+	return c.login(ctx, password)
+}
+
+// login authenticates with the switch; the caller holds the session's loginMu
+func (c *Client) login(ctx context.Context, password string) error {
+	// If no password provided, ask the password provider or environment variables
 	if password == "" {
-		if c.passwordMgr != nil {
-			if config, found := c.passwordMgr.GetSwitchConfig(c.address); found {
-				password = config.Password
-				// Note: Model should already be detected, don't override from config
-				if c.verbose {
-					fmt.Printf("Using environment password for %s\n", c.address)
-				}
-			} else {
-				return NewAuthError("no password provided and no environment variable found", nil)
-			}
-		} else {
+		if c.passwords == nil && c.passwordMgr == nil {
 			return NewAuthError("password cannot be empty", nil)
 		}
+		stored, found, err := c.lookupPassword(ctx)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return NewAuthError("no password provided and none found by the password provider or environment", nil)
+		}
+		password = stored
+		// Note: Model should already be detected, don't override from config
+		c.logger.Debug("using stored password", "address", c.address)
 	}
 
 	// Perform authentication based on model type
 	var token string
 	var err error
 
+	start := c.clock.Now()
+	defer func() { c.recordHistory(ctx, "LOGIN", c.address, start, c.clock.Now(), err) }()
+
 	authType := GetAuthenticationType(c.model)
-	switch authType {
-	case AuthTypeSession:
-		token, err = c.loginWithSession(ctx, password)
-	case AuthTypeGambit:
-		token, err = c.loginWithGambit(ctx, password)
-	default:
-		return NewAuthError(fmt.Sprintf("unsupported authentication type for model %s", c.model), nil)
+	for attempt := 1; ; attempt++ {
+		switch authType {
+		case AuthTypeSession:
+			token, err = c.loginWithSession(ctx, password)
+		case AuthTypeGambit:
+			token, err = c.loginWithGambit(ctx, password)
+		default:
+			err = NewAuthError(fmt.Sprintf("unsupported authentication type for model %s", c.model), nil)
+			return err
+		}
+
+		wait, retry := c.retry.wait(attempt, err)
+		if !retry {
+			break
+		}
+		c.logger.Debug("login failed, retrying", "address", c.address, "error", err, "wait", wait)
+		if ctx.Err() != nil || c.clock.Sleep(ctx, wait) != nil {
+			break
+		}
 	}
 
+	if err == ErrInitialPasswordRequired && token != "" {
+		// Keep the restricted session so SetInitialPassword can use it, but don't cache it
+		c.setToken(token)
+		return err
+	}
 	if err != nil {
 		return err
 	}
 
-	c.token = token
+	c.setToken(token)
 
 	// Store token for future use
-	err = c.tokenMgr.StoreToken(ctx, c.address, token, c.model)
-	if err != nil {
+	if storeErr := c.tokenMgr.StoreToken(ctx, c.address, token, c.model); storeErr != nil {
 		// Log warning but don't fail login
-		if c.verbose {
-			fmt.Printf("Warning: failed to store token: %v\n", err)
-		}
+		c.logger.Warn("failed to store token", "address", c.address, "error", storeErr)
+	} else {
+		c.storeTokenInfo(ctx, c.session.issuedAt())
 	}
 
 	return nil
@@ -265,8 +460,29 @@ func (c *Client) LoginAuto(ctx context.Context) error {
 
 // loginWithSession performs session-based authentication (30x series)
 func (c *Client) loginWithSession(ctx context.Context, password string) (string, error) {
+	post := c.postSessionLogin
+	if c.model.IsModelSmartPro() {
+		post = c.postSmartProLogin
+	}
+
+	token, err := post(ctx, password)
+	if err == ErrInvalidCredentials && !c.GetQuirks().NeedsReferer {
+		// Some firmware silently rejects logins without a Referer; retry once with it
+		c.httpClient.SetSendReferer(true)
+		token, err = post(ctx, password)
+		if err == nil {
+			c.rememberQuirks(ctx, func(q *Quirks) { q.NeedsReferer = true })
+		} else {
+			c.httpClient.SetSendReferer(false)
+		}
+	}
+	return token, err
+}
+
+// postSessionLogin fetches a fresh seed and posts the encrypted password to the login page
+func (c *Client) postSessionLogin(ctx context.Context, password string) (string, error) {
 	// Step 1: Get seed value from login page
-	seedValue, err := c.getSeedValue(ctx, "/login.cgi")
+	loginPath, seedValue, err := c.discoverLoginPage(ctx, "/login.cgi")
 	if err != nil {
 		return "", NewAuthError("failed to get seed value", err)
 	}
@@ -279,28 +495,80 @@ func (c *Client) loginWithSession(ctx context.Context, password string) (string,
 	data.Set("password", encryptedPassword)
 
 	// Step 4: Make login request
-	resp, err := c.httpClient.Post(ctx, "/login.cgi", data, nil)
+	resp, err := c.httpClient.Post(ctx, loginPath, data, nil)
 	if err != nil {
 		return "", NewNetworkError("login request failed", err)
 	}
 
 	// Step 5: Extract session token from response headers
 	token := c.extractSessionToken(resp)
+	body, _ := c.httpClient.ReadBody(resp)
 	if token == "" {
-		body, _ := c.httpClient.ReadBody(resp)
+		if err := loginFailure(resp.StatusCode, body); err != nil {
+			return "", err
+		}
 		if errorMsg := internal.ExtractErrorMessage(body); errorMsg != "" {
 			return "", NewAuthError(fmt.Sprintf("login failed: %s", errorMsg), nil)
 		}
 		return "", ErrInvalidCredentials
 	}
 
+	// A factory-default switch hands out a session that only reaches the password change page
+	if internal.IsPasswordChangeRequired(body) || internal.IsPasswordChangeRequired(resp.Header.Get("Location")) {
+		return token, ErrInitialPasswordRequired
+	}
+
+	return token, nil
+}
+
+// postSmartProLogin posts the password to the login page of smart managed pro
+// models. Firmware that serves a seed gets the encrypted password like GS30x
+// models; older firmware takes it as it is.
+func (c *Client) postSmartProLogin(ctx context.Context, password string) (string, error) {
+	resp, err := c.httpClient.Get(ctx, smartProLoginPath, nil)
+	if err != nil {
+		return "", NewNetworkError("failed to load login page", err)
+	}
+	page, err := c.httpClient.ReadBody(resp)
+	if err != nil {
+		return "", NewNetworkError("failed to read login page", err)
+	}
+
+	data := url.Values{}
+	if seedValue := internal.ExtractSeedValue(page); seedValue != "" {
+		data.Set("pwd", c.encryptPassword(password, seedValue))
+	} else {
+		data.Set("pwd", password)
+	}
+
+	resp, err = c.httpClient.Post(ctx, smartProLoginPath, data, nil)
+	if err != nil {
+		return "", NewNetworkError("login request failed", err)
+	}
+
+	token := c.extractSessionToken(resp)
+	body, _ := c.httpClient.ReadBody(resp)
+	if token == "" {
+		if err := loginFailure(resp.StatusCode, body); err != nil {
+			return "", err
+		}
+		if errorMsg := internal.ExtractErrorMessage(body); errorMsg != "" {
+			return "", NewAuthError(fmt.Sprintf("login failed: %s", errorMsg), nil)
+		}
+		return "", ErrInvalidCredentials
+	}
+
+	if internal.IsPasswordChangeRequired(body) || internal.IsPasswordChangeRequired(resp.Header.Get("Location")) {
+		return token, ErrInitialPasswordRequired
+	}
+
 	return token, nil
 }
 
 // loginWithGambit performs Gambit-based authentication (316 series)
 func (c *Client) loginWithGambit(ctx context.Context, password string) (string, error) {
 	// Step 1: Get seed value from login page
-	seedValue, err := c.getSeedValue(ctx, "/wmi/login")
+	_, seedValue, err := c.discoverLoginPage(ctx, "/wmi/login")
 	if err != nil {
 		return "", NewAuthError("failed to get seed value", err)
 	}
@@ -326,18 +594,25 @@ func (c *Client) loginWithGambit(ctx context.Context, password string) (string,
 	// Step 5: Extract Gambit token from response body
 	token := internal.ExtractGambitToken(body)
 	if token == "" {
+		if err := loginFailure(resp.StatusCode, body); err != nil {
+			return "", err
+		}
 		if errorMsg := internal.ExtractErrorMessage(body); errorMsg != "" {
 			return "", NewAuthError(fmt.Sprintf("gambit login failed: %s", errorMsg), nil)
 		}
 		return "", ErrInvalidCredentials
 	}
 
+	if internal.IsPasswordChangeRequired(body) {
+		return token, ErrInitialPasswordRequired
+	}
+
 	return token, nil
 }
 
 // IsAuthenticated returns true if the client has a valid token
 func (c *Client) IsAuthenticated() bool {
-	return c.token != ""
+	return c.getToken() != ""
 }
 
 // GetModel returns the detected switch model
@@ -350,6 +625,83 @@ func (c *Client) GetAddress() string {
 	return c.address
 }
 
+// GetFirmware returns the firmware version reported by the switch.
+// It is empty until System().GetInfo has been called.
+func (c *Client) GetFirmware() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.firmware
+}
+
+// setFirmware records the firmware version reported by the switch
+func (c *Client) setFirmware(firmware string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.firmware = firmware
+}
+
+// GetAuthType returns the authentication scheme used for the switch
+func (c *Client) GetAuthType() AuthenticationType {
+	return GetAuthenticationType(c.model)
+}
+
+// String describes the client for logging; it never includes the session token
+func (c *Client) String() string {
+	firmware := c.GetFirmware()
+	if firmware == "" {
+		firmware = "unknown"
+	}
+	return fmt.Sprintf("netgear.Client{address=%s model=%s firmware=%s auth=%s authenticated=%t}",
+		c.address, c.model, firmware, c.GetAuthType(), c.IsAuthenticated())
+}
+
+// Clone returns a new client for the same switch that shares this client's
+// session, token manager and clock but has its own HTTP client, quirks
+// snapshot, metrics, history and locks, for per-goroutine use without contention.
+// A login on either client updates the session seen by both; logins through
+// either one wait for each other. A clone of a WithSharedSession client holds
+// the shared session until it is closed too.
+func (c *Client) Clone() *Client {
+	quirks := c.GetQuirks()
+	if c.sharedSession {
+		retainSession(c.session)
+	}
+	return &Client{
+		address:       c.address,
+		model:         c.model,
+		httpClient:    c.httpClient.Clone(),
+		session:       c.session,
+		sharedSession: c.sharedSession,
+		tokenMgr:      c.tokenMgr,
+		passwordMgr:   c.passwordMgr,
+		passwords:     c.passwords,
+		detector:      c.detector,
+		endpoints:     c.endpoints,
+		quirks:        &quirks,
+		metrics:       newMetricsRecorder(),
+		history:       newHistoryRecorder(len(c.history.entries)),
+		pages:         c.pages.clone(),
+		skew:          skewTracker{estimate: c.ClockSkew()},
+		clock:         c.clock,
+		failFast:      c.failFast,
+		window:        c.window,
+		windowPolicy:  c.windowPolicy,
+		faults:        c.faults,
+		lockOwner:     c.lockOwner,
+		tlsSettings:   c.tlsSettings,
+		maxInFlight:   c.maxInFlight,
+		busyRetry:     c.busyRetry,
+		retry:         c.retry,
+		tokenMaxAge:   c.tokenMaxAge,
+		store:         c.store,
+		firmware:      c.GetFirmware(),
+		verbose:       c.verbose,
+		logger:        c.logger,
+		requestHooks:  c.requestHooks,
+		responseHooks: c.responseHooks,
+	}
+}
+
 // GetTokenManager returns the token manager being used
 func (c *Client) GetTokenManager() TokenManager {
 	return c.tokenMgr
@@ -365,58 +717,213 @@ func (c *Client) Ports() *PortManager {
 	return newPortManager(c)
 }
 
+// Security returns the security settings management interface
+func (c *Client) Security() *SecurityManager {
+	return newSecurityManager(c)
+}
+
+// System returns the switch-wide information and settings interface
+func (c *Client) System() *SystemManager {
+	return newSystemManager(c)
+}
+
+// Config returns the declarative configuration interface
+func (c *Client) Config() *ConfigManager {
+	return newConfigManager(c)
+}
+
+// Meta returns the interface to library-side switch metadata such as port locks
+func (c *Client) Meta() *MetaManager {
+	return newMetaManager(c)
+}
+
+// VLANs returns the 802.1Q VLAN management interface
+func (c *Client) VLANs() *VLANManager {
+	return newVLANManager(c)
+}
+
+// MACTable returns the learned MAC address table interface
+func (c *Client) MACTable() *MACTableManager {
+	return newMACTableManager(c)
+}
+
 // Logout clears the authentication token
 func (c *Client) Logout(ctx context.Context) error {
-	c.token = ""
-	
+	c.setToken("")
+
 	// Remove stored token
 	err := c.tokenMgr.DeleteToken(ctx, c.address)
-	if err != nil && c.verbose {
-		fmt.Printf("Warning: failed to delete stored token: %v\n", err)
+	if err != nil {
+		c.logger.Warn("failed to delete stored token", "address", c.address, "error", err)
 	}
-	
+
 	return nil
 }
 
 // makeAuthenticatedRequest makes an HTTP request with appropriate authentication
 func (c *Client) makeAuthenticatedRequest(ctx context.Context, method, path string, data url.Values) (string, error) {
 	if !c.IsAuthenticated() {
-		return "", ErrNotAuthenticated
+		return "", ErrNotAuthenticated.WithSwitch(c.address, c.model).WithEndpoint(path)
+	}
+	if err := c.refreshExpiredToken(ctx); err != nil {
+		return "", err
 	}
 
-	headers := make(map[string]string)
+	if method == "POST" {
+		if err := c.admitWrite(ctx); err != nil {
+			return "", err
+		}
+	}
 
-	// Add authentication based on model type
-	authType := GetAuthenticationType(c.model)
-	switch authType {
-	case AuthTypeSession:
-		// Use session cookie
-		headers["Cookie"] = fmt.Sprintf("SID=%s", c.token)
-	case AuthTypeGambit:
-		// Add Gambit parameter to URL
-		if data == nil {
-			data = url.Values{}
+	// Firmware answers 503 or a busy page while committing configuration; the
+	// request was not processed, so it is sent again once the switch is ready.
+	// Other transient failures are retried as the retry policy allows.
+	var response string
+	var err error
+	for attempt := 1; ; attempt++ {
+		headers := make(map[string]string)
+
+		// Add authentication based on model type; a retry after logging in
+		// again sends the new token
+		token := c.getToken()
+		authType := GetAuthenticationType(c.model)
+		switch authType {
+		case AuthTypeSession:
+			// Use session cookie
+			headers["Cookie"] = fmt.Sprintf("SID=%s", token)
+		case AuthTypeGambit:
+			// Add Gambit parameter to URL
+			if data == nil {
+				data = url.Values{}
+			}
+			data.Set("Gambit", token)
 		}
-		data.Set("Gambit", c.token)
+
+		start := c.clock.Now()
+		response, err = c.doRequest(ctx, method, path, data, headers)
+		end := c.clock.Now()
+		c.metrics.record(path, end.Sub(start), end, err)
+		c.recordHistory(ctx, method, path, start, end, err)
+
+		wait, retry := c.retryWait(method, attempt, err)
+		if !retry {
+			break
+		}
+		c.logger.Debug("request failed, retrying", "address", c.address, "path", path, "error", err, "wait", wait)
+		if ctx.Err() != nil || c.clock.Sleep(ctx, wait) != nil {
+			break
+		}
+		if errors.Is(err, ErrSessionActive) {
+			if loginErr := c.reauthenticate(ctx, token); loginErr != nil {
+				err = loginErr
+				break
+			}
+		}
+	}
+	if err != nil {
+		var netgearErr *Error
+		if errors.As(err, &netgearErr) {
+			return "", netgearErr.WithSwitch(c.address, c.model).WithEndpoint(path)
+		}
+		return "", err
 	}
+	return response, nil
+}
 
+// doRequest performs the HTTP request and reads the response body
+func (c *Client) doRequest(ctx context.Context, method, path string, data url.Values, headers map[string]string) (string, error) {
+	var httpResp *http.Response
+	var err error
+
+	endpoint := path
+	sent := c.clock.Now()
 	if method == "GET" {
 		if len(data) > 0 {
 			// Add query parameters for GET requests
 			path += "?" + data.Encode()
 		}
-		httpResp, err := c.httpClient.Get(ctx, path, headers)
+		c.pages.addValidators(path, headers)
+		httpResp, err = c.httpClient.Get(ctx, path, headers)
 		if err != nil {
 			return "", NewNetworkError("GET request failed", err)
 		}
-		return c.httpClient.ReadBody(httpResp)
 	} else {
-		httpResp, err := c.httpClient.Post(ctx, path, data, headers)
+		// Writes may change any page, so stop trusting cached ones
+		c.pages.invalidate()
+		httpResp, err = c.httpClient.Post(ctx, path, data, headers)
 		if err != nil {
 			return "", NewNetworkError("POST request failed", err)
 		}
-		return c.httpClient.ReadBody(httpResp)
 	}
+	c.skew.observe(httpResp, sent, c.clock.Now())
+
+	if httpResp.StatusCode == http.StatusServiceUnavailable {
+		httpResp.Body.Close()
+		return "", newBusyError(httpResp.StatusCode, parseRetryAfter(httpResp, c.clock.Now()))
+	}
+	if httpResp.StatusCode >= http.StatusInternalServerError {
+		httpResp.Body.Close()
+		return "", newServerError(httpResp.StatusCode)
+	}
+	if httpResp.StatusCode >= http.StatusBadRequest {
+		httpResp.Body.Close()
+		return "", NewNetworkError(fmt.Sprintf("switch returned HTTP %d", httpResp.StatusCode), nil).WithHTTPStatus(httpResp.StatusCode)
+	}
+
+	if method == "GET" && httpResp.StatusCode == http.StatusNotModified {
+		httpResp.Body.Close()
+		body, cached := c.pages.lookup(path)
+		if !cached {
+			return "", NewNetworkError("switch answered 304 for a page that is not cached", nil).WithHTTPStatus(httpResp.StatusCode)
+		}
+		c.metrics.recordTransfer(endpoint, 0, len(body))
+		return body, nil
+	}
+
+	body, err := c.httpClient.ReadBody(httpResp)
+	if err != nil {
+		return "", NewNetworkError("failed to read response", err).WithHTTPStatus(httpResp.StatusCode)
+	}
+	if isBusyPage(body) {
+		return "", newBusyError(httpResp.StatusCode, parseRetryAfter(httpResp, c.clock.Now()))
+	}
+	if isSessionActivePage(body) {
+		return "", ErrSessionActive.WithHTTPStatus(httpResp.StatusCode)
+	}
+	if method == "GET" && httpResp.StatusCode == http.StatusOK {
+		c.pages.store(path, httpResp, body)
+	}
+	c.metrics.recordTransfer(endpoint, len(body), 0)
+	return body, nil
+}
+
+// discoverLoginPage finds the login page serving a seed value, trying the path recorded
+// in the switch's quirks first, then the model's default, then the other known login paths
+func (c *Client) discoverLoginPage(ctx context.Context, defaultPath string) (string, string, error) {
+	candidates := []string{defaultPath, "/login.cgi", "/wmi/login"}
+	if loginPath := c.GetQuirks().LoginPath; loginPath != "" {
+		candidates = append([]string{loginPath}, candidates...)
+	}
+
+	var firstErr error
+	tried := make(map[string]bool)
+	for _, path := range candidates {
+		if tried[path] {
+			continue
+		}
+		tried[path] = true
+
+		seedValue, err := c.getSeedValue(ctx, path)
+		if err == nil {
+			c.rememberQuirks(ctx, func(q *Quirks) { q.LoginPath = path })
+			return path, seedValue, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return "", "", firstErr
 }
 
 // getSeedValue retrieves the random seed value from the login page
@@ -486,12 +993,10 @@ func (c *Client) makeAuthenticatedRequestWithFallback(ctx context.Context, metho
 	response, err := c.makeAuthenticatedRequest(ctx, method, endpoint, data)
 
 	// If we get a 404 and this endpoint is known to be unsupported for this model, return a helpful error
-	if err != nil && strings.Contains(err.Error(), "404") {
+	var netgearErr *Error
+	if err != nil && errors.As(err, &netgearErr) && netgearErr.HTTPStatus == http.StatusNotFound {
 		if !c.endpoints.IsEndpointSupported(endpointType) {
-			return "", NewOperationError(
-				fmt.Sprintf("%s operation not supported on %s model (endpoint %s not available)",
-					string(endpointType), string(c.model), endpoint),
-				err)
+			return "", &UnsupportedOperationError{Operation: string(endpointType), Model: c.model}
 		}
 		// If the endpoint should be supported but returns 404, it's still an error
 		return "", NewOperationError(
@@ -500,4 +1005,48 @@ func (c *Client) makeAuthenticatedRequestWithFallback(ctx context.Context, metho
 	}
 
 	return response, err
-}
\ No newline at end of file
+}
+
+// fetchSecurityHash loads a settings page and returns the security hash required to post it back
+func (c *Client) fetchSecurityHash(ctx context.Context, endpoint string, endpointType EndpointType) (string, error) {
+	response, err := c.makeAuthenticatedRequestWithFallback(ctx, "GET", endpoint, nil, endpointType)
+	if err != nil {
+		return "", err
+	}
+
+	securityHash := c.extractSecurityHash(ctx, response)
+	if securityHash == "" {
+		return "", NewOperationError(fmt.Sprintf("security hash not found on %s - cannot update settings", endpoint), nil)
+	}
+
+	return securityHash, nil
+}
+
+// fingerprintResponse summarizes a response for identifying non-switch devices
+func fingerprintResponse(resp *http.Response, body string) ResponseFingerprint {
+	sum := sha256.Sum256([]byte(body))
+	return ResponseFingerprint{
+		StatusCode:  resp.StatusCode,
+		Server:      resp.Header.Get("Server"),
+		ContentType: resp.Header.Get("Content-Type"),
+		Location:    resp.Header.Get("Location"),
+		Title:       internal.ExtractTitle(body),
+		BodyLength:  len(body),
+		BodySHA256:  hex.EncodeToString(sum[:]),
+	}
+}
+
+// portError creates an operation error annotated with this switch and the port involved
+func (c *Client) portError(portID int, message string, cause error) *Error {
+	return NewOperationError(message, cause).WithSwitch(c.address, c.model).WithPort(portID)
+}
+
+// ping checks that the switch answers HTTP requests at all
+func (c *Client) ping(ctx context.Context) error {
+	resp, err := c.httpClient.Get(ctx, "/", nil)
+	if err != nil {
+		return NewNetworkError("switch did not respond", err)
+	}
+	resp.Body.Close()
+	return nil
+}
@@ -0,0 +1,144 @@
+package netgear
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gherlein/go-netgear/pkg/netgear/internal"
+)
+
+// TestClientConcurrentUse exercises shared client state from many goroutines;
+// run with -race to catch unsynchronized access
+func TestClientConcurrentUse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", "Mon, 01 Jan 2024 00:00:00 GMT")
+		w.Write([]byte(`<html><input type="hidden" name="Hash" value="abc123"></html>`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	address := strings.TrimPrefix(server.URL, "http://")
+	tokenMgr := NewMemoryTokenManager()
+	tokenMgr.StoreToken(ctx, address, "token", ModelGS308EPP)
+
+	client, err := NewClient(address, WithTokenManager(tokenMgr), WithPasswordManager(nil))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c := client
+			if i%2 == 0 {
+				c = client.Clone()
+			}
+			for j := 0; j < 5; j++ {
+				c.POE().GetStatus(ctx)
+				c.fetchSecurityHash(ctx, "/PoEPortConfig.cgi", EndpointPOESettings)
+				_ = c.GetQuirks()
+				_ = c.String()
+				_ = c.Metrics()
+				_ = c.ClockSkew()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if got := client.GetQuirks().HashFieldName; got != "Hash" {
+		t.Errorf("Expected discovered hash field Hash, got %q", got)
+	}
+	if clone := client.Clone(); clone.getToken() != "token" {
+		t.Error("Expected clone to share the session token")
+	}
+}
+
+func TestSmartProClient(t *testing.T) {
+	const password = "Pr0Switch"
+	var model string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch {
+		case r.URL.Path == "/":
+			fmt.Fprint(w, `<script>top.location.href = "/base/main_login.html";</script>`)
+		case r.Method == "GET" && r.URL.Path == "/base/main_login.html":
+			fmt.Fprintf(w, `<title>NETGEAR %s</title><input id="rand" value="%s">`, model, factorySeed)
+		case r.URL.Path == "/base/main_login.html":
+			if r.PostForm.Get("pwd") != internal.EncryptPasswordWithSeed(password, factorySeed) {
+				fmt.Fprint(w, `<html>login</html>`)
+				return
+			}
+			w.Header().Set("Set-Cookie", "SID=pro; path=/")
+			fmt.Fprint(w, `<html>ok</html>`)
+		case r.URL.Path == "/base/system/management/sysInfo.html" && r.Header.Get("Cookie") == "SID=pro":
+			fmt.Fprintf(w, `<table><tr><td>System Name</td><td>lab-pro</td></tr>
+				<tr><td>Base MAC Address</td><td>A0:21:B7:00:11:22</td></tr>
+				<tr><td>Software Version</td><td>6.0.1.16</td></tr></table>`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	address := strings.TrimPrefix(server.URL, "http://")
+	for _, model = range []string{"GS110TP", "GS108Tv3"} {
+		client, err := NewClient(address, WithTokenManager(NewMemoryTokenManager()), WithPasswordManager(nil))
+		if err != nil {
+			t.Fatalf("NewClient failed: %v", err)
+		}
+		if client.GetModel() != Model(model) || !client.GetModel().IsModelSmartPro() {
+			t.Fatalf("expected smart managed pro model %s, got %s", model, client.GetModel())
+		}
+
+		if err := client.Login(ctx, "wrong"); !errors.Is(err, ErrInvalidCredentials) {
+			t.Errorf("expected ErrInvalidCredentials for a wrong password, got %v", err)
+		}
+		if err := client.Login(ctx, password); err != nil {
+			t.Fatalf("Login failed: %v", err)
+		}
+
+		info, err := client.System().GetInfo(ctx)
+		if err != nil {
+			t.Fatalf("GetInfo failed: %v", err)
+		}
+		if info.DeviceName != "lab-pro" || info.MACAddress != "A0:21:B7:00:11:22" || info.Firmware != "6.0.1.16" {
+			t.Errorf("unexpected system info %+v", info)
+		}
+
+		if got := client.endpoints.IsEndpointSupported(EndpointPOEStatus); got != client.GetModel().HasPOE() {
+			t.Errorf("%s: POE status supported = %v, expected %v", model, got, client.GetModel().HasPOE())
+		}
+		if _, err := client.POE().GetStatus(ctx); !client.GetModel().HasPOE() && err == nil {
+			t.Errorf("%s: expected POE status to be refused", model)
+		}
+	}
+}
+
+func TestNewClientContextBoundsDetection(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `<html><title>GS308EPP</title></html>`)
+	}))
+	defer server.Close()
+	address := strings.TrimPrefix(server.URL, "http://")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := NewClientContext(ctx, address, WithTokenManager(NewMemoryTokenManager()), WithPasswordManager(nil))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected detection to stop with the context, got %v", err)
+	}
+	if requests != 0 {
+		t.Errorf("expected no requests with a cancelled context, got %d", requests)
+	}
+}
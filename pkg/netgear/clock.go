@@ -0,0 +1,58 @@
+package netgear
+
+import (
+	"context"
+	"time"
+)
+
+// Clock is the source of time for everything the client measures, schedules
+// or waits on. Replace it with WithClock to test time-dependent behavior
+// deterministically or to run simulations faster than real time.
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+	// Sleep waits for d, returning early with ctx.Err() if ctx is done
+	Sleep(ctx context.Context, d time.Duration) error
+	// After returns a channel that receives the time once d has elapsed
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock is the Clock backed by the time package
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) Sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// SystemClock returns the Clock backed by the real system time
+func SystemClock() Clock {
+	return realClock{}
+}
+
+// WithClock sets the clock used for timing, waits and schedules
+func WithClock(clock Clock) ClientOption {
+	return func(c *Client) {
+		c.clock = clock
+	}
+}
+
+// Clock returns the clock used by the client
+func (c *Client) Clock() Clock {
+	return c.clock
+}
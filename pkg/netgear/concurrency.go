@@ -0,0 +1,65 @@
+package netgear
+
+import (
+	"io"
+	"net/http"
+	"sync"
+)
+
+// DefaultMaxConcurrentRequests is how many requests a client has in flight
+// at once unless WithMaxConcurrentRequests says otherwise. Switch firmware
+// mishandles overlapping requests, e.g. by invalidating the form hash of one
+// page while another is served, so requests are serialized by default.
+const DefaultMaxConcurrentRequests = 1
+
+// WithMaxConcurrentRequests sets how many HTTP requests the client sends to
+// the switch at once; further requests wait for a free slot, or until their
+// context ends. Zero or less removes the limit. The limit is shared with
+// clients made by Clone, so goroutines can share a Client or its clones
+// without overlapping requests.
+func WithMaxConcurrentRequests(n int) ClientOption {
+	return func(c *Client) {
+		c.maxInFlight = n
+	}
+}
+
+// limitTransport lets at most cap(slots) requests be in flight. A request
+// holds its slot until its response body is closed, since the switch is
+// still busy serving it until then.
+type limitTransport struct {
+	next  http.RoundTripper
+	slots chan struct{}
+}
+
+func newLimitTransport(next http.RoundTripper, n int) *limitTransport {
+	return &limitTransport{next: next, slots: make(chan struct{}, n)}
+}
+
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	select {
+	case t.slots <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		<-t.slots
+		return nil, err
+	}
+	resp.Body = &slotBody{ReadCloser: resp.Body, release: func() { <-t.slots }}
+	return resp, nil
+}
+
+// slotBody releases its request's slot when closed
+type slotBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *slotBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
@@ -0,0 +1,82 @@
+package netgear
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// concurrencySwitch records the most requests it ever served at once
+func concurrencySwitch(t *testing.T) (address string, peak *atomic.Int32) {
+	var inFlight atomic.Int32
+	peak = &atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		w.Write([]byte("<html></html>"))
+	}))
+	t.Cleanup(server.Close)
+	return strings.TrimPrefix(server.URL, "http://"), peak
+}
+
+func TestRequestsSerializedByDefault(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		opts  []ClientOption
+		limit int32
+	}{
+		{"default", nil, 1},
+		{"three", []ClientOption{WithMaxConcurrentRequests(3)}, 3},
+	} {
+		address, peak := concurrencySwitch(t)
+		client, err := NewClient(address, append(factoryClientOptions(address), tt.opts...)...)
+		if err != nil {
+			t.Fatalf("NewClient failed: %v", err)
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			// Clones share the limit with the client they were made from
+			c := client
+			if i%2 == 1 {
+				c = client.Clone()
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := c.makeAuthenticatedRequest(context.Background(), "GET", "/dashboard.cgi", nil); err != nil {
+					t.Errorf("request failed: %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if got := peak.Load(); got > tt.limit {
+			t.Errorf("%s: %d requests in flight, limit %d", tt.name, got, tt.limit)
+		}
+	}
+}
+
+func TestRequestWaitHonorsContext(t *testing.T) {
+	limit := newLimitTransport(http.DefaultTransport, 1)
+	limit.slots <- struct{}{} // another request holds the only slot
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, _ := http.NewRequestWithContext(ctx, "GET", "http://192.0.2.1/", nil)
+	if _, err := limit.RoundTrip(req); err != context.Canceled {
+		t.Errorf("expected context.Canceled while waiting for a slot, got %v", err)
+	}
+}
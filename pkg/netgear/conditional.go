@@ -0,0 +1,112 @@
+package netgear
+
+import (
+	"net/http"
+	"sync"
+)
+
+// WithConditionalRequests controls whether repeated GETs of a page carry the
+// validators (Last-Modified, ETag) of its previous response, so firmware that
+// honors If-Modified-Since or If-None-Match can answer 304 Not Modified
+// instead of resending the page. Firmware that sends no validators or ignores
+// them simply returns the full page. Enabled by default; the bytes saved are
+// reported per endpoint by Client.Metrics.
+func WithConditionalRequests(enabled bool) ClientOption {
+	return func(c *Client) {
+		if enabled {
+			c.pages = newPageCache()
+		} else {
+			c.pages = nil
+		}
+	}
+}
+
+// cachedPage is the last full response to a GET and the validators it carried
+type cachedPage struct {
+	body         string
+	lastModified string
+	etag         string
+}
+
+// pageCache remembers validated GET responses per request path. A nil
+// *pageCache disables conditional requests.
+type pageCache struct {
+	mu    sync.Mutex
+	pages map[string]cachedPage
+}
+
+func newPageCache() *pageCache {
+	return &pageCache{pages: make(map[string]cachedPage)}
+}
+
+// addValidators sets the conditional headers for a GET of path if a previous response carried validators
+func (p *pageCache) addValidators(path string, headers map[string]string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	page, exists := p.pages[path]
+	if !exists {
+		return
+	}
+	if page.lastModified != "" {
+		headers["If-Modified-Since"] = page.lastModified
+	}
+	if page.etag != "" {
+		headers["If-None-Match"] = page.etag
+	}
+}
+
+// store remembers body if resp carries validators, and forgets the page otherwise
+func (p *pageCache) store(path string, resp *http.Response, body string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	page := cachedPage{
+		body:         body,
+		lastModified: resp.Header.Get("Last-Modified"),
+		etag:         resp.Header.Get("ETag"),
+	}
+	if page.lastModified == "" && page.etag == "" {
+		delete(p.pages, path)
+		return
+	}
+	p.pages[path] = page
+}
+
+// lookup returns the body remembered for path
+func (p *pageCache) lookup(path string) (string, bool) {
+	if p == nil {
+		return "", false
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	page, exists := p.pages[path]
+	return page.body, exists
+}
+
+// clone returns an empty cache if conditional requests are enabled, so clones
+// do not share state
+func (p *pageCache) clone() *pageCache {
+	if p == nil {
+		return nil
+	}
+	return newPageCache()
+}
+
+// invalidate forgets every page, e.g. after a write that may change them
+// within the one-second resolution of Last-Modified
+func (p *pageCache) invalidate() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pages = make(map[string]cachedPage)
+}
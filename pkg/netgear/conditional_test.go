@@ -0,0 +1,91 @@
+package netgear
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestConditionalRequests(t *testing.T) {
+	const page = "<html>dashboard</html>"
+	const lastModified = "Mon, 12 Oct 2026 08:00:00 GMT"
+
+	var mu sync.Mutex
+	var conditional []bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			return
+		}
+		mu.Lock()
+		conditional = append(conditional, r.Header.Get("If-Modified-Since") != "")
+		mu.Unlock()
+
+		w.Header().Set("Last-Modified", lastModified)
+		if r.Header.Get("If-Modified-Since") == lastModified {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Write([]byte(page))
+	}))
+	defer server.Close()
+
+	address := strings.TrimPrefix(server.URL, "http://")
+	client, err := NewClient(address, factoryClientOptions(address)...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		body, err := client.makeAuthenticatedRequest(ctx, "GET", "/dashboard.cgi", nil)
+		if err != nil || body != page {
+			t.Fatalf("request %d: got %q, %v", i, body, err)
+		}
+	}
+	if _, err := client.makeAuthenticatedRequest(ctx, "POST", "/dashboard.cgi", nil); err != nil {
+		t.Fatalf("POST failed: %v", err)
+	}
+	if _, err := client.makeAuthenticatedRequest(ctx, "GET", "/dashboard.cgi", nil); err != nil {
+		t.Fatalf("GET after POST failed: %v", err)
+	}
+
+	want := []bool{false, true, false}
+	if len(conditional) != len(want) {
+		t.Fatalf("expected %d GETs, got %v", len(want), conditional)
+	}
+	for i := range want {
+		if conditional[i] != want[i] {
+			t.Errorf("GET %d conditional = %v, want %v", i, conditional[i], want[i])
+		}
+	}
+
+	metrics := client.Metrics()
+	if len(metrics) != 1 || metrics[0].NotModified != 1 || metrics[0].BytesSaved != uint64(len(page)) || metrics[0].BytesReceived != uint64(2*len(page)) {
+		t.Errorf("unexpected transfer metrics %+v", metrics)
+	}
+}
+
+func TestConditionalRequestsDisabled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-Modified-Since") != "" {
+			t.Error("conditional header sent although disabled")
+		}
+		w.Header().Set("Last-Modified", "Mon, 12 Oct 2026 08:00:00 GMT")
+		w.Write([]byte("<html></html>"))
+	}))
+	defer server.Close()
+
+	address := strings.TrimPrefix(server.URL, "http://")
+	client, err := NewClient(address, append(factoryClientOptions(address), WithConditionalRequests(false))...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := client.makeAuthenticatedRequest(context.Background(), "GET", "/dashboard.cgi", nil); err != nil {
+			t.Fatalf("request %d failed: %v", i, err)
+		}
+	}
+}
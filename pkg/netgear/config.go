@@ -0,0 +1,164 @@
+package netgear
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigSpecVersion is the current SwitchConfigSpec document version
+const ConfigSpecVersion = 1
+
+// SwitchConfigSpec is the desired configuration of one switch. Fields left
+// unset are not managed, so a spec only needs to describe what it cares about.
+//
+// Specs are YAML (or JSON) documents and may be Go templates rendered with
+// per-switch variables, letting one file provision many similar switches:
+//
+//	version: 1
+//	ports:
+//	  - port: 1
+//	    name: "{{.SiteCode}}-uplink"
+//	  - port: 2
+//	    name: "{{.SiteCode}}-cam-lobby"
+//	    poe:
+//	      enabled: true
+//	      priority: high
+type SwitchConfigSpec struct {
+	Version int        `json:"version" yaml:"version"`
+	Ports   []PortSpec `json:"ports,omitempty" yaml:"ports,omitempty"`
+}
+
+// PortSpec is the desired configuration of one port
+type PortSpec struct {
+	PortID      int           `json:"port" yaml:"port"`
+	Name        *string       `json:"name,omitempty" yaml:"name,omitempty"`
+	Speed       *PortSpeed    `json:"speed,omitempty" yaml:"speed,omitempty"`
+	FlowControl *bool         `json:"flow_control,omitempty" yaml:"flow_control,omitempty"`
+	POE         *POEPortSpec  `json:"poe,omitempty" yaml:"poe,omitempty"`
+	VLAN        *PortVLANSpec `json:"vlan,omitempty" yaml:"vlan,omitempty"`
+}
+
+// POEPortSpec is the desired POE configuration of one port
+type POEPortSpec struct {
+	Enabled        *bool         `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	Mode           *POEMode      `json:"mode,omitempty" yaml:"mode,omitempty"`
+	Priority       *POEPriority  `json:"priority,omitempty" yaml:"priority,omitempty"`
+	PowerLimitType *POELimitType `json:"power_limit_type,omitempty" yaml:"power_limit_type,omitempty"`
+	PowerLimitW    *float64      `json:"power_limit_w,omitempty" yaml:"power_limit_w,omitempty"`
+}
+
+// PortVLANSpec is the desired VLAN membership of one port: untagged in
+// Native (which is also the PVID) and tagged in Tagged
+type PortVLANSpec struct {
+	Native int   `json:"native" yaml:"native"`
+	Tagged []int `json:"tagged,omitempty" yaml:"tagged,omitempty"`
+}
+
+// LoadConfigSpec reads and validates a spec file
+func LoadConfigSpec(filename string) (*SwitchConfigSpec, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config spec: %w", err)
+	}
+	return ParseConfigSpec(data)
+}
+
+// ParseConfigSpec parses and validates a YAML or JSON spec
+func ParseConfigSpec(data []byte) (*SwitchConfigSpec, error) {
+	var spec SwitchConfigSpec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse config spec: %w", err)
+	}
+	if spec.Version == 0 {
+		spec.Version = ConfigSpecVersion
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+// LoadConfigTemplate reads a spec template file and renders it with vars
+func LoadConfigTemplate(filename string, vars map[string]string) (*SwitchConfigSpec, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config template: %w", err)
+	}
+	return RenderConfigSpec(data, vars)
+}
+
+// RenderConfigSpec executes a spec template with vars and parses the result.
+// Referencing a variable that is not in vars is an error, so a typo cannot
+// silently provision an empty port name.
+func RenderConfigSpec(tmpl []byte, vars map[string]string) (*SwitchConfigSpec, error) {
+	t, err := template.New("config").Option("missingkey=error").Parse(string(tmpl))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, vars); err != nil {
+		return nil, fmt.Errorf("failed to render config template: %w", err)
+	}
+
+	return ParseConfigSpec(buf.Bytes())
+}
+
+// TemplateVars returns the variables available to a config template for this
+// switch: its Vars plus Name, Model and Address (the primary address).
+// Entries in Vars take precedence.
+func (e InventoryEntry) TemplateVars() map[string]string {
+	vars := map[string]string{
+		"Name":  e.Name,
+		"Model": string(e.Model),
+	}
+	if len(e.Addresses) > 0 {
+		vars["Address"] = e.Addresses[0]
+	}
+	for k, v := range e.Vars {
+		vars[k] = v
+	}
+	return vars
+}
+
+// Validate checks the spec for unsupported versions, duplicate ports and
+// out-of-range VLAN IDs
+func (s *SwitchConfigSpec) Validate() error {
+	if s.Version != ConfigSpecVersion {
+		return fmt.Errorf("unsupported config spec version %d", s.Version)
+	}
+
+	seen := make(map[int]bool)
+	for i, port := range s.Ports {
+		if port.PortID <= 0 {
+			return fmt.Errorf("port spec %d: invalid port %d", i, port.PortID)
+		}
+		if seen[port.PortID] {
+			return fmt.Errorf("duplicate spec for port %d", port.PortID)
+		}
+		seen[port.PortID] = true
+
+		if port.VLAN != nil {
+			for _, id := range append([]int{port.VLAN.Native}, port.VLAN.Tagged...) {
+				if id < 1 || id > 4094 {
+					return fmt.Errorf("port %d: invalid VLAN ID %d", port.PortID, id)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Port returns the spec for a port
+func (s *SwitchConfigSpec) Port(portID int) (PortSpec, bool) {
+	for _, port := range s.Ports {
+		if port.PortID == portID {
+			return port, true
+		}
+	}
+	return PortSpec{}, false
+}
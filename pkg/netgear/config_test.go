@@ -0,0 +1,65 @@
+package netgear
+
+import (
+	"strings"
+	"testing"
+)
+
+const testConfigTemplate = `version: 1
+ports:
+  - port: 1
+    name: "{{.SiteCode}}-uplink"
+    vlan:
+      native: 1
+      tagged: [10, 20]
+  - port: 2
+    name: "{{.SiteCode}}-cam-{{.Name}}"
+    poe:
+      enabled: true
+      priority: high
+`
+
+func TestRenderConfigSpec(t *testing.T) {
+	entry := InventoryEntry{
+		Name:      "lobby",
+		Addresses: []string{"192.168.1.10"},
+		Vars:      map[string]string{"SiteCode": "SEA1"},
+	}
+
+	spec, err := RenderConfigSpec([]byte(testConfigTemplate), entry.TemplateVars())
+	if err != nil {
+		t.Fatalf("RenderConfigSpec failed: %v", err)
+	}
+
+	port, ok := spec.Port(2)
+	if !ok || port.Name == nil || *port.Name != "SEA1-cam-lobby" {
+		t.Fatalf("unexpected port 2 spec: %+v", port)
+	}
+	if port.POE == nil || *port.POE.Priority != POEPriorityHigh {
+		t.Errorf("expected POE priority high, got %+v", port.POE)
+	}
+
+	uplink, _ := spec.Port(1)
+	if uplink.VLAN == nil || len(uplink.VLAN.Tagged) != 2 {
+		t.Errorf("unexpected uplink VLAN spec: %+v", uplink.VLAN)
+	}
+}
+
+func TestRenderConfigSpecMissingVar(t *testing.T) {
+	_, err := RenderConfigSpec([]byte(testConfigTemplate), map[string]string{"Name": "lobby"})
+	if err == nil || !strings.Contains(err.Error(), "SiteCode") {
+		t.Fatalf("expected missing SiteCode error, got %v", err)
+	}
+}
+
+func TestConfigSpecValidate(t *testing.T) {
+	for name, doc := range map[string]string{
+		"duplicate port": "ports: [{port: 1}, {port: 1}]",
+		"bad vlan":       "ports: [{port: 1, vlan: {native: 5000}}]",
+		"bad version":    "version: 9",
+	} {
+		if _, err := ParseConfigSpec([]byte(doc)); err == nil {
+			t.Errorf("%s: expected validation error", name)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+// Package decode converts the loosely typed records produced by page parsers
+// (map[string]interface{} keyed by field name) into typed structs.
+//
+// Struct fields are matched by the name in their json tag, falling back to the
+// Go field name. Values are assigned when their type is assignable or
+// convertible to the field's kind without loss: strings to string-based types
+// such as enums, ints to int or float fields, float64 to float fields, and
+// bools to bool fields. A value of the wrong type is always an error; unknown
+// and missing keys are errors only when requested with DisallowUnknown and
+// Require.
+package decode
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Option configures how strictly a record is decoded
+type Option func(*options)
+
+type options struct {
+	disallowUnknown bool
+	required        []string
+}
+
+// DisallowUnknown makes keys without a matching struct field an error
+func DisallowUnknown() Option {
+	return func(o *options) {
+		o.disallowUnknown = true
+	}
+}
+
+// Require makes the absence of any of the named keys an error
+func Require(keys ...string) Option {
+	return func(o *options) {
+		o.required = append(o.required, keys...)
+	}
+}
+
+// Record decodes raw into the struct pointed to by out
+func Record(raw map[string]interface{}, out interface{}, opts ...Option) error {
+	target := reflect.ValueOf(out)
+	if target.Kind() != reflect.Pointer || target.IsNil() || target.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("decode target must be a non-nil pointer to a struct, got %T", out)
+	}
+
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	for _, key := range o.required {
+		if _, ok := raw[key]; !ok {
+			return fmt.Errorf("missing required field %q", key)
+		}
+	}
+
+	fields := fieldsOf(target.Elem().Type())
+
+	// Visit keys in order so the reported error does not depend on map iteration
+	keys := make([]string, 0, len(raw))
+	for key := range raw {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		index, ok := fields[key]
+		if !ok {
+			if o.disallowUnknown {
+				return fmt.Errorf("unknown field %q", key)
+			}
+			continue
+		}
+		if err := assign(target.Elem().Field(index), raw[key]); err != nil {
+			return fmt.Errorf("field %q: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// Records decodes every raw record into a T, reporting the position of the
+// first record that fails
+func Records[T any](raw []map[string]interface{}, opts ...Option) ([]T, error) {
+	results := make([]T, 0, len(raw))
+	for i, record := range raw {
+		var value T
+		if err := Record(record, &value, opts...); err != nil {
+			return nil, fmt.Errorf("record %d: %w", i, err)
+		}
+		results = append(results, value)
+	}
+	return results, nil
+}
+
+// assign stores value in field, converting between compatible kinds
+func assign(field reflect.Value, value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	v := reflect.ValueOf(value)
+	switch field.Kind() {
+	case reflect.String:
+		if v.Kind() == reflect.String {
+			field.SetString(v.String())
+			return nil
+		}
+	case reflect.Bool:
+		if v.Kind() == reflect.Bool {
+			field.SetBool(v.Bool())
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			field.SetInt(v.Int())
+			return nil
+		}
+	case reflect.Float32, reflect.Float64:
+		switch v.Kind() {
+		case reflect.Float32, reflect.Float64:
+			field.SetFloat(v.Float())
+			return nil
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			field.SetFloat(float64(v.Int()))
+			return nil
+		}
+	default:
+		if v.Type().AssignableTo(field.Type()) {
+			field.Set(v)
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot use %T as %s", value, field.Type())
+}
+
+// fieldCache holds the key-to-field-index map of each decoded struct type
+var fieldCache sync.Map // map[reflect.Type]map[string]int
+
+// fieldsOf maps record keys to the indexes of the exported fields of t
+func fieldsOf(t reflect.Type) map[string]int {
+	if cached, ok := fieldCache.Load(t); ok {
+		return cached.(map[string]int)
+	}
+
+	fields := make(map[string]int)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = field.Name
+		}
+		fields[name] = i
+	}
+
+	fieldCache.Store(t, fields)
+	return fields
+}
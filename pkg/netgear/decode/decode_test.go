@@ -0,0 +1,85 @@
+package decode
+
+import (
+	"strings"
+	"testing"
+)
+
+type mode string
+
+type sample struct {
+	PortID  int     `json:"port_id"`
+	Name    string  `json:"port_name"`
+	Mode    mode    `json:"mode"`
+	Enabled bool    `json:"enabled"`
+	PowerW  float64 `json:"power_w"`
+	VLANs   []int   `json:"vlans,omitempty"`
+	Skipped string  `json:"-"`
+	Plain   string
+}
+
+func TestRecord(t *testing.T) {
+	raw := map[string]interface{}{
+		"port_id":   3,
+		"port_name": "camera",
+		"mode":      "802.3at",
+		"enabled":   true,
+		"power_w":   7, // ints widen to float fields
+		"vlans":     []int{10, 20},
+		"Plain":     "by field name",
+		"extra":     "ignored",
+	}
+
+	var s sample
+	if err := Record(raw, &s); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	if s.PortID != 3 || s.Name != "camera" || s.Mode != "802.3at" || !s.Enabled || s.PowerW != 7 || len(s.VLANs) != 2 || s.Plain != "by field name" {
+		t.Errorf("unexpected result %+v", s)
+	}
+}
+
+func TestRecordErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  map[string]interface{}
+		opts []Option
+		want string
+	}{
+		{"wrong type", map[string]interface{}{"port_id": "3"}, nil, `field "port_id": cannot use string as int`},
+		{"float to int", map[string]interface{}{"port_id": 3.5}, nil, `cannot use float64 as int`},
+		{"unknown strict", map[string]interface{}{"extra": 1}, []Option{DisallowUnknown()}, `unknown field "extra"`},
+		{"ignored key strict", map[string]interface{}{"Skipped": "x"}, []Option{DisallowUnknown()}, `unknown field "Skipped"`},
+		{"missing required", map[string]interface{}{"port_name": "x"}, []Option{Require("port_id")}, `missing required field "port_id"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s sample
+			err := Record(tt.raw, &s, tt.opts...)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+
+	if err := Record(map[string]interface{}{}, sample{}); err == nil {
+		t.Error("expected error for a non-pointer target")
+	}
+}
+
+func TestRecords(t *testing.T) {
+	raw := []map[string]interface{}{
+		{"port_id": 1},
+		{"port_id": 2, "port_name": nil},
+	}
+	results, err := Records[sample](raw, Require("port_id"))
+	if err != nil || len(results) != 2 || results[1].PortID != 2 {
+		t.Fatalf("unexpected results %+v (%v)", results, err)
+	}
+
+	raw = append(raw, map[string]interface{}{"port_name": "no id"})
+	if _, err := Records[sample](raw, Require("port_id")); err == nil || !strings.Contains(err.Error(), "record 2") {
+		t.Errorf("expected error naming record 2, got %v", err)
+	}
+}
@@ -0,0 +1,182 @@
+package netgear
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/gherlein/go-netgear/pkg/netgear/internal"
+)
+
+// dot1xFieldEnabled is the switch-wide 802.1X toggle of the port authentication page
+const dot1xFieldEnabled = "dot1x_status"
+
+// dot1xPortField matches the per-port control selects of the port authentication page
+var dot1xPortField = regexp.MustCompile(`^(?i:port_?control|portCtrl)_?(\d+)$`)
+
+// GetDot1X reads the switch-wide 802.1X state and the authorization mode of
+// every port. Only GS316 and smart managed pro models offer port
+// authentication; other models return ErrPortAuthNotSupported.
+func (m *SecurityManager) GetDot1X(ctx context.Context) (*Dot1XSettings, error) {
+	if !m.client.IsAuthenticated() {
+		return nil, ErrNotAuthenticated
+	}
+	if !m.client.endpoints.IsEndpointSupported(EndpointPortAuth) {
+		return nil, ErrPortAuthNotSupported.WithSwitch(m.client.address, m.client.model)
+	}
+
+	endpoint := m.client.endpoints.GetEndpoint(EndpointPortAuth).URL
+	response, err := m.client.makeAuthenticatedRequestWithFallback(ctx, "GET", endpoint, nil, EndpointPortAuth)
+	if err != nil {
+		return nil, err
+	}
+
+	values, fields, err := parseDot1X(response)
+	if err != nil {
+		return nil, err
+	}
+	options, err := internal.ParseSelectOptions(response)
+	if err != nil {
+		return nil, NewParsingError("failed to parse 802.1X settings", err)
+	}
+
+	settings := &Dot1XSettings{Enabled: isEnabledValue(values[dot1xFieldEnabled])}
+	for portID, field := range fields {
+		control, err := ParsePortAuthControl(dot1xControlLabel(options[field], values[field]))
+		if err != nil {
+			return nil, NewParsingError(fmt.Sprintf("invalid 802.1X control for port %d", portID), err)
+		}
+		settings.Ports = append(settings.Ports, Dot1XPort{PortID: portID, Control: control})
+	}
+	sort.Slice(settings.Ports, func(i, j int) bool { return settings.Ports[i].PortID < settings.Ports[j].PortID })
+	return settings, nil
+}
+
+// SetDot1X applies changes to the 802.1X configuration in a single form
+// submission. Settings not named in the update keep their value. Only GS316
+// and smart managed pro models offer port authentication; other models return
+// ErrPortAuthNotSupported.
+func (m *SecurityManager) SetDot1X(ctx context.Context, update Dot1XUpdate) error {
+	if !m.client.IsAuthenticated() {
+		return ErrNotAuthenticated
+	}
+	if !m.client.endpoints.IsEndpointSupported(EndpointPortAuth) {
+		return ErrPortAuthNotSupported.WithSwitch(m.client.address, m.client.model)
+	}
+	if err := m.client.admitWrite(ctx); err != nil {
+		return err
+	}
+	if update.Enabled == nil && len(update.Ports) == 0 {
+		return NewOperationError("no updates provided", nil)
+	}
+	portIDs := make([]int, len(update.Ports))
+	for i, port := range update.Ports {
+		portIDs[i] = port.PortID
+	}
+	if err := m.client.checkPortLocks(ctx, portIDs...); err != nil {
+		return err
+	}
+
+	endpoint := m.client.endpoints.GetEndpoint(EndpointPortAuth).URL
+	page, err := m.client.makeAuthenticatedRequestWithFallback(ctx, "GET", endpoint, nil, EndpointPortAuth)
+	if err != nil {
+		return err
+	}
+
+	values, fields, err := parseDot1X(page)
+	if err != nil {
+		return err
+	}
+	options, err := internal.ParseSelectOptions(page)
+	if err != nil {
+		return NewParsingError("failed to parse 802.1X settings", err)
+	}
+
+	// Resubmit the whole form so ports not being changed keep their mode
+	data := url.Values{}
+	for name, value := range values {
+		data.Set(name, value)
+	}
+	setFormBool(data, dot1xFieldEnabled, update.Enabled)
+	for _, port := range update.Ports {
+		field, ok := fields[port.PortID]
+		if !ok {
+			return m.client.portError(port.PortID, fmt.Sprintf("port %d has no 802.1X setting", port.PortID), nil)
+		}
+		value, err := dot1xControlValue(options[field], port.Control)
+		if err != nil {
+			return m.client.portError(port.PortID, fmt.Sprintf("port %d: %v", port.PortID, err), nil)
+		}
+		data.Set(field, value)
+	}
+	if hash := m.client.extractSecurityHash(ctx, page); hash != "" {
+		data.Set(m.client.hashFieldName(), hash)
+	}
+
+	response, err := m.client.makeAuthenticatedRequestWithFallback(ctx, "POST", endpoint, data, EndpointPortAuth)
+	if err != nil {
+		return err
+	}
+	if errorMsg := internal.ExtractErrorMessage(response); errorMsg != "" {
+		return NewOperationError("802.1X update failed: "+errorMsg, nil).WithSwitch(m.client.address, m.client.model)
+	}
+	return nil
+}
+
+// SetPortAuthControl sets the 802.1X authorization mode of a single port
+func (m *SecurityManager) SetPortAuthControl(ctx context.Context, portID int, control PortAuthControl) error {
+	return m.SetDot1X(ctx, Dot1XUpdate{Ports: []Dot1XPort{{PortID: portID, Control: control}}})
+}
+
+// parseDot1X returns the port authentication page's form values and the control field of each port
+func parseDot1X(content string) (map[string]string, map[int]string, error) {
+	values, err := internal.ParseFormValues(content)
+	if err != nil {
+		return nil, nil, NewParsingError("failed to parse 802.1X settings", err)
+	}
+	if _, ok := values[dot1xFieldEnabled]; !ok {
+		return nil, nil, NewParsingError("802.1X settings not found in response", nil)
+	}
+
+	fields := make(map[int]string)
+	for name := range values {
+		if match := dot1xPortField.FindStringSubmatch(name); match != nil {
+			portID, _ := strconv.Atoi(match[1])
+			fields[portID] = name
+		}
+	}
+	return values, fields, nil
+}
+
+// dot1xControlLabel returns the label of the selected option, since firmware
+// may number the modes; values without a matching option are returned as they are
+func dot1xControlLabel(options []internal.SelectOption, value string) string {
+	for _, option := range options {
+		if option.Value == value {
+			return option.Label
+		}
+	}
+	return value
+}
+
+// dot1xControlValue returns the option value selecting a port control mode;
+// without options the mode is posted as it is
+func dot1xControlValue(options []internal.SelectOption, control PortAuthControl) (string, error) {
+	if _, err := ParsePortAuthControl(string(control)); err != nil {
+		return "", err
+	}
+	if len(options) == 0 {
+		return string(control), nil
+	}
+	for _, option := range options {
+		for _, label := range []string{option.Value, option.Label} {
+			if parsed, err := ParsePortAuthControl(label); err == nil && parsed == control {
+				return option.Value, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("802.1X control %q not offered by the switch", control)
+}
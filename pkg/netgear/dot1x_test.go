@@ -0,0 +1,79 @@
+package netgear
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestDot1X(t *testing.T) {
+	var posted url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/iss/specific/dot1x.html" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Method == http.MethodPost {
+			r.ParseForm()
+			posted = r.PostForm
+			return
+		}
+		options := `<option value="1">Auto</option><option value="2" selected>Force Authorized</option><option value="3">Force Unauthorized</option>`
+		fmt.Fprintf(w, `<input type="hidden" name="hash" value="h1">
+<input type="checkbox" name="dot1x_status">
+<select name="port_control_1">%s</select>
+<select name="port_control_2">%s</select>`, options, strings.Replace(options, `"1">`, `"1" selected>`, 1))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	address := strings.TrimPrefix(server.URL, "http://")
+	tokenMgr := NewMemoryTokenManager()
+	tokenMgr.StoreToken(ctx, address, "token", ModelGS316EP)
+	client, err := NewClient(address, WithTokenManager(tokenMgr), WithPasswordManager(nil))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	settings, err := client.Security().GetDot1X(ctx)
+	if err != nil {
+		t.Fatalf("GetDot1X failed: %v", err)
+	}
+	if settings.Enabled || len(settings.Ports) != 2 ||
+		settings.Ports[0] != (Dot1XPort{PortID: 1, Control: PortAuthForceAuthorized}) ||
+		settings.Ports[1] != (Dot1XPort{PortID: 2, Control: PortAuthAuto}) {
+		t.Errorf("unexpected settings %+v", settings)
+	}
+
+	enabled := true
+	update := Dot1XUpdate{Enabled: &enabled, Ports: []Dot1XPort{{PortID: 1, Control: PortAuthAuto}}}
+	if err := client.Security().SetDot1X(ctx, update); err != nil {
+		t.Fatalf("SetDot1X failed: %v", err)
+	}
+	if posted.Get("dot1x_status") != "1" || posted.Get("port_control_1") != "1" || posted.Get("port_control_2") != "1" || posted.Get("hash") != "h1" {
+		t.Errorf("unexpected form %v", posted)
+	}
+
+	if err := client.Security().SetPortAuthControl(ctx, 3, PortAuthAuto); err == nil {
+		t.Error("expected an error for a port without an 802.1X setting")
+	}
+}
+
+func TestDot1XNotSupportedOnGS30x(t *testing.T) {
+	address := "192.0.2.1"
+	client, err := NewClient(address, factoryClientOptions(address)...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	if _, err := client.Security().GetDot1X(context.Background()); !errors.Is(err, ErrPortAuthNotSupported) {
+		t.Errorf("expected ErrPortAuthNotSupported, got %v", err)
+	}
+	if err := client.Security().SetPortAuthControl(context.Background(), 1, PortAuthAuto); !errors.Is(err, ErrPortAuthNotSupported) {
+		t.Errorf("expected ErrPortAuthNotSupported, got %v", err)
+	}
+}
@@ -1,8 +1,10 @@
 package netgear
 
-import "fmt"
+// smartProLoginPath is the login page of smart managed pro models
+const smartProLoginPath = "/base/main_login.html"
 
-// EndpointRegistry manages model-specific endpoint mappings
+// EndpointRegistry resolves operations to the pages of a model, as listed in
+// its family's endpoint table
 type EndpointRegistry struct {
 	model Model
 }
@@ -11,14 +13,32 @@ type EndpointRegistry struct {
 type EndpointType string
 
 const (
-	EndpointLogin          EndpointType = "login"
-	EndpointPOEStatus      EndpointType = "poe_status"
-	EndpointPOESettings    EndpointType = "poe_settings"
-	EndpointPOEUpdate      EndpointType = "poe_update"
-	EndpointPortStatus     EndpointType = "port_status"
-	EndpointPortSettings   EndpointType = "port_settings"
-	EndpointPortUpdate     EndpointType = "port_update"
-	EndpointDashboard      EndpointType = "dashboard"
+	EndpointLogin           EndpointType = "login"
+	EndpointPOEStatus       EndpointType = "poe_status"
+	EndpointPOESettings     EndpointType = "poe_settings"
+	EndpointPOEUpdate       EndpointType = "poe_update"
+	EndpointPortStatus      EndpointType = "port_status"
+	EndpointPortSettings    EndpointType = "port_settings"
+	EndpointPortUpdate      EndpointType = "port_update"
+	EndpointDashboard       EndpointType = "dashboard"
+	EndpointDoS             EndpointType = "dos"
+	EndpointVLANConfig      EndpointType = "vlan_config"
+	EndpointVLANMembership  EndpointType = "vlan_membership"
+	EndpointVLANPVID        EndpointType = "vlan_pvid"
+	EndpointAttachedDevices EndpointType = "attached_devices"
+	EndpointChangePassword  EndpointType = "change_password"
+	EndpointSystemUpdate    EndpointType = "system_update"
+	EndpointFlowControl     EndpointType = "flow_control"
+	EndpointBroadcastFilter EndpointType = "broadcast_filter"
+	EndpointFactoryReset    EndpointType = "factory_reset"
+	EndpointMACTable        EndpointType = "mac_table"
+	EndpointReboot          EndpointType = "reboot"
+	EndpointPOEOverview     EndpointType = "poe_overview"
+	EndpointPortStatistics  EndpointType = "port_statistics"
+	EndpointLLDPNeighbors   EndpointType = "lldp_neighbors"
+	EndpointLoopPrevention  EndpointType = "loop_prevention"
+	EndpointStormControl    EndpointType = "storm_control"
+	EndpointPortAuth        EndpointType = "port_authentication"
 )
 
 // EndpointInfo contains endpoint URL and whether it's supported
@@ -35,65 +55,121 @@ func NewEndpointRegistry(model Model) *EndpointRegistry {
 
 // GetEndpoint returns the endpoint info for a given operation type
 func (er *EndpointRegistry) GetEndpoint(endpointType EndpointType) EndpointInfo {
-	switch {
-	case er.model.IsModel30x():
-		return er.getGS30xEndpoint(endpointType)
-	case er.model.IsModel316():
-		return er.getGS316Endpoint(endpointType)
-	default:
+	spec, ok := knownModels[er.model]
+	if !ok {
 		return EndpointInfo{URL: "", Supported: false}
 	}
+	return spec.endpoint(endpointType)
 }
 
-// getGS30xEndpoint returns endpoints for GS30x series (GS305EP, GS308EP, GS316EP, etc.)
-func (er *EndpointRegistry) getGS30xEndpoint(endpointType EndpointType) EndpointInfo {
-	switch endpointType {
-	case EndpointLogin:
-		return EndpointInfo{URL: "/login.cgi", Supported: true, Method: "POST"}
-	case EndpointPOEStatus:
-		return EndpointInfo{URL: "/getPoePortStatus.cgi", Supported: true, Method: "GET"}
-	case EndpointPOESettings:
-		return EndpointInfo{URL: "/PoEPortConfig.cgi", Supported: true, Method: "GET"}
-	case EndpointPOEUpdate:
-		return EndpointInfo{URL: "/PoEPortConfig.cgi", Supported: true, Method: "POST"}
-	case EndpointPortStatus:
-		// GS30x series doesn't have a dedicated port status endpoint - use dashboard
-		return EndpointInfo{URL: "/dashboard.cgi", Supported: false, Method: "GET"}
-	case EndpointPortSettings:
-		// GS30x series doesn't have a dedicated port settings endpoint
-		return EndpointInfo{URL: "/dashboard.cgi", Supported: false, Method: "GET"}
-	case EndpointPortUpdate:
-		// GS30x series doesn't have a dedicated port update endpoint - NOT SUPPORTED
-		return EndpointInfo{URL: "/PortConfig.cgi", Supported: false, Method: "POST"}
-	case EndpointDashboard:
-		return EndpointInfo{URL: "/dashboard.cgi", Supported: true, Method: "GET"}
-	default:
-		return EndpointInfo{URL: "", Supported: false}
-	}
+// gs30xEndpoints are the pages of the GS30x family (GS305EP, GS308EP and MS108EUP)
+var gs30xEndpoints = map[EndpointType]EndpointInfo{
+	EndpointLogin:       {URL: "/login.cgi", Supported: true, Method: "POST"},
+	EndpointPOEStatus:   {URL: "/getPoePortStatus.cgi", Supported: true, Method: "GET"},
+	EndpointPOESettings: {URL: "/PoEPortConfig.cgi", Supported: true, Method: "GET"},
+	EndpointPOEUpdate:   {URL: "/PoEPortConfig.cgi", Supported: true, Method: "POST"},
+	// GS30x series doesn't have a dedicated port status endpoint - use dashboard
+	EndpointPortStatus: {URL: "/dashboard.cgi", Supported: false, Method: "GET"},
+	// GS30x series doesn't have a dedicated port settings endpoint
+	EndpointPortSettings: {URL: "/dashboard.cgi", Supported: false, Method: "GET"},
+	// GS30x series doesn't have a dedicated port update endpoint - NOT SUPPORTED
+	EndpointPortUpdate:     {URL: "/PortConfig.cgi", Supported: false, Method: "POST"},
+	EndpointDashboard:      {URL: "/dashboard.cgi", Supported: true, Method: "GET"},
+	EndpointDoS:            {URL: "/dos.cgi", Supported: true, Method: "GET"},
+	EndpointVLANConfig:     {URL: "/8021qCf.cgi", Supported: true, Method: "GET"},
+	EndpointVLANMembership: {URL: "/8021qMembe.cgi", Supported: true, Method: "GET"},
+	EndpointVLANPVID:       {URL: "/portPVID.cgi", Supported: true, Method: "GET"},
+	// Only listed by some firmware versions; older ones answer 404
+	EndpointAttachedDevices: {URL: "/attachedDevices.cgi", Supported: true, Method: "GET"},
+	EndpointChangePassword:  {URL: "/changePassword.cgi", Supported: true, Method: "POST"},
+	EndpointSystemUpdate:    {URL: "/dashboard.cgi", Supported: true, Method: "POST"},
+	// GS30x firmware configures flow control for the whole switch
+	EndpointFlowControl: {URL: "/flowControl.cgi", Supported: true, Method: "GET"},
+	// Per-port ingress broadcast filtering, only present on EP firmware
+	EndpointBroadcastFilter: {URL: "/broadcastFilter.cgi", Supported: true, Method: "GET"},
+	EndpointFactoryReset:    {URL: "/factoryDefault.cgi", Supported: true, Method: "POST"},
+	EndpointMACTable:        {URL: "/macAddrTable.cgi", Supported: true, Method: "GET"},
+	EndpointReboot:          {URL: "/device_reboot.cgi", Supported: true, Method: "POST"},
+	// Switch-wide power budget and consumption; older firmware answers 404
+	EndpointPOEOverview:    {URL: "/poeConfig.cgi", Supported: true, Method: "GET"},
+	EndpointPortStatistics: {URL: "/portStatistics.cgi", Supported: true, Method: "GET"},
+	// Only firmware with LLDP lists neighbors; older ones answer 404
+	EndpointLLDPNeighbors:  {URL: "/lldpNeighbors.cgi", Supported: true, Method: "GET"},
+	EndpointLoopPrevention: {URL: "/loopDetection.cgi", Supported: true, Method: "GET"},
+	EndpointStormControl:   {URL: "/stormControl.cgi", Supported: true, Method: "GET"},
+	// GS30x firmware has no 802.1X port authentication
+	EndpointPortAuth: {URL: "", Supported: false},
 }
 
-// getGS316Endpoint returns endpoints for GS316 series
-func (er *EndpointRegistry) getGS316Endpoint(endpointType EndpointType) EndpointInfo {
-	switch endpointType {
-	case EndpointLogin:
-		return EndpointInfo{URL: "/login.cgi", Supported: true, Method: "POST"}
-	case EndpointPOEStatus:
-		return EndpointInfo{URL: "/iss/specific/poePortStatus.html", Supported: true, Method: "GET"}
-	case EndpointPOESettings:
-		return EndpointInfo{URL: "/iss/specific/poePortConf.html", Supported: true, Method: "GET"}
-	case EndpointPOEUpdate:
-		return EndpointInfo{URL: "/iss/specific/poePortConf.html", Supported: true, Method: "POST"}
-	case EndpointPortStatus:
-		return EndpointInfo{URL: "/iss/specific/interface.html", Supported: true, Method: "GET"}
-	case EndpointPortSettings:
-		return EndpointInfo{URL: "/iss/specific/interface.html", Supported: true, Method: "GET"}
-	case EndpointPortUpdate:
-		return EndpointInfo{URL: "/iss/specific/interface.html", Supported: true, Method: "POST"}
-	case EndpointDashboard:
-		return EndpointInfo{URL: "/iss/specific/dashboard.html", Supported: true, Method: "GET"}
-	default:
-		return EndpointInfo{URL: "", Supported: false}
-	}
+// gs316Endpoints are the pages of the GS316 family
+var gs316Endpoints = map[EndpointType]EndpointInfo{
+	EndpointLogin:          {URL: "/login.cgi", Supported: true, Method: "POST"},
+	EndpointPOEStatus:      {URL: "/iss/specific/poePortStatus.html", Supported: true, Method: "GET"},
+	EndpointPOESettings:    {URL: "/iss/specific/poePortConf.html", Supported: true, Method: "GET"},
+	EndpointPOEUpdate:      {URL: "/iss/specific/poePortConf.html", Supported: true, Method: "POST"},
+	EndpointPortStatus:     {URL: "/iss/specific/interface.html", Supported: true, Method: "GET"},
+	EndpointPortSettings:   {URL: "/iss/specific/interface.html", Supported: true, Method: "GET"},
+	EndpointPortUpdate:     {URL: "/iss/specific/interface.html", Supported: true, Method: "POST"},
+	EndpointDashboard:      {URL: "/iss/specific/dashboard.html", Supported: true, Method: "GET"},
+	EndpointDoS:            {URL: "/iss/specific/dos.html", Supported: true, Method: "GET"},
+	EndpointVLANConfig:     {URL: "/iss/specific/vlanConf.html", Supported: true, Method: "GET"},
+	EndpointVLANMembership: {URL: "/iss/specific/vlanMembership.html", Supported: true, Method: "GET"},
+	EndpointVLANPVID:       {URL: "/iss/specific/vlanPvid.html", Supported: true, Method: "GET"},
+	// Only listed by some firmware versions; older ones answer 404
+	EndpointAttachedDevices: {URL: "/iss/specific/attachedDevices.html", Supported: true, Method: "GET"},
+	EndpointChangePassword:  {URL: "/iss/specific/changePassword.html", Supported: true, Method: "POST"},
+	EndpointSystemUpdate:    {URL: "/iss/specific/dashboard.html", Supported: true, Method: "POST"},
+	// GS316 configures flow control per port on the interface page
+	EndpointFlowControl: {URL: "", Supported: false},
+	// GS316 firmware only offers rate-based storm control
+	EndpointBroadcastFilter: {URL: "", Supported: false},
+	EndpointFactoryReset:    {URL: "/iss/specific/factoryDefault.html", Supported: true, Method: "POST"},
+	EndpointMACTable:        {URL: "/iss/specific/macAddrTable.html", Supported: true, Method: "GET"},
+	EndpointReboot:          {URL: "/iss/specific/reboot.html", Supported: true, Method: "POST"},
+	// Switch-wide power budget and consumption; older firmware answers 404
+	EndpointPOEOverview:    {URL: "/iss/specific/poe.html", Supported: true, Method: "GET"},
+	EndpointPortStatistics: {URL: "/iss/specific/portStatistics.html", Supported: true, Method: "GET"},
+	// Only firmware with LLDP lists neighbors; older ones answer 404
+	EndpointLLDPNeighbors:  {URL: "/iss/specific/lldpRemoteDevices.html", Supported: true, Method: "GET"},
+	EndpointLoopPrevention: {URL: "/iss/specific/loopPrevention.html", Supported: true, Method: "GET"},
+	EndpointStormControl:   {URL: "/iss/specific/stormControl.html", Supported: true, Method: "GET"},
+	EndpointPortAuth:       {URL: "/iss/specific/dot1x.html", Supported: true, Method: "GET"},
+}
+
+// smartProEndpoints are the pages of the smart managed pro family (GS108Tv3,
+// GS110TP, MS108TUP). Its pages live under /base/ but post the same form
+// fields and security hash as GS30x firmware.
+var smartProEndpoints = map[EndpointType]EndpointInfo{
+	EndpointLogin:          {URL: smartProLoginPath, Supported: true, Method: "POST"},
+	EndpointPOEStatus:      {URL: "/base/poe/poe_port_status.html", Supported: true, Method: "GET"},
+	EndpointPOESettings:    {URL: "/base/poe/poe_port_config.html", Supported: true, Method: "GET"},
+	EndpointPOEUpdate:      {URL: "/base/poe/poe_port_config.html", Supported: true, Method: "POST"},
+	EndpointPOEOverview:    {URL: "/base/poe/poe_config.html", Supported: true, Method: "GET"},
+	EndpointPortStatus:     {URL: "/base/system/port_summary.html", Supported: true, Method: "GET"},
+	EndpointPortSettings:   {URL: "/base/system/port_config.html", Supported: true, Method: "GET"},
+	EndpointPortUpdate:     {URL: "/base/system/port_config.html", Supported: true, Method: "POST"},
+	EndpointDashboard:      {URL: "/base/system/management/sysInfo.html", Supported: true, Method: "GET"},
+	EndpointDoS:            {URL: "/base/security/dos_config.html", Supported: true, Method: "GET"},
+	EndpointVLANConfig:     {URL: "/base/switching/vlan/vlan_config.html", Supported: true, Method: "GET"},
+	EndpointVLANMembership: {URL: "/base/switching/vlan/vlan_membership.html", Supported: true, Method: "GET"},
+	EndpointVLANPVID:       {URL: "/base/switching/vlan/port_vlan_config.html", Supported: true, Method: "GET"},
+	// Smart managed pro firmware has no attached devices page; use the MAC table
+	EndpointAttachedDevices: {URL: "", Supported: false},
+	EndpointChangePassword:  {URL: "/base/system/management/set_password.html", Supported: true, Method: "POST"},
+	EndpointSystemUpdate:    {URL: "/base/system/management/sysInfo.html", Supported: true, Method: "POST"},
+	// Flow control is set per port on the port configuration page
+	EndpointFlowControl: {URL: "", Supported: false},
+	// Smart managed pro firmware only offers rate-based storm control
+	EndpointBroadcastFilter: {URL: "", Supported: false},
+	EndpointFactoryReset:    {URL: "/base/system/factory_default.html", Supported: true, Method: "POST"},
+	EndpointMACTable:        {URL: "/base/switching/mac_address_table.html", Supported: true, Method: "GET"},
+	EndpointReboot:          {URL: "/base/system/device_reboot.html", Supported: true, Method: "POST"},
+	EndpointPortStatistics:  {URL: "/base/monitoring/port_statistics.html", Supported: true, Method: "GET"},
+	EndpointLLDPNeighbors:   {URL: "/base/system/lldp/lldp_remote_devices.html", Supported: true, Method: "GET"},
+	// Smart managed pro firmware relies on spanning tree instead
+	EndpointLoopPrevention: {URL: "", Supported: false},
+	EndpointStormControl:   {URL: "/base/qos/storm_control.html", Supported: true, Method: "GET"},
+	EndpointPortAuth:       {URL: "/base/security/dot1x_port_config.html", Supported: true, Method: "GET"},
 }
 
 // IsEndpointSupported checks if an endpoint is supported for the current model
@@ -103,13 +179,8 @@ func (er *EndpointRegistry) IsEndpointSupported(endpointType EndpointType) bool
 
 // GetSupportedEndpoints returns all supported endpoints for the current model
 func (er *EndpointRegistry) GetSupportedEndpoints() map[EndpointType]EndpointInfo {
-	allEndpoints := []EndpointType{
-		EndpointLogin, EndpointPOEStatus, EndpointPOESettings, EndpointPOEUpdate,
-		EndpointPortStatus, EndpointPortSettings, EndpointPortUpdate, EndpointDashboard,
-	}
-
 	supported := make(map[EndpointType]EndpointInfo)
-	for _, endpoint := range allEndpoints {
+	for endpoint := range knownModels[er.model].family.endpoints {
 		info := er.GetEndpoint(endpoint)
 		if info.Supported {
 			supported[endpoint] = info
@@ -122,9 +193,7 @@ func (er *EndpointRegistry) GetSupportedEndpoints() map[EndpointType]EndpointInf
 func (er *EndpointRegistry) ValidateEndpoint(endpointType EndpointType) error {
 	info := er.GetEndpoint(endpointType)
 	if !info.Supported {
-		return NewOperationError(
-			fmt.Sprintf("%s operation not supported on %s model",
-				string(endpointType), string(er.model)), nil)
+		return &UnsupportedOperationError{Operation: string(endpointType), Model: er.model}
 	}
 	return nil
-}
\ No newline at end of file
+}
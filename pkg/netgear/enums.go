@@ -0,0 +1,109 @@
+package netgear
+
+import (
+	"fmt"
+	"strings"
+)
+
+// portSpeedLabels maps normalized firmware speed labels to speeds. Labels are
+// lower-cased with spaces, dashes and "duplex"/"bps" stripped before lookup.
+var portSpeedLabels = map[string]PortSpeed{
+	"auto":            PortSpeedAuto,
+	"autonegotiation": PortSpeedAuto,
+	"autonegotiate":   PortSpeedAuto,
+	"10mhalf":         PortSpeed10MHalf,
+	"10mhd":           PortSpeed10MHalf,
+	"10h":             PortSpeed10MHalf,
+	"10mfull":         PortSpeed10MFull,
+	"10mfd":           PortSpeed10MFull,
+	"10f":             PortSpeed10MFull,
+	"100mhalf":        PortSpeed100MHalf,
+	"100mhd":          PortSpeed100MHalf,
+	"100h":            PortSpeed100MHalf,
+	"100mfull":        PortSpeed100MFull,
+	"100mfd":          PortSpeed100MFull,
+	"100f":            PortSpeed100MFull,
+	"disable":         PortSpeedDisable,
+	"disabled":        PortSpeedDisable,
+	"off":             PortSpeedDisable,
+}
+
+// portStatusLabels maps lower-cased firmware link labels to statuses
+var portStatusLabels = map[string]PortStatus{
+	"connected":  PortStatusConnected,
+	"up":         PortStatusConnected,
+	"link up":    PortStatusConnected,
+	"linkup":     PortStatusConnected,
+	"available":  PortStatusAvailable,
+	"down":       PortStatusAvailable,
+	"link down":  PortStatusAvailable,
+	"linkdown":   PortStatusAvailable,
+	"no link":    PortStatusAvailable,
+	"disabled":   PortStatusDisabled,
+	"disable":    PortStatusDisabled,
+	"admin down": PortStatusDisabled,
+	"shutdown":   PortStatusDisabled,
+}
+
+// ParsePortSpeed converts a speed label as shown by any supported firmware
+// ("Auto", "100M Full", "100 Mbps Full Duplex", "10M-HD", "Disable") to a PortSpeed
+func ParsePortSpeed(s string) (PortSpeed, error) {
+	key := strings.ToLower(s)
+	for _, noise := range []string{"duplex", "bps", " ", "-", "_"} {
+		key = strings.ReplaceAll(key, noise, "")
+	}
+	if speed, ok := portSpeedLabels[key]; ok {
+		return speed, nil
+	}
+	return "", fmt.Errorf("unknown port speed %q", s)
+}
+
+// ParsePortStatus converts a link status label as shown by any supported
+// firmware ("Up", "UP", "Connected", "Link Down", "Disabled") to a PortStatus
+func ParsePortStatus(s string) (PortStatus, error) {
+	if status, ok := portStatusLabels[strings.ToLower(strings.TrimSpace(s))]; ok {
+		return status, nil
+	}
+	return "", fmt.Errorf("unknown port status %q", s)
+}
+
+// String returns the speed label
+func (s PortSpeed) String() string {
+	return string(s)
+}
+
+// String returns the status label
+func (s PortStatus) String() string {
+	return string(s)
+}
+
+// portAuthControlLabels maps normalized firmware 802.1X port control labels
+// to modes. Labels are lower-cased with spaces, dashes and underscores stripped.
+var portAuthControlLabels = map[string]PortAuthControl{
+	"auto":              PortAuthAuto,
+	"forceauthorized":   PortAuthForceAuthorized,
+	"authorized":        PortAuthForceAuthorized,
+	"forceauth":         PortAuthForceAuthorized,
+	"forceunauthorized": PortAuthForceUnauthorized,
+	"unauthorized":      PortAuthForceUnauthorized,
+	"forceunauth":       PortAuthForceUnauthorized,
+}
+
+// ParsePortAuthControl converts an 802.1X port control label as shown by the
+// firmware ("Auto", "Force Authorized", "force_unauthorized") to a PortAuthControl
+func ParsePortAuthControl(s string) (PortAuthControl, error) {
+	if control, ok := portAuthControlLabels[normalizePortAuthControl(s)]; ok {
+		return control, nil
+	}
+	return "", fmt.Errorf("unknown 802.1X port control %q", s)
+}
+
+// normalizePortAuthControl reduces a port control label to its lookup key
+func normalizePortAuthControl(s string) string {
+	return strings.NewReplacer(" ", "", "-", "", "_", "").Replace(strings.ToLower(strings.TrimSpace(s)))
+}
+
+// String returns the port control label
+func (c PortAuthControl) String() string {
+	return string(c)
+}
@@ -0,0 +1,41 @@
+package netgear
+
+import "testing"
+
+func TestParsePortSpeed(t *testing.T) {
+	tests := map[string]PortSpeed{
+		"Auto":                   PortSpeedAuto,
+		"100M Full":              PortSpeed100MFull,
+		"100 Mbps Full Duplex":   PortSpeed100MFull,
+		"10M-HD":                 PortSpeed10MHalf,
+		"Disable":                PortSpeedDisable,
+		string(PortSpeed10MFull): PortSpeed10MFull,
+	}
+	for label, want := range tests {
+		if got, err := ParsePortSpeed(label); err != nil || got != want {
+			t.Errorf("ParsePortSpeed(%q) = %q, %v; want %q", label, got, err, want)
+		}
+	}
+	if _, err := ParsePortSpeed("warp 9"); err == nil {
+		t.Error("expected error for unknown speed")
+	}
+}
+
+func TestParsePortStatus(t *testing.T) {
+	tests := map[string]PortStatus{
+		"Up":         PortStatusConnected,
+		"UP":         PortStatusConnected,
+		"Connected":  PortStatusConnected,
+		" Link Down": PortStatusAvailable,
+		"Available":  PortStatusAvailable,
+		"Disabled":   PortStatusDisabled,
+	}
+	for label, want := range tests {
+		if got, err := ParsePortStatus(label); err != nil || got != want {
+			t.Errorf("ParsePortStatus(%q) = %q, %v; want %q", label, got, err, want)
+		}
+	}
+	if _, err := ParsePortStatus("sideways"); err == nil {
+		t.Error("expected error for unknown status")
+	}
+}
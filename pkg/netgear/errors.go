@@ -1,6 +1,12 @@
 package netgear
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/gherlein/go-netgear/pkg/redact"
+)
 
 // ErrorType represents the category of error
 type ErrorType string
@@ -13,42 +19,228 @@ const (
 	ErrorTypeOperation ErrorType = "operation"
 )
 
-// Error represents a netgear client error
+// Error represents a netgear client error. The context fields identify where
+// the error happened so fleet tooling can aggregate failures with errors.As;
+// zero values mean the context is unknown.
 type Error struct {
 	Type    ErrorType
 	Message string
 	Cause   error
+
+	Address    string
+	Model      Model
+	Endpoint   string
+	PortID     int
+	HTTPStatus int
 }
 
+// Error describes the error with secrets redacted, since causes such as
+// *url.Error carry request URLs that include Gambit tokens
 func (e *Error) Error() string {
 	if e.Cause != nil {
-		return fmt.Sprintf("%s error: %s: %v", e.Type, e.Message, e.Cause)
+		return redact.String(fmt.Sprintf("%s error: %s: %v", e.Type, e.Message, e.Cause))
 	}
-	return fmt.Sprintf("%s error: %s", e.Type, e.Message)
+	return redact.String(fmt.Sprintf("%s error: %s", e.Type, e.Message))
 }
 
 func (e *Error) Unwrap() error {
 	return e.Cause
 }
 
+// Is matches errors of the same type and message, so sentinel errors still
+// match after context has been attached to a copy of them. Network errors
+// also match ErrNetworkTimeout and ErrConnectionFailed when their cause is a
+// timeout or a failed connection attempt.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok || t.Cause != nil || e.Type != t.Type {
+		return false
+	}
+	if e.Message == t.Message {
+		return true
+	}
+	if e.Type != ErrorTypeNetwork || e.Cause == nil {
+		return false
+	}
+	switch t.Message {
+	case ErrNetworkTimeout.Message:
+		var netErr net.Error
+		return errors.As(e.Cause, &netErr) && netErr.Timeout()
+	case ErrConnectionFailed.Message:
+		var opErr *net.OpError
+		return errors.As(e.Cause, &opErr) && opErr.Op == "dial"
+	}
+	return false
+}
+
+// WithSwitch returns a copy of the error annotated with the switch address and model
+func (e *Error) WithSwitch(address string, model Model) *Error {
+	c := *e
+	c.Address = address
+	c.Model = model
+	return &c
+}
+
+// WithEndpoint returns a copy of the error annotated with the switch page involved
+func (e *Error) WithEndpoint(endpoint string) *Error {
+	c := *e
+	c.Endpoint = endpoint
+	return &c
+}
+
+// WithPort returns a copy of the error annotated with the port involved
+func (e *Error) WithPort(portID int) *Error {
+	c := *e
+	c.PortID = portID
+	return &c
+}
+
+// WithHTTPStatus returns a copy of the error annotated with the HTTP status returned by the switch
+func (e *Error) WithHTTPStatus(status int) *Error {
+	c := *e
+	c.HTTPStatus = status
+	return &c
+}
+
+// inheritContext fills unset context fields from the first *Error in cause's chain
+func (e *Error) inheritContext(cause error) {
+	var inner *Error
+	if !errors.As(cause, &inner) {
+		return
+	}
+	if e.Address == "" {
+		e.Address = inner.Address
+	}
+	if e.Model == "" {
+		e.Model = inner.Model
+	}
+	if e.Endpoint == "" {
+		e.Endpoint = inner.Endpoint
+	}
+	if e.PortID == 0 {
+		e.PortID = inner.PortID
+	}
+	if e.HTTPStatus == 0 {
+		e.HTTPStatus = inner.HTTPStatus
+	}
+}
+
 // Sentinel errors
 var (
-	ErrNotAuthenticated   = &Error{Type: ErrorTypeAuth, Message: "not authenticated"}
-	ErrSessionExpired     = &Error{Type: ErrorTypeAuth, Message: "session expired"}
-	ErrModelNotSupported  = &Error{Type: ErrorTypeModel, Message: "model not supported"}
-	ErrModelNotDetected   = &Error{Type: ErrorTypeModel, Message: "could not detect switch model"}
-	ErrInvalidCredentials = &Error{Type: ErrorTypeAuth, Message: "invalid credentials"}
-	ErrNetworkTimeout     = &Error{Type: ErrorTypeNetwork, Message: "network timeout"}
-	ErrInvalidResponse    = &Error{Type: ErrorTypeParsing, Message: "invalid response format"}
+	ErrNotAuthenticated         = &Error{Type: ErrorTypeAuth, Message: "not authenticated"}
+	ErrSessionExpired           = &Error{Type: ErrorTypeAuth, Message: "session expired"}
+	ErrModelNotSupported        = &Error{Type: ErrorTypeModel, Message: "model not supported"}
+	ErrModelNotDetected         = &Error{Type: ErrorTypeModel, Message: "could not detect switch model"}
+	ErrInvalidCredentials       = &Error{Type: ErrorTypeAuth, Message: "invalid credentials"}
+	ErrNetworkTimeout           = &Error{Type: ErrorTypeNetwork, Message: "network timeout"}
+	ErrConnectionFailed         = &Error{Type: ErrorTypeNetwork, Message: "could not connect to the switch"}
+	ErrInvalidResponse          = &Error{Type: ErrorTypeParsing, Message: "invalid response format"}
+	ErrNotANetgearSwitch        = &Error{Type: ErrorTypeModel, Message: "response did not come from a Netgear switch"}
+	ErrUnmanagedDevice          = &Error{Type: ErrorTypeModel, Message: "Netgear device is not a supported managed switch"}
+	ErrNotAttempted             = &Error{Type: ErrorTypeOperation, Message: "not attempted after an earlier failure"}
+	ErrEventBufferClosed        = &Error{Type: ErrorTypeOperation, Message: "event buffer closed"}
+	ErrPlanStale                = &Error{Type: ErrorTypeOperation, Message: "live state changed since the plan was created"}
+	ErrOutsideMaintenanceWindow = &Error{Type: ErrorTypeOperation, Message: "write attempted outside the maintenance window"}
+	ErrInitialPasswordRequired  = &Error{Type: ErrorTypeAuth, Message: "switch has the factory default password and requires it to be changed"}
+	ErrPerPortFlowControl       = &Error{Type: ErrorTypeOperation, Message: "flow control is configured for the whole switch on this model; use System().SetFlowControl"}
+	ErrSwitchDisabled           = &Error{Type: ErrorTypeOperation, Message: "switch is disabled in the fleet"}
+	ErrPortLocked               = &Error{Type: ErrorTypeOperation, Message: "port is locked by another owner"}
+	ErrResetNotConfirmed        = &Error{Type: ErrorTypeOperation, Message: "factory reset confirmation does not match the switch"}
+	ErrSwitchBusy               = &Error{Type: ErrorTypeNetwork, Message: "switch is busy"}
+	ErrPasswordNotFound         = &Error{Type: ErrorTypeAuth, Message: "no password found for switch"}
+	ErrPortAuthNotSupported     = &Error{Type: ErrorTypeModel, Message: "802.1X port authentication is only available on GS316 and smart managed pro models"}
+	ErrSessionActive            = &Error{Type: ErrorTypeAuth, Message: "another session is active on the switch"}
+	ErrServerError              = &Error{Type: ErrorTypeNetwork, Message: "switch returned a server error"}
+	ErrOperationNotSupported    = &Error{Type: ErrorTypeModel, Message: "operation not supported on this model"}
 )
 
-// NewError creates a new netgear error
+// ResponseFingerprint summarizes an HTTP response so unexpected devices
+// (auth proxies, captive portals, other vendors' gear) can be identified
+type ResponseFingerprint struct {
+	StatusCode  int    `json:"status_code"`
+	Server      string `json:"server,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Location    string `json:"location,omitempty"`
+	Title       string `json:"title,omitempty"`
+	BodyLength  int    `json:"body_length"`
+	BodySHA256  string `json:"body_sha256"`
+}
+
+// NotANetgearSwitchError is returned when model detection receives a response
+// without any Netgear markers, typically from a proxy or captive portal in
+// front of the switch. It matches ErrNotANetgearSwitch with errors.Is.
+type NotANetgearSwitchError struct {
+	Fingerprint ResponseFingerprint
+}
+
+func (e *NotANetgearSwitchError) Error() string {
+	fp := e.Fingerprint
+	msg := fmt.Sprintf("%s (status %d", ErrNotANetgearSwitch.Error(), fp.StatusCode)
+	if fp.Server != "" {
+		msg += fmt.Sprintf(", server %q", fp.Server)
+	}
+	if fp.Title != "" {
+		msg += fmt.Sprintf(", title %q", fp.Title)
+	}
+	if fp.Location != "" {
+		msg += fmt.Sprintf(", redirect to %s", fp.Location)
+	}
+	return msg + ")"
+}
+
+// Is reports whether target is ErrNotANetgearSwitch
+func (e *NotANetgearSwitchError) Is(target error) bool {
+	return target == ErrNotANetgearSwitch
+}
+
+// UnmanagedDeviceError is returned when model detection reaches a Netgear
+// device that is not one of the supported managed switches, such as an
+// unmanaged PoE switch (GS305PP, GS108LP) or another product family. Inventory
+// scans can use it to report the device as present but unsupported. It matches
+// ErrUnmanagedDevice with errors.Is.
+type UnmanagedDeviceError struct {
+	ModelGuess  string
+	Fingerprint ResponseFingerprint
+}
+
+func (e *UnmanagedDeviceError) Error() string {
+	if e.ModelGuess == "" {
+		return ErrUnmanagedDevice.Error()
+	}
+	return fmt.Sprintf("%s (looks like %s)", ErrUnmanagedDevice.Error(), e.ModelGuess)
+}
+
+// Is reports whether target is ErrUnmanagedDevice
+func (e *UnmanagedDeviceError) Is(target error) bool {
+	return target == ErrUnmanagedDevice
+}
+
+// UnsupportedOperationError is returned for operations the switch model does
+// not offer, such as POE on a switch without POE ports. It matches
+// ErrOperationNotSupported with errors.Is.
+type UnsupportedOperationError struct {
+	Operation string
+	Model     Model
+}
+
+func (e *UnsupportedOperationError) Error() string {
+	return fmt.Sprintf("%s error: %s not supported on %s", ErrorTypeModel, e.Operation, e.Model)
+}
+
+// Is reports whether target is ErrOperationNotSupported
+func (e *UnsupportedOperationError) Is(target error) bool {
+	return target == ErrOperationNotSupported
+}
+
+// NewError creates a new netgear error, carrying over the context of cause
 func NewError(errorType ErrorType, message string, cause error) *Error {
-	return &Error{
+	err := &Error{
 		Type:    errorType,
 		Message: message,
 		Cause:   cause,
 	}
+	err.inheritContext(cause)
+	return err
 }
 
 // NewAuthError creates a new authentication error
@@ -74,4 +266,13 @@ func NewModelError(message string, cause error) *Error {
 // NewOperationError creates a new operation error
 func NewOperationError(message string, cause error) *Error {
 	return NewError(ErrorTypeOperation, message, cause)
-}
\ No newline at end of file
+}
+
+// withPort annotates a netgear error with the port involved, leaving other errors unchanged
+func withPort(err error, portID int) error {
+	var netgearErr *Error
+	if errors.As(err, &netgearErr) {
+		return netgearErr.WithPort(portID)
+	}
+	return err
+}
@@ -0,0 +1,87 @@
+package netgear
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestErrorContextInheritedFromCause(t *testing.T) {
+	cause := NewNetworkError("GET request failed", nil).
+		WithSwitch("10.0.0.2", ModelGS308EPP).
+		WithEndpoint("/getPoePortStatus.cgi").
+		WithHTTPStatus(http.StatusServiceUnavailable)
+
+	err := NewOperationError("failed to get POE status", cause).WithPort(3)
+
+	var netgearErr *Error
+	if !errors.As(err, &netgearErr) {
+		t.Fatal("Expected *Error")
+	}
+	if netgearErr.Address != "10.0.0.2" || netgearErr.Model != ModelGS308EPP ||
+		netgearErr.Endpoint != "/getPoePortStatus.cgi" || netgearErr.PortID != 3 ||
+		netgearErr.HTTPStatus != http.StatusServiceUnavailable {
+		t.Errorf("Context not carried over: %+v", netgearErr)
+	}
+}
+
+func TestAnnotatedSentinelStillMatches(t *testing.T) {
+	err := ErrNotAuthenticated.WithSwitch("10.0.0.2", ModelGS305EP)
+	if !errors.Is(err, ErrNotAuthenticated) {
+		t.Error("Expected annotated copy to match ErrNotAuthenticated")
+	}
+	if ErrNotAuthenticated.Address != "" {
+		t.Error("Annotating must not modify the sentinel")
+	}
+}
+
+func TestRequestErrorCarriesHTTPStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	address := strings.TrimPrefix(server.URL, "http://")
+	tokenMgr := NewMemoryTokenManager()
+	tokenMgr.StoreToken(ctx, address, "token", ModelGS308EPP)
+
+	client, err := NewClient(address, WithTokenManager(tokenMgr), WithPasswordManager(nil))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	_, err = client.POE().GetStatus(ctx)
+	var netgearErr *Error
+	if !errors.As(err, &netgearErr) {
+		t.Fatalf("Expected *Error, got %v", err)
+	}
+	if netgearErr.HTTPStatus != http.StatusNotFound || netgearErr.Address != address ||
+		netgearErr.Model != ModelGS308EPP || netgearErr.Endpoint != "/getPoePortStatus.cgi" {
+		t.Errorf("Unexpected error context: %+v", netgearErr)
+	}
+}
+
+func TestUnsupportedOperationMatches(t *testing.T) {
+	ctx := context.Background()
+	tokenMgr := NewMemoryTokenManager()
+	tokenMgr.StoreToken(ctx, "10.0.0.3", "token", ModelGS108Tv3)
+	client, err := NewClient("10.0.0.3", WithTokenManager(tokenMgr), WithPasswordManager(nil))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	_, err = client.POE().GetStatus(ctx)
+	if !errors.Is(err, ErrOperationNotSupported) || !strings.Contains(err.Error(), "POE status not supported on GS108Tv3") {
+		t.Errorf("expected ErrOperationNotSupported, got %v", err)
+	}
+	if err := client.endpoints.ValidateEndpoint(EndpointPOEUpdate); !errors.Is(err, ErrOperationNotSupported) {
+		t.Errorf("expected ErrOperationNotSupported from the endpoint registry, got %v", err)
+	}
+	if errors.Is(ErrServerError, ErrOperationNotSupported) {
+		t.Error("expected other errors not to match")
+	}
+}
@@ -0,0 +1,188 @@
+package netgear
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// OverflowPolicy decides what an EventBuffer does when a consumer falls behind
+// and the buffer is full
+type OverflowPolicy int
+
+const (
+	// OverflowDropOldest discards the oldest pending event to make room
+	OverflowDropOldest OverflowPolicy = iota
+	// OverflowCoalesce replaces a pending event with the same key, so only the
+	// latest state per key is delivered. Falls back to dropping the oldest event
+	// when no pending event shares the key.
+	OverflowCoalesce
+	// OverflowBlock makes the producer wait until the consumer catches up
+	OverflowBlock
+)
+
+// String returns the policy name
+func (p OverflowPolicy) String() string {
+	switch p {
+	case OverflowDropOldest:
+		return "drop-oldest"
+	case OverflowCoalesce:
+		return "coalesce"
+	case OverflowBlock:
+		return "block"
+	default:
+		return "unknown"
+	}
+}
+
+// DefaultEventBufferSize is the buffer size used when none is configured
+const DefaultEventBufferSize = 64
+
+// EventBuffer is a bounded queue between an event producer (a poller) and a
+// consumer reading from C. Unlike a plain buffered channel it never silently
+// loses events: every discarded or coalesced event is counted in Dropped.
+type EventBuffer[T any] struct {
+	mu      sync.Mutex
+	queue   []T
+	size    int
+	policy  OverflowPolicy
+	key     func(T) string
+	out     chan T
+	notify  chan struct{}
+	space   chan struct{}
+	done    chan struct{}
+	closed  bool
+	dropped atomic.Uint64
+}
+
+// NewEventBuffer creates a buffer holding up to size pending events. key
+// identifies events that may be coalesced (e.g. by port) and is only used by
+// OverflowCoalesce.
+func NewEventBuffer[T any](size int, policy OverflowPolicy, key func(T) string) *EventBuffer[T] {
+	if size <= 0 {
+		size = DefaultEventBufferSize
+	}
+
+	b := &EventBuffer[T]{
+		size:   size,
+		policy: policy,
+		key:    key,
+		out:    make(chan T),
+		notify: make(chan struct{}, 1),
+		space:  make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+	go b.pump()
+	return b
+}
+
+// C returns the channel events are delivered on. It is closed by Close.
+func (b *EventBuffer[T]) C() <-chan T {
+	return b.out
+}
+
+// Dropped returns the number of events discarded or coalesced because the
+// consumer fell behind
+func (b *EventBuffer[T]) Dropped() uint64 {
+	return b.dropped.Load()
+}
+
+// Push queues an event, applying the overflow policy if the buffer is full.
+// With OverflowBlock it waits for room until ctx is done.
+func (b *EventBuffer[T]) Push(ctx context.Context, event T) error {
+	for {
+		b.mu.Lock()
+		if b.closed {
+			b.mu.Unlock()
+			return ErrEventBufferClosed
+		}
+
+		if len(b.queue) < b.size {
+			b.queue = append(b.queue, event)
+			b.mu.Unlock()
+			b.signal(b.notify)
+			return nil
+		}
+
+		switch b.policy {
+		case OverflowBlock:
+			b.mu.Unlock()
+			select {
+			case <-b.space:
+				continue
+			case <-b.done:
+				return ErrEventBufferClosed
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		case OverflowCoalesce:
+			if b.key != nil {
+				k := b.key(event)
+				for i := len(b.queue) - 1; i >= 0; i-- {
+					if b.key(b.queue[i]) == k {
+						b.queue[i] = event
+						b.dropped.Add(1)
+						b.mu.Unlock()
+						return nil
+					}
+				}
+			}
+			fallthrough
+		default:
+			b.queue = append(b.queue[1:], event)
+			b.dropped.Add(1)
+			b.mu.Unlock()
+			b.signal(b.notify)
+			return nil
+		}
+	}
+}
+
+// Close stops the buffer, discards pending events and closes C
+func (b *EventBuffer[T]) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.closed = true
+	close(b.done)
+}
+
+// pump moves events from the queue to the consumer channel
+func (b *EventBuffer[T]) pump() {
+	defer close(b.out)
+
+	for {
+		b.mu.Lock()
+		if len(b.queue) == 0 {
+			b.mu.Unlock()
+			select {
+			case <-b.notify:
+				continue
+			case <-b.done:
+				return
+			}
+		}
+		event := b.queue[0]
+		var zero T
+		b.queue[0] = zero
+		b.queue = b.queue[1:]
+		b.mu.Unlock()
+		b.signal(b.space)
+
+		select {
+		case b.out <- event:
+		case <-b.done:
+			return
+		}
+	}
+}
+
+// signal performs a non-blocking wake-up on ch
+func (b *EventBuffer[T]) signal(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,99 @@
+package netgear
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// fillBuffer pushes events until the pump is parked on an undelivered event and
+// the queue holds size more
+func fillBuffer(t *testing.T, b *EventBuffer[int], values ...int) {
+	t.Helper()
+	for _, v := range values {
+		if err := b.Push(context.Background(), v); err != nil {
+			t.Fatalf("Push(%d) failed: %v", v, err)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func drain(b *EventBuffer[int], n int) []int {
+	var got []int
+	for i := 0; i < n; i++ {
+		select {
+		case v := <-b.C():
+			got = append(got, v)
+		case <-time.After(time.Second):
+			return got
+		}
+	}
+	return got
+}
+
+func TestEventBufferDropOldest(t *testing.T) {
+	b := NewEventBuffer[int](2, OverflowDropOldest, nil)
+	defer b.Close()
+
+	// 1 is held by the pump, 2 and 3 fill the queue, 4 evicts 2
+	fillBuffer(t, b, 1, 2, 3, 4)
+
+	got := drain(b, 3)
+	if len(got) != 3 || got[0] != 1 || got[1] != 3 || got[2] != 4 {
+		t.Errorf("Expected [1 3 4], got %v", got)
+	}
+	if b.Dropped() != 1 {
+		t.Errorf("Expected 1 dropped event, got %d", b.Dropped())
+	}
+}
+
+func TestEventBufferCoalesce(t *testing.T) {
+	b := NewEventBuffer[int](2, OverflowCoalesce, func(v int) string { return strconv.Itoa(v % 10) })
+	defer b.Close()
+
+	// 12 replaces the pending 2 because both share key "2"
+	fillBuffer(t, b, 1, 2, 3, 12)
+
+	got := drain(b, 3)
+	if len(got) != 3 || got[0] != 1 || got[1] != 12 || got[2] != 3 {
+		t.Errorf("Expected [1 12 3], got %v", got)
+	}
+	if b.Dropped() != 1 {
+		t.Errorf("Expected 1 dropped event, got %d", b.Dropped())
+	}
+}
+
+func TestEventBufferBlock(t *testing.T) {
+	b := NewEventBuffer[int](1, OverflowBlock, nil)
+	defer b.Close()
+
+	fillBuffer(t, b, 1, 2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := b.Push(ctx, 3); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected blocked push to time out, got %v", err)
+	}
+
+	got := drain(b, 2)
+	if len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Errorf("Expected [1 2], got %v", got)
+	}
+	if b.Dropped() != 0 {
+		t.Errorf("Expected no dropped events, got %d", b.Dropped())
+	}
+}
+
+func TestEventBufferClose(t *testing.T) {
+	b := NewEventBuffer[int](4, OverflowDropOldest, nil)
+	b.Close()
+
+	if err := b.Push(context.Background(), 1); !errors.Is(err, ErrEventBufferClosed) {
+		t.Errorf("Expected ErrEventBufferClosed, got %v", err)
+	}
+	if _, ok := <-b.C(); ok {
+		t.Error("Expected channel to be closed")
+	}
+}
@@ -0,0 +1,298 @@
+package netgear
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EventType identifies a state change reported by Events
+type EventType string
+
+const (
+	// EventPortLinkUp is sent when a port gains link
+	EventPortLinkUp EventType = "PortLinkUp"
+	// EventPortLinkDown is sent when a port loses link
+	EventPortLinkDown EventType = "PortLinkDown"
+	// EventPOEDeviceConnected is sent when a POE port starts powering a device
+	EventPOEDeviceConnected EventType = "POEDeviceConnected"
+	// EventPOEOverBudget is sent when the switch's POE draw reaches its budget
+	EventPOEOverBudget EventType = "POEOverBudget"
+	// EventPOEFault is sent when a POE port reports an error
+	EventPOEFault EventType = "POEFault"
+)
+
+// Event is a state change of a switch observed by Events
+type Event struct {
+	Type EventType `json:"type"`
+	// Switch is the name the switch was added to Events with
+	Switch string    `json:"switch"`
+	Time   time.Time `json:"time"`
+	// Port and PortName are empty for switch-wide events (POEOverBudget)
+	Port     int    `json:"port,omitempty"`
+	PortName string `json:"port_name,omitempty"`
+	// PowerW is the port's draw, or the switch's total draw for POEOverBudget
+	PowerW float64 `json:"power_w,omitempty"`
+	// BudgetW is the switch's POE budget (POEOverBudget only)
+	BudgetW float64 `json:"budget_w,omitempty"`
+	// Detail is the link speed for PortLinkUp and the firmware's error text
+	// for POEFault
+	Detail string `json:"detail,omitempty"`
+}
+
+// String returns a one-line description of the event
+func (e Event) String() string {
+	if e.Port == 0 {
+		return fmt.Sprintf("%s %s", e.Switch, e.Type)
+	}
+	if e.Detail != "" {
+		return fmt.Sprintf("%s port %d %s (%s)", e.Switch, e.Port, e.Type, e.Detail)
+	}
+	return fmt.Sprintf("%s port %d %s", e.Switch, e.Port, e.Type)
+}
+
+// EventHandler receives events from Events
+type EventHandler func(Event)
+
+// Events polls one or more switches and reports changes of port link and POE
+// state as typed events, to handlers registered with On and OnAll and to
+// channels returned by Subscribe. The first poll of a switch records its
+// state without reporting it; events describe changes from then on.
+type Events struct {
+	interval time.Duration
+	clock    Clock
+
+	mu          sync.Mutex
+	sources     []*eventSource
+	handlers    map[EventType][]EventHandler
+	allHandlers []EventHandler
+	buffers     []*EventBuffer[Event]
+}
+
+// eventSource is a switch watched by Events and its last observed state
+type eventSource struct {
+	name   string
+	client *Client
+
+	polledPOE   bool
+	delivering  map[int]bool
+	faulted     map[int]bool
+	overBudget  bool
+	polledPorts bool
+	linkUp      map[int]bool
+}
+
+// NewEvents creates an event source that polls its switches every interval
+// once Run is called
+func NewEvents(interval time.Duration) *Events {
+	return &Events{
+		interval: interval,
+		clock:    realClock{},
+		handlers: make(map[EventType][]EventHandler),
+	}
+}
+
+// Add watches a switch, reporting its events under name
+func (e *Events) Add(name string, client *Client) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.sources = append(e.sources, &eventSource{name: name, client: client})
+}
+
+// On registers a handler for one type of event. Handlers run on the polling
+// goroutine in the order they were registered, so they should return quickly.
+func (e *Events) On(eventType EventType, handler EventHandler) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.handlers[eventType] = append(e.handlers[eventType], handler)
+}
+
+// OnAll registers a handler for every event
+func (e *Events) OnAll(handler EventHandler) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.allHandlers = append(e.allHandlers, handler)
+}
+
+// Subscribe returns a channel receiving every event. Up to size events wait
+// for a slow reader before policy applies; with OverflowBlock a slow reader
+// delays polling. The channel is closed when Run returns.
+func (e *Events) Subscribe(size int, policy OverflowPolicy) <-chan Event {
+	buffer := NewEventBuffer[Event](size, policy, func(event Event) string {
+		return fmt.Sprintf("%s/%d/%s", event.Switch, event.Port, event.Type)
+	})
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.buffers = append(e.buffers, buffer)
+	return buffer.C()
+}
+
+// Run polls the switches every interval until ctx is done, then closes the
+// subscribed channels. Failed polls do not stop it; their errors are logged
+// to the failing client's logger.
+func (e *Events) Run(ctx context.Context) error {
+	if e.interval <= 0 {
+		return NewOperationError("events interval must be positive", nil)
+	}
+	defer e.closeBuffers()
+
+	for {
+		e.Poll(ctx)
+		if err := e.clock.Sleep(ctx, e.interval); err != nil {
+			return nil
+		}
+	}
+}
+
+// closeBuffers closes the channels returned by Subscribe
+func (e *Events) closeBuffers() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, buffer := range e.buffers {
+		buffer.Close()
+	}
+	e.buffers = nil
+}
+
+// Poll reads every switch once and dispatches the events found, for callers
+// that poll on their own schedule instead of calling Run; do not use both.
+// It returns the errors of the switches that could not be read, joined.
+func (e *Events) Poll(ctx context.Context) error {
+	e.mu.Lock()
+	sources := append([]*eventSource(nil), e.sources...)
+	e.mu.Unlock()
+
+	var errs []error
+	for _, source := range sources {
+		events, err := source.poll(ctx)
+		if err != nil {
+			source.client.logger.Warn("failed to poll switch for events", "switch", source.name, "error", err)
+			errs = append(errs, fmt.Errorf("%s: %w", source.name, err))
+		}
+		for _, event := range events {
+			e.dispatch(ctx, event)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// dispatch hands an event to the handlers and subscribers
+func (e *Events) dispatch(ctx context.Context, event Event) {
+	e.mu.Lock()
+	handlers := append(append([]EventHandler(nil), e.handlers[event.Type]...), e.allHandlers...)
+	buffers := append([]*EventBuffer[Event](nil), e.buffers...)
+	e.mu.Unlock()
+
+	for _, handler := range handlers {
+		handler(event)
+	}
+	for _, buffer := range buffers {
+		buffer.Push(ctx, event)
+	}
+}
+
+// poll reads the switch and returns the events since the previous poll.
+// Events found before a read failed are still returned.
+func (s *eventSource) poll(ctx context.Context) ([]Event, error) {
+	var events []Event
+	endpoints := s.client.endpoints
+
+	if endpoints.IsEndpointSupported(EndpointPOEStatus) {
+		statuses, err := s.client.POE().GetStatus(ctx)
+		if err != nil {
+			return events, err
+		}
+		events = append(events, s.poeEvents(statuses)...)
+	}
+
+	if endpoints.IsEndpointSupported(EndpointPortSettings) {
+		settings, err := s.client.Ports().GetSettings(ctx)
+		if err != nil {
+			return events, err
+		}
+		events = append(events, s.linkEvents(settings)...)
+	}
+
+	return events, nil
+}
+
+// poeEvents compares POE status with the previous poll
+func (s *eventSource) poeEvents(statuses []POEPortStatus) []Event {
+	now := s.client.clock.Now()
+	delivering := make(map[int]bool, len(statuses))
+	faulted := make(map[int]bool, len(statuses))
+	var events []Event
+	var consumedW float64
+
+	for _, status := range statuses {
+		consumedW += status.PowerW
+		delivering[status.PortID] = status.Delivering()
+		faulted[status.PortID] = status.Faulted()
+		if !s.polledPOE {
+			continue
+		}
+
+		event := Event{Switch: s.name, Time: now, Port: status.PortID, PortName: status.PortName, PowerW: status.PowerW}
+		if delivering[status.PortID] && !s.delivering[status.PortID] {
+			event.Type = EventPOEDeviceConnected
+			events = append(events, event)
+		}
+		if faulted[status.PortID] && !s.faulted[status.PortID] {
+			event.Type = EventPOEFault
+			event.Detail = status.ErrorStatus
+			events = append(events, event)
+		}
+	}
+
+	budgetW, known := POEBudgetW(s.client.model)
+	overBudget := known && consumedW >= budgetW
+	if s.polledPOE && overBudget && !s.overBudget {
+		events = append(events, Event{Type: EventPOEOverBudget, Switch: s.name, Time: now, PowerW: consumedW, BudgetW: budgetW})
+	}
+
+	s.polledPOE, s.delivering, s.faulted, s.overBudget = true, delivering, faulted, overBudget
+	return events
+}
+
+// linkEvents compares port link state with the previous poll
+func (s *eventSource) linkEvents(settings []PortSettings) []Event {
+	now := s.client.clock.Now()
+	linkUp := make(map[int]bool, len(settings))
+	var events []Event
+
+	for _, setting := range settings {
+		up := setting.Status == PortStatusConnected
+		linkUp[setting.PortID] = up
+		was, known := s.linkUp[setting.PortID]
+		if !s.polledPorts || !known || was == up {
+			continue
+		}
+
+		event := Event{Type: EventPortLinkDown, Switch: s.name, Time: now, Port: setting.PortID, PortName: setting.PortName}
+		if up {
+			event.Type = EventPortLinkUp
+			event.Detail = setting.LinkSpeed
+		}
+		events = append(events, event)
+	}
+
+	s.polledPorts, s.linkUp = true, linkUp
+	return events
+}
+
+// Delivering reports whether the port is powering a device
+func (s POEPortStatus) Delivering() bool {
+	return s.PowerW > 0 || strings.Contains(strings.ToLower(s.Status), "deliver")
+}
+
+// Faulted reports whether the port reports a POE error. Firmware shows
+// "No Error" (or nothing) on healthy ports.
+func (s POEPortStatus) Faulted() bool {
+	errorStatus := strings.ToLower(strings.TrimSpace(s.ErrorStatus))
+	return (errorStatus != "" && errorStatus != "no error" && errorStatus != "none") ||
+		strings.Contains(strings.ToLower(s.Status), "fault")
+}
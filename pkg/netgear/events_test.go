@@ -0,0 +1,118 @@
+package netgear
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gherlein/go-netgear/pkg/netgear/internal/fakeclock"
+)
+
+func TestEventsPoll(t *testing.T) {
+	address, setPower := newWatchSwitch(t, map[int]float64{1: 30, 2: 0, 3: 30, 4: 30})
+	client, err := NewClient(address, factoryClientOptions(address)...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	events := NewEvents(time.Minute)
+	events.Add("lab", client)
+	var connected, all []Event
+	events.On(EventPOEDeviceConnected, func(e Event) { connected = append(connected, e) })
+	events.OnAll(func(e Event) { all = append(all, e) })
+	subscribed := events.Subscribe(8, OverflowDropOldest)
+
+	ctx := context.Background()
+	// The first poll records the state the switch is in without reporting it
+	if err := events.Poll(ctx); err != nil {
+		t.Fatalf("Poll failed: %v", err)
+	}
+	if len(all) != 0 {
+		t.Fatalf("expected no events from the first poll, got %v", all)
+	}
+
+	// A device on port 2 pushing the draw past the GS308EPP's 123W budget
+	setPower(2, 34)
+	if err := events.Poll(ctx); err != nil {
+		t.Fatalf("Poll failed: %v", err)
+	}
+	if len(connected) != 1 || connected[0].Port != 2 || connected[0].Switch != "lab" || connected[0].PowerW != 34 {
+		t.Fatalf("expected POEDeviceConnected for port 2, got %v", connected)
+	}
+	if len(all) != 2 || all[1].Type != EventPOEOverBudget || all[1].BudgetW != 123 || all[1].PowerW != 124 {
+		t.Fatalf("expected POEOverBudget after the connect, got %v", all)
+	}
+
+	// Unchanged state reports nothing more
+	if err := events.Poll(ctx); err != nil {
+		t.Fatalf("Poll failed: %v", err)
+	}
+	if len(all) != 2 {
+		t.Errorf("expected no events for an unchanged switch, got %v", all[2:])
+	}
+
+	for i, want := range all {
+		select {
+		case got := <-subscribed:
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("subscriber event %d = %v, want %v", i, got, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("subscriber missed event %d", i)
+		}
+	}
+}
+
+func TestEventsLinkAndFault(t *testing.T) {
+	clock := fakeclock.New(time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC))
+	tokenMgr := NewMemoryTokenManager()
+	tokenMgr.StoreToken(context.Background(), "192.0.2.1", "token", ModelGS316EP)
+	client, err := NewClient("192.0.2.1", WithTokenManager(tokenMgr), WithPasswordManager(nil), WithClock(clock))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	source := &eventSource{name: "core", client: client}
+
+	source.linkEvents([]PortSettings{{PortID: 1, Status: PortStatusConnected}, {PortID: 2, Status: PortStatusAvailable}})
+	got := source.linkEvents([]PortSettings{
+		{PortID: 1, PortName: "uplink", Status: PortStatusAvailable},
+		{PortID: 2, Status: PortStatusConnected, LinkSpeed: "1000M"},
+		{PortID: 3, Status: PortStatusConnected},
+	})
+	now := clock.Now()
+	want := []Event{
+		{Type: EventPortLinkDown, Switch: "core", Time: now, Port: 1, PortName: "uplink"},
+		{Type: EventPortLinkUp, Switch: "core", Time: now, Port: 2, Detail: "1000M"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("link events = %v, want %v", got, want)
+	}
+
+	source.poeEvents([]POEPortStatus{{PortID: 1, ErrorStatus: "No Error"}})
+	got = source.poeEvents([]POEPortStatus{{PortID: 1, ErrorStatus: "Short Circuit"}})
+	if len(got) != 1 || got[0].Type != EventPOEFault || got[0].Detail != "Short Circuit" {
+		t.Errorf("expected a POEFault event, got %v", got)
+	}
+	if got := source.poeEvents([]POEPortStatus{{PortID: 1, ErrorStatus: "Short Circuit"}}); len(got) != 0 {
+		t.Errorf("expected a lasting fault to be reported once, got %v", got)
+	}
+}
+
+func TestEventsRun(t *testing.T) {
+	events := NewEvents(0)
+	if err := events.Run(context.Background()); err == nil {
+		t.Error("expected an error for a zero interval")
+	}
+
+	events = NewEvents(time.Minute)
+	subscribed := events.Subscribe(0, OverflowDropOldest)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := events.Run(ctx); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if _, open := <-subscribed; open {
+		t.Error("expected Run to close subscribed channels")
+	}
+}
@@ -0,0 +1,72 @@
+package netgear_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/gherlein/go-netgear/pkg/netgear"
+)
+
+// These examples talk to real switches, so they have no Output comment:
+// go test compiles them but does not run them.
+
+func ExampleNewClient() {
+	// Passwords are resolved from NETGEAR_PASSWORD_<HOST> or NETGEAR_SWITCHES
+	client, err := netgear.NewClient("192.168.1.10")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if !client.IsAuthenticated() {
+		if err := client.Login(context.Background(), "admin_password"); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	fmt.Println("connected to", client.GetModel())
+}
+
+func ExamplePOEManager_GetStatus() {
+	client, err := netgear.NewClient("192.168.1.10")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	statuses, err := client.POE().GetStatus(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, status := range statuses {
+		fmt.Printf("Port %d: %s %.2fW\n", status.PortID, status.Status, status.PowerW)
+	}
+}
+
+func ExampleVLANManager_MakeTrunkPort() {
+	client, err := netgear.NewClient("192.168.1.10")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Port 8 carries VLAN 1 untagged and VLANs 20 and 30 tagged
+	if err := client.VLANs().MakeTrunkPort(context.Background(), 8, 1, 20, 30); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func ExampleSecurityManager_SetDoS() {
+	client, err := netgear.NewClient("192.168.1.10")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	enabled := true
+	err = client.Security().SetDoS(context.Background(), netgear.DoSUpdate{
+		AutoDoS:     &enabled,
+		TCPFragment: &enabled,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,248 @@
+package netgear
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SwitchExportVersion is the current SwitchExport document version
+const SwitchExportVersion = 1
+
+// SwitchExport is the readable configuration of a switch: its identity, port
+// settings, POE settings and VLANs. POE readings and uptime are left out, so
+// exports of an unchanged switch differ only in ExportedAt and the ports'
+// link state, and can be kept and reviewed in git.
+//
+// Exports are written as YAML or JSON with the field names of the JSON
+// encoding (see docs/json-output.md).
+type SwitchExport struct {
+	Version    int               `json:"version"`
+	Address    string            `json:"address"`
+	Model      Model             `json:"model"`
+	ExportedAt time.Time         `json:"exported_at"`
+	System     *ExportedSystem   `json:"system,omitempty"`
+	Ports      []PortSettings    `json:"ports,omitempty"`
+	POE        []POEPortSettings `json:"poe,omitempty"`
+	VLANs      []VLAN            `json:"vlans,omitempty"`
+}
+
+// ExportedSystem is the identity of an exported switch
+type ExportedSystem struct {
+	ProductName  string `json:"product_name,omitempty"`
+	DeviceName   string `json:"device_name,omitempty"`
+	SerialNumber string `json:"serial_number,omitempty"`
+	MACAddress   string `json:"mac_address,omitempty"`
+	IPAddress    string `json:"ip_address,omitempty"`
+	SubnetMask   string `json:"subnet_mask,omitempty"`
+	Gateway      string `json:"gateway,omitempty"`
+	Firmware     string `json:"firmware,omitempty"`
+}
+
+// Export reads the switch's system information, port settings, POE settings
+// and VLANs. Sections the model does not support are left empty.
+func (m *ConfigManager) Export(ctx context.Context) (*SwitchExport, error) {
+	state, err := m.client.FetchAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &SwitchExport{
+		Version:    SwitchExportVersion,
+		Address:    state.Address,
+		Model:      state.Model,
+		ExportedAt: state.FetchedAt,
+		Ports:      state.Ports,
+		POE:        state.POESettings,
+		VLANs:      state.VLANs,
+	}
+	if info := state.System; info != nil {
+		export.System = &ExportedSystem{
+			ProductName:  info.ProductName,
+			DeviceName:   info.DeviceName,
+			SerialNumber: info.SerialNumber,
+			MACAddress:   info.MACAddress,
+			IPAddress:    info.IPAddress,
+			SubnetMask:   info.SubnetMask,
+			Gateway:      info.Gateway,
+			Firmware:     info.Firmware,
+		}
+	}
+
+	return export, nil
+}
+
+// Spec returns a spec pinning the exported port names, speeds, flow control,
+// POE settings and VLAN membership, so DiffConfig can report how the switch
+// has drifted from the export
+func (e *SwitchExport) Spec() *SwitchConfigSpec {
+	spec := &SwitchConfigSpec{Version: ConfigSpecVersion}
+	index := make(map[int]int)
+	for _, port := range e.Ports {
+		portSpec := PortSpec{PortID: port.PortID, Name: &port.PortName, FlowControl: &port.FlowControl}
+		if port.Speed != "" {
+			portSpec.Speed = &port.Speed
+		}
+		if port.PVID > 0 {
+			portSpec.VLAN = &PortVLANSpec{Native: port.PVID, Tagged: port.TaggedVLANs}
+		}
+		index[port.PortID] = len(spec.Ports)
+		spec.Ports = append(spec.Ports, portSpec)
+	}
+
+	for _, poe := range e.POE {
+		i, ok := index[poe.PortID]
+		if !ok {
+			i = len(spec.Ports)
+			spec.Ports = append(spec.Ports, PortSpec{PortID: poe.PortID})
+		}
+		spec.Ports[i].POE = &POEPortSpec{
+			Enabled:        &poe.Enabled,
+			Mode:           &poe.Mode,
+			Priority:       &poe.Priority,
+			PowerLimitType: &poe.PowerLimitType,
+			PowerLimitW:    &poe.PowerLimitW,
+		}
+	}
+	return spec
+}
+
+// MarshalJSONDocument encodes the export as indented JSON
+func (e *SwitchExport) MarshalJSONDocument() ([]byte, error) {
+	data, err := json.MarshalIndent(e, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal export: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// MarshalYAMLDocument encodes the export as YAML
+func (e *SwitchExport) MarshalYAMLDocument() ([]byte, error) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal export: %w", err)
+	}
+
+	// Going through JSON keeps the JSON field names and order; JSON is YAML,
+	// so only the quoting needs to be undone
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to marshal export: %w", err)
+	}
+	plainStyle(&doc)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to marshal export: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to marshal export: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Save writes the export to a file, as JSON if its name ends in .json and
+// as YAML otherwise
+func (e *SwitchExport) Save(filename string) error {
+	marshal := e.MarshalYAMLDocument
+	if strings.EqualFold(filepath.Ext(filename), ".json") {
+		marshal = e.MarshalJSONDocument
+	}
+	data, err := marshal()
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(filename, data, 0600)
+}
+
+// LoadExport reads an export written by SwitchExport.Save
+func LoadExport(filename string) (*SwitchExport, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read export: %w", err)
+	}
+	return ParseExport(data)
+}
+
+// ParseExport parses a YAML or JSON export
+func ParseExport(data []byte) (*SwitchExport, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse export: %w", err)
+	}
+	value, err := jsonValue(&doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse export: %w", err)
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse export: %w", err)
+	}
+
+	var export SwitchExport
+	if err := json.Unmarshal(encoded, &export); err != nil {
+		return nil, fmt.Errorf("failed to parse export: %w", err)
+	}
+	if export.Version != SwitchExportVersion {
+		return nil, fmt.Errorf("unsupported export version %d", export.Version)
+	}
+	return &export, nil
+}
+
+// plainStyle clears the quoting of a YAML tree decoded from JSON. The
+// encoder still quotes strings that would otherwise read as another type.
+func plainStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		plainStyle(child)
+	}
+}
+
+// jsonValue converts a YAML tree to values encoding/json can marshal,
+// turning every mapping key into a string
+func jsonValue(node *yaml.Node) (interface{}, error) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return nil, nil
+		}
+		return jsonValue(node.Content[0])
+	case yaml.AliasNode:
+		return jsonValue(node.Alias)
+	case yaml.MappingNode:
+		m := make(map[string]interface{}, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			value, err := jsonValue(node.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+			m[node.Content[i].Value] = value
+		}
+		return m, nil
+	case yaml.SequenceNode:
+		s := make([]interface{}, len(node.Content))
+		for i, child := range node.Content {
+			value, err := jsonValue(child)
+			if err != nil {
+				return nil, err
+			}
+			s[i] = value
+		}
+		return s, nil
+	default:
+		var value interface{}
+		if err := node.Decode(&value); err != nil {
+			return nil, err
+		}
+		return value, nil
+	}
+}
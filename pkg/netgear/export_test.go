@@ -0,0 +1,133 @@
+package netgear
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testSwitchExport() *SwitchExport {
+	return &SwitchExport{
+		Version:    SwitchExportVersion,
+		Address:    "10.0.0.2",
+		Model:      ModelGS316EP,
+		ExportedAt: time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC),
+		System:     &ExportedSystem{DeviceName: "true", Firmware: "1.0"},
+		Ports: []PortSettings{
+			{PortID: 1, PortName: "uplink", Speed: PortSpeedAuto, Status: PortStatusConnected, LinkSpeed: "1000M", FlowControl: true, PVID: 10, UntaggedVLANs: []int{10}, TaggedVLANs: []int{20}},
+			{PortID: 2, PortName: "", Speed: PortSpeedAuto, Status: PortStatusAvailable},
+		},
+		POE: []POEPortSettings{
+			{PortID: 2, Enabled: true, Mode: POEMode8023at, Priority: POEPriorityHigh, PowerLimitType: POELimitTypeUser, PowerLimitW: 15.4},
+		},
+		VLANs: []VLAN{
+			{ID: 10, Members: map[int]VLANMembership{1: VLANMemberUntagged}},
+			{ID: 20, Members: map[int]VLANMembership{1: VLANMemberTagged, 2: VLANMemberNone}},
+		},
+	}
+}
+
+func TestSwitchExportRoundTrip(t *testing.T) {
+	export := testSwitchExport()
+	dir := t.TempDir()
+
+	for _, name := range []string{"switch.yaml", "switch.json"} {
+		filename := filepath.Join(dir, name)
+		if err := export.Save(filename); err != nil {
+			t.Fatalf("Save(%s) failed: %v", name, err)
+		}
+		loaded, err := LoadExport(filename)
+		if err != nil {
+			t.Fatalf("LoadExport(%s) failed: %v", name, err)
+		}
+		if !reflect.DeepEqual(loaded, export) {
+			t.Errorf("%s did not round trip:\ngot  %+v\nwant %+v", name, loaded, export)
+		}
+	}
+
+	data, err := export.MarshalYAMLDocument()
+	if err != nil {
+		t.Fatalf("MarshalYAMLDocument failed: %v", err)
+	}
+	for _, want := range []string{"version: 1\n", "model: GS316EP\n", "device_name: \"true\"\n", "port_name: uplink\n", "power_limit_w: 15.4\n"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("YAML lacks %q:\n%s", want, data)
+		}
+	}
+
+	if _, err := ParseExport([]byte("version: 2\n")); err == nil {
+		t.Error("expected an error for an unsupported version")
+	}
+}
+
+func TestSwitchExportSpec(t *testing.T) {
+	export := testSwitchExport()
+	spec := export.Spec()
+	if err := spec.Validate(); err != nil {
+		t.Fatalf("exported spec is invalid: %v", err)
+	}
+
+	state := &configState{ports: make(map[int]PortSettings), poe: make(map[int]POEPortSettings)}
+	for _, port := range export.Ports {
+		state.ports[port.PortID] = port
+	}
+	for _, poe := range export.POE {
+		state.poe[poe.PortID] = poe
+	}
+	if changes, err := specChanges(spec, state); err != nil || len(changes) != 0 {
+		t.Fatalf("expected the exported state to match its spec, got %v, %v", changes, err)
+	}
+
+	state.ports[1] = PortSettings{PortID: 1, PortName: "renamed", Speed: PortSpeedAuto, FlowControl: true, PVID: 10, UntaggedVLANs: []int{10}, TaggedVLANs: []int{20}}
+	changes, err := specChanges(spec, state)
+	if err != nil || len(changes) != 1 || changes[0].Field != "name" || changes[0].Current != "renamed" {
+		t.Errorf("expected the rename to be reported, got %v, %v", changes, err)
+	}
+}
+
+func TestConfigExport(t *testing.T) {
+	vlans := &vlanSwitch{members: map[int]string{1: "11111111", 10: "21000000"}, pvids: map[int]int{1: 10, 2: 1}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/dashboard.cgi":
+			fmt.Fprint(w, `<html>
+<table><tr><td>Switch Name</td><td>lab-switch</td></tr><tr><td>Firmware Version</td><td>V1.0.0.8</td></tr></table>
+<table><tr><th>Port</th></tr><tr><td>1</td><td>uplink</td><td>Auto</td><td></td><td></td><td>On</td><td>Connected</td><td>1000M</td></tr></table>
+</html>`)
+		case "/getPoePortStatus.cgi", "/PoEPortConfig.cgi":
+			fmt.Fprint(w, `<html></html>`)
+		default:
+			vlans.serve(w, r)
+		}
+	}))
+	defer server.Close()
+
+	address := strings.TrimPrefix(server.URL, "http://")
+	client, err := NewClient(address, factoryClientOptions(address)...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	export, err := client.Config().Export(context.Background())
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if export.Version != SwitchExportVersion || export.Model != ModelGS308EPP || export.Address != address {
+		t.Errorf("unexpected export header %+v", export)
+	}
+	if export.System == nil || export.System.DeviceName != "lab-switch" || export.System.Firmware != "V1.0.0.8" {
+		t.Errorf("unexpected system section %+v", export.System)
+	}
+	if len(export.Ports) != 1 || export.Ports[0].PortName != "uplink" || export.Ports[0].PVID != 10 {
+		t.Errorf("unexpected ports %+v", export.Ports)
+	}
+	if len(export.VLANs) != 2 || export.VLANs[1].ID != 10 {
+		t.Errorf("unexpected VLANs %+v", export.VLANs)
+	}
+}
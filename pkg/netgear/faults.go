@@ -0,0 +1,129 @@
+package netgear
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Fault is a switch failure mode a FaultInjector can simulate
+type Fault int
+
+const (
+	// FaultNone sends the request to the switch unchanged
+	FaultNone Fault = iota
+	// FaultTimeout fails the request as if the switch stopped answering
+	FaultTimeout
+	// FaultNotFound answers HTTP 404 without contacting the switch, as
+	// firmware without the requested page does
+	FaultNotFound
+	// FaultStaleHash answers with the error page firmware shows when a form
+	// is posted with an outdated security hash; the switch is not contacted
+	FaultStaleHash
+	// FaultTruncatedHTML sends the request but cuts the response body in
+	// half, as an overloaded switch dropping the connection does
+	FaultTruncatedHTML
+)
+
+// String returns the fault name
+func (f Fault) String() string {
+	switch f {
+	case FaultNone:
+		return "none"
+	case FaultTimeout:
+		return "timeout"
+	case FaultNotFound:
+		return "not-found"
+	case FaultStaleHash:
+		return "stale-hash"
+	case FaultTruncatedHTML:
+		return "truncated-html"
+	default:
+		return "unknown"
+	}
+}
+
+// FaultInjector decides, for every HTTP request the client makes, whether to
+// simulate a failure instead of the switch's normal answer
+type FaultInjector interface {
+	Inject(req *http.Request) Fault
+}
+
+// FaultInjectorFunc adapts a function to the FaultInjector interface
+type FaultInjectorFunc func(req *http.Request) Fault
+
+// Inject calls f(req)
+func (f FaultInjectorFunc) Inject(req *http.Request) Fault {
+	return f(req)
+}
+
+// WithFaultInjector makes the client consult injector before every request
+// and simulate the fault it returns at the transport layer. It is meant for
+// tests and resilience drills that exercise retry and rollback logic against
+// realistic switch failures.
+func WithFaultInjector(injector FaultInjector) ClientOption {
+	return func(c *Client) {
+		c.faults = injector
+	}
+}
+
+// staleHashPage is what firmware answers to a form posted with an outdated hash
+const staleHashPage = `<html><script>alert("Invalid hash value. Please refresh the page and try again.")</script></html>`
+
+// faultTransport applies a FaultInjector's decisions in front of the real transport
+type faultTransport struct {
+	next     http.RoundTripper
+	injector FaultInjector
+}
+
+func (t *faultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch fault := t.injector.Inject(req); fault {
+	case FaultNone:
+		return t.next.RoundTrip(req)
+	case FaultTimeout:
+		return nil, faultTimeoutError{}
+	case FaultNotFound:
+		return faultResponse(req, http.StatusNotFound, "<html><body>404 Not Found</body></html>"), nil
+	case FaultStaleHash:
+		return faultResponse(req, http.StatusOK, staleHashPage), nil
+	case FaultTruncatedHTML:
+		resp, err := t.next.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = io.NopCloser(strings.NewReader(string(body[:len(body)/2])))
+		resp.ContentLength = -1
+		resp.Header.Del("Content-Length")
+		return resp, nil
+	default:
+		return nil, fmt.Errorf("unknown injected fault %d", fault)
+	}
+}
+
+// faultResponse builds a synthetic HTML response to req
+func faultResponse(req *http.Request, status int, body string) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"text/html"}},
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// faultTimeoutError is the net.Error returned for FaultTimeout
+type faultTimeoutError struct{}
+
+func (faultTimeoutError) Error() string   { return "injected fault: request timed out" }
+func (faultTimeoutError) Timeout() bool   { return true }
+func (faultTimeoutError) Temporary() bool { return true }
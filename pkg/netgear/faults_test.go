@@ -0,0 +1,68 @@
+package netgear
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFaultInjector(t *testing.T) {
+	const page = `<html><body><input type="hidden" name="hash" value="h1"></body></html>`
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, page)
+	}))
+	defer server.Close()
+
+	faults := map[string]Fault{
+		"/timeout":   FaultTimeout,
+		"/missing":   FaultNotFound,
+		"/stale":     FaultStaleHash,
+		"/truncated": FaultTruncatedHTML,
+	}
+	injector := FaultInjectorFunc(func(req *http.Request) Fault { return faults[req.URL.Path] })
+
+	ctx := context.Background()
+	address := strings.TrimPrefix(server.URL, "http://")
+	// WithTimeout after the injector replaces the HTTP client; the injector must survive it
+	client, err := NewClient(address, append(factoryClientOptions(address), WithFaultInjector(injector), WithTimeout(0))...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	if body, err := client.makeAuthenticatedRequest(ctx, "GET", "/normal", nil); err != nil || body != page {
+		t.Errorf("expected untouched response, got %q (%v)", body, err)
+	}
+
+	_, err = client.makeAuthenticatedRequest(ctx, "GET", "/timeout", nil)
+	var netErr net.Error
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Errorf("expected a timeout error, got %v", err)
+	}
+
+	_, err = client.makeAuthenticatedRequest(ctx, "GET", "/missing", nil)
+	var netgearErr *Error
+	if !errors.As(err, &netgearErr) || netgearErr.HTTPStatus != http.StatusNotFound {
+		t.Errorf("expected HTTP 404, got %v", err)
+	}
+
+	body, err := client.makeAuthenticatedRequest(ctx, "POST", "/stale", nil)
+	if err != nil || !strings.Contains(body, "Invalid hash") {
+		t.Errorf("expected stale hash page, got %q (%v)", body, err)
+	}
+
+	body, err = client.makeAuthenticatedRequest(ctx, "GET", "/truncated", nil)
+	if err != nil || body != page[:len(page)/2] {
+		t.Errorf("expected truncated page, got %q (%v)", body, err)
+	}
+
+	if requests != 2 {
+		t.Errorf("expected only /normal and /truncated to reach the switch, got %d requests", requests)
+	}
+}
@@ -0,0 +1,262 @@
+// Package fixture records the HTTP exchanges between a client and a live
+// switch as sanitized fixtures and replays them, so support for a new model
+// or firmware revision can be developed and tested without the hardware.
+//
+// Each exchange is a JSON file in the fixture directory, named after its
+// sequence number, method and path (0003-POST-login.cgi.json). Secrets are
+// redacted with the redact package's Default scrubber and the switch's
+// address is replaced by Host, so fixtures can be committed as they are.
+//
+// Record with netgear.WithRecorder, then serve the directory to a client
+// with NewReplay as its transport or behind an httptest.Server, see
+// netgeartest.NewReplaySwitch.
+package fixture
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gherlein/go-netgear/pkg/redact"
+)
+
+// Host replaces the switch's address in recorded fixtures
+const Host = "switch.invalid"
+
+// recordedHeaders are the response headers kept in fixtures
+var recordedHeaders = []string{"Content-Type", "Location", "Set-Cookie"}
+
+// Exchange is one recorded request and the switch's response
+type Exchange struct {
+	Method string `json:"method"`
+	// Path is the request path and query
+	Path string `json:"path"`
+	// Form is the URL-encoded request body of a form post
+	Form   string            `json:"form,omitempty"`
+	Status int               `json:"status"`
+	Header map[string]string `json:"header,omitempty"`
+	Body   string            `json:"body"`
+}
+
+// requestPath returns the sanitized path and query of a request
+func requestPath(req *http.Request) string {
+	return redact.String(req.URL.RequestURI())
+}
+
+// Recorder is an http.RoundTripper that passes requests on and writes every
+// exchange to a fixture directory
+type Recorder struct {
+	dir  string
+	next http.RoundTripper
+	mu   sync.Mutex
+	seq  int
+}
+
+// NewRecorder creates a recorder writing to dir, which is created if needed,
+// and sending requests through next. Numbering continues after the fixtures
+// already in dir, so several sessions can be recorded into one directory.
+func NewRecorder(dir string, next http.RoundTripper) (*Recorder, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create fixture directory: %w", err)
+	}
+	existing, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	return &Recorder{dir: dir, next: next, seq: len(existing)}, nil
+}
+
+// RoundTrip sends the request and records the exchange. Recording errors
+// do not fail the request.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	exchange := Exchange{Method: req.Method, Path: requestPath(req)}
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := io.ReadAll(body)
+			body.Close()
+			if form, err := url.ParseQuery(string(data)); err == nil {
+				exchange.Form = redact.Default().Values(form).Encode()
+			}
+		}
+	}
+
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return resp, nil
+	}
+
+	host := req.URL.Host
+	exchange.Status = resp.StatusCode
+	exchange.Body = sanitize(string(body), host)
+	for _, name := range recordedHeaders {
+		if value := resp.Header.Get(name); value != "" {
+			if exchange.Header == nil {
+				exchange.Header = make(map[string]string)
+			}
+			exchange.Header[name] = sanitize(value, host)
+		}
+	}
+	r.save(exchange)
+	return resp, nil
+}
+
+// sanitize redacts secrets and replaces the switch's address by Host
+func sanitize(text, host string) string {
+	if host != "" {
+		text = strings.ReplaceAll(text, host, Host)
+	}
+	return redact.String(text)
+}
+
+// save writes an exchange to the next fixture file
+func (r *Recorder) save(exchange Exchange) {
+	data, err := json.MarshalIndent(exchange, "", "  ")
+	if err != nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seq++
+	name := fmt.Sprintf("%04d-%s-%s.json", r.seq, exchange.Method, fileSlug(exchange.Path))
+	os.WriteFile(filepath.Join(r.dir, name), append(data, '\n'), 0600)
+}
+
+// fileSlug turns a request path into a file name part, e.g. "/iss/specific/poe.html?x=1" into "iss_specific_poe.html"
+func fileSlug(path string) string {
+	path, _, _ = strings.Cut(path, "?")
+	slug := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-':
+			return r
+		default:
+			return '_'
+		}
+	}, strings.Trim(path, "/"))
+	if slug == "" {
+		return "root"
+	}
+	return slug
+}
+
+// Replay answers requests from recorded exchanges. It is an
+// http.RoundTripper for clients and an http.Handler for test servers.
+//
+// A request matches the exchanges with its method and sanitized path. They
+// are served in recorded order and the last one repeats, so a page read
+// before and after a change returns both versions. It is safe for
+// concurrent use.
+type Replay struct {
+	mu        sync.Mutex
+	exchanges map[string][]Exchange
+	served    map[string]int
+}
+
+// Load reads the fixtures in dir, in file name order
+func Load(dir string) (*Replay, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no fixtures in %s", dir)
+	}
+	sort.Strings(files)
+
+	exchanges := make([]Exchange, 0, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read fixture: %w", err)
+		}
+		var exchange Exchange
+		if err := json.Unmarshal(data, &exchange); err != nil {
+			return nil, fmt.Errorf("failed to parse fixture %s: %w", filepath.Base(file), err)
+		}
+		exchanges = append(exchanges, exchange)
+	}
+	return NewReplay(exchanges...), nil
+}
+
+// NewReplay creates a replay of the given exchanges
+func NewReplay(exchanges ...Exchange) *Replay {
+	r := &Replay{exchanges: make(map[string][]Exchange), served: make(map[string]int)}
+	for _, exchange := range exchanges {
+		key := exchange.Method + " " + exchange.Path
+		r.exchanges[key] = append(r.exchanges[key], exchange)
+	}
+	return r
+}
+
+// next returns the exchange answering a request
+func (r *Replay) next(req *http.Request) (Exchange, error) {
+	key := req.Method + " " + requestPath(req)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	candidates := r.exchanges[key]
+	if len(candidates) == 0 {
+		return Exchange{}, fmt.Errorf("no fixture for %s", key)
+	}
+	i := min(r.served[key], len(candidates)-1)
+	r.served[key]++
+	return candidates[i], nil
+}
+
+// RoundTrip answers the request from the fixtures without a network
+func (r *Replay) RoundTrip(req *http.Request) (*http.Response, error) {
+	exchange, err := r.next(req)
+	if err != nil {
+		return nil, err
+	}
+	header := make(http.Header)
+	for name, value := range exchange.Header {
+		header.Set(name, restoreHost(value, req.Host))
+	}
+	body := restoreHost(exchange.Body, req.Host)
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", exchange.Status, http.StatusText(exchange.Status)),
+		StatusCode:    exchange.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// ServeHTTP answers the request from the fixtures; requests without one get 404
+func (r *Replay) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	exchange, err := r.next(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	for name, value := range exchange.Header {
+		w.Header().Set(name, restoreHost(value, req.Host))
+	}
+	w.WriteHeader(exchange.Status)
+	io.WriteString(w, restoreHost(exchange.Body, req.Host))
+}
+
+// restoreHost points links recorded against the switch at the host replaying them
+func restoreHost(text, host string) string {
+	if host == "" {
+		return text
+	}
+	return strings.ReplaceAll(text, Host, host)
+}
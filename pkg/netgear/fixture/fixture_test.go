@@ -0,0 +1,119 @@
+package fixture
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	reads := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login.cgi":
+			w.Header().Set("Location", "http://"+r.Host+"/index.htm")
+			w.Header().Set("Set-Cookie", "SID=abc123; path=/")
+			w.WriteHeader(http.StatusFound)
+		case "/poe.htm":
+			reads++
+			fmt.Fprintf(w, `<input type="hidden" name="hash" value="h%d"><td>read %d</td>`, reads, reads)
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	recorder, err := NewRecorder(dir, http.DefaultTransport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{
+		Transport:     recorder,
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+	resp, err := client.PostForm(server.URL+"/login.cgi", url.Values{"password": {"s3cret"}, "lang": {"en"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(server.URL + "/poe.htm?Gambit=tok3n")
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if !strings.Contains(string(body), fmt.Sprintf("read %d", i+1)) {
+			t.Errorf("recording changed the response: %s", body)
+		}
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 3 || filepath.Base(files[0]) != "0001-POST-login.cgi.json" {
+		t.Fatalf("unexpected fixtures %v", files)
+	}
+	host := strings.TrimPrefix(server.URL, "http://")
+	for _, file := range files {
+		data, _ := os.ReadFile(file)
+		for _, secret := range []string{"s3cret", "abc123", "tok3n", `"h1"`, host} {
+			if strings.Contains(string(data), secret) {
+				t.Errorf("%s contains %q:\n%s", filepath.Base(file), secret, data)
+			}
+		}
+	}
+
+	replay, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client = &http.Client{
+		Transport:     replay,
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+	resp, err = client.PostForm("http://10.0.0.1/login.cgi", url.Values{"password": {"other"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusFound || resp.Header.Get("Location") != "http://10.0.0.1/index.htm" {
+		t.Errorf("expected the recorded redirect to the replaying host, got %d %s", resp.StatusCode, resp.Header.Get("Location"))
+	}
+
+	// Recorded responses are served in order and the last one repeats
+	for _, want := range []string{"read 1", "read 2", "read 2"} {
+		resp, err := client.Get("http://10.0.0.1/poe.htm?Gambit=other")
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if !strings.Contains(string(body), want) {
+			t.Errorf("expected %q, got %s", want, body)
+		}
+	}
+
+	if _, err := client.Get("http://10.0.0.1/missing.htm"); err == nil {
+		t.Error("expected an error for a request without fixture")
+	}
+	if _, err := Load(t.TempDir()); err == nil {
+		t.Error("expected an error for an empty fixture directory")
+	}
+}
+
+func TestFileSlug(t *testing.T) {
+	cases := map[string]string{
+		"/":                           "root",
+		"/login.cgi":                  "login.cgi",
+		"/iss/specific/poe.html?x=1":  "iss_specific_poe.html",
+		"/cgi/get.cgi?cmd=home_login": "cgi_get.cgi",
+	}
+	for path, want := range cases {
+		if got := fileSlug(path); got != want {
+			t.Errorf("fileSlug(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
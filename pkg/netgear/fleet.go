@@ -0,0 +1,363 @@
+package netgear
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+// InventoryEntry describes one switch in an inventory
+type InventoryEntry struct {
+	Name string `json:"name"`
+	// Addresses are tried in order, so list the primary management address
+	// first followed by fallbacks (e.g. an IPv6 link-local or out-of-band address)
+	Addresses []string `json:"addresses"`
+	Model     Model    `json:"model,omitempty"`
+	Password  string   `json:"password,omitempty"`
+	// PasswordEnv names an environment variable holding the password, so the
+	// inventory file itself need not contain it
+	PasswordEnv string   `json:"password_env,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	// Vars are per-switch values available to configuration templates
+	Vars map[string]string `json:"vars,omitempty"`
+	// Disabled is set while the switch is muted, e.g. during an RMA
+	Disabled *SwitchDisable `json:"disabled,omitempty"`
+	// PollInterval overrides how often pollers read this switch, as a Go
+	// duration such as "15s"; empty uses the poller's default
+	PollInterval string `json:"poll_interval,omitempty"`
+}
+
+// SwitchDisable records why and since when a fleet member is disabled
+type SwitchDisable struct {
+	Reason string    `json:"reason"`
+	Since  time.Time `json:"since"`
+}
+
+// ResolvePassword returns the entry's password, reading it from PasswordEnv
+// when no password is stored inline
+func (e InventoryEntry) ResolvePassword() string {
+	if e.Password == "" && e.PasswordEnv != "" {
+		return os.Getenv(e.PasswordEnv)
+	}
+	return e.Password
+}
+
+// PollEvery returns the entry's poll interval, or defaultInterval if it has none
+func (e InventoryEntry) PollEvery(defaultInterval time.Duration) (time.Duration, error) {
+	if e.PollInterval == "" {
+		return defaultInterval, nil
+	}
+	interval, err := time.ParseDuration(e.PollInterval)
+	if err != nil || interval <= 0 {
+		return 0, fmt.Errorf("inventory entry %q has invalid poll interval %q", e.Name, e.PollInterval)
+	}
+	return interval, nil
+}
+
+// Inventory is the list of switches managed together as a Fleet
+type Inventory struct {
+	Switches []InventoryEntry `json:"switches"`
+}
+
+// LoadInventory reads an inventory from a JSON file
+func LoadInventory(filename string) (*Inventory, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read inventory: %w", err)
+	}
+
+	var inventory Inventory
+	if err := json.Unmarshal(data, &inventory); err != nil {
+		return nil, fmt.Errorf("failed to parse inventory: %w", err)
+	}
+
+	if err := inventory.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &inventory, nil
+}
+
+// Save writes the inventory to a JSON file
+func (inv *Inventory) Save(filename string) error {
+	data, err := json.MarshalIndent(inv, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal inventory: %w", err)
+	}
+	return os.WriteFile(filename, data, 0600)
+}
+
+// Validate checks that every entry has a unique name, at least one address
+// and a valid poll interval
+func (inv *Inventory) Validate() error {
+	seen := make(map[string]bool)
+	for i, entry := range inv.Switches {
+		if entry.Name == "" {
+			return fmt.Errorf("inventory entry %d has no name", i)
+		}
+		if seen[entry.Name] {
+			return fmt.Errorf("duplicate inventory entry %q", entry.Name)
+		}
+		seen[entry.Name] = true
+		if len(entry.Addresses) == 0 {
+			return fmt.Errorf("inventory entry %q has no addresses", entry.Name)
+		}
+		if _, err := entry.PollEvery(0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Lookup returns the entry with the given name
+func (inv *Inventory) Lookup(name string) (InventoryEntry, bool) {
+	if i := inv.index(name); i >= 0 {
+		return inv.Switches[i], true
+	}
+	return InventoryEntry{}, false
+}
+
+// index returns the position of the named entry, or -1
+func (inv *Inventory) index(name string) int {
+	for i, entry := range inv.Switches {
+		if entry.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// Fleet manages clients for every switch in an inventory, connecting to each
+// switch through the first of its addresses that responds
+type Fleet struct {
+	mu         sync.Mutex
+	inventory  *Inventory
+	opts       []ClientOption
+	clients    map[string]*Client
+	connecting map[string]*fleetConnect
+}
+
+// fleetConnect is a connection attempt in progress, shared by every caller
+// asking for the same switch until it completes
+type fleetConnect struct {
+	done   chan struct{}
+	client *Client
+	err    error
+}
+
+// NewFleet creates a fleet for an inventory. The options are applied to every client.
+func NewFleet(inventory *Inventory, opts ...ClientOption) *Fleet {
+	return &Fleet{
+		inventory:  inventory,
+		opts:       opts,
+		clients:    make(map[string]*Client),
+		connecting: make(map[string]*fleetConnect),
+	}
+}
+
+// Names returns the names of the enabled switches in the fleet, sorted.
+// Pollers iterating Names skip disabled switches without further checks.
+func (f *Fleet) Names() []string {
+	return f.names(false)
+}
+
+// AllNames returns the names of all switches in the fleet, including disabled ones, sorted
+func (f *Fleet) AllNames() []string {
+	return f.names(true)
+}
+
+func (f *Fleet) names(includeDisabled bool) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	names := make([]string, 0, len(f.inventory.Switches))
+	for _, entry := range f.inventory.Switches {
+		if entry.Disabled == nil || includeDisabled {
+			names = append(names, entry.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Disable mutes the named switch: it is left out of Names and Client refuses
+// it with ErrSwitchDisabled, while its inventory entry and configuration
+// history are kept. Use Save to persist the state.
+func (f *Fleet) Disable(name, reason string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	i := f.inventory.index(name)
+	if i < 0 {
+		return NewOperationError(fmt.Sprintf("switch %q not in inventory", name), nil)
+	}
+	f.inventory.Switches[i].Disabled = &SwitchDisable{Reason: reason, Since: time.Now()}
+	f.dropClient(name)
+	return nil
+}
+
+// Enable returns a disabled switch to the fleet
+func (f *Fleet) Enable(name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	i := f.inventory.index(name)
+	if i < 0 {
+		return NewOperationError(fmt.Sprintf("switch %q not in inventory", name), nil)
+	}
+	f.inventory.Switches[i].Disabled = nil
+	return nil
+}
+
+// DisabledState reports whether the named switch is disabled, and why
+func (f *Fleet) DisabledState(name string) (SwitchDisable, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if i := f.inventory.index(name); i >= 0 && f.inventory.Switches[i].Disabled != nil {
+		return *f.inventory.Switches[i].Disabled, true
+	}
+	return SwitchDisable{}, false
+}
+
+// Save writes the fleet's inventory, including disabled state, to a JSON file
+func (f *Fleet) Save(filename string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.inventory.Save(filename)
+}
+
+// Client returns an authenticated client for the named switch. The first call
+// tries each address in order and keeps the first one that responds; call
+// Invalidate after a failure to fail over again on the next call. Connecting
+// to one switch does not hold up calls for the others, and concurrent calls
+// for the same switch share one connection attempt.
+func (f *Fleet) Client(ctx context.Context, name string) (*Client, error) {
+	f.mu.Lock()
+	if client, exists := f.clients[name]; exists {
+		f.mu.Unlock()
+		return client, nil
+	}
+	if pending, exists := f.connecting[name]; exists {
+		f.mu.Unlock()
+		select {
+		case <-pending.done:
+			return pending.client, pending.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	entry, found := f.inventory.Lookup(name)
+	if !found {
+		f.mu.Unlock()
+		return nil, NewOperationError(fmt.Sprintf("switch %q not in inventory", name), nil)
+	}
+	if entry.Disabled != nil {
+		f.mu.Unlock()
+		return nil, fmt.Errorf("%w: %s (%s)", ErrSwitchDisabled, name, entry.Disabled.Reason)
+	}
+	pending := &fleetConnect{done: make(chan struct{})}
+	f.connecting[name] = pending
+	f.mu.Unlock()
+
+	client, err := f.connectAny(ctx, entry)
+
+	f.mu.Lock()
+	delete(f.connecting, name)
+	if err == nil {
+		// The switch may have been disabled while connecting
+		if current, _ := f.inventory.Lookup(name); current.Disabled != nil {
+			client.Close()
+			client, err = nil, fmt.Errorf("%w: %s (%s)", ErrSwitchDisabled, name, current.Disabled.Reason)
+		} else {
+			f.clients[name] = client
+		}
+	}
+	pending.client, pending.err = client, err
+	f.mu.Unlock()
+	close(pending.done)
+
+	return client, err
+}
+
+// connectAny connects to the first address of the entry that responds
+func (f *Fleet) connectAny(ctx context.Context, entry InventoryEntry) (*Client, error) {
+	var errs []error
+	for _, address := range entry.Addresses {
+		client, err := f.connect(ctx, entry, address)
+		if err == nil {
+			return client, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", address, err))
+
+		// Wrong credentials will not improve on another path to the same switch
+		var netgearErr *Error
+		if errors.As(err, &netgearErr) && netgearErr.Type == ErrorTypeAuth {
+			break
+		}
+	}
+
+	return nil, NewNetworkError(fmt.Sprintf("switch %q unreachable on all addresses", entry.Name), errors.Join(errs...))
+}
+
+// ActiveAddress returns the address the named switch is currently reached
+// through, or "" if no client is connected
+func (f *Fleet) ActiveAddress(name string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if client, exists := f.clients[name]; exists {
+		return client.GetAddress()
+	}
+	return ""
+}
+
+// Invalidate closes and drops the cached client for the named switch so the
+// next call to Client starts again from the primary address
+func (f *Fleet) Invalidate(name string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.dropClient(name)
+}
+
+// dropClient closes and forgets the named switch's client; the caller holds f.mu
+func (f *Fleet) dropClient(name string) {
+	if client, exists := f.clients[name]; exists {
+		client.Close()
+		delete(f.clients, name)
+	}
+}
+
+// connect creates a client for one address and makes sure the switch answers on it
+func (f *Fleet) connect(ctx context.Context, entry InventoryEntry, address string) (*Client, error) {
+	opts := slices.Clip(f.opts)
+	if entry.Model != "" {
+		opts = append(opts, WithModel(entry.Model))
+	}
+	// The inventory's password comes before the fleet's password provider
+	// and the environment
+	if password := entry.ResolvePassword(); password != "" {
+		opts = append(opts, WithEnvironmentAuth(false), WithPasswordProvider(PasswordProviderFunc(
+			func(ctx context.Context, address string) (string, error) { return password, nil })))
+	}
+
+	client, err := NewClientContext(ctx, address, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// A cached token lets NewClient succeed without contacting the switch
+	if err := client.ping(ctx); err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	return client, nil
+}
@@ -0,0 +1,211 @@
+package netgear
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gherlein/go-netgear/pkg/netgear/internal"
+)
+
+func TestFleetFailsOverToSecondaryAddress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html><head><title>GS308EPP</title></head></html>"))
+	}))
+	defer server.Close()
+
+	secondary := strings.TrimPrefix(server.URL, "http://")
+	inventory := &Inventory{Switches: []InventoryEntry{
+		// Port 1 on loopback refuses connections immediately
+		{Name: "closet", Addresses: []string{"127.0.0.1:1", secondary}},
+	}}
+
+	fleet := NewFleet(inventory, WithTokenManager(NewMemoryTokenManager()), WithPasswordManager(nil))
+	client, err := fleet.Client(context.Background(), "closet")
+	if err != nil {
+		t.Fatalf("Expected failover to secondary address, got %v", err)
+	}
+	if client.GetAddress() != secondary || fleet.ActiveAddress("closet") != secondary {
+		t.Errorf("Expected active address %s, got %s", secondary, fleet.ActiveAddress("closet"))
+	}
+}
+
+func TestFleetDisable(t *testing.T) {
+	inventory := &Inventory{Switches: []InventoryEntry{
+		{Name: "closet", Addresses: []string{"127.0.0.1:1"}},
+		{Name: "lobby", Addresses: []string{"127.0.0.1:1"}},
+	}}
+	fleet := NewFleet(inventory, WithTokenManager(NewMemoryTokenManager()), WithPasswordManager(nil))
+
+	if err := fleet.Disable("closet", "RMA 1234"); err != nil {
+		t.Fatalf("Disable failed: %v", err)
+	}
+	if names := fleet.Names(); len(names) != 1 || names[0] != "lobby" {
+		t.Errorf("expected only lobby enabled, got %v", names)
+	}
+	if names := fleet.AllNames(); len(names) != 2 {
+		t.Errorf("expected both switches in AllNames, got %v", names)
+	}
+	if _, err := fleet.Client(context.Background(), "closet"); !errors.Is(err, ErrSwitchDisabled) {
+		t.Errorf("expected ErrSwitchDisabled, got %v", err)
+	}
+
+	filename := filepath.Join(t.TempDir(), "inventory.json")
+	if err := fleet.Save(filename); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded, err := LoadInventory(filename)
+	if err != nil {
+		t.Fatalf("LoadInventory failed: %v", err)
+	}
+	reloaded := NewFleet(loaded)
+	if state, disabled := reloaded.DisabledState("closet"); !disabled || state.Reason != "RMA 1234" || state.Since.IsZero() {
+		t.Errorf("expected persisted disabled state, got %+v %v", state, disabled)
+	}
+
+	if err := reloaded.Enable("closet"); err != nil {
+		t.Fatalf("Enable failed: %v", err)
+	}
+	if len(reloaded.Names()) != 2 {
+		t.Errorf("expected both switches enabled, got %v", reloaded.Names())
+	}
+	if err := fleet.Disable("missing", ""); err == nil {
+		t.Error("expected error disabling an unknown switch")
+	}
+}
+
+func TestFleetConnectsSwitchesIndependently(t *testing.T) {
+	var hits atomic.Int32
+	started, release := make(chan struct{}), make(chan struct{})
+	var releaseOnce sync.Once
+	unblock := func() { releaseOnce.Do(func() { close(release) }) }
+	hanging := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) == 1 {
+			close(started)
+		}
+		<-release
+		w.Write([]byte("<html><head><title>GS308EPP</title></head></html>"))
+	}))
+	defer hanging.Close()
+	defer unblock()
+	live := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html><head><title>GS308EPP</title></head></html>"))
+	}))
+	defer live.Close()
+
+	inventory := &Inventory{Switches: []InventoryEntry{
+		{Name: "hanging", Addresses: []string{strings.TrimPrefix(hanging.URL, "http://")}, Model: ModelGS308EPP},
+		{Name: "live", Addresses: []string{strings.TrimPrefix(live.URL, "http://")}},
+	}}
+	fleet := NewFleet(inventory, WithTokenManager(NewMemoryTokenManager()), WithPasswordManager(nil))
+
+	ctx := context.Background()
+	results := make(chan error, 2)
+	for range 2 {
+		go func() {
+			_, err := fleet.Client(ctx, "hanging")
+			results <- err
+		}()
+	}
+	<-started
+
+	done := make(chan error)
+	go func() {
+		_, err := fleet.Client(ctx, "live")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("expected the live switch to connect, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("connecting to one switch blocked another")
+	}
+	if fleet.ActiveAddress("hanging") != "" {
+		t.Error("expected no active address while connecting")
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := fleet.Client(cancelled, "hanging"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected a waiting caller to give up with its context, got %v", err)
+	}
+
+	unblock()
+	for range 2 {
+		if err := <-results; err != nil {
+			t.Errorf("expected the shared attempt to succeed, got %v", err)
+		}
+	}
+	// Both calls shared one attempt, so the switch saw a single request
+	if n := hits.Load(); n != 1 {
+		t.Errorf("expected one connection attempt, got %d requests", n)
+	}
+
+	first, _ := fleet.Client(ctx, "hanging")
+	fleet.Invalidate("hanging")
+	if fleet.ActiveAddress("hanging") != "" {
+		t.Error("expected Invalidate to drop the client")
+	}
+	second, err := fleet.Client(ctx, "hanging")
+	if err != nil || second == first {
+		t.Errorf("expected a new client after Invalidate, got %v", err)
+	}
+}
+
+func TestFleetUsesInventoryModelAndPassword(t *testing.T) {
+	const password = "Inv3ntory"
+	var mu sync.Mutex
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/login.cgi":
+			fmt.Fprintf(w, `<input id="rand" value="%s">`, factorySeed)
+		case r.URL.Path == "/login.cgi":
+			if r.PostForm.Get("password") != internal.EncryptPasswordWithSeed(password, factorySeed) {
+				requests = append(requests, "login with the wrong password")
+				fmt.Fprint(w, `<html>login</html>`)
+				return
+			}
+			w.Header().Set("Set-Cookie", "SID=fresh")
+			fmt.Fprint(w, `<html>dashboard</html>`)
+		default:
+			fmt.Fprint(w, `<html>ok</html>`)
+		}
+		requests = append(requests, r.Method+" "+r.URL.Path)
+	}))
+	defer server.Close()
+	address := strings.TrimPrefix(server.URL, "http://")
+
+	// The environment holds another password for the same switch
+	t.Setenv("NETGEAR_SWITCHES", address+"=wrong")
+	inventory := &Inventory{Switches: []InventoryEntry{
+		{Name: "closet", Addresses: []string{address}, Model: ModelGS308EPP, Password: password},
+	}}
+	fleet := NewFleet(inventory, WithTokenManager(NewMemoryTokenManager()))
+
+	client, err := fleet.Client(context.Background(), "closet")
+	if err != nil {
+		t.Fatalf("Client failed: %v", err)
+	}
+	if !client.IsAuthenticated() || client.GetModel() != ModelGS308EPP {
+		t.Errorf("expected an authenticated GS308EPP client, got model %s", client.GetModel())
+	}
+	// The model comes from the inventory, so the switch is not probed for it
+	want := "GET /login.cgi, POST /login.cgi, GET /"
+	if got := strings.Join(requests, ", "); got != want {
+		t.Errorf("expected requests %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,199 @@
+package netgear
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// csvInventoryColumns maps accepted CSV header names (lower case) to inventory fields.
+// Columns not listed here become template Vars of each entry.
+var csvInventoryColumns = map[string]string{
+	"name":          "name",
+	"switch":        "name",
+	"hostname":      "name",
+	"address":       "addresses",
+	"addresses":     "addresses",
+	"ip":            "addresses",
+	"ip address":    "addresses",
+	"host":          "addresses",
+	"model":         "model",
+	"password":      "password",
+	"pass":          "password",
+	"password env":  "password_env",
+	"password_env":  "password_env",
+	"tags":          "tags",
+	"poll interval": "poll_interval",
+	"poll_interval": "poll_interval",
+}
+
+// LoadInventoryCSV reads an inventory from a CSV file, see ParseInventoryCSV
+func LoadInventoryCSV(filename string) (*Inventory, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read inventory: %w", err)
+	}
+	defer file.Close()
+	return ParseInventoryCSV(file)
+}
+
+// ParseInventoryCSV builds an inventory from a spreadsheet export. The first
+// row names the columns: name, address, model, password, password_env, tags
+// and poll_interval are recognized (with common aliases), every other column
+// becomes a template variable. Several addresses or tags in one cell are
+// separated by spaces or semicolons. A password written as "env:VAR" or
+// "$VAR" is stored as a reference to that environment variable rather than in
+// the inventory.
+func ParseInventoryCSV(r io.Reader) (*Inventory, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read inventory header: %w", err)
+	}
+	columns := make([]string, len(header))
+	hasName, hasAddress := false, false
+	for i, title := range header {
+		title = strings.TrimSpace(strings.TrimPrefix(title, "\ufeff")) // spreadsheet exports often start with a BOM
+		if field, ok := csvInventoryColumns[strings.ToLower(title)]; ok {
+			columns[i] = field
+		} else {
+			columns[i] = "var:" + title
+		}
+		hasName = hasName || columns[i] == "name"
+		hasAddress = hasAddress || columns[i] == "addresses"
+	}
+	if !hasName || !hasAddress {
+		return nil, errors.New("inventory CSV needs name and address columns")
+	}
+
+	inventory := &Inventory{}
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse inventory CSV: %w", err)
+		}
+
+		entry, err := inventoryEntryFromCSV(columns, record)
+		if err != nil {
+			return nil, fmt.Errorf("inventory CSV line %d: %w", line, err)
+		}
+		if entry.Name == "" && len(entry.Addresses) == 0 {
+			continue // blank spreadsheet row
+		}
+		inventory.Switches = append(inventory.Switches, entry)
+	}
+
+	if err := inventory.Validate(); err != nil {
+		return nil, err
+	}
+	return inventory, nil
+}
+
+// inventoryEntryFromCSV converts one CSV record using the column mapping from the header
+func inventoryEntryFromCSV(columns, record []string) (InventoryEntry, error) {
+	var entry InventoryEntry
+	for i, value := range record {
+		if i >= len(columns) {
+			break
+		}
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		switch column := columns[i]; column {
+		case "name":
+			entry.Name = value
+		case "addresses":
+			entry.Addresses = append(entry.Addresses, splitCSVList(value)...)
+		case "model":
+			model := Model(strings.ToUpper(value))
+			if !model.IsSupported() {
+				return entry, fmt.Errorf("unsupported model %q", value)
+			}
+			entry.Model = model
+		case "password":
+			if ref, ok := passwordEnvReference(value); ok {
+				entry.PasswordEnv = ref
+			} else {
+				entry.Password = value
+			}
+		case "password_env":
+			entry.PasswordEnv = strings.TrimPrefix(value, "$")
+		case "tags":
+			entry.Tags = append(entry.Tags, splitCSVList(value)...)
+		case "poll_interval":
+			entry.PollInterval = value
+		default:
+			if entry.Vars == nil {
+				entry.Vars = make(map[string]string)
+			}
+			entry.Vars[strings.TrimPrefix(column, "var:")] = value
+		}
+	}
+	return entry, nil
+}
+
+// passwordEnvReference recognizes "env:VAR" and "$VAR" password cells
+func passwordEnvReference(value string) (string, bool) {
+	if ref, ok := strings.CutPrefix(value, "env:"); ok && ref != "" {
+		return ref, true
+	}
+	if ref, ok := strings.CutPrefix(value, "$"); ok && ref != "" && !strings.ContainsAny(ref, " \t") {
+		return ref, true
+	}
+	return "", false
+}
+
+// splitCSVList splits a cell holding several values separated by spaces or semicolons
+func splitCSVList(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool {
+		return r == ';' || r == ' ' || r == '\t'
+	})
+}
+
+// PasswordManager returns a PasswordManager answering with the password of the
+// inventory entry that lists the address, resolving environment references at
+// lookup time. Use it with WithPasswordManager to authenticate clients created
+// outside a Fleet from the same inventory.
+func (inv *Inventory) PasswordManager() PasswordManager {
+	return &inventoryPasswordManager{inventory: inv}
+}
+
+// inventoryPasswordManager resolves passwords from an inventory
+type inventoryPasswordManager struct {
+	inventory *Inventory
+}
+
+func (m *inventoryPasswordManager) GetPassword(address string) (string, bool) {
+	config, found := m.GetSwitchConfig(address)
+	if !found {
+		return "", false
+	}
+	return config.Password, true
+}
+
+func (m *inventoryPasswordManager) GetSwitchConfig(address string) (*SwitchConfig, bool) {
+	for _, entry := range m.inventory.Switches {
+		for _, candidate := range entry.Addresses {
+			if candidate != address {
+				continue
+			}
+			password := entry.ResolvePassword()
+			if password == "" {
+				return nil, false
+			}
+			return &SwitchConfig{Host: address, Password: password, Model: string(entry.Model)}, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,59 @@
+package netgear
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseInventoryCSV(t *testing.T) {
+	t.Setenv("SW_CORE_PASSWORD", "from-env")
+
+	csv := "\ufeffName,IP Address,Model,Password,Tags,Site,Poll Interval\n" +
+		"core,192.168.1.10; fe80::1%eth0,gs308epp,$SW_CORE_PASSWORD,poe;core,HQ,10s\n" +
+		",,,,,,\n" +
+		"cam,192.168.1.11,,plain-secret,,Lab,\n"
+
+	inventory, err := ParseInventoryCSV(strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("ParseInventoryCSV failed: %v", err)
+	}
+	if len(inventory.Switches) != 2 {
+		t.Fatalf("expected 2 switches, got %+v", inventory.Switches)
+	}
+
+	core := inventory.Switches[0]
+	if len(core.Addresses) != 2 || core.Addresses[1] != "fe80::1%eth0" {
+		t.Errorf("unexpected addresses %v", core.Addresses)
+	}
+	if core.Model != ModelGS308EPP || core.Password != "" || core.PasswordEnv != "SW_CORE_PASSWORD" {
+		t.Errorf("unexpected core entry %+v", core)
+	}
+	if len(core.Tags) != 2 || core.Vars["Site"] != "HQ" {
+		t.Errorf("unexpected tags/vars %v %v", core.Tags, core.Vars)
+	}
+	if interval, err := core.PollEvery(time.Minute); err != nil || interval != 10*time.Second {
+		t.Errorf("expected a 10s poll interval for core, got %v, %v", interval, err)
+	}
+	if interval, _ := inventory.Switches[1].PollEvery(time.Minute); interval != time.Minute {
+		t.Errorf("expected the default poll interval for cam, got %v", interval)
+	}
+
+	passwords := inventory.PasswordManager()
+	if password, ok := passwords.GetPassword("192.168.1.10"); !ok || password != "from-env" {
+		t.Errorf("expected env password for core, got %q %v", password, ok)
+	}
+	if password, ok := passwords.GetPassword("192.168.1.11"); !ok || password != "plain-secret" {
+		t.Errorf("expected inline password for cam, got %q %v", password, ok)
+	}
+	if _, ok := passwords.GetPassword("192.168.1.99"); ok {
+		t.Error("expected no password for an unknown address")
+	}
+
+	if _, err := ParseInventoryCSV(strings.NewReader("name,address,model\nx,10.0.0.1,GS999\n")); err == nil {
+		t.Error("expected error for an unsupported model")
+	}
+	if _, err := ParseInventoryCSV(strings.NewReader("name,model\nx,GS308EP\n")); err == nil {
+		t.Error("expected error without an address column")
+	}
+}
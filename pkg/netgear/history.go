@@ -0,0 +1,90 @@
+package netgear
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gherlein/go-netgear/pkg/redact"
+)
+
+// DefaultHistorySize is the number of operations a client remembers unless
+// WithHistorySize says otherwise
+const DefaultHistorySize = 100
+
+// HistoryEntry records one operation the client performed against the switch
+type HistoryEntry struct {
+	Time     time.Time     `json:"time"`
+	Op       string        `json:"op"`
+	Target   string        `json:"target"`
+	Duration time.Duration `json:"duration"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// historyRecorder keeps the most recent operations in a fixed-size ring
+type historyRecorder struct {
+	mu      sync.Mutex
+	entries []HistoryEntry
+	next    int
+	full    bool
+}
+
+func newHistoryRecorder(size int) *historyRecorder {
+	if size < 0 {
+		size = 0
+	}
+	return &historyRecorder{entries: make([]HistoryEntry, size)}
+}
+
+// newHistoryEntry describes an operation that started at start and ended at end
+func newHistoryEntry(op, target string, start, end time.Time, err error) HistoryEntry {
+	// Entries end up in audit trails and bug reports, so keep secrets out
+	entry := HistoryEntry{Time: start, Op: op, Target: redact.String(target), Duration: end.Sub(start)}
+	if err != nil {
+		entry.Error = redact.String(err.Error())
+	}
+	return entry
+}
+
+// add records an entry, overwriting the oldest entry when full
+func (r *historyRecorder) add(entry HistoryEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.entries) == 0 {
+		return
+	}
+
+	r.entries[r.next] = entry
+	r.next = (r.next + 1) % len(r.entries)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// snapshot returns the recorded operations, oldest first
+func (r *historyRecorder) snapshot() []HistoryEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.full {
+		return append([]HistoryEntry(nil), r.entries[:r.next]...)
+	}
+	result := make([]HistoryEntry, 0, len(r.entries))
+	result = append(result, r.entries[r.next:]...)
+	return append(result, r.entries[:r.next]...)
+}
+
+// WithHistorySize sets how many recent operations the client keeps for
+// History. Zero disables recording.
+func WithHistorySize(size int) ClientOption {
+	return func(c *Client) {
+		c.history = newHistoryRecorder(size)
+	}
+}
+
+// History returns the client's most recent operations, oldest first, so that
+// a misbehaving automation can be reconstructed after the fact: every login
+// and every page request with its duration and outcome.
+func (c *Client) History() []HistoryEntry {
+	return c.history.snapshot()
+}
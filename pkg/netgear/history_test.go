@@ -0,0 +1,49 @@
+package netgear
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHistoryKeepsMostRecentOperations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, "<html></html>")
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	address := strings.TrimPrefix(server.URL, "http://")
+	client, err := NewClient(address, append(factoryClientOptions(address), WithHistorySize(3))...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	for _, path := range []string{"/a", "/b", "/missing", "/c"} {
+		client.makeAuthenticatedRequest(ctx, "GET", path, nil)
+	}
+
+	history := client.History()
+	if len(history) != 3 {
+		t.Fatalf("expected 3 entries, got %+v", history)
+	}
+	if history[0].Target != "/b" || history[2].Target != "/c" {
+		t.Errorf("expected oldest-first order /b../c, got %+v", history)
+	}
+	if history[1].Op != "GET" || history[1].Error == "" {
+		t.Errorf("expected failed GET for /missing, got %+v", history[1])
+	}
+
+	disabled, _ := NewClient(address, append(factoryClientOptions(address), WithHistorySize(0))...)
+	disabled.makeAuthenticatedRequest(ctx, "GET", "/a", nil)
+	if len(disabled.History()) != 0 {
+		t.Error("expected no history when disabled")
+	}
+}
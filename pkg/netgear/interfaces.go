@@ -0,0 +1,72 @@
+package netgear
+
+import "context"
+
+// POEController is the POE management of a switch. POEManager implements it
+// against a real switch and netgeartest.FakeClient in memory, so code
+// written against it can be tested without hardware.
+type POEController interface {
+	GetStatus(ctx context.Context) ([]POEPortStatus, error)
+	GetSettings(ctx context.Context) ([]POEPortSettings, error)
+	GetPortStatus(ctx context.Context, portID int) (*POEPortStatus, error)
+	GetPortSettings(ctx context.Context, portID int) (*POEPortSettings, error)
+	GetBudget(ctx context.Context) (*POEBudget, error)
+	UpdatePort(ctx context.Context, updates ...POEPortUpdate) error
+	EnablePort(ctx context.Context, portID int) error
+	DisablePort(ctx context.Context, portID int) error
+	SetPortMode(ctx context.Context, portID int, mode POEMode) error
+	SetPortPriority(ctx context.Context, portID int, priority POEPriority) error
+	SetPortPowerLimit(ctx context.Context, portID int, limitType POELimitType, limitW float64) error
+	CyclePower(ctx context.Context, portIDs ...int) error
+}
+
+// PortController is the port management of a switch, implemented by
+// PortManager and netgeartest.FakeClient
+type PortController interface {
+	GetSettings(ctx context.Context) ([]PortSettings, error)
+	GetPortSettings(ctx context.Context, portID int) (*PortSettings, error)
+	UpdatePort(ctx context.Context, updates ...PortUpdate) error
+	SetPortName(ctx context.Context, portID int, name string) error
+	SetPortSpeed(ctx context.Context, portID int, speed PortSpeed) error
+	SetPortFlowControl(ctx context.Context, portID int, enabled bool) error
+	SetPortLimits(ctx context.Context, portID int, ingressLimit, egressLimit string) error
+	EnablePort(ctx context.Context, portID int) error
+	DisablePort(ctx context.Context, portID int) error
+	GetStatistics(ctx context.Context) ([]PortStatistics, error)
+	GetPortStatistics(ctx context.Context, portID int) (*PortStatistics, error)
+	ResetStatistics(ctx context.Context, portID int) error
+	GetNeighbors(ctx context.Context) ([]Neighbor, error)
+}
+
+// SwitchClient is a connection to a switch as seen by code that manages POE
+// and ports. *Client implements it; accept a SwitchClient instead of a
+// *Client to substitute netgeartest.FakeClient in tests:
+//
+//	func powerCycleCameras(ctx context.Context, sw netgear.SwitchClient) error {
+//		return sw.POEController().CyclePower(ctx, 1, 2, 3)
+//	}
+type SwitchClient interface {
+	GetAddress() string
+	GetModel() Model
+	IsAuthenticated() bool
+	Login(ctx context.Context, password string) error
+	Logout(ctx context.Context) error
+	POEController() POEController
+	PortController() PortController
+}
+
+var (
+	_ POEController  = (*POEManager)(nil)
+	_ PortController = (*PortManager)(nil)
+	_ SwitchClient   = (*Client)(nil)
+)
+
+// POEController returns POE() as a POEController
+func (c *Client) POEController() POEController {
+	return c.POE()
+}
+
+// PortController returns Ports() as a PortController
+func (c *Client) PortController() PortController {
+	return c.Ports()
+}
@@ -0,0 +1,44 @@
+package internal
+
+import (
+	"fmt"
+	"sort"
+)
+
+// PortsToBitmap builds a port bitmap string with one '0'/'1' character per
+// port, where character i (0-based) represents port i+1
+func PortsToBitmap(ports []int, portCount int) (string, error) {
+	if portCount <= 0 {
+		return "", fmt.Errorf("invalid port count %d", portCount)
+	}
+
+	bitmap := make([]byte, portCount)
+	for i := range bitmap {
+		bitmap[i] = '0'
+	}
+
+	for _, port := range ports {
+		if port < 1 || port > portCount {
+			return "", fmt.Errorf("port %d out of range 1-%d", port, portCount)
+		}
+		bitmap[port-1] = '1'
+	}
+
+	return string(bitmap), nil
+}
+
+// BitmapToPorts returns the sorted port IDs selected in a port bitmap
+func BitmapToPorts(bitmap string) ([]int, error) {
+	var ports []int
+	for i, c := range bitmap {
+		switch c {
+		case '1':
+			ports = append(ports, i+1)
+		case '0':
+		default:
+			return nil, fmt.Errorf("invalid character %q at position %d in port bitmap %q", c, i+1, bitmap)
+		}
+	}
+	sort.Ints(ports)
+	return ports, nil
+}
@@ -0,0 +1,36 @@
+package internal
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPortsToBitmap(t *testing.T) {
+	bitmap, err := PortsToBitmap([]int{1, 3, 16}, 16)
+	if err != nil {
+		t.Fatalf("PortsToBitmap failed: %v", err)
+	}
+	if bitmap != "1010000000000001" {
+		t.Errorf("Unexpected bitmap %q", bitmap)
+	}
+
+	for _, ports := range [][]int{{0}, {17}} {
+		if _, err := PortsToBitmap(ports, 16); err == nil {
+			t.Errorf("Expected error for ports %v", ports)
+		}
+	}
+}
+
+func TestBitmapToPorts(t *testing.T) {
+	ports, err := BitmapToPorts("0100000000000011")
+	if err != nil {
+		t.Fatalf("BitmapToPorts failed: %v", err)
+	}
+	if !reflect.DeepEqual(ports, []int{2, 15, 16}) {
+		t.Errorf("Unexpected ports %v", ports)
+	}
+
+	if _, err := BitmapToPorts("01x0"); err == nil {
+		t.Error("Expected error for invalid character")
+	}
+}
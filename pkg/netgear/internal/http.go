@@ -5,24 +5,30 @@ import (
 	"crypto/md5"
 	"fmt"
 	"io"
+	"log/slog"
+	"net"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync/atomic"
 	"time"
+
+	"github.com/gherlein/go-netgear/pkg/redact"
 )
 
 // HTTPClient wraps the standard HTTP client with netgear-specific functionality
 type HTTPClient struct {
-	client  *http.Client
-	baseURL string
-	verbose bool
+	client      *http.Client
+	baseURL     string
+	logger      *slog.Logger
+	sendReferer atomic.Bool
 }
 
 // NewHTTPClient creates a new HTTP client for netgear switch communication
-func NewHTTPClient(address string, timeout time.Duration, verbose bool) *HTTPClient {
+func NewHTTPClient(address string, timeout time.Duration, logger *slog.Logger) *HTTPClient {
 	// Ensure address has protocol
 	if !strings.HasPrefix(address, "http://") && !strings.HasPrefix(address, "https://") {
-		address = "http://" + address
+		address = "http://" + hostForURL(address)
 	}
 
 	return &HTTPClient{
@@ -34,31 +40,34 @@ func NewHTTPClient(address string, timeout time.Duration, verbose bool) *HTTPCli
 			},
 		},
 		baseURL: address,
-		verbose: verbose,
+		logger:  logger,
 	}
 }
 
 // Get performs a GET request
 func (h *HTTPClient) Get(ctx context.Context, path string, headers map[string]string) (*http.Response, error) {
-	return h.request(ctx, "GET", path, nil, headers)
+	return h.request(ctx, "GET", path, nil, "", headers)
 }
 
 // Post performs a POST request
 func (h *HTTPClient) Post(ctx context.Context, path string, data url.Values, headers map[string]string) (*http.Response, error) {
 	var body io.Reader
+	var logBody string
 	if data != nil {
 		body = strings.NewReader(data.Encode())
+		logBody = redact.Default().Values(data).Encode()
 		if headers == nil {
 			headers = make(map[string]string)
 		}
 		headers["Content-Type"] = "application/x-www-form-urlencoded"
 	}
 	
-	return h.request(ctx, "POST", path, body, headers)
+	return h.request(ctx, "POST", path, body, logBody, headers)
 }
 
-// request is the internal method for making HTTP requests
-func (h *HTTPClient) request(ctx context.Context, method, path string, body io.Reader, headers map[string]string) (*http.Response, error) {
+// request is the internal method for making HTTP requests. logBody is the
+// redacted body logged at debug level.
+func (h *HTTPClient) request(ctx context.Context, method, path string, body io.Reader, logBody string, headers map[string]string) (*http.Response, error) {
 	fullURL := h.baseURL + path
 	
 	req, err := http.NewRequestWithContext(ctx, method, fullURL, body)
@@ -76,11 +85,13 @@ func (h *HTTPClient) request(ctx context.Context, method, path string, body io.R
 		req.Header.Set("User-Agent", "ntgrrc-library/1.0")
 	}
 
-	if h.verbose {
-		fmt.Printf("Making %s request to %s\n", method, fullURL)
-		if body != nil {
-			fmt.Printf("Request body: %v\n", body)
-		}
+	// Some firmware rejects form posts without a same-origin Referer
+	if h.sendReferer.Load() && req.Header.Get("Referer") == "" {
+		req.Header.Set("Referer", h.baseURL+"/")
+	}
+
+	if logBody != "" {
+		h.logger.DebugContext(ctx, "request body", "url", redact.String(fullURL), "body", logBody)
 	}
 
 	resp, err := h.client.Do(req)
@@ -88,13 +99,23 @@ func (h *HTTPClient) request(ctx context.Context, method, path string, body io.R
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
 
-	if h.verbose {
-		fmt.Printf("Response status: %s\n", resp.Status)
-	}
-
 	return resp, nil
 }
 
+// hostForURL brackets bare IPv6 literals (including link-local zones) so they
+// can be used as a URL host
+func hostForURL(address string) string {
+	host, zone, hasZone := strings.Cut(address, "%")
+	ip := net.ParseIP(host)
+	if ip == nil || ip.To4() != nil {
+		return address
+	}
+	if hasZone {
+		return "[" + host + "%25" + zone + "]"
+	}
+	return "[" + host + "]"
+}
+
 // ReadBody reads and returns the response body as a string
 func (h *HTTPClient) ReadBody(resp *http.Response) (string, error) {
 	defer resp.Body.Close()
@@ -105,13 +126,17 @@ func (h *HTTPClient) ReadBody(resp *http.Response) (string, error) {
 	}
 
 	bodyStr := string(body)
-	if h.verbose && len(bodyStr) > 0 {
+	if len(bodyStr) > 0 && h.logger.Enabled(context.Background(), slog.LevelDebug) {
 		// Only show first 500 characters to avoid flooding logs
 		preview := bodyStr
 		if len(preview) > 500 {
 			preview = preview[:500] + "..."
 		}
-		fmt.Printf("Response body preview: %s\n", preview)
+		var target string
+		if resp.Request != nil {
+			target = redact.String(resp.Request.URL.String())
+		}
+		h.logger.Debug("response body", "url", target, "preview", redact.String(preview))
 	}
 
 	return bodyStr, nil
@@ -160,9 +185,48 @@ func IsRedirect(resp *http.Response) bool {
 	return resp.StatusCode >= 300 && resp.StatusCode < 400
 }
 
-// SetVerbose enables or disables verbose logging
-func (h *HTTPClient) SetVerbose(verbose bool) {
-	h.verbose = verbose
+// SetLogger sets the logger request and response bodies are logged to at
+// debug level
+func (h *HTTPClient) SetLogger(logger *slog.Logger) {
+	h.logger = logger
+}
+
+// SetSendReferer enables or disables sending a same-origin Referer header
+func (h *HTTPClient) SetSendReferer(enabled bool) {
+	h.sendReferer.Store(enabled)
+}
+
+// Clone returns an independent HTTP client with the same settings
+func (h *HTTPClient) Clone() *HTTPClient {
+	clone := &HTTPClient{
+		client: &http.Client{
+			Timeout:       h.client.Timeout,
+			CheckRedirect: h.client.CheckRedirect,
+			Transport:     h.client.Transport,
+		},
+		baseURL: h.baseURL,
+		logger:  h.logger,
+	}
+	clone.sendReferer.Store(h.sendReferer.Load())
+	return clone
+}
+
+// Transport returns the round tripper requests are sent through
+func (h *HTTPClient) Transport() http.RoundTripper {
+	if h.client.Transport == nil {
+		return http.DefaultTransport
+	}
+	return h.client.Transport
+}
+
+// SetTransport replaces the round tripper requests are sent through
+func (h *HTTPClient) SetTransport(transport http.RoundTripper) {
+	h.client.Transport = transport
+}
+
+// UseHTTPS switches a base URL built from an address without a scheme to https
+func (h *HTTPClient) UseHTTPS() {
+	h.baseURL = "https://" + strings.TrimPrefix(h.baseURL, "http://")
 }
 
 // GetBaseURL returns the base URL
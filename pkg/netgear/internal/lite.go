@@ -0,0 +1,241 @@
+package internal
+
+import (
+	"io"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// liteElement is an element left open by the streaming parser
+type liteElement struct {
+	tag     string
+	classes []string
+}
+
+func (e liteElement) is(tag, class string) bool {
+	if e.tag != tag {
+		return false
+	}
+	if class == "" {
+		return true
+	}
+	for _, c := range e.classes {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+// voidElements have no content and no end tag
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "source": true, "track": true, "wbr": true,
+}
+
+// liteAttributeTags are the elements whose attributes the selectors look at
+var liteAttributeTags = map[string]bool{"li": true, "input": true, "span": true, "div": true}
+
+// poeStatusItemClasses mark the list items of the GS30x POE status page
+var poeStatusItemClasses = []string{"poePortStatusListItem", "poe_port_list_item"}
+
+// poeStatusTextFields map the span classes holding a single text value of a
+// list item to the result keys, as in "span.poe-port-index span"
+var poeStatusTextFields = []struct {
+	class string
+	key   string
+}{
+	{"poe-port-index", "port_name"},
+	{"poe-power-mode", "status"},
+	{"poe-portPwr-width", "power_class"},
+}
+
+// liteItem collects one POE status list item while it is streamed
+type liteItem struct {
+	depth     int // stack index of the item's li element
+	portID    int
+	hasPortID bool
+	sawPortID bool // the first hidden port input was seen
+	fields    [3]strings.Builder
+	readingAt int // stack index of the open reading span, or -1
+	reading   strings.Builder
+	readings  map[string]interface{}
+}
+
+// parsePOEStatusLite parses the GS30x POE status list from the token stream
+// without building a document, producing the same records as the goquery
+// parser. ok is false whenever the page is not in the simple shape it
+// understands (no status list items, tags left unbalanced inside an item,
+// nested matches), leaving such pages to the document parser.
+func parsePOEStatusLite(content string) (results []map[string]interface{}, ok bool) {
+	z := html.NewTokenizer(strings.NewReader(content))
+	var stack []liteElement
+	var item *liteItem
+
+	for {
+		tokenType := z.Next()
+		switch tokenType {
+		case html.ErrorToken:
+			if z.Err() != io.EOF || item != nil {
+				return nil, false
+			}
+			return results, len(results) > 0
+
+		case html.TextToken:
+			if item == nil {
+				continue
+			}
+			text := string(z.Text())
+			for i, field := range poeStatusTextFields {
+				switch countInnerSpans(stack, item.depth, field.class) {
+				case 0:
+				case 1:
+					item.fields[i].WriteString(text)
+				default:
+					return nil, false
+				}
+			}
+			if item.readingAt >= 0 {
+				item.reading.WriteString(text)
+			}
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			tag := string(name)
+			element := liteElement{tag: tag}
+			var attrs map[string]string
+			for hasAttr && liteAttributeTags[tag] {
+				var key, value []byte
+				key, value, hasAttr = z.TagAttr()
+				if attrs == nil {
+					attrs = make(map[string]string)
+				}
+				if _, exists := attrs[string(key)]; !exists {
+					attrs[string(key)] = string(value)
+				}
+			}
+			element.classes = strings.Fields(attrs["class"])
+
+			if tag == "li" {
+				if item != nil {
+					return nil, false // nested or implicitly closed item
+				}
+				for _, class := range poeStatusItemClasses {
+					if element.is("li", class) {
+						item = &liteItem{depth: len(stack), readingAt: -1, readings: make(map[string]interface{})}
+						break
+					}
+				}
+			}
+
+			if item != nil && tag == "input" && !item.sawPortID && attrs["type"] == "hidden" && element.is("input", "port") {
+				item.sawPortID = true
+				if value, exists := attrs["value"]; exists {
+					if portID, err := strconv.Atoi(value); err == nil {
+						item.portID, item.hasPortID = portID, true
+					}
+				}
+			}
+
+			if voidElements[tag] {
+				continue
+			}
+			stack = append(stack, element)
+
+			if item != nil && tag == "span" && isReadingSpan(stack, len(stack)-1) {
+				if item.readingAt >= 0 {
+					return nil, false // nested reading spans
+				}
+				item.readingAt = len(stack) - 1
+			}
+
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			tag := string(name)
+			top := len(stack) - 1
+			if top >= 0 && stack[top].tag == tag {
+				if item != nil && item.readingAt == top {
+					recordPOEReading(item.readings, item.reading.String())
+					item.reading.Reset()
+					item.readingAt = -1
+				}
+				stack = stack[:top]
+				if item != nil && item.depth == top {
+					if record := item.record(); record != nil {
+						results = append(results, record)
+					}
+					item = nil
+				}
+				continue
+			}
+			if item != nil {
+				return nil, false // unbalanced markup inside an item
+			}
+			// Outside items, close up to the matching element as the HTML parser would
+			for i := top; i >= 0; i-- {
+				if stack[i].tag == tag {
+					stack = stack[:i]
+					break
+				}
+			}
+		}
+	}
+}
+
+// countInnerSpans counts the open spans inside the item that are nested in a
+// span of the given class, returning 2 for any ambiguous nesting
+func countInnerSpans(stack []liteElement, itemDepth int, class string) int {
+	outer := -1
+	for i := range stack {
+		if stack[i].is("span", class) {
+			if outer >= 0 {
+				return 2
+			}
+			outer = i
+		}
+	}
+	if outer < 0 {
+		return 0
+	}
+	count := 0
+	for i := max(outer, itemDepth) + 1; i < len(stack); i++ {
+		if stack[i].tag == "span" {
+			count++
+		}
+	}
+	return count
+}
+
+// isReadingSpan reports whether the span at index matches
+// "div.poe_port_status div div span"
+func isReadingSpan(stack []liteElement, index int) bool {
+	divs := 0
+	for i := index - 1; i >= 0; i-- {
+		if divs >= 2 && stack[i].is("div", "poe_port_status") {
+			return true
+		}
+		if stack[i].tag == "div" {
+			divs++
+		}
+	}
+	return false
+}
+
+// record returns the item's record, or nil if it has no port ID
+func (item *liteItem) record() map[string]interface{} {
+	if !item.hasPortID {
+		return nil
+	}
+	portData := map[string]interface{}{"port_id": item.portID}
+	for i, field := range poeStatusTextFields {
+		if text := strings.TrimSpace(item.fields[i].String()); text != "" {
+			portData[field.key] = text
+		}
+	}
+	for key, value := range item.readings {
+		portData[key] = value
+	}
+	return portData
+}
@@ -0,0 +1,6 @@
+//go:build !netgear_lite
+
+package internal
+
+// liteParsing selects the streaming fast path for hot status pages
+const liteParsing = false
@@ -0,0 +1,92 @@
+package internal
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const poeStatusItemTemplate = `<li class="poePortStatusListItem">
+	<input type="hidden" class="port" value="%d"/>
+	<span class="poe-port-index"><span>%d</span></span>
+	<span class="poe-power-mode"><span>%s</span></span>
+	<span class="poe-portPwr-width"><span>Class 4</span></span>
+	<div class="poe_port_status"><div><div>
+		<span>%s</span><span>%s</span><span>%s</span>
+	</div></div></div>
+</li>`
+
+func poeStatusPage(ports int) string {
+	var page strings.Builder
+	page.WriteString(`<!DOCTYPE html><html><head><title>PoE</title><script>var x = "<li>";</script></head><body><p>unclosed<ul>`)
+	for port := 1; port <= ports; port++ {
+		fmt.Fprintf(&page, poeStatusItemTemplate, port, port, "Delivering Power", "53.2 V", "105 mA", "5.6 W")
+	}
+	page.WriteString(`</ul></body></html>`)
+	return page.String()
+}
+
+// TestLitePOEStatusConformance checks that the streaming parser produces
+// exactly what the document parser produces, or declines the page
+func TestLitePOEStatusConformance(t *testing.T) {
+	pages := map[string]struct {
+		content string
+		lite    bool // the fast path is expected to handle the page
+	}{
+		"eight ports": {poeStatusPage(8), true},
+		"alternate item class": {`<ul><li class="poe_port_list_item big"><input type="hidden" class="port" value="2">
+			<span class="poe-port-index"><span> Port &amp; 2 </span></span>
+			<div class="poe_port_status"><div><div><span>0 W</span><span></span></div></div></div></li></ul>`, true},
+		"item without port id": {`<ul><li class="poePortStatusListItem"><span class="poe-port-index"><span>1</span></span></li>` +
+			`<li class="poePortStatusListItem"><input type="hidden" class="port" value="3"></li></ul>`, true},
+		"readings too shallow": {`<ul><li class="poePortStatusListItem"><input type="hidden" class="port" value="1">
+			<div class="poe_port_status"><div><span>48 V</span></div></div></li></ul>`, true},
+		"first port input wins": {`<ul><li class="poePortStatusListItem"><input type="hidden" class="port" value="x">
+			<input type="hidden" class="port" value="4"></li></ul>`, false},
+		"table layout":   {`<table><tr><th>Port</th></tr><tr><td>1</td><td>p1</td><td>On</td><td>0</td><td>53</td><td>100</td><td>5.3</td></tr></table>`, false},
+		"unclosed items": {`<ul><li class="poePortStatusListItem"><input type="hidden" class="port" value="1"><li class="poePortStatusListItem"><input type="hidden" class="port" value="2"></ul>`, false},
+		"nested name spans": {`<ul><li class="poePortStatusListItem"><input type="hidden" class="port" value="1">
+			<span class="poe-port-index"><span>a<span>b</span></span></span></li></ul>`, false},
+		"unbalanced item": {`<ul><li class="poePortStatusListItem"><input type="hidden" class="port" value="1"><div></span></li></ul>`, false},
+	}
+
+	for name, page := range pages {
+		t.Run(name, func(t *testing.T) {
+			want, err := parsePOEStatusDocument(page.content)
+			if err != nil {
+				t.Fatalf("document parser failed: %v", err)
+			}
+
+			got, ok := parsePOEStatusLite(page.content)
+			if ok != page.lite {
+				t.Fatalf("fast path handled page = %v, want %v", ok, page.lite)
+			}
+			if ok && !reflect.DeepEqual(got, want) {
+				t.Errorf("fast path differs from document parser:\n got  %v\n want %v", got, want)
+			}
+
+			parsed, err := NewPOEDataParser().ParsePOEStatus(page.content)
+			if err != nil || !reflect.DeepEqual(parsed, want) {
+				t.Errorf("ParsePOEStatus differs from document parser: %v (%v)", parsed, err)
+			}
+		})
+	}
+}
+
+func BenchmarkParsePOEStatus(b *testing.B) {
+	page := poeStatusPage(24)
+
+	b.Run("document", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			parsePOEStatusDocument(page)
+		}
+	})
+	b.Run("lite", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			parsePOEStatusLite(page)
+		}
+	})
+}
@@ -3,39 +3,164 @@ package internal
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/PuerkitoBio/goquery"
 )
 
 // ModelDetector contains logic for detecting Netgear switch models
-type ModelDetector struct{}
+type ModelDetector struct {
+	models []string
+}
 
-// NewModelDetector creates a new model detector
-func NewModelDetector() *ModelDetector {
-	return &ModelDetector{}
+// NewModelDetector creates a model detector recognizing the given model names
+func NewModelDetector(models ...string) *ModelDetector {
+	// Check longer names first so "GS308EPP" is not reported as "GS308EP"
+	sorted := append([]string(nil), models...)
+	sort.SliceStable(sorted, func(i, j int) bool { return len(sorted[i]) > len(sorted[j]) })
+	return &ModelDetector{models: sorted}
 }
 
 // DetectFromHTML attempts to detect the switch model from HTML content
 func (md *ModelDetector) DetectFromHTML(htmlContent string) string {
-	// Check for most specific models first to avoid partial matches
-	specificModels := []string{"GS316EPP", "GS308EPP", "GS305EPP", "GS316EP", "GS308EP", "GS305EP"}
-	for _, model := range specificModels {
+	for _, model := range md.models {
 		if strings.Contains(htmlContent, model) {
 			return model
 		}
 	}
 	
-	// If no specific model found but it looks like a redirect page, assume GS30xEPx
-	if strings.Contains(htmlContent, "Redirect to Login") || 
-	   strings.Contains(htmlContent, "redirect") {
+	// Smart managed pro switches redirect to their own login page, which names the model
+	if strings.Contains(htmlContent, "/base/main_login.html") {
+		return ""
+	}
+
+	// If no specific model found but it looks like a redirect page, assume GS30xEPx.
+	// A bare "redirect" only counts alongside Netgear markers, so interstitials
+	// from proxies and captive portals are not mistaken for a switch.
+	if strings.Contains(htmlContent, "Redirect to Login") ||
+		(strings.Contains(htmlContent, "redirect") && HasNetgearMarkers(htmlContent)) {
 		return "GS30xEPx"
 	}
-	
+
 	return ""
 }
 
+// netgearMarkers are strings found on the landing or login pages of Netgear switches
+var netgearMarkers = []string{"NETGEAR", "Netgear", "netgear", "/login.cgi", "/wmi/login", "/redirect.html", "Gambit", "/base/main_login.html"}
+
+// HasNetgearMarkers reports whether the HTML looks like it was served by a Netgear switch
+func HasNetgearMarkers(htmlContent string) bool {
+	for _, marker := range netgearMarkers {
+		if strings.Contains(htmlContent, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// netgearModelRegex matches Netgear switch model numbers such as GS305PP or GS108LPv2
+var netgearModelRegex = regexp.MustCompile(`\b(?:JGS|GS|GC|MS|XS)\d{3}[A-Z]*(?:v\d+)?\b`)
+
+// GuessModel returns the first Netgear switch model number found in the content,
+// for devices DetectFromHTML does not recognize as a supported model
+func GuessModel(content string) string {
+	return netgearModelRegex.FindString(content)
+}
+
+// ExtractTitle returns the text of the page's <title> element
+func ExtractTitle(htmlContent string) string {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlContent))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(doc.Find("title").First().Text())
+}
+
+// Layout identifies the generation of the GS30x web UI that served a page
+type Layout int
+
+const (
+	// LayoutUnknown pages carry none of the known layout's marker classes
+	LayoutUnknown Layout = iota
+	// LayoutClassic is the original GS30x UI (li.poePortStatusListItem, li.port_circle)
+	LayoutClassic
+	// LayoutRevised is the UI of the GS305EPv2 and of recent GS305EP/GS308EP
+	// firmware, which renamed the CSS classes to kebab-case (li.poe-port-status-item, li.port-circle)
+	LayoutRevised
+)
+
+// String returns the layout name
+func (l Layout) String() string {
+	switch l {
+	case LayoutClassic:
+		return "classic"
+	case LayoutRevised:
+		return "revised"
+	default:
+		return "unknown"
+	}
+}
+
+// layoutSelectors are the goquery selectors of the POE pages in one layout
+type layoutSelectors struct {
+	poeItem       string // a port's list item on the POE status page
+	poePortID     string // hidden input holding the port number, within poeItem
+	poePortName   string
+	poeStatus     string
+	poePowerClass string
+	poeReadings   string // spans holding voltage, current, power and temperature
+	portNumber    string // port numbers on the POE configuration page
+}
+
+// layouts lists the selector set of each layout, newest first
+var layouts = []struct {
+	layout    Layout
+	selectors layoutSelectors
+}{
+	{LayoutRevised, layoutSelectors{
+		poeItem:       "li.poe-port-status-item",
+		poePortID:     "input[type=hidden].port-id",
+		poePortName:   "span.poe-port-name",
+		poeStatus:     "span.poe-port-state",
+		poePowerClass: "span.poe-power-class",
+		poeReadings:   "div.poe-port-readings span",
+		portNumber:    "li.port-circle span.port-circle-num",
+	}},
+	{LayoutClassic, layoutSelectors{
+		poeItem:       "li.poePortStatusListItem, li.poe_port_list_item",
+		poePortID:     "input[type=hidden].port",
+		poePortName:   "span.poe-port-index span",
+		poeStatus:     "span.poe-power-mode span",
+		poePowerClass: "span.poe-portPwr-width span",
+		poeReadings:   "div.poe_port_status div div span",
+		portNumber:    "li.port_circle span.port_circle_num",
+	}},
+}
+
+// DetectLayout reports which GS30x UI layout served a page
+func DetectLayout(content string) Layout {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(content))
+	if err != nil {
+		return LayoutUnknown
+	}
+	layout, _ := detectLayout(doc)
+	return layout
+}
+
+// detectLayout finds the layout whose marker elements appear in the document
+// and returns its selectors; unknown pages get the classic selectors
+func detectLayout(doc *goquery.Document) (Layout, layoutSelectors) {
+	for _, candidate := range layouts {
+		if doc.Find(candidate.selectors.poeItem+", "+candidate.selectors.portNumber).Length() > 0 {
+			return candidate.layout, candidate.selectors
+		}
+	}
+	return LayoutUnknown, layouts[len(layouts)-1].selectors
+}
+
 // POEDataParser contains logic for parsing POE-related data
 type POEDataParser struct{}
 
@@ -44,8 +169,21 @@ func NewPOEDataParser() *POEDataParser {
 	return &POEDataParser{}
 }
 
-// ParsePOEStatus parses POE status data from HTML/JavaScript response
+// ParsePOEStatus parses POE status data from HTML/JavaScript response. Builds
+// with the netgear_lite tag try the allocation-light streaming parser first
+// and fall back to the full document parser when the page is not in the
+// classic GS30x layout.
 func (p *POEDataParser) ParsePOEStatus(content string) ([]map[string]interface{}, error) {
+	if liteParsing {
+		if results, ok := parsePOEStatusLite(content); ok {
+			return results, nil
+		}
+	}
+	return parsePOEStatusDocument(content)
+}
+
+// parsePOEStatusDocument parses the POE status page with goquery
+func parsePOEStatusDocument(content string) ([]map[string]interface{}, error) {
 	var results []map[string]interface{}
 	
 	doc, err := goquery.NewDocumentFromReader(strings.NewReader(content))
@@ -53,56 +191,36 @@ func (p *POEDataParser) ParsePOEStatus(content string) ([]map[string]interface{}
 		return nil, fmt.Errorf("failed to parse HTML: %w", err)
 	}
 	
-	// Parse GS30x series format (li.poePortStatusListItem or li.poe_port_list_item)
-	doc.Find("li.poePortStatusListItem, li.poe_port_list_item").Each(func(i int, s *goquery.Selection) {
+	// Parse GS30x series format in whichever layout the firmware serves
+	_, sel := detectLayout(doc)
+	doc.Find(sel.poeItem).Each(func(i int, s *goquery.Selection) {
 		portData := make(map[string]interface{})
 		
 		// Extract port ID from hidden input
-		if id, exists := s.Find("input[type=hidden].port").Attr("value"); exists {
+		if id, exists := s.Find(sel.poePortID).First().Attr("value"); exists {
 			if portID, err := strconv.Atoi(id); err == nil {
 				portData["port_id"] = portID
 			}
 		}
 		
-		// Extract port name from poe-port-index span
-		if portText := strings.TrimSpace(s.Find("span.poe-port-index span").Text()); portText != "" {
+		// Extract port name
+		if portText := strings.TrimSpace(s.Find(sel.poePortName).Text()); portText != "" {
 			portData["port_name"] = portText
 		}
 		
-		// Extract POE status from poe-power-mode span
-		if status := strings.TrimSpace(s.Find("span.poe-power-mode span").Text()); status != "" {
+		// Extract POE status
+		if status := strings.TrimSpace(s.Find(sel.poeStatus).Text()); status != "" {
 			portData["status"] = status
 		}
 		
-		// Extract power class from poe-portPwr-width span
-		if powerClass := strings.TrimSpace(s.Find("span.poe-portPwr-width span").Text()); powerClass != "" {
+		// Extract power class
+		if powerClass := strings.TrimSpace(s.Find(sel.poePowerClass).Text()); powerClass != "" {
 			portData["power_class"] = powerClass
 		}
 		
-		// Extract voltage, current, and power from poe_port_status divs
-		s.Find("div.poe_port_status div div span").Each(func(j int, span *goquery.Selection) {
-			text := strings.TrimSpace(span.Text())
-			if text == "" {
-				return
-			}
-			
-			// Try to extract numeric values
-			if strings.Contains(text, "V") {
-				// Voltage
-				if val := extractNumericValue(text); val > 0 {
-					portData["voltage_v"] = val
-				}
-			} else if strings.Contains(text, "mA") {
-				// Current
-				if val := extractNumericValue(text); val > 0 {
-					portData["current_ma"] = val
-				}
-			} else if strings.Contains(text, "W") {
-				// Power
-				if val := extractNumericValue(text); val > 0 {
-					portData["power_w"] = val
-				}
-			}
+		// Extract voltage, current, and power readings
+		s.Find(sel.poeReadings).Each(func(j int, span *goquery.Selection) {
+			recordPOEReading(portData, span.Text())
 		})
 		
 		// Only add if we found at least a port ID
@@ -169,8 +287,9 @@ func (p *POEDataParser) ParsePOESettings(content string) ([]map[string]interface
 
 	// For GS30x series (like GS308EPP), the POE settings are in div.poe-port-box elements
 	// First, try to find port circles to get port numbers
+	_, sel := detectLayout(doc)
 	portNumbers := make([]int, 0)
-	doc.Find("li.port_circle span.port_circle_num").Each(func(i int, s *goquery.Selection) {
+	doc.Find(sel.portNumber).Each(func(i int, s *goquery.Selection) {
 		portText := strings.TrimSpace(s.Text())
 		if portID, err := strconv.Atoi(portText); err == nil {
 			portNumbers = append(portNumbers, portID)
@@ -361,6 +480,15 @@ func ExtractGambitToken(content string) string {
 	return ""
 }
 
+// passwordChangeRegex matches the forced password change page (or a redirect to it)
+// that factory-default switches show instead of the dashboard after login
+var passwordChangeRegex = regexp.MustCompile(`(?i)change_?password|chgPassword|passwordChange|name=["']?newPassword|change\s+(the\s+)?default\s+password`)
+
+// IsPasswordChangeRequired reports whether a login response is the forced password change page
+func IsPasswordChangeRequired(content string) bool {
+	return passwordChangeRegex.MatchString(content)
+}
+
 // ExtractErrorMessage extracts error messages from response content
 func ExtractErrorMessage(content string) string {
 	// Look for common error patterns
@@ -397,6 +525,32 @@ func ExtractSeedValue(content string) string {
 	return ""
 }
 
+// recordPOEReading stores a voltage, current or power reading found in a status span
+func recordPOEReading(portData map[string]interface{}, text string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+
+	// Try to extract numeric values
+	if strings.Contains(text, "V") {
+		// Voltage
+		if val := extractNumericValue(text); val > 0 {
+			portData["voltage_v"] = val
+		}
+	} else if strings.Contains(text, "mA") {
+		// Current
+		if val := extractNumericValue(text); val > 0 {
+			portData["current_ma"] = val
+		}
+	} else if strings.Contains(text, "W") {
+		// Power
+		if val := extractNumericValue(text); val > 0 {
+			portData["power_w"] = val
+		}
+	}
+}
+
 // extractNumericValue extracts a numeric value from a string that may contain units
 func extractNumericValue(text string) float64 {
 	// Remove common units and non-numeric characters
@@ -411,4 +565,575 @@ func extractNumericValue(text string) float64 {
 	}
 	
 	return 0
-}
\ No newline at end of file
+}
+
+// ExtractSecurityHash extracts the CSRF security hash from a settings form page
+func ExtractSecurityHash(content string) string {
+	value, _ := ExtractSecurityHashField(content, []string{"hash"})
+	return value
+}
+
+// ExtractSecurityHashField looks for the CSRF security hash under each of the
+// candidate field names in order, returning the value and the name it was found under
+func ExtractSecurityHashField(content string, fieldNames []string) (string, string) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(content))
+	if err != nil {
+		return "", ""
+	}
+
+	for _, field := range fieldNames {
+		selector := fmt.Sprintf("input[name='%s'], input[id='%s']", field, field)
+		var securityHash string
+		doc.Find(selector).Each(func(i int, s *goquery.Selection) {
+			if value, exists := s.Attr("value"); exists && value != "" {
+				securityHash = value
+			}
+		})
+		if securityHash != "" {
+			return securityHash, field
+		}
+	}
+
+	return "", ""
+}
+
+// ParseFormValues extracts the current value of every named form control in the page.
+// Checkboxes and radio buttons report "1" when checked and "0" otherwise, selects
+// report the value of the selected option.
+func ParseFormValues(content string) (map[string]string, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	values := make(map[string]string)
+
+	doc.Find("input").Each(func(i int, input *goquery.Selection) {
+		name, _ := input.Attr("name")
+		if name == "" {
+			return
+		}
+
+		inputType := strings.ToLower(input.AttrOr("type", "text"))
+		_, checked := input.Attr("checked")
+		switch inputType {
+		case "checkbox":
+			if checked {
+				values[name] = "1"
+			} else {
+				values[name] = "0"
+			}
+		case "radio":
+			// Only the checked radio button of a group carries the value
+			if checked {
+				values[name] = input.AttrOr("value", "")
+			}
+		case "button", "submit", "reset":
+			return
+		default:
+			values[name] = input.AttrOr("value", "")
+		}
+	})
+
+	doc.Find("select").Each(func(i int, sel *goquery.Selection) {
+		name, _ := sel.Attr("name")
+		if name == "" {
+			return
+		}
+
+		option := sel.Find("option[selected]").First()
+		if option.Length() == 0 {
+			option = sel.Find("option").First()
+		}
+		values[name] = option.AttrOr("value", strings.TrimSpace(option.Text()))
+	})
+
+	return values, nil
+}
+
+// SelectOption is one choice of a select form control
+type SelectOption struct {
+	Value string
+	Label string
+}
+
+// ParseSelectOptions returns the options of every named select control in the page
+func ParseSelectOptions(content string) (map[string][]SelectOption, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	selects := make(map[string][]SelectOption)
+	doc.Find("select").Each(func(i int, sel *goquery.Selection) {
+		name, _ := sel.Attr("name")
+		if name == "" {
+			return
+		}
+		options := []SelectOption{}
+		sel.Find("option").Each(func(j int, option *goquery.Selection) {
+			label := strings.TrimSpace(option.Text())
+			options = append(options, SelectOption{Value: option.AttrOr("value", label), Label: label})
+		})
+		selects[name] = options
+	})
+
+	return selects, nil
+}
+
+// VLAN membership values reported by ParseVLANMembers
+const (
+	VLANMemberUntagged = "untagged"
+	VLANMemberTagged   = "tagged"
+)
+
+// ParseVLANIDs extracts the configured 802.1Q VLAN IDs from the VLAN configuration page
+func ParseVLANIDs(content string) ([]int, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	seen := make(map[int]bool)
+	var ids []int
+	doc.Find("input[name='VLAN_ID'], input[name='vlanId'], input[name='vlanck']").Each(func(i int, s *goquery.Selection) {
+		value := strings.TrimSpace(s.AttrOr("value", ""))
+		if id, err := strconv.Atoi(value); err == nil && id > 0 && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	})
+
+	return ids, nil
+}
+
+// ParseVLANMembers extracts the port membership of a single VLAN.
+// GS30x pages carry a "hiddenMem" string with one character per port
+// ('1' untagged, '2' tagged, '3' not a member), GS316 pages carry
+// "untagPorts"/"tagPorts" bitmaps with one '0'/'1' character per port.
+// It returns the members keyed by port ID and the number of ports on the switch.
+func ParseVLANMembers(content string) (map[int]string, int, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(content))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	members := make(map[int]string)
+
+	if mem, exists := doc.Find("input[name='hiddenMem'], input[id='hiddenMem']").First().Attr("value"); exists {
+		for i, c := range mem {
+			switch c {
+			case '1':
+				members[i+1] = VLANMemberUntagged
+			case '2':
+				members[i+1] = VLANMemberTagged
+			}
+		}
+		return members, len(mem), nil
+	}
+
+	untagged, hasUntagged := doc.Find("input[name='untagPorts']").First().Attr("value")
+	tagged, hasTagged := doc.Find("input[name='tagPorts']").First().Attr("value")
+	if !hasUntagged && !hasTagged {
+		return nil, 0, fmt.Errorf("VLAN membership not found in response")
+	}
+
+	untaggedPorts, err := BitmapToPorts(untagged)
+	if err != nil {
+		return nil, 0, err
+	}
+	taggedPorts, err := BitmapToPorts(tagged)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, port := range untaggedPorts {
+		members[port] = VLANMemberUntagged
+	}
+	for _, port := range taggedPorts {
+		members[port] = VLANMemberTagged
+	}
+
+	portCount := len(untagged)
+	if len(tagged) > portCount {
+		portCount = len(tagged)
+	}
+	return members, portCount, nil
+}
+
+// ParsePortPVIDs extracts the port VLAN ID (PVID) of every port from the PVID page
+func ParsePortPVIDs(content string) (map[int]int, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	pvids := make(map[int]int)
+	doc.Find("table tr").Each(func(i int, row *goquery.Selection) {
+		cells := row.Find("td")
+		if cells.Length() < 2 {
+			return // Header or layout row
+		}
+
+		portID, err := strconv.Atoi(strings.TrimSpace(cells.Eq(0).Text()))
+		if err != nil {
+			return
+		}
+		pvid, err := strconv.Atoi(strings.TrimSpace(cells.Eq(1).Text()))
+		if err != nil {
+			return
+		}
+		pvids[portID] = pvid
+	})
+
+	return pvids, nil
+}
+
+// systemInfoLabels maps dashboard labels (lower case) to the keys returned by ParseSystemInfo
+var systemInfoLabels = map[string]string{
+	"product name":       "product_name",
+	"model name":         "product_name",
+	"switch name":        "device_name",
+	"device name":        "device_name",
+	"system name":        "device_name",
+	"serial number":      "serial_number",
+	"mac address":        "mac_address",
+	"base mac address":   "mac_address",
+	"ip address":         "ip_address",
+	"subnet mask":        "subnet_mask",
+	"gateway address":    "gateway",
+	"default gateway":    "gateway",
+	"firmware version":   "firmware",
+	"software version":   "firmware",
+	"firmware":           "firmware",
+	"system up time":     "uptime",
+	"system uptime":      "uptime",
+	"up time":            "uptime",
+	"dhcp mode":          "dhcp",
+	"dhcp":               "dhcp",
+	"management vlan":    "management_vlan",
+	"management vlan id": "management_vlan",
+	"mgmt vlan":          "management_vlan",
+}
+
+// ParseSystemInfo extracts label/value pairs describing the switch from its dashboard page
+func ParseSystemInfo(content string) (map[string]string, error) {
+	return parseLabeledValues(content, systemInfoLabels)
+}
+
+// poeOverviewLabels maps POE overview labels (lower case) to the keys returned by ParsePOEOverview
+var poeOverviewLabels = map[string]string{
+	"power budget":            "total",
+	"total power budget":      "total",
+	"total power":             "total",
+	"total power (w)":         "total",
+	"nominal power":           "total",
+	"nominal power (w)":       "total",
+	"consumed power":          "consumed",
+	"consumed power (w)":      "consumed",
+	"power consumption":       "consumed",
+	"total power consumption": "consumed",
+	"available power":         "remaining",
+	"available power (w)":     "remaining",
+	"remaining power":         "remaining",
+	"power threshold":         "threshold",
+	"threshold power":         "threshold",
+	"threshold power (w)":     "threshold",
+	"usage threshold":         "threshold",
+}
+
+// poeOverviewFields maps hidden input names (lower case) carrying the overview to the same keys
+var poeOverviewFields = map[string]string{
+	"pwrbudget":      "total",
+	"totalpower":     "total",
+	"pwrconsumption": "consumed",
+	"consumedpower":  "consumed",
+	"pwrthreshold":   "threshold",
+}
+
+// wattsRegex matches the number in a reading such as "123.0 W" or "61W"
+var wattsRegex = regexp.MustCompile(`\d+(?:\.\d+)?`)
+
+// ParsePOEOverview extracts the switch-wide POE readings in watts from the
+// POE overview page: "total", "consumed", "remaining" and "threshold", each
+// only when the page shows it
+func ParsePOEOverview(content string) (map[string]float64, error) {
+	labeled, err := parseLabeledValues(content, poeOverviewLabels)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := ParseFormValues(content)
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range fields {
+		if key, ok := poeOverviewFields[strings.ToLower(name)]; ok {
+			if _, exists := labeled[key]; !exists {
+				labeled[key] = value
+			}
+		}
+	}
+
+	readings := make(map[string]float64)
+	for key, value := range labeled {
+		if number := wattsRegex.FindString(value); number != "" {
+			readings[key], _ = strconv.ParseFloat(number, 64)
+		}
+	}
+	return readings, nil
+}
+
+// parseLabeledValues extracts the values next to the given labels from table
+// rows and lists, returning them under the labels' keys
+func parseLabeledValues(content string, labels map[string]string) (map[string]string, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	info := make(map[string]string)
+	record := func(label, value string) {
+		label = strings.ToLower(strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(label), ":")))
+		value = strings.TrimSpace(value)
+		if key, ok := labels[label]; ok && value != "" {
+			if _, exists := info[key]; !exists {
+				info[key] = value
+			}
+		}
+	}
+
+	// Table layout: <tr><td>Label</td><td>Value</td></tr>
+	doc.Find("tr").Each(func(i int, row *goquery.Selection) {
+		cells := row.Find("td, th")
+		if cells.Length() >= 2 {
+			record(cells.Eq(0).Text(), cells.Eq(1).Text())
+		}
+	})
+
+	// List layout: <li><span>Label</span><span>Value</span></li>
+	doc.Find("li").Each(func(i int, item *goquery.Selection) {
+		spans := item.ChildrenFiltered("span")
+		if spans.Length() >= 2 {
+			record(spans.Eq(0).Text(), spans.Eq(1).Text())
+		}
+	})
+
+	return info, nil
+}
+
+var (
+	uptimeClockRegex = regexp.MustCompile(`(\d+):(\d{1,2}):(\d{1,2})`)
+	uptimeUnitRegex  = regexp.MustCompile(`(?i)(\d+)\s*(days?|d|hours?|hrs?|h|minutes?|mins?|m|seconds?|secs?|s)\b`)
+)
+
+// ParseUptime converts a switch uptime string such as "3 days 04:05:06",
+// "3 Days 4 Hours 5 Mins 6 Secs" or "3d 4h 5m 6s" into a duration
+func ParseUptime(uptime string) (time.Duration, error) {
+	var total time.Duration
+	found := false
+
+	rest := uptime
+	if match := uptimeClockRegex.FindStringSubmatchIndex(uptime); match != nil {
+		h, _ := strconv.Atoi(uptime[match[2]:match[3]])
+		m, _ := strconv.Atoi(uptime[match[4]:match[5]])
+		s, _ := strconv.Atoi(uptime[match[6]:match[7]])
+		total += time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s)*time.Second
+		rest = uptime[:match[0]] + " " + uptime[match[1]:]
+		found = true
+	}
+
+	for _, match := range uptimeUnitRegex.FindAllStringSubmatch(rest, -1) {
+		value, _ := strconv.Atoi(match[1])
+		switch unit := strings.ToLower(match[2]); {
+		case strings.HasPrefix(unit, "d"):
+			total += time.Duration(value) * 24 * time.Hour
+		case strings.HasPrefix(unit, "h"):
+			total += time.Duration(value) * time.Hour
+		case strings.HasPrefix(unit, "m"):
+			total += time.Duration(value) * time.Minute
+		default:
+			total += time.Duration(value) * time.Second
+		}
+		found = true
+	}
+
+	if !found {
+		return 0, fmt.Errorf("unrecognized uptime format %q", uptime)
+	}
+	return total, nil
+}
+
+// attachedDeviceColumns maps attached-device table headers (lower case) to the keys returned by ParseAttachedDevices
+var attachedDeviceColumns = map[string]string{
+	"port":        "port",
+	"port id":     "port",
+	"interface":   "port",
+	"mac":         "mac",
+	"mac address": "mac",
+	"ip":          "ip",
+	"ip address":  "ip",
+	"vlan":        "vlan",
+	"vlan id":     "vlan",
+	"name":        "name",
+	"device name": "name",
+	"host name":   "name",
+	"hostname":    "name",
+}
+
+// ParseAttachedDevices extracts the rows of the attached devices table. Columns
+// are identified by their header text since firmware versions order them
+// differently. Rows without a MAC address are skipped.
+func ParseAttachedDevices(content string) ([]map[string]string, error) {
+	rows, found, err := parseTableByHeader(content, attachedDeviceColumns, "mac")
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("attached devices table not found in response")
+	}
+	return rows, nil
+}
+
+// macTableColumns maps MAC address table headers (lower case) to the keys returned by ParseMACTable
+var macTableColumns = map[string]string{
+	"mac":         "mac",
+	"mac address": "mac",
+	"vlan":        "vlan",
+	"vlan id":     "vlan",
+	"port":        "port",
+	"port id":     "port",
+	"interface":   "port",
+	"type":        "type",
+	"status":      "type",
+}
+
+// ParseMACTable extracts the rows of the learned MAC address table, keyed
+// "mac", "vlan", "port" and "type". Like ParseAttachedDevices, columns are
+// found by their header text and rows without a MAC address are skipped.
+func ParseMACTable(content string) ([]map[string]string, error) {
+	rows, found, err := parseTableByHeader(content, macTableColumns, "mac")
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("MAC address table not found in response")
+	}
+	return rows, nil
+}
+
+// portStatisticsColumns maps port statistics headers (lower case) to the keys returned by ParsePortStatistics
+var portStatisticsColumns = map[string]string{
+	"port":              "port",
+	"port id":           "port",
+	"interface":         "port",
+	"bytes received":    "rx_bytes",
+	"received bytes":    "rx_bytes",
+	"rx bytes":          "rx_bytes",
+	"bytes sent":        "tx_bytes",
+	"sent bytes":        "tx_bytes",
+	"tx bytes":          "tx_bytes",
+	"packets received":  "rx_packets",
+	"received packets":  "rx_packets",
+	"rx packets":        "rx_packets",
+	"packets sent":      "tx_packets",
+	"sent packets":      "tx_packets",
+	"tx packets":        "tx_packets",
+	"crc error packets": "crc_errors",
+	"crc errors":        "crc_errors",
+	"crc error":         "crc_errors",
+	"rx errors":         "rx_errors",
+	"receive errors":    "rx_errors",
+	"tx errors":         "tx_errors",
+	"transmit errors":   "tx_errors",
+}
+
+// ParsePortStatistics extracts the rows of the port statistics table, keyed
+// "port", "rx_bytes", "tx_bytes", "rx_packets", "tx_packets", "crc_errors",
+// "rx_errors" and "tx_errors" as far as the firmware shows them. Rows without
+// a port are skipped.
+func ParsePortStatistics(content string) ([]map[string]string, error) {
+	rows, found, err := parseTableByHeader(content, portStatisticsColumns, "port")
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("port statistics table not found in response")
+	}
+	return rows, nil
+}
+
+// lldpNeighborColumns maps LLDP neighbor table headers (lower case) to the keys returned by ParseLLDPNeighbors
+var lldpNeighborColumns = map[string]string{
+	"port":               "port",
+	"local port":         "port",
+	"interface":          "port",
+	"local interface":    "port",
+	"chassis id":         "chassis_id",
+	"remote chassis id":  "chassis_id",
+	"port id":            "remote_port",
+	"remote port id":     "remote_port",
+	"remote port":        "remote_port",
+	"port description":   "port_description",
+	"system name":        "system_name",
+	"remote system name": "system_name",
+	"management address": "management_address",
+	"management ip":      "management_address",
+}
+
+// ParseLLDPNeighbors extracts the rows of the LLDP remote device table, keyed
+// "port", "chassis_id", "remote_port", "port_description", "system_name" and
+// "management_address". Rows without a local port are skipped.
+func ParseLLDPNeighbors(content string) ([]map[string]string, error) {
+	rows, found, err := parseTableByHeader(content, lldpNeighborColumns, "port")
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("LLDP neighbor table not found in response")
+	}
+	return rows, nil
+}
+
+// parseTableByHeader returns the rows of every table whose header row names
+// the key column, keyed by the columns' mapped names. Rows without a value in
+// the key column are skipped. found reports whether any such table exists, so
+// an empty table can be told from a missing one.
+func parseTableByHeader(content string, columnKeys map[string]string, keyColumn string) (rows []map[string]string, found bool, err error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(content))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	doc.Find("table").Each(func(i int, table *goquery.Selection) {
+		var columns []string
+		table.Find("tr").Each(func(j int, row *goquery.Selection) {
+			cells := row.Find("td, th")
+			if columns == nil {
+				// The first row naming the key column is the header
+				var header []string
+				hasKey := false
+				cells.Each(func(k int, cell *goquery.Selection) {
+					key := columnKeys[strings.ToLower(strings.TrimSpace(cell.Text()))]
+					header = append(header, key)
+					hasKey = hasKey || key == keyColumn
+				})
+				if hasKey {
+					columns = header
+					found = true
+				}
+				return
+			}
+
+			values := make(map[string]string)
+			cells.Each(func(k int, cell *goquery.Selection) {
+				if k < len(columns) && columns[k] != "" {
+					values[columns[k]] = strings.TrimSpace(cell.Text())
+				}
+			})
+			if values[keyColumn] != "" {
+				rows = append(rows, values)
+			}
+		})
+	})
+
+	return rows, found, nil
+}
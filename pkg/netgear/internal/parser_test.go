@@ -0,0 +1,284 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseFormValues(t *testing.T) {
+	html := `<form>
+		<input type="hidden" name="hash" value="abc123">
+		<input type="checkbox" name="auto_dos" checked>
+		<input type="checkbox" name="tcp_frag">
+		<input type="radio" name="mode" value="static">
+		<input type="radio" name="mode" value="dhcp" checked>
+		<input type="text" name="name" value="lab-switch">
+		<input type="submit" name="apply" value="Apply">
+		<select name="speed">
+			<option value="1">Auto</option>
+			<option value="3" selected>100M full</option>
+		</select>
+	</form>`
+
+	values, err := ParseFormValues(html)
+	if err != nil {
+		t.Fatalf("ParseFormValues returned error: %v", err)
+	}
+
+	expected := map[string]string{
+		"hash":     "abc123",
+		"auto_dos": "1",
+		"tcp_frag": "0",
+		"mode":     "dhcp",
+		"name":     "lab-switch",
+		"speed":    "3",
+	}
+	for name, want := range expected {
+		if got := values[name]; got != want {
+			t.Errorf("field %s: expected %q, got %q", name, want, got)
+		}
+	}
+
+	if _, ok := values["apply"]; ok {
+		t.Error("submit buttons should not be reported as form values")
+	}
+}
+
+func TestExtractSecurityHash(t *testing.T) {
+	if hash := ExtractSecurityHash(`<input type="hidden" id="hash" value="f00d">`); hash != "f00d" {
+		t.Errorf("expected hash f00d, got %q", hash)
+	}
+	if hash := ExtractSecurityHash(`<html></html>`); hash != "" {
+		t.Errorf("expected empty hash, got %q", hash)
+	}
+}
+
+func TestDetectFromHTMLIgnoresCaptivePortals(t *testing.T) {
+	detector := NewModelDetector("GS308EP", "GS308EPP")
+
+	portal := `<html><head><title>Guest Wi-Fi</title></head>
+		<body><script>window.location = "https://portal.example.com/redirect?to=login"</script></body></html>`
+	if model := detector.DetectFromHTML(portal); model != "" {
+		t.Errorf("expected no model for captive portal page, got %q", model)
+	}
+	if HasNetgearMarkers(portal) {
+		t.Error("captive portal page should not carry Netgear markers")
+	}
+
+	redirect := `<html><head><title>Redirect to Login</title></head>
+		<body><script>top.location.href = "/login.cgi";</script></body></html>`
+	if model := detector.DetectFromHTML(redirect); model != "GS30xEPx" {
+		t.Errorf("expected GS30xEPx for switch redirect page, got %q", model)
+	}
+
+	smartPro := `<script>top.location.href = "/base/main_login.html";</script>`
+	if model := detector.DetectFromHTML(smartPro); model != "" || !HasNetgearMarkers(smartPro) {
+		t.Errorf("expected smart managed pro redirect to carry markers but no model, got %q", model)
+	}
+
+	if model := detector.DetectFromHTML(`<title>GS308EPP</title>`); model != "GS308EPP" {
+		t.Errorf("expected the most specific model GS308EPP, got %q", model)
+	}
+}
+
+func TestParseSystemInfo(t *testing.T) {
+	html := `<table>
+		<tr><td>Product Name</td><td>GS308EPP</td></tr>
+		<tr><td>Firmware Version:</td><td> V1.0.1.4 </td></tr>
+		<tr><td>Serial Number</td><td>6LX1234567</td></tr>
+	</table>
+	<ul><li><span>MAC Address</span><span>38:94:ED:00:11:22</span></li></ul>`
+
+	info, err := ParseSystemInfo(html)
+	if err != nil {
+		t.Fatalf("ParseSystemInfo returned error: %v", err)
+	}
+
+	expected := map[string]string{
+		"product_name":  "GS308EPP",
+		"firmware":      "V1.0.1.4",
+		"serial_number": "6LX1234567",
+		"mac_address":   "38:94:ED:00:11:22",
+	}
+	for key, want := range expected {
+		if got := info[key]; got != want {
+			t.Errorf("%s: expected %q, got %q", key, want, got)
+		}
+	}
+}
+
+func TestParsePOEOverview(t *testing.T) {
+	html := `<table>
+		<tr><td>Power Budget</td><td>123.0 W</td></tr>
+		<tr><td>Consumed Power:</td><td>41.5W</td></tr>
+		<tr><td>Available Power</td><td>81.5 W</td></tr>
+	</table>
+	<input type="hidden" name="pwrThreshold" value="110">`
+
+	readings, err := ParsePOEOverview(html)
+	if err != nil {
+		t.Fatalf("ParsePOEOverview returned error: %v", err)
+	}
+
+	expected := map[string]float64{"total": 123, "consumed": 41.5, "remaining": 81.5, "threshold": 110}
+	for key, want := range expected {
+		if got, ok := readings[key]; !ok || got != want {
+			t.Errorf("%s: expected %v, got %v", key, want, got)
+		}
+	}
+}
+
+func TestParsePortStatistics(t *testing.T) {
+	html := `<table>
+		<tr><td>Port</td><td>Rx Bytes</td><td>Tx Bytes</td><td>CRC Errors</td></tr>
+		<tr><td>1</td><td>1024</td><td>2048</td><td>0</td></tr>
+		<tr><td></td><td>Total</td><td></td><td></td></tr>
+	</table>`
+
+	rows, err := ParsePortStatistics(html)
+	if err != nil {
+		t.Fatalf("ParsePortStatistics returned error: %v", err)
+	}
+	if len(rows) != 1 || rows[0]["port"] != "1" || rows[0]["rx_bytes"] != "1024" ||
+		rows[0]["tx_bytes"] != "2048" || rows[0]["crc_errors"] != "0" {
+		t.Errorf("unexpected rows %v", rows)
+	}
+
+	if _, err := ParsePortStatistics("<table><tr><td>MAC</td></tr></table>"); err == nil {
+		t.Error("expected an error for a page without the statistics table")
+	}
+}
+
+func TestParseUptime(t *testing.T) {
+	want := 3*24*time.Hour + 4*time.Hour + 5*time.Minute + 6*time.Second
+	for _, input := range []string{"3 days 04:05:06", "3 Days 4 Hours 5 Mins 6 Secs", "3d 4h 5m 6s"} {
+		got, err := ParseUptime(input)
+		if err != nil {
+			t.Errorf("ParseUptime(%q) failed: %v", input, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("ParseUptime(%q) = %v, want %v", input, got, want)
+		}
+	}
+
+	if _, err := ParseUptime("unknown"); err == nil {
+		t.Error("Expected error for unrecognized uptime")
+	}
+}
+
+func TestGuessModel(t *testing.T) {
+	cases := map[string]string{
+		`<title>NETGEAR GS108LPv2</title>`: "GS108LPv2",
+		`<div>Model: GS305PP</div>`:        "GS305PP",
+		`<html>NETGEAR router</html>`:      "",
+	}
+	for content, want := range cases {
+		if got := GuessModel(content); got != want {
+			t.Errorf("GuessModel(%q) = %q, want %q", content, got, want)
+		}
+	}
+}
+
+func TestParseAttachedDevices(t *testing.T) {
+	html := `<table>
+		<tr><th>MAC Address</th><th>Port</th><th>IP Address</th><th>VLAN ID</th></tr>
+		<tr><td>AA:BB:CC:00:11:22</td><td>3</td><td>192.168.1.50</td><td>1</td></tr>
+		<tr><td></td><td>4</td><td></td><td></td></tr>
+		<tr><td>aa-bb-cc-00-11-33</td><td>5</td><td>-</td><td>10</td></tr>
+	</table>`
+
+	rows, err := ParseAttachedDevices(html)
+	if err != nil {
+		t.Fatalf("ParseAttachedDevices returned error: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 devices, got %v", rows)
+	}
+	if rows[0]["port"] != "3" || rows[0]["ip"] != "192.168.1.50" || rows[1]["vlan"] != "10" {
+		t.Errorf("unexpected rows: %v", rows)
+	}
+
+	if _, err := ParseAttachedDevices("<table><tr><td>Port</td></tr></table>"); err == nil {
+		t.Error("expected error when no attached devices table is present")
+	}
+}
+
+func TestParseMACTable(t *testing.T) {
+	html := `<table>
+		<tr><th>VLAN ID</th><th>MAC Address</th><th>Port</th><th>Type</th></tr>
+		<tr><td>1</td><td>AA:BB:CC:00:11:22</td><td>3</td><td>Dynamic</td></tr>
+		<tr><td>10</td><td>aa-bb-cc-00-11-33</td><td>8</td><td>Static</td></tr>
+	</table>`
+
+	rows, err := ParseMACTable(html)
+	if err != nil {
+		t.Fatalf("ParseMACTable returned error: %v", err)
+	}
+	if len(rows) != 2 || rows[0]["port"] != "3" || rows[1]["vlan"] != "10" || rows[1]["type"] != "Static" {
+		t.Errorf("unexpected rows: %v", rows)
+	}
+
+	rows, err = ParseMACTable(`<table><tr><th>MAC Address</th><th>Port</th></tr></table>`)
+	if err != nil || len(rows) != 0 {
+		t.Errorf("expected an empty table, got %v, %v", rows, err)
+	}
+	if _, err := ParseMACTable("<html></html>"); err == nil {
+		t.Error("expected error when no MAC table is present")
+	}
+}
+
+func TestParsePOEPagesInEachLayout(t *testing.T) {
+	for _, layout := range []Layout{LayoutClassic, LayoutRevised} {
+		t.Run(layout.String(), func(t *testing.T) {
+			status, err := os.ReadFile(filepath.Join("testdata", "poe_status_"+layout.String()+".html"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := DetectLayout(string(status)); got != layout {
+				t.Errorf("expected %s layout, detected %s", layout, got)
+			}
+
+			ports, err := NewPOEDataParser().ParsePOEStatus(string(status))
+			if err != nil {
+				t.Fatalf("ParsePOEStatus returned error: %v", err)
+			}
+			expected := []map[string]interface{}{
+				{"port_id": 1, "port_name": "1", "status": "Delivering Power", "power_class": "Class 4",
+					"voltage_v": 53.2, "current_ma": 105.0, "power_w": 5.6},
+				{"port_id": 2, "port_name": "2", "status": "Searching", "power_class": "Unknown"},
+			}
+			if !reflect.DeepEqual(ports, expected) {
+				t.Errorf("unexpected POE status\n got: %v\nwant: %v", ports, expected)
+			}
+
+			config, err := os.ReadFile(filepath.Join("testdata", "poe_config_"+layout.String()+".html"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := DetectLayout(string(config)); got != layout {
+				t.Errorf("expected %s layout for the configuration page, detected %s", layout, got)
+			}
+			settings, err := NewPOEDataParser().ParsePOESettings(string(config))
+			if err != nil {
+				t.Fatalf("ParsePOESettings returned error: %v", err)
+			}
+			var portIDs []int
+			for _, setting := range settings {
+				if portID, ok := setting["port_id"].(int); ok {
+					portIDs = append(portIDs, portID)
+				}
+			}
+			if !reflect.DeepEqual(portIDs, []int{1, 2}) {
+				t.Errorf("expected ports 1 and 2 on the configuration page, got %v", portIDs)
+			}
+		})
+	}
+
+	if got := DetectLayout(`<table><tr><td>1</td></tr></table>`); got != LayoutUnknown {
+		t.Errorf("expected unknown layout for a plain table, got %s", got)
+	}
+}
@@ -0,0 +1,83 @@
+package netgear
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// The JSON field names are an API: jq filters and dashboards depend on them.
+// Renaming, reordering or dropping a field here must come with a changelog
+// entry and an update of docs/json-output.md.
+func TestJSONFieldNames(t *testing.T) {
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name  string
+		value any
+		want  string
+	}{
+		{"POEPortStatus", POEPortStatus{PortID: 1, PortName: "cam", Status: "Delivering Power", PowerClass: "4", VoltageV: 53.5, CurrentMA: 84, PowerW: 4.5, TemperatureC: 31, ErrorStatus: "No Error"},
+			`{"port_id":1,"port_name":"cam","status":"Delivering Power","power_class":"4","voltage_v":53.5,"current_ma":84,"power_w":4.5,"temperature_c":31,"error_status":"No Error"}`},
+		{"POEPortSettings", POEPortSettings{PortID: 1, PortName: "cam", Enabled: true, Mode: POEMode8023at, Priority: POEPriorityLow, PowerLimitType: POELimitTypeClass, PowerLimitW: 30, DetectionType: "IEEE 802", LongerDetectionTime: false},
+			`{"port_id":1,"port_name":"cam","enabled":true,"mode":"802.3at","priority":"low","power_limit_type":"class","power_limit_w":30,"detection_type":"IEEE 802","longer_detection_time":false}`},
+		{"PortSettings", PortSettings{PortID: 2, PortName: "uplink", Speed: PortSpeedAuto, IngressLimit: "No Limit", EgressLimit: "No Limit", FlowControl: true, Status: PortStatusConnected, LinkSpeed: "1000M", PVID: 10, UntaggedVLANs: []int{10}, TaggedVLANs: []int{20}},
+			`{"port_id":2,"port_name":"uplink","speed":"auto","ingress_limit":"No Limit","egress_limit":"No Limit","flow_control":true,"status":"connected","link_speed":"1000M","pvid":10,"untagged_vlans":[10],"tagged_vlans":[20]}`},
+		{"PortSettings without VLANs", PortSettings{PortID: 2},
+			`{"port_id":2,"port_name":"","speed":"","ingress_limit":"","egress_limit":"","flow_control":false,"status":"","link_speed":""}`},
+		{"SwitchState", SwitchState{Address: "10.0.0.1", Model: ModelGS308EPP, FetchedAt: at, System: &SystemInfo{Model: ModelGS308EPP, DeviceName: "lab"}, POEStatus: []POEPortStatus{{PortID: 1}}, VLANs: []VLAN{{ID: 1, Members: map[int]VLANMembership{1: VLANMemberUntagged, 2: VLANMemberTagged}}}},
+			`{"address":"10.0.0.1","model":"GS308EPP","fetched_at":"2026-01-02T03:04:05Z","system":{"model":"GS308EPP","device_name":"lab"},"poe_status":[{"port_id":1,"port_name":"","status":"","power_class":"","voltage_v":0,"current_ma":0,"power_w":0,"temperature_c":0,"error_status":""}],"vlans":[{"id":1,"members":{"1":"untagged","2":"tagged"}}]}`},
+		{"SystemInfo with clock skew", SystemInfo{Model: ModelGS308EPP, SkewEstimate: &SkewEstimate{Offset: -1500 * time.Millisecond, Uncertainty: 500 * time.Millisecond, MeasuredAt: at}},
+			`{"model":"GS308EPP","skew_estimate":{"offset":-1500000000,"uncertainty":500000000,"measured_at":"2026-01-02T03:04:05Z"}}`},
+		{"HistoryEntry", HistoryEntry{Time: at, Op: "GET", Target: "/getPoePortStatus.cgi", Duration: time.Second, Error: "timeout"},
+			`{"time":"2026-01-02T03:04:05Z","op":"GET","target":"/getPoePortStatus.cgi","duration":1000000000,"error":"timeout"}`},
+	}
+
+	for _, tt := range tests {
+		data, err := json.Marshal(tt.value)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if string(data) != tt.want {
+			t.Errorf("%s JSON changed:\n got %s\nwant %s", tt.name, data, tt.want)
+		}
+	}
+}
+
+func TestPortListsSortedByPort(t *testing.T) {
+	// Firmware pages are not guaranteed to list ports in order
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/getPoePortStatus.cgi" {
+			fmt.Fprint(w, "<html></html>")
+			return
+		}
+		fmt.Fprint(w, "<ul>")
+		for _, port := range []int{3, 1, 2} {
+			fmt.Fprintf(w, `<li class="poePortStatusListItem"><input type="hidden" class="port" value="%d"><span class="poe-power-mode"><span>Delivering Power</span></span></li>`, port)
+		}
+		fmt.Fprint(w, "</ul>")
+	}))
+	defer server.Close()
+	address := strings.TrimPrefix(server.URL, "http://")
+
+	client, err := NewClient(address, factoryClientOptions(address)...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	statuses, err := client.POE().GetStatus(context.Background())
+	if err != nil {
+		t.Fatalf("GetStatus failed: %v", err)
+	}
+
+	var ports []int
+	for _, status := range statuses {
+		ports = append(ports, status.PortID)
+	}
+	if fmt.Sprint(ports) != "[1 2 3]" {
+		t.Errorf("expected ports in order, got %v", ports)
+	}
+}
@@ -0,0 +1,176 @@
+package netgear
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gherlein/go-netgear/pkg/redact"
+)
+
+// WithLogger makes the client log to l: one debug record per HTTP request
+// with its method, redacted URL, status, duration, bytes and the switch
+// model, plus the client's own debug messages and warnings. Without it the
+// client logs nothing, unless WithVerbose is set.
+func WithLogger(l *slog.Logger) ClientOption {
+	return func(c *Client) {
+		if l == nil {
+			l = discardLogger
+		}
+		c.logger = l
+	}
+}
+
+// verboseLogger is the logger WithVerbose(true) installs: debug records as
+// text on standard output, where verbose output always went
+var verboseLogger = slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+// discardLogger drops every record
+var discardLogger = slog.New(discardHandler{})
+
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
+
+// Logger returns the logger the client writes to
+func (c *Client) Logger() *slog.Logger {
+	return c.logger
+}
+
+// RequestInfo describes an HTTP request to the switch
+type RequestInfo struct {
+	Method string
+	// URL is the request URL with session tokens redacted
+	URL   string
+	Model Model
+	// Header holds the outgoing headers; request hooks may add to it, e.g. to
+	// propagate a trace context
+	Header http.Header
+	Start  time.Time
+}
+
+// ResponseInfo describes the outcome of an HTTP request to the switch
+type ResponseInfo struct {
+	Request RequestInfo
+	// Status is the HTTP status, 0 if no response arrived
+	Status int
+	// Duration runs until the response body was read or closed
+	Duration time.Duration
+	// Bytes is the size of the response body read
+	Bytes int64
+	Err   error
+}
+
+// RequestHook is called before each HTTP request to the switch. The context
+// it returns is used for the request and passed to the response hooks, so a
+// tracer can start a span here and end it in a ResponseHook.
+type RequestHook func(ctx context.Context, req *RequestInfo) context.Context
+
+// ResponseHook is called once the response to a request has been read, or
+// the request failed
+type ResponseHook func(ctx context.Context, resp ResponseInfo)
+
+// WithRequestHook adds a hook called before every HTTP request the client
+// sends, including logins. Hooks run in the order they were added.
+func WithRequestHook(hook RequestHook) ClientOption {
+	return func(c *Client) {
+		c.requestHooks = append(c.requestHooks, hook)
+	}
+}
+
+// WithResponseHook adds a hook called after every HTTP request the client
+// sends, including logins. Hooks run in the order they were added.
+func WithResponseHook(hook ResponseHook) ClientOption {
+	return func(c *Client) {
+		c.responseHooks = append(c.responseHooks, hook)
+	}
+}
+
+// observeTransport logs every request and runs the client's hooks around it
+type observeTransport struct {
+	next   http.RoundTripper
+	client *Client
+}
+
+func (t *observeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c := t.client
+	ctx := req.Context()
+	info := RequestInfo{
+		Method: req.Method,
+		URL:    redact.String(req.URL.String()),
+		Model:  c.model,
+		Header: req.Header.Clone(),
+		Start:  c.clock.Now(),
+	}
+	for _, hook := range c.requestHooks {
+		ctx = hook(ctx, &info)
+	}
+	req = req.WithContext(ctx)
+	req.Header = info.Header
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		t.finish(ctx, ResponseInfo{Request: info, Err: err})
+		return nil, err
+	}
+	resp.Body = &observedBody{ReadCloser: resp.Body, done: func(n int64, readErr error) {
+		t.finish(ctx, ResponseInfo{Request: info, Status: resp.StatusCode, Bytes: n, Err: readErr})
+	}}
+	return resp, nil
+}
+
+// finish logs a completed request and runs the response hooks
+func (t *observeTransport) finish(ctx context.Context, resp ResponseInfo) {
+	c := t.client
+	resp.Duration = c.clock.Now().Sub(resp.Request.Start)
+
+	attrs := []slog.Attr{
+		slog.String("method", resp.Request.Method),
+		slog.String("url", resp.Request.URL),
+		slog.Int("status", resp.Status),
+		slog.Duration("duration", resp.Duration),
+		slog.Int64("bytes", resp.Bytes),
+		slog.String("model", string(resp.Request.Model)),
+	}
+	if resp.Err != nil {
+		attrs = append(attrs, slog.String("error", redact.String(resp.Err.Error())))
+	}
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "switch request", attrs...)
+
+	for _, hook := range c.responseHooks {
+		hook(ctx, resp)
+	}
+}
+
+// observedBody counts the bytes read from a response body and reports them
+// once, at the end of the body or when it is closed
+type observedBody struct {
+	io.ReadCloser
+	n    int64
+	once sync.Once
+	done func(n int64, err error)
+}
+
+func (b *observedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n += int64(n)
+	if err == io.EOF {
+		b.once.Do(func() { b.done(b.n, nil) })
+	} else if err != nil {
+		b.once.Do(func() { b.done(b.n, err) })
+	}
+	return n, err
+}
+
+func (b *observedBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() { b.done(b.n, nil) })
+	return err
+}
@@ -0,0 +1,105 @@
+package netgear
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWithLoggerAndHooks(t *testing.T) {
+	const page = "<html>dashboard</html>"
+	var traceHeader string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceHeader = r.Header.Get("Traceparent")
+		fmt.Fprint(w, page)
+	}))
+	defer server.Close()
+	address := strings.TrimPrefix(server.URL, "http://")
+
+	type spanKey struct{}
+	var logs bytes.Buffer
+	var responses []ResponseInfo
+	var spans []string
+	client, err := NewClient(address, append(factoryClientOptions(address),
+		WithLogger(slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))),
+		WithRequestHook(func(ctx context.Context, req *RequestInfo) context.Context {
+			req.Header.Set("Traceparent", "00-trace-span-01")
+			return context.WithValue(ctx, spanKey{}, req.Method+" "+req.URL)
+		}),
+		WithResponseHook(func(ctx context.Context, resp ResponseInfo) {
+			responses = append(responses, resp)
+			spans = append(spans, ctx.Value(spanKey{}).(string))
+		}))...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	if _, err := client.makeAuthenticatedRequest(context.Background(), "GET", "/dashboard.cgi", nil); err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+
+	if traceHeader != "00-trace-span-01" {
+		t.Errorf("expected the request hook's header to be sent, got %q", traceHeader)
+	}
+	if len(responses) != 1 || responses[0].Status != http.StatusOK || responses[0].Bytes != int64(len(page)) ||
+		responses[0].Request.Model != ModelGS308EPP || responses[0].Err != nil {
+		t.Fatalf("unexpected responses %+v", responses)
+	}
+	if want := "GET " + server.URL + "/dashboard.cgi"; len(spans) != 1 || spans[0] != want {
+		t.Errorf("expected the request hook's context in the response hook, got %v", spans)
+	}
+
+	var record struct {
+		Msg    string `json:"msg"`
+		Method string `json:"method"`
+		URL    string `json:"url"`
+		Status int    `json:"status"`
+		Bytes  int    `json:"bytes"`
+		Model  string `json:"model"`
+	}
+	for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+		json.Unmarshal([]byte(line), &record)
+		if record.Msg == "switch request" {
+			break
+		}
+	}
+	if record.Msg != "switch request" || record.Method != "GET" || record.URL != server.URL+"/dashboard.cgi" ||
+		record.Status != http.StatusOK || record.Bytes != len(page) || record.Model != string(ModelGS308EPP) {
+		t.Errorf("unexpected request log record %+v in:\n%s", record, logs.String())
+	}
+}
+
+func TestResponseHookOnNetworkError(t *testing.T) {
+	var responses []ResponseInfo
+	client, err := NewClient("127.0.0.1:1", append(factoryClientOptions("127.0.0.1:1"),
+		WithResponseHook(func(ctx context.Context, resp ResponseInfo) { responses = append(responses, resp) }))...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	if _, err := client.makeAuthenticatedRequest(context.Background(), "GET", "/dashboard.cgi", nil); err == nil {
+		t.Fatal("expected the request to fail")
+	}
+	if len(responses) != 1 || responses[0].Status != 0 || responses[0].Err == nil {
+		t.Errorf("expected one failed response, got %+v", responses)
+	}
+}
+
+func TestWithVerboseLogsToStdoutUnlessLoggerSet(t *testing.T) {
+	client, _ := NewClient("192.0.2.1", append(factoryClientOptions("192.0.2.1"), WithVerbose(true))...)
+	if client.Logger() != verboseLogger {
+		t.Error("expected WithVerbose to install the verbose logger")
+	}
+
+	logger := slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+	client, _ = NewClient("192.0.2.1", append(factoryClientOptions("192.0.2.1"), WithLogger(logger), WithVerbose(true))...)
+	if client.Logger() != logger {
+		t.Error("expected the logger set with WithLogger to be kept")
+	}
+}
@@ -0,0 +1,97 @@
+package netgear
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gherlein/go-netgear/pkg/netgear/internal"
+)
+
+// MACTableManager reads the switch's table of learned MAC addresses
+type MACTableManager struct {
+	client *Client
+}
+
+// newMACTableManager creates a new MAC table manager (internal constructor)
+func newMACTableManager(client *Client) *MACTableManager {
+	return &MACTableManager{
+		client: client,
+	}
+}
+
+// MACTableEntry is one address the switch has learned or been configured with
+type MACTableEntry struct {
+	MACAddress string `json:"mac_address"`
+	VLAN       int    `json:"vlan"`
+	PortID     int    `json:"port_id"`
+	// Type is the firmware's entry type in lower case, e.g. "dynamic" or "static"
+	Type string `json:"type,omitempty"`
+}
+
+// MACTableFilter selects the entries GetEntries returns
+type MACTableFilter func(MACTableEntry) bool
+
+// OnPort keeps the entries learned on a port
+func OnPort(portID int) MACTableFilter {
+	return func(entry MACTableEntry) bool { return entry.PortID == portID }
+}
+
+// InVLAN keeps the entries learned in a VLAN
+func InVLAN(vlanID int) MACTableFilter {
+	return func(entry MACTableEntry) bool { return entry.VLAN == vlanID }
+}
+
+// GetEntries returns the MAC address table sorted by port, VLAN and address.
+// With filters, only entries matching all of them are returned, e.g.
+// GetEntries(ctx, OnPort(3)) to find what is plugged into port 3.
+func (m *MACTableManager) GetEntries(ctx context.Context, filters ...MACTableFilter) ([]MACTableEntry, error) {
+	if !m.client.IsAuthenticated() {
+		return nil, ErrNotAuthenticated
+	}
+
+	if err := m.client.endpoints.ValidateEndpoint(EndpointMACTable); err != nil {
+		return nil, err
+	}
+
+	endpoint := m.client.endpoints.GetEndpoint(EndpointMACTable).URL
+	response, err := m.client.makeAuthenticatedRequestWithFallback(ctx, "GET", endpoint, nil, EndpointMACTable)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := internal.ParseMACTable(response)
+	if err != nil {
+		return nil, NewParsingError("failed to parse MAC address table", err)
+	}
+
+	entries := make([]MACTableEntry, 0, len(rows))
+rows:
+	for _, row := range rows {
+		entry := MACTableEntry{
+			MACAddress: normalizeMAC(row["mac"]),
+			Type:       strings.ToLower(row["type"]),
+		}
+		entry.PortID, _ = strconv.Atoi(row["port"])
+		entry.VLAN, _ = strconv.Atoi(row["vlan"])
+		for _, filter := range filters {
+			if !filter(entry) {
+				continue rows
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.PortID != b.PortID {
+			return a.PortID < b.PortID
+		}
+		if a.VLAN != b.VLAN {
+			return a.VLAN < b.VLAN
+		}
+		return a.MACAddress < b.MACAddress
+	})
+	return entries, nil
+}
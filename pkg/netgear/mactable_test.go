@@ -0,0 +1,59 @@
+package netgear
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMACTableGetEntries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/macAddrTable.cgi" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `<table>
+<tr><th>VLAN ID</th><th>MAC Address</th><th>Port</th><th>Type</th></tr>
+<tr><td>10</td><td>AA-BB-CC-00-00-03</td><td>5</td><td>Dynamic</td></tr>
+<tr><td>1</td><td>aa:bb:cc:00:00:02</td><td>5</td><td>Dynamic</td></tr>
+<tr><td>1</td><td>aa:bb:cc:00:00:01</td><td>2</td><td>Static</td></tr>
+</table>`)
+	}))
+	defer server.Close()
+
+	address := strings.TrimPrefix(server.URL, "http://")
+	client, err := NewClient(address, factoryClientOptions(address)...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	ctx := context.Background()
+
+	entries, err := client.MACTable().GetEntries(ctx)
+	if err != nil {
+		t.Fatalf("GetEntries failed: %v", err)
+	}
+	want := []MACTableEntry{
+		{MACAddress: "aa:bb:cc:00:00:01", VLAN: 1, PortID: 2, Type: "static"},
+		{MACAddress: "aa:bb:cc:00:00:02", VLAN: 1, PortID: 5, Type: "dynamic"},
+		{MACAddress: "aa:bb:cc:00:00:03", VLAN: 10, PortID: 5, Type: "dynamic"},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("expected %d entries, got %+v", len(want), entries)
+	}
+	for i := range want {
+		if entries[i] != want[i] {
+			t.Errorf("entry %d = %+v, want %+v", i, entries[i], want[i])
+		}
+	}
+
+	entries, err = client.MACTable().GetEntries(ctx, OnPort(5), InVLAN(10))
+	if err != nil {
+		t.Fatalf("filtered GetEntries failed: %v", err)
+	}
+	if len(entries) != 1 || entries[0].MACAddress != "aa:bb:cc:00:00:03" {
+		t.Errorf("unexpected filtered entries %+v", entries)
+	}
+}
@@ -0,0 +1,170 @@
+package netgear
+
+import (
+	"context"
+	"errors"
+	"net"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// mdnsAddress is the IPv4 mDNS multicast group
+var mdnsAddress = &net.UDPAddr{IP: net.IPv4(224, 0, 0, 251), Port: 5353}
+
+// mdnsQueries are the service types Netgear switches announce their web UI under
+var mdnsQueries = []string{"_http._tcp.local.", "_services._dns-sd._udp.local."}
+
+// defaultMDNSWait is how long ResolveByMDNS listens when ctx has no deadline
+const defaultMDNSWait = 3 * time.Second
+
+// DiscoveredSwitch is a device found by ResolveByMDNS
+type DiscoveredSwitch struct {
+	Name      string   `json:"name"`
+	Addresses []string `json:"addresses"`
+}
+
+// ResolveByMDNS queries the local network via mDNS and returns devices whose
+// announced host or instance name matches pattern, a case-insensitive glob such
+// as "GS308EPP-*". It listens until ctx is done, or for three seconds if ctx
+// has no deadline. The results are candidates for a Fleet inventory; they are
+// not verified to be supported switches.
+func ResolveByMDNS(ctx context.Context, pattern string) ([]DiscoveredSwitch, error) {
+	if _, err := path.Match(strings.ToLower(pattern), ""); err != nil {
+		return nil, NewOperationError("invalid mDNS name pattern", err)
+	}
+
+	if _, hasDeadline := ctx.Deadline(); !hasDeadline {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultMDNSWait)
+		defer cancel()
+	}
+
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4zero})
+	if err != nil {
+		return nil, NewNetworkError("failed to open mDNS socket", err)
+	}
+	defer conn.Close()
+
+	for _, name := range mdnsQueries {
+		query, err := buildMDNSQuery(name)
+		if err != nil {
+			return nil, NewOperationError("failed to build mDNS query", err)
+		}
+		if _, err := conn.WriteToUDP(query, mdnsAddress); err != nil {
+			return nil, NewNetworkError("failed to send mDNS query", err)
+		}
+	}
+
+	deadline, _ := ctx.Deadline()
+	conn.SetReadDeadline(deadline)
+
+	found := make(map[string]map[string]bool)
+	buf := make([]byte, 9000)
+	for {
+		n, _, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				break
+			}
+			return nil, NewNetworkError("failed to read mDNS response", err)
+		}
+		collectMDNSAnswers(buf[:n], pattern, found)
+	}
+
+	return discoveredSwitches(found), nil
+}
+
+// buildMDNSQuery encodes a PTR query asking for a unicast response
+func buildMDNSQuery(name string) ([]byte, error) {
+	msg := dnsmessage.Message{
+		Questions: []dnsmessage.Question{{
+			Name:  dnsmessage.MustNewName(name),
+			Type:  dnsmessage.TypePTR,
+			Class: dnsmessage.ClassINET,
+		}},
+	}
+	return msg.Pack()
+}
+
+// collectMDNSAnswers records the addresses of hosts in a response whose name
+// matches pattern. SRV records link announced instance names to host names, so
+// either may match.
+func collectMDNSAnswers(packet []byte, pattern string, found map[string]map[string]bool) {
+	var msg dnsmessage.Message
+	if err := msg.Unpack(packet); err != nil {
+		return
+	}
+
+	records := append(append(msg.Answers, msg.Additionals...), msg.Authorities...)
+
+	// Map host names to the instance names announcing them
+	aliases := make(map[string][]string)
+	for _, rr := range records {
+		if srv, ok := rr.Body.(*dnsmessage.SRVResource); ok {
+			host := mdnsLabel(srv.Target.String())
+			aliases[host] = append(aliases[host], mdnsLabel(rr.Header.Name.String()))
+		}
+	}
+
+	pattern = strings.ToLower(pattern)
+	for _, rr := range records {
+		var ip net.IP
+		switch body := rr.Body.(type) {
+		case *dnsmessage.AResource:
+			ip = net.IP(body.A[:])
+		case *dnsmessage.AAAAResource:
+			ip = net.IP(body.AAAA[:])
+		default:
+			continue
+		}
+
+		host := mdnsLabel(rr.Header.Name.String())
+		for _, name := range append([]string{host}, aliases[host]...) {
+			if matched, _ := path.Match(pattern, strings.ToLower(name)); matched {
+				if found[host] == nil {
+					found[host] = make(map[string]bool)
+				}
+				found[host][ip.String()] = true
+				break
+			}
+		}
+	}
+}
+
+// mdnsLabel returns the first label of an mDNS name ("GS308EPP-ABC123.local." → "GS308EPP-ABC123")
+func mdnsLabel(name string) string {
+	label, _, _ := strings.Cut(strings.TrimSuffix(name, "."), ".")
+	return label
+}
+
+// discoveredSwitches converts collected hosts into sorted results, IPv4 addresses first
+func discoveredSwitches(found map[string]map[string]bool) []DiscoveredSwitch {
+	var result []DiscoveredSwitch
+	for host, addrs := range found {
+		d := DiscoveredSwitch{Name: host}
+		for addr := range addrs {
+			d.Addresses = append(d.Addresses, addr)
+		}
+		sort.Slice(d.Addresses, func(i, j int) bool {
+			iv4 := net.ParseIP(d.Addresses[i]).To4() != nil
+			jv4 := net.ParseIP(d.Addresses[j]).To4() != nil
+			if iv4 != jv4 {
+				return iv4
+			}
+			return d.Addresses[i] < d.Addresses[j]
+		})
+		result = append(result, d)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// InventoryEntry converts a discovered switch into an inventory entry
+func (d DiscoveredSwitch) InventoryEntry() InventoryEntry {
+	return InventoryEntry{Name: d.Name, Addresses: append([]string(nil), d.Addresses...)}
+}
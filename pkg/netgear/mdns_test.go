@@ -0,0 +1,55 @@
+package netgear
+
+import (
+	"testing"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+func TestCollectMDNSAnswers(t *testing.T) {
+	msg := dnsmessage.Message{
+		Header: dnsmessage.Header{Response: true, Authoritative: true},
+		Answers: []dnsmessage.Resource{
+			{
+				Header: dnsmessage.ResourceHeader{Name: dnsmessage.MustNewName("GS308EPP-A1B2C3.local."), Type: dnsmessage.TypeA, Class: dnsmessage.ClassINET},
+				Body:   &dnsmessage.AResource{A: [4]byte{192, 168, 1, 20}},
+			},
+			{
+				Header: dnsmessage.ResourceHeader{Name: dnsmessage.MustNewName("printer.local."), Type: dnsmessage.TypeA, Class: dnsmessage.ClassINET},
+				Body:   &dnsmessage.AResource{A: [4]byte{192, 168, 1, 30}},
+			},
+			{
+				Header: dnsmessage.ResourceHeader{Name: dnsmessage.MustNewName("GS316EP-Office._http._tcp.local."), Type: dnsmessage.TypeSRV, Class: dnsmessage.ClassINET},
+				Body:   &dnsmessage.SRVResource{Port: 80, Target: dnsmessage.MustNewName("netgear-d4e5.local.")},
+			},
+			{
+				Header: dnsmessage.ResourceHeader{Name: dnsmessage.MustNewName("netgear-d4e5.local."), Type: dnsmessage.TypeA, Class: dnsmessage.ClassINET},
+				Body:   &dnsmessage.AResource{A: [4]byte{192, 168, 1, 21}},
+			},
+		},
+	}
+	packet, err := msg.Pack()
+	if err != nil {
+		t.Fatalf("failed to pack message: %v", err)
+	}
+
+	found := make(map[string]map[string]bool)
+	collectMDNSAnswers(packet, "gs3*", found)
+	collectMDNSAnswers([]byte("garbage"), "gs3*", found)
+
+	result := discoveredSwitches(found)
+	if len(result) != 2 {
+		t.Fatalf("expected 2 switches, got %+v", result)
+	}
+	if result[0].Name != "GS308EPP-A1B2C3" || result[0].Addresses[0] != "192.168.1.20" {
+		t.Errorf("unexpected first result: %+v", result[0])
+	}
+	if result[1].Name != "netgear-d4e5" || result[1].Addresses[0] != "192.168.1.21" {
+		t.Errorf("expected SRV alias match, got %+v", result[1])
+	}
+
+	entry := result[0].InventoryEntry()
+	if entry.Name != result[0].Name || len(entry.Addresses) != 1 {
+		t.Errorf("unexpected inventory entry: %+v", entry)
+	}
+}
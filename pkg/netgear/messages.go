@@ -0,0 +1,116 @@
+package netgear
+
+import (
+	"errors"
+
+	"github.com/gherlein/go-netgear/pkg/i18n"
+)
+
+// errorMessageIDs maps sentinel errors to their catalog message IDs
+var errorMessageIDs = []struct {
+	err error
+	id  string
+}{
+	{ErrNotAuthenticated, "error.not_authenticated"},
+	{ErrSessionExpired, "error.session_expired"},
+	{ErrModelNotSupported, "error.model_not_supported"},
+	{ErrModelNotDetected, "error.model_not_detected"},
+	{ErrInvalidCredentials, "error.invalid_credentials"},
+	{ErrNetworkTimeout, "error.network_timeout"},
+	{ErrConnectionFailed, "error.connection_failed"},
+	{ErrInvalidResponse, "error.invalid_response"},
+	{ErrNotANetgearSwitch, "error.not_a_netgear_switch"},
+	{ErrUnmanagedDevice, "error.unmanaged_device"},
+	{ErrNotAttempted, "error.not_attempted"},
+	{ErrPlanStale, "error.plan_stale"},
+	{ErrOutsideMaintenanceWindow, "error.outside_maintenance_window"},
+	{ErrInitialPasswordRequired, "error.initial_password_required"},
+	{ErrPerPortFlowControl, "error.per_port_flow_control"},
+	{ErrSwitchDisabled, "error.switch_disabled"},
+	{ErrPortLocked, "error.port_locked"},
+	{ErrResetNotConfirmed, "error.reset_not_confirmed"},
+	{ErrSwitchBusy, "error.switch_busy"},
+	{ErrPasswordNotFound, "error.password_not_found"},
+	{ErrPortAuthNotSupported, "error.port_auth_not_supported"},
+	{ErrSessionActive, "error.session_active"},
+	{ErrServerError, "error.server_error"},
+	{ErrOperationNotSupported, "error.operation_not_supported"},
+}
+
+func init() {
+	i18n.Register(i18n.English, map[string]string{
+		"error.not_authenticated":          "Not logged in to the switch.",
+		"error.session_expired":            "The session expired; log in again.",
+		"error.model_not_supported":        "This switch model is not supported.",
+		"error.model_not_detected":         "The switch model could not be detected.",
+		"error.invalid_credentials":        "The switch rejected the password.",
+		"error.network_timeout":            "The switch did not respond in time.",
+		"error.connection_failed":          "Could not connect to the switch.",
+		"error.invalid_response":           "The switch sent a response that could not be understood.",
+		"error.not_a_netgear_switch":       "The device at this address is not a Netgear switch.",
+		"error.unmanaged_device":           "This Netgear device is not a supported managed switch.",
+		"error.not_attempted":              "Skipped after an earlier failure.",
+		"error.plan_stale":                 "The switch changed since the plan was made; create a new plan.",
+		"error.outside_maintenance_window": "Changes are only allowed during the maintenance window.",
+		"error.initial_password_required":  "The switch still has its factory password, which must be changed first.",
+		"error.per_port_flow_control":      "This switch sets flow control for all ports at once, not per port.",
+		"error.switch_disabled":            "This switch is disabled in the fleet.",
+		"error.port_locked":                "The port is reserved by another operator or automation.",
+		"error.reset_not_confirmed":        "The factory reset was not confirmed for this switch's serial number.",
+		"error.switch_busy":                "The switch stayed busy; try again once it finished applying changes.",
+		"error.password_not_found":         "No password is configured for this switch.",
+		"error.port_auth_not_supported":    "This switch model has no 802.1X port authentication; it is available on GS316 and smart managed pro (GS108Tv3, GS110TP) models.",
+		"error.session_active":             "Another session is logged in to the switch; try again once it logged out or timed out.",
+		"error.server_error":               "The switch answered with an internal error.",
+		"error.operation_not_supported":    "This switch model does not offer the operation.",
+		"error.switch":                     "%s (switch %s)",
+	})
+	i18n.Register(i18n.German, map[string]string{
+		"error.not_authenticated":          "Nicht am Switch angemeldet.",
+		"error.session_expired":            "Die Sitzung ist abgelaufen; bitte erneut anmelden.",
+		"error.model_not_supported":        "Dieses Switch-Modell wird nicht unterstützt.",
+		"error.model_not_detected":         "Das Switch-Modell konnte nicht erkannt werden.",
+		"error.invalid_credentials":        "Der Switch hat das Passwort abgelehnt.",
+		"error.network_timeout":            "Der Switch hat nicht rechtzeitig geantwortet.",
+		"error.connection_failed":          "Keine Verbindung zum Switch möglich.",
+		"error.invalid_response":           "Die Antwort des Switches konnte nicht verarbeitet werden.",
+		"error.not_a_netgear_switch":       "Das Gerät unter dieser Adresse ist kein Netgear-Switch.",
+		"error.unmanaged_device":           "Dieses Netgear-Gerät ist kein unterstützter Managed Switch.",
+		"error.not_attempted":              "Nach einem vorherigen Fehler übersprungen.",
+		"error.plan_stale":                 "Der Switch wurde seit der Planung geändert; bitte einen neuen Plan erstellen.",
+		"error.outside_maintenance_window": "Änderungen sind nur im Wartungsfenster erlaubt.",
+		"error.initial_password_required":  "Der Switch hat noch das Werkspasswort, das zuerst geändert werden muss.",
+		"error.per_port_flow_control":      "Dieser Switch stellt die Flusskontrolle für alle Ports gemeinsam ein, nicht pro Port.",
+		"error.switch_disabled":            "Dieser Switch ist in der Flotte deaktiviert.",
+		"error.port_locked":                "Der Port ist von einem anderen Bediener oder einer anderen Automatisierung reserviert.",
+		"error.reset_not_confirmed":        "Das Zurücksetzen auf Werkseinstellungen wurde für die Seriennummer dieses Switches nicht bestätigt.",
+		"error.switch_busy":                "Der Switch blieb beschäftigt; erneut versuchen, sobald er die Änderungen übernommen hat.",
+		"error.password_not_found":         "Für diesen Switch ist kein Passwort hinterlegt.",
+		"error.port_auth_not_supported":    "Dieses Switch-Modell hat keine 802.1X-Portauthentifizierung; sie ist auf GS316- und Smart-Managed-Pro-Modellen (GS108Tv3, GS110TP) verfügbar.",
+		"error.session_active":             "Eine andere Sitzung ist am Switch angemeldet; erneut versuchen, sobald sie abgemeldet oder abgelaufen ist.",
+		"error.server_error":               "Der Switch hat mit einem internen Fehler geantwortet.",
+		"error.operation_not_supported":    "Dieses Switch-Modell bietet die Funktion nicht an.",
+		"error.switch":                     "%s (Switch %s)",
+	})
+}
+
+// LocalizedError returns a user-facing description of err in the current
+// i18n language. Errors without a translation are described by err.Error().
+// The error itself is unchanged, so errors.Is and errors.As keep working.
+func LocalizedError(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	for _, entry := range errorMessageIDs {
+		if errors.Is(err, entry.err) {
+			message := i18n.T(entry.id)
+			var netgearErr *Error
+			if errors.As(err, &netgearErr) && netgearErr.Address != "" {
+				message = i18n.T("error.switch", message, netgearErr.Address)
+			}
+			return message
+		}
+	}
+	return err.Error()
+}
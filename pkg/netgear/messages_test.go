@@ -0,0 +1,27 @@
+package netgear
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gherlein/go-netgear/pkg/i18n"
+)
+
+func TestLocalizedError(t *testing.T) {
+	defer i18n.SetLanguage(i18n.CurrentLanguage())
+	i18n.SetLanguage(i18n.German)
+
+	err := fmt.Errorf("login: %w", ErrInvalidCredentials.WithSwitch("192.168.1.10", ModelGS308EPP))
+	if got := LocalizedError(err); got != "Der Switch hat das Passwort abgelehnt. (Switch 192.168.1.10)" {
+		t.Errorf("unexpected German message %q", got)
+	}
+	if !errors.Is(err, ErrInvalidCredentials) {
+		t.Error("localizing must not change error identity")
+	}
+
+	other := errors.New("something else")
+	if got := LocalizedError(other); got != other.Error() {
+		t.Errorf("expected untranslated error text, got %q", got)
+	}
+}
@@ -0,0 +1,286 @@
+package netgear
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// SwitchMetadata is information about a switch kept by this library rather
+// than on the switch itself, stored next to its cached token
+type SwitchMetadata struct {
+	PortLocks map[int]PortLock `json:"port_locks,omitempty"`
+	UpdatedAt time.Time        `json:"updated_at"`
+}
+
+// PortLock is an advisory reservation of a port by an operator or automation
+type PortLock struct {
+	PortID   int       `json:"port_id"`
+	Owner    string    `json:"owner"`
+	LockedAt time.Time `json:"locked_at"`
+	// ExpiresAt is zero for locks held until UnlockPort
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+}
+
+// Expired reports whether the lock no longer applies at the given time
+func (l PortLock) Expired(now time.Time) bool {
+	return !l.ExpiresAt.IsZero() && !now.Before(l.ExpiresAt)
+}
+
+// MetadataStore persists SwitchMetadata per switch address. Token managers
+// that also implement MetadataStore enable Client.Meta, unless the client
+// keeps its data in a WithStore store.
+type MetadataStore interface {
+	// GetMetadata retrieves the stored metadata for an address
+	GetMetadata(ctx context.Context, address string) (*SwitchMetadata, error)
+
+	// StoreMetadata saves the metadata for an address
+	StoreMetadata(ctx context.Context, address string, metadata *SwitchMetadata) error
+}
+
+// metadataMu serializes read-modify-write cycles on stored metadata within the process
+var metadataMu sync.Mutex
+
+// GetMetadata retrieves stored metadata from memory
+func (m *MemoryTokenManager) GetMetadata(ctx context.Context, address string) (*SwitchMetadata, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	metadata, exists := m.metadata[address]
+	if !exists {
+		return nil, NewOperationError("metadata not found", nil)
+	}
+	return metadata.clone(), nil
+}
+
+// StoreMetadata saves metadata in memory
+func (m *MemoryTokenManager) StoreMetadata(ctx context.Context, address string, metadata *SwitchMetadata) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.metadata == nil {
+		m.metadata = make(map[string]*SwitchMetadata)
+	}
+	m.metadata[address] = metadata.clone()
+	return nil
+}
+
+// GetMetadata retrieves stored metadata from the cache directory
+func (m *FileTokenManager) GetMetadata(ctx context.Context, address string) (*SwitchMetadata, error) {
+	data, err := os.ReadFile(m.getMetadataFilename(address))
+	if err != nil {
+		return nil, NewOperationError("failed to read metadata file", err)
+	}
+
+	var metadata SwitchMetadata
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return nil, NewParsingError("malformed metadata file", err)
+	}
+	return &metadata, nil
+}
+
+// StoreMetadata saves metadata next to the token file in the cache directory
+func (m *FileTokenManager) StoreMetadata(ctx context.Context, address string, metadata *SwitchMetadata) error {
+	if err := os.MkdirAll(m.cacheDir, 0700); err != nil {
+		return NewOperationError("failed to create cache directory", err)
+	}
+
+	data, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return NewOperationError("failed to encode metadata", err)
+	}
+
+	if err := writeFileAtomic(m.getMetadataFilename(address), data, 0600); err != nil {
+		return NewOperationError("failed to write metadata file", err)
+	}
+	return nil
+}
+
+// getMetadataFilename generates the metadata filename for an address
+func (m *FileTokenManager) getMetadataFilename(address string) string {
+	h := fnv.New32a()
+	h.Write([]byte(address))
+	return filepath.Join(m.cacheDir, fmt.Sprintf("netgear-meta-%x.json", h.Sum32()))
+}
+
+// clone returns a deep copy so stored metadata cannot be changed through a returned value
+func (md *SwitchMetadata) clone() *SwitchMetadata {
+	copied := *md
+	if md.PortLocks != nil {
+		copied.PortLocks = make(map[int]PortLock, len(md.PortLocks))
+		for port, lock := range md.PortLocks {
+			copied.PortLocks[port] = lock
+		}
+	}
+	return &copied
+}
+
+// MetaManager manages library-side metadata about a switch, such as port locks
+type MetaManager struct {
+	client *Client
+}
+
+// newMetaManager creates a new metadata manager (internal constructor)
+func newMetaManager(client *Client) *MetaManager {
+	return &MetaManager{client: client}
+}
+
+// store returns the client's metadata store
+func (m *MetaManager) store() (MetadataStore, error) {
+	store, ok := m.client.metadataStore()
+	if !ok {
+		return nil, NewOperationError("neither the store nor the token manager stores switch metadata", nil)
+	}
+	return store, nil
+}
+
+// load returns the stored metadata, or empty metadata if none is stored yet
+func (m *MetaManager) load(ctx context.Context, store MetadataStore) *SwitchMetadata {
+	metadata, err := store.GetMetadata(ctx, m.client.address)
+	if err != nil {
+		return &SwitchMetadata{}
+	}
+	return metadata
+}
+
+// update applies change to the stored metadata and saves it
+func (m *MetaManager) update(ctx context.Context, change func(*SwitchMetadata) error) error {
+	store, err := m.store()
+	if err != nil {
+		return err
+	}
+
+	metadataMu.Lock()
+	defer metadataMu.Unlock()
+
+	metadata := m.load(ctx, store)
+	if err := change(metadata); err != nil {
+		return err
+	}
+	metadata.UpdatedAt = m.client.clock.Now()
+	return store.StoreMetadata(ctx, m.client.address, metadata)
+}
+
+// LockPort reserves a port for owner. While the lock is held, mutating
+// operations on the port from clients with a different WithLockOwner fail
+// with ErrPortLocked unless their context is marked with Force. The owner
+// holding the lock may call LockPort again to extend it. A ttl of zero keeps
+// the lock until UnlockPort.
+func (m *MetaManager) LockPort(ctx context.Context, portID int, owner string, ttl time.Duration) error {
+	if owner == "" {
+		return NewOperationError("lock owner cannot be empty", nil)
+	}
+
+	return m.update(ctx, func(metadata *SwitchMetadata) error {
+		now := m.client.clock.Now()
+		if held, exists := metadata.PortLocks[portID]; exists && !held.Expired(now) && held.Owner != owner && !isForced(ctx) {
+			return portLockedError(m.client, held)
+		}
+
+		lock := PortLock{PortID: portID, Owner: owner, LockedAt: now}
+		if ttl > 0 {
+			lock.ExpiresAt = now.Add(ttl)
+		}
+		if metadata.PortLocks == nil {
+			metadata.PortLocks = make(map[int]PortLock)
+		}
+		metadata.PortLocks[portID] = lock
+		return nil
+	})
+}
+
+// UnlockPort releases owner's lock on a port. Releasing another owner's lock
+// requires a context marked with Force.
+func (m *MetaManager) UnlockPort(ctx context.Context, portID int, owner string) error {
+	return m.update(ctx, func(metadata *SwitchMetadata) error {
+		held, exists := metadata.PortLocks[portID]
+		if !exists {
+			return nil
+		}
+		if held.Owner != owner && !held.Expired(m.client.clock.Now()) && !isForced(ctx) {
+			return portLockedError(m.client, held)
+		}
+		delete(metadata.PortLocks, portID)
+		return nil
+	})
+}
+
+// PortLocks returns the locks currently in effect, sorted by port
+func (m *MetaManager) PortLocks(ctx context.Context) ([]PortLock, error) {
+	store, err := m.store()
+	if err != nil {
+		return nil, err
+	}
+
+	now := m.client.clock.Now()
+	var locks []PortLock
+	for _, lock := range m.load(ctx, store).PortLocks {
+		if !lock.Expired(now) {
+			locks = append(locks, lock)
+		}
+	}
+	sort.Slice(locks, func(i, j int) bool { return locks[i].PortID < locks[j].PortID })
+	return locks, nil
+}
+
+// WithLockOwner identifies the client as owner when port locks are checked,
+// so it may change ports it has locked itself
+func WithLockOwner(owner string) ClientOption {
+	return func(c *Client) {
+		c.lockOwner = owner
+	}
+}
+
+// forceKey marks contexts created by Force
+type forceKey struct{}
+
+// Force returns a context under which mutating operations ignore port locks
+// held by other owners. Use it for deliberate overrides, e.g. incident response.
+func Force(ctx context.Context) context.Context {
+	return context.WithValue(ctx, forceKey{}, true)
+}
+
+// isForced reports whether ctx was created by Force
+func isForced(ctx context.Context) bool {
+	forced, _ := ctx.Value(forceKey{}).(bool)
+	return forced
+}
+
+// checkPortLocks fails with ErrPortLocked if any of the ports is locked by an
+// owner other than this client. Without a metadata store there are no locks.
+func (c *Client) checkPortLocks(ctx context.Context, portIDs ...int) error {
+	if isForced(ctx) {
+		return nil
+	}
+	store, ok := c.metadataStore()
+	if !ok {
+		return nil
+	}
+	metadata, err := store.GetMetadata(ctx, c.address)
+	if err != nil || len(metadata.PortLocks) == 0 {
+		return nil
+	}
+
+	now := c.clock.Now()
+	for _, portID := range portIDs {
+		if held, exists := metadata.PortLocks[portID]; exists && !held.Expired(now) && held.Owner != c.lockOwner {
+			return portLockedError(c, held)
+		}
+	}
+	return nil
+}
+
+// portLockedError describes a lock held by someone else
+func portLockedError(c *Client, held PortLock) error {
+	detail := fmt.Sprintf("port %d held by %q", held.PortID, held.Owner)
+	if !held.ExpiresAt.IsZero() {
+		detail += " until " + held.ExpiresAt.Format(time.RFC3339)
+	}
+	return fmt.Errorf("%w: %s", ErrPortLocked.WithSwitch(c.address, c.model).WithPort(held.PortID), detail)
+}
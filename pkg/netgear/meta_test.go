@@ -0,0 +1,68 @@
+package netgear
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gherlein/go-netgear/pkg/netgear/internal/fakeclock"
+)
+
+func TestPortLocks(t *testing.T) {
+	ctx := context.Background()
+	clock := fakeclock.New(time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC))
+	tokenMgr := NewMemoryTokenManager()
+	tokenMgr.StoreToken(ctx, "192.0.2.1", "token", ModelGS308EPP)
+
+	newClient := func(owner string) *Client {
+		client, err := NewClient("192.0.2.1", WithTokenManager(tokenMgr), WithPasswordManager(nil), WithClock(clock), WithLockOwner(owner))
+		if err != nil {
+			t.Fatalf("NewClient failed: %v", err)
+		}
+		return client
+	}
+	alice, bob := newClient("alice"), newClient("bob")
+
+	if err := alice.Meta().LockPort(ctx, 3, "alice", time.Hour); err != nil {
+		t.Fatalf("LockPort failed: %v", err)
+	}
+	if err := bob.Meta().LockPort(ctx, 3, "bob", time.Hour); !errors.Is(err, ErrPortLocked) {
+		t.Errorf("expected ErrPortLocked for a second owner, got %v", err)
+	}
+
+	// The lock check runs before any request reaches the switch
+	err := bob.POE().CyclePower(ctx, 1, 3)
+	var netgearErr *Error
+	if !errors.Is(err, ErrPortLocked) || !errors.As(err, &netgearErr) || netgearErr.PortID != 3 {
+		t.Errorf("expected ErrPortLocked for port 3, got %v", err)
+	}
+	if err := alice.checkPortLocks(ctx, 3); err != nil {
+		t.Errorf("expected the owner to pass the lock check, got %v", err)
+	}
+	if err := bob.checkPortLocks(Force(ctx), 3); err != nil {
+		t.Errorf("expected Force to override the lock, got %v", err)
+	}
+	if err := bob.Meta().UnlockPort(ctx, 3, "bob"); !errors.Is(err, ErrPortLocked) {
+		t.Errorf("expected bob unable to release alice's lock, got %v", err)
+	}
+
+	locks, err := bob.Meta().PortLocks(ctx)
+	if err != nil || len(locks) != 1 || locks[0].Owner != "alice" {
+		t.Fatalf("unexpected locks %+v (%v)", locks, err)
+	}
+
+	clock.Advance(time.Hour)
+	if err := bob.checkPortLocks(ctx, 3); err != nil {
+		t.Errorf("expected expired lock to be ignored, got %v", err)
+	}
+	if locks, _ := bob.Meta().PortLocks(ctx); len(locks) != 0 {
+		t.Errorf("expected no locks in effect, got %+v", locks)
+	}
+	if err := bob.Meta().LockPort(ctx, 3, "bob", 0); err != nil {
+		t.Errorf("expected to take over an expired lock, got %v", err)
+	}
+	if err := bob.Meta().UnlockPort(ctx, 3, "bob"); err != nil {
+		t.Errorf("UnlockPort failed: %v", err)
+	}
+}
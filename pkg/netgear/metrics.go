@@ -0,0 +1,143 @@
+package netgear
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// LatencyBuckets are the upper bounds of the request latency histogram. Switch
+// web servers are slow, so the buckets stretch well past typical API latencies.
+var LatencyBuckets = []time.Duration{
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// HistogramBucket is a cumulative histogram bucket: Count requests completed
+// in at most UpperBound
+type HistogramBucket struct {
+	UpperBound time.Duration `json:"upper_bound"`
+	Count      uint64        `json:"count"`
+}
+
+// EndpointMetrics holds request statistics for a single switch page
+type EndpointMetrics struct {
+	Endpoint     string            `json:"endpoint"`
+	Requests     uint64            `json:"requests"`
+	Errors       uint64            `json:"errors"`
+	TotalLatency time.Duration     `json:"total_latency"`
+	Buckets      []HistogramBucket `json:"buckets"`
+	LastError    string            `json:"last_error,omitempty"`
+	LastRequest  time.Time         `json:"last_request"`
+
+	// BytesReceived counts response body bytes actually transferred
+	BytesReceived uint64 `json:"bytes_received"`
+	// NotModified counts GETs answered 304 from the conditional request cache
+	NotModified uint64 `json:"not_modified"`
+	// BytesSaved counts body bytes the switch did not resend thanks to 304 answers
+	BytesSaved uint64 `json:"bytes_saved"`
+}
+
+// AverageLatency returns the mean latency over all recorded requests
+func (m EndpointMetrics) AverageLatency() time.Duration {
+	if m.Requests == 0 {
+		return 0
+	}
+	return m.TotalLatency / time.Duration(m.Requests)
+}
+
+// metricsRecorder accumulates per-endpoint request metrics for a client
+type metricsRecorder struct {
+	mu        sync.Mutex
+	endpoints map[string]*EndpointMetrics
+}
+
+func newMetricsRecorder() *metricsRecorder {
+	return &metricsRecorder{endpoints: make(map[string]*EndpointMetrics)}
+}
+
+// record adds one request outcome, completed at the given time, to the endpoint's histogram and counters
+func (r *metricsRecorder) record(endpoint string, latency time.Duration, at time.Time, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	m := r.entry(endpoint)
+	m.Requests++
+	m.TotalLatency += latency
+	m.LastRequest = at
+	for i := range m.Buckets {
+		if latency <= m.Buckets[i].UpperBound {
+			m.Buckets[i].Count++
+		}
+	}
+	if err != nil {
+		m.Errors++
+		m.LastError = err.Error()
+	}
+}
+
+// recordTransfer adds the body bytes received for a request, or the bytes a
+// 304 answer saved
+func (r *metricsRecorder) recordTransfer(endpoint string, received, saved int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	m := r.entry(endpoint)
+	m.BytesReceived += uint64(received)
+	if saved > 0 {
+		m.NotModified++
+		m.BytesSaved += uint64(saved)
+	}
+}
+
+// entry returns the metrics of an endpoint, creating them on first use; r.mu must be held
+func (r *metricsRecorder) entry(endpoint string) *EndpointMetrics {
+	m, exists := r.endpoints[endpoint]
+	if !exists {
+		m = &EndpointMetrics{Endpoint: endpoint, Buckets: make([]HistogramBucket, len(LatencyBuckets))}
+		for i, bound := range LatencyBuckets {
+			m.Buckets[i].UpperBound = bound
+		}
+		r.endpoints[endpoint] = m
+	}
+	return m
+}
+
+// snapshot returns a copy of the metrics sorted by endpoint
+func (r *metricsRecorder) snapshot() []EndpointMetrics {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make([]EndpointMetrics, 0, len(r.endpoints))
+	for _, m := range r.endpoints {
+		copied := *m
+		copied.Buckets = append([]HistogramBucket(nil), m.Buckets...)
+		result = append(result, copied)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Endpoint < result[j].Endpoint })
+	return result
+}
+
+// reset discards all recorded metrics
+func (r *metricsRecorder) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.endpoints = make(map[string]*EndpointMetrics)
+}
+
+// Metrics returns per-endpoint latency histograms and error counters for every
+// switch page requested by this client
+func (c *Client) Metrics() []EndpointMetrics {
+	return c.metrics.snapshot()
+}
+
+// ResetMetrics discards all recorded request metrics
+func (c *Client) ResetMetrics() {
+	c.metrics.reset()
+}
@@ -0,0 +1,116 @@
+package netgear
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gherlein/go-netgear/pkg/netgear/internal/fakeclock"
+)
+
+// bucketCounts returns the cumulative counts of a histogram
+func bucketCounts(buckets []HistogramBucket) []uint64 {
+	counts := make([]uint64, len(buckets))
+	for i, bucket := range buckets {
+		counts[i] = bucket.Count
+	}
+	return counts
+}
+
+func TestMetricsRecorder(t *testing.T) {
+	recorder := newMetricsRecorder()
+	at := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	recorder.record("/b", 30*time.Millisecond, at, nil)
+	recorder.record("/b", 300*time.Millisecond, at.Add(time.Second), errors.New("refused"))
+	recorder.record("/b", 3*time.Second, at.Add(2*time.Second), nil)
+	recorder.record("/a", 50*time.Millisecond, at, nil)
+
+	metrics := recorder.snapshot()
+	if len(metrics) != 2 || metrics[0].Endpoint != "/a" || metrics[1].Endpoint != "/b" {
+		t.Fatalf("expected metrics for /a and /b sorted by endpoint, got %+v", metrics)
+	}
+
+	b := metrics[1]
+	if b.Requests != 3 || b.Errors != 1 || b.LastError != "refused" || !b.LastRequest.Equal(at.Add(2*time.Second)) {
+		t.Errorf("unexpected counters %+v", b)
+	}
+	if b.TotalLatency != 3330*time.Millisecond || b.AverageLatency() != 1110*time.Millisecond {
+		t.Errorf("expected 3.33s in total and 1.11s on average, got %v and %v", b.TotalLatency, b.AverageLatency())
+	}
+	if got := fmt.Sprint(bucketCounts(b.Buckets)); got != "[1 1 1 2 2 2 3 3]" {
+		t.Errorf("unexpected cumulative buckets %s", got)
+	}
+	// A latency equal to a bucket's upper bound counts towards that bucket
+	if got := fmt.Sprint(bucketCounts(metrics[0].Buckets)); got != "[1 1 1 1 1 1 1 1]" {
+		t.Errorf("unexpected buckets for /a %s", got)
+	}
+
+	// Snapshots are copies
+	metrics[1].Buckets[0].Count = 100
+	if recorder.snapshot()[1].Buckets[0].Count != 1 {
+		t.Error("expected changes to a snapshot not to reach the recorder")
+	}
+
+	recorder.reset()
+	if metrics := recorder.snapshot(); len(metrics) != 0 {
+		t.Errorf("expected no metrics after reset, got %+v", metrics)
+	}
+	if (EndpointMetrics{}).AverageLatency() != 0 {
+		t.Error("expected no average latency without requests")
+	}
+}
+
+func TestClientMetrics(t *testing.T) {
+	clock := fakeclock.New(time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Each request takes 200ms on the client's clock
+		clock.Advance(200 * time.Millisecond)
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprint(w, "<html>ok</html>")
+	}))
+	defer server.Close()
+	address := strings.TrimPrefix(server.URL, "http://")
+
+	client, err := NewClient(address, append(factoryClientOptions(address), WithClock(clock))...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	ctx := context.Background()
+	for _, path := range []string{"/ok", "/fail", "/ok"} {
+		client.makeAuthenticatedRequest(ctx, "GET", path, nil)
+	}
+
+	metrics := client.Metrics()
+	if len(metrics) != 2 {
+		t.Fatalf("expected metrics for 2 endpoints, got %+v", metrics)
+	}
+	fail, ok := metrics[0], metrics[1]
+	if fail.Endpoint != "/fail" || fail.Requests != 1 || fail.Errors != 1 || !strings.Contains(fail.LastError, "server error") {
+		t.Errorf("unexpected metrics for the failing page %+v", fail)
+	}
+	if ok.Endpoint != "/ok" || ok.Requests != 2 || ok.Errors != 0 || ok.LastError != "" {
+		t.Errorf("unexpected metrics for the working page %+v", ok)
+	}
+	if ok.TotalLatency != 400*time.Millisecond || ok.AverageLatency() != 200*time.Millisecond {
+		t.Errorf("expected 200ms per request, got %v in total", ok.TotalLatency)
+	}
+	if got := fmt.Sprint(bucketCounts(ok.Buckets)); got != "[0 0 2 2 2 2 2 2]" {
+		t.Errorf("unexpected buckets %s", got)
+	}
+	if !ok.LastRequest.Equal(clock.Now()) {
+		t.Errorf("expected the last request at %v, got %v", clock.Now(), ok.LastRequest)
+	}
+
+	client.ResetMetrics()
+	if metrics := client.Metrics(); len(metrics) != 0 {
+		t.Errorf("expected no metrics after ResetMetrics, got %+v", metrics)
+	}
+}
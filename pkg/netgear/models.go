@@ -1,5 +1,10 @@
 package netgear
 
+import (
+	"sort"
+	"time"
+)
+
 // Model represents a Netgear switch model
 type Model string
 
@@ -11,37 +16,81 @@ const (
 	ModelGS316EP  Model = "GS316EP"
 	ModelGS316EPP Model = "GS316EPP"
 	ModelGS30xEPx Model = "GS30xEPx"
+	ModelGS108Tv3 Model = "GS108Tv3"
+	ModelGS110TP  Model = "GS110TP"
+	ModelMS108EUP Model = "MS108EUP"
+	ModelMS108TUP Model = "MS108TUP"
+)
+
+const (
+	// POEPlusPortPowerW is the highest power an 802.3at (POE+) port delivers
+	POEPlusPortPowerW = 30.0
+	// Ultra60PortPowerW is the highest power an Ultra60 (802.3bt POE++) port delivers
+	Ultra60PortPowerW = 60.0
 )
 
 // IsModel30x returns true if the model is part of the 30x series
 func (m Model) IsModel30x() bool {
-	switch m {
-	case ModelGS305EP, ModelGS305EPP, ModelGS308EP, ModelGS308EPP, ModelGS30xEPx:
-		return true
-	default:
-		return false
-	}
+	return knownModels[m].family == familyGS30x
 }
 
 // IsModel316 returns true if the model is part of the 316 series
 func (m Model) IsModel316() bool {
-	switch m {
-	case ModelGS316EP, ModelGS316EPP:
-		return true
-	default:
-		return false
-	}
+	return knownModels[m].family == familyGS316
+}
+
+// IsModelSmartPro returns true if the model is part of the smart managed pro
+// family (GS108Tv3, GS110TP), whose web UI lives under /base/
+func (m Model) IsModelSmartPro() bool {
+	return knownModels[m].family == familySmartPro
+}
+
+// HasPOE returns true if the model has POE ports
+func (m Model) HasPOE() bool {
+	return knownModels[m].portW > 0
+}
+
+// MaxPortPowerW returns the highest power limit a single POE port of the
+// model accepts in watts, or 0 for models without POE
+func (m Model) MaxPortPowerW() float64 {
+	return knownModels[m].portW
 }
 
 // IsSupported returns true if the model is supported
 func (m Model) IsSupported() bool {
-	switch m {
-	case ModelGS305EP, ModelGS305EPP, ModelGS308EP, ModelGS308EPP, 
-		 ModelGS316EP, ModelGS316EPP, ModelGS30xEPx:
-		return true
-	default:
-		return false
+	_, ok := knownModels[m]
+	return ok
+}
+
+// PortCount returns the number of switch ports, or 0 if unknown
+func (m Model) PortCount() int {
+	return knownModels[m].ports
+}
+
+// String returns the model name
+func (m Model) String() string {
+	return string(m)
+}
+
+// SupportedModels returns every supported model, sorted by name
+func SupportedModels() []Model {
+	models := make([]Model, 0, len(knownModels))
+	for model := range knownModels {
+		models = append(models, model)
+	}
+	sort.Slice(models, func(i, j int) bool { return models[i] < models[j] })
+	return models
+}
+
+// detectableModels returns the names of specific models the detector looks for in page content
+func detectableModels() []string {
+	var names []string
+	for _, model := range SupportedModels() {
+		if model.PortCount() > 0 {
+			names = append(names, string(model))
+		}
 	}
+	return names
 }
 
 // POEPortStatus represents the status of a POE port
@@ -57,6 +106,40 @@ type POEPortStatus struct {
 	ErrorStatus  string  `json:"error_status"`
 }
 
+// POEBudget summarizes the switch's total POE power budget and current draw
+type POEBudget struct {
+	TotalW     float64 `json:"total_w"`
+	ConsumedW  float64 `json:"consumed_w"`
+	RemainingW float64 `json:"remaining_w"`
+	// ThresholdW is the usage threshold configured on the switch, if it reports one
+	ThresholdW float64 `json:"threshold_w,omitempty"`
+	// Source tells whether the figures were read from the switch or estimated
+	Source POEBudgetSource `json:"source"`
+}
+
+// POEBudgetSource tells where the figures of a POEBudget come from
+type POEBudgetSource string
+
+const (
+	// POEBudgetFromSwitch figures were read from the switch's POE overview page
+	POEBudgetFromSwitch POEBudgetSource = "switch"
+	// POEBudgetEstimated figures combine the model's datasheet budget with the sum of the port readings
+	POEBudgetEstimated POEBudgetSource = "estimated"
+)
+
+// UsedPercent returns the consumed power as a percentage of the total budget
+func (b POEBudget) UsedPercent() float64 {
+	if b.TotalW <= 0 {
+		return 0
+	}
+	return b.ConsumedW / b.TotalW * 100
+}
+
+// Exceeds reports whether the consumed power is above the given percentage of the total budget
+func (b POEBudget) Exceeds(percent float64) bool {
+	return b.TotalW > 0 && b.UsedPercent() > percent
+}
+
 // POEPortSettings represents POE port configuration
 type POEPortSettings struct {
 	PortID              int          `json:"port_id"`
@@ -72,14 +155,17 @@ type POEPortSettings struct {
 
 // PortSettings represents switch port configuration
 type PortSettings struct {
-	PortID       int        `json:"port_id"`
-	PortName     string     `json:"port_name"`
-	Speed        PortSpeed  `json:"speed"`
-	IngressLimit string     `json:"ingress_limit"`
-	EgressLimit  string     `json:"egress_limit"`
-	FlowControl  bool       `json:"flow_control"`
-	Status       PortStatus `json:"status"`
-	LinkSpeed    string     `json:"link_speed"`
+	PortID        int        `json:"port_id"`
+	PortName      string     `json:"port_name"`
+	Speed         PortSpeed  `json:"speed"`
+	IngressLimit  string     `json:"ingress_limit"`
+	EgressLimit   string     `json:"egress_limit"`
+	FlowControl   bool       `json:"flow_control"`
+	Status        PortStatus `json:"status"`
+	LinkSpeed     string     `json:"link_speed"`
+	PVID          int        `json:"pvid,omitempty"`
+	UntaggedVLANs []int      `json:"untagged_vlans,omitempty"`
+	TaggedVLANs   []int      `json:"tagged_vlans,omitempty"`
 }
 
 // POEMode represents POE power mode
@@ -140,6 +226,8 @@ type POEPortUpdate struct {
 	PowerLimitType *POELimitType `json:"power_limit_type,omitempty"`
 	PowerLimitW    *float64      `json:"power_limit_w,omitempty"`
 	DetectionType  *string       `json:"detection_type,omitempty"`
+	// LongerDetectionTime gives slow-starting devices more time to be detected
+	LongerDetectionTime *bool `json:"longer_detection_time,omitempty"`
 }
 
 // PortUpdate represents changes to apply to a port
@@ -150,4 +238,141 @@ type PortUpdate struct {
 	IngressLimit *string    `json:"ingress_limit,omitempty"`
 	EgressLimit  *string    `json:"egress_limit,omitempty"`
 	FlowControl  *bool      `json:"flow_control,omitempty"`
-}
\ No newline at end of file
+}
+// DoSSettings represents the switch's denial-of-service protection configuration
+type DoSSettings struct {
+	AutoDoS        bool `json:"auto_dos"`
+	SIPEqualsDIP   bool `json:"sip_equals_dip"`
+	SMACEqualsDMAC bool `json:"smac_equals_dmac"`
+	TCPFinUrgPsh   bool `json:"tcp_fin_urg_psh"`
+	TCPSynFin      bool `json:"tcp_syn_fin"`
+	TCPFragment    bool `json:"tcp_fragment"`
+	ICMPFragment   bool `json:"icmp_fragment"`
+}
+
+// DoSUpdate represents changes to apply to the DoS protection configuration
+type DoSUpdate struct {
+	AutoDoS        *bool `json:"auto_dos,omitempty"`
+	SIPEqualsDIP   *bool `json:"sip_equals_dip,omitempty"`
+	SMACEqualsDMAC *bool `json:"smac_equals_dmac,omitempty"`
+	TCPFinUrgPsh   *bool `json:"tcp_fin_urg_psh,omitempty"`
+	TCPSynFin      *bool `json:"tcp_syn_fin,omitempty"`
+	TCPFragment    *bool `json:"tcp_fragment,omitempty"`
+	ICMPFragment   *bool `json:"icmp_fragment,omitempty"`
+}
+
+// StormControlNoLimit is the storm control rate that lets all traffic pass
+const StormControlNoLimit = "No Limit"
+
+// PortStormControl is the storm control configuration of one port. Rates are
+// shown as the switch UI lists them, e.g. "No Limit" or "1 Mbps"; a rate is
+// empty when the firmware cannot limit that traffic.
+type PortStormControl struct {
+	PortID             int    `json:"port_id"`
+	BroadcastRate      string `json:"broadcast_rate,omitempty"`
+	MulticastRate      string `json:"multicast_rate,omitempty"`
+	UnknownUnicastRate string `json:"unknown_unicast_rate,omitempty"`
+}
+
+// StormControlUpdate represents storm control changes for a single port; nil
+// rates keep their current value
+type StormControlUpdate struct {
+	PortID             int     `json:"port_id"`
+	BroadcastRate      *string `json:"broadcast_rate,omitempty"`
+	MulticastRate      *string `json:"multicast_rate,omitempty"`
+	UnknownUnicastRate *string `json:"unknown_unicast_rate,omitempty"`
+}
+
+// PortAuthControl is the 802.1X authorization mode of a port
+type PortAuthControl string
+
+const (
+	// PortAuthAuto authorizes the port once the connected device authenticates
+	PortAuthAuto PortAuthControl = "auto"
+	// PortAuthForceAuthorized passes traffic without authentication
+	PortAuthForceAuthorized PortAuthControl = "force-authorized"
+	// PortAuthForceUnauthorized blocks the port's traffic
+	PortAuthForceUnauthorized PortAuthControl = "force-unauthorized"
+)
+
+// Dot1XSettings is the 802.1X port authentication configuration of a switch
+type Dot1XSettings struct {
+	Enabled bool        `json:"enabled"`
+	Ports   []Dot1XPort `json:"ports"`
+}
+
+// Dot1XPort is the 802.1X authorization mode of one port
+type Dot1XPort struct {
+	PortID  int             `json:"port_id"`
+	Control PortAuthControl `json:"control"`
+}
+
+// Dot1XUpdate represents changes to the 802.1X configuration; Enabled nil
+// keeps the switch-wide state and ports not listed keep their mode
+type Dot1XUpdate struct {
+	Enabled *bool       `json:"enabled,omitempty"`
+	Ports   []Dot1XPort `json:"ports,omitempty"`
+}
+
+// VLANMembership represents how a port participates in an 802.1Q VLAN
+type VLANMembership string
+
+const (
+	VLANMemberUntagged VLANMembership = "untagged"
+	VLANMemberTagged   VLANMembership = "tagged"
+	VLANMemberNone     VLANMembership = "none"
+)
+
+// VLAN represents an 802.1Q VLAN and its port membership
+type VLAN struct {
+	ID      int                    `json:"id"`
+	Members map[int]VLANMembership `json:"members"`
+}
+
+// IPConfig is the management address configuration of a switch
+type IPConfig struct {
+	DHCP       bool   `json:"dhcp"`
+	Address    string `json:"address,omitempty"`
+	SubnetMask string `json:"subnet_mask,omitempty"`
+	Gateway    string `json:"gateway,omitempty"`
+}
+
+// AttachedDevice is a device the switch has seen on one of its ports
+type AttachedDevice struct {
+	PortID     int    `json:"port_id"`
+	MACAddress string `json:"mac_address"`
+	IPAddress  string `json:"ip_address,omitempty"`
+	VLAN       int    `json:"vlan,omitempty"`
+	Name       string `json:"name,omitempty"`
+}
+
+// PortBroadcastFilter is the ingress broadcast filtering state of one port
+type PortBroadcastFilter struct {
+	PortID  int  `json:"port_id"`
+	Enabled bool `json:"enabled"`
+}
+
+// SystemInfo describes the switch's identity and management configuration
+type SystemInfo struct {
+	Model        Model  `json:"model"`
+	ProductName  string `json:"product_name,omitempty"`
+	DeviceName   string `json:"device_name,omitempty"`
+	SerialNumber string `json:"serial_number,omitempty"`
+	MACAddress   string `json:"mac_address,omitempty"`
+	IPAddress    string `json:"ip_address,omitempty"`
+	SubnetMask   string `json:"subnet_mask,omitempty"`
+	Gateway      string `json:"gateway,omitempty"`
+	Firmware     string `json:"firmware,omitempty"`
+	Uptime       string `json:"uptime,omitempty"`
+	// DHCP is set when the switch takes its management address from DHCP
+	DHCP bool `json:"dhcp,omitempty"`
+	// ManagementVLAN is the VLAN the switch answers on, 0 when not shown
+	ManagementVLAN int `json:"management_vlan,omitempty"`
+
+	// BootTime is the host-clock time the switch booted, derived from Uptime
+	BootTime *time.Time `json:"boot_time,omitempty"`
+	// SkewEstimate is the switch clock's offset from the host clock, nil when
+	// the switch sent no Date header; use its ToHostTime to order
+	// switch-reported timestamps across switches
+	SkewEstimate *SkewEstimate `json:"skew_estimate,omitempty"`
+}
@@ -0,0 +1,141 @@
+package netgear
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gherlein/go-netgear/pkg/netgear/internal"
+)
+
+// NeighborSource tells how a Neighbor was discovered
+type NeighborSource string
+
+const (
+	// NeighborFromLLDP neighbors announced themselves over LLDP
+	NeighborFromLLDP NeighborSource = "lldp"
+	// NeighborFromMACTable neighbors are addresses learned on the port, with
+	// the vendor looked up from the address
+	NeighborFromMACTable NeighborSource = "mac_table"
+)
+
+// Neighbor is a device connected to one of the switch's ports
+type Neighbor struct {
+	PortID int `json:"port_id"`
+	// ChassisID identifies the remote device, usually its MAC address
+	ChassisID string `json:"chassis_id"`
+	// RemotePortID is the remote device's name for the port facing the switch
+	RemotePortID      string         `json:"remote_port_id,omitempty"`
+	PortDescription   string         `json:"port_description,omitempty"`
+	SystemName        string         `json:"system_name,omitempty"`
+	ManagementAddress string         `json:"management_address,omitempty"`
+	Vendor            string         `json:"vendor,omitempty"`
+	Source            NeighborSource `json:"source"`
+}
+
+// GetNeighbors maps the switch's ports to the devices connected to them,
+// sorted by port. Firmware with LLDP reports the remote chassis and port IDs
+// and system names; on other firmware every MAC address learned on a port is
+// a neighbor with only its vendor known, so a port leading to another
+// switch lists all devices behind it.
+func (m *PortManager) GetNeighbors(ctx context.Context) ([]Neighbor, error) {
+	if !m.client.IsAuthenticated() {
+		return nil, ErrNotAuthenticated
+	}
+
+	neighbors, err := m.lldpNeighbors(ctx)
+	if err != nil || neighbors != nil {
+		return neighbors, err
+	}
+	return m.macTableNeighbors(ctx)
+}
+
+// lldpNeighbors reads the LLDP remote device table. It returns nil without
+// an error when the firmware has no LLDP page.
+func (m *PortManager) lldpNeighbors(ctx context.Context) ([]Neighbor, error) {
+	info := m.client.endpoints.GetEndpoint(EndpointLLDPNeighbors)
+	if !info.Supported || m.client.GetQuirks().NoLLDP {
+		return nil, nil
+	}
+
+	response, err := m.client.makeAuthenticatedRequest(ctx, "GET", info.URL, nil)
+	var netgearErr *Error
+	if errors.As(err, &netgearErr) && netgearErr.HTTPStatus == http.StatusNotFound {
+		m.client.rememberQuirks(ctx, func(q *Quirks) { q.NoLLDP = true })
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := internal.ParseLLDPNeighbors(response)
+	if err != nil {
+		return nil, NewParsingError("failed to parse LLDP neighbors", err)
+	}
+
+	neighbors := make([]Neighbor, 0, len(rows))
+	for _, row := range rows {
+		portID, err := strconv.Atoi(strings.TrimSpace(row["port"]))
+		if err != nil || portID < 1 {
+			continue
+		}
+		neighbor := Neighbor{
+			PortID:            portID,
+			ChassisID:         row["chassis_id"],
+			RemotePortID:      row["remote_port"],
+			PortDescription:   row["port_description"],
+			SystemName:        row["system_name"],
+			ManagementAddress: row["management_address"],
+			Source:            NeighborFromLLDP,
+		}
+		if normalized := normalizeMAC(neighbor.ChassisID); normalized != neighbor.ChassisID {
+			neighbor.ChassisID = normalized
+			neighbor.Vendor = LookupVendor(normalized)
+		}
+		neighbors = append(neighbors, neighbor)
+	}
+	sortNeighbors(neighbors)
+	return neighbors, nil
+}
+
+// macTableNeighbors derives neighbors from the MAC address table
+func (m *PortManager) macTableNeighbors(ctx context.Context) ([]Neighbor, error) {
+	entries, err := m.client.MACTable().GetEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	neighbors := make([]Neighbor, 0, len(entries))
+	seen := make(map[Neighbor]bool)
+	for _, entry := range entries {
+		if entry.PortID < 1 {
+			continue
+		}
+		// The same address is learned once per VLAN
+		neighbor := Neighbor{
+			PortID:    entry.PortID,
+			ChassisID: entry.MACAddress,
+			Vendor:    LookupVendor(entry.MACAddress),
+			Source:    NeighborFromMACTable,
+		}
+		if !seen[neighbor] {
+			seen[neighbor] = true
+			neighbors = append(neighbors, neighbor)
+		}
+	}
+	sortNeighbors(neighbors)
+	return neighbors, nil
+}
+
+// sortNeighbors orders neighbors by port and chassis ID
+func sortNeighbors(neighbors []Neighbor) {
+	sort.Slice(neighbors, func(i, j int) bool {
+		if neighbors[i].PortID != neighbors[j].PortID {
+			return neighbors[i].PortID < neighbors[j].PortID
+		}
+		return neighbors[i].ChassisID < neighbors[j].ChassisID
+	})
+}
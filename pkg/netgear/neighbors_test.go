@@ -0,0 +1,110 @@
+package netgear
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestGetNeighborsFromLLDP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/lldpNeighbors.cgi" {
+			t.Errorf("unexpected request for %s", r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `<table>
+<tr><th>Local Port</th><th>Chassis ID</th><th>Port ID</th><th>System Name</th><th>Management Address</th></tr>
+<tr><td>8</td><td>B8-A4-4F-00-00-01</td><td>eth0</td><td>cam-lobby</td><td>192.168.1.50</td></tr>
+<tr><td>1</td><td>core-sw</td><td>Gi1/0/24</td><td>core</td><td></td></tr>
+</table>`)
+	}))
+	defer server.Close()
+
+	address := strings.TrimPrefix(server.URL, "http://")
+	client, err := NewClient(address, factoryClientOptions(address)...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	neighbors, err := client.Ports().GetNeighbors(context.Background())
+	if err != nil {
+		t.Fatalf("GetNeighbors failed: %v", err)
+	}
+	want := []Neighbor{
+		{PortID: 1, ChassisID: "core-sw", RemotePortID: "Gi1/0/24", SystemName: "core", Source: NeighborFromLLDP},
+		{PortID: 8, ChassisID: "b8:a4:4f:00:00:01", RemotePortID: "eth0", SystemName: "cam-lobby",
+			ManagementAddress: "192.168.1.50", Vendor: "Axis", Source: NeighborFromLLDP},
+	}
+	if len(neighbors) != len(want) {
+		t.Fatalf("expected %d neighbors, got %+v", len(want), neighbors)
+	}
+	for i := range want {
+		if neighbors[i] != want[i] {
+			t.Errorf("neighbor %d = %+v, want %+v", i, neighbors[i], want[i])
+		}
+	}
+}
+
+func TestGetNeighborsFallsBackToMACTable(t *testing.T) {
+	var lldpRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/macAddrTable.cgi":
+			fmt.Fprint(w, `<table>
+<tr><th>VLAN ID</th><th>MAC Address</th><th>Port</th></tr>
+<tr><td>1</td><td>b8:27:eb:00:00:01</td><td>3</td></tr>
+<tr><td>10</td><td>b8:27:eb:00:00:01</td><td>3</td></tr>
+<tr><td>1</td><td>02:00:00:00:00:09</td><td>2</td></tr>
+</table>`)
+		case "/lldpNeighbors.cgi":
+			lldpRequests.Add(1)
+			http.NotFound(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	address := strings.TrimPrefix(server.URL, "http://")
+	client, err := NewClient(address, factoryClientOptions(address)...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		neighbors, err := client.Ports().GetNeighbors(ctx)
+		if err != nil {
+			t.Fatalf("GetNeighbors failed: %v", err)
+		}
+		want := []Neighbor{
+			{PortID: 2, ChassisID: "02:00:00:00:00:09", Source: NeighborFromMACTable},
+			{PortID: 3, ChassisID: "b8:27:eb:00:00:01", Vendor: "Raspberry Pi", Source: NeighborFromMACTable},
+		}
+		if len(neighbors) != len(want) || neighbors[0] != want[0] || neighbors[1] != want[1] {
+			t.Errorf("expected %+v, got %+v", want, neighbors)
+		}
+	}
+	if n := lldpRequests.Load(); n != 1 {
+		t.Errorf("expected the missing LLDP page to be requested once, got %d", n)
+	}
+}
+
+func TestLookupVendor(t *testing.T) {
+	for mac, want := range map[string]string{
+		"38:94:ED:00:11:22": "",
+		"00-40-8C-12-34-56": "Axis",
+		"e091.f500.0001":    "Netgear",
+		"06:40:8c:12:34:56": "",
+		"zz":                "",
+	} {
+		if got := LookupVendor(mac); got != want {
+			t.Errorf("LookupVendor(%q) = %q, want %q", mac, got, want)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+// Package netgeartest provides test doubles for code built on pkg/netgear.
+package netgeartest
+
+import (
+	"time"
+
+	"github.com/gherlein/go-netgear/pkg/netgear/internal/fakeclock"
+)
+
+// FakeClock is a netgear.Clock whose time only moves when Advance is called,
+// so retries, waits and schedules can be tested without real sleeps
+type FakeClock = fakeclock.FakeClock
+
+// NewFakeClock creates a fake clock set to start
+func NewFakeClock(start time.Time) *FakeClock {
+	return fakeclock.New(start)
+}
@@ -0,0 +1,49 @@
+package netgeartest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gherlein/go-netgear/pkg/netgear"
+)
+
+var _ netgear.Clock = (*FakeClock)(nil)
+
+func TestFakeClockSleep(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	ctx := context.Background()
+
+	done := make(chan error, 1)
+	go func() { done <- clock.Sleep(ctx, time.Minute) }()
+
+	if err := clock.BlockUntil(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	clock.Advance(30 * time.Second)
+	select {
+	case <-done:
+		t.Fatal("Sleep returned before its deadline")
+	default:
+	}
+
+	clock.Advance(30 * time.Second)
+	if err := <-done; err != nil {
+		t.Fatalf("Sleep returned %v", err)
+	}
+	if got := clock.Now(); !got.Equal(start.Add(time.Minute)) {
+		t.Errorf("Expected %v, got %v", start.Add(time.Minute), got)
+	}
+}
+
+func TestFakeClockSleepCancelled(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := clock.Sleep(ctx, time.Hour); err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,478 @@
+package netgeartest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/gherlein/go-netgear/pkg/netgear"
+)
+
+// FakeClient is an in-memory netgear.SwitchClient. It starts logged in with
+// every port up, named "Port N" and delivering no POE power; updates change
+// its state the way the switch would, so code under test can read back what
+// it wrote. It is safe for concurrent use.
+type FakeClient struct {
+	mu            sync.Mutex
+	address       string
+	model         netgear.Model
+	password      string
+	authenticated bool
+	err           error
+	ports         []netgear.PortSettings
+	poeSettings   []netgear.POEPortSettings
+	poeStatus     []netgear.POEPortStatus
+	statistics    []netgear.PortStatistics
+	neighbors     []netgear.Neighbor
+	cycles        [][]int
+}
+
+var _ netgear.SwitchClient = (*FakeClient)(nil)
+
+// NewFakeClient creates a fake switch of the given model, with as many ports
+// as the model has (8 when unknown)
+func NewFakeClient(model netgear.Model) *FakeClient {
+	count := model.PortCount()
+	if count == 0 {
+		count = 8
+	}
+	c := &FakeClient{address: "fake-" + string(model), model: model, authenticated: true}
+	for id := 1; id <= count; id++ {
+		name := fmt.Sprintf("Port %d", id)
+		c.ports = append(c.ports, netgear.PortSettings{
+			PortID: id, PortName: name, Speed: netgear.PortSpeedAuto, Status: netgear.PortStatusConnected,
+			LinkSpeed: "1000M", IngressLimit: "No Limit", EgressLimit: "No Limit", PVID: 1, UntaggedVLANs: []int{1},
+		})
+		c.poeSettings = append(c.poeSettings, netgear.POEPortSettings{
+			PortID: id, PortName: name, Enabled: true, Mode: netgear.POEMode8023at,
+			Priority: netgear.POEPriorityLow, PowerLimitType: netgear.POELimitTypeClass,
+		})
+		c.poeStatus = append(c.poeStatus, netgear.POEPortStatus{PortID: id, PortName: name, Status: "Searching"})
+		c.statistics = append(c.statistics, netgear.PortStatistics{PortID: id})
+	}
+	return c
+}
+
+// SetPassword makes Login fail unless given password
+func (c *FakeClient) SetPassword(password string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.password = password
+}
+
+// Fail makes every following operation return err, until Fail(nil)
+func (c *FakeClient) Fail(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.err = err
+}
+
+// SetPOEStatus replaces the POE reading of status.PortID
+func (c *FakeClient) SetPOEStatus(status netgear.POEPortStatus) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if i := c.index(status.PortID); i >= 0 {
+		c.poeStatus[i] = status
+	}
+}
+
+// SetLinkStatus sets the link state and speed the switch reports for a port
+func (c *FakeClient) SetLinkStatus(portID int, status netgear.PortStatus, linkSpeed string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if i := c.index(portID); i >= 0 {
+		c.ports[i].Status = status
+		c.ports[i].LinkSpeed = linkSpeed
+	}
+}
+
+// SetStatistics replaces the traffic counters of stats.PortID
+func (c *FakeClient) SetStatistics(stats netgear.PortStatistics) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if i := c.index(stats.PortID); i >= 0 {
+		c.statistics[i] = stats
+	}
+}
+
+// SetNeighbors replaces the neighbors GetNeighbors reports
+func (c *FakeClient) SetNeighbors(neighbors ...netgear.Neighbor) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.neighbors = append([]netgear.Neighbor(nil), neighbors...)
+}
+
+// Cycles returns the port lists passed to CyclePower, in call order
+func (c *FakeClient) Cycles() [][]int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cycles := make([][]int, len(c.cycles))
+	for i, ports := range c.cycles {
+		cycles[i] = append([]int(nil), ports...)
+	}
+	return cycles
+}
+
+// GetAddress returns the fake switch's address
+func (c *FakeClient) GetAddress() string {
+	return c.address
+}
+
+// GetModel returns the model given to NewFakeClient
+func (c *FakeClient) GetModel() netgear.Model {
+	return c.model
+}
+
+// IsAuthenticated reports whether the client is logged in
+func (c *FakeClient) IsAuthenticated() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.authenticated
+}
+
+// Login logs in, checking the password set with SetPassword if any
+func (c *FakeClient) Login(ctx context.Context, password string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return c.err
+	}
+	if c.password != "" && password != c.password {
+		return netgear.ErrInvalidCredentials.WithSwitch(c.address, c.model)
+	}
+	c.authenticated = true
+	return nil
+}
+
+// Logout ends the session; operations then fail until Login
+func (c *FakeClient) Logout(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.authenticated = false
+	return nil
+}
+
+// POEController returns the fake's POE management
+func (c *FakeClient) POEController() netgear.POEController {
+	return fakePOE{c}
+}
+
+// PortController returns the fake's port management
+func (c *FakeClient) PortController() netgear.PortController {
+	return fakePorts{c}
+}
+
+// check returns the error an operation fails with; c.mu must be held
+func (c *FakeClient) check() error {
+	if c.err != nil {
+		return c.err
+	}
+	if !c.authenticated {
+		return netgear.ErrNotAuthenticated.WithSwitch(c.address, c.model)
+	}
+	return nil
+}
+
+// index returns the position of a port, or -1
+func (c *FakeClient) index(portID int) int {
+	if portID < 1 || portID > len(c.ports) {
+		return -1
+	}
+	return portID - 1
+}
+
+// portError is the error for a port the switch does not have
+func (c *FakeClient) portError(portID int) error {
+	return netgear.NewOperationError(fmt.Sprintf("port %d not found", portID), nil).WithSwitch(c.address, c.model).WithPort(portID)
+}
+
+// rename names a port on every page, as the switch shares one name
+func (c *FakeClient) rename(i int, name string) {
+	c.ports[i].PortName = name
+	c.poeSettings[i].PortName = name
+	c.poeStatus[i].PortName = name
+}
+
+// fakePOE is the POEController of a FakeClient
+type fakePOE struct{ c *FakeClient }
+
+func (p fakePOE) GetStatus(ctx context.Context) ([]netgear.POEPortStatus, error) {
+	p.c.mu.Lock()
+	defer p.c.mu.Unlock()
+	if err := p.c.check(); err != nil {
+		return nil, err
+	}
+	return append([]netgear.POEPortStatus(nil), p.c.poeStatus...), nil
+}
+
+func (p fakePOE) GetSettings(ctx context.Context) ([]netgear.POEPortSettings, error) {
+	p.c.mu.Lock()
+	defer p.c.mu.Unlock()
+	if err := p.c.check(); err != nil {
+		return nil, err
+	}
+	return append([]netgear.POEPortSettings(nil), p.c.poeSettings...), nil
+}
+
+func (p fakePOE) GetPortStatus(ctx context.Context, portID int) (*netgear.POEPortStatus, error) {
+	p.c.mu.Lock()
+	defer p.c.mu.Unlock()
+	if err := p.c.check(); err != nil {
+		return nil, err
+	}
+	i := p.c.index(portID)
+	if i < 0 {
+		return nil, p.c.portError(portID)
+	}
+	status := p.c.poeStatus[i]
+	return &status, nil
+}
+
+func (p fakePOE) GetPortSettings(ctx context.Context, portID int) (*netgear.POEPortSettings, error) {
+	p.c.mu.Lock()
+	defer p.c.mu.Unlock()
+	if err := p.c.check(); err != nil {
+		return nil, err
+	}
+	i := p.c.index(portID)
+	if i < 0 {
+		return nil, p.c.portError(portID)
+	}
+	settings := p.c.poeSettings[i]
+	return &settings, nil
+}
+
+func (p fakePOE) GetBudget(ctx context.Context) (*netgear.POEBudget, error) {
+	p.c.mu.Lock()
+	defer p.c.mu.Unlock()
+	if err := p.c.check(); err != nil {
+		return nil, err
+	}
+	total, known := netgear.POEBudgetW(p.c.model)
+	if !known {
+		return nil, netgear.NewOperationError(fmt.Sprintf("POE budget unknown for model %s", p.c.model), nil)
+	}
+	budget := &netgear.POEBudget{TotalW: total, Source: netgear.POEBudgetEstimated}
+	for _, status := range p.c.poeStatus {
+		budget.ConsumedW += status.PowerW
+	}
+	budget.RemainingW = budget.TotalW - budget.ConsumedW
+	return budget, nil
+}
+
+func (p fakePOE) UpdatePort(ctx context.Context, updates ...netgear.POEPortUpdate) error {
+	p.c.mu.Lock()
+	defer p.c.mu.Unlock()
+	if err := p.c.check(); err != nil {
+		return err
+	}
+	for _, update := range updates {
+		if p.c.index(update.PortID) < 0 {
+			return p.c.portError(update.PortID)
+		}
+	}
+	for _, update := range updates {
+		settings := &p.c.poeSettings[p.c.index(update.PortID)]
+		if update.Enabled != nil {
+			settings.Enabled = *update.Enabled
+		}
+		if update.Mode != nil {
+			settings.Mode = *update.Mode
+		}
+		if update.Priority != nil {
+			settings.Priority = *update.Priority
+		}
+		if update.PowerLimitType != nil {
+			settings.PowerLimitType = *update.PowerLimitType
+		}
+		if update.PowerLimitW != nil {
+			settings.PowerLimitW = *update.PowerLimitW
+		}
+		if update.DetectionType != nil {
+			settings.DetectionType = *update.DetectionType
+		}
+	}
+	return nil
+}
+
+func (p fakePOE) EnablePort(ctx context.Context, portID int) error {
+	enabled := true
+	return p.UpdatePort(ctx, netgear.POEPortUpdate{PortID: portID, Enabled: &enabled})
+}
+
+func (p fakePOE) DisablePort(ctx context.Context, portID int) error {
+	enabled := false
+	return p.UpdatePort(ctx, netgear.POEPortUpdate{PortID: portID, Enabled: &enabled})
+}
+
+func (p fakePOE) SetPortMode(ctx context.Context, portID int, mode netgear.POEMode) error {
+	return p.UpdatePort(ctx, netgear.POEPortUpdate{PortID: portID, Mode: &mode})
+}
+
+func (p fakePOE) SetPortPriority(ctx context.Context, portID int, priority netgear.POEPriority) error {
+	return p.UpdatePort(ctx, netgear.POEPortUpdate{PortID: portID, Priority: &priority})
+}
+
+func (p fakePOE) SetPortPowerLimit(ctx context.Context, portID int, limitType netgear.POELimitType, limitW float64) error {
+	return p.UpdatePort(ctx, netgear.POEPortUpdate{PortID: portID, PowerLimitType: &limitType, PowerLimitW: &limitW})
+}
+
+func (p fakePOE) CyclePower(ctx context.Context, portIDs ...int) error {
+	p.c.mu.Lock()
+	defer p.c.mu.Unlock()
+	if err := p.c.check(); err != nil {
+		return err
+	}
+	for _, portID := range portIDs {
+		if p.c.index(portID) < 0 {
+			return p.c.portError(portID)
+		}
+	}
+	p.c.cycles = append(p.c.cycles, append([]int(nil), portIDs...))
+	return nil
+}
+
+// fakePorts is the PortController of a FakeClient
+type fakePorts struct{ c *FakeClient }
+
+func (p fakePorts) GetSettings(ctx context.Context) ([]netgear.PortSettings, error) {
+	p.c.mu.Lock()
+	defer p.c.mu.Unlock()
+	if err := p.c.check(); err != nil {
+		return nil, err
+	}
+	settings := make([]netgear.PortSettings, len(p.c.ports))
+	for i, port := range p.c.ports {
+		settings[i] = clonePort(port)
+	}
+	return settings, nil
+}
+
+func (p fakePorts) GetPortSettings(ctx context.Context, portID int) (*netgear.PortSettings, error) {
+	p.c.mu.Lock()
+	defer p.c.mu.Unlock()
+	if err := p.c.check(); err != nil {
+		return nil, err
+	}
+	i := p.c.index(portID)
+	if i < 0 {
+		return nil, p.c.portError(portID)
+	}
+	settings := clonePort(p.c.ports[i])
+	return &settings, nil
+}
+
+func (p fakePorts) UpdatePort(ctx context.Context, updates ...netgear.PortUpdate) error {
+	p.c.mu.Lock()
+	defer p.c.mu.Unlock()
+	if err := p.c.check(); err != nil {
+		return err
+	}
+	for _, update := range updates {
+		if p.c.index(update.PortID) < 0 {
+			return p.c.portError(update.PortID)
+		}
+	}
+	for _, update := range updates {
+		i := p.c.index(update.PortID)
+		port := &p.c.ports[i]
+		if update.Name != nil {
+			p.c.rename(i, *update.Name)
+		}
+		if update.Speed != nil {
+			port.Speed = *update.Speed
+			if *update.Speed == netgear.PortSpeedDisable {
+				port.Status, port.LinkSpeed = netgear.PortStatusDisabled, ""
+			} else if port.Status == netgear.PortStatusDisabled {
+				port.Status = netgear.PortStatusConnected
+			}
+		}
+		if update.IngressLimit != nil {
+			port.IngressLimit = *update.IngressLimit
+		}
+		if update.EgressLimit != nil {
+			port.EgressLimit = *update.EgressLimit
+		}
+		if update.FlowControl != nil {
+			port.FlowControl = *update.FlowControl
+		}
+	}
+	return nil
+}
+
+func (p fakePorts) SetPortName(ctx context.Context, portID int, name string) error {
+	return p.UpdatePort(ctx, netgear.PortUpdate{PortID: portID, Name: &name})
+}
+
+func (p fakePorts) SetPortSpeed(ctx context.Context, portID int, speed netgear.PortSpeed) error {
+	return p.UpdatePort(ctx, netgear.PortUpdate{PortID: portID, Speed: &speed})
+}
+
+func (p fakePorts) SetPortFlowControl(ctx context.Context, portID int, enabled bool) error {
+	return p.UpdatePort(ctx, netgear.PortUpdate{PortID: portID, FlowControl: &enabled})
+}
+
+func (p fakePorts) SetPortLimits(ctx context.Context, portID int, ingressLimit, egressLimit string) error {
+	return p.UpdatePort(ctx, netgear.PortUpdate{PortID: portID, IngressLimit: &ingressLimit, EgressLimit: &egressLimit})
+}
+
+func (p fakePorts) EnablePort(ctx context.Context, portID int) error {
+	return p.SetPortSpeed(ctx, portID, netgear.PortSpeedAuto)
+}
+
+func (p fakePorts) DisablePort(ctx context.Context, portID int) error {
+	return p.SetPortSpeed(ctx, portID, netgear.PortSpeedDisable)
+}
+
+func (p fakePorts) GetStatistics(ctx context.Context) ([]netgear.PortStatistics, error) {
+	p.c.mu.Lock()
+	defer p.c.mu.Unlock()
+	if err := p.c.check(); err != nil {
+		return nil, err
+	}
+	return append([]netgear.PortStatistics(nil), p.c.statistics...), nil
+}
+
+func (p fakePorts) GetPortStatistics(ctx context.Context, portID int) (*netgear.PortStatistics, error) {
+	p.c.mu.Lock()
+	defer p.c.mu.Unlock()
+	if err := p.c.check(); err != nil {
+		return nil, err
+	}
+	i := p.c.index(portID)
+	if i < 0 {
+		return nil, p.c.portError(portID)
+	}
+	stats := p.c.statistics[i]
+	return &stats, nil
+}
+
+func (p fakePorts) ResetStatistics(ctx context.Context, portID int) error {
+	p.c.mu.Lock()
+	defer p.c.mu.Unlock()
+	if err := p.c.check(); err != nil {
+		return err
+	}
+	i := p.c.index(portID)
+	if i < 0 {
+		return p.c.portError(portID)
+	}
+	p.c.statistics[i] = netgear.PortStatistics{PortID: portID}
+	return nil
+}
+
+func (p fakePorts) GetNeighbors(ctx context.Context) ([]netgear.Neighbor, error) {
+	p.c.mu.Lock()
+	defer p.c.mu.Unlock()
+	if err := p.c.check(); err != nil {
+		return nil, err
+	}
+	return append([]netgear.Neighbor{}, p.c.neighbors...), nil
+}
+
+// clonePort copies port settings including their VLAN lists
+func clonePort(port netgear.PortSettings) netgear.PortSettings {
+	port.UntaggedVLANs = append([]int(nil), port.UntaggedVLANs...)
+	port.TaggedVLANs = append([]int(nil), port.TaggedVLANs...)
+	return port
+}
@@ -0,0 +1,87 @@
+package netgeartest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/gherlein/go-netgear/pkg/netgear"
+)
+
+// renameAndCycle stands for downstream code written against the interfaces
+func renameAndCycle(ctx context.Context, sw netgear.SwitchClient, portID int, name string) error {
+	if err := sw.PortController().SetPortName(ctx, portID, name); err != nil {
+		return err
+	}
+	return sw.POEController().CyclePower(ctx, portID)
+}
+
+func TestFakeClient(t *testing.T) {
+	ctx := context.Background()
+	fake := NewFakeClient(netgear.ModelGS308EPP)
+
+	if err := renameAndCycle(ctx, fake, 3, "camera-lobby"); err != nil {
+		t.Fatalf("renameAndCycle failed: %v", err)
+	}
+	if cycles := fake.Cycles(); !reflect.DeepEqual(cycles, [][]int{{3}}) {
+		t.Errorf("expected port 3 to be cycled, got %v", cycles)
+	}
+	status, err := fake.POEController().GetPortStatus(ctx, 3)
+	if err != nil || status.PortName != "camera-lobby" {
+		t.Errorf("expected the name on the POE page too, got %+v (%v)", status, err)
+	}
+
+	ports, _ := fake.PortController().GetSettings(ctx)
+	if len(ports) != 8 {
+		t.Errorf("expected the model's 8 ports, got %d", len(ports))
+	}
+	fake.PortController().DisablePort(ctx, 2)
+	if port, _ := fake.PortController().GetPortSettings(ctx, 2); port.Status != netgear.PortStatusDisabled {
+		t.Errorf("expected a disabled port, got %s", port.Status)
+	}
+
+	fake.POEController().SetPortPowerLimit(ctx, 1, netgear.POELimitTypeUser, 15.4)
+	if settings, _ := fake.POEController().GetPortSettings(ctx, 1); settings.PowerLimitType != netgear.POELimitTypeUser || settings.PowerLimitW != 15.4 {
+		t.Errorf("expected the user limit, got %+v", settings)
+	}
+
+	fake.SetPOEStatus(netgear.POEPortStatus{PortID: 1, PowerW: 20})
+	if budget, err := fake.POEController().GetBudget(ctx); err != nil || budget.ConsumedW != 20 || budget.RemainingW != 103 {
+		t.Errorf("unexpected budget %+v (%v)", budget, err)
+	}
+
+	fake.SetStatistics(netgear.PortStatistics{PortID: 4, RxBytes: 4096, CRCErrors: 2})
+	fake.PortController().ResetStatistics(ctx, 4)
+	if stats, err := fake.PortController().GetPortStatistics(ctx, 4); err != nil || stats.RxBytes != 0 || stats.CRCErrors != 0 {
+		t.Errorf("expected reset counters, got %+v (%v)", stats, err)
+	}
+
+	fake.SetNeighbors(netgear.Neighbor{PortID: 8, ChassisID: "core-sw", Source: netgear.NeighborFromLLDP})
+	if neighbors, err := fake.PortController().GetNeighbors(ctx); err != nil || len(neighbors) != 1 || neighbors[0].PortID != 8 {
+		t.Errorf("unexpected neighbors %+v (%v)", neighbors, err)
+	}
+
+	if err := fake.POEController().CyclePower(ctx, 9); err == nil {
+		t.Error("expected an error for a port the model lacks")
+	}
+
+	failure := errors.New("switch unreachable")
+	fake.Fail(failure)
+	if err := renameAndCycle(ctx, fake, 1, "x"); !errors.Is(err, failure) {
+		t.Errorf("expected the injected failure, got %v", err)
+	}
+	fake.Fail(nil)
+
+	fake.SetPassword("s3cret")
+	fake.Logout(ctx)
+	if _, err := fake.POEController().GetStatus(ctx); !errors.Is(err, netgear.ErrNotAuthenticated) {
+		t.Errorf("expected ErrNotAuthenticated after logout, got %v", err)
+	}
+	if err := fake.Login(ctx, "wrong"); !errors.Is(err, netgear.ErrInvalidCredentials) {
+		t.Errorf("expected ErrInvalidCredentials, got %v", err)
+	}
+	if err := fake.Login(ctx, "s3cret"); err != nil || !fake.IsAuthenticated() {
+		t.Errorf("expected to log in, got %v", err)
+	}
+}
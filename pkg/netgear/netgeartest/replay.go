@@ -0,0 +1,23 @@
+package netgeartest
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gherlein/go-netgear/pkg/netgear/fixture"
+)
+
+// NewReplaySwitch starts a mock switch answering from the fixtures recorded
+// in dir with netgear.WithRecorder and returns its address for
+// netgear.NewClient. The server is closed when the test ends.
+func NewReplaySwitch(t testing.TB, dir string) string {
+	t.Helper()
+	replay, err := fixture.Load(dir)
+	if err != nil {
+		t.Fatalf("failed to load fixtures: %v", err)
+	}
+	server := httptest.NewServer(replay)
+	t.Cleanup(server.Close)
+	return strings.TrimPrefix(server.URL, "http://")
+}
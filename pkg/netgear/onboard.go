@@ -0,0 +1,325 @@
+package netgear
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// OnboardStep names one stage of Onboard
+type OnboardStep string
+
+const (
+	OnboardStepDiscover OnboardStep = "discover"
+	OnboardStepLogin    OnboardStep = "login" // includes replacing the factory default password
+	OnboardStepName     OnboardStep = "name"
+	OnboardStepConfig   OnboardStep = "config"
+	OnboardStepAddress  OnboardStep = "address"
+)
+
+// onboardSteps is the order Onboard runs its steps in. The address changes
+// last so every other step runs over a stable connection.
+var onboardSteps = []OnboardStep{
+	OnboardStepDiscover, OnboardStepLogin, OnboardStepName, OnboardStepConfig, OnboardStepAddress,
+}
+
+// OnboardSpec describes how to take a switch from out-of-box to managed
+type OnboardSpec struct {
+	// Address is where the switch can be reached now. If empty, the switch is
+	// found with ResolveByMDNS using DiscoverPattern.
+	Address         string
+	DiscoverPattern string
+
+	// Password is the administrator password the switch should end up with.
+	// CurrentPassword is tried if Password is rejected; it defaults to the
+	// factory default password.
+	Password        string
+	CurrentPassword string
+
+	// Name, StaticIP and Config are optional; steps for unset fields are skipped
+	Name     string
+	StaticIP *IPConfig
+	Config   *SwitchConfigSpec
+
+	// StateFile records completed steps so a failed onboarding resumes where
+	// it stopped instead of starting over. Empty disables resuming.
+	StateFile string
+
+	// Progress, if set, is called as each step starts and finishes
+	Progress func(OnboardProgress)
+
+	// ClientOptions are applied to every client Onboard creates
+	ClientOptions []ClientOption
+}
+
+// OnboardProgress reports the state of one step
+type OnboardProgress struct {
+	Step    OnboardStep
+	Done    bool
+	Skipped bool
+	Err     error
+}
+
+// OnboardResult is the outcome of a successful Onboard
+type OnboardResult struct {
+	Address string
+	Model   Model
+	Changes []ConfigChange
+	// Client is authenticated against the switch at Address
+	Client *Client
+}
+
+// onboardState is the resumable progress persisted to OnboardSpec.StateFile
+type onboardState struct {
+	Address   string        `json:"address"`
+	Completed []OnboardStep `json:"completed"`
+}
+
+func (s *onboardState) done(step OnboardStep) bool {
+	for _, completed := range s.Completed {
+		if completed == step {
+			return true
+		}
+	}
+	return false
+}
+
+// onboardReconnectInterval is how often Onboard polls for the switch at its new address
+const onboardReconnectInterval = 2 * time.Second
+
+// Onboard discovers a switch, replaces its factory default password, names
+// it, applies a baseline configuration and moves it to its static address.
+// With a StateFile, calling Onboard again after a failure skips the steps that
+// already completed. ctx bounds the whole workflow, including waiting for the
+// switch to come back on its new address.
+func Onboard(ctx context.Context, spec OnboardSpec) (*OnboardResult, error) {
+	if spec.Password == "" {
+		return nil, NewOperationError("onboarding requires the password the switch should use", nil)
+	}
+	if spec.Address == "" && spec.DiscoverPattern == "" {
+		return nil, NewOperationError("onboarding requires an address or a discovery pattern", nil)
+	}
+	if spec.CurrentPassword == "" {
+		spec.CurrentPassword = DefaultPassword
+	}
+
+	state, err := loadOnboardState(spec.StateFile)
+	if err != nil {
+		return nil, err
+	}
+	if state.Address == "" {
+		state.Address = spec.Address
+	}
+
+	o := &onboarding{spec: spec, state: state, result: &OnboardResult{}}
+	for _, step := range onboardSteps {
+		if err := o.run(ctx, step); err != nil {
+			return nil, fmt.Errorf("onboarding step %s: %w", step, err)
+		}
+	}
+
+	// A fully resumed run skipped every step that would have logged in
+	if o.client == nil {
+		if err := o.connect(ctx); err != nil {
+			return nil, fmt.Errorf("onboarding step %s: %w", OnboardStepLogin, err)
+		}
+	}
+
+	o.result.Address = o.state.Address
+	return o.result, nil
+}
+
+// onboarding carries the state of one Onboard call between steps
+type onboarding struct {
+	spec   OnboardSpec
+	state  *onboardState
+	client *Client
+	result *OnboardResult
+}
+
+// run executes a step unless it already completed or has nothing to do, then records it
+func (o *onboarding) run(ctx context.Context, step OnboardStep) error {
+	report := func(p OnboardProgress) {
+		if o.spec.Progress != nil {
+			p.Step = step
+			o.spec.Progress(p)
+		}
+	}
+
+	if o.state.done(step) || o.skip(step) {
+		report(OnboardProgress{Done: true, Skipped: true})
+		return nil
+	}
+
+	report(OnboardProgress{})
+	if err := o.execute(ctx, step); err != nil {
+		report(OnboardProgress{Err: err})
+		return err
+	}
+
+	o.state.Completed = append(o.state.Completed, step)
+	if err := o.save(); err != nil {
+		return err
+	}
+	report(OnboardProgress{Done: true})
+	return nil
+}
+
+// skip reports whether the spec asks for nothing in this step. Login always
+// runs since later steps and the result need an authenticated client.
+func (o *onboarding) skip(step OnboardStep) bool {
+	switch step {
+	case OnboardStepDiscover:
+		return o.state.Address != ""
+	case OnboardStepName:
+		return o.spec.Name == ""
+	case OnboardStepConfig:
+		return o.spec.Config == nil
+	case OnboardStepAddress:
+		return o.spec.StaticIP == nil
+	}
+	return false
+}
+
+func (o *onboarding) execute(ctx context.Context, step OnboardStep) error {
+	// Every step after discovery needs a client; a resumed run reconnects here
+	if step != OnboardStepDiscover && o.client == nil {
+		if err := o.connect(ctx); err != nil {
+			return err
+		}
+		if step == OnboardStepLogin {
+			return nil
+		}
+	}
+
+	switch step {
+	case OnboardStepDiscover:
+		found, err := ResolveByMDNS(ctx, o.spec.DiscoverPattern)
+		if err != nil {
+			return err
+		}
+		if len(found) == 0 || len(found[0].Addresses) == 0 {
+			return NewNetworkError(fmt.Sprintf("no switch matching %q answered mDNS discovery", o.spec.DiscoverPattern), nil)
+		}
+		if len(found) > 1 {
+			return NewOperationError(fmt.Sprintf("%d switches match %q; onboard them one at a time by address", len(found), o.spec.DiscoverPattern), nil)
+		}
+		o.state.Address = found[0].Addresses[0]
+		return nil
+
+	case OnboardStepLogin:
+		return nil // connect logged in
+
+	case OnboardStepName:
+		return o.client.System().SetDeviceName(ctx, o.spec.Name)
+
+	case OnboardStepConfig:
+		plan, err := o.client.Config().Plan(ctx, o.spec.Config)
+		if err != nil {
+			return err
+		}
+		if err := o.client.Config().Apply(ctx, plan); err != nil {
+			return err
+		}
+		o.result.Changes = plan.Changes
+		return nil
+
+	case OnboardStepAddress:
+		return o.moveAddress(ctx)
+	}
+	return nil
+}
+
+// connect logs in to the switch at the recorded address, replacing the
+// factory default password if the switch still has it
+func (o *onboarding) connect(ctx context.Context) error {
+	client, err := NewClient(o.state.Address, o.spec.ClientOptions...)
+	if err != nil {
+		return err
+	}
+
+	err = client.Login(ctx, o.spec.Password)
+	if errors.Is(err, ErrInvalidCredentials) {
+		err = client.Login(ctx, o.spec.CurrentPassword)
+	}
+	if errors.Is(err, ErrInitialPasswordRequired) {
+		err = client.System().SetInitialPassword(ctx, o.spec.Password)
+	}
+	if err != nil {
+		return err
+	}
+
+	o.client = client
+	o.result.Client = client
+	o.result.Model = client.GetModel()
+	return nil
+}
+
+// moveAddress applies the static IP configuration and reconnects to the
+// switch at its new address
+func (o *onboarding) moveAddress(ctx context.Context) error {
+	newAddress := o.spec.StaticIP.Address
+	if o.spec.StaticIP.DHCP || newAddress == o.state.Address {
+		return o.client.System().SetIPConfig(ctx, *o.spec.StaticIP)
+	}
+
+	// The switch moves before answering, so a network error here is expected
+	if err := o.client.System().SetIPConfig(ctx, *o.spec.StaticIP); err != nil {
+		var netgearErr *Error
+		if !errors.As(err, &netgearErr) || netgearErr.Type != ErrorTypeNetwork {
+			return err
+		}
+	}
+
+	o.state.Address = newAddress
+	if err := o.save(); err != nil {
+		return err
+	}
+
+	clock := o.client.Clock()
+	o.client = nil
+	for {
+		err := o.connect(ctx)
+		if err == nil {
+			return nil
+		}
+		if sleepErr := clock.Sleep(ctx, onboardReconnectInterval); sleepErr != nil {
+			return NewNetworkError(fmt.Sprintf("switch did not come back on %s", newAddress), err)
+		}
+	}
+}
+
+// save persists progress when a state file is configured
+func (o *onboarding) save() error {
+	if o.spec.StateFile == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(o.state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal onboarding state: %w", err)
+	}
+	return writeFileAtomic(o.spec.StateFile, data, 0600)
+}
+
+// loadOnboardState reads saved progress, returning empty progress if there is none
+func loadOnboardState(filename string) (*onboardState, error) {
+	state := &onboardState{}
+	if filename == "" {
+		return state, nil
+	}
+
+	data, err := os.ReadFile(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read onboarding state: %w", err)
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse onboarding state: %w", err)
+	}
+	return state, nil
+}
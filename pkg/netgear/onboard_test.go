@@ -0,0 +1,50 @@
+package netgear
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestOnboardResumes(t *testing.T) {
+	const newPassword = "Sup3rSecret"
+	sw, address := newFactorySwitch(t, newPassword)
+	stateFile := filepath.Join(t.TempDir(), "onboard.json")
+
+	var steps []OnboardProgress
+	spec := OnboardSpec{
+		Address:       address,
+		Password:      newPassword,
+		Name:          "lobby-sw1",
+		StateFile:     stateFile,
+		ClientOptions: factoryClientOptions(address),
+		Progress:      func(p OnboardProgress) { steps = append(steps, p) },
+	}
+
+	result, err := Onboard(context.Background(), spec)
+	if err != nil {
+		t.Fatalf("Onboard failed: %v", err)
+	}
+	if !sw.changed || sw.name != "lobby-sw1" {
+		t.Fatalf("expected password change and name, got changed=%v name=%q", sw.changed, sw.name)
+	}
+	if result.Address != address || result.Client == nil || !result.Client.IsAuthenticated() {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	// A second run finds every step recorded as complete and only logs in
+	sw.name = ""
+	steps = nil
+	spec.ClientOptions = factoryClientOptions(address)
+	if _, err := Onboard(context.Background(), spec); err != nil {
+		t.Fatalf("resumed Onboard failed: %v", err)
+	}
+	if sw.name != "" {
+		t.Error("resumed run repeated the name step")
+	}
+	for _, p := range steps {
+		if !p.Skipped {
+			t.Errorf("expected step %s to be skipped on resume", p.Step)
+		}
+	}
+}
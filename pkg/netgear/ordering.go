@@ -0,0 +1,15 @@
+package netgear
+
+import "sort"
+
+// Port lists returned by the managers and FetchAll are sorted by port
+// number, whatever order the firmware's pages list them in, so output
+// piped into jq or dashboards does not reorder between polls or releases.
+// The JSON field names of the API structs are part of the API as well; see
+// docs/json-output.md.
+
+// sortByPort sorts items by the port number returned by port, keeping the
+// page order of entries for the same port
+func sortByPort[T any](items []T, port func(T) int) {
+	sort.SliceStable(items, func(i, j int) bool { return port(items[i]) < port(items[j]) })
+}
@@ -0,0 +1,61 @@
+package netgear
+
+import "strings"
+
+// ouiVendors maps the OUI (first three bytes, upper-case hex without
+// separators) of MAC addresses to their vendor. It is a small curated list of
+// the vendors found on small office and camera networks, not the IEEE
+// registry; LookupVendor returns "" for other addresses.
+var ouiVendors = map[string]string{
+	// Netgear
+	"00095B": "Netgear", "000FB5": "Netgear", "00146C": "Netgear", "00184D": "Netgear",
+	"001B2F": "Netgear", "001E2A": "Netgear", "001F33": "Netgear", "00223F": "Netgear",
+	"0024B2": "Netgear", "0026F2": "Netgear", "204E7F": "Netgear", "28C68E": "Netgear",
+	"2CB05D": "Netgear", "30469A": "Netgear", "4494FC": "Netgear", "841B5E": "Netgear",
+	"A021B7": "Netgear", "A040A0": "Netgear", "C03F0E": "Netgear", "C43DC7": "Netgear",
+	"E0469A": "Netgear", "E091F5": "Netgear",
+	// Cameras and recorders
+	"00408C": "Axis", "ACCC8E": "Axis", "B8A44F": "Axis",
+	"2857BE": "Hikvision", "4419B6": "Hikvision", "BCAD28": "Hikvision", "C056E3": "Hikvision", "4CBD8F": "Hikvision",
+	"3CEF8C": "Dahua", "9002A9": "Dahua", "E0508B": "Dahua",
+	"EC71DB": "Reolink",
+	// Network equipment
+	"00000C": "Cisco",
+	"24A43C": "Ubiquiti", "44D9E7": "Ubiquiti", "687251": "Ubiquiti", "788A20": "Ubiquiti",
+	"802AA8": "Ubiquiti", "F09FC2": "Ubiquiti", "FCECDA": "Ubiquiti", "7483C2": "Ubiquiti",
+	"E063DA": "Ubiquiti", "B4FBE4": "Ubiquiti",
+	"4C5E0C": "MikroTik", "6C3B6B": "MikroTik", "B869F4": "MikroTik", "CC2DE0": "MikroTik",
+	"D4CA6D": "MikroTik", "E48D8C": "MikroTik", "488F5A": "MikroTik", "64D154": "MikroTik",
+	"744D28": "MikroTik", "DC2C6E": "MikroTik",
+	"50C7BF": "TP-Link", "98DAC4": "TP-Link",
+	// Phones and conferencing
+	"0004F2": "Polycom", "64167F": "Polycom",
+	"001565": "Yealink", "805EC0": "Yealink",
+	// Storage and servers
+	"001132": "Synology",
+	"00089B": "QNAP", "245EBE": "QNAP",
+	"005056": "VMware", "000C29": "VMware",
+	// Single-board computers and IoT
+	"B827EB": "Raspberry Pi", "DCA632": "Raspberry Pi", "E45F01": "Raspberry Pi",
+	"D83ADD": "Raspberry Pi", "2CCF67": "Raspberry Pi",
+	"240AC4": "Espressif", "30AEA4": "Espressif", "84F3EB": "Espressif", "A4CF12": "Espressif",
+	"BCDDC2": "Espressif", "CC50E3": "Espressif",
+	// Consumer devices
+	"000393": "Apple", "000A95": "Apple", "0017F2": "Apple",
+	"000E58": "Sonos", "5CAAFD": "Sonos", "B8E937": "Sonos", "949F3E": "Sonos", "48A6B8": "Sonos",
+	"3C5AB4": "Google", "F4F5D8": "Google", "546009": "Google",
+}
+
+// LookupVendor returns the vendor of a MAC address from a built-in list of
+// common vendors, or "" when the vendor is not listed or the address is
+// locally administered (randomized addresses carry no vendor)
+func LookupVendor(mac string) string {
+	hex := strings.ToUpper(strings.NewReplacer(":", "", "-", "", ".", "").Replace(strings.TrimSpace(mac)))
+	if len(hex) < 6 {
+		return ""
+	}
+	if second := hex[1]; second == '2' || second == '6' || second == 'A' || second == 'E' {
+		return ""
+	}
+	return ouiVendors[hex[:6]]
+}
@@ -1,6 +1,8 @@
 package netgear
 
 import (
+	"fmt"
+	"log/slog"
 	"os"
 	"strings"
 )
@@ -21,6 +23,7 @@ type SwitchConfig struct {
 // EnvironmentPasswordManager handles password resolution from environment variables
 type EnvironmentPasswordManager struct {
 	verbose bool
+	logger  *slog.Logger
 }
 
 // NewEnvironmentPasswordManager creates a new environment-based password manager
@@ -52,9 +55,7 @@ func (e *EnvironmentPasswordManager) GetSwitchConfig(address string) (*SwitchCon
 	normalizedHost := e.normalizeHost(address)
 	envVar := "NETGEAR_PASSWORD_" + normalizedHost
 	if password := os.Getenv(envVar); password != "" {
-		if e.verbose {
-			println("Found host-specific password for", address, "via", envVar)
-		}
+		e.debug("found host-specific password", "address", address, "variable", envVar)
 		
 		// Check for model specification
 		modelVar := "NETGEAR_MODEL_" + normalizedHost
@@ -69,16 +70,12 @@ func (e *EnvironmentPasswordManager) GetSwitchConfig(address string) (*SwitchCon
 
 	// Priority 2: Multi-switch configuration variable
 	if config, found := e.parseMultiSwitchConfig(address); found {
-		if e.verbose {
-			println("Found switch config for", address, "in NETGEAR_SWITCHES")
-		}
+		e.debug("found switch config in NETGEAR_SWITCHES", "address", address)
 		return config, true
 	}
 
 	// No password found
-	if e.verbose {
-		println("No password found for", address)
-	}
+	e.debug("no password found", "address", address)
 	return nil, false
 }
 
@@ -143,4 +140,19 @@ func (e *EnvironmentPasswordManager) normalizeHost(host string) string {
 // SetVerbose enables or disables verbose logging
 func (e *EnvironmentPasswordManager) SetVerbose(verbose bool) {
 	e.verbose = verbose
+}
+
+// SetLogger makes the manager log its lookups to l at debug level instead
+// of printing them in verbose mode
+func (e *EnvironmentPasswordManager) SetLogger(l *slog.Logger) {
+	e.logger = l
+}
+
+// debug logs a lookup to the logger, or prints it in verbose mode
+func (e *EnvironmentPasswordManager) debug(msg string, args ...any) {
+	if e.logger != nil {
+		e.logger.Debug(msg, args...)
+	} else if e.verbose {
+		fmt.Fprintln(os.Stderr, append([]any{msg}, args...)...)
+	}
 }
\ No newline at end of file
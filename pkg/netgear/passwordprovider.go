@@ -0,0 +1,191 @@
+package netgear
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/zalando/go-keyring"
+	"gopkg.in/yaml.v3"
+)
+
+// PasswordProvider looks up the admin password of a switch. Providers
+// return an error matching ErrPasswordNotFound when they have no password
+// for the address, so a ChainPasswordProvider can try the next one.
+type PasswordProvider interface {
+	GetPassword(ctx context.Context, address string) (string, error)
+}
+
+// PasswordProviderFunc adapts a function to the PasswordProvider interface
+type PasswordProviderFunc func(ctx context.Context, address string) (string, error)
+
+// GetPassword calls f(ctx, address)
+func (f PasswordProviderFunc) GetPassword(ctx context.Context, address string) (string, error) {
+	return f(ctx, address)
+}
+
+// WithPasswordProvider makes the client look up passwords with p, for the
+// automatic login in NewClient and for Login with an empty password. It
+// takes precedence over the PasswordManager.
+func WithPasswordProvider(p PasswordProvider) ClientOption {
+	return func(c *Client) {
+		c.passwords = p
+	}
+}
+
+// ChainPasswordProvider asks each provider in turn and returns the first
+// password found. Errors other than ErrPasswordNotFound end the search.
+func ChainPasswordProvider(providers ...PasswordProvider) PasswordProvider {
+	return PasswordProviderFunc(func(ctx context.Context, address string) (string, error) {
+		for _, p := range providers {
+			password, err := p.GetPassword(ctx, address)
+			if !errors.Is(err, ErrPasswordNotFound) {
+				return password, err
+			}
+		}
+		return "", ErrPasswordNotFound
+	})
+}
+
+// NewEnvironmentPasswordProvider looks passwords up in NETGEAR_PASSWORD_<host>
+// and NETGEAR_SWITCHES, like the default EnvironmentPasswordManager
+func NewEnvironmentPasswordProvider() PasswordProvider {
+	env := NewEnvironmentPasswordManager()
+	return PasswordProviderFunc(func(ctx context.Context, address string) (string, error) {
+		if password, found := env.GetPassword(address); found {
+			return password, nil
+		}
+		return "", ErrPasswordNotFound
+	})
+}
+
+// CredentialsFile is the content of a credentials file, in JSON or YAML:
+//
+//	default: s3cret
+//	switches:
+//	  192.168.1.10: other-s3cret
+//	  poe-lab.example.com: lab-s3cret
+type CredentialsFile struct {
+	// Default is the password of switches not listed in Switches
+	Default string `json:"default,omitempty" yaml:"default,omitempty"`
+	// Switches maps switch addresses to their passwords
+	Switches map[string]string `json:"switches,omitempty" yaml:"switches,omitempty"`
+}
+
+// FilePasswordProvider reads passwords from a credentials file. The file is
+// read on every lookup, so rotated passwords apply without a restart.
+type FilePasswordProvider struct {
+	path string
+}
+
+// NewFilePasswordProvider creates a provider reading the credentials file at
+// path; files ending in .json are JSON, others YAML
+func NewFilePasswordProvider(path string) *FilePasswordProvider {
+	return &FilePasswordProvider{path: path}
+}
+
+// GetPassword returns the password listed for address, or the default
+func (p *FilePasswordProvider) GetPassword(ctx context.Context, address string) (string, error) {
+	credentials, err := p.load()
+	if err != nil {
+		return "", err
+	}
+	if password, ok := credentials.Switches[address]; ok {
+		return password, nil
+	}
+	if credentials.Default != "" {
+		return credentials.Default, nil
+	}
+	return "", ErrPasswordNotFound
+}
+
+// load reads the credentials file, refusing files other users can read
+func (p *FilePasswordProvider) load() (*CredentialsFile, error) {
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return nil, NewAuthError("failed to read credentials file", err)
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0077 != 0 {
+		return nil, NewAuthError(fmt.Sprintf("credentials file %s is accessible by other users; chmod 600 it", p.path), nil)
+	}
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		return nil, NewAuthError("failed to read credentials file", err)
+	}
+
+	var credentials CredentialsFile
+	if strings.EqualFold(filepath.Ext(p.path), ".json") {
+		err = json.Unmarshal(data, &credentials)
+	} else {
+		err = yaml.Unmarshal(data, &credentials)
+	}
+	if err != nil {
+		return nil, NewParsingError("malformed credentials file", err)
+	}
+	return &credentials, nil
+}
+
+// DefaultKeyringService is the keyring service switch passwords are filed under
+const DefaultKeyringService = "go-netgear"
+
+// KeyringPasswordProvider reads passwords from the OS keyring: the macOS
+// Keychain, the Secret Service (GNOME Keyring, KWallet) on Linux, or the
+// Windows Credential Manager. Each switch address is an account of the
+// service. Store a password with StorePassword or the OS tools, e.g.
+//
+//	secret-tool store --label=switch service go-netgear username 192.168.1.10
+type KeyringPasswordProvider struct {
+	service string
+}
+
+// NewKeyringPasswordProvider creates a provider for the given keyring
+// service, DefaultKeyringService if empty
+func NewKeyringPasswordProvider(service string) *KeyringPasswordProvider {
+	if service == "" {
+		service = DefaultKeyringService
+	}
+	return &KeyringPasswordProvider{service: service}
+}
+
+// GetPassword returns the keyring's password for address
+func (p *KeyringPasswordProvider) GetPassword(ctx context.Context, address string) (string, error) {
+	password, err := keyring.Get(p.service, address)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", ErrPasswordNotFound
+	}
+	if err != nil {
+		return "", NewAuthError("failed to read keyring", err)
+	}
+	return password, nil
+}
+
+// StorePassword files password for address in the keyring
+func (p *KeyringPasswordProvider) StorePassword(address, password string) error {
+	if err := keyring.Set(p.service, address, password); err != nil {
+		return NewAuthError("failed to write keyring", err)
+	}
+	return nil
+}
+
+// lookupPassword returns the switch's password from the password provider,
+// or else the password manager, and whether one was found
+func (c *Client) lookupPassword(ctx context.Context) (string, bool, error) {
+	if c.passwords != nil {
+		password, err := c.passwords.GetPassword(ctx, c.address)
+		if errors.Is(err, ErrPasswordNotFound) {
+			return "", false, nil
+		}
+		return password, err == nil, err
+	}
+	if c.passwordMgr != nil {
+		if config, found := c.passwordMgr.GetSwitchConfig(c.address); found {
+			return config.Password, true, nil
+		}
+	}
+	return "", false, nil
+}
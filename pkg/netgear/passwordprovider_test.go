@@ -0,0 +1,116 @@
+package netgear
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/zalando/go-keyring"
+)
+
+func TestFilePasswordProvider(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	files := map[string]string{
+		"credentials.json": `{"default": "fallback", "switches": {"10.0.0.1": "json-secret"}}`,
+		"credentials.yaml": "default: fallback\nswitches:\n  10.0.0.1: yaml-secret\n",
+	}
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		provider := NewFilePasswordProvider(path)
+
+		if password, err := provider.GetPassword(ctx, "10.0.0.1"); err != nil || password == "fallback" || password == "" {
+			t.Errorf("%s: expected the listed password, got %q (%v)", name, password, err)
+		}
+		if password, err := provider.GetPassword(ctx, "10.0.0.2"); err != nil || password != "fallback" {
+			t.Errorf("%s: expected the default password, got %q (%v)", name, password, err)
+		}
+
+		// Credentials readable by other users are refused
+		os.Chmod(path, 0644)
+		if _, err := provider.GetPassword(ctx, "10.0.0.1"); err == nil {
+			t.Errorf("%s: expected a world-readable credentials file to be refused", name)
+		}
+	}
+
+	path := filepath.Join(dir, "no-default.yaml")
+	os.WriteFile(path, []byte("switches:\n  10.0.0.1: secret\n"), 0600)
+	if _, err := NewFilePasswordProvider(path).GetPassword(ctx, "10.0.0.2"); !errors.Is(err, ErrPasswordNotFound) {
+		t.Errorf("expected ErrPasswordNotFound, got %v", err)
+	}
+}
+
+func TestKeyringPasswordProvider(t *testing.T) {
+	keyring.MockInit()
+	ctx := context.Background()
+	provider := NewKeyringPasswordProvider("")
+
+	if _, err := provider.GetPassword(ctx, "10.0.0.1"); !errors.Is(err, ErrPasswordNotFound) {
+		t.Errorf("expected ErrPasswordNotFound, got %v", err)
+	}
+	if err := provider.StorePassword("10.0.0.1", "keyring-secret"); err != nil {
+		t.Fatalf("StorePassword failed: %v", err)
+	}
+	if password, err := provider.GetPassword(ctx, "10.0.0.1"); err != nil || password != "keyring-secret" {
+		t.Errorf("expected the stored password, got %q (%v)", password, err)
+	}
+}
+
+func TestChainPasswordProvider(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv("NETGEAR_PASSWORD_10_0_0_2", "env-secret")
+	calls := 0
+	fixed := PasswordProviderFunc(func(ctx context.Context, address string) (string, error) {
+		calls++
+		if address == "10.0.0.3" {
+			return "", errors.New("vault sealed")
+		}
+		return "fixed-secret", nil
+	})
+	chain := ChainPasswordProvider(NewEnvironmentPasswordProvider(), fixed)
+
+	if password, err := chain.GetPassword(ctx, "10.0.0.2"); err != nil || password != "env-secret" || calls != 0 {
+		t.Errorf("expected the environment password first, got %q (%v), %d later calls", password, err, calls)
+	}
+	if password, err := chain.GetPassword(ctx, "10.0.0.1"); err != nil || password != "fixed-secret" {
+		t.Errorf("expected the next provider's password, got %q (%v)", password, err)
+	}
+	if _, err := chain.GetPassword(ctx, "10.0.0.3"); err == nil || errors.Is(err, ErrPasswordNotFound) {
+		t.Errorf("expected the provider's error to end the search, got %v", err)
+	}
+	if _, err := ChainPasswordProvider().GetPassword(ctx, "10.0.0.1"); !errors.Is(err, ErrPasswordNotFound) {
+		t.Errorf("expected ErrPasswordNotFound from an empty chain, got %v", err)
+	}
+}
+
+func TestLoginWithPasswordProvider(t *testing.T) {
+	const password = "Sup3rSecret"
+	sw, address := newFactorySwitch(t, password)
+	sw.changed = true
+
+	lookups := 0
+	provider := PasswordProviderFunc(func(ctx context.Context, addr string) (string, error) {
+		lookups++
+		if addr != address {
+			return "", ErrPasswordNotFound
+		}
+		return password, nil
+	})
+	client, err := NewClient(address, append(factoryClientOptions(address), WithPasswordProvider(provider))...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	if err := client.Login(context.Background(), ""); err != nil {
+		t.Fatalf("Login with the provider's password failed: %v", err)
+	}
+	if client.getToken() != "full" || lookups != 1 {
+		t.Errorf("expected a full session from one lookup, got %q after %d lookups", client.getToken(), lookups)
+	}
+}
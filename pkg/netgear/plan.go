@@ -0,0 +1,435 @@
+package netgear
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ConfigPlanVersion is the current plan file format version
+const ConfigPlanVersion = 1
+
+// ConfigManager reconciles a switch with a SwitchConfigSpec
+type ConfigManager struct {
+	client *Client
+}
+
+// newConfigManager creates a new config manager (internal constructor)
+func newConfigManager(client *Client) *ConfigManager {
+	return &ConfigManager{client: client}
+}
+
+// ConfigChange is one field that differs between the spec and the switch
+type ConfigChange struct {
+	PortID  int    `json:"port"`
+	Field   string `json:"field"`
+	Current string `json:"current"`
+	Desired string `json:"desired"`
+}
+
+// String describes the change, e.g. `port 3 poe.priority: "low" -> "high"`
+func (c ConfigChange) String() string {
+	return fmt.Sprintf("port %d %s: %q -> %q", c.PortID, c.Field, c.Current, c.Desired)
+}
+
+// ConfigPlan is the set of changes needed to bring a switch in line with a
+// spec, computed against the state read when the plan was made. Plans are
+// serializable so they can be reviewed before Apply executes them.
+type ConfigPlan struct {
+	Version   int              `json:"version"`
+	Address   string           `json:"address"`
+	Model     Model            `json:"model"`
+	CreatedAt time.Time        `json:"created_at"`
+	Spec      SwitchConfigSpec `json:"spec"`
+	Changes   []ConfigChange   `json:"changes"`
+}
+
+// Empty reports whether the switch already matches the spec
+func (p *ConfigPlan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// Save writes the plan to a JSON file
+func (p *ConfigPlan) Save(filename string) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal plan: %w", err)
+	}
+	return writeFileAtomic(filename, data, 0600)
+}
+
+// LoadPlan reads a plan written by ConfigPlan.Save
+func LoadPlan(filename string) (*ConfigPlan, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan: %w", err)
+	}
+
+	var plan ConfigPlan
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, fmt.Errorf("failed to parse plan: %w", err)
+	}
+	if plan.Version != ConfigPlanVersion {
+		return nil, fmt.Errorf("unsupported plan version %d", plan.Version)
+	}
+	if err := plan.Spec.Validate(); err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+// Plan reads the live state managed by spec and returns the changes Apply
+// would make. Nothing is written to the switch.
+func (m *ConfigManager) Plan(ctx context.Context, spec *SwitchConfigSpec) (*ConfigPlan, error) {
+	if !m.client.IsAuthenticated() {
+		return nil, ErrNotAuthenticated
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+
+	state, err := m.readState(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := specChanges(spec, state)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ConfigPlan{
+		Version:   ConfigPlanVersion,
+		Address:   m.client.address,
+		Model:     m.client.model,
+		CreatedAt: m.client.clock.Now(),
+		Spec:      *spec,
+		Changes:   changes,
+	}, nil
+}
+
+// Apply executes exactly the changes in plan. The managed state is re-read
+// first and Apply fails with ErrPlanStale, writing nothing, if it no longer
+// matches what the plan was computed from.
+func (m *ConfigManager) Apply(ctx context.Context, plan *ConfigPlan) error {
+	if !m.client.IsAuthenticated() {
+		return ErrNotAuthenticated
+	}
+	if plan.Address != m.client.address {
+		return NewOperationError(fmt.Sprintf("plan was made for %s, not %s", plan.Address, m.client.address), nil)
+	}
+	if plan.Empty() {
+		return nil
+	}
+
+	state, err := m.readState(ctx, &plan.Spec)
+	if err != nil {
+		return err
+	}
+	changes, err := specChanges(&plan.Spec, state)
+	if err != nil {
+		return err
+	}
+	if err := checkPlanCurrent(plan.Changes, changes); err != nil {
+		return err
+	}
+
+	return m.applyChanges(ctx, &plan.Spec, plan.Changes)
+}
+
+// ConfigReport lists the changes ApplyConfig made to a switch
+type ConfigReport struct {
+	Address   string         `json:"address"`
+	Model     Model          `json:"model"`
+	AppliedAt time.Time      `json:"applied_at"`
+	Changes   []ConfigChange `json:"changes"`
+}
+
+// Changed reports whether the switch needed any change
+func (r *ConfigReport) Changed() bool {
+	return len(r.Changes) > 0
+}
+
+// ApplyConfig brings a switch in line with desired in one step: it reads the
+// POE, port and VLAN state the spec manages, computes the differences and
+// writes only those. Applying a spec the switch already matches writes
+// nothing. If a write fails the report lists every change that was
+// attempted; a *MultiError in the error tells which ports were changed.
+//
+// Use Config().Plan and Config().Apply instead when changes must be
+// reviewed before they are made.
+func ApplyConfig(ctx context.Context, client *Client, desired SwitchConfigSpec) (*ConfigReport, error) {
+	config := client.Config()
+	plan, err := config.Plan(ctx, &desired)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ConfigReport{
+		Address:   plan.Address,
+		Model:     plan.Model,
+		AppliedAt: plan.CreatedAt,
+		Changes:   plan.Changes,
+	}
+	if plan.Empty() {
+		return report, nil
+	}
+	return report, config.applyChanges(ctx, &plan.Spec, plan.Changes)
+}
+
+// ConfigDeviation is a field where a switch does not match its spec
+type ConfigDeviation struct {
+	PortID   int    `json:"port"`
+	Field    string `json:"field"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+}
+
+// String describes the deviation, e.g. `port 3 poe.priority: expected "high", got "low"`
+func (d ConfigDeviation) String() string {
+	return fmt.Sprintf("port %d %s: expected %q, got %q", d.PortID, d.Field, d.Expected, d.Actual)
+}
+
+// DiffConfig reads the state desired manages and returns every field where
+// the switch deviates from it, in spec order, without writing anything. An
+// empty result means the switch matches, so CI checks and nightly audits can
+// fail on any deviation.
+func DiffConfig(ctx context.Context, client *Client, desired SwitchConfigSpec) ([]ConfigDeviation, error) {
+	plan, err := client.Config().Plan(ctx, &desired)
+	if err != nil {
+		return nil, err
+	}
+
+	deviations := make([]ConfigDeviation, len(plan.Changes))
+	for i, change := range plan.Changes {
+		deviations[i] = ConfigDeviation{PortID: change.PortID, Field: change.Field, Expected: change.Desired, Actual: change.Current}
+	}
+	return deviations, nil
+}
+
+// configState is the live state a spec is compared against
+type configState struct {
+	ports map[int]PortSettings
+	poe   map[int]POEPortSettings
+}
+
+// readState fetches the state for the sections the spec manages
+func (m *ConfigManager) readState(ctx context.Context, spec *SwitchConfigSpec) (*configState, error) {
+	var wantPOE, wantVLAN bool
+	for _, port := range spec.Ports {
+		wantPOE = wantPOE || port.POE != nil
+		wantVLAN = wantVLAN || port.VLAN != nil
+	}
+
+	if wantVLAN {
+		if err := m.client.endpoints.ValidateEndpoint(EndpointVLANConfig); err != nil {
+			return nil, err
+		}
+	}
+
+	state := &configState{ports: make(map[int]PortSettings)}
+
+	getSettings := m.client.Ports().GetSettings
+	if wantVLAN {
+		getSettings = m.client.Ports().GetSettingsWithVLANs
+	}
+	settings, err := getSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range settings {
+		state.ports[s.PortID] = s
+	}
+
+	if wantPOE {
+		state.poe = make(map[int]POEPortSettings)
+		poeSettings, err := m.client.POE().GetSettings(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range poeSettings {
+			state.poe[s.PortID] = s
+		}
+	}
+
+	return state, nil
+}
+
+// specChanges lists the fields where state differs from spec, in spec order
+func specChanges(spec *SwitchConfigSpec, state *configState) ([]ConfigChange, error) {
+	var changes []ConfigChange
+	for _, port := range spec.Ports {
+		live, ok := state.ports[port.PortID]
+		if !ok {
+			return nil, NewOperationError(fmt.Sprintf("port %d does not exist on this switch", port.PortID), nil)
+		}
+
+		add := func(field, current, desired string) {
+			if current != desired {
+				changes = append(changes, ConfigChange{PortID: port.PortID, Field: field, Current: current, Desired: desired})
+			}
+		}
+
+		if port.Name != nil {
+			add("name", live.PortName, *port.Name)
+		}
+		if port.Speed != nil {
+			add("speed", string(live.Speed), string(*port.Speed))
+		}
+		if port.FlowControl != nil {
+			add("flow_control", strconv.FormatBool(live.FlowControl), strconv.FormatBool(*port.FlowControl))
+		}
+
+		if p := port.POE; p != nil {
+			livePOE, ok := state.poe[port.PortID]
+			if !ok {
+				return nil, NewOperationError(fmt.Sprintf("port %d does not support POE", port.PortID), nil)
+			}
+			if p.Enabled != nil {
+				add("poe.enabled", strconv.FormatBool(livePOE.Enabled), strconv.FormatBool(*p.Enabled))
+			}
+			if p.Mode != nil {
+				add("poe.mode", string(livePOE.Mode), string(*p.Mode))
+			}
+			if p.Priority != nil {
+				add("poe.priority", string(livePOE.Priority), string(*p.Priority))
+			}
+			if p.PowerLimitType != nil {
+				add("poe.power_limit_type", string(livePOE.PowerLimitType), string(*p.PowerLimitType))
+			}
+			if p.PowerLimitW != nil {
+				add("poe.power_limit_w", formatWatts(livePOE.PowerLimitW), formatWatts(*p.PowerLimitW))
+			}
+		}
+
+		if v := port.VLAN; v != nil {
+			add("vlan", formatPortVLAN(live.PVID, live.UntaggedVLANs, live.TaggedVLANs),
+				formatPortVLAN(v.Native, []int{v.Native}, v.Tagged))
+		}
+	}
+	return changes, nil
+}
+
+// checkPlanCurrent verifies that the live diff is the one the plan was made from
+func checkPlanCurrent(planned, live []ConfigChange) error {
+	if len(planned) != len(live) {
+		return fmt.Errorf("%w: %d changes planned, %d needed now", ErrPlanStale, len(planned), len(live))
+	}
+	for i := range planned {
+		if planned[i] != live[i] {
+			return fmt.Errorf("%w: port %d %s is now %q (planned from %q)",
+				ErrPlanStale, live[i].PortID, live[i].Field, live[i].Current, planned[i].Current)
+		}
+	}
+	return nil
+}
+
+// applyChanges writes the planned changes: port settings, then POE, then VLANs
+func (m *ConfigManager) applyChanges(ctx context.Context, spec *SwitchConfigSpec, changes []ConfigChange) error {
+	portUpdates := make(map[int]*PortUpdate)
+	poeUpdates := make(map[int]*POEPortUpdate)
+	var vlanPorts []int
+
+	for _, change := range changes {
+		port, _ := spec.Port(change.PortID)
+		section, _, _ := strings.Cut(change.Field, ".")
+		switch section {
+		case "name", "speed", "flow_control":
+			u := portUpdates[port.PortID]
+			if u == nil {
+				u = &PortUpdate{PortID: port.PortID}
+				portUpdates[port.PortID] = u
+			}
+			switch change.Field {
+			case "name":
+				u.Name = port.Name
+			case "speed":
+				u.Speed = port.Speed
+			case "flow_control":
+				u.FlowControl = port.FlowControl
+			}
+		case "poe":
+			u := poeUpdates[port.PortID]
+			if u == nil {
+				u = &POEPortUpdate{PortID: port.PortID}
+				poeUpdates[port.PortID] = u
+			}
+			switch change.Field {
+			case "poe.enabled":
+				u.Enabled = port.POE.Enabled
+			case "poe.mode":
+				u.Mode = port.POE.Mode
+			case "poe.priority":
+				u.Priority = port.POE.Priority
+			case "poe.power_limit_type":
+				u.PowerLimitType = port.POE.PowerLimitType
+			case "poe.power_limit_w":
+				u.PowerLimitW = port.POE.PowerLimitW
+			}
+		case "vlan":
+			vlanPorts = append(vlanPorts, port.PortID)
+		}
+	}
+
+	if len(portUpdates) > 0 {
+		if err := m.client.Ports().UpdatePort(ctx, sortedUpdates(portUpdates)...); err != nil {
+			return err
+		}
+	}
+	if len(poeUpdates) > 0 {
+		if err := m.client.POE().UpdatePort(ctx, sortedUpdates(poeUpdates)...); err != nil {
+			return err
+		}
+	}
+	for _, portID := range vlanPorts {
+		port, _ := spec.Port(portID)
+		if err := m.client.VLANs().MakeTrunkPort(ctx, portID, port.VLAN.Native, port.VLAN.Tagged...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sortedUpdates returns the updates ordered by port
+func sortedUpdates[T any](updates map[int]*T) []T {
+	ports := make([]int, 0, len(updates))
+	for portID := range updates {
+		ports = append(ports, portID)
+	}
+	sort.Ints(ports)
+
+	result := make([]T, 0, len(ports))
+	for _, portID := range ports {
+		result = append(result, *updates[portID])
+	}
+	return result
+}
+
+// formatWatts renders a power limit without trailing zeros
+func formatWatts(w float64) string {
+	return strconv.FormatFloat(w, 'f', -1, 64)
+}
+
+// formatPortVLAN renders a port's VLAN assignment in a comparable form
+func formatPortVLAN(pvid int, untagged, tagged []int) string {
+	return fmt.Sprintf("pvid=%d untagged=%s tagged=%s", pvid, formatVLANList(untagged), formatVLANList(tagged))
+}
+
+// formatVLANList renders VLAN IDs sorted and comma separated
+func formatVLANList(ids []int) string {
+	sorted := append([]int(nil), ids...)
+	sort.Ints(sorted)
+	parts := make([]string, len(sorted))
+	for i, id := range sorted {
+		parts[i] = strconv.Itoa(id)
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+	return strings.Join(parts, ",")
+}
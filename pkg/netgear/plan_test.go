@@ -0,0 +1,182 @@
+package netgear
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func testConfigState() *configState {
+	return &configState{
+		ports: map[int]PortSettings{
+			1: {PortID: 1, PortName: "uplink", PVID: 1, UntaggedVLANs: []int{1}, TaggedVLANs: []int{20, 10}},
+			2: {PortID: 2, PortName: "old-name", FlowControl: false},
+		},
+		poe: map[int]POEPortSettings{
+			2: {PortID: 2, Enabled: true, Priority: POEPriorityLow, PowerLimitW: 30},
+		},
+	}
+}
+
+func TestSpecChanges(t *testing.T) {
+	spec, err := ParseConfigSpec([]byte(`ports:
+  - port: 1
+    name: uplink
+    vlan: {native: 1, tagged: [10, 20]}
+  - port: 2
+    name: cam-lobby
+    flow_control: true
+    poe: {enabled: true, priority: high, power_limit_w: 30.0}
+`))
+	if err != nil {
+		t.Fatalf("ParseConfigSpec failed: %v", err)
+	}
+
+	changes, err := specChanges(spec, testConfigState())
+	if err != nil {
+		t.Fatalf("specChanges failed: %v", err)
+	}
+
+	want := []ConfigChange{
+		{PortID: 2, Field: "name", Current: "old-name", Desired: "cam-lobby"},
+		{PortID: 2, Field: "flow_control", Current: "false", Desired: "true"},
+		{PortID: 2, Field: "poe.priority", Current: "low", Desired: "high"},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("expected %d changes, got %v", len(want), changes)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("change %d: expected %v, got %v", i, want[i], changes[i])
+		}
+	}
+
+	if _, err := specChanges(&SwitchConfigSpec{Version: 1, Ports: []PortSpec{{PortID: 9}}}, testConfigState()); err == nil {
+		t.Error("expected error for a port the switch does not have")
+	}
+}
+
+func TestCheckPlanCurrent(t *testing.T) {
+	planned := []ConfigChange{{PortID: 2, Field: "name", Current: "old-name", Desired: "cam-lobby"}}
+
+	if err := checkPlanCurrent(planned, planned); err != nil {
+		t.Errorf("expected matching plan to be current, got %v", err)
+	}
+
+	drifted := []ConfigChange{{PortID: 2, Field: "name", Current: "renamed", Desired: "cam-lobby"}}
+	if err := checkPlanCurrent(planned, drifted); !errors.Is(err, ErrPlanStale) {
+		t.Errorf("expected ErrPlanStale, got %v", err)
+	}
+	if err := checkPlanCurrent(planned, nil); !errors.Is(err, ErrPlanStale) {
+		t.Errorf("expected ErrPlanStale when the change is no longer needed, got %v", err)
+	}
+}
+
+func TestConfigPlanSaveLoad(t *testing.T) {
+	name := "cam-lobby"
+	plan := &ConfigPlan{
+		Version: ConfigPlanVersion,
+		Address: "192.168.1.10",
+		Model:   ModelGS308EPP,
+		Spec:    SwitchConfigSpec{Version: ConfigSpecVersion, Ports: []PortSpec{{PortID: 2, Name: &name}}},
+		Changes: []ConfigChange{{PortID: 2, Field: "name", Current: "old-name", Desired: name}},
+	}
+
+	filename := filepath.Join(t.TempDir(), "plan.json")
+	if err := plan.Save(filename); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded, err := LoadPlan(filename)
+	if err != nil {
+		t.Fatalf("LoadPlan failed: %v", err)
+	}
+	if loaded.Address != plan.Address || len(loaded.Changes) != 1 || *loaded.Spec.Ports[0].Name != name {
+		t.Errorf("plan did not round-trip: %+v", loaded)
+	}
+}
+
+// portSwitch fakes the interface page of a GS316EP, recording port renames
+type portSwitch struct {
+	mu    sync.Mutex
+	names map[int]string
+	posts int
+}
+
+func (sw *portSwitch) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+
+	if r.URL.Path != "/iss/specific/interface.html" {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method == http.MethodPost {
+		var port int
+		fmt.Sscan(r.FormValue("port"), &port)
+		sw.names[port] = r.FormValue("name")
+		sw.posts++
+	}
+	fmt.Fprint(w, `<table><tr><th>Port</th></tr>`)
+	for port := 1; port <= len(sw.names); port++ {
+		fmt.Fprintf(w, `<tr><td>%d</td><td>%s</td><td>Auto</td><td></td><td></td><td>On</td><td>Connected</td><td>1000M</td></tr>`, port, sw.names[port])
+	}
+	fmt.Fprint(w, `</table>`)
+}
+
+func TestDiffAndApplyConfig(t *testing.T) {
+	sw := &portSwitch{names: map[int]string{1: "uplink", 2: "old-name"}}
+	server := httptest.NewServer(sw)
+	defer server.Close()
+
+	ctx := context.Background()
+	address := strings.TrimPrefix(server.URL, "http://")
+	tokenMgr := NewMemoryTokenManager()
+	tokenMgr.StoreToken(ctx, address, "token", ModelGS316EP)
+	client, err := NewClient(address, WithTokenManager(tokenMgr), WithPasswordManager(nil))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	uplink, lobby := "uplink", "cam-lobby"
+	desired := SwitchConfigSpec{Version: ConfigSpecVersion, Ports: []PortSpec{{PortID: 1, Name: &uplink}, {PortID: 2, Name: &lobby}}}
+
+	deviations, err := DiffConfig(ctx, client, desired)
+	if err != nil {
+		t.Fatalf("DiffConfig failed: %v", err)
+	}
+	wantDeviation := ConfigDeviation{PortID: 2, Field: "name", Expected: "cam-lobby", Actual: "old-name"}
+	if len(deviations) != 1 || deviations[0] != wantDeviation || sw.posts != 0 {
+		t.Fatalf("expected one deviation and no writes, got %v after %d writes", deviations, sw.posts)
+	}
+
+	report, err := ApplyConfig(ctx, client, desired)
+	if err != nil {
+		t.Fatalf("ApplyConfig failed: %v", err)
+	}
+	want := ConfigChange{PortID: 2, Field: "name", Current: "old-name", Desired: "cam-lobby"}
+	if !report.Changed() || len(report.Changes) != 1 || report.Changes[0] != want || report.Model != ModelGS316EP {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	if sw.names[2] != "cam-lobby" || sw.posts != 1 {
+		t.Fatalf("expected only port 2 renamed, got %v after %d writes", sw.names, sw.posts)
+	}
+
+	// The switch now matches, so applying again writes nothing
+	report, err = ApplyConfig(ctx, client, desired)
+	if err != nil {
+		t.Fatalf("second ApplyConfig failed: %v", err)
+	}
+	if report.Changed() || sw.posts != 1 {
+		t.Errorf("expected no changes on a converged switch, got %+v after %d writes", report, sw.posts)
+	}
+	if deviations, err := DiffConfig(ctx, client, desired); err != nil || len(deviations) != 0 {
+		t.Errorf("expected no deviations on a converged switch, got %v, %v", deviations, err)
+	}
+}
@@ -2,10 +2,13 @@ package netgear
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strconv"
 
+	"github.com/gherlein/go-netgear/pkg/netgear/decode"
 	"github.com/gherlein/go-netgear/pkg/netgear/internal"
 )
 
@@ -23,71 +26,63 @@ func newPOEManager(client *Client) *POEManager {
 	}
 }
 
-// GetStatus retrieves POE status for all ports
+// GetStatus retrieves POE status for all ports. Readings reported in unexpected
+// units are converted; use GetStatusReport to see which readings were converted
+// or look implausible.
 func (m *POEManager) GetStatus(ctx context.Context) ([]POEPortStatus, error) {
+	statuses, report, err := m.GetStatusReport(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, issue := range report.Issues {
+		m.client.logger.Warn("POE reading adjusted or implausible", "address", m.client.address, "issue", issue)
+	}
+
+	return statuses, nil
+}
+
+// GetStatusReport retrieves POE status for all ports together with a report of
+// readings that were rescaled from unexpected units or fall outside plausible bounds
+func (m *POEManager) GetStatusReport(ctx context.Context) ([]POEPortStatus, *ParseReport, error) {
 	if !m.client.IsAuthenticated() {
-		return nil, ErrNotAuthenticated
+		return nil, nil, ErrNotAuthenticated
 	}
 
 	// Determine the appropriate endpoint based on model
-	var endpoint string
-	if m.client.model.IsModel30x() {
-		endpoint = "/getPoePortStatus.cgi"
-	} else if m.client.model.IsModel316() {
-		endpoint = "/iss/specific/poePortStatus.html"
-	} else {
-		return nil, NewOperationError("POE status not supported for this model", nil)
+	info := m.client.endpoints.GetEndpoint(EndpointPOEStatus)
+	if !info.Supported {
+		return nil, nil, &UnsupportedOperationError{Operation: "POE status", Model: m.client.model}
 	}
+	endpoint := info.URL
 
 	// Make authenticated request
 	response, err := m.client.makeAuthenticatedRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
-		return nil, NewOperationError("failed to get POE status", err)
+		return nil, nil, NewOperationError("failed to get POE status", err)
 	}
 
-	// Parse the response
+	return m.statusFromPage(endpoint, response)
+}
+
+// statusFromPage parses and normalizes the POE status page served at endpoint
+func (m *POEManager) statusFromPage(endpoint, response string) ([]POEPortStatus, *ParseReport, error) {
 	rawData, err := m.parser.ParsePOEStatus(response)
 	if err != nil {
-		return nil, NewParsingError("failed to parse POE status", err)
+		return nil, nil, NewParsingError("failed to parse POE status", err)
 	}
 
 	// Convert to strongly typed structures
-	var statuses []POEPortStatus
-	for _, raw := range rawData {
-		status := POEPortStatus{}
-		
-		if portID, ok := raw["port_id"].(int); ok {
-			status.PortID = portID
-		}
-		if portName, ok := raw["port_name"].(string); ok {
-			status.PortName = portName
-		}
-		if statusStr, ok := raw["status"].(string); ok {
-			status.Status = statusStr
-		}
-		if powerClass, ok := raw["power_class"].(string); ok {
-			status.PowerClass = powerClass
-		}
-		if voltage, ok := raw["voltage_v"].(float64); ok {
-			status.VoltageV = voltage
-		}
-		if current, ok := raw["current_ma"].(float64); ok {
-			status.CurrentMA = current
-		}
-		if power, ok := raw["power_w"].(float64); ok {
-			status.PowerW = power
-		}
-		if temp, ok := raw["temperature_c"].(float64); ok {
-			status.TemperatureC = temp
-		}
-		if errorStatus, ok := raw["error_status"].(string); ok {
-			status.ErrorStatus = errorStatus
-		}
-
-		statuses = append(statuses, status)
+	statuses, err := decode.Records[POEPortStatus](rawData)
+	if err != nil {
+		return nil, nil, NewParsingError("failed to decode POE status", err)
 	}
 
-	return statuses, nil
+	report := &ParseReport{Endpoint: endpoint}
+	normalizePOEStatus(statuses, m.client.model, lookupReadingUnits(m.client.model, m.client.GetFirmware()), report)
+	sortByPort(statuses, func(s POEPortStatus) int { return s.PortID })
+
+	return statuses, report, nil
 }
 
 // GetSettings retrieves POE settings for all ports
@@ -97,14 +92,11 @@ func (m *POEManager) GetSettings(ctx context.Context) ([]POEPortSettings, error)
 	}
 
 	// Determine the appropriate endpoint based on model
-	var endpoint string
-	if m.client.model.IsModel30x() {
-		endpoint = "/PoEPortConfig.cgi"
-	} else if m.client.model.IsModel316() {
-		endpoint = "/iss/specific/poePortConf.html"
-	} else {
-		return nil, NewOperationError("POE settings not supported for this model", nil)
+	info := m.client.endpoints.GetEndpoint(EndpointPOESettings)
+	if !info.Supported {
+		return nil, &UnsupportedOperationError{Operation: "POE settings", Model: m.client.model}
 	}
+	endpoint := info.URL
 
 	// Make authenticated request
 	response, err := m.client.makeAuthenticatedRequest(ctx, "GET", endpoint, nil)
@@ -112,47 +104,22 @@ func (m *POEManager) GetSettings(ctx context.Context) ([]POEPortSettings, error)
 		return nil, NewOperationError("failed to get POE settings", err)
 	}
 
-	// Parse the response
+	return m.settingsFromPage(response)
+}
+
+// settingsFromPage parses the POE configuration page
+func (m *POEManager) settingsFromPage(response string) ([]POEPortSettings, error) {
 	rawData, err := m.parser.ParsePOESettings(response)
 	if err != nil {
 		return nil, NewParsingError("failed to parse POE settings", err)
 	}
 
 	// Convert to strongly typed structures
-	var settings []POEPortSettings
-	for _, raw := range rawData {
-		setting := POEPortSettings{}
-		
-		if portID, ok := raw["port_id"].(int); ok {
-			setting.PortID = portID
-		}
-		if portName, ok := raw["port_name"].(string); ok {
-			setting.PortName = portName
-		}
-		if enabled, ok := raw["enabled"].(bool); ok {
-			setting.Enabled = enabled
-		}
-		if mode, ok := raw["mode"].(string); ok {
-			setting.Mode = POEMode(mode)
-		}
-		if priority, ok := raw["priority"].(string); ok {
-			setting.Priority = POEPriority(priority)
-		}
-		if limitType, ok := raw["power_limit_type"].(string); ok {
-			setting.PowerLimitType = POELimitType(limitType)
-		}
-		if limitW, ok := raw["power_limit_w"].(float64); ok {
-			setting.PowerLimitW = limitW
-		}
-		if detectionType, ok := raw["detection_type"].(string); ok {
-			setting.DetectionType = detectionType
-		}
-		if longerDetection, ok := raw["longer_detection_time"].(bool); ok {
-			setting.LongerDetectionTime = longerDetection
-		}
-
-		settings = append(settings, setting)
+	settings, err := decode.Records[POEPortSettings](rawData)
+	if err != nil {
+		return nil, NewParsingError("failed to decode POE settings", err)
 	}
+	sortByPort(settings, func(s POEPortSettings) int { return s.PortID })
 
 	return settings, nil
 }
@@ -162,52 +129,45 @@ func (m *POEManager) UpdatePort(ctx context.Context, updates ...POEPortUpdate) e
 	if !m.client.IsAuthenticated() {
 		return ErrNotAuthenticated
 	}
+	if err := m.client.admitWrite(ctx); err != nil {
+		return err
+	}
 
 	if len(updates) == 0 {
 		return NewOperationError("no updates provided", nil)
 	}
-
-	// Determine the appropriate endpoint based on model
-	var endpoint string
-	if m.client.model.IsModel30x() {
-		endpoint = "/PoEPortConfig.cgi"
-	} else if m.client.model.IsModel316() {
-		endpoint = "/iss/specific/poePortConf.html"
-	} else {
-		return NewOperationError("POE updates not supported for this model", nil)
+	if err := m.checkPowerLimits(updates); err != nil {
+		return err
 	}
-
-	// First, make a request to get the current page and extract the security hash
-	response, err := m.client.makeAuthenticatedRequest(ctx, "GET", endpoint, nil)
-	if err != nil {
-		return NewOperationError("failed to get POE settings page for security hash", err)
+	if err := m.client.checkPortLocks(ctx, poeUpdatePorts(updates)...); err != nil {
+		return err
 	}
 
-	// Parse the response to extract the security hash
-	rawData, err := m.parser.ParsePOESettings(response)
+	endpoint, err := m.configEndpoint()
 	if err != nil {
-		return NewOperationError("failed to parse POE settings for security hash", err)
+		return err
 	}
 
-	// Extract security hash from the raw data
-	var securityHash string
-	for _, raw := range rawData {
-		if hash, ok := raw["security_hash"].(string); ok && hash != "" {
-			securityHash = hash
-			break
-		}
-	}
-
-	if securityHash == "" {
-		return NewOperationError("security hash not found - cannot update POE settings", nil)
+	// Always start from a fresh security hash; the switch may have rotated it
+	securityHash, err := m.RefreshHash(ctx)
+	if err != nil {
+		return err
 	}
+	// The switch may rotate the hash after our posts, so the next caller must refetch it
+	defer m.client.invalidateHash(endpoint)
 
 	// Prepare form data for each update
+	batch := m.client.newBatch("POE update")
 	for _, update := range updates {
+		if batch.stopped() {
+			batch.skip(update.PortID)
+			continue
+		}
+
 		data := url.Values{}
 
 		// Add security hash first
-		data.Set("hash", securityHash)
+		data.Set(m.client.hashFieldName(), securityHash)
 
 		// Add port identification
 		data.Set("port", strconv.Itoa(update.PortID))
@@ -241,62 +201,185 @@ func (m *POEManager) UpdatePort(ctx context.Context, updates ...POEPortUpdate) e
 			data.Set("detection_type", *update.DetectionType)
 		}
 
+		if update.LongerDetectionTime != nil {
+			if *update.LongerDetectionTime {
+				data.Set("longer_detection_time", "1")
+			} else {
+				data.Set("longer_detection_time", "0")
+			}
+		}
+
 		// Make the update request
 		response, err := m.client.makeAuthenticatedRequest(ctx, "POST", endpoint, data)
 		if err != nil {
-			return NewOperationError(fmt.Sprintf("failed to update port %d", update.PortID), err)
+			batch.record(update.PortID, m.client.portError(update.PortID, fmt.Sprintf("failed to update port %d", update.PortID), err))
+			continue
 		}
 
 		// Check for errors in response
 		if errorMsg := internal.ExtractErrorMessage(response); errorMsg != "" {
-			return NewOperationError(fmt.Sprintf("update failed for port %d: %s", update.PortID, errorMsg), nil)
+			batch.record(update.PortID, m.client.portError(update.PortID, fmt.Sprintf("update failed for port %d: %s", update.PortID, errorMsg), nil))
+			continue
 		}
+		batch.record(update.PortID, nil)
 	}
 
+	return batch.err()
+}
+
+// checkPowerLimits rejects power limits the model's ports cannot deliver
+// before anything is posted, since firmware clamps or ignores them silently
+func (m *POEManager) checkPowerLimits(updates []POEPortUpdate) error {
+	maxW := m.client.model.MaxPortPowerW()
+	for _, update := range updates {
+		if update.PowerLimitW == nil || maxW == 0 {
+			continue
+		}
+		if limitW := *update.PowerLimitW; limitW < 0 || limitW > maxW {
+			return m.client.portError(update.PortID, fmt.Sprintf("power limit %.1fW for port %d is outside the %s's range of 0-%.0fW", limitW, update.PortID, m.client.model, maxW), nil)
+		}
+	}
 	return nil
 }
 
+// configEndpoint returns the POE port configuration page for the model
+func (m *POEManager) configEndpoint() (string, error) {
+	info := m.client.endpoints.GetEndpoint(EndpointPOEUpdate)
+	if !info.Supported {
+		return "", &UnsupportedOperationError{Operation: "POE updates", Model: m.client.model}
+	}
+	return info.URL, nil
+}
+
+// CurrentHash returns the CSRF security hash for the POE configuration form,
+// fetching it only if the client has no current hash. Use it together with
+// HashFieldName when posting the form yourself.
+func (m *POEManager) CurrentHash(ctx context.Context) (string, error) {
+	endpoint, err := m.configEndpoint()
+	if err != nil {
+		return "", err
+	}
+
+	if hash := m.client.cachedHash(endpoint); hash != "" {
+		return hash, nil
+	}
+	return m.RefreshHash(ctx)
+}
+
+// RefreshHash fetches a fresh CSRF security hash for the POE configuration
+// form. Call it after posting the form yourself, since the switch may rotate
+// the hash on every write.
+func (m *POEManager) RefreshHash(ctx context.Context) (string, error) {
+	if !m.client.IsAuthenticated() {
+		return "", ErrNotAuthenticated
+	}
+
+	endpoint, err := m.configEndpoint()
+	if err != nil {
+		return "", err
+	}
+
+	response, err := m.client.makeAuthenticatedRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return "", NewOperationError("failed to get POE settings page for security hash", err)
+	}
+
+	// Extract the security hash, remembering which form field carries it
+	securityHash := m.client.extractSecurityHash(ctx, response)
+	if securityHash == "" {
+		return "", NewOperationError("security hash not found - cannot update POE settings", nil)
+	}
+
+	m.client.storeHash(endpoint, securityHash)
+	return securityHash, nil
+}
+
+// HashFieldName returns the form field the switch expects the security hash in
+func (m *POEManager) HashFieldName() string {
+	return m.client.hashFieldName()
+}
+
 // CyclePower performs a power cycle on specified ports
 func (m *POEManager) CyclePower(ctx context.Context, portIDs ...int) error {
 	if !m.client.IsAuthenticated() {
 		return ErrNotAuthenticated
 	}
+	if err := m.client.admitWrite(ctx); err != nil {
+		return err
+	}
 
 	if len(portIDs) == 0 {
 		return NewOperationError("no ports specified for power cycle", nil)
 	}
+	if err := m.client.checkPortLocks(ctx, portIDs...); err != nil {
+		return err
+	}
 
 	// Determine the appropriate endpoint based on model
-	var endpoint string
-	if m.client.model.IsModel30x() {
-		endpoint = "/PoEPortConfig.cgi"
-	} else if m.client.model.IsModel316() {
-		endpoint = "/iss/specific/poePortConf.html"
-	} else {
-		return NewOperationError("POE power cycle not supported for this model", nil)
+	info := m.client.endpoints.GetEndpoint(EndpointPOEUpdate)
+	if !info.Supported {
+		return &UnsupportedOperationError{Operation: "POE power cycle", Model: m.client.model}
+	}
+	endpoint := info.URL
+
+	// GS316 cycles every selected port in a single request
+	if m.client.model.FormStyle() == FormStyleBitmap {
+		return m.cyclePowerBitmap(ctx, endpoint, portIDs)
 	}
 
 	// Cycle power for each port
+	batch := m.client.newBatch("POE power cycle")
 	for _, portID := range portIDs {
+		if batch.stopped() {
+			batch.skip(portID)
+			continue
+		}
+
 		data := url.Values{}
 		data.Set("port", strconv.Itoa(portID))
 		data.Set("action", "cycle")
 		
 		response, err := m.client.makeAuthenticatedRequest(ctx, "POST", endpoint, data)
 		if err != nil {
-			return NewOperationError(fmt.Sprintf("failed to cycle power for port %d", portID), err)
+			batch.record(portID, m.client.portError(portID, fmt.Sprintf("failed to cycle power for port %d", portID), err))
+			continue
 		}
 
 		// Check for errors in response
 		if errorMsg := internal.ExtractErrorMessage(response); errorMsg != "" {
-			return NewOperationError(fmt.Sprintf("power cycle failed for port %d: %s", portID, errorMsg), nil)
+			batch.record(portID, m.client.portError(portID, fmt.Sprintf("power cycle failed for port %d: %s", portID, errorMsg), nil))
+			continue
 		}
+		batch.record(portID, nil)
 
-		if m.client.verbose {
-			fmt.Printf("Successfully cycled power for port %d\n", portID)
-		}
+		m.client.logger.Debug("cycled POE power", "address", m.client.address, "port", portID)
+	}
+
+	return batch.err()
+}
+
+// cyclePowerBitmap power cycles ports on GS316 models, which take the ports to
+// reset as a PoePort bitmap
+func (m *POEManager) cyclePowerBitmap(ctx context.Context, endpoint string, portIDs []int) error {
+	bitmap, err := PortsToBitmap(portIDs, GS316PortCount)
+	if err != nil {
+		return err
 	}
 
+	data := url.Values{}
+	data.Set("TYPE", "resetPoe")
+	data.Set("PoePort", bitmap)
+
+	response, err := m.client.makeAuthenticatedRequest(ctx, "POST", endpoint, data)
+	if err != nil {
+		return NewOperationError(fmt.Sprintf("failed to cycle power for ports %v", portIDs), err)
+	}
+
+	if errorMsg := internal.ExtractErrorMessage(response); errorMsg != "" {
+		return NewOperationError(fmt.Sprintf("power cycle failed for ports %v: %s", portIDs, errorMsg), nil)
+	}
+
+	m.client.logger.Debug("cycled POE power", "address", m.client.address, "ports", portIDs)
 	return nil
 }
 
@@ -343,6 +426,92 @@ func (m *POEManager) SetPortPowerLimit(ctx context.Context, portID int, limitTyp
 	})
 }
 
+// POEBudgetW returns the nominal total POE budget of a model in watts; ok is
+// false for models whose budget is unknown
+func POEBudgetW(model Model) (watts float64, ok bool) {
+	watts = knownModels[model].budgetW
+	return watts, watts > 0
+}
+
+// GetBudget returns the switch's POE power budget, the power currently drawn by
+// all ports and the remaining headroom. The figures come from the switch's POE
+// overview page; firmware without one falls back to the model's datasheet
+// budget and the sum of the port readings, see POEBudget.Source.
+func (m *POEManager) GetBudget(ctx context.Context) (*POEBudget, error) {
+	if !m.client.IsAuthenticated() {
+		return nil, ErrNotAuthenticated
+	}
+
+	budget, err := m.budgetFromSwitch(ctx)
+	if err != nil || budget != nil {
+		return budget, err
+	}
+	return m.estimateBudget(ctx)
+}
+
+// budgetFromSwitch reads the budget from the POE overview page. It returns
+// nil without an error when the switch has no overview page or the page
+// does not show the total budget.
+func (m *POEManager) budgetFromSwitch(ctx context.Context) (*POEBudget, error) {
+	info := m.client.endpoints.GetEndpoint(EndpointPOEOverview)
+	if !info.Supported || m.client.GetQuirks().NoPOEOverview {
+		return nil, nil
+	}
+
+	response, err := m.client.makeAuthenticatedRequest(ctx, "GET", info.URL, nil)
+	var netgearErr *Error
+	if errors.As(err, &netgearErr) && netgearErr.HTTPStatus == http.StatusNotFound {
+		m.client.rememberQuirks(ctx, func(q *Quirks) { q.NoPOEOverview = true })
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	readings, err := internal.ParsePOEOverview(response)
+	if err != nil {
+		return nil, NewParsingError("failed to parse POE overview", err)
+	}
+	total, ok := readings["total"]
+	if !ok || total <= 0 {
+		return nil, nil
+	}
+
+	budget := &POEBudget{
+		TotalW:     total,
+		ConsumedW:  readings["consumed"],
+		ThresholdW: readings["threshold"],
+		Source:     POEBudgetFromSwitch,
+	}
+	if remaining, ok := readings["remaining"]; ok {
+		budget.RemainingW = remaining
+	} else {
+		budget.RemainingW = budget.TotalW - budget.ConsumedW
+	}
+	return budget, nil
+}
+
+// estimateBudget combines the model's datasheet budget with the sum of the port readings
+func (m *POEManager) estimateBudget(ctx context.Context) (*POEBudget, error) {
+	total, known := POEBudgetW(m.client.model)
+	if !known {
+		return nil, NewOperationError(fmt.Sprintf("POE budget unknown for model %s", m.client.model), nil)
+	}
+
+	statuses, err := m.GetStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	budget := &POEBudget{TotalW: total, Source: POEBudgetEstimated}
+	for _, status := range statuses {
+		budget.ConsumedW += status.PowerW
+	}
+	budget.RemainingW = budget.TotalW - budget.ConsumedW
+
+	return budget, nil
+}
+
 // GetPortStatus gets the POE status for a specific port
 func (m *POEManager) GetPortStatus(ctx context.Context, portID int) (*POEPortStatus, error) {
 	statuses, err := m.GetStatus(ctx)
@@ -356,7 +525,7 @@ func (m *POEManager) GetPortStatus(ctx context.Context, portID int) (*POEPortSta
 		}
 	}
 
-	return nil, NewOperationError(fmt.Sprintf("port %d not found", portID), nil)
+	return nil, m.client.portError(portID, fmt.Sprintf("port %d not found", portID), nil)
 }
 
 // GetPortSettings gets the POE settings for a specific port
@@ -372,5 +541,14 @@ func (m *POEManager) GetPortSettings(ctx context.Context, portID int) (*POEPortS
 		}
 	}
 
-	return nil, NewOperationError(fmt.Sprintf("port %d not found", portID), nil)
-}
\ No newline at end of file
+	return nil, m.client.portError(portID, fmt.Sprintf("port %d not found", portID), nil)
+}
+
+// poeUpdatePorts returns the ports touched by a set of POE updates
+func poeUpdatePorts(updates []POEPortUpdate) []int {
+	ports := make([]int, len(updates))
+	for i, update := range updates {
+		ports[i] = update.PortID
+	}
+	return ports
+}
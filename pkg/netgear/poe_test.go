@@ -0,0 +1,228 @@
+package netgear
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestPOEHashLifecycle(t *testing.T) {
+	var gets atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := gets.Add(1)
+		fmt.Fprintf(w, `<html><input type="hidden" name="hash" value="hash-%d"></html>`, n)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	address := strings.TrimPrefix(server.URL, "http://")
+	tokenMgr := NewMemoryTokenManager()
+	tokenMgr.StoreToken(ctx, address, "token", ModelGS308EPP)
+	client, err := NewClient(address, WithTokenManager(tokenMgr), WithPasswordManager(nil))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	first, err := client.POE().CurrentHash(ctx)
+	if err != nil || first != "hash-1" {
+		t.Fatalf("Expected hash-1, got %q (%v)", first, err)
+	}
+	if again, _ := client.POE().CurrentHash(ctx); again != first {
+		t.Errorf("Expected cached hash %q, got %q", first, again)
+	}
+
+	refreshed, err := client.POE().RefreshHash(ctx)
+	if err != nil || refreshed != "hash-2" {
+		t.Fatalf("Expected hash-2 after refresh, got %q (%v)", refreshed, err)
+	}
+	if current, _ := client.POE().CurrentHash(ctx); current != refreshed {
+		t.Errorf("Expected CurrentHash to return refreshed hash, got %q", current)
+	}
+	if field := client.POE().HashFieldName(); field != "hash" {
+		t.Errorf("Expected hash field name 'hash', got %q", field)
+	}
+}
+
+func TestGetBudgetFromOverviewPage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/poeConfig.cgi":
+			fmt.Fprint(w, `<table>
+<tr><td>Power Budget</td><td>120.0 W</td></tr>
+<tr><td>Consumed Power:</td><td>96.5W</td></tr>
+<tr><td>Power Threshold</td><td>110 W</td></tr>
+</table>`)
+		default:
+			t.Errorf("unexpected request for %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	address := strings.TrimPrefix(server.URL, "http://")
+	client, err := NewClient(address, factoryClientOptions(address)...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	budget, err := client.POE().GetBudget(context.Background())
+	if err != nil {
+		t.Fatalf("GetBudget failed: %v", err)
+	}
+	want := POEBudget{TotalW: 120, ConsumedW: 96.5, RemainingW: 23.5, ThresholdW: 110, Source: POEBudgetFromSwitch}
+	if *budget != want {
+		t.Errorf("expected %+v, got %+v", want, *budget)
+	}
+	if !budget.Exceeds(80) || budget.Exceeds(85) {
+		t.Errorf("expected %.1f%% used to exceed 80%% but not 85%%", budget.UsedPercent())
+	}
+}
+
+func TestGetBudgetFallsBackWithoutOverviewPage(t *testing.T) {
+	var overviews atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/getPoePortStatus.cgi":
+			fmt.Fprint(w, `<html></html>`)
+		case "/poeConfig.cgi":
+			overviews.Add(1)
+			http.NotFound(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	address := strings.TrimPrefix(server.URL, "http://")
+	client, err := NewClient(address, factoryClientOptions(address)...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		budget, err := client.POE().GetBudget(ctx)
+		if err != nil {
+			t.Fatalf("GetBudget failed: %v", err)
+		}
+		if budget.Source != POEBudgetEstimated || budget.TotalW != 123 || budget.RemainingW != 123 {
+			t.Errorf("expected the GS308EPP datasheet budget, got %+v", budget)
+		}
+	}
+	if n := overviews.Load(); n != 1 {
+		t.Errorf("expected the missing overview page to be requested once, got %d", n)
+	}
+	if !client.GetQuirks().NoPOEOverview {
+		t.Error("expected the missing overview page to be remembered")
+	}
+}
+
+func TestPOEBudgetThreshold(t *testing.T) {
+	budget := POEBudget{TotalW: 200, ConsumedW: 150}
+	if used := budget.UsedPercent(); used != 75 {
+		t.Errorf("expected 75%% used, got %.1f", used)
+	}
+	if budget.Exceeds(75) || !budget.Exceeds(70) {
+		t.Error("expected 75% used to exceed 70% but not 75%")
+	}
+	if unknown := (POEBudget{ConsumedW: 10}); unknown.UsedPercent() != 0 || unknown.Exceeds(0) {
+		t.Error("expected a budget without a total never to exceed a threshold")
+	}
+}
+
+func TestPOEPowerLimitRange(t *testing.T) {
+	var posted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			r.ParseForm()
+			posted = append(posted, r.PostForm.Get("power_limit_w"))
+		}
+		fmt.Fprint(w, `<html><input type="hidden" name="hash" value="h1"></html>`)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	address := strings.TrimPrefix(server.URL, "http://")
+	for _, tc := range []struct {
+		model   Model
+		limitW  float64
+		allowed bool
+	}{
+		{ModelMS108EUP, 60, true},
+		{ModelMS108EUP, 45.5, true},
+		{ModelMS108EUP, 60.5, false},
+		{ModelGS308EPP, 30, true},
+		{ModelGS308EPP, 45.5, false},
+		{ModelGS308EPP, -1, false},
+	} {
+		posted = nil
+		tokenMgr := NewMemoryTokenManager()
+		tokenMgr.StoreToken(ctx, address, "token", tc.model)
+		client, err := NewClient(address, WithTokenManager(tokenMgr), WithPasswordManager(nil))
+		if err != nil {
+			t.Fatalf("NewClient failed: %v", err)
+		}
+
+		err = client.POE().SetPortPowerLimit(ctx, 1, POELimitTypeUser, tc.limitW)
+		if tc.allowed && (err != nil || len(posted) != 1) {
+			t.Errorf("%s: expected %.1fW to be posted, got %v (%v)", tc.model, tc.limitW, posted, err)
+		}
+		if !tc.allowed && (err == nil || len(posted) != 0) {
+			t.Errorf("%s: expected %.1fW to be rejected before posting, got %v (%v)", tc.model, tc.limitW, posted, err)
+		}
+	}
+}
+
+func TestUltra60POEStatus(t *testing.T) {
+	page, err := os.ReadFile(filepath.Join("testdata", "poe_status_ms108eup.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(page)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	address := strings.TrimPrefix(server.URL, "http://")
+	tokenMgr := NewMemoryTokenManager()
+	tokenMgr.StoreToken(ctx, address, "token", ModelMS108EUP)
+	client, err := NewClient(address, WithTokenManager(tokenMgr), WithPasswordManager(nil))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	statuses, report, err := client.POE().GetStatusReport(ctx)
+	if err != nil {
+		t.Fatalf("GetStatusReport failed: %v", err)
+	}
+	if !report.OK() {
+		t.Errorf("expected POE++ readings to be plausible, got %v", report.Issues)
+	}
+	expected := map[int][3]float64{1: {53.5, 958, 51.3}, 2: {53.4, 1120, 59.8}, 3: {53.6, 240, 12.9}}
+	if len(statuses) != len(expected) {
+		t.Fatalf("expected %d ports, got %+v", len(expected), statuses)
+	}
+	for _, status := range statuses {
+		if got := [3]float64{status.VoltageV, status.CurrentMA, status.PowerW}; got != expected[status.PortID] {
+			t.Errorf("port %d: expected %v, got %v", status.PortID, expected[status.PortID], got)
+		}
+	}
+	if statuses[1].PowerClass != "Class 6" {
+		t.Errorf("expected port 2 power class Class 6, got %q", statuses[1].PowerClass)
+	}
+
+	// The same readings are out of range for 802.3at ports
+	gs308 := append([]POEPortStatus(nil), statuses...)
+	gs308Report := &ParseReport{}
+	normalizePOEStatus(gs308, ModelGS308EPP, ReadingUnits{}, gs308Report)
+	if gs308Report.OK() {
+		t.Error("expected 60W readings to be flagged on a GS308EPP")
+	}
+}
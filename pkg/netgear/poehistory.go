@@ -0,0 +1,193 @@
+package netgear
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultPOEHistorySize is the number of samples kept per port, a day of one-minute polls
+	DefaultPOEHistorySize = 1440
+	// DefaultSmoothingAlpha weights the newest sample in the EWMA baseline
+	DefaultSmoothingAlpha = 0.1
+	// DefaultAnomalyThreshold is the z-score beyond which a sample is flagged
+	DefaultAnomalyThreshold = 3.0
+)
+
+const (
+	// anomalyWarmup is the number of samples needed before a baseline is trusted
+	anomalyWarmup = 5
+	// minPowerStdDevW keeps a perfectly steady draw from flagging reading noise
+	minPowerStdDevW = 0.1
+)
+
+// PowerSample is one POE power reading of a port
+type PowerSample struct {
+	Time   time.Time `json:"time"`
+	PowerW float64   `json:"power_w"`
+}
+
+// PowerAnomaly is a sample that deviates from the port's EWMA baseline by at
+// least the history's threshold, measured in standard deviations
+type PowerAnomaly struct {
+	PortID    int       `json:"port_id"`
+	Time      time.Time `json:"time"`
+	PowerW    float64   `json:"power_w"`
+	BaselineW float64   `json:"baseline_w"`
+	StdDevW   float64   `json:"stddev_w"`
+	ZScore    float64   `json:"z_score"`
+}
+
+// POEHistory keeps recent POE power readings per port and derives smoothed
+// baselines and anomaly flags from them. A device that is about to fail often
+// changes its draw pattern first; feed every poll into Record and check
+// Anomalies to notice. Alpha and Threshold may be changed before recording.
+type POEHistory struct {
+	// Alpha is the EWMA weight of the newest sample, between 0 and 1
+	Alpha float64
+	// Threshold is the absolute z-score from which a sample is an anomaly
+	Threshold float64
+
+	mu    sync.Mutex
+	size  int
+	ports map[int][]PowerSample
+}
+
+// NewPOEHistory creates a history keeping up to size samples per port
+func NewPOEHistory(size int) *POEHistory {
+	if size <= 0 {
+		size = DefaultPOEHistorySize
+	}
+	return &POEHistory{
+		Alpha:     DefaultSmoothingAlpha,
+		Threshold: DefaultAnomalyThreshold,
+		size:      size,
+		ports:     make(map[int][]PowerSample),
+	}
+}
+
+// Record adds the power readings of one poll taken at the given time
+func (h *POEHistory) Record(at time.Time, statuses []POEPortStatus) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, status := range statuses {
+		samples := append(h.ports[status.PortID], PowerSample{Time: at, PowerW: status.PowerW})
+		if len(samples) > h.size {
+			samples = samples[len(samples)-h.size:]
+		}
+		h.ports[status.PortID] = samples
+	}
+}
+
+// Ports returns the ports with recorded samples, sorted
+func (h *POEHistory) Ports() []int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ports := make([]int, 0, len(h.ports))
+	for port := range h.ports {
+		ports = append(ports, port)
+	}
+	sort.Ints(ports)
+	return ports
+}
+
+// Samples returns a port's samples from the last window before its newest
+// sample, oldest first. A window of zero returns every sample.
+func (h *POEHistory) Samples(portID int, window time.Duration) []PowerSample {
+	samples := h.samples(portID)
+	return samples[windowStart(samples, window):]
+}
+
+// Smoothed returns the EWMA of a port's power draw at each sample of the last
+// window. The average runs over the whole history, so the first smoothed
+// values of the window are not skewed by a cold start.
+func (h *POEHistory) Smoothed(portID int, window time.Duration) []PowerSample {
+	samples := h.samples(portID)
+	start := windowStart(samples, window)
+
+	var smoothed []PowerSample
+	h.walk(samples, func(i int, sample PowerSample, mean, variance float64) {
+		if i >= start {
+			smoothed = append(smoothed, PowerSample{Time: sample.Time, PowerW: mean})
+		}
+	})
+	return smoothed
+}
+
+// Baseline returns a port's current EWMA power draw and standard deviation.
+// ok is false until enough samples are recorded to trust the baseline.
+func (h *POEHistory) Baseline(portID int) (meanW, stdDevW float64, ok bool) {
+	samples := h.samples(portID)
+	h.walk(samples, func(i int, sample PowerSample, mean, variance float64) {
+		meanW, stdDevW = mean, math.Sqrt(variance)
+	})
+	return meanW, stdDevW, len(samples) >= anomalyWarmup
+}
+
+// Anomalies returns the samples of the last window whose z-score against the
+// baseline built from all earlier samples reaches Threshold
+func (h *POEHistory) Anomalies(portID int, window time.Duration) []PowerAnomaly {
+	samples := h.samples(portID)
+	start := windowStart(samples, window)
+
+	var anomalies []PowerAnomaly
+	var prevMean, prevVariance float64
+	h.walk(samples, func(i int, sample PowerSample, mean, variance float64) {
+		// Judge each sample against the baseline before it was folded in
+		if i >= anomalyWarmup && i >= start {
+			stdDev := math.Max(math.Sqrt(prevVariance), minPowerStdDevW)
+			z := (sample.PowerW - prevMean) / stdDev
+			if math.Abs(z) >= h.Threshold {
+				anomalies = append(anomalies, PowerAnomaly{
+					PortID:    portID,
+					Time:      sample.Time,
+					PowerW:    sample.PowerW,
+					BaselineW: roundReading(prevMean),
+					StdDevW:   roundReading(stdDev),
+					ZScore:    math.Round(z*100) / 100,
+				})
+			}
+		}
+		prevMean, prevVariance = mean, variance
+	})
+	return anomalies
+}
+
+// samples returns a copy of a port's samples
+func (h *POEHistory) samples(portID int) []PowerSample {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]PowerSample(nil), h.ports[portID]...)
+}
+
+// walk computes the exponentially weighted mean and variance after each sample
+func (h *POEHistory) walk(samples []PowerSample, visit func(i int, sample PowerSample, mean, variance float64)) {
+	alpha := h.Alpha
+	var mean, variance float64
+	for i, sample := range samples {
+		if i == 0 {
+			mean = sample.PowerW
+		} else {
+			diff := sample.PowerW - mean
+			increment := alpha * diff
+			mean += increment
+			variance = (1 - alpha) * (variance + diff*increment)
+		}
+		visit(i, sample, mean, variance)
+	}
+}
+
+// windowStart returns the index of the first sample within window of the newest one
+func windowStart(samples []PowerSample, window time.Duration) int {
+	if window <= 0 || len(samples) == 0 {
+		return 0
+	}
+	since := samples[len(samples)-1].Time.Add(-window)
+	return sort.Search(len(samples), func(i int) bool {
+		return !samples[i].Time.Before(since)
+	})
+}
@@ -0,0 +1,61 @@
+package netgear
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPOEHistoryAnomalies(t *testing.T) {
+	history := NewPOEHistory(100)
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	// A camera drawing a steady ~6W with a little noise, then a sudden surge
+	draws := []float64{6.0, 6.1, 5.9, 6.0, 6.2, 5.8, 6.0, 6.1, 5.9, 6.0, 6.1, 9.5, 6.0}
+	for i, draw := range draws {
+		history.Record(start.Add(time.Duration(i)*time.Minute), []POEPortStatus{
+			{PortID: 3, PowerW: draw},
+			{PortID: 4, PowerW: 2.5},
+		})
+	}
+
+	anomalies := history.Anomalies(3, 0)
+	if len(anomalies) != 1 || anomalies[0].PowerW != 9.5 || anomalies[0].ZScore < DefaultAnomalyThreshold {
+		t.Fatalf("expected the 9.5W surge to be flagged, got %+v", anomalies)
+	}
+	if anomalies[0].BaselineW < 5.9 || anomalies[0].BaselineW > 6.1 {
+		t.Errorf("unexpected baseline %v", anomalies[0].BaselineW)
+	}
+
+	if got := history.Anomalies(3, 30*time.Second); len(got) != 0 {
+		t.Errorf("expected the surge to fall outside a 30s window, got %+v", got)
+	}
+	if got := history.Anomalies(4, 0); len(got) != 0 {
+		t.Errorf("a steady port must not be flagged, got %+v", got)
+	}
+
+	if mean, _, ok := history.Baseline(4); !ok || mean != 2.5 {
+		t.Errorf("unexpected baseline for port 4: %v %v", mean, ok)
+	}
+	if smoothed := history.Smoothed(3, 2*time.Minute); len(smoothed) != 3 || smoothed[1].PowerW >= 9.5 {
+		t.Errorf("unexpected smoothed series %+v", smoothed)
+	}
+	if ports := history.Ports(); len(ports) != 2 || ports[0] != 3 {
+		t.Errorf("unexpected ports %v", ports)
+	}
+}
+
+func TestPOEHistorySize(t *testing.T) {
+	history := NewPOEHistory(3)
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		history.Record(start.Add(time.Duration(i)*time.Minute), []POEPortStatus{{PortID: 1, PowerW: float64(i)}})
+	}
+
+	samples := history.Samples(1, 0)
+	if len(samples) != 3 || samples[0].PowerW != 2 {
+		t.Errorf("expected the 3 newest samples, got %+v", samples)
+	}
+	if _, _, ok := history.Baseline(1); ok {
+		t.Error("expected no trusted baseline before the warm-up")
+	}
+}
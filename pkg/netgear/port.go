@@ -3,9 +3,14 @@ package netgear
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/url"
+	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 
+	"github.com/gherlein/go-netgear/pkg/netgear/decode"
 	"github.com/gherlein/go-netgear/pkg/netgear/internal"
 )
 
@@ -23,7 +28,8 @@ func newPortManager(client *Client) *PortManager {
 	}
 }
 
-// GetSettings retrieves port settings
+// GetSettings retrieves port settings without their VLAN assignment; use
+// GetSettingsWithVLANs to fill in PVID and VLAN tagging
 func (m *PortManager) GetSettings(ctx context.Context) ([]PortSettings, error) {
 	if !m.client.IsAuthenticated() {
 		return nil, ErrNotAuthenticated
@@ -44,46 +50,94 @@ func (m *PortManager) GetSettings(ctx context.Context) ([]PortSettings, error) {
 		return nil, err // Error already wrapped by makeAuthenticatedRequestWithFallback
 	}
 
-	// Parse the response
+	return m.settingsFromPage(response)
+}
+
+// GetSettingsWithVLANs retrieves port settings together with each port's PVID
+// and VLAN tagging. Reading the VLAN state takes a request per VLAN on top of
+// the settings page, so pollers that only need speeds and limits should use
+// GetSettings. Models without VLAN pages return the settings alone.
+func (m *PortManager) GetSettingsWithVLANs(ctx context.Context) ([]PortSettings, error) {
+	settings, err := m.GetSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.attachVLANState(ctx, settings); err != nil {
+		return nil, NewOperationError("failed to read VLAN state for ports", err)
+	}
+	return settings, nil
+}
+
+// settingsFromPage parses a page listing port settings, normalizing the
+// speed and status labels firmware uses
+func (m *PortManager) settingsFromPage(response string) ([]PortSettings, error) {
 	rawData, err := m.parser.ParsePortSettings(response)
 	if err != nil {
 		return nil, NewParsingError("failed to parse port settings", err)
 	}
 
-	// Convert to strongly typed structures
-	var settings []PortSettings
-	for _, raw := range rawData {
-		setting := PortSettings{}
-
-		if portID, ok := raw["port_id"].(int); ok {
-			setting.PortID = portID
-		}
-		if portName, ok := raw["port_name"].(string); ok {
-			setting.PortName = portName
-		}
-		if speed, ok := raw["speed"].(string); ok {
-			setting.Speed = PortSpeed(speed)
-		}
-		if ingressLimit, ok := raw["ingress_limit"].(string); ok {
-			setting.IngressLimit = ingressLimit
-		}
-		if egressLimit, ok := raw["egress_limit"].(string); ok {
-			setting.EgressLimit = egressLimit
-		}
-		if flowControl, ok := raw["flow_control"].(bool); ok {
-			setting.FlowControl = flowControl
-		}
-		if status, ok := raw["status"].(string); ok {
-			setting.Status = PortStatus(status)
+	settings, err := decode.Records[PortSettings](rawData)
+	if err != nil {
+		return nil, NewParsingError("failed to decode port settings", err)
+	}
+	for i := range settings {
+		if parsed, err := ParsePortSpeed(string(settings[i].Speed)); err == nil {
+			settings[i].Speed = parsed
 		}
-		if linkSpeed, ok := raw["link_speed"].(string); ok {
-			setting.LinkSpeed = linkSpeed
+		if parsed, err := ParsePortStatus(string(settings[i].Status)); err == nil {
+			settings[i].Status = parsed
 		}
+	}
+	sortByPort(settings, func(s PortSettings) int { return s.PortID })
+	return settings, nil
+}
 
-		settings = append(settings, setting)
+// attachVLANState fills in PVID and VLAN membership for each port
+func (m *PortManager) attachVLANState(ctx context.Context, settings []PortSettings) error {
+	if !m.client.endpoints.IsEndpointSupported(EndpointVLANConfig) ||
+		!m.client.endpoints.IsEndpointSupported(EndpointVLANPVID) {
+		return nil
 	}
 
-	return settings, nil
+	vlans, pvids, err := m.vlanState(ctx)
+	if err != nil {
+		return err
+	}
+	applyVLANState(settings, vlans, pvids)
+	return nil
+}
+
+// vlanState reads every VLAN with its members and the PVID of every port
+func (m *PortManager) vlanState(ctx context.Context) ([]VLAN, map[int]int, error) {
+	vlanMgr := newVLANManager(m.client)
+	vlans, _, err := vlanMgr.getVLANs(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	pvids, err := vlanMgr.getPVIDs(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return vlans, pvids, nil
+}
+
+// applyVLANState sets the PVID and VLAN membership of each port from the
+// switch's VLANs
+func applyVLANState(settings []PortSettings, vlans []VLAN, pvids map[int]int) {
+	for i := range settings {
+		portID := settings[i].PortID
+		settings[i].PVID = pvids[portID]
+		settings[i].UntaggedVLANs = nil
+		settings[i].TaggedVLANs = nil
+		for _, vlan := range vlans {
+			switch vlan.Members[portID] {
+			case VLANMemberUntagged:
+				settings[i].UntaggedVLANs = append(settings[i].UntaggedVLANs, vlan.ID)
+			case VLANMemberTagged:
+				settings[i].TaggedVLANs = append(settings[i].TaggedVLANs, vlan.ID)
+			}
+		}
+	}
 }
 
 // UpdatePort updates settings for specific ports
@@ -91,10 +145,20 @@ func (m *PortManager) UpdatePort(ctx context.Context, updates ...PortUpdate) err
 	if !m.client.IsAuthenticated() {
 		return ErrNotAuthenticated
 	}
+	if err := m.client.admitWrite(ctx); err != nil {
+		return err
+	}
 
 	if len(updates) == 0 {
 		return NewOperationError("no updates provided", nil)
 	}
+	portIDs := make([]int, len(updates))
+	for i, update := range updates {
+		portIDs[i] = update.PortID
+	}
+	if err := m.client.checkPortLocks(ctx, portIDs...); err != nil {
+		return err
+	}
 
 	// Check if port updates is supported for this model
 	if err := m.client.endpoints.ValidateEndpoint(EndpointPortUpdate); err != nil {
@@ -106,7 +170,13 @@ func (m *PortManager) UpdatePort(ctx context.Context, updates ...PortUpdate) err
 	endpoint := endpointInfo.URL
 
 	// Apply each update
+	batch := m.client.newBatch("port update")
 	for _, update := range updates {
+		if batch.stopped() {
+			batch.skip(update.PortID)
+			continue
+		}
+
 		data := url.Values{}
 
 		// Add port identification
@@ -140,16 +210,19 @@ func (m *PortManager) UpdatePort(ctx context.Context, updates ...PortUpdate) err
 		// Make the update request with graceful 404 handling
 		response, err := m.client.makeAuthenticatedRequestWithFallback(ctx, "POST", endpoint, data, EndpointPortUpdate)
 		if err != nil {
-			return err // Error already wrapped by makeAuthenticatedRequestWithFallback
+			batch.record(update.PortID, withPort(err, update.PortID)) // Error already wrapped by makeAuthenticatedRequestWithFallback
+			continue
 		}
 
 		// Check for errors in response
 		if errorMsg := internal.ExtractErrorMessage(response); errorMsg != "" {
-			return NewOperationError(fmt.Sprintf("update failed for port %d: %s", update.PortID, errorMsg), nil)
+			batch.record(update.PortID, m.client.portError(update.PortID, fmt.Sprintf("update failed for port %d: %s", update.PortID, errorMsg), nil))
+			continue
 		}
+		batch.record(update.PortID, nil)
 	}
 
-	return nil
+	return batch.err()
 }
 
 // SetPortName sets the name for a specific port
@@ -168,8 +241,13 @@ func (m *PortManager) SetPortSpeed(ctx context.Context, portID int, speed PortSp
 	})
 }
 
-// SetPortFlowControl sets the flow control for a specific port
+// SetPortFlowControl sets the flow control for a specific port. On switches
+// that only have a switch-wide setting it returns ErrPerPortFlowControl.
 func (m *PortManager) SetPortFlowControl(ctx context.Context, portID int, enabled bool) error {
+	if !m.client.endpoints.IsEndpointSupported(EndpointPortUpdate) &&
+		m.client.endpoints.IsEndpointSupported(EndpointFlowControl) {
+		return ErrPerPortFlowControl.WithSwitch(m.client.address, m.client.model).WithPort(portID)
+	}
 	return m.UpdatePort(ctx, PortUpdate{
 		PortID:      portID,
 		FlowControl: &enabled,
@@ -198,7 +276,7 @@ func (m *PortManager) GetPortSettings(ctx context.Context, portID int) (*PortSet
 		}
 	}
 
-	return nil, NewOperationError(fmt.Sprintf("port %d not found", portID), nil)
+	return nil, m.client.portError(portID, fmt.Sprintf("port %d not found", portID), nil)
 }
 
 // DisablePort disables a specific port
@@ -217,4 +295,174 @@ func (m *PortManager) EnablePort(ctx context.Context, portID int) error {
 		PortID: portID,
 		Speed:  &speed,
 	})
-}
\ No newline at end of file
+}
+
+// GetAttachedDevices lists the devices the switch reports on each port, with
+// their IP address where the firmware knows it. Not all firmware versions
+// provide this page.
+func (m *PortManager) GetAttachedDevices(ctx context.Context) ([]AttachedDevice, error) {
+	if !m.client.IsAuthenticated() {
+		return nil, ErrNotAuthenticated
+	}
+
+	if err := m.client.endpoints.ValidateEndpoint(EndpointAttachedDevices); err != nil {
+		return nil, err
+	}
+
+	endpoint := m.client.endpoints.GetEndpoint(EndpointAttachedDevices).URL
+	response, err := m.client.makeAuthenticatedRequestWithFallback(ctx, "GET", endpoint, nil, EndpointAttachedDevices)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := internal.ParseAttachedDevices(response)
+	if err != nil {
+		return nil, NewParsingError("failed to parse attached devices", err)
+	}
+
+	devices := make([]AttachedDevice, 0, len(rows))
+	for _, row := range rows {
+		device := AttachedDevice{
+			MACAddress: normalizeMAC(row["mac"]),
+			Name:       row["name"],
+		}
+		device.PortID, _ = strconv.Atoi(row["port"])
+		device.VLAN, _ = strconv.Atoi(row["vlan"])
+		if ip := net.ParseIP(row["ip"]); ip != nil && !ip.IsUnspecified() {
+			device.IPAddress = ip.String()
+		}
+		devices = append(devices, device)
+	}
+
+	return devices, nil
+}
+
+// normalizeMAC renders a MAC address as lower-case colon-separated hex,
+// leaving values it cannot parse unchanged
+func normalizeMAC(mac string) string {
+	if hw, err := net.ParseMAC(strings.TrimSpace(mac)); err == nil {
+		return hw.String()
+	}
+	return mac
+}
+
+// broadcastFilterField matches the per-port form controls of the broadcast filtering page
+var broadcastFilterField = regexp.MustCompile(`^(?i:broadcastFilter|bcastFilter|bcFilter)_?(\d+)$`)
+
+// GetBroadcastFilters reads the ingress broadcast filtering state of every
+// port. Only EP firmware on GS30x models has this page.
+func (m *PortManager) GetBroadcastFilters(ctx context.Context) ([]PortBroadcastFilter, error) {
+	if !m.client.IsAuthenticated() {
+		return nil, ErrNotAuthenticated
+	}
+	if err := m.client.endpoints.ValidateEndpoint(EndpointBroadcastFilter); err != nil {
+		return nil, err
+	}
+
+	endpoint := m.client.endpoints.GetEndpoint(EndpointBroadcastFilter).URL
+	response, err := m.client.makeAuthenticatedRequestWithFallback(ctx, "GET", endpoint, nil, EndpointBroadcastFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	values, fields, err := parseBroadcastFilters(response)
+	if err != nil {
+		return nil, err
+	}
+
+	filters := make([]PortBroadcastFilter, 0, len(fields))
+	for portID, field := range fields {
+		filters = append(filters, PortBroadcastFilter{PortID: portID, Enabled: isEnabledValue(values[field])})
+	}
+	sort.Slice(filters, func(i, j int) bool { return filters[i].PortID < filters[j].PortID })
+	return filters, nil
+}
+
+// UpdateBroadcastFilters changes the broadcast filtering state of the given
+// ports in a single form submission, leaving all other ports unchanged
+func (m *PortManager) UpdateBroadcastFilters(ctx context.Context, filters ...PortBroadcastFilter) error {
+	if !m.client.IsAuthenticated() {
+		return ErrNotAuthenticated
+	}
+	if err := m.client.admitWrite(ctx); err != nil {
+		return err
+	}
+	if len(filters) == 0 {
+		return NewOperationError("no updates provided", nil)
+	}
+	portIDs := make([]int, len(filters))
+	for i, filter := range filters {
+		portIDs[i] = filter.PortID
+	}
+	if err := m.client.checkPortLocks(ctx, portIDs...); err != nil {
+		return err
+	}
+	if err := m.client.endpoints.ValidateEndpoint(EndpointBroadcastFilter); err != nil {
+		return err
+	}
+
+	endpoint := m.client.endpoints.GetEndpoint(EndpointBroadcastFilter).URL
+	page, err := m.client.makeAuthenticatedRequestWithFallback(ctx, "GET", endpoint, nil, EndpointBroadcastFilter)
+	if err != nil {
+		return err
+	}
+
+	values, fields, err := parseBroadcastFilters(page)
+	if err != nil {
+		return err
+	}
+
+	// Resubmit the whole form so ports not being changed keep their state
+	data := url.Values{}
+	for name, value := range values {
+		data.Set(name, value)
+	}
+	for _, filter := range filters {
+		field, ok := fields[filter.PortID]
+		if !ok {
+			return m.client.portError(filter.PortID, fmt.Sprintf("port %d has no broadcast filtering setting", filter.PortID), nil)
+		}
+		data.Set(field, "0")
+		if filter.Enabled {
+			data.Set(field, "1")
+		}
+	}
+	if hash := m.client.extractSecurityHash(ctx, page); hash != "" {
+		data.Set(m.client.hashFieldName(), hash)
+	}
+
+	response, err := m.client.makeAuthenticatedRequestWithFallback(ctx, "POST", endpoint, data, EndpointBroadcastFilter)
+	if err != nil {
+		return err
+	}
+	if errorMsg := internal.ExtractErrorMessage(response); errorMsg != "" {
+		return NewOperationError(fmt.Sprintf("broadcast filter update failed: %s", errorMsg), nil).WithSwitch(m.client.address, m.client.model)
+	}
+	return nil
+}
+
+// SetBroadcastFilter enables or disables ingress broadcast filtering on a single port
+func (m *PortManager) SetBroadcastFilter(ctx context.Context, portID int, enabled bool) error {
+	return m.UpdateBroadcastFilters(ctx, PortBroadcastFilter{PortID: portID, Enabled: enabled})
+}
+
+// parseBroadcastFilters returns the page's form values and the broadcast
+// filtering field of each port
+func parseBroadcastFilters(content string) (map[string]string, map[int]string, error) {
+	values, err := internal.ParseFormValues(content)
+	if err != nil {
+		return nil, nil, NewParsingError("failed to parse broadcast filtering settings", err)
+	}
+
+	fields := make(map[int]string)
+	for name := range values {
+		if match := broadcastFilterField.FindStringSubmatch(name); match != nil {
+			portID, _ := strconv.Atoi(match[1])
+			fields[portID] = name
+		}
+	}
+	if len(fields) == 0 {
+		return nil, nil, NewParsingError("broadcast filtering settings not found in response", nil)
+	}
+	return values, fields, nil
+}
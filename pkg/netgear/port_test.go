@@ -0,0 +1,132 @@
+package netgear
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestBroadcastFilters(t *testing.T) {
+	var posted string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			r.ParseForm()
+			posted = r.PostForm.Get("bcFilter1") + r.PostForm.Get("bcFilter2") + "/" + r.PostForm.Get("hash")
+		}
+		fmt.Fprint(w, `<form>
+			<select name="bcFilter1"><option value="0">Disable</option><option value="1" selected>Enable</option></select>
+			<select name="bcFilter2"><option value="0" selected>Disable</option><option value="1">Enable</option></select>
+			<input type="hidden" name="hash" value="h1"></form>`)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	address := strings.TrimPrefix(server.URL, "http://")
+	client, err := NewClient(address, factoryClientOptions(address)...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	filters, err := client.Ports().GetBroadcastFilters(ctx)
+	if err != nil {
+		t.Fatalf("GetBroadcastFilters failed: %v", err)
+	}
+	if len(filters) != 2 || !filters[0].Enabled || filters[1].Enabled {
+		t.Fatalf("unexpected filters %+v", filters)
+	}
+
+	if err := client.Ports().SetBroadcastFilter(ctx, 2, true); err != nil {
+		t.Fatalf("SetBroadcastFilter failed: %v", err)
+	}
+	if posted != "11/h1" {
+		t.Errorf("unexpected posted form %q", posted)
+	}
+
+	if err := client.Ports().SetBroadcastFilter(ctx, 9, true); err == nil {
+		t.Error("expected error for a port without a broadcast filtering setting")
+	}
+}
+
+func TestBroadcastFiltersUnsupportedModel(t *testing.T) {
+	tokenMgr := NewMemoryTokenManager()
+	tokenMgr.StoreToken(context.Background(), "192.0.2.1", "token", ModelGS316EPP)
+	client, err := NewClient("192.0.2.1", WithTokenManager(tokenMgr), WithPasswordManager(nil))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	if _, err := client.Ports().GetBroadcastFilters(context.Background()); err == nil {
+		t.Error("expected GS316 to reject broadcast filtering")
+	}
+}
+
+func TestGetSettingsWithVLANs(t *testing.T) {
+	// A GS316 serving the VLAN pages of vlanSwitch under its own paths
+	sw := &vlanSwitch{
+		members: map[int]string{1: "12", 20: "21"},
+		pvids:   map[int]int{1: 1, 2: 20},
+	}
+	vlanPaths := map[string]string{
+		"/iss/specific/vlanConf.html":       "/8021qCf.cgi",
+		"/iss/specific/vlanMembership.html": "/8021qMembe.cgi",
+		"/iss/specific/vlanPvid.html":       "/portPVID.cgi",
+	}
+	var requests []string
+	failVLANs := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path)
+		if r.URL.Path == "/iss/specific/interface.html" {
+			fmt.Fprint(w, `<table><tr><th>Port</th></tr>
+				<tr><td>1</td><td>uplink</td><td>Auto</td><td>No Limit</td><td>No Limit</td><td>Off</td><td>Up</td><td>1000M</td></tr>
+				<tr><td>2</td><td>camera</td><td>Auto</td><td>No Limit</td><td>No Limit</td><td>Off</td><td>Up</td><td>100M</td></tr></table>`)
+			return
+		}
+		if failVLANs {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if path, ok := vlanPaths[r.URL.Path]; ok {
+			query := r.URL.Query()
+			query.Set("VLAN_ID", query.Get("vlanId"))
+			r.URL.Path, r.URL.RawQuery = path, query.Encode()
+		}
+		sw.serve(w, r)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	address := strings.TrimPrefix(server.URL, "http://")
+	tokenMgr := NewMemoryTokenManager()
+	tokenMgr.StoreToken(ctx, address, "token", ModelGS316EP)
+	client, err := NewClient(address, WithTokenManager(tokenMgr), WithPasswordManager(nil))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	settings, err := client.Ports().GetSettings(ctx)
+	if err != nil || len(settings) != 2 {
+		t.Fatalf("GetSettings failed: %v (%+v)", err, settings)
+	}
+	if len(requests) != 1 || settings[1].PVID != 0 {
+		t.Errorf("expected GetSettings to read only the interface page, got %v", requests)
+	}
+
+	settings, err = client.Ports().GetSettingsWithVLANs(ctx)
+	if err != nil {
+		t.Fatalf("GetSettingsWithVLANs failed: %v", err)
+	}
+	if settings[0].PVID != 1 || fmt.Sprint(settings[0].UntaggedVLANs, settings[0].TaggedVLANs) != "[1] [20]" {
+		t.Errorf("unexpected VLANs of port 1: %+v", settings[0])
+	}
+	if settings[1].PVID != 20 || fmt.Sprint(settings[1].UntaggedVLANs, settings[1].TaggedVLANs) != "[20] [1]" {
+		t.Errorf("unexpected VLANs of port 2: %+v", settings[1])
+	}
+
+	failVLANs = true
+	if _, err := client.Ports().GetSettingsWithVLANs(ctx); err == nil {
+		t.Error("expected an error when the VLAN pages fail")
+	}
+}
@@ -0,0 +1,95 @@
+package netgear
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxPortNameLength is the longest port name, in characters, the supported
+// firmware accepts
+const MaxPortNameLength = 16
+
+// PortNoteSeparator separates a port's name from its note in the port name
+// the switch stores, e.g. "cam-lobby#T4711". Names using notes must not
+// contain it themselves.
+const PortNoteSeparator = "#"
+
+// EncodePortNote returns the port name storing name and a short note, such
+// as a ticket number, for firmware without port description fields. The note
+// is kept whole and the name shortened if both do not fit in
+// MaxPortNameLength; an empty note returns name unchanged.
+func EncodePortNote(name, note string) (string, error) {
+	if strings.Contains(note, PortNoteSeparator) {
+		return "", NewOperationError(fmt.Sprintf("port note %q contains the separator %q", note, PortNoteSeparator), nil)
+	}
+	if strings.Contains(name, PortNoteSeparator) {
+		return "", NewOperationError(fmt.Sprintf("port name %q contains the note separator %q", name, PortNoteSeparator), nil)
+	}
+	if note == "" {
+		return name, nil
+	}
+
+	room := MaxPortNameLength - utf8.RuneCountInString(PortNoteSeparator+note)
+	if room < 0 {
+		return "", NewOperationError(fmt.Sprintf("port note %q is too long for a %d character port name", note, MaxPortNameLength), nil)
+	}
+	return truncateRunes(name, room) + PortNoteSeparator + note, nil
+}
+
+// SplitPortNote splits a port name written by EncodePortNote into the name
+// and the note; names without a note return an empty note
+func SplitPortNote(portName string) (name, note string) {
+	i := strings.LastIndex(portName, PortNoteSeparator)
+	if i < 0 {
+		return portName, ""
+	}
+	return portName[:i], portName[i+len(PortNoteSeparator):]
+}
+
+// StripPortNote returns a port name without its note
+func StripPortNote(portName string) string {
+	name, _ := SplitPortNote(portName)
+	return name
+}
+
+// Note returns the note stored in the port name, if any
+func (s PortSettings) Note() string {
+	_, note := SplitPortNote(s.PortName)
+	return note
+}
+
+// SetPortNote stores note in the name of a port, keeping the name before
+// the separator as far as it fits. An empty note removes the note.
+func (m *PortManager) SetPortNote(ctx context.Context, portID int, note string) error {
+	settings, err := m.GetSettings(ctx)
+	if err != nil {
+		return err
+	}
+	for _, port := range settings {
+		if port.PortID != portID {
+			continue
+		}
+		name, err := EncodePortNote(StripPortNote(port.PortName), note)
+		if err != nil {
+			return withPort(err, portID)
+		}
+		if name == port.PortName {
+			return nil
+		}
+		return m.SetPortName(ctx, portID, name)
+	}
+	return m.client.portError(portID, fmt.Sprintf("port %d not found", portID), nil)
+}
+
+// truncateRunes returns the first n characters of s
+func truncateRunes(s string, n int) string {
+	for i := range s {
+		if n == 0 {
+			return s[:i]
+		}
+		n--
+	}
+	return s
+}
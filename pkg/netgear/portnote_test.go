@@ -0,0 +1,112 @@
+package netgear
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestEncodePortNote(t *testing.T) {
+	tests := []struct {
+		name, note string
+		want       string
+		wantErr    bool
+	}{
+		{"cam-lobby", "T4711", "cam-lobby#T4711", false},
+		{"cam-lobby", "", "cam-lobby", false},
+		{"", "T4711", "#T4711", false},
+		{"ceiling-camera-2", "T4711", "ceiling-ca#T4711", false}, // name shortened to fit 16
+		{"kamera-küche-süd", "T1", "kamera-küche-#T1", false},    // counted in characters, not bytes
+		{"uplink", "123456789012345", "#123456789012345", false},
+		{"uplink", "1234567890123456", "", true},
+		{"a#b", "T1", "", true},
+		{"uplink", "T#1", "", true},
+	}
+	for _, tt := range tests {
+		got, err := EncodePortNote(tt.name, tt.note)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("EncodePortNote(%q, %q) = %q, %v; want %q", tt.name, tt.note, got, err, tt.want)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+		if utf8.RuneCountInString(got) > MaxPortNameLength && tt.note != "" {
+			t.Errorf("EncodePortNote(%q, %q) = %q exceeds %d characters", tt.name, tt.note, got, MaxPortNameLength)
+		}
+
+		// Round trip: the note survives whole, the name as far as it fit
+		name, note := SplitPortNote(got)
+		if note != tt.note || !strings.HasPrefix(tt.name, name) {
+			t.Errorf("SplitPortNote(%q) = %q, %q; want a prefix of %q and %q", got, name, note, tt.name, tt.note)
+		}
+		if StripPortNote(got) != name {
+			t.Errorf("StripPortNote(%q) = %q, want %q", got, StripPortNote(got), name)
+		}
+	}
+}
+
+func TestSetPortNote(t *testing.T) {
+	names := map[string]string{"1": "uplink", "2": "ceiling-camera-2#T1"}
+	posts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/iss/specific/interface.html" {
+			fmt.Fprint(w, "<html></html>")
+			return
+		}
+		if r.Method == "POST" {
+			r.ParseForm()
+			names[r.PostForm.Get("port")] = r.PostForm.Get("name")
+			posts++
+		}
+		fmt.Fprintf(w, `<table><tr><th>Port</th><th>Name</th></tr>
+			<tr><td>1</td><td>%s</td></tr><tr><td>2</td><td>%s</td></tr></table>`, names["1"], names["2"])
+	}))
+	defer server.Close()
+	address := strings.TrimPrefix(server.URL, "http://")
+
+	tokenMgr := NewMemoryTokenManager()
+	tokenMgr.StoreToken(context.Background(), address, "token", ModelGS316EP)
+	client, err := NewClient(address, WithTokenManager(tokenMgr), WithPasswordManager(nil))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	ctx := context.Background()
+	ports := client.Ports()
+
+	if err := ports.SetPortNote(ctx, 1, "T4711"); err != nil {
+		t.Fatalf("SetPortNote failed: %v", err)
+	}
+	if names["1"] != "uplink#T4711" {
+		t.Errorf("expected uplink#T4711, got %q", names["1"])
+	}
+
+	// Replacing a note keeps the name, shortened as far as the new note needs
+	if err := ports.SetPortNote(ctx, 2, "T99"); err != nil {
+		t.Fatalf("SetPortNote failed: %v", err)
+	}
+	if names["2"] != "ceiling-came#T99" {
+		t.Errorf("unexpected name %q", names["2"])
+	}
+
+	settings, err := ports.GetSettings(ctx)
+	if err != nil {
+		t.Fatalf("GetSettings failed: %v", err)
+	}
+	if settings[0].Note() != "T4711" || settings[1].Note() != "T99" {
+		t.Errorf("unexpected notes %q, %q", settings[0].Note(), settings[1].Note())
+	}
+
+	// Setting the same note again does not write
+	before := posts
+	if err := ports.SetPortNote(ctx, 1, "T4711"); err != nil || posts != before {
+		t.Errorf("expected no write for an unchanged note, got %d writes (%v)", posts-before, err)
+	}
+	if err := ports.SetPortNote(ctx, 9, "T1"); err == nil {
+		t.Error("expected an error for a missing port")
+	}
+}
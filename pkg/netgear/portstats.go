@@ -0,0 +1,158 @@
+package netgear
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gherlein/go-netgear/pkg/netgear/internal"
+)
+
+// PortStatistics are the traffic counters of a port since the switch started
+// or its counters were last reset. Counters the firmware does not show are 0.
+type PortStatistics struct {
+	PortID    int    `json:"port_id"`
+	RxBytes   uint64 `json:"rx_bytes"`
+	TxBytes   uint64 `json:"tx_bytes"`
+	RxPackets uint64 `json:"rx_packets,omitempty"`
+	TxPackets uint64 `json:"tx_packets,omitempty"`
+	RxErrors  uint64 `json:"rx_errors,omitempty"`
+	TxErrors  uint64 `json:"tx_errors,omitempty"`
+	CRCErrors uint64 `json:"crc_errors"`
+}
+
+// GetStatistics reads the traffic counters of every port from the port
+// statistics page, sorted by port. Poll it to derive bandwidth from the
+// byte counters' growth.
+func (m *PortManager) GetStatistics(ctx context.Context) ([]PortStatistics, error) {
+	if !m.client.IsAuthenticated() {
+		return nil, ErrNotAuthenticated
+	}
+	if err := m.client.endpoints.ValidateEndpoint(EndpointPortStatistics); err != nil {
+		return nil, err
+	}
+
+	endpoint := m.client.endpoints.GetEndpoint(EndpointPortStatistics).URL
+	response, err := m.client.makeAuthenticatedRequestWithFallback(ctx, "GET", endpoint, nil, EndpointPortStatistics)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := internal.ParsePortStatistics(response)
+	if err != nil {
+		return nil, NewParsingError("failed to parse port statistics", err)
+	}
+
+	statistics := make([]PortStatistics, 0, len(rows))
+	for _, row := range rows {
+		portID, err := strconv.Atoi(strings.TrimSpace(row["port"]))
+		if err != nil || portID < 1 {
+			continue
+		}
+		stats := PortStatistics{PortID: portID}
+		for key, counter := range map[string]*uint64{
+			"rx_bytes":   &stats.RxBytes,
+			"tx_bytes":   &stats.TxBytes,
+			"rx_packets": &stats.RxPackets,
+			"tx_packets": &stats.TxPackets,
+			"rx_errors":  &stats.RxErrors,
+			"tx_errors":  &stats.TxErrors,
+			"crc_errors": &stats.CRCErrors,
+		} {
+			if value, ok := row[key]; ok {
+				if *counter, err = parseCounter(value); err != nil {
+					return nil, NewParsingError(fmt.Sprintf("invalid %s counter %q for port %d", key, value, portID), err)
+				}
+			}
+		}
+		statistics = append(statistics, stats)
+	}
+
+	sort.Slice(statistics, func(i, j int) bool { return statistics[i].PortID < statistics[j].PortID })
+	return statistics, nil
+}
+
+// GetPortStatistics reads the traffic counters of a single port
+func (m *PortManager) GetPortStatistics(ctx context.Context, portID int) (*PortStatistics, error) {
+	statistics, err := m.GetStatistics(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, stats := range statistics {
+		if stats.PortID == portID {
+			return &stats, nil
+		}
+	}
+
+	return nil, m.client.portError(portID, fmt.Sprintf("port %d not found", portID), nil)
+}
+
+// ResetStatistics sets the traffic counters of a port back to zero
+func (m *PortManager) ResetStatistics(ctx context.Context, portID int) error {
+	if !m.client.IsAuthenticated() {
+		return ErrNotAuthenticated
+	}
+	if err := m.client.admitWrite(ctx); err != nil {
+		return err
+	}
+	if portID < 1 {
+		return m.client.portError(portID, fmt.Sprintf("invalid port %d", portID), nil)
+	}
+	if err := m.client.checkPortLocks(ctx, portID); err != nil {
+		return err
+	}
+	if err := m.client.endpoints.ValidateEndpoint(EndpointPortStatistics); err != nil {
+		return err
+	}
+
+	endpoint := m.client.endpoints.GetEndpoint(EndpointPortStatistics).URL
+	page, err := m.client.makeAuthenticatedRequestWithFallback(ctx, "GET", endpoint, nil, EndpointPortStatistics)
+	if err != nil {
+		return err
+	}
+
+	data := url.Values{}
+	if m.client.model.FormStyle() == FormStyleBitmap {
+		// GS316 clears the ports selected in a PortList bitmap
+		bitmap, err := PortsToBitmap([]int{portID}, GS316PortCount)
+		if err != nil {
+			return err
+		}
+		data.Set("TYPE", "clearCounters")
+		data.Set("PortList", bitmap)
+	} else {
+		data.Set("port", strconv.Itoa(portID))
+		data.Set("action", "clear")
+	}
+	if hash := m.client.extractSecurityHash(ctx, page); hash != "" {
+		data.Set(m.client.hashFieldName(), hash)
+	}
+
+	response, err := m.client.makeAuthenticatedRequestWithFallback(ctx, "POST", endpoint, data, EndpointPortStatistics)
+	if err != nil {
+		return m.client.portError(portID, fmt.Sprintf("failed to reset statistics for port %d", portID), err)
+	}
+	if errorMsg := internal.ExtractErrorMessage(response); errorMsg != "" {
+		return m.client.portError(portID, fmt.Sprintf("statistics reset failed for port %d: %s", portID, errorMsg), nil)
+	}
+
+	m.client.logger.Debug("reset port statistics", "address", m.client.address, "port", portID)
+	return nil
+}
+
+// parseCounter parses a counter as shown by the firmware: decimal, possibly
+// with thousands separators, or hexadecimal with a 0x prefix
+func parseCounter(value string) (uint64, error) {
+	value = strings.NewReplacer(",", "", " ", "").Replace(strings.TrimSpace(value))
+	if value == "" {
+		return 0, nil
+	}
+	if hex, ok := strings.CutPrefix(strings.ToLower(value), "0x"); ok {
+		return strconv.ParseUint(hex, 16, 64)
+	}
+	return strconv.ParseUint(value, 10, 64)
+}
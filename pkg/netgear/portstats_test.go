@@ -0,0 +1,69 @@
+package netgear
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestPortStatistics(t *testing.T) {
+	var mu sync.Mutex
+	var posted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/portStatistics.cgi" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Method == http.MethodPost {
+			r.ParseForm()
+			mu.Lock()
+			posted = append(posted, r.PostForm.Encode())
+			mu.Unlock()
+		}
+		fmt.Fprint(w, `<input type="hidden" name="hash" value="h1">
+<table>
+<tr><th>Port</th><th>Bytes Received</th><th>Bytes Sent</th><th>CRC Error Packets</th></tr>
+<tr><td>2</td><td>0x1f4</td><td>0</td><td>0</td></tr>
+<tr><td>1</td><td>1,234,567</td><td>89012</td><td>3</td></tr>
+</table>`)
+	}))
+	defer server.Close()
+
+	address := strings.TrimPrefix(server.URL, "http://")
+	client, err := NewClient(address, factoryClientOptions(address)...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	ctx := context.Background()
+
+	statistics, err := client.Ports().GetStatistics(ctx)
+	if err != nil {
+		t.Fatalf("GetStatistics failed: %v", err)
+	}
+	want := []PortStatistics{
+		{PortID: 1, RxBytes: 1234567, TxBytes: 89012, CRCErrors: 3},
+		{PortID: 2, RxBytes: 500},
+	}
+	if len(statistics) != len(want) {
+		t.Fatalf("expected %d ports, got %+v", len(want), statistics)
+	}
+	for i := range want {
+		if statistics[i] != want[i] {
+			t.Errorf("port %d = %+v, want %+v", i+1, statistics[i], want[i])
+		}
+	}
+
+	if err := client.Ports().ResetStatistics(ctx, 2); err != nil {
+		t.Fatalf("ResetStatistics failed: %v", err)
+	}
+	if len(posted) != 1 || posted[0] != "action=clear&hash=h1&port=2" {
+		t.Errorf("unexpected reset form %v", posted)
+	}
+	if _, err := client.Ports().GetPortStatistics(ctx, 9); err == nil {
+		t.Error("expected an error for a port without statistics")
+	}
+}
@@ -0,0 +1,196 @@
+package netgear
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SwitchProfile describes a named switch in the profiles file
+type SwitchProfile struct {
+	Address string `yaml:"address"`
+	// Model skips model detection when set, see WithModel
+	Model Model `yaml:"model,omitempty"`
+	// Credentials names where the password comes from:
+	//
+	//	env:VAR            the environment variable VAR
+	//	file:PATH          a credentials file, see NewFilePasswordProvider
+	//	keyring[:SERVICE]  the OS keyring, see NewKeyringPasswordProvider
+	//
+	// NETGEAR_PASSWORD_<host> and NETGEAR_SWITCHES are consulted when the
+	// source has no password, or when Credentials is empty.
+	Credentials string `yaml:"credentials,omitempty"`
+}
+
+// Profiles is the content of the profiles file, in YAML:
+//
+//	switches:
+//	  lab-sw1:
+//	    address: 192.168.1.10
+//	    model: GS308EPP
+//	    credentials: keyring
+//	  office:
+//	    address: 10.0.0.2
+//	    credentials: env:OFFICE_SWITCH_PASSWORD
+type Profiles struct {
+	Switches map[string]SwitchProfile `yaml:"switches"`
+}
+
+// DefaultProfilesPath returns the profiles file following the XDG Base
+// Directory Specification: $XDG_CONFIG_HOME/go-netgear/switches.yaml,
+// falling back to ~/.config/go-netgear/switches.yaml
+func DefaultProfilesPath() string {
+	if xdgConfig := os.Getenv("XDG_CONFIG_HOME"); xdgConfig != "" {
+		return filepath.Join(xdgConfig, "go-netgear", "switches.yaml")
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		return filepath.Join(home, ".config", "go-netgear", "switches.yaml")
+	}
+	return ""
+}
+
+// LoadProfiles reads a profiles file, DefaultProfilesPath if filename is
+// empty. A missing default file holds no profiles; a missing file named
+// explicitly is an error.
+func LoadProfiles(filename string) (*Profiles, error) {
+	explicit := filename != ""
+	if !explicit {
+		filename = DefaultProfilesPath()
+	}
+	data, err := os.ReadFile(filename)
+	if !explicit && (filename == "" || errors.Is(err, os.ErrNotExist)) {
+		return &Profiles{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profiles: %w", err)
+	}
+
+	var profiles Profiles
+	if err := yaml.Unmarshal(data, &profiles); err != nil {
+		return nil, fmt.Errorf("failed to parse profiles %s: %w", filename, err)
+	}
+	if err := profiles.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	return &profiles, nil
+}
+
+// Validate checks that every profile has an address, a supported model if
+// any and a known credential source
+func (p *Profiles) Validate() error {
+	for _, name := range p.Names() {
+		profile := p.Switches[name]
+		if profile.Address == "" {
+			return fmt.Errorf("profile %q has no address", name)
+		}
+		if profile.Model != "" && !profile.Model.IsSupported() {
+			return fmt.Errorf("profile %q has unsupported model %q", name, profile.Model)
+		}
+		if _, err := profile.PasswordProvider(); err != nil {
+			return fmt.Errorf("profile %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Names returns the profile names, sorted
+func (p *Profiles) Names() []string {
+	names := make([]string, 0, len(p.Switches))
+	for name := range p.Switches {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Lookup returns the profile with the given name
+func (p *Profiles) Lookup(name string) (SwitchProfile, bool) {
+	profile, ok := p.Switches[name]
+	return profile, ok
+}
+
+// NewClient creates a client for the named profile; opts are applied after
+// the profile's own options and so override them
+func (p *Profiles) NewClient(name string, opts ...ClientOption) (*Client, error) {
+	profile, ok := p.Lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown switch profile %q", name)
+	}
+	profileOpts, err := profile.ClientOptions()
+	if err != nil {
+		return nil, fmt.Errorf("profile %q: %w", name, err)
+	}
+	return NewClient(profile.Address, append(profileOpts, opts...)...)
+}
+
+// NewClientFromProfile creates a client for a switch named in the default
+// profiles file, see DefaultProfilesPath
+func NewClientFromProfile(name string, opts ...ClientOption) (*Client, error) {
+	profiles, err := LoadProfiles("")
+	if err != nil {
+		return nil, err
+	}
+	return profiles.NewClient(name, opts...)
+}
+
+// ClientOptions returns the options applying the profile's model hint and
+// credential source
+func (p SwitchProfile) ClientOptions() ([]ClientOption, error) {
+	var opts []ClientOption
+	if p.Model != "" {
+		opts = append(opts, WithModel(p.Model))
+	}
+	provider, err := p.PasswordProvider()
+	if err != nil {
+		return nil, err
+	}
+	if provider != nil {
+		opts = append(opts, WithPasswordProvider(ChainPasswordProvider(provider, NewEnvironmentPasswordProvider())))
+	}
+	return opts, nil
+}
+
+// PasswordProvider returns the provider for the profile's credential
+// source, or nil if it names none
+func (p SwitchProfile) PasswordProvider() (PasswordProvider, error) {
+	source, arg, _ := strings.Cut(p.Credentials, ":")
+	switch source {
+	case "":
+		return nil, nil
+	case "env":
+		if arg == "" {
+			return nil, errors.New("credentials env: needs a variable name")
+		}
+		return PasswordProviderFunc(func(ctx context.Context, address string) (string, error) {
+			if password := os.Getenv(arg); password != "" {
+				return password, nil
+			}
+			return "", ErrPasswordNotFound
+		}), nil
+	case "file":
+		if arg == "" {
+			return nil, errors.New("credentials file: needs a path")
+		}
+		return NewFilePasswordProvider(expandHome(arg)), nil
+	case "keyring":
+		return NewKeyringPasswordProvider(arg), nil
+	default:
+		return nil, fmt.Errorf("unknown credential source %q, use env:, file: or keyring", p.Credentials)
+	}
+}
+
+// expandHome replaces a leading ~/ with the user's home directory
+func expandHome(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	return path
+}
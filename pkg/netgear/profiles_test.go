@@ -0,0 +1,121 @@
+package netgear
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadProfiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "switches.yaml")
+	content := `switches:
+  lab-sw1:
+    address: 192.168.1.10
+    model: GS308EPP
+    credentials: env:LAB_PASSWORD
+  core:
+    address: 10.0.0.2
+`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	profiles, err := LoadProfiles(path)
+	if err != nil {
+		t.Fatalf("LoadProfiles failed: %v", err)
+	}
+	if names := profiles.Names(); !reflect.DeepEqual(names, []string{"core", "lab-sw1"}) {
+		t.Errorf("expected sorted names, got %v", names)
+	}
+	profile, ok := profiles.Lookup("lab-sw1")
+	if !ok || profile.Address != "192.168.1.10" || profile.Model != ModelGS308EPP {
+		t.Errorf("unexpected profile %+v", profile)
+	}
+
+	// The default file may be missing, a file named explicitly may not
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	if got := DefaultProfilesPath(); got != filepath.Join(dir, "go-netgear", "switches.yaml") {
+		t.Errorf("unexpected default path %s", got)
+	}
+	if profiles, err := LoadProfiles(""); err != nil || len(profiles.Names()) != 0 {
+		t.Errorf("expected no profiles without a file, got %v (%v)", profiles, err)
+	}
+	if _, err := LoadProfiles(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Error("expected an error for a missing profiles file")
+	}
+
+	invalid := map[string]string{
+		"no address":  "switches:\n  sw: {model: GS308EPP}\n",
+		"bad model":   "switches:\n  sw: {address: 10.0.0.1, model: XS999}\n",
+		"bad source":  "switches:\n  sw: {address: 10.0.0.1, credentials: vault}\n",
+		"empty env":   "switches:\n  sw: {address: 10.0.0.1, credentials: 'env:'}\n",
+		"not mapping": "switches: [sw]\n",
+	}
+	for name, content := range invalid {
+		os.WriteFile(path, []byte(content), 0600)
+		if _, err := LoadProfiles(path); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestSwitchProfilePasswordProvider(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv("LAB_PASSWORD", "lab-secret")
+
+	provider, err := SwitchProfile{Credentials: "env:LAB_PASSWORD"}.PasswordProvider()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if password, err := provider.GetPassword(ctx, "10.0.0.1"); err != nil || password != "lab-secret" {
+		t.Errorf("expected the variable's password, got %q (%v)", password, err)
+	}
+	provider, _ = SwitchProfile{Credentials: "env:UNSET_PASSWORD"}.PasswordProvider()
+	if _, err := provider.GetPassword(ctx, "10.0.0.1"); !errors.Is(err, ErrPasswordNotFound) {
+		t.Errorf("expected ErrPasswordNotFound, got %v", err)
+	}
+
+	if provider, _ := (SwitchProfile{Credentials: "keyring:lab"}).PasswordProvider(); provider.(*KeyringPasswordProvider).service != "lab" {
+		t.Error("expected the named keyring service")
+	}
+	if provider, _ := (SwitchProfile{Credentials: "file:/etc/netgear.yaml"}).PasswordProvider(); provider.(*FilePasswordProvider).path != "/etc/netgear.yaml" {
+		t.Error("expected the named credentials file")
+	}
+	if provider, err := (SwitchProfile{}).PasswordProvider(); provider != nil || err != nil {
+		t.Errorf("expected no provider without a source, got %v (%v)", provider, err)
+	}
+}
+
+func TestProfilesNewClient(t *testing.T) {
+	tokens := NewMemoryTokenManager()
+	tokens.StoreToken(context.Background(), "192.168.1.10", "cached-token", ModelGS316EP)
+	profiles := &Profiles{Switches: map[string]SwitchProfile{
+		"lab-sw1": {Address: "192.168.1.10"},
+		"offline": {Address: "127.0.0.1:1", Model: ModelGS305EP},
+	}}
+
+	client, err := profiles.NewClient("lab-sw1", WithTokenManager(tokens))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	if client.GetAddress() != "192.168.1.10" || client.GetModel() != ModelGS316EP {
+		t.Errorf("expected the profile's switch, got %s (%s)", client.GetAddress(), client.GetModel())
+	}
+
+	// The model hint spares contacting the switch
+	client, err = profiles.NewClient("offline", WithTokenManager(NewMemoryTokenManager()), WithEnvironmentAuth(false))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	if client.GetModel() != ModelGS305EP {
+		t.Errorf("expected the hinted model, got %s", client.GetModel())
+	}
+
+	if _, err := profiles.NewClient("unknown"); err == nil {
+		t.Error("expected an error for an unknown profile")
+	}
+}
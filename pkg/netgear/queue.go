@@ -0,0 +1,232 @@
+package netgear
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Operation is a journaled write waiting to be applied and verified
+type Operation struct {
+	ID        string         `json:"id"`
+	Address   string         `json:"address"`
+	Port      *PortUpdate    `json:"port,omitempty"`
+	POE       *POEPortUpdate `json:"poe,omitempty"`
+	CreatedAt time.Time      `json:"created_at"`
+	Attempts  int            `json:"attempts"`
+	LastError string         `json:"last_error,omitempty"`
+}
+
+// OperationQueue is a file-backed queue of writes for fire-and-forget
+// automation over unreliable links. Operations survive process restarts and
+// are only removed once reading the switch back shows they took effect.
+// It is safe for concurrent use.
+type OperationQueue struct {
+	mu       sync.Mutex
+	filename string
+	ops      []Operation
+}
+
+// OpenOperationQueue opens the queue journal at filename, creating it if needed
+func OpenOperationQueue(filename string) (*OperationQueue, error) {
+	q := &OperationQueue{filename: filename}
+
+	data, err := os.ReadFile(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return q, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read operation queue: %w", err)
+	}
+	if err := json.Unmarshal(data, &q.ops); err != nil {
+		return nil, fmt.Errorf("failed to parse operation queue: %w", err)
+	}
+	return q, nil
+}
+
+// EnqueuePortUpdate journals a port settings change for the switch at address
+func (q *OperationQueue) EnqueuePortUpdate(address string, update PortUpdate) (string, error) {
+	return q.enqueue(Operation{Address: address, Port: &update})
+}
+
+// EnqueuePOEUpdate journals a POE settings change for the switch at address
+func (q *OperationQueue) EnqueuePOEUpdate(address string, update POEPortUpdate) (string, error) {
+	return q.enqueue(Operation{Address: address, POE: &update})
+}
+
+func (q *OperationQueue) enqueue(op Operation) (string, error) {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return "", fmt.Errorf("failed to generate operation ID: %w", err)
+	}
+	op.ID = hex.EncodeToString(id)
+	op.CreatedAt = time.Now()
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.ops = append(q.ops, op)
+	if err := q.saveLocked(); err != nil {
+		q.ops = q.ops[:len(q.ops)-1]
+		return "", err
+	}
+	return op.ID, nil
+}
+
+// Pending returns the operations not yet verified, oldest first
+func (q *OperationQueue) Pending() []Operation {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return append([]Operation(nil), q.ops...)
+}
+
+// Process applies and verifies every pending operation for the client's
+// switch, in order. Verified operations are removed from the journal; failed
+// ones stay queued with their error recorded for the next call. It returns the
+// number of operations completed and the last failure, if any.
+func (q *OperationQueue) Process(ctx context.Context, client *Client) (int, error) {
+	var completed int
+	var lastErr error
+	for _, op := range q.Pending() {
+		if op.Address != client.GetAddress() {
+			continue
+		}
+		if ctx.Err() != nil {
+			return completed, ctx.Err()
+		}
+
+		err := applyOperation(ctx, client, op)
+		if err == nil {
+			err = verifyOperation(ctx, client, op)
+		}
+		if saveErr := q.finish(op.ID, err); saveErr != nil {
+			return completed, saveErr
+		}
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		completed++
+	}
+	return completed, lastErr
+}
+
+// Run processes the queue for the client every interval until the queue has no
+// operations for the switch or ctx is done
+func (q *OperationQueue) Run(ctx context.Context, client *Client, interval time.Duration) error {
+	for {
+		q.Process(ctx, client)
+
+		remaining := 0
+		for _, op := range q.Pending() {
+			if op.Address == client.GetAddress() {
+				remaining++
+			}
+		}
+		if remaining == 0 {
+			return nil
+		}
+
+		if err := client.Clock().Sleep(ctx, interval); err != nil {
+			return err
+		}
+	}
+}
+
+// finish removes a verified operation or records a failed attempt, and persists the journal
+func (q *OperationQueue) finish(id string, opErr error) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for i := range q.ops {
+		if q.ops[i].ID != id {
+			continue
+		}
+		if opErr == nil {
+			q.ops = append(q.ops[:i], q.ops[i+1:]...)
+		} else {
+			q.ops[i].Attempts++
+			q.ops[i].LastError = opErr.Error()
+		}
+		break
+	}
+	return q.saveLocked()
+}
+
+func (q *OperationQueue) saveLocked() error {
+	data, err := json.MarshalIndent(q.ops, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal operation queue: %w", err)
+	}
+	if err := writeFileAtomic(q.filename, data, 0600); err != nil {
+		return fmt.Errorf("failed to write operation queue: %w", err)
+	}
+	return nil
+}
+
+// applyOperation performs the write described by op
+func applyOperation(ctx context.Context, client *Client, op Operation) error {
+	switch {
+	case op.Port != nil:
+		return client.Ports().UpdatePort(ctx, *op.Port)
+	case op.POE != nil:
+		return client.POE().UpdatePort(ctx, *op.POE)
+	}
+	return NewOperationError(fmt.Sprintf("operation %s has nothing to apply", op.ID), nil)
+}
+
+// verifyOperation reads the port back and checks every field op sets
+func verifyOperation(ctx context.Context, client *Client, op Operation) error {
+	mismatch := func(portID int, field string, want, got any) error {
+		return client.portError(portID, fmt.Sprintf("read-back shows %s=%v, expected %v", field, got, want), nil)
+	}
+
+	if u := op.Port; u != nil {
+		s, err := client.Ports().GetPortSettings(ctx, u.PortID)
+		if err != nil {
+			return err
+		}
+		switch {
+		case u.Name != nil && s.PortName != *u.Name:
+			return mismatch(u.PortID, "name", *u.Name, s.PortName)
+		case u.Speed != nil && s.Speed != *u.Speed:
+			return mismatch(u.PortID, "speed", *u.Speed, s.Speed)
+		case u.IngressLimit != nil && s.IngressLimit != *u.IngressLimit:
+			return mismatch(u.PortID, "ingress_limit", *u.IngressLimit, s.IngressLimit)
+		case u.EgressLimit != nil && s.EgressLimit != *u.EgressLimit:
+			return mismatch(u.PortID, "egress_limit", *u.EgressLimit, s.EgressLimit)
+		case u.FlowControl != nil && s.FlowControl != *u.FlowControl:
+			return mismatch(u.PortID, "flow_control", *u.FlowControl, s.FlowControl)
+		}
+	}
+
+	if u := op.POE; u != nil {
+		s, err := client.POE().GetPortSettings(ctx, u.PortID)
+		if err != nil {
+			return err
+		}
+		switch {
+		case u.Enabled != nil && s.Enabled != *u.Enabled:
+			return mismatch(u.PortID, "enabled", *u.Enabled, s.Enabled)
+		case u.Mode != nil && s.Mode != *u.Mode:
+			return mismatch(u.PortID, "mode", *u.Mode, s.Mode)
+		case u.Priority != nil && s.Priority != *u.Priority:
+			return mismatch(u.PortID, "priority", *u.Priority, s.Priority)
+		case u.PowerLimitType != nil && s.PowerLimitType != *u.PowerLimitType:
+			return mismatch(u.PortID, "power_limit_type", *u.PowerLimitType, s.PowerLimitType)
+		case u.PowerLimitW != nil && s.PowerLimitW != *u.PowerLimitW:
+			return mismatch(u.PortID, "power_limit_w", *u.PowerLimitW, s.PowerLimitW)
+		case u.DetectionType != nil && s.DetectionType != *u.DetectionType:
+			return mismatch(u.PortID, "detection_type", *u.DetectionType, s.DetectionType)
+		case u.LongerDetectionTime != nil && s.LongerDetectionTime != *u.LongerDetectionTime:
+			return mismatch(u.PortID, "longer_detection_time", *u.LongerDetectionTime, s.LongerDetectionTime)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package netgear
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestOperationQueuePersistsFailedAttempts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "busy", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	address := strings.TrimPrefix(server.URL, "http://")
+
+	filename := filepath.Join(t.TempDir(), "queue.json")
+	queue, err := OpenOperationQueue(filename)
+	if err != nil {
+		t.Fatalf("OpenOperationQueue failed: %v", err)
+	}
+
+	enabled := false
+	id, err := queue.EnqueuePOEUpdate(address, POEPortUpdate{PortID: 2, Enabled: &enabled})
+	if err != nil {
+		t.Fatalf("EnqueuePOEUpdate failed: %v", err)
+	}
+	if _, err := queue.EnqueuePOEUpdate("192.0.2.99", POEPortUpdate{PortID: 1, Enabled: &enabled}); err != nil {
+		t.Fatalf("EnqueuePOEUpdate failed: %v", err)
+	}
+
+	client, err := NewClient(address, append(factoryClientOptions(address), WithBusyRetry(BusyRetryPolicy{MaxAttempts: 1}))...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	completed, err := queue.Process(context.Background(), client)
+	if completed != 0 || err == nil {
+		t.Fatalf("expected the write to fail, got completed=%d err=%v", completed, err)
+	}
+
+	// A restarted process sees the failed attempt and the untouched operation for the other switch
+	reopened, err := OpenOperationQueue(filename)
+	if err != nil {
+		t.Fatalf("reopen failed: %v", err)
+	}
+	pending := reopened.Pending()
+	if len(pending) != 2 {
+		t.Fatalf("expected 2 pending operations, got %d", len(pending))
+	}
+	if pending[0].ID != id || pending[0].Attempts != 1 || pending[0].LastError == "" {
+		t.Errorf("expected failed attempt to be journaled, got %+v", pending[0])
+	}
+	if pending[1].Attempts != 0 {
+		t.Errorf("operation for another switch should not be attempted, got %+v", pending[1])
+	}
+}
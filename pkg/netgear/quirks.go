@@ -0,0 +1,200 @@
+package netgear
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gherlein/go-netgear/pkg/netgear/internal"
+)
+
+// Quirks records per-switch protocol details discovered while talking to a
+// switch, so later clients can skip the discovery requests. Pagination is
+// deliberately not recorded: every page the library reads returns its whole
+// table in one response on all supported firmware, so there is nothing to
+// discover.
+type Quirks struct {
+	Model         Model     `json:"model,omitempty"`
+	LoginPath     string    `json:"login_path,omitempty"`
+	HashFieldName string    `json:"hash_field_name,omitempty"`
+	NeedsReferer  bool      `json:"needs_referer,omitempty"`
+	NoPOEOverview bool      `json:"no_poe_overview,omitempty"`
+	NoLLDP        bool      `json:"no_lldp,omitempty"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// QuirksStore persists discovered quirks per switch address.
+// Token managers that also implement QuirksStore have quirks applied
+// automatically, unless the client keeps its data in a WithStore store.
+type QuirksStore interface {
+	// GetQuirks retrieves the stored quirks for an address
+	GetQuirks(ctx context.Context, address string) (*Quirks, error)
+
+	// StoreQuirks saves the quirks for an address
+	StoreQuirks(ctx context.Context, address string, quirks *Quirks) error
+}
+
+// securityHashFields are the form field names firmware versions use for the CSRF hash
+var securityHashFields = []string{"hash", "Hash", "csrf_token", "token"}
+
+// GetQuirks retrieves stored quirks from memory
+func (m *MemoryTokenManager) GetQuirks(ctx context.Context, address string) (*Quirks, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	quirks, exists := m.quirks[address]
+	if !exists {
+		return nil, NewOperationError("quirks not found", nil)
+	}
+
+	copied := quirks
+	return &copied, nil
+}
+
+// StoreQuirks saves quirks in memory
+func (m *MemoryTokenManager) StoreQuirks(ctx context.Context, address string, quirks *Quirks) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.quirks == nil {
+		m.quirks = make(map[string]Quirks)
+	}
+	m.quirks[address] = *quirks
+	return nil
+}
+
+// GetQuirks retrieves stored quirks from the cache directory
+func (m *FileTokenManager) GetQuirks(ctx context.Context, address string) (*Quirks, error) {
+	data, err := os.ReadFile(m.getQuirksFilename(address))
+	if err != nil {
+		return nil, NewOperationError("failed to read quirks file", err)
+	}
+
+	var quirks Quirks
+	if err := json.Unmarshal(data, &quirks); err != nil {
+		return nil, NewParsingError("malformed quirks file", err)
+	}
+
+	return &quirks, nil
+}
+
+// StoreQuirks saves quirks next to the token file in the cache directory
+func (m *FileTokenManager) StoreQuirks(ctx context.Context, address string, quirks *Quirks) error {
+	if err := os.MkdirAll(m.cacheDir, 0700); err != nil {
+		return NewOperationError("failed to create cache directory", err)
+	}
+
+	data, err := json.MarshalIndent(quirks, "", "  ")
+	if err != nil {
+		return NewOperationError("failed to encode quirks", err)
+	}
+
+	if err := writeFileAtomic(m.getQuirksFilename(address), data, 0600); err != nil {
+		return NewOperationError("failed to write quirks file", err)
+	}
+
+	return nil
+}
+
+// getQuirksFilename generates the quirks filename for an address
+func (m *FileTokenManager) getQuirksFilename(address string) string {
+	h := fnv.New32a()
+	h.Write([]byte(address))
+	return filepath.Join(m.cacheDir, fmt.Sprintf("netgear-quirks-%x.json", h.Sum32()))
+}
+
+// loadQuirks applies previously discovered quirks from the token manager, if it stores them
+func (c *Client) loadQuirks(ctx context.Context) {
+	quirks := &Quirks{}
+	if store, ok := c.quirksStore(); ok {
+		if stored, err := store.GetQuirks(ctx, c.address); err == nil {
+			quirks = stored
+			c.logger.Debug("loaded stored quirks", "address", c.address)
+		}
+	}
+
+	c.mu.Lock()
+	c.quirks = quirks
+	c.mu.Unlock()
+	c.httpClient.SetSendReferer(quirks.NeedsReferer)
+}
+
+// rememberQuirks records a change to the switch's quirks and persists it when changed
+func (c *Client) rememberQuirks(ctx context.Context, update func(q *Quirks)) {
+	c.mu.Lock()
+	before := *c.quirks
+	updated := before
+	update(&updated)
+	if updated == before {
+		c.mu.Unlock()
+		return
+	}
+	updated.UpdatedAt = c.clock.Now()
+	*c.quirks = updated
+	c.mu.Unlock()
+
+	store, ok := c.quirksStore()
+	if !ok {
+		return
+	}
+	if err := store.StoreQuirks(ctx, c.address, &updated); err != nil {
+		c.logger.Warn("failed to store quirks", "address", c.address, "error", err)
+	}
+}
+
+// GetQuirks returns the quirks known for the switch
+func (c *Client) GetQuirks() Quirks {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return *c.quirks
+}
+
+// hashFieldName returns the form field name the switch uses for the CSRF hash
+func (c *Client) hashFieldName() string {
+	if field := c.GetQuirks().HashFieldName; field != "" {
+		return field
+	}
+	return "hash"
+}
+
+// cachedHash returns the last security hash seen for a form page
+func (c *Client) cachedHash(endpoint string) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.hashes[endpoint]
+}
+
+// storeHash records the current security hash of a form page
+func (c *Client) storeHash(endpoint, hash string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.hashes == nil {
+		c.hashes = make(map[string]string)
+	}
+	c.hashes[endpoint] = hash
+}
+
+// invalidateHash forgets the security hash of a form page after it was posted
+func (c *Client) invalidateHash(endpoint string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.hashes, endpoint)
+}
+
+// extractSecurityHash finds the CSRF hash in a settings page, remembering which field carried it
+func (c *Client) extractSecurityHash(ctx context.Context, content string) string {
+	candidates := securityHashFields
+	if field := c.GetQuirks().HashFieldName; field != "" {
+		candidates = append([]string{field}, securityHashFields...)
+	}
+
+	value, field := internal.ExtractSecurityHashField(content, candidates)
+	if field != "" {
+		c.rememberQuirks(ctx, func(q *Quirks) { q.HashFieldName = field })
+	}
+	return value
+}
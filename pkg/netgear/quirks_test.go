@@ -0,0 +1,105 @@
+package netgear
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestQuirksRoundTrip(t *testing.T) {
+	var referers []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		referers = append(referers, r.Header.Get("Referer"))
+		w.Write([]byte("<html>ok</html>"))
+	}))
+	defer server.Close()
+	address := strings.TrimPrefix(server.URL, "http://")
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	tokenMgr := NewFileTokenManager(dir)
+	tokenMgr.StoreToken(ctx, address, "token", ModelGS308EPP)
+	first, err := NewClient(address, WithTokenManager(tokenMgr), WithPasswordManager(nil))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	first.rememberQuirks(ctx, func(q *Quirks) {
+		q.Model = ModelGS308EPP
+		q.HashFieldName = "Hash"
+		q.NeedsReferer = true
+	})
+
+	// A new client reading the same cache directory starts with the quirks
+	second, err := NewClient(address, WithTokenManager(NewFileTokenManager(dir)), WithPasswordManager(nil))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	quirks := second.GetQuirks()
+	if quirks.Model != ModelGS308EPP || quirks.HashFieldName != "Hash" || !quirks.NeedsReferer || quirks.UpdatedAt.IsZero() {
+		t.Errorf("expected the stored quirks, got %+v", quirks)
+	}
+	if second.hashFieldName() != "Hash" {
+		t.Errorf("expected the stored hash field, got %q", second.hashFieldName())
+	}
+	if _, err := second.makeAuthenticatedRequest(ctx, "GET", "/page", nil); err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if len(referers) != 1 || referers[0] != server.URL+"/" {
+		t.Errorf("expected the stored Referer quirk to be applied, got %q", referers)
+	}
+
+	// Without a cached token the recorded model is used instead of detecting it
+	tokenMgr.DeleteToken(ctx, address)
+	referers = nil
+	third, err := NewClient(address, WithTokenManager(NewFileTokenManager(dir)), WithPasswordManager(nil))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	if third.GetModel() != ModelGS308EPP || len(referers) != 0 {
+		t.Errorf("expected the recorded model without detection requests, got %s after %d requests", third.GetModel(), len(referers))
+	}
+}
+
+func TestQuirksStoreMissingOrCorrupt(t *testing.T) {
+	ctx := context.Background()
+	const address = "10.0.0.9"
+	dir := t.TempDir()
+	tokenMgr := NewFileTokenManager(dir)
+
+	if _, err := tokenMgr.GetQuirks(ctx, address); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected a missing quirks file to be reported, got %v", err)
+	}
+
+	if err := os.WriteFile(tokenMgr.getQuirksFilename(address), []byte("{not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	var netgearErr *Error
+	if _, err := tokenMgr.GetQuirks(ctx, address); !errors.As(err, &netgearErr) || netgearErr.Type != ErrorTypeParsing {
+		t.Errorf("expected a parsing error for a corrupt quirks file, got %v", err)
+	}
+
+	// A client ignores the corrupt file and replaces it with what it learns
+	tokenMgr.StoreToken(ctx, address, "token", ModelGS316EP)
+	client, err := NewClient(address, WithTokenManager(tokenMgr), WithPasswordManager(nil))
+	if err != nil {
+		t.Fatalf("NewClient failed with a corrupt quirks file: %v", err)
+	}
+	if quirks := client.GetQuirks(); quirks != (Quirks{}) {
+		t.Errorf("expected no quirks from a corrupt file, got %+v", quirks)
+	}
+	client.rememberQuirks(ctx, func(q *Quirks) { q.NoLLDP = true })
+
+	data, err := os.ReadFile(tokenMgr.getQuirksFilename(address))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stored Quirks
+	if err := json.Unmarshal(data, &stored); err != nil || !stored.NoLLDP {
+		t.Errorf("expected the corrupt file to be replaced, got %s (%v)", data, err)
+	}
+}
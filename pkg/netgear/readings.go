@@ -0,0 +1,176 @@
+package netgear
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+)
+
+// Plausible ranges for POE readings on 802.3af/at ports; the power and current
+// bounds scale with the port power of models with POE++ ports
+const (
+	maxPlausibleVoltageV     = 60.0
+	minPlausibleVoltageV     = 30.0 // below this a powered port is not delivering PoE
+	maxPlausibleCurrentMA    = 1000.0
+	maxPlausiblePowerW       = 35.0
+	maxPlausibleTemperatureC = 120.0
+	minPlausibleTemperatureC = -40.0
+)
+
+// ReadingUnits pins the scale factors a model/firmware reports POE readings in.
+// Each factor converts the raw value to V, mA, W or °C; zero means the unit is
+// detected heuristically.
+type ReadingUnits struct {
+	VoltageScale     float64
+	CurrentScale     float64
+	PowerScale       float64
+	TemperatureScale float64
+}
+
+// readingUnitRegistry holds units registered per model and firmware prefix
+var readingUnitRegistry = struct {
+	mu    sync.RWMutex
+	units map[Model]map[string]ReadingUnits
+}{units: make(map[Model]map[string]ReadingUnits)}
+
+// RegisterReadingUnits pins the units used by a model when its firmware version
+// starts with firmwarePrefix (empty matches any firmware). Pinned units take
+// precedence over the heuristics applied to status readings.
+func RegisterReadingUnits(model Model, firmwarePrefix string, units ReadingUnits) {
+	readingUnitRegistry.mu.Lock()
+	defer readingUnitRegistry.mu.Unlock()
+
+	if readingUnitRegistry.units[model] == nil {
+		readingUnitRegistry.units[model] = make(map[string]ReadingUnits)
+	}
+	readingUnitRegistry.units[model][firmwarePrefix] = units
+}
+
+// lookupReadingUnits returns the units registered with the longest matching firmware prefix
+func lookupReadingUnits(model Model, firmware string) ReadingUnits {
+	readingUnitRegistry.mu.RLock()
+	defer readingUnitRegistry.mu.RUnlock()
+
+	var best ReadingUnits
+	bestLen := -1
+	for prefix, units := range readingUnitRegistry.units[model] {
+		if strings.HasPrefix(firmware, prefix) && len(prefix) > bestLen {
+			best, bestLen = units, len(prefix)
+		}
+	}
+	return best
+}
+
+// ReadingIssue describes a status reading that was rescaled or looks implausible
+type ReadingIssue struct {
+	PortID    int     `json:"port_id"`
+	Field     string  `json:"field"`
+	Raw       float64 `json:"raw"`
+	Value     float64 `json:"value"`
+	Converted bool    `json:"converted"` // true if Raw was rescaled to Value, false if Value is implausible
+	Reason    string  `json:"reason"`
+}
+
+func (i ReadingIssue) String() string {
+	if i.Converted {
+		return fmt.Sprintf("port %d %s: converted %g to %g (%s)", i.PortID, i.Field, i.Raw, i.Value, i.Reason)
+	}
+	return fmt.Sprintf("port %d %s: implausible value %g (%s)", i.PortID, i.Field, i.Value, i.Reason)
+}
+
+// ParseReport lists readings that were converted or flagged while parsing a status page
+type ParseReport struct {
+	Endpoint string         `json:"endpoint"`
+	Issues   []ReadingIssue `json:"issues,omitempty"`
+}
+
+// OK reports whether every reading was plausible as reported
+func (r *ParseReport) OK() bool {
+	return len(r.Issues) == 0
+}
+
+// Implausible returns the issues that could not be corrected by unit conversion
+func (r *ParseReport) Implausible() []ReadingIssue {
+	var issues []ReadingIssue
+	for _, issue := range r.Issues {
+		if !issue.Converted {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+func (r *ParseReport) add(issue ReadingIssue) {
+	r.Issues = append(r.Issues, issue)
+}
+
+// normalizePOEStatus converts readings reported in unexpected units (A instead
+// of mA, decivolts, deciwatts, tenths of a degree) and flags values outside
+// plausible bounds for the model's ports
+func normalizePOEStatus(statuses []POEPortStatus, model Model, units ReadingUnits, report *ParseReport) {
+	scale := math.Max(1, model.MaxPortPowerW()/POEPlusPortPowerW)
+	maxPowerW, maxCurrentMA := maxPlausiblePowerW*scale, maxPlausibleCurrentMA*scale
+
+	for i := range statuses {
+		s := &statuses[i]
+
+		s.VoltageV = normalizeReading(report, s.PortID, "voltage_v", s.VoltageV, units.VoltageScale,
+			func(v float64) bool { return v == 0 || (v >= minPlausibleVoltageV && v <= maxPlausibleVoltageV) },
+			[]float64{0.1, 0.001})
+
+		// Fractional currents below 1.5 are amps: 0.25 A is a normal draw, 0.25 mA is not
+		s.CurrentMA = normalizeReading(report, s.PortID, "current_ma", s.CurrentMA, units.CurrentScale,
+			func(v float64) bool { return v <= maxCurrentMA && (v == 0 || v >= 1.5 || v == math.Trunc(v)) },
+			[]float64{1000})
+
+		s.PowerW = normalizeReading(report, s.PortID, "power_w", s.PowerW, units.PowerScale,
+			func(v float64) bool { return v <= maxPowerW },
+			[]float64{0.1, 0.001})
+
+		s.TemperatureC = normalizeReading(report, s.PortID, "temperature_c", s.TemperatureC, units.TemperatureScale,
+			func(v float64) bool { return v >= minPlausibleTemperatureC && v <= maxPlausibleTemperatureC },
+			[]float64{0.1})
+
+		// Power should roughly match voltage × current once both are in range
+		if s.VoltageV > 0 && s.CurrentMA > 0 && s.PowerW > 0 {
+			expected := s.VoltageV * s.CurrentMA / 1000
+			if s.PowerW > expected*2+1 || s.PowerW < expected/2-1 {
+				report.add(ReadingIssue{PortID: s.PortID, Field: "power_w", Raw: s.PowerW, Value: s.PowerW,
+					Reason: fmt.Sprintf("does not match voltage × current (%.1f W)", expected)})
+			}
+		}
+	}
+}
+
+// normalizeReading applies a pinned scale, or tries each candidate scale when
+// the raw value is implausible, recording what it did in the report
+func normalizeReading(report *ParseReport, portID int, field string, raw, pinned float64, plausible func(float64) bool, scales []float64) float64 {
+	if pinned != 0 {
+		value := roundReading(raw * pinned)
+		if !plausible(value) {
+			report.add(ReadingIssue{PortID: portID, Field: field, Raw: raw, Value: value, Reason: "outside plausible range with registered units"})
+		}
+		return value
+	}
+
+	if plausible(raw) {
+		return raw
+	}
+
+	for _, scale := range scales {
+		if value := roundReading(raw * scale); plausible(value) {
+			report.add(ReadingIssue{PortID: portID, Field: field, Raw: raw, Value: value, Converted: true,
+				Reason: fmt.Sprintf("rescaled by %g", scale)})
+			return value
+		}
+	}
+
+	report.add(ReadingIssue{PortID: portID, Field: field, Raw: raw, Value: raw, Reason: "outside plausible range"})
+	return raw
+}
+
+// roundReading drops floating point noise introduced by rescaling
+func roundReading(value float64) float64 {
+	return math.Round(value*1000) / 1000
+}
@@ -0,0 +1,49 @@
+package netgear
+
+import "testing"
+
+func TestNormalizePOEStatusConvertsUnits(t *testing.T) {
+	statuses := []POEPortStatus{
+		{PortID: 1, VoltageV: 53.2, CurrentMA: 120, PowerW: 6.4, TemperatureC: 41},  // already sane
+		{PortID: 2, VoltageV: 532, CurrentMA: 0.12, PowerW: 64, TemperatureC: 410},  // decivolts, amps, deciwatts, tenths
+		{PortID: 3, VoltageV: 53.2, CurrentMA: 5000, PowerW: 6.4, TemperatureC: 41}, // nonsense current
+	}
+
+	report := &ParseReport{}
+	normalizePOEStatus(statuses, ModelGS308EPP, ReadingUnits{}, report)
+
+	if statuses[0].VoltageV != 53.2 || statuses[0].CurrentMA != 120 || statuses[0].PowerW != 6.4 {
+		t.Errorf("Sane readings must not change: %+v", statuses[0])
+	}
+
+	got := statuses[1]
+	if got.VoltageV != 53.2 || got.CurrentMA != 120 || got.PowerW != 6.4 || got.TemperatureC != 41 {
+		t.Errorf("Expected port 2 converted to 53.2V/120mA/6.4W/41C, got %+v", got)
+	}
+
+	implausible := report.Implausible()
+	if len(implausible) == 0 || implausible[0].PortID != 3 || implausible[0].Field != "current_ma" {
+		t.Errorf("Expected port 3 current flagged as implausible, got %v", report.Issues)
+	}
+}
+
+func TestRegisteredReadingUnitsTakePrecedence(t *testing.T) {
+	RegisterReadingUnits(ModelGS316EP, "1.0.", ReadingUnits{PowerScale: 0.1})
+	defer func() {
+		readingUnitRegistry.mu.Lock()
+		delete(readingUnitRegistry.units, ModelGS316EP)
+		readingUnitRegistry.mu.Unlock()
+	}()
+
+	units := lookupReadingUnits(ModelGS316EP, "1.0.4.4")
+	statuses := []POEPortStatus{{PortID: 1, VoltageV: 53, CurrentMA: 100, PowerW: 53}}
+	report := &ParseReport{}
+	normalizePOEStatus(statuses, ModelGS316EP, units, report)
+
+	if statuses[0].PowerW != 5.3 {
+		t.Errorf("Expected pinned deciwatt scale to give 5.3W, got %v", statuses[0].PowerW)
+	}
+	if !report.OK() {
+		t.Errorf("Expected no issues with pinned units, got %v", report.Issues)
+	}
+}
@@ -0,0 +1,93 @@
+package netgear
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/gherlein/go-netgear/pkg/netgear/internal"
+)
+
+const (
+	// restartPollInterval is how often a restarting switch is checked for an answer
+	restartPollInterval = time.Second
+	// shutdownTimeout bounds the wait for a restarting switch to stop answering
+	shutdownTimeout = 30 * time.Second
+)
+
+// Reboot restarts the switch and returns once it stopped answering, so a
+// following WaitForOnline waits for the restarted switch rather than
+// catching the old one before it went down. The session ends with the
+// reboot; log in again once the switch is back.
+func (m *SystemManager) Reboot(ctx context.Context) error {
+	if !m.client.IsAuthenticated() {
+		return ErrNotAuthenticated
+	}
+	if err := m.client.admitWrite(ctx); err != nil {
+		return err
+	}
+	if err := m.client.endpoints.ValidateEndpoint(EndpointReboot); err != nil {
+		return err
+	}
+
+	endpoint := m.client.endpoints.GetEndpoint(EndpointReboot).URL
+	securityHash, err := m.client.fetchSecurityHash(ctx, endpoint, EndpointReboot)
+	if err != nil {
+		return err
+	}
+
+	data := url.Values{}
+	data.Set("CBox", "on")
+	data.Set(m.client.hashFieldName(), securityHash)
+	return m.restart(ctx, endpoint, data, EndpointReboot)
+}
+
+// restart posts a form that makes the switch restart, ends the session and
+// waits for the switch to go down
+func (m *SystemManager) restart(ctx context.Context, endpoint string, data url.Values, endpointType EndpointType) error {
+	// The switch may go down before answering, so a network error here is expected
+	response, err := m.client.makeAuthenticatedRequestWithFallback(ctx, "POST", endpoint, data, endpointType)
+	if err != nil && !isNetworkError(err) {
+		return err
+	}
+	if errorMsg := internal.ExtractErrorMessage(response); errorMsg != "" {
+		return NewOperationError(fmt.Sprintf("%s failed: %s", endpointType, errorMsg), nil)
+	}
+
+	m.client.setToken("")
+	return m.client.waitForShutdown(ctx)
+}
+
+// WaitForOnline polls the switch until it answers HTTP requests again, e.g.
+// after Reboot or FactoryReset, failing once timeout has passed or ctx ends.
+// It does not log in.
+func (c *Client) WaitForOnline(ctx context.Context, timeout time.Duration) error {
+	deadline := c.clock.Now().Add(timeout)
+	for {
+		err := c.ping(ctx)
+		if err == nil {
+			return nil
+		}
+		if !c.clock.Now().Before(deadline) {
+			return NewNetworkError(fmt.Sprintf("switch did not come back within %v", timeout), err)
+		}
+		if sleepErr := c.clock.Sleep(ctx, restartPollInterval); sleepErr != nil {
+			return NewNetworkError("stopped waiting for the switch to come back", sleepErr)
+		}
+	}
+}
+
+// waitForShutdown polls the switch until it stops answering
+func (c *Client) waitForShutdown(ctx context.Context) error {
+	deadline := c.clock.Now().Add(shutdownTimeout)
+	for c.ping(ctx) == nil {
+		if !c.clock.Now().Before(deadline) {
+			return NewOperationError(fmt.Sprintf("switch still answering %v after the restart request", shutdownTimeout), nil)
+		}
+		if err := c.clock.Sleep(ctx, restartPollInterval); err != nil {
+			return NewNetworkError("stopped waiting for the switch to go down", err)
+		}
+	}
+	return nil
+}
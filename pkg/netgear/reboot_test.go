@@ -0,0 +1,89 @@
+package netgear
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// restartingSwitch simulates a switch that drops every connection for a
+// number of requests after a restart form was posted to it
+type restartingSwitch struct {
+	mu       sync.Mutex
+	path     string // the restart form
+	restarts int
+	downFor  int // requests left to drop
+	serve    http.HandlerFunc
+}
+
+func newRestartingSwitch(t *testing.T, path string, serve http.HandlerFunc) (*restartingSwitch, string) {
+	sw := &restartingSwitch{path: path, serve: serve}
+	server := httptest.NewServer(http.HandlerFunc(sw.handle))
+	t.Cleanup(server.Close)
+	return sw, strings.TrimPrefix(server.URL, "http://")
+}
+
+func (sw *restartingSwitch) handle(w http.ResponseWriter, r *http.Request) {
+	sw.mu.Lock()
+	down := sw.downFor > 0
+	if down {
+		sw.downFor--
+	}
+	sw.mu.Unlock()
+
+	if down {
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err == nil {
+			conn.Close()
+		}
+		return
+	}
+
+	switch {
+	case r.URL.Path == sw.path && r.Method == "GET":
+		fmt.Fprint(w, `<input type="hidden" name="hash" value="h1">`)
+	case r.URL.Path == sw.path && r.Method == "POST":
+		sw.mu.Lock()
+		sw.restarts++
+		sw.downFor = 2
+		sw.mu.Unlock()
+	case sw.serve != nil:
+		sw.serve(w, r)
+	default:
+		fmt.Fprint(w, `<html></html>`)
+	}
+}
+
+func TestRebootAndWaitForOnline(t *testing.T) {
+	sw, address := newRestartingSwitch(t, "/device_reboot.cgi", nil)
+	client, err := NewClient(address, factoryClientOptions(address)...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	ctx := context.Background()
+
+	if err := client.System().Reboot(ctx); err != nil {
+		t.Fatalf("Reboot failed: %v", err)
+	}
+	if sw.restarts != 1 {
+		t.Errorf("expected one reboot, got %d", sw.restarts)
+	}
+	if client.IsAuthenticated() {
+		t.Error("client still authenticated after the reboot")
+	}
+	if err := client.System().Reboot(ctx); err != ErrNotAuthenticated {
+		t.Errorf("expected ErrNotAuthenticated, got %v", err)
+	}
+
+	if err := client.WaitForOnline(ctx, 10*time.Second); err != nil {
+		t.Fatalf("WaitForOnline failed: %v", err)
+	}
+	if sw.downFor != 0 {
+		t.Errorf("WaitForOnline returned while the switch was down")
+	}
+}
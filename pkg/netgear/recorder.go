@@ -0,0 +1,12 @@
+package netgear
+
+// WithRecorder records every request the client makes and the switch's
+// response as a fixture in dir, with passwords, session tokens, security
+// hashes and the switch's address removed. Replay the fixtures with
+// fixture.Load or netgeartest.NewReplaySwitch to test against a model or
+// firmware revision without the hardware.
+func WithRecorder(dir string) ClientOption {
+	return func(c *Client) {
+		c.recordDir = dir
+	}
+}
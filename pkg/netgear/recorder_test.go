@@ -0,0 +1,51 @@
+package netgear
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gherlein/go-netgear/pkg/netgear/fixture"
+)
+
+func TestRecorderReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `<html><input type="hidden" name="hash" value="h-%s"><td>%s</td></html>`, r.URL.Path, r.URL.Path)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	address := strings.TrimPrefix(server.URL, "http://")
+	client, err := NewClient(address, append(factoryClientOptions(address), WithRecorder(dir))...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	recorded, err := client.makeAuthenticatedRequest(ctx, "GET", "/PoEPortConfig.cgi", nil)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+
+	// The mock switch answers with the recorded page, hash redacted
+	replay, err := fixture.Load(dir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	mock := httptest.NewServer(replay)
+	defer mock.Close()
+	replayAddress := strings.TrimPrefix(mock.URL, "http://")
+	client, err = NewClient(replayAddress, factoryClientOptions(replayAddress)...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	replayed, err := client.makeAuthenticatedRequest(ctx, "GET", "/PoEPortConfig.cgi", nil)
+	if err != nil {
+		t.Fatalf("replayed request failed: %v", err)
+	}
+	if replayed != strings.Replace(recorded, "h-/PoEPortConfig.cgi", "REDACTED", 1) {
+		t.Errorf("expected the recorded page, got %q", replayed)
+	}
+}
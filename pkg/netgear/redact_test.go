@@ -0,0 +1,86 @@
+package netgear
+
+import (
+	"encoding/json"
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gherlein/go-netgear/pkg/redact"
+)
+
+// secretFieldName matches exported field names that are likely to hold credentials
+var secretFieldName = regexp.MustCompile(`(?i)password|passwd|token|secret|gambit|cookie|hash`)
+
+// notSecretFields hold names of secrets or derived values, not secrets themselves
+var notSecretFields = map[string]string{
+	"PasswordEnv":   "names an environment variable",
+	"HashFieldName": "names the form field carrying the hash",
+}
+
+// TestSecretFieldsAreRedacted fails when an exported struct field that looks
+// like a credential is added without its serialized name being one of the
+// redaction keys, so new fields cannot leak through logs and debug dumps
+func TestSecretFieldsAreRedacted(t *testing.T) {
+	fset := token.NewFileSet()
+	packages, err := parser.ParseDir(fset, ".", func(info fs.FileInfo) bool { return !strings.HasSuffix(info.Name(), "_test.go") }, 0)
+	if err != nil {
+		t.Fatalf("failed to parse package: %v", err)
+	}
+
+	scrubber := redact.Default()
+	for _, pkg := range packages {
+		ast.Inspect(pkg, func(node ast.Node) bool {
+			structType, ok := node.(*ast.StructType)
+			if !ok {
+				return true
+			}
+			for _, field := range structType.Fields.List {
+				for _, name := range field.Names {
+					if !name.IsExported() || !secretFieldName.MatchString(name.Name) {
+						continue
+					}
+					if _, skip := notSecretFields[name.Name]; skip {
+						continue
+					}
+					serialized := name.Name
+					if field.Tag != nil {
+						tag := reflect.StructTag(strings.Trim(field.Tag.Value, "`"))
+						if jsonName, _, _ := strings.Cut(tag.Get("json"), ","); jsonName == "-" {
+							continue
+						} else if jsonName != "" {
+							serialized = jsonName
+						}
+					}
+					if !scrubber.IsSecret(serialized) {
+						t.Errorf("%s: field %s (serialized as %q) looks secret but is not covered by redact.DefaultKeys",
+							fset.Position(name.Pos()), name.Name, serialized)
+					}
+				}
+			}
+			return true
+		})
+	}
+}
+
+func TestSecretsRedactedFromDumps(t *testing.T) {
+	entry := InventoryEntry{Name: "core", Addresses: []string{"10.0.0.2"}, Password: "hunter22"}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dump := redact.String(string(data)); strings.Contains(dump, "hunter22") || !strings.Contains(dump, "10.0.0.2") {
+		t.Errorf("unexpected redacted dump %s", dump)
+	}
+
+	cause := errors.New(`Get "http://10.0.0.2/iss/specific/dashboard.html?Gambit=5fa9e2c7": connection refused`)
+	if message := NewNetworkError("GET request failed", cause).Error(); strings.Contains(message, "5fa9e2c7") {
+		t.Errorf("error leaks the Gambit token: %s", message)
+	}
+}
@@ -0,0 +1,136 @@
+package netgear
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// FactoryResetToken returns the confirmation FactoryReset requires for the
+// switch with the given serial number. Deriving it from the serial means a
+// script has to name the exact switch it intends to wipe; a token copied
+// from another switch, or a reset aimed at the wrong address, is refused.
+func FactoryResetToken(serial string) string {
+	return "RESET-" + strings.ToUpper(strings.TrimSpace(serial))
+}
+
+// FactoryResetOptions guard and extend a factory reset
+type FactoryResetOptions struct {
+	// Confirm must equal FactoryResetToken of the switch's serial number
+	Confirm string
+
+	// SnapshotFile receives the switch state as JSON before anything is
+	// reset. It is required so a wiped configuration can always be recovered.
+	SnapshotFile string
+
+	// Onboard, if set, is run once the switch is back with its factory
+	// settings. Its Address defaults to the client's address; Onboard is
+	// retried until the switch answers or ctx ends.
+	Onboard *OnboardSpec
+}
+
+// FactoryResetResult is the outcome of a successful FactoryReset
+type FactoryResetResult struct {
+	// Snapshot is the state that was written to SnapshotFile
+	Snapshot *SwitchState
+	// Onboard is the result of the post-reset onboarding, if one was requested
+	Onboard *OnboardResult
+}
+
+// FactoryReset restores the switch to its factory settings. Nothing is sent
+// to the switch unless opts.Confirm matches the switch's serial number,
+// otherwise ErrResetNotConfirmed is returned, and the switch state is
+// written to opts.SnapshotFile first. The switch drops the session and
+// reboots; FactoryReset returns once it went down, or once Onboard finished.
+// Without Onboard, use WaitForOnline and log in again with DefaultPassword at
+// whatever address the switch comes back on.
+func (m *SystemManager) FactoryReset(ctx context.Context, opts FactoryResetOptions) (*FactoryResetResult, error) {
+	if !m.client.IsAuthenticated() {
+		return nil, ErrNotAuthenticated
+	}
+	if opts.SnapshotFile == "" {
+		return nil, NewOperationError("factory reset requires a snapshot file", nil)
+	}
+	if opts.Confirm == "" {
+		return nil, ErrResetNotConfirmed
+	}
+	if err := m.client.admitWrite(ctx); err != nil {
+		return nil, err
+	}
+	if err := m.client.endpoints.ValidateEndpoint(EndpointFactoryReset); err != nil {
+		return nil, err
+	}
+
+	snapshot, err := m.client.FetchAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot switch before reset: %w", err)
+	}
+	if snapshot.System.SerialNumber == "" {
+		return nil, NewOperationError("switch did not report a serial number to confirm the reset against", nil)
+	}
+	if opts.Confirm != FactoryResetToken(snapshot.System.SerialNumber) {
+		return nil, ErrResetNotConfirmed
+	}
+
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+	if err := writeFileAtomic(opts.SnapshotFile, data, 0600); err != nil {
+		return nil, fmt.Errorf("failed to write snapshot: %w", err)
+	}
+
+	if err := m.resetToDefaults(ctx); err != nil {
+		return nil, err
+	}
+	result := &FactoryResetResult{Snapshot: snapshot}
+
+	if opts.Onboard != nil {
+		spec := *opts.Onboard
+		if spec.Address == "" && spec.DiscoverPattern == "" {
+			spec.Address = m.client.address
+		}
+		if result.Onboard, err = m.onboardAfterReset(ctx, spec); err != nil {
+			return result, fmt.Errorf("switch was reset but onboarding failed: %w", err)
+		}
+	}
+	return result, nil
+}
+
+// resetToDefaults posts the factory reset form, ends the session and waits
+// for the switch to go down
+func (m *SystemManager) resetToDefaults(ctx context.Context) error {
+	endpoint := m.client.endpoints.GetEndpoint(EndpointFactoryReset).URL
+	securityHash, err := m.client.fetchSecurityHash(ctx, endpoint, EndpointFactoryReset)
+	if err != nil {
+		return err
+	}
+
+	data := url.Values{}
+	data.Set("factory_default", "1")
+	data.Set(m.client.hashFieldName(), securityHash)
+	return m.restart(ctx, endpoint, data, EndpointFactoryReset)
+}
+
+// onboardAfterReset runs Onboard until the rebooting switch answers
+func (m *SystemManager) onboardAfterReset(ctx context.Context, spec OnboardSpec) (*OnboardResult, error) {
+	clock := m.client.Clock()
+	for {
+		result, err := Onboard(ctx, spec)
+		if err == nil || !isNetworkError(err) {
+			return result, err
+		}
+		if sleepErr := clock.Sleep(ctx, restartPollInterval); sleepErr != nil {
+			return nil, err
+		}
+	}
+}
+
+// isNetworkError reports whether err is a network failure rather than a refusal by the switch
+func isNetworkError(err error) bool {
+	var netgearErr *Error
+	return errors.As(err, &netgearErr) && netgearErr.Type == ErrorTypeNetwork
+}
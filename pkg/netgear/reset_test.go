@@ -0,0 +1,69 @@
+package netgear
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFactoryReset(t *testing.T) {
+	sw, address := newRestartingSwitch(t, "/factoryDefault.cgi", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/dashboard.cgi" {
+			fmt.Fprint(w, `<table><tr><td>Serial Number</td><td>6lx1234567</td></tr></table>`)
+			return
+		}
+		fmt.Fprint(w, `<html></html>`)
+	})
+	snapshotFile := filepath.Join(t.TempDir(), "before-reset.json")
+	ctx := context.Background()
+
+	client, err := NewClient(address, factoryClientOptions(address)...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	system := client.System()
+
+	if _, err := system.FactoryReset(ctx, FactoryResetOptions{Confirm: FactoryResetToken("6LX1234567")}); err == nil {
+		t.Error("expected an error without a snapshot file")
+	}
+	for _, confirm := range []string{"", "yes", FactoryResetToken("6LX7654321")} {
+		_, err := system.FactoryReset(ctx, FactoryResetOptions{Confirm: confirm, SnapshotFile: snapshotFile})
+		if !errors.Is(err, ErrResetNotConfirmed) {
+			t.Errorf("confirm %q: expected ErrResetNotConfirmed, got %v", confirm, err)
+		}
+	}
+	if _, err := os.Stat(snapshotFile); !os.IsNotExist(err) {
+		t.Error("snapshot written for an unconfirmed reset")
+	}
+	if sw.restarts != 0 {
+		t.Fatalf("switch reset %d times without confirmation", sw.restarts)
+	}
+
+	result, err := system.FactoryReset(ctx, FactoryResetOptions{Confirm: "RESET-6LX1234567", SnapshotFile: snapshotFile})
+	if err != nil {
+		t.Fatalf("FactoryReset failed: %v", err)
+	}
+	if sw.restarts != 1 {
+		t.Errorf("expected one reset, got %d", sw.restarts)
+	}
+	if client.IsAuthenticated() {
+		t.Error("client still authenticated after the reset")
+	}
+
+	data, err := os.ReadFile(snapshotFile)
+	if err != nil {
+		t.Fatalf("snapshot not written: %v", err)
+	}
+	var saved SwitchState
+	if err := json.Unmarshal(data, &saved); err != nil {
+		t.Fatalf("snapshot is not valid JSON: %v", err)
+	}
+	if saved.System == nil || saved.System.SerialNumber != result.Snapshot.System.SerialNumber {
+		t.Errorf("unexpected snapshot %+v", saved.System)
+	}
+}
@@ -0,0 +1,153 @@
+package netgear
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// MaxRetryBackoff caps a single wait between retries of the retry policy
+const MaxRetryBackoff = 30 * time.Second
+
+// DefaultRetryableErrors are the errors WithRetryPolicy retries when given
+// none: a switch that still holds another session and transient HTTP 5xx
+// answers. Busy switches are retried by the busy policy, see WithBusyRetry.
+var DefaultRetryableErrors = []error{ErrSessionActive, ErrServerError}
+
+// retryPolicy bounds how requests failing with a transient error are retried
+type retryPolicy struct {
+	maxAttempts int           // sends including the first; 1 or less disables retries
+	backoff     time.Duration // wait before the first retry, doubled for each further one
+	retryable   []error       // errors worth retrying, matched with errors.Is
+}
+
+// WithRetryPolicy retries logins and authenticated requests that fail with
+// one of retryableErrors, DefaultRetryableErrors if none are given, sending
+// each at most maxAttempts times. The wait before a retry starts at backoff
+// and doubles with each attempt up to MaxRetryBackoff; each wait is
+// randomized between half and all of it, so clients retrying after the same
+// failure do not hit the switch at the same moment. When an authenticated
+// request finds that another session displaced the client's one, the client
+// logs in again before retrying if it can look up the password. Requests
+// other than GET are not retried after ErrServerError or ErrNetworkTimeout,
+// as the switch may have applied the change before failing. Pass
+// ErrNetworkTimeout and ErrConnectionFailed to also retry requests the switch
+// did not answer.
+func WithRetryPolicy(maxAttempts int, backoff time.Duration, retryableErrors ...error) ClientOption {
+	if len(retryableErrors) == 0 {
+		retryableErrors = DefaultRetryableErrors
+	}
+	return func(c *Client) {
+		c.retry = retryPolicy{maxAttempts: maxAttempts, backoff: backoff, retryable: retryableErrors}
+	}
+}
+
+// wait returns how long to wait before sending a request again after the
+// given attempt failed with err, and false if the policy gives up
+func (p retryPolicy) wait(attempt int, err error) (time.Duration, bool) {
+	if err == nil || attempt >= p.maxAttempts || !p.isRetryable(err) {
+		return 0, false
+	}
+	wait := p.backoff
+	for i := 1; i < attempt && wait < MaxRetryBackoff; i++ {
+		wait *= 2
+	}
+	wait = min(wait, MaxRetryBackoff)
+	if wait <= 1 {
+		return wait, true
+	}
+	return wait/2 + rand.N(wait/2+1), true
+}
+
+// isRetryable reports whether err is one of the policy's retryable errors; a
+// cancelled context is never retried. Request timeouts cannot be told apart
+// from an expired context, so the retry loops stop once ctx is done.
+func (p retryPolicy) isRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	for _, retryable := range p.retryable {
+		if errors.Is(err, retryable) {
+			return true
+		}
+	}
+	return false
+}
+
+// retryWait returns how long to wait before sending a request with the given
+// method again after the given attempt failed with err, and false if it
+// should not be sent again. Busy answers follow the busy policy, everything
+// else the retry policy.
+func (c *Client) retryWait(method string, attempt int, err error) (time.Duration, bool) {
+	var hint retryAfter
+	if errors.Is(err, ErrSwitchBusy) && errors.As(err, &hint) {
+		return c.busyRetry.wait(attempt, time.Duration(hint))
+	}
+	// A server error or timeout leaves open whether a write was applied;
+	// sending it again could apply it twice
+	if method != http.MethodGet && (errors.Is(err, ErrServerError) || errors.Is(err, ErrNetworkTimeout)) {
+		return 0, false
+	}
+	return c.retry.wait(attempt, err)
+}
+
+// reauthenticate logs in again after another session displaced the client's
+// one, unless a client sharing the session already replaced staleToken.
+// Without a password the old token is kept, as the switch accepts it again
+// once the other session ends.
+func (c *Client) reauthenticate(ctx context.Context, staleToken string) error {
+	c.session.loginMu.Lock()
+	defer c.session.loginMu.Unlock()
+
+	if c.getToken() != staleToken {
+		return nil
+	}
+
+	password, found, err := c.lookupPassword(ctx)
+	if err != nil || !found {
+		return err
+	}
+	c.logger.Debug("session displaced by another login, logging in again", "address", c.address)
+	return c.login(ctx, password)
+}
+
+// sessionActiveMarkers are phrases of the pages firmware serves when it
+// refuses a login or request because another session holds the switch
+var sessionActiveMarkers = []string{
+	"another session is active",
+	"another user is logged in",
+	"already logged in",
+	"maximum number of sessions",
+	"too many sessions",
+}
+
+// isSessionActivePage reports whether body says another session holds the switch
+func isSessionActivePage(body string) bool {
+	body = strings.ToLower(body)
+	for _, marker := range sessionActiveMarkers {
+		if strings.Contains(body, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// newServerError returns an error matching ErrServerError for an HTTP 5xx answer
+func newServerError(status int) *Error {
+	return NewNetworkError(ErrServerError.Message, nil).WithHTTPStatus(status)
+}
+
+// loginFailure returns the transient error behind a login that yielded no
+// token, or nil if the switch refused the login otherwise
+func loginFailure(status int, body string) error {
+	if status >= 500 && status != 503 {
+		return newServerError(status)
+	}
+	if isSessionActivePage(body) {
+		return ErrSessionActive.WithHTTPStatus(status)
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+package netgear
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gherlein/go-netgear/pkg/netgear/internal"
+)
+
+func TestRetryPolicyWait(t *testing.T) {
+	policy := retryPolicy{maxAttempts: 10, backoff: time.Second, retryable: DefaultRetryableErrors}
+
+	for attempt, full := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 7: MaxRetryBackoff, 9: MaxRetryBackoff} {
+		for range 20 {
+			wait, retry := policy.wait(attempt, ErrServerError)
+			if !retry || wait < full/2 || wait > full {
+				t.Fatalf("attempt %d: expected a wait between %v and %v, got %v (retry %v)", attempt, full/2, full, wait, retry)
+			}
+		}
+	}
+
+	for _, err := range []error{nil, ErrInvalidCredentials, context.Canceled, fmt.Errorf("wrapped: %w", context.DeadlineExceeded)} {
+		if _, retry := policy.wait(1, err); retry {
+			t.Errorf("expected %v not to be retried", err)
+		}
+	}
+	if _, retry := policy.wait(10, ErrSessionActive); retry {
+		t.Error("expected the last attempt not to be retried")
+	}
+	if _, retry := (retryPolicy{}).wait(1, ErrServerError); retry {
+		t.Error("expected no retries without a retry policy")
+	}
+}
+
+func TestRetryServerError(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests <= 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprint(w, "<html>ok</html>")
+	}))
+	defer server.Close()
+	address := strings.TrimPrefix(server.URL, "http://")
+
+	clock := &sleepRecorder{}
+	client, err := NewClient(address, append(factoryClientOptions(address), WithClock(clock), WithRetryPolicy(3, time.Second))...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	body, err := client.makeAuthenticatedRequest(context.Background(), "GET", "/page", nil)
+	if err != nil || body != "<html>ok</html>" {
+		t.Fatalf("expected the page after retrying, got %q (%v)", body, err)
+	}
+	if requests != 3 || len(clock.sleeps) != 2 {
+		t.Fatalf("expected 3 requests and 2 waits, got %d and %v", requests, clock.sleeps)
+	}
+	if clock.sleeps[1] < time.Second || clock.sleeps[1] > 2*time.Second {
+		t.Errorf("expected the second wait to back off to between 1s and 2s, got %v", clock.sleeps[1])
+	}
+
+	// A write may have been applied before the error, so it is not sent again
+	requests = 0
+	clock.sleeps = nil
+	if _, err := client.makeAuthenticatedRequest(context.Background(), "POST", "/page", nil); !errors.Is(err, ErrServerError) {
+		t.Errorf("expected ErrServerError for a POST, got %v", err)
+	}
+	if requests != 1 || len(clock.sleeps) != 0 {
+		t.Errorf("expected a single POST without waits, got %d requests and %v", requests, clock.sleeps)
+	}
+
+	// Without a retry policy the first server error is returned
+	requests = 0
+	client, err = NewClient(address, append(factoryClientOptions(address), WithClock(&sleepRecorder{}))...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	_, err = client.makeAuthenticatedRequest(context.Background(), "GET", "/page", nil)
+	var netgearErr *Error
+	if !errors.Is(err, ErrServerError) || !errors.As(err, &netgearErr) || netgearErr.HTTPStatus != http.StatusInternalServerError {
+		t.Errorf("expected ErrServerError with status 500, got %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("expected a single request, got %d", requests)
+	}
+}
+
+func TestRetrySessionActive(t *testing.T) {
+	const password = "Sw1tchPass"
+	const sessionActive = `<html><body>Another session is active. Please try again later.</body></html>`
+	logins, pages := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/login.cgi":
+			fmt.Fprintf(w, `<input id="rand" value="%s">`, factorySeed)
+		case r.URL.Path == "/login.cgi":
+			logins++
+			if logins == 1 {
+				fmt.Fprint(w, sessionActive)
+				return
+			}
+			if r.PostForm.Get("password") != internal.EncryptPasswordWithSeed(password, factorySeed) {
+				fmt.Fprint(w, `<html>login</html>`)
+				return
+			}
+			w.Header().Set("Set-Cookie", "SID=fresh")
+			fmt.Fprint(w, `<html>dashboard</html>`)
+		case r.URL.Path == "/page":
+			pages++
+			if pages == 1 {
+				fmt.Fprint(w, sessionActive)
+				return
+			}
+			fmt.Fprint(w, "<html>ok</html>")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	address := strings.TrimPrefix(server.URL, "http://")
+
+	ctx := context.Background()
+	clock := &sleepRecorder{}
+	client, err := NewClient(address, append(factoryClientOptions(address), WithClock(clock), WithRetryPolicy(3, time.Second))...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	if err := client.Login(ctx, password); err != nil {
+		t.Fatalf("expected the login to succeed once the other session ended, got %v", err)
+	}
+	if logins != 2 || client.getToken() != "fresh" {
+		t.Errorf("expected 2 logins and the fresh token, got %d and %q", logins, client.getToken())
+	}
+
+	body, err := client.makeAuthenticatedRequest(ctx, "GET", "/page", nil)
+	if err != nil || body != "<html>ok</html>" {
+		t.Fatalf("expected the page after retrying, got %q (%v)", body, err)
+	}
+	if pages != 2 || len(clock.sleeps) != 2 {
+		t.Errorf("expected 2 page requests and 2 waits in total, got %d and %v", pages, clock.sleeps)
+	}
+
+	// A refused login is not retried; it is only posted again with a Referer
+	logins = 1
+	if err := client.Login(ctx, "wrong"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("expected ErrInvalidCredentials, got %v", err)
+	}
+	if logins != 3 || len(clock.sleeps) != 2 {
+		t.Errorf("expected no retries of a refused login, got %d login posts and waits %v", logins-1, clock.sleeps)
+	}
+}
+
+func TestRetryNetworkErrors(t *testing.T) {
+	var requests atomic.Int32
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		time.Sleep(100 * time.Millisecond)
+	}))
+	defer slow.Close()
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	retryable := []error{ErrNetworkTimeout, ErrConnectionFailed}
+	newClient := func(address string) (*Client, *sleepRecorder) {
+		clock := &sleepRecorder{}
+		client, err := NewClient(address, append(factoryClientOptions(address), WithClock(clock), WithTimeout(20*time.Millisecond), WithRetryPolicy(3, time.Second, retryable...))...)
+		if err != nil {
+			t.Fatalf("NewClient failed: %v", err)
+		}
+		return client, clock
+	}
+
+	// Nothing reached the switch, so writes are sent again too
+	client, clock := newClient(strings.TrimPrefix(closed.URL, "http://"))
+	if _, err := client.makeAuthenticatedRequest(context.Background(), "POST", "/page", nil); !errors.Is(err, ErrConnectionFailed) {
+		t.Errorf("expected ErrConnectionFailed, got %v", err)
+	}
+	if len(clock.sleeps) != 2 {
+		t.Errorf("expected 2 retries of a failed connection, got waits %v", clock.sleeps)
+	}
+
+	// A timed out read is sent again, a timed out write is not
+	client, clock = newClient(strings.TrimPrefix(slow.URL, "http://"))
+	if _, err := client.makeAuthenticatedRequest(context.Background(), "GET", "/page", nil); !errors.Is(err, ErrNetworkTimeout) {
+		t.Errorf("expected ErrNetworkTimeout, got %v", err)
+	}
+	if requests.Load() != 3 || len(clock.sleeps) != 2 {
+		t.Errorf("expected 3 GETs, got %d and waits %v", requests.Load(), clock.sleeps)
+	}
+	requests.Store(0)
+	if _, err := client.makeAuthenticatedRequest(context.Background(), "POST", "/page", nil); !errors.Is(err, ErrNetworkTimeout) {
+		t.Errorf("expected ErrNetworkTimeout, got %v", err)
+	}
+	if requests.Load() != 1 {
+		t.Errorf("expected a single POST, got %d", requests.Load())
+	}
+	if errors.Is(ErrConnectionFailed, ErrNetworkTimeout) || errors.Is(NewNetworkError("GET request failed", nil), ErrConnectionFailed) {
+		t.Error("expected network errors without a matching cause not to match")
+	}
+}
@@ -0,0 +1,151 @@
+package netgear
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/gherlein/go-netgear/pkg/netgear/internal"
+)
+
+// Form field names used by the DoS protection page
+const (
+	dosFieldAuto        = "auto_dos"
+	dosFieldSIPDIP      = "sip_dip"
+	dosFieldSMACDMAC    = "smac_dmac"
+	dosFieldTCPFinUrg   = "tcp_fin_urg_psh"
+	dosFieldTCPSynFin   = "tcp_syn_fin"
+	dosFieldTCPFragment = "tcp_frag"
+	dosFieldICMPFrag    = "icmp_frag"
+)
+
+// SecurityManager handles switch security settings
+type SecurityManager struct {
+	client *Client
+}
+
+// newSecurityManager creates a new security manager (internal constructor)
+func newSecurityManager(client *Client) *SecurityManager {
+	return &SecurityManager{
+		client: client,
+	}
+}
+
+// GetDoS retrieves the Auto-DoS toggle and individual DoS protection options
+func (m *SecurityManager) GetDoS(ctx context.Context) (*DoSSettings, error) {
+	if !m.client.IsAuthenticated() {
+		return nil, ErrNotAuthenticated
+	}
+
+	if err := m.client.endpoints.ValidateEndpoint(EndpointDoS); err != nil {
+		return nil, err
+	}
+
+	endpoint := m.client.endpoints.GetEndpoint(EndpointDoS).URL
+	response, err := m.client.makeAuthenticatedRequestWithFallback(ctx, "GET", endpoint, nil, EndpointDoS)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := internal.ParseFormValues(response)
+	if err != nil {
+		return nil, NewParsingError("failed to parse DoS settings", err)
+	}
+
+	if _, ok := values[dosFieldAuto]; !ok {
+		return nil, NewParsingError("DoS settings not found in response", nil)
+	}
+
+	return &DoSSettings{
+		AutoDoS:        values[dosFieldAuto] == "1",
+		SIPEqualsDIP:   values[dosFieldSIPDIP] == "1",
+		SMACEqualsDMAC: values[dosFieldSMACDMAC] == "1",
+		TCPFinUrgPsh:   values[dosFieldTCPFinUrg] == "1",
+		TCPSynFin:      values[dosFieldTCPSynFin] == "1",
+		TCPFragment:    values[dosFieldTCPFragment] == "1",
+		ICMPFragment:   values[dosFieldICMPFrag] == "1",
+	}, nil
+}
+
+// SetDoS applies changes to the DoS protection configuration.
+// Options not set in the update keep their current value on the switch.
+func (m *SecurityManager) SetDoS(ctx context.Context, update DoSUpdate) error {
+	if !m.client.IsAuthenticated() {
+		return ErrNotAuthenticated
+	}
+	if err := m.client.admitWrite(ctx); err != nil {
+		return err
+	}
+
+	if err := m.client.endpoints.ValidateEndpoint(EndpointDoS); err != nil {
+		return err
+	}
+
+	endpoint := m.client.endpoints.GetEndpoint(EndpointDoS).URL
+
+	// Read the current form so unchanged options are submitted as-is
+	response, err := m.client.makeAuthenticatedRequestWithFallback(ctx, "GET", endpoint, nil, EndpointDoS)
+	if err != nil {
+		return err
+	}
+
+	values, err := internal.ParseFormValues(response)
+	if err != nil {
+		return NewParsingError("failed to parse DoS settings", err)
+	}
+
+	data := url.Values{}
+	for name, value := range values {
+		data.Set(name, value)
+	}
+
+	if m.client.model.FormStyle() != FormStyleBitmap {
+		securityHash := m.client.extractSecurityHash(ctx, response)
+		if securityHash == "" {
+			return NewOperationError("security hash not found - cannot update DoS settings", nil)
+		}
+		data.Set(m.client.hashFieldName(), securityHash)
+	}
+
+	setFormBool(data, dosFieldAuto, update.AutoDoS)
+	setFormBool(data, dosFieldSIPDIP, update.SIPEqualsDIP)
+	setFormBool(data, dosFieldSMACDMAC, update.SMACEqualsDMAC)
+	setFormBool(data, dosFieldTCPFinUrg, update.TCPFinUrgPsh)
+	setFormBool(data, dosFieldTCPSynFin, update.TCPSynFin)
+	setFormBool(data, dosFieldTCPFragment, update.TCPFragment)
+	setFormBool(data, dosFieldICMPFrag, update.ICMPFragment)
+
+	response, err = m.client.makeAuthenticatedRequestWithFallback(ctx, "POST", endpoint, data, EndpointDoS)
+	if err != nil {
+		return err
+	}
+
+	if errorMsg := internal.ExtractErrorMessage(response); errorMsg != "" {
+		return NewOperationError("DoS settings update failed: "+errorMsg, nil)
+	}
+
+	return nil
+}
+
+// EnableAutoDoS turns on automatic DoS protection
+func (m *SecurityManager) EnableAutoDoS(ctx context.Context) error {
+	enabled := true
+	return m.SetDoS(ctx, DoSUpdate{AutoDoS: &enabled})
+}
+
+// DisableAutoDoS turns off automatic DoS protection
+func (m *SecurityManager) DisableAutoDoS(ctx context.Context) error {
+	enabled := false
+	return m.SetDoS(ctx, DoSUpdate{AutoDoS: &enabled})
+}
+
+// setFormBool sets a checkbox-style form field when the update value is provided
+func setFormBool(data url.Values, field string, value *bool) {
+	if value == nil {
+		return
+	}
+	if *value {
+		data.Set(field, "1")
+	} else {
+		data.Set(field, "0")
+	}
+}
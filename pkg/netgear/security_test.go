@@ -0,0 +1,173 @@
+package netgear
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// dosSwitch simulates the DoS protection page. Form-style switches rotate the
+// security hash on every write and answer it with a redirect to the page.
+type dosSwitch struct {
+	mu      sync.Mutex
+	path    string
+	options map[string]bool
+	hashes  int // 0 for switches without a security hash
+	posts   []url.Values
+}
+
+func newDoSSwitch(t *testing.T, path string, withHash bool) (*dosSwitch, string) {
+	sw := &dosSwitch{
+		path: path,
+		options: map[string]bool{
+			dosFieldAuto: true, dosFieldSIPDIP: false, dosFieldSMACDMAC: true, dosFieldTCPFinUrg: false,
+			dosFieldTCPSynFin: false, dosFieldTCPFragment: true, dosFieldICMPFrag: false,
+		},
+	}
+	if withHash {
+		sw.hashes = 1
+	}
+	server := httptest.NewServer(http.HandlerFunc(sw.serve))
+	t.Cleanup(server.Close)
+	return sw, strings.TrimPrefix(server.URL, "http://")
+}
+
+func (sw *dosSwitch) serve(w http.ResponseWriter, r *http.Request) {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+
+	if r.URL.Path != sw.path {
+		http.NotFound(w, r)
+		return
+	}
+	r.ParseForm()
+	if r.Method == "POST" {
+		sw.posts = append(sw.posts, r.PostForm)
+		if sw.hashes > 0 {
+			if r.PostForm.Get("hash") != sw.hash() {
+				fmt.Fprint(w, `<script>alert("invalid security hash")</script>`)
+				return
+			}
+			sw.hashes++
+		}
+		for name := range sw.options {
+			sw.options[name] = r.PostForm.Get(name) == "1"
+		}
+		w.Header().Set("Location", sw.path)
+		w.WriteHeader(http.StatusFound)
+		return
+	}
+
+	fmt.Fprint(w, `<form>`)
+	if sw.hashes > 0 {
+		fmt.Fprintf(w, `<input type="hidden" name="hash" value="%s">`, sw.hash())
+	}
+	fmt.Fprint(w, `<input type="hidden" name="dos_page" value="1">`)
+	names := make([]string, 0, len(sw.options))
+	for name := range sw.options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		checked := ""
+		if sw.options[name] {
+			checked = " checked"
+		}
+		fmt.Fprintf(w, `<input type="checkbox" name="%s" value="1"%s>`, name, checked)
+	}
+	fmt.Fprint(w, `<input type="submit" value="Apply"></form>`)
+}
+
+// hash returns the current security hash; the caller holds mu
+func (sw *dosSwitch) hash() string {
+	return fmt.Sprintf("h%d", sw.hashes)
+}
+
+func TestSetDoSMergesUnchangedFields(t *testing.T) {
+	sw, address := newDoSSwitch(t, "/dos.cgi", true)
+	client, err := NewClient(address, factoryClientOptions(address)...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	ctx := context.Background()
+
+	settings, err := client.Security().GetDoS(ctx)
+	if err != nil {
+		t.Fatalf("GetDoS failed: %v", err)
+	}
+	if want := (DoSSettings{AutoDoS: true, SMACEqualsDMAC: true, TCPFragment: true}); *settings != want {
+		t.Errorf("expected %+v, got %+v", want, *settings)
+	}
+
+	enabled, disabled := true, false
+	if err := client.Security().SetDoS(ctx, DoSUpdate{SIPEqualsDIP: &enabled, TCPFragment: &disabled}); err != nil {
+		t.Fatalf("SetDoS failed: %v", err)
+	}
+	if len(sw.posts) != 1 {
+		t.Fatalf("expected one write, got %d", len(sw.posts))
+	}
+	// Options outside the update and other form fields are posted as read
+	want := "auto_dos=1&dos_page=1&hash=h1&icmp_frag=0&sip_dip=1&smac_dmac=1&tcp_fin_urg_psh=0&tcp_frag=0&tcp_syn_fin=0"
+	if got := sw.posts[0].Encode(); got != want {
+		t.Errorf("unexpected form\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestSetDoSRefreshesHashAfterRedirect(t *testing.T) {
+	sw, address := newDoSSwitch(t, "/dos.cgi", true)
+	client, err := NewClient(address, factoryClientOptions(address)...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	ctx := context.Background()
+
+	// Each write is answered with a redirect and a new hash, which the next
+	// write must read again instead of reusing the page it saw before
+	if err := client.Security().EnableAutoDoS(ctx); err != nil {
+		t.Fatalf("EnableAutoDoS failed: %v", err)
+	}
+	if err := client.Security().DisableAutoDoS(ctx); err != nil {
+		t.Fatalf("DisableAutoDoS failed: %v", err)
+	}
+	if err := client.Security().EnableAutoDoS(ctx); err != nil {
+		t.Fatalf("EnableAutoDoS failed: %v", err)
+	}
+	var hashes []string
+	for _, post := range sw.posts {
+		hashes = append(hashes, post.Get("hash")+"/"+post.Get(dosFieldAuto))
+	}
+	if got := strings.Join(hashes, " "); got != "h1/1 h2/0 h3/1" {
+		t.Errorf("expected a fresh hash for every write, got %s", got)
+	}
+	if !sw.options[dosFieldAuto] || !sw.options[dosFieldTCPFragment] {
+		t.Errorf("unexpected options after the writes: %v", sw.options)
+	}
+}
+
+func TestSetDoSGS316(t *testing.T) {
+	sw, address := newDoSSwitch(t, "/iss/specific/dos.html", false)
+	tokenMgr := NewMemoryTokenManager()
+	tokenMgr.StoreToken(context.Background(), address, "token", ModelGS316EP)
+	client, err := NewClient(address, WithTokenManager(tokenMgr), WithPasswordManager(nil))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	if err := client.Security().DisableAutoDoS(context.Background()); err != nil {
+		t.Fatalf("DisableAutoDoS failed: %v", err)
+	}
+	if len(sw.posts) != 1 {
+		t.Fatalf("expected one write to the GS316 page, got %d", len(sw.posts))
+	}
+	// GS316 forms carry the session token instead of a security hash
+	want := "Gambit=token&auto_dos=0&dos_page=1&icmp_frag=0&sip_dip=0&smac_dmac=1&tcp_fin_urg_psh=0&tcp_frag=1&tcp_syn_fin=0"
+	if got := sw.posts[0].Encode(); got != want {
+		t.Errorf("unexpected form\n got: %s\nwant: %s", got, want)
+	}
+}
@@ -0,0 +1,123 @@
+package netgear
+
+import (
+	"sync"
+	"time"
+)
+
+// session holds the authentication token for a switch. Clients created with
+// WithSharedSession share one session per address, all other clients own a
+// private session.
+type session struct {
+	mu      sync.RWMutex
+	loginMu sync.Mutex // serializes logins of every client using the session
+	token   string
+	model   Model
+	issued  time.Time // zero when unknown
+	refs    int       // clients holding a shared session, guarded by sessionRegistry.mu
+}
+
+// sessionRegistry is the process-wide registry of shared sessions keyed by address
+var sessionRegistry = struct {
+	mu       sync.Mutex
+	sessions map[string]*session
+}{sessions: make(map[string]*session)}
+
+// acquireSession returns the shared session for an address, creating it if
+// needed; every acquire is paired with a releaseSession
+func acquireSession(address string) *session {
+	sessionRegistry.mu.Lock()
+	defer sessionRegistry.mu.Unlock()
+
+	s, exists := sessionRegistry.sessions[address]
+	if !exists {
+		s = &session{}
+		sessionRegistry.sessions[address] = s
+	}
+	s.refs++
+	return s
+}
+
+// retainSession adds a holder to a shared session already acquired by another
+// client
+func retainSession(s *session) {
+	sessionRegistry.mu.Lock()
+	defer sessionRegistry.mu.Unlock()
+	s.refs++
+}
+
+// releaseSession drops a holder of the shared session for an address; the
+// registry forgets the session once it has no holders left, so a later client
+// starts from the token manager again
+func releaseSession(address string, s *session) {
+	sessionRegistry.mu.Lock()
+	defer sessionRegistry.mu.Unlock()
+
+	s.refs--
+	if s.refs <= 0 && sessionRegistry.sessions[address] == s {
+		delete(sessionRegistry.sessions, address)
+	}
+}
+
+// get returns the current token and the model it was issued for
+func (s *session) get() (string, Model) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.token, s.model
+}
+
+// issuedAt returns when the current token was issued
+func (s *session) issuedAt() time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.issued
+}
+
+// set replaces the token, making it visible to every client sharing the session
+func (s *session) set(token string, model Model, issued time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.token = token
+	s.model = model
+	s.issued = issued
+}
+
+// WithSharedSession makes the client share its session with every other client
+// created with this option for the same address in this process.
+//
+// Netgear switches only keep one management session per switch: logging in
+// again invalidates the previous token. Sharing the session keeps the first
+// client working when a second client for the same switch is created, and a
+// login or logout through any of them applies to all of them.
+func WithSharedSession() ClientOption {
+	return func(c *Client) {
+		c.sharedSession = true
+	}
+}
+
+// Close releases the client's shared session; once every client sharing it
+// is closed, the process no longer keeps the session. Close does not log out,
+// so the token stays cached by the token manager. Closing a client without a
+// shared session does nothing.
+func (c *Client) Close() error {
+	if c.sharedSession {
+		c.closeOnce.Do(func() { releaseSession(c.address, c.session) })
+	}
+	return nil
+}
+
+// getToken returns the client's current session token
+func (c *Client) getToken() string {
+	token, _ := c.session.get()
+	return token
+}
+
+// setToken stores a session token just issued to this client (and any
+// clients sharing its session)
+func (c *Client) setToken(token string) {
+	var issued time.Time
+	if token != "" {
+		issued = c.clock.Now()
+	}
+	c.session.set(token, c.model, issued)
+}
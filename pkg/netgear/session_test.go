@@ -0,0 +1,158 @@
+package netgear
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gherlein/go-netgear/pkg/netgear/internal"
+)
+
+// registeredSession returns the shared session the registry holds for an address
+func registeredSession(address string) (*session, int) {
+	sessionRegistry.mu.Lock()
+	defer sessionRegistry.mu.Unlock()
+	s := sessionRegistry.sessions[address]
+	if s == nil {
+		return nil, 0
+	}
+	return s, s.refs
+}
+
+func TestSharedSessionRelease(t *testing.T) {
+	const address = "192.0.2.10"
+	newShared := func() *Client {
+		client, err := NewClient(address, append(factoryClientOptions(address), WithSharedSession())...)
+		if err != nil {
+			t.Fatalf("NewClient failed: %v", err)
+		}
+		return client
+	}
+
+	first, second := newShared(), newShared()
+	clone := second.Clone()
+	if s, refs := registeredSession(address); s != first.session || s != clone.session || refs != 3 {
+		t.Fatalf("expected one session held by 3 clients, got %d holders", refs)
+	}
+
+	first.Close()
+	first.Close()
+	second.Close()
+	if _, refs := registeredSession(address); refs != 1 {
+		t.Errorf("expected the clone to still hold the session, got %d holders", refs)
+	}
+	clone.Close()
+	if s, _ := registeredSession(address); s != nil {
+		t.Error("expected the session to be forgotten once every client is closed")
+	}
+
+	// A later client starts over from its token manager
+	later := newShared()
+	defer later.Close()
+	if later.session == first.session || later.getToken() != "stale" {
+		t.Errorf("expected a new session with the cached token, got %q", later.getToken())
+	}
+
+	// Private sessions are not registered
+	private, err := NewClient("192.0.2.11", factoryClientOptions("192.0.2.11")...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	private.Close()
+	if s, _ := registeredSession("192.0.2.11"); s != nil {
+		t.Error("expected a private session not to be registered")
+	}
+}
+
+func TestSharedSessionReleasedOnFailure(t *testing.T) {
+	const address = "192.0.2.12"
+	failing := PasswordProviderFunc(func(ctx context.Context, address string) (string, error) {
+		return "", fmt.Errorf("vault sealed")
+	})
+	_, err := NewClient(address, WithTokenManager(NewMemoryTokenManager()), WithPasswordProvider(failing), WithSharedSession())
+	if err == nil {
+		t.Fatal("expected NewClient to fail")
+	}
+	if s, _ := registeredSession(address); s != nil {
+		t.Error("expected a failed NewClient to release the shared session")
+	}
+}
+
+// TestSharedSessionConcurrentLogin displaces the session of several clients
+// sharing it at once; only one of them may log in again, as every further
+// login would displace the others once more
+func TestSharedSessionConcurrentLogin(t *testing.T) {
+	const password = "Sw1tchPass"
+	var mu sync.Mutex
+	current, logins := "other", 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/login.cgi":
+			fmt.Fprintf(w, `<input id="rand" value="%s">`, factorySeed)
+		case r.URL.Path == "/login.cgi":
+			if r.PostForm.Get("password") != internal.EncryptPasswordWithSeed(password, factorySeed) {
+				fmt.Fprint(w, `<html>login</html>`)
+				return
+			}
+			logins++
+			current = fmt.Sprintf("fresh-%d", logins)
+			w.Header().Set("Set-Cookie", "SID="+current)
+			fmt.Fprint(w, `<html>dashboard</html>`)
+		case r.URL.Path == "/page":
+			if cookie, err := r.Cookie("SID"); err != nil || cookie.Value != current {
+				fmt.Fprint(w, `<html><body>Another session is active. Please try again later.</body></html>`)
+				return
+			}
+			fmt.Fprint(w, "<html>ok</html>")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	address := strings.TrimPrefix(server.URL, "http://")
+
+	provider := PasswordProviderFunc(func(ctx context.Context, address string) (string, error) {
+		return password, nil
+	})
+	clients := make([]*Client, 8)
+	for i := range clients {
+		client, err := NewClient(address, append(factoryClientOptions(address),
+			WithSharedSession(), WithPasswordProvider(provider), WithClock(&sleepRecorder{}), WithRetryPolicy(3, time.Second))...)
+		if err != nil {
+			t.Fatalf("NewClient failed: %v", err)
+		}
+		defer client.Close()
+		clients[i] = client
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(clients))
+	for i, client := range clients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = client.makeAuthenticatedRequest(context.Background(), "GET", "/page", nil)
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("client %d: %v", i, err)
+		}
+	}
+	if logins != 1 {
+		t.Errorf("expected a single login for all clients, got %d", logins)
+	}
+	if token := clients[0].getToken(); token != "fresh-1" {
+		t.Errorf("expected every client to use the new token, got %q", token)
+	}
+}
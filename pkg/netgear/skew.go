@@ -0,0 +1,110 @@
+package netgear
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// SkewEstimate is the estimated offset of the switch's clock from the host clock,
+// derived from the Date header of switch responses
+type SkewEstimate struct {
+	// Offset is switch time minus host time; positive when the switch clock is ahead
+	Offset time.Duration `json:"offset"`
+	// Uncertainty bounds the error of Offset (request round trip plus the
+	// header's one-second resolution)
+	Uncertainty time.Duration `json:"uncertainty"`
+	// MeasuredAt is the host time of the measurement
+	MeasuredAt time.Time `json:"measured_at"`
+}
+
+// Known reports whether a measurement has been taken
+func (s SkewEstimate) Known() bool {
+	return !s.MeasuredAt.IsZero()
+}
+
+// ToHostTime converts a timestamp reported by the switch (e.g. a log entry) to host time
+func (s SkewEstimate) ToHostTime(switchTime time.Time) time.Time {
+	return switchTime.Add(-s.Offset)
+}
+
+// skewTracker keeps the most precise skew measurement seen recently
+type skewTracker struct {
+	mu       sync.Mutex
+	estimate SkewEstimate
+}
+
+// skewMaxAge is how long a precise measurement is preferred over a newer, less precise one
+const skewMaxAge = 10 * time.Minute
+
+// observe records the Date header of a response received between sent and received
+func (t *skewTracker) observe(resp *http.Response, sent, received time.Time) {
+	switchTime, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return
+	}
+
+	rtt := received.Sub(sent)
+	midpoint := sent.Add(rtt / 2)
+	estimate := SkewEstimate{
+		// The header truncates to the second, so its true value is up to 1s later
+		Offset:      switchTime.Add(500 * time.Millisecond).Sub(midpoint).Round(time.Millisecond),
+		Uncertainty: rtt/2 + 500*time.Millisecond,
+		MeasuredAt:  received,
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.estimate.Known() || estimate.Uncertainty <= t.estimate.Uncertainty ||
+		received.Sub(t.estimate.MeasuredAt) > skewMaxAge {
+		t.estimate = estimate
+	}
+}
+
+// get returns the current estimate
+func (t *skewTracker) get() SkewEstimate {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.estimate
+}
+
+// ClockSkew returns the current estimate of the switch clock's offset from the
+// host clock. The estimate is refreshed by every request made to the switch.
+func (c *Client) ClockSkew() SkewEstimate {
+	return c.skew.get()
+}
+
+// Drift returns the smallest clock offset consistent with the measurement:
+// the magnitude of Offset less its Uncertainty, or zero if they overlap
+func (s SkewEstimate) Drift() time.Duration {
+	drift := s.Offset
+	if drift < 0 {
+		drift = -drift
+	}
+	if drift <= s.Uncertainty {
+		return 0
+	}
+	return drift - s.Uncertainty
+}
+
+// Exceeds reports whether the switch clock is off by more than threshold even
+// allowing for the measurement uncertainty
+func (s SkewEstimate) Exceeds(threshold time.Duration) bool {
+	return s.Known() && s.Drift() > threshold
+}
+
+// CheckClockDrift refreshes the estimate of the switch clock's offset from the
+// host clock with a request and returns it. Switches with a wrong clock silently misfire POE schedules,
+// so poll this and compare the result with Exceeds, or feed it to an alert
+// rule. Firmware that sends no Date header cannot be checked.
+func (c *Client) CheckClockDrift(ctx context.Context) (SkewEstimate, error) {
+	info, err := c.System().GetInfo(ctx)
+	if err != nil {
+		return SkewEstimate{}, err
+	}
+	if info.SkewEstimate == nil {
+		return SkewEstimate{}, NewOperationError("switch did not report its time", nil)
+	}
+	return *info.SkewEstimate, nil
+}
@@ -0,0 +1,55 @@
+package netgear
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSkewEstimateDrift(t *testing.T) {
+	tests := []struct {
+		estimate SkewEstimate
+		drift    time.Duration
+		exceeds  bool
+	}{
+		{SkewEstimate{}, 0, false},
+		{SkewEstimate{Offset: 800 * time.Millisecond, Uncertainty: time.Second, MeasuredAt: time.Now()}, 0, false},
+		{SkewEstimate{Offset: -90 * time.Second, Uncertainty: time.Second, MeasuredAt: time.Now()}, 89 * time.Second, true},
+		{SkewEstimate{Offset: 30 * time.Second, Uncertainty: time.Second, MeasuredAt: time.Now()}, 29 * time.Second, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.estimate.Drift(); got != tt.drift {
+			t.Errorf("Drift() of %+v = %v, want %v", tt.estimate, got, tt.drift)
+		}
+		if got := tt.estimate.Exceeds(time.Minute); got != tt.exceeds {
+			t.Errorf("Exceeds(1m) of %+v = %v, want %v", tt.estimate, got, tt.exceeds)
+		}
+	}
+}
+
+func TestCheckClockDrift(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A switch whose clock was never set and runs two hours behind
+		w.Header().Set("Date", time.Now().Add(-2*time.Hour).UTC().Format(http.TimeFormat))
+		w.Write([]byte(`<table><tr><td>Switch Name</td><td>closet</td></tr></table>`))
+	}))
+	defer server.Close()
+
+	address := strings.TrimPrefix(server.URL, "http://")
+	client, err := NewClient(address, factoryClientOptions(address)...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	skew, err := client.CheckClockDrift(context.Background())
+	if err != nil {
+		t.Fatalf("CheckClockDrift failed: %v", err)
+	}
+	if !skew.Exceeds(time.Hour) || skew.Offset > -time.Hour {
+		t.Errorf("expected the clock to be about 2h behind, got %+v", skew)
+	}
+}
@@ -0,0 +1,91 @@
+package netgear
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestFetchAll(t *testing.T) {
+	var mu sync.Mutex
+	requests := make(map[string]int)
+	// Port 1 carries VLAN 10 untagged and VLAN 20 tagged
+	vlans := &vlanSwitch{
+		members: map[int]string{1: "31111111", 10: "13333333", 20: "23333333"},
+		pvids:   map[int]int{1: 10, 2: 1},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests[r.URL.Path]++
+		mu.Unlock()
+
+		switch r.URL.Path {
+		case "/dashboard.cgi":
+			fmt.Fprint(w, `<html>
+<table><tr><td>Switch Name</td><td>lab-switch</td></tr><tr><td>Firmware Version</td><td>V1.0.0.8</td></tr></table>
+<table><tr><th>Port</th></tr><tr><td>1</td><td>uplink</td><td>Auto</td><td></td><td></td><td>On</td><td>Connected</td><td>1000M</td></tr></table>
+</html>`)
+		case "/getPoePortStatus.cgi":
+			fmt.Fprint(w, `<ul><li class="poePortStatusListItem"><input type="hidden" class="port" value="1">
+<span class="poe-power-mode"><span>Delivering Power</span></span></li></ul>`)
+		case "/PoEPortConfig.cgi":
+			fmt.Fprint(w, `<html></html>`)
+		default:
+			vlans.serve(w, r)
+		}
+	}))
+	defer server.Close()
+
+	address := strings.TrimPrefix(server.URL, "http://")
+	client, err := NewClient(address, factoryClientOptions(address)...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	state, err := client.FetchAll(context.Background())
+	if err != nil {
+		t.Fatalf("FetchAll failed: %v", err)
+	}
+
+	if state.System == nil || state.System.DeviceName != "lab-switch" {
+		t.Errorf("unexpected system info %+v", state.System)
+	}
+	if len(state.POEStatus) != 1 || state.POEStatus[0].PortID != 1 {
+		t.Errorf("unexpected POE status %+v", state.POEStatus)
+	}
+	if len(state.Ports) != 1 || state.Ports[0].PortName != "uplink" || state.Ports[0].Status != PortStatusConnected {
+		t.Errorf("expected the uplink port from the dashboard, got %+v", state.Ports)
+	}
+	if state.FetchedAt.IsZero() || state.Address != address || state.Model != ModelGS308EPP {
+		t.Errorf("unexpected snapshot metadata %+v", state)
+	}
+
+	var ids []int
+	for _, vlan := range state.VLANs {
+		ids = append(ids, vlan.ID)
+	}
+	if fmt.Sprint(ids) != "[1 10 20]" || state.VLANs[1].Members[1] != VLANMemberUntagged {
+		t.Errorf("unexpected VLANs %+v", state.VLANs)
+	}
+	if port := state.Ports[0]; port.PVID != 10 || fmt.Sprint(port.UntaggedVLANs) != "[10]" || fmt.Sprint(port.TaggedVLANs) != "[20]" {
+		t.Errorf("expected the VLAN state of the uplink port, got %+v", port)
+	}
+
+	// The membership page is read once per VLAN, every other page once
+	for path, count := range requests {
+		want := 1
+		if path == "/8021qMembe.cgi" {
+			want = 3
+		}
+		if count != want {
+			t.Errorf("%s requested %d times, want %d", path, count, want)
+		}
+	}
+	if len(requests) != 6 {
+		t.Errorf("expected 6 pages to be requested, got %v", requests)
+	}
+}
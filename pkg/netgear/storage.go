@@ -0,0 +1,172 @@
+package netgear
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/gherlein/go-netgear/pkg/store"
+)
+
+// Namespaces a client files its data under in a store.Store. Values are JSON.
+const (
+	// StoreNamespaceQuirks holds a Quirks per switch address
+	StoreNamespaceQuirks = "quirks"
+	// StoreNamespaceMetadata holds a SwitchMetadata per switch address
+	StoreNamespaceMetadata = "metadata"
+	// StoreNamespaceAudit prefixes the per-switch namespaces ("audit/" plus
+	// the address) holding a HistoryEntry per operation, keyed so that keys
+	// sort by time
+	StoreNamespaceAudit = "audit"
+)
+
+// WithStore keeps the client's quirks, port metadata and audit trail in s
+// instead of the token manager's storage, so they can live in a database
+// shared by several hosts. Every operation the client performs is appended
+// to the audit trail; read it back with AuditLog.
+func WithStore(s store.Store) ClientOption {
+	return func(c *Client) {
+		c.store = s
+	}
+}
+
+// auditNamespace returns the namespace of the audit trail of a switch
+func auditNamespace(address string) string {
+	return StoreNamespaceAudit + "/" + address
+}
+
+// auditSeq orders audit entries that start in the same nanosecond
+var auditSeq atomic.Uint64
+
+// auditKey returns the key of an audit entry; keys sort by the entry's time
+func auditKey(entry HistoryEntry) string {
+	return fmt.Sprintf("%s-%010d", entry.Time.UTC().Format("2006-01-02T15:04:05.000000000Z"), auditSeq.Add(1))
+}
+
+// recordHistory adds an operation to the client's history and, with a
+// store, to the switch's audit trail
+func (c *Client) recordHistory(ctx context.Context, op, target string, start, end time.Time, err error) {
+	entry := newHistoryEntry(op, target, start, end, err)
+	c.history.add(entry)
+	if c.store == nil {
+		return
+	}
+
+	data, marshalErr := json.Marshal(entry)
+	if marshalErr != nil {
+		return
+	}
+	// Operations that failed because ctx ended are recorded all the same
+	if putErr := c.store.Put(context.WithoutCancel(ctx), auditNamespace(c.address), auditKey(entry), data); putErr != nil {
+		c.logger.Warn("failed to record audit entry", "address", c.address, "error", putErr)
+	}
+}
+
+// AuditLog returns every operation recorded against the switch in the
+// client's store, oldest first. Unlike History it survives restarts and
+// includes the operations of every client sharing the store.
+func (c *Client) AuditLog(ctx context.Context) ([]HistoryEntry, error) {
+	if c.store == nil {
+		return nil, NewOperationError("client has no store; see WithStore", nil)
+	}
+
+	namespace := auditNamespace(c.address)
+	keys, err := c.store.List(ctx, namespace)
+	if err != nil {
+		return nil, NewOperationError("failed to list audit entries", err)
+	}
+	entries := make([]HistoryEntry, 0, len(keys))
+	for _, key := range keys {
+		data, err := c.store.Get(ctx, namespace, key)
+		if err != nil {
+			return nil, NewOperationError("failed to read audit entry", err)
+		}
+		var entry HistoryEntry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			return nil, NewParsingError("malformed audit entry", err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// quirksStore returns where the client persists quirks, if anywhere
+func (c *Client) quirksStore() (QuirksStore, bool) {
+	if c.store != nil {
+		return storeBackend{c.store}, true
+	}
+	s, ok := c.tokenMgr.(QuirksStore)
+	return s, ok
+}
+
+// metadataStore returns where the client persists switch metadata, if anywhere
+func (c *Client) metadataStore() (MetadataStore, bool) {
+	if c.store != nil {
+		return storeBackend{c.store}, true
+	}
+	s, ok := c.tokenMgr.(MetadataStore)
+	return s, ok
+}
+
+// storeBackend implements QuirksStore and MetadataStore on a store.Store
+type storeBackend struct {
+	store store.Store
+}
+
+// GetQuirks retrieves the stored quirks for an address
+func (b storeBackend) GetQuirks(ctx context.Context, address string) (*Quirks, error) {
+	var quirks Quirks
+	if err := b.get(ctx, StoreNamespaceQuirks, address, &quirks); err != nil {
+		return nil, err
+	}
+	return &quirks, nil
+}
+
+// StoreQuirks saves the quirks for an address
+func (b storeBackend) StoreQuirks(ctx context.Context, address string, quirks *Quirks) error {
+	return b.put(ctx, StoreNamespaceQuirks, address, quirks)
+}
+
+// GetMetadata retrieves the stored metadata for an address
+func (b storeBackend) GetMetadata(ctx context.Context, address string) (*SwitchMetadata, error) {
+	var metadata SwitchMetadata
+	if err := b.get(ctx, StoreNamespaceMetadata, address, &metadata); err != nil {
+		return nil, err
+	}
+	return &metadata, nil
+}
+
+// StoreMetadata saves the metadata for an address
+func (b storeBackend) StoreMetadata(ctx context.Context, address string, metadata *SwitchMetadata) error {
+	return b.put(ctx, StoreNamespaceMetadata, address, metadata)
+}
+
+// get decodes the JSON value of key in namespace into v
+func (b storeBackend) get(ctx context.Context, namespace, key string, v any) error {
+	data, err := b.store.Get(ctx, namespace, key)
+	if errors.Is(err, store.ErrNotFound) {
+		return NewOperationError(namespace+" not found", err)
+	}
+	if err != nil {
+		return NewOperationError("failed to read "+namespace, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return NewParsingError("malformed "+namespace, err)
+	}
+	return nil
+}
+
+// put stores v as JSON under key in namespace
+func (b storeBackend) put(ctx context.Context, namespace, key string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return NewOperationError("failed to encode "+namespace, err)
+	}
+	if err := b.store.Put(ctx, namespace, key, data); err != nil {
+		return NewOperationError("failed to write "+namespace, err)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package netgear
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gherlein/go-netgear/pkg/store"
+)
+
+func newPageSwitch(t *testing.T) string {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "<html></html>")
+	}))
+	t.Cleanup(server.Close)
+	return strings.TrimPrefix(server.URL, "http://")
+}
+
+func TestWithStore(t *testing.T) {
+	address := newPageSwitch(t)
+	ctx := context.Background()
+	s := store.NewFileStore(t.TempDir())
+
+	client, err := NewClient(address, append(factoryClientOptions(address), WithStore(s))...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	if _, err := client.AuditLog(ctx); err != nil {
+		t.Fatalf("AuditLog failed: %v", err)
+	}
+
+	// Quirks and metadata go to the store, not the token manager
+	client.rememberQuirks(ctx, func(q *Quirks) { q.LoginPath = "/wmi/login" })
+	if err := client.Meta().LockPort(ctx, 3, "provisioner", time.Hour); err != nil {
+		t.Fatalf("LockPort failed: %v", err)
+	}
+	if _, err := s.Get(ctx, StoreNamespaceQuirks, address); err != nil {
+		t.Errorf("quirks not in the store: %v", err)
+	}
+	if _, err := s.Get(ctx, StoreNamespaceMetadata, address); err != nil {
+		t.Errorf("metadata not in the store: %v", err)
+	}
+
+	if _, err := client.makeAuthenticatedRequest(ctx, "GET", "/dashboard.cgi?Gambit=secret", nil); err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	client.makeAuthenticatedRequest(canceled, "GET", "/getPoePortStatus.cgi", nil)
+
+	// A new client, as after a restart, sees everything through the store
+	restarted, err := NewClient(address, append(factoryClientOptions(address), WithStore(s))...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	if quirks := restarted.GetQuirks(); quirks.LoginPath != "/wmi/login" {
+		t.Errorf("quirks not loaded from the store: %+v", quirks)
+	}
+	locks, err := restarted.Meta().PortLocks(ctx)
+	if err != nil || len(locks) != 1 || locks[0].Owner != "provisioner" {
+		t.Errorf("expected the port lock from the store, got %+v (%v)", locks, err)
+	}
+
+	entries, err := restarted.AuditLog(ctx)
+	if err != nil {
+		t.Fatalf("AuditLog failed: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 audit entries, got %+v", entries)
+	}
+	if entries[0].Target != "/dashboard.cgi?Gambit=REDACTED" || entries[0].Error != "" {
+		t.Errorf("unexpected first entry %+v", entries[0])
+	}
+	if entries[1].Target != "/getPoePortStatus.cgi" || entries[1].Error == "" {
+		t.Errorf("expected the canceled request with its error, got %+v", entries[1])
+	}
+}
+
+func TestAuditLogWithoutStore(t *testing.T) {
+	address := newPageSwitch(t)
+	client, err := NewClient(address, factoryClientOptions(address)...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	var netgearErr *Error
+	if _, err := client.AuditLog(context.Background()); !errors.As(err, &netgearErr) {
+		t.Errorf("expected an operation error, got %v", err)
+	}
+}
@@ -0,0 +1,295 @@
+package netgear
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gherlein/go-netgear/pkg/netgear/internal"
+)
+
+// loopPreventionField matches the loop prevention toggle of GS30x (loopDetection) and GS316 (loop_prevention) firmware
+var loopPreventionField = regexp.MustCompile(`^(?i)loop_?(?:detection|prevention)(?:_?(?:enable|status|mode))?$`)
+
+// stormControlField matches the per-port rate controls of the storm control page, e.g. bcastRate_3 or dlf3
+var stormControlField = regexp.MustCompile(`^(?i)(broadcast|bcast|multicast|mcast|unknownunicast|dlf)(?:_?(?:rate|storm|limit))?_?(\d+)$`)
+
+// stormTraffic names the traffic kinds of the storm control page by field prefix
+var stormTraffic = map[string]string{
+	"broadcast": "broadcast", "bcast": "broadcast",
+	"multicast": "multicast", "mcast": "multicast",
+	"unknownunicast": "unknown_unicast", "dlf": "unknown_unicast",
+}
+
+// GetLoopPrevention reports whether loop prevention is enabled on the switch
+func (m *SecurityManager) GetLoopPrevention(ctx context.Context) (bool, error) {
+	if !m.client.IsAuthenticated() {
+		return false, ErrNotAuthenticated
+	}
+	if err := m.client.endpoints.ValidateEndpoint(EndpointLoopPrevention); err != nil {
+		return false, err
+	}
+
+	endpoint := m.client.endpoints.GetEndpoint(EndpointLoopPrevention).URL
+	response, err := m.client.makeAuthenticatedRequestWithFallback(ctx, "GET", endpoint, nil, EndpointLoopPrevention)
+	if err != nil {
+		return false, err
+	}
+
+	values, field, err := parseLoopPrevention(response)
+	if err != nil {
+		return false, err
+	}
+	return isEnabledValue(values[field]), nil
+}
+
+// SetLoopPrevention enables or disables loop prevention
+func (m *SecurityManager) SetLoopPrevention(ctx context.Context, enabled bool) error {
+	if !m.client.IsAuthenticated() {
+		return ErrNotAuthenticated
+	}
+	if err := m.client.admitWrite(ctx); err != nil {
+		return err
+	}
+	if err := m.client.endpoints.ValidateEndpoint(EndpointLoopPrevention); err != nil {
+		return err
+	}
+
+	endpoint := m.client.endpoints.GetEndpoint(EndpointLoopPrevention).URL
+	page, err := m.client.makeAuthenticatedRequestWithFallback(ctx, "GET", endpoint, nil, EndpointLoopPrevention)
+	if err != nil {
+		return err
+	}
+
+	values, field, err := parseLoopPrevention(page)
+	if err != nil {
+		return err
+	}
+
+	data := url.Values{}
+	for name, value := range values {
+		data.Set(name, value)
+	}
+	setFormBool(data, field, &enabled)
+	if hash := m.client.extractSecurityHash(ctx, page); hash != "" {
+		data.Set(m.client.hashFieldName(), hash)
+	}
+
+	response, err := m.client.makeAuthenticatedRequestWithFallback(ctx, "POST", endpoint, data, EndpointLoopPrevention)
+	if err != nil {
+		return err
+	}
+	if errorMsg := internal.ExtractErrorMessage(response); errorMsg != "" {
+		return NewOperationError("loop prevention update failed: "+errorMsg, nil).WithSwitch(m.client.address, m.client.model)
+	}
+	return nil
+}
+
+// parseLoopPrevention returns the page's form values and the name of the loop prevention toggle
+func parseLoopPrevention(content string) (map[string]string, string, error) {
+	values, err := internal.ParseFormValues(content)
+	if err != nil {
+		return nil, "", NewParsingError("failed to parse loop prevention settings", err)
+	}
+	for name := range values {
+		if loopPreventionField.MatchString(name) {
+			return values, name, nil
+		}
+	}
+	return nil, "", NewParsingError("loop prevention setting not found in response", nil)
+}
+
+// stormControlForm is the storm control page: its form values, the options of
+// its select controls and the rate field of each port and traffic kind
+type stormControlForm struct {
+	values  map[string]string
+	options map[string][]internal.SelectOption
+	fields  map[int]map[string]string
+}
+
+// GetStormControl reads the broadcast, multicast and unknown unicast storm
+// control rates of every port, sorted by port
+func (m *SecurityManager) GetStormControl(ctx context.Context) ([]PortStormControl, error) {
+	if !m.client.IsAuthenticated() {
+		return nil, ErrNotAuthenticated
+	}
+	if err := m.client.endpoints.ValidateEndpoint(EndpointStormControl); err != nil {
+		return nil, err
+	}
+
+	endpoint := m.client.endpoints.GetEndpoint(EndpointStormControl).URL
+	response, err := m.client.makeAuthenticatedRequestWithFallback(ctx, "GET", endpoint, nil, EndpointStormControl)
+	if err != nil {
+		return nil, err
+	}
+
+	form, err := parseStormControl(response)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := make([]PortStormControl, 0, len(form.fields))
+	for portID, fields := range form.fields {
+		settings = append(settings, PortStormControl{
+			PortID:             portID,
+			BroadcastRate:      form.rate(fields["broadcast"]),
+			MulticastRate:      form.rate(fields["multicast"]),
+			UnknownUnicastRate: form.rate(fields["unknown_unicast"]),
+		})
+	}
+	sort.Slice(settings, func(i, j int) bool { return settings[i].PortID < settings[j].PortID })
+	return settings, nil
+}
+
+// UpdateStormControl changes the storm control rates of the given ports in a
+// single form submission, leaving all other ports and rates unchanged. Rates
+// are matched against the choices the switch offers, ignoring case and spaces.
+func (m *SecurityManager) UpdateStormControl(ctx context.Context, updates ...StormControlUpdate) error {
+	if !m.client.IsAuthenticated() {
+		return ErrNotAuthenticated
+	}
+	if err := m.client.admitWrite(ctx); err != nil {
+		return err
+	}
+	if len(updates) == 0 {
+		return NewOperationError("no updates provided", nil)
+	}
+	portIDs := make([]int, len(updates))
+	for i, update := range updates {
+		portIDs[i] = update.PortID
+	}
+	if err := m.client.checkPortLocks(ctx, portIDs...); err != nil {
+		return err
+	}
+	if err := m.client.endpoints.ValidateEndpoint(EndpointStormControl); err != nil {
+		return err
+	}
+
+	endpoint := m.client.endpoints.GetEndpoint(EndpointStormControl).URL
+	page, err := m.client.makeAuthenticatedRequestWithFallback(ctx, "GET", endpoint, nil, EndpointStormControl)
+	if err != nil {
+		return err
+	}
+
+	form, err := parseStormControl(page)
+	if err != nil {
+		return err
+	}
+
+	// Resubmit the whole form so ports not being changed keep their rates
+	data := url.Values{}
+	for name, value := range form.values {
+		data.Set(name, value)
+	}
+	for _, update := range updates {
+		fields, ok := form.fields[update.PortID]
+		if !ok {
+			return m.client.portError(update.PortID, fmt.Sprintf("port %d has no storm control setting", update.PortID), nil)
+		}
+		for traffic, rate := range map[string]*string{
+			"broadcast":       update.BroadcastRate,
+			"multicast":       update.MulticastRate,
+			"unknown_unicast": update.UnknownUnicastRate,
+		} {
+			if rate == nil {
+				continue
+			}
+			field, ok := fields[traffic]
+			if !ok {
+				return m.client.portError(update.PortID, fmt.Sprintf("port %d has no %s storm control", update.PortID, strings.ReplaceAll(traffic, "_", " ")), nil)
+			}
+			value, err := form.optionValue(field, *rate)
+			if err != nil {
+				return m.client.portError(update.PortID, fmt.Sprintf("port %d: %v", update.PortID, err), nil)
+			}
+			data.Set(field, value)
+		}
+	}
+	if hash := m.client.extractSecurityHash(ctx, page); hash != "" {
+		data.Set(m.client.hashFieldName(), hash)
+	}
+
+	response, err := m.client.makeAuthenticatedRequestWithFallback(ctx, "POST", endpoint, data, EndpointStormControl)
+	if err != nil {
+		return err
+	}
+	if errorMsg := internal.ExtractErrorMessage(response); errorMsg != "" {
+		return NewOperationError("storm control update failed: "+errorMsg, nil).WithSwitch(m.client.address, m.client.model)
+	}
+	return nil
+}
+
+// SetPortStormControl sets all three storm control rates of a single port
+func (m *SecurityManager) SetPortStormControl(ctx context.Context, portID int, broadcastRate, multicastRate, unknownUnicastRate string) error {
+	return m.UpdateStormControl(ctx, StormControlUpdate{
+		PortID:             portID,
+		BroadcastRate:      &broadcastRate,
+		MulticastRate:      &multicastRate,
+		UnknownUnicastRate: &unknownUnicastRate,
+	})
+}
+
+// parseStormControl reads the storm control form
+func parseStormControl(content string) (*stormControlForm, error) {
+	values, err := internal.ParseFormValues(content)
+	if err != nil {
+		return nil, NewParsingError("failed to parse storm control settings", err)
+	}
+	options, err := internal.ParseSelectOptions(content)
+	if err != nil {
+		return nil, NewParsingError("failed to parse storm control settings", err)
+	}
+
+	form := &stormControlForm{values: values, options: options, fields: make(map[int]map[string]string)}
+	for name := range values {
+		match := stormControlField.FindStringSubmatch(name)
+		if match == nil {
+			continue
+		}
+		portID, _ := strconv.Atoi(match[2])
+		if form.fields[portID] == nil {
+			form.fields[portID] = make(map[string]string)
+		}
+		form.fields[portID][stormTraffic[strings.ToLower(match[1])]] = name
+	}
+	if len(form.fields) == 0 {
+		return nil, NewParsingError("storm control settings not found in response", nil)
+	}
+	return form, nil
+}
+
+// rate returns the rate a field is set to, by its label when the field is a select
+func (f *stormControlForm) rate(field string) string {
+	if field == "" {
+		return ""
+	}
+	value := f.values[field]
+	for _, option := range f.options[field] {
+		if option.Value == value {
+			return option.Label
+		}
+	}
+	return value
+}
+
+// optionValue returns the form value selecting rate in a field; fields that
+// are not selects take the rate as it is
+func (f *stormControlForm) optionValue(field, rate string) (string, error) {
+	options, ok := f.options[field]
+	if !ok {
+		return rate, nil
+	}
+	normalize := func(s string) string { return strings.ToLower(strings.ReplaceAll(s, " ", "")) }
+	labels := make([]string, len(options))
+	for i, option := range options {
+		if normalize(option.Label) == normalize(rate) || option.Value == rate {
+			return option.Value, nil
+		}
+		labels[i] = option.Label
+	}
+	return "", fmt.Errorf("rate %q not offered, choose one of %s", rate, strings.Join(labels, ", "))
+}
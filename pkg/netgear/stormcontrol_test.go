@@ -0,0 +1,108 @@
+package netgear
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// stormControlSwitch serves loop prevention and storm control forms and records what is posted back
+type stormControlSwitch struct {
+	mu     sync.Mutex
+	posted map[string]url.Values
+}
+
+// rateSelect renders a storm control rate select with the given option selected
+func rateSelect(name, selected string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, `<select name="%s">`, name)
+	for value, label := range []string{"No Limit", "512 Kbps", "1 Mbps", "4 Mbps"} {
+		attr := ""
+		if fmt.Sprint(value) == selected {
+			attr = " selected"
+		}
+		fmt.Fprintf(&b, `<option value="%d"%s>%s</option>`, value, attr, label)
+	}
+	b.WriteString(`</select>`)
+	return b.String()
+}
+
+func (s *stormControlSwitch) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPost {
+		r.ParseForm()
+		s.mu.Lock()
+		s.posted[r.URL.Path] = r.PostForm
+		s.mu.Unlock()
+		return
+	}
+	switch r.URL.Path {
+	case "/loopDetection.cgi", "/iss/specific/loopPrevention.html":
+		fmt.Fprint(w, `<input type="hidden" name="hash" value="h1"><input type="checkbox" name="loopDetection" checked>`)
+	case "/stormControl.cgi", "/iss/specific/stormControl.html":
+		fmt.Fprint(w, `<input type="hidden" name="hash" value="h1">`+
+			rateSelect("bcastRate_1", "2")+rateSelect("mcastRate_1", "0")+rateSelect("dlfRate_1", "0")+
+			rateSelect("bcastRate_2", "0")+rateSelect("mcastRate_2", "0")+rateSelect("dlfRate_2", "0"))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestLoopPreventionAndStormControl(t *testing.T) {
+	for _, model := range []Model{ModelGS308EPP, ModelGS316EP} {
+		t.Run(string(model), func(t *testing.T) {
+			sw := &stormControlSwitch{posted: make(map[string]url.Values)}
+			server := httptest.NewServer(sw)
+			defer server.Close()
+
+			ctx := context.Background()
+			address := strings.TrimPrefix(server.URL, "http://")
+			tokenMgr := NewMemoryTokenManager()
+			tokenMgr.StoreToken(ctx, address, "token", model)
+			client, err := NewClient(address, WithTokenManager(tokenMgr), WithPasswordManager(nil))
+			if err != nil {
+				t.Fatalf("NewClient failed: %v", err)
+			}
+			endpoints := NewEndpointRegistry(model)
+
+			enabled, err := client.Security().GetLoopPrevention(ctx)
+			if err != nil || !enabled {
+				t.Fatalf("expected loop prevention enabled, got %v (%v)", enabled, err)
+			}
+			if err := client.Security().SetLoopPrevention(ctx, false); err != nil {
+				t.Fatalf("SetLoopPrevention failed: %v", err)
+			}
+			form := sw.posted[endpoints.GetEndpoint(EndpointLoopPrevention).URL]
+			if form.Get("loopDetection") != "0" || form.Get("hash") != "h1" {
+				t.Errorf("unexpected loop prevention form %v", form)
+			}
+
+			settings, err := client.Security().GetStormControl(ctx)
+			if err != nil {
+				t.Fatalf("GetStormControl failed: %v", err)
+			}
+			want := PortStormControl{PortID: 1, BroadcastRate: "1 Mbps", MulticastRate: StormControlNoLimit, UnknownUnicastRate: StormControlNoLimit}
+			if len(settings) != 2 || settings[0] != want {
+				t.Errorf("expected port 1 %+v, got %+v", want, settings)
+			}
+
+			rate := "4mbps"
+			if err := client.Security().UpdateStormControl(ctx, StormControlUpdate{PortID: 2, MulticastRate: &rate}); err != nil {
+				t.Fatalf("UpdateStormControl failed: %v", err)
+			}
+			form = sw.posted[endpoints.GetEndpoint(EndpointStormControl).URL]
+			if form.Get("mcastRate_2") != "3" || form.Get("bcastRate_1") != "2" || form.Get("bcastRate_2") != "0" {
+				t.Errorf("unexpected storm control form %v", form)
+			}
+
+			rate = "10 Gbps"
+			if err := client.Security().UpdateStormControl(ctx, StormControlUpdate{PortID: 1, BroadcastRate: &rate}); err == nil {
+				t.Error("expected an error for a rate the switch does not offer")
+			}
+		})
+	}
+}
@@ -0,0 +1,320 @@
+package netgear
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gherlein/go-netgear/pkg/netgear/internal"
+)
+
+// SystemManager handles switch-wide information and settings
+type SystemManager struct {
+	client *Client
+}
+
+// newSystemManager creates a new system manager (internal constructor)
+func newSystemManager(client *Client) *SystemManager {
+	return &SystemManager{
+		client: client,
+	}
+}
+
+// GetInfo retrieves the switch's identity information from its dashboard
+func (m *SystemManager) GetInfo(ctx context.Context) (*SystemInfo, error) {
+	if !m.client.IsAuthenticated() {
+		return nil, ErrNotAuthenticated
+	}
+
+	if err := m.client.endpoints.ValidateEndpoint(EndpointDashboard); err != nil {
+		return nil, err
+	}
+
+	endpoint := m.client.endpoints.GetEndpoint(EndpointDashboard).URL
+	response, err := m.client.makeAuthenticatedRequestWithFallback(ctx, "GET", endpoint, nil, EndpointDashboard)
+	if err != nil {
+		return nil, err
+	}
+	return m.infoFromDashboard(response, m.client.clock.Now())
+}
+
+// infoFromDashboard extracts the switch's identity from a dashboard page fetched at fetchedAt
+func (m *SystemManager) infoFromDashboard(response string, fetchedAt time.Time) (*SystemInfo, error) {
+	raw, err := internal.ParseSystemInfo(response)
+	if err != nil {
+		return nil, NewParsingError("failed to parse system information", err)
+	}
+
+	info := &SystemInfo{
+		Model:        m.client.model,
+		ProductName:  raw["product_name"],
+		DeviceName:   raw["device_name"],
+		SerialNumber: raw["serial_number"],
+		MACAddress:   raw["mac_address"],
+		IPAddress:    raw["ip_address"],
+		SubnetMask:   raw["subnet_mask"],
+		Gateway:      raw["gateway"],
+		Firmware:     raw["firmware"],
+		Uptime:       raw["uptime"],
+		DHCP:         isEnabledValue(raw["dhcp"]),
+	}
+	info.ManagementVLAN, _ = strconv.Atoi(raw["management_vlan"])
+	if skew := m.client.ClockSkew(); skew.Known() {
+		info.SkewEstimate = &skew
+	}
+
+	// Uptime is relative, so the boot time only depends on the host clock
+	if info.Uptime != "" {
+		if uptime, err := internal.ParseUptime(info.Uptime); err == nil {
+			bootTime := fetchedAt.Add(-uptime).Truncate(time.Second)
+			info.BootTime = &bootTime
+		} else {
+			m.client.logger.Warn("failed to parse uptime", "address", m.client.address, "error", err)
+		}
+	}
+
+	if info.Firmware != "" {
+		m.client.setFirmware(info.Firmware)
+	}
+
+	return info, nil
+}
+
+// SetDeviceName sets the switch name shown on its dashboard
+func (m *SystemManager) SetDeviceName(ctx context.Context, name string) error {
+	if len(name) > 20 {
+		return NewOperationError("device name must be at most 20 characters", nil)
+	}
+	data := url.Values{}
+	data.Set("switch_name", name)
+	return m.updateSystem(ctx, data)
+}
+
+// SetIPConfig changes the management address of the switch. The switch moves
+// to the new address as soon as it accepts the change, so the response to this
+// request may never arrive; callers should reconnect to the new address rather
+// than rely on the returned error.
+func (m *SystemManager) SetIPConfig(ctx context.Context, config IPConfig) error {
+	data := url.Values{}
+	if config.DHCP {
+		data.Set("dhcpMode", "1")
+	} else {
+		for _, addr := range []string{config.Address, config.SubnetMask, config.Gateway} {
+			if net.ParseIP(addr).To4() == nil {
+				return NewOperationError(fmt.Sprintf("invalid IPv4 address %q in static IP configuration", addr), nil)
+			}
+		}
+		data.Set("dhcpMode", "0")
+		data.Set("ip_address", config.Address)
+		data.Set("subnet_mask", config.SubnetMask)
+		data.Set("gateway_address", config.Gateway)
+	}
+	return m.updateSystem(ctx, data)
+}
+
+// SetManagementVLAN moves the switch's management interface to a VLAN. The
+// VLAN must already exist; the switch only answers on ports that are members
+// of it afterwards, so make sure the port this client connects through is
+// one before changing it.
+func (m *SystemManager) SetManagementVLAN(ctx context.Context, vlanID int) error {
+	if vlanID < MinVLANID || vlanID > MaxVLANID {
+		return NewOperationError(fmt.Sprintf("VLAN ID %d out of range (%d-%d)", vlanID, MinVLANID, MaxVLANID), nil)
+	}
+	if !m.client.IsAuthenticated() {
+		return ErrNotAuthenticated
+	}
+
+	vlans, err := m.client.VLANs().GetVLANs(ctx)
+	if err != nil {
+		return err
+	}
+	exists := false
+	for _, vlan := range vlans {
+		exists = exists || vlan.ID == vlanID
+	}
+	if !exists {
+		return NewOperationError(fmt.Sprintf("VLAN %d does not exist; create it before making it the management VLAN", vlanID), nil).WithSwitch(m.client.address, m.client.model)
+	}
+
+	data := url.Values{}
+	data.Set("mgmt_vlan", strconv.Itoa(vlanID))
+	return m.updateSystem(ctx, data)
+}
+
+// updateSystem posts changed fields back to the system settings page with its security hash
+func (m *SystemManager) updateSystem(ctx context.Context, data url.Values) error {
+	if !m.client.IsAuthenticated() {
+		return ErrNotAuthenticated
+	}
+	if err := m.client.admitWrite(ctx); err != nil {
+		return err
+	}
+	if err := m.client.endpoints.ValidateEndpoint(EndpointSystemUpdate); err != nil {
+		return err
+	}
+
+	endpoint := m.client.endpoints.GetEndpoint(EndpointSystemUpdate).URL
+	securityHash, err := m.client.fetchSecurityHash(ctx, endpoint, EndpointDashboard)
+	if err != nil {
+		return err
+	}
+	data.Set(m.client.hashFieldName(), securityHash)
+
+	response, err := m.client.makeAuthenticatedRequestWithFallback(ctx, "POST", endpoint, data, EndpointSystemUpdate)
+	if err != nil {
+		return err
+	}
+	if errorMsg := internal.ExtractErrorMessage(response); errorMsg != "" {
+		return NewOperationError(fmt.Sprintf("system update failed: %s", errorMsg), nil)
+	}
+	return nil
+}
+
+// flowControlFields are the names firmware versions use for the switch-wide flow control setting
+var flowControlFields = []string{"flowCtrl", "flowControl", "flow_control"}
+
+// GetFlowControl reads the switch-wide flow control setting on models that
+// configure flow control globally rather than per port
+func (m *SystemManager) GetFlowControl(ctx context.Context) (bool, error) {
+	if !m.client.IsAuthenticated() {
+		return false, ErrNotAuthenticated
+	}
+	if err := m.client.endpoints.ValidateEndpoint(EndpointFlowControl); err != nil {
+		return false, err
+	}
+
+	endpoint := m.client.endpoints.GetEndpoint(EndpointFlowControl).URL
+	response, err := m.client.makeAuthenticatedRequestWithFallback(ctx, "GET", endpoint, nil, EndpointFlowControl)
+	if err != nil {
+		return false, err
+	}
+
+	_, enabled, err := parseFlowControl(response)
+	return enabled, err
+}
+
+// SetFlowControl changes the switch-wide flow control setting
+func (m *SystemManager) SetFlowControl(ctx context.Context, enabled bool) error {
+	if !m.client.IsAuthenticated() {
+		return ErrNotAuthenticated
+	}
+	if err := m.client.admitWrite(ctx); err != nil {
+		return err
+	}
+	if err := m.client.endpoints.ValidateEndpoint(EndpointFlowControl); err != nil {
+		return err
+	}
+
+	endpoint := m.client.endpoints.GetEndpoint(EndpointFlowControl).URL
+	page, err := m.client.makeAuthenticatedRequestWithFallback(ctx, "GET", endpoint, nil, EndpointFlowControl)
+	if err != nil {
+		return err
+	}
+
+	field, _, err := parseFlowControl(page)
+	if err != nil {
+		return err
+	}
+
+	data := url.Values{}
+	data.Set(field, "0")
+	if enabled {
+		data.Set(field, "1")
+	}
+	if hash := m.client.extractSecurityHash(ctx, page); hash != "" {
+		data.Set(m.client.hashFieldName(), hash)
+	}
+
+	response, err := m.client.makeAuthenticatedRequestWithFallback(ctx, "POST", endpoint, data, EndpointFlowControl)
+	if err != nil {
+		return err
+	}
+	if errorMsg := internal.ExtractErrorMessage(response); errorMsg != "" {
+		return NewOperationError(fmt.Sprintf("flow control update failed: %s", errorMsg), nil)
+	}
+	return nil
+}
+
+// parseFlowControl finds the flow control field on the page and its current value
+func parseFlowControl(content string) (string, bool, error) {
+	values, err := internal.ParseFormValues(content)
+	if err != nil {
+		return "", false, NewParsingError("failed to parse flow control setting", err)
+	}
+	for _, field := range flowControlFields {
+		if value, ok := values[field]; ok {
+			return field, isEnabledValue(value), nil
+		}
+	}
+	return "", false, NewParsingError("flow control setting not found in response", nil)
+}
+
+// isEnabledValue reports whether a form value means "on"
+func isEnabledValue(value string) bool {
+	switch strings.ToLower(value) {
+	case "1", "on", "enable", "enabled", "true":
+		return true
+	}
+	return false
+}
+
+// DefaultPassword is the administrator password of a factory-default switch
+const DefaultPassword = "password"
+
+// SetInitialPassword replaces the factory default password on a switch whose
+// Login returned ErrInitialPasswordRequired, then logs in again with the new
+// password so the client is fully usable.
+func (m *SystemManager) SetInitialPassword(ctx context.Context, newPassword string) error {
+	if !m.client.IsAuthenticated() {
+		return ErrNotAuthenticated
+	}
+	if len(newPassword) < 8 || len(newPassword) > 20 {
+		return NewOperationError("new password must be 8 to 20 characters", nil)
+	}
+	if newPassword == DefaultPassword {
+		return NewOperationError("new password must differ from the factory default", nil)
+	}
+
+	if err := m.client.endpoints.ValidateEndpoint(EndpointChangePassword); err != nil {
+		return err
+	}
+	endpoint := m.client.endpoints.GetEndpoint(EndpointChangePassword).URL
+
+	page, err := m.client.makeAuthenticatedRequestWithFallback(ctx, "GET", endpoint, nil, EndpointChangePassword)
+	if err != nil {
+		return err
+	}
+
+	// Passwords are encrypted with the page's seed like the login form, when it has one
+	encode := func(password string) string { return password }
+	if seed := internal.ExtractSeedValue(page); seed != "" {
+		encode = func(password string) string { return m.client.encryptPassword(password, seed) }
+	}
+
+	data := url.Values{}
+	data.Set("oldPassword", encode(DefaultPassword))
+	data.Set("newPassword", encode(newPassword))
+	data.Set("reNewPassword", encode(newPassword))
+	if hash := m.client.extractSecurityHash(ctx, page); hash != "" {
+		data.Set(m.client.hashFieldName(), hash)
+	}
+
+	response, err := m.client.makeAuthenticatedRequestWithFallback(ctx, "POST", endpoint, data, EndpointChangePassword)
+	if err != nil {
+		return err
+	}
+	if errorMsg := internal.ExtractErrorMessage(response); errorMsg != "" {
+		return NewOperationError(fmt.Sprintf("password change failed: %s", errorMsg), nil)
+	}
+
+	// The restricted session ends with the password change
+	m.client.setToken("")
+	if err := m.client.Login(ctx, newPassword); err != nil {
+		return NewAuthError("password changed but login with the new password failed", err)
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package netgear
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gherlein/go-netgear/pkg/netgear/internal"
+)
+
+const factorySeed = "12345678"
+
+// factorySwitch simulates a factory-default GS308EPP that forces a password change
+type factorySwitch struct {
+	mu          sync.Mutex
+	newPassword string
+	changed     bool
+	name        string
+}
+
+func newFactorySwitch(t *testing.T, newPassword string) (*factorySwitch, string) {
+	sw := &factorySwitch{newPassword: newPassword}
+	server := httptest.NewServer(http.HandlerFunc(sw.serve))
+	t.Cleanup(server.Close)
+	return sw, strings.TrimPrefix(server.URL, "http://")
+}
+
+func (sw *factorySwitch) serve(w http.ResponseWriter, r *http.Request) {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+
+	r.ParseForm()
+	switch {
+	case r.Method == "GET" && (r.URL.Path == "/login.cgi" || r.URL.Path == "/changePassword.cgi" || r.URL.Path == "/dashboard.cgi"):
+		fmt.Fprintf(w, `<input id="rand" value="%s"><input type="hidden" name="hash" value="h1">`, factorySeed)
+	case r.URL.Path == "/login.cgi":
+		password := r.PostForm.Get("password")
+		switch {
+		case !sw.changed && password == internal.EncryptPasswordWithSeed(DefaultPassword, factorySeed):
+			w.Header().Set("Set-Cookie", "SID=restricted")
+			fmt.Fprint(w, `<form action="changePassword.cgi"><input name="newPassword"></form>`)
+		case sw.changed && password == internal.EncryptPasswordWithSeed(sw.newPassword, factorySeed):
+			w.Header().Set("Set-Cookie", "SID=full")
+			fmt.Fprint(w, `<html>dashboard</html>`)
+		default:
+			fmt.Fprint(w, `<html>login</html>`)
+		}
+	case r.URL.Path == "/changePassword.cgi":
+		if r.PostForm.Get("hash") != "h1" ||
+			r.PostForm.Get("oldPassword") != internal.EncryptPasswordWithSeed(DefaultPassword, factorySeed) ||
+			r.PostForm.Get("newPassword") != internal.EncryptPasswordWithSeed(sw.newPassword, factorySeed) {
+			http.Error(w, "bad form", http.StatusBadRequest)
+			return
+		}
+		sw.changed = true
+		fmt.Fprint(w, `<html>ok</html>`)
+	case r.URL.Path == "/dashboard.cgi":
+		sw.name = r.PostForm.Get("switch_name")
+		fmt.Fprint(w, `<html>ok</html>`)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// factoryClientOptions returns options that let NewClient skip model detection
+func factoryClientOptions(address string) []ClientOption {
+	tokenMgr := NewMemoryTokenManager()
+	tokenMgr.StoreToken(context.Background(), address, "stale", ModelGS308EPP)
+	return []ClientOption{WithTokenManager(tokenMgr), WithPasswordManager(nil)}
+}
+
+func TestSetInitialPassword(t *testing.T) {
+	const newPassword = "Sup3rSecret"
+	_, address := newFactorySwitch(t, newPassword)
+
+	ctx := context.Background()
+	tokenMgr := NewMemoryTokenManager()
+	tokenMgr.StoreToken(ctx, address, "stale", ModelGS308EPP)
+	client, err := NewClient(address, WithTokenManager(tokenMgr), WithPasswordManager(nil))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	tokenMgr.DeleteToken(ctx, address)
+
+	if err := client.Login(ctx, DefaultPassword); !errors.Is(err, ErrInitialPasswordRequired) {
+		t.Fatalf("expected ErrInitialPasswordRequired, got %v", err)
+	}
+	if client.getToken() != "restricted" {
+		t.Fatalf("expected restricted session to be kept, got %q", client.getToken())
+	}
+	if _, _, err := tokenMgr.GetToken(ctx, address); err == nil {
+		t.Error("restricted session should not be cached")
+	}
+
+	if err := client.System().SetInitialPassword(ctx, "short"); err == nil {
+		t.Error("expected short password to be rejected")
+	}
+	if err := client.System().SetInitialPassword(ctx, newPassword); err != nil {
+		t.Fatalf("SetInitialPassword failed: %v", err)
+	}
+	if client.getToken() != "full" {
+		t.Errorf("expected login with new password, got token %q", client.getToken())
+	}
+}
+
+func TestGlobalFlowControl(t *testing.T) {
+	var posted string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			r.ParseForm()
+			posted = r.PostForm.Get("flowCtrl") + "/" + r.PostForm.Get("hash")
+		}
+		fmt.Fprint(w, `<form><input type="radio" name="flowCtrl" value="1" checked>
+			<input type="radio" name="flowCtrl" value="0"><input type="hidden" name="hash" value="h1"></form>`)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	address := strings.TrimPrefix(server.URL, "http://")
+	client, err := NewClient(address, factoryClientOptions(address)...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	enabled, err := client.System().GetFlowControl(ctx)
+	if err != nil || !enabled {
+		t.Fatalf("expected flow control enabled, got %v (%v)", enabled, err)
+	}
+	if err := client.System().SetFlowControl(ctx, false); err != nil {
+		t.Fatalf("SetFlowControl failed: %v", err)
+	}
+	if posted != "0/h1" {
+		t.Errorf("unexpected posted form %q", posted)
+	}
+
+	err = client.Ports().SetPortFlowControl(ctx, 3, true)
+	if !errors.Is(err, ErrPerPortFlowControl) {
+		t.Errorf("expected ErrPerPortFlowControl on GS308EPP, got %v", err)
+	}
+}
+
+func TestSetManagementVLAN(t *testing.T) {
+	var posted string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch {
+		case r.URL.Path == "/8021qCf.cgi":
+			fmt.Fprint(w, `<input type="checkbox" name="vlanck" value="1"><input type="checkbox" name="vlanck" value="10">`)
+		case r.URL.Path == "/8021qMembe.cgi":
+			fmt.Fprint(w, `<input type="hidden" name="hiddenMem" value="11111111">`)
+		case r.Method == "GET" && r.URL.Path == "/dashboard.cgi":
+			fmt.Fprint(w, `<input type="hidden" name="hash" value="h1"><table>
+				<tr><td>DHCP Mode</td><td>Disable</td></tr>
+				<tr><td>Management VLAN ID</td><td>10</td></tr></table>`)
+		case r.URL.Path == "/dashboard.cgi":
+			posted = r.PostForm.Get("mgmt_vlan") + "/" + r.PostForm.Get("hash")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	address := strings.TrimPrefix(server.URL, "http://")
+	client, err := NewClient(address, factoryClientOptions(address)...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	if err := client.System().SetManagementVLAN(ctx, 4095); err == nil {
+		t.Error("expected out of range VLAN to be rejected")
+	}
+	if err := client.System().SetManagementVLAN(ctx, 20); err == nil {
+		t.Error("expected missing VLAN to be rejected")
+	}
+	if posted != "" {
+		t.Fatalf("rejected changes should not be posted, got %q", posted)
+	}
+	if err := client.System().SetManagementVLAN(ctx, 10); err != nil {
+		t.Fatalf("SetManagementVLAN failed: %v", err)
+	}
+	if posted != "10/h1" {
+		t.Errorf("unexpected posted form %q", posted)
+	}
+
+	info, err := client.System().GetInfo(ctx)
+	if err != nil {
+		t.Fatalf("GetInfo failed: %v", err)
+	}
+	if info.ManagementVLAN != 10 || info.DHCP {
+		t.Errorf("expected management VLAN 10 without DHCP, got %d/%v", info.ManagementVLAN, info.DHCP)
+	}
+}
@@ -0,0 +1,115 @@
+package netgear
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// tlsSettings collects the TLS client options; they are turned into a single
+// transport once all options are applied
+type tlsSettings struct {
+	https              bool
+	legacy             bool
+	insecureSkipVerify bool
+	rootCAs            *x509.CertPool
+	err                error // from an option that could not be applied, returned by NewClient
+}
+
+// WithTLS makes the client talk HTTPS to switches configured for HTTPS
+// management. It only changes addresses given without a scheme; an address
+// such as "https://proxy.example:8443" is used as is. Certificates are
+// verified against the system roots unless WithCACert or
+// WithInsecureSkipVerify say otherwise.
+func WithTLS() ClientOption {
+	return func(c *Client) {
+		c.tlsSettings.https = true
+	}
+}
+
+// WithInsecureSkipVerify disables certificate verification for HTTPS
+// connections, for switches with the self-signed certificate they ship with.
+// The connection is still encrypted but no longer authenticated; prefer
+// WithCACert with the switch's certificate where possible.
+func WithInsecureSkipVerify() ClientOption {
+	return func(c *Client) {
+		c.tlsSettings.insecureSkipVerify = true
+	}
+}
+
+// WithCACert verifies HTTPS connections against the PEM certificates in
+// file instead of the system roots, e.g. an internal CA or the exported
+// self-signed certificate of the switch. NewClient fails if the file cannot
+// be read or holds no certificate.
+func WithCACert(file string) ClientOption {
+	return func(c *Client) {
+		pem, err := os.ReadFile(file)
+		if err != nil {
+			c.tlsSettings.err = fmt.Errorf("failed to read CA certificate: %w", err)
+			return
+		}
+		if c.tlsSettings.rootCAs == nil {
+			c.tlsSettings.rootCAs = x509.NewCertPool()
+		}
+		if !c.tlsSettings.rootCAs.AppendCertsFromPEM(pem) {
+			c.tlsSettings.err = fmt.Errorf("no PEM certificate found in %s", file)
+		}
+	}
+}
+
+// WithLegacyTLS lets the client talk HTTPS to firmware whose embedded web
+// server only offers TLS 1.0/1.1 or cipher suites Go no longer enables by
+// default (RSA key exchange, CBC-SHA, 3DES). Certificate verification is
+// unchanged. The relaxed settings apply to this client only, so use the
+// option just for the switches that need it.
+func WithLegacyTLS() ClientOption {
+	return func(c *Client) {
+		c.tlsSettings.legacy = true
+	}
+}
+
+// config returns the TLS configuration the options ask for, or nil if the
+// defaults apply
+func (s tlsSettings) config() *tls.Config {
+	var config *tls.Config
+	if s.legacy {
+		config = legacyTLSConfig()
+	}
+	if s.insecureSkipVerify || s.rootCAs != nil {
+		if config == nil {
+			config = &tls.Config{}
+		}
+		config.InsecureSkipVerify = s.insecureSkipVerify
+		config.RootCAs = s.rootCAs
+	}
+	return config
+}
+
+// legacyTLSConfig accepts every TLS version and cipher suite Go implements
+func legacyTLSConfig() *tls.Config {
+	var suites []uint16
+	for _, suite := range tls.CipherSuites() {
+		suites = append(suites, suite.ID)
+	}
+	for _, suite := range tls.InsecureCipherSuites() {
+		suites = append(suites, suite.ID)
+	}
+	return &tls.Config{
+		MinVersion:   tls.VersionTLS10,
+		CipherSuites: suites,
+	}
+}
+
+// tlsTransport returns a copy of the default transport using config
+func tlsTransport(config *tls.Config) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = config
+	return transport
+}
+
+// legacyTLSTransport returns a copy of the default transport using legacyTLSConfig
+func legacyTLSTransport() *http.Transport {
+	return tlsTransport(legacyTLSConfig())
+}
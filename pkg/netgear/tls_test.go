@@ -0,0 +1,129 @@
+package netgear
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLegacyTLSTransport(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html>login</html>"))
+	}))
+	// Mimic embedded firmware: TLS 1.0 with an RSA key exchange CBC suite only
+	server.TLS = &tls.Config{
+		MinVersion:   tls.VersionTLS10,
+		MaxVersion:   tls.VersionTLS10,
+		CipherSuites: []uint16{tls.TLS_RSA_WITH_AES_128_CBC_SHA},
+	}
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	server.StartTLS()
+	defer server.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+
+	strict := http.DefaultTransport.(*http.Transport).Clone()
+	strict.TLSClientConfig = &tls.Config{RootCAs: roots}
+	if resp, err := (&http.Client{Transport: strict}).Get(server.URL); err == nil {
+		resp.Body.Close()
+		t.Fatal("expected the default TLS settings to reject the legacy server")
+	}
+
+	legacy := legacyTLSTransport()
+	legacy.TLSClientConfig.RootCAs = roots
+	resp, err := (&http.Client{Transport: legacy}).Get(server.URL)
+	if err != nil {
+		t.Fatalf("legacy TLS request failed: %v", err)
+	}
+	resp.Body.Close()
+}
+
+func TestWithLegacyTLS(t *testing.T) {
+	client, err := NewClient("192.0.2.1", append(factoryClientOptions("192.0.2.1"), WithLegacyTLS())...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	limit, ok := client.httpClient.Transport().(*limitTransport)
+	if !ok {
+		t.Fatalf("expected the request limit outermost, got %T", client.httpClient.Transport())
+	}
+	observe, ok := limit.next.(*observeTransport)
+	if !ok {
+		t.Fatalf("expected logging and hooks inside the limit, got %T", limit.next)
+	}
+	transport, ok := observe.next.(*http.Transport)
+	if !ok || transport.TLSClientConfig == nil || transport.TLSClientConfig.MinVersion != tls.VersionTLS10 {
+		t.Errorf("expected a legacy TLS transport, got %T", observe.next)
+	}
+	if client.Clone().httpClient.Transport() != client.httpClient.Transport() {
+		t.Error("expected clones to keep the legacy TLS transport")
+	}
+}
+
+// newHTTPSSwitch starts a switch answering only over HTTPS and returns its
+// address without a scheme and a PEM file with its certificate
+func newHTTPSSwitch(t *testing.T) (address, caFile string) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Cookie") != "SID=stale" {
+			http.Error(w, "no session", http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte("<html>dashboard</html>"))
+	}))
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	caFile = filepath.Join(t.TempDir(), "switch.pem")
+	block := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, block, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return strings.TrimPrefix(server.URL, "https://"), caFile
+}
+
+func TestWithTLS(t *testing.T) {
+	address, caFile := newHTTPSSwitch(t)
+	ctx := context.Background()
+
+	for name, opts := range map[string][]ClientOption{
+		"ca cert":       {WithTLS(), WithCACert(caFile)},
+		"skip verify":   {WithTLS(), WithInsecureSkipVerify()},
+		"https address": {WithCACert(caFile)},
+	} {
+		target := address
+		if name == "https address" {
+			target = "https://" + address
+		}
+		client, err := NewClient(target, append(factoryClientOptions(target), opts...)...)
+		if err != nil {
+			t.Fatalf("%s: NewClient failed: %v", name, err)
+		}
+		if body, err := client.makeAuthenticatedRequest(ctx, "GET", "/dashboard.cgi", nil); err != nil || body != "<html>dashboard</html>" {
+			t.Errorf("%s: got %q, %v", name, body, err)
+		}
+	}
+
+	// The switch's self-signed certificate is not trusted by default
+	client, err := NewClient(address, append(factoryClientOptions(address), WithTLS())...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	if _, err := client.makeAuthenticatedRequest(ctx, "GET", "/dashboard.cgi", nil); err == nil {
+		t.Error("expected an untrusted certificate to be rejected")
+	}
+
+	if _, err := NewClient(address, WithCACert(filepath.Join(t.TempDir(), "missing.pem"))); err == nil {
+		t.Error("expected an error for a missing CA file")
+	}
+}
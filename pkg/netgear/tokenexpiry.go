@@ -0,0 +1,178 @@
+package netgear
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// TokenInfo records when a session token was issued and how long the client
+// that obtained it trusted it
+type TokenInfo struct {
+	IssuedAt time.Time `json:"issued_at"`
+	// TTL is the token's lifetime, 0 if unknown
+	TTL time.Duration `json:"ttl"`
+}
+
+// IsExpired reports whether the token is older than its TTL at now. Tokens
+// without a TTL or issue time never expire.
+func (i TokenInfo) IsExpired(now time.Time) bool {
+	return i.TTL > 0 && !i.IssuedAt.IsZero() && now.Sub(i.IssuedAt) >= i.TTL
+}
+
+// TokenInfoStore persists the TokenInfo of stored tokens. Token managers
+// that also implement TokenInfoStore let clients refresh cached tokens that
+// are too old instead of sending requests with them (see WithTokenRefresh).
+type TokenInfoStore interface {
+	// GetTokenInfo retrieves the info of the token stored for an address
+	GetTokenInfo(ctx context.Context, address string) (*TokenInfo, error)
+
+	// StoreTokenInfo saves the info of the token stored for an address
+	StoreTokenInfo(ctx context.Context, address string, info *TokenInfo) error
+}
+
+// WithTokenRefresh makes the client log in again before a request once its
+// session token is older than maxAge, using the password provider or
+// password manager, instead of failing mid-operation when the switch drops
+// the session. Cached tokens older than maxAge are not reused by NewClient
+// when a password is available. Zero, the default, disables refreshing.
+func WithTokenRefresh(maxAge time.Duration) ClientOption {
+	return func(c *Client) {
+		c.tokenMaxAge = maxAge
+	}
+}
+
+// GetTokenInfo retrieves the info of a token stored in memory
+func (m *MemoryTokenManager) GetTokenInfo(ctx context.Context, address string) (*TokenInfo, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	data, exists := m.tokens[address]
+	if !exists || data.info.IssuedAt.IsZero() {
+		return nil, NewAuthError("token info not found", nil)
+	}
+
+	info := data.info
+	return &info, nil
+}
+
+// StoreTokenInfo saves the info of a token stored in memory
+func (m *MemoryTokenManager) StoreTokenInfo(ctx context.Context, address string, info *TokenInfo) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	data, exists := m.tokens[address]
+	if !exists {
+		return NewAuthError("token not found", nil)
+	}
+	data.info = *info
+	m.tokens[address] = data
+	return nil
+}
+
+// GetTokenInfo retrieves the info of a token from the cache directory
+func (m *FileTokenManager) GetTokenInfo(ctx context.Context, address string) (*TokenInfo, error) {
+	data, err := os.ReadFile(m.getTokenInfoFilename(address))
+	if err != nil {
+		return nil, NewAuthError("failed to read token info file", err)
+	}
+
+	var info TokenInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, NewParsingError("malformed token info file", err)
+	}
+
+	return &info, nil
+}
+
+// StoreTokenInfo saves the info of a token next to the token file. The token
+// file keeps its format so older releases can still read it.
+func (m *FileTokenManager) StoreTokenInfo(ctx context.Context, address string, info *TokenInfo) error {
+	if err := os.MkdirAll(m.cacheDir, 0700); err != nil {
+		return NewAuthError("failed to create token cache directory", err)
+	}
+
+	data, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return NewAuthError("failed to encode token info", err)
+	}
+
+	if err := writeFileAtomic(m.getTokenInfoFilename(address), data, 0600); err != nil {
+		return NewAuthError("failed to write token info file", err)
+	}
+
+	return nil
+}
+
+// getTokenInfoFilename generates the token info filename for an address
+func (m *FileTokenManager) getTokenInfoFilename(address string) string {
+	h := fnv.New32a()
+	h.Write([]byte(address))
+	return filepath.Join(m.cacheDir, fmt.Sprintf("netgear-token-%x.info.json", h.Sum32()))
+}
+
+// storeTokenInfo records the info of a freshly stored token, if the token
+// manager keeps token info
+func (c *Client) storeTokenInfo(ctx context.Context, issuedAt time.Time) {
+	store, ok := c.tokenMgr.(TokenInfoStore)
+	if !ok {
+		return
+	}
+	info := &TokenInfo{IssuedAt: issuedAt, TTL: c.tokenMaxAge}
+	if err := store.StoreTokenInfo(ctx, c.address, info); err != nil {
+		c.logger.Warn("failed to store token info", "address", c.address, "error", err)
+	}
+}
+
+// cachedTokenInfo returns the info of the token cached for the switch, or
+// an empty TokenInfo if the token manager does not know it
+func (c *Client) cachedTokenInfo(ctx context.Context) TokenInfo {
+	if store, ok := c.tokenMgr.(TokenInfoStore); ok {
+		if info, err := store.GetTokenInfo(ctx, c.address); err == nil {
+			return *info
+		}
+	}
+	return TokenInfo{}
+}
+
+// TokenInfo returns when the client's session token was issued, and the
+// refresh threshold set with WithTokenRefresh as its TTL. IssuedAt is zero
+// when the client has no token or loaded one of unknown age.
+func (c *Client) TokenInfo() TokenInfo {
+	return TokenInfo{IssuedAt: c.session.issuedAt(), TTL: c.tokenMaxAge}
+}
+
+// tokenExpired reports whether the client's token is due for a refresh
+func (c *Client) tokenExpired() bool {
+	return c.IsAuthenticated() && c.TokenInfo().IsExpired(c.clock.Now())
+}
+
+// refreshExpiredToken logs in again if the session token is older than the
+// refresh threshold. Without a stored password the old token is kept, as the
+// switch may still accept it.
+func (c *Client) refreshExpiredToken(ctx context.Context) error {
+	if !c.tokenExpired() {
+		return nil
+	}
+
+	c.session.loginMu.Lock()
+	defer c.session.loginMu.Unlock()
+
+	// Another request may have refreshed the token while this one waited
+	if !c.tokenExpired() {
+		return nil
+	}
+	password, found, err := c.lookupPassword(ctx)
+	if err != nil || !found {
+		return err
+	}
+	c.logger.Debug("session token expired, logging in again", "address", c.address, "max_age", c.tokenMaxAge)
+	if err := c.login(ctx, password); err != nil {
+		return NewAuthError("failed to refresh expired session", err)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package netgear
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gherlein/go-netgear/pkg/netgear/internal/fakeclock"
+)
+
+func TestTokenInfoIsExpired(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		info TokenInfo
+		want bool
+	}{
+		{TokenInfo{IssuedAt: now.Add(-time.Hour), TTL: 30 * time.Minute}, true},
+		{TokenInfo{IssuedAt: now.Add(-30 * time.Minute), TTL: 30 * time.Minute}, true},
+		{TokenInfo{IssuedAt: now.Add(-time.Minute), TTL: 30 * time.Minute}, false},
+		{TokenInfo{IssuedAt: now.Add(-time.Hour)}, false}, // no TTL
+		{TokenInfo{TTL: time.Minute}, false},              // unknown age
+	}
+	for _, tt := range tests {
+		if got := tt.info.IsExpired(now); got != tt.want {
+			t.Errorf("%+v.IsExpired = %t, want %t", tt.info, got, tt.want)
+		}
+	}
+}
+
+func TestFileTokenManagerTokenInfo(t *testing.T) {
+	ctx := context.Background()
+	mgr := NewFileTokenManager(t.TempDir())
+	if _, err := mgr.GetTokenInfo(ctx, "10.0.0.1"); err == nil {
+		t.Error("expected an error for a token without info")
+	}
+
+	mgr.StoreToken(ctx, "10.0.0.1", "token", ModelGS308EPP)
+	issued := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	if err := mgr.StoreTokenInfo(ctx, "10.0.0.1", &TokenInfo{IssuedAt: issued, TTL: time.Hour}); err != nil {
+		t.Fatalf("StoreTokenInfo failed: %v", err)
+	}
+	info, err := mgr.GetTokenInfo(ctx, "10.0.0.1")
+	if err != nil || !info.IssuedAt.Equal(issued) || info.TTL != time.Hour {
+		t.Errorf("unexpected token info %+v (%v)", info, err)
+	}
+
+	// The token file keeps its format
+	if token, model, err := mgr.GetToken(ctx, "10.0.0.1"); err != nil || token != "token" || model != ModelGS308EPP {
+		t.Errorf("unexpected token %q, %q (%v)", token, model, err)
+	}
+
+	mgr.DeleteToken(ctx, "10.0.0.1")
+	if _, err := mgr.GetTokenInfo(ctx, "10.0.0.1"); err == nil {
+		t.Error("expected the token info to be deleted with the token")
+	}
+}
+
+func TestTokenRefreshBeforeRequest(t *testing.T) {
+	const password = "Sup3rSecret"
+	sw, address := newFactorySwitch(t, password)
+	sw.changed = true
+
+	clock := fakeclock.New(time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC))
+	tokenMgr := NewMemoryTokenManager()
+	tokenMgr.StoreQuirks(context.Background(), address, &Quirks{Model: ModelGS308EPP})
+	provider := PasswordProviderFunc(func(ctx context.Context, address string) (string, error) { return password, nil })
+	client, err := NewClient(address, WithTokenManager(tokenMgr), WithPasswordManager(nil),
+		WithPasswordProvider(provider), WithClock(clock), WithTokenRefresh(10*time.Minute))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	ctx := context.Background()
+
+	first := client.TokenInfo().IssuedAt
+	if !client.IsAuthenticated() || !first.Equal(clock.Now()) {
+		t.Fatalf("expected a fresh login, got %+v", client.TokenInfo())
+	}
+	if info, err := tokenMgr.GetTokenInfo(ctx, address); err != nil || !info.IssuedAt.Equal(first) || info.TTL != 10*time.Minute {
+		t.Errorf("expected the token info to be stored, got %+v (%v)", info, err)
+	}
+
+	// A young token is used as is
+	clock.Advance(5 * time.Minute)
+	if _, err := client.makeAuthenticatedRequest(ctx, "GET", "/dashboard.cgi", nil); err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if !client.TokenInfo().IssuedAt.Equal(first) {
+		t.Error("expected no refresh before the threshold")
+	}
+
+	// An old one is replaced before the request is sent
+	clock.Advance(6 * time.Minute)
+	if _, err := client.makeAuthenticatedRequest(ctx, "GET", "/dashboard.cgi", nil); err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if !client.TokenInfo().IssuedAt.Equal(clock.Now()) || client.getToken() != "full" {
+		t.Errorf("expected a refreshed token, got %+v", client.TokenInfo())
+	}
+}
+
+func TestNewClientSkipsExpiredCachedToken(t *testing.T) {
+	const password = "Sup3rSecret"
+	sw, address := newFactorySwitch(t, password)
+	sw.changed = true
+
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	newTokenMgr := func() *MemoryTokenManager {
+		tokenMgr := NewMemoryTokenManager()
+		tokenMgr.StoreToken(ctx, address, "old", ModelGS308EPP)
+		tokenMgr.StoreTokenInfo(ctx, address, &TokenInfo{IssuedAt: now.Add(-time.Hour)})
+		tokenMgr.StoreQuirks(ctx, address, &Quirks{Model: ModelGS308EPP})
+		return tokenMgr
+	}
+	opts := []ClientOption{WithPasswordManager(nil), WithClock(fakeclock.New(now)), WithTokenRefresh(10 * time.Minute)}
+
+	provider := PasswordProviderFunc(func(ctx context.Context, address string) (string, error) { return password, nil })
+	client, err := NewClient(address, append(opts, WithTokenManager(newTokenMgr()), WithPasswordProvider(provider))...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	if client.getToken() != "full" {
+		t.Errorf("expected a new login instead of the expired token, got %q", client.getToken())
+	}
+
+	// Without a password the expired token is still tried
+	client, err = NewClient(address, append(opts, WithTokenManager(newTokenMgr()))...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	if client.getToken() != "old" || !client.TokenInfo().IssuedAt.Equal(now.Add(-time.Hour)) {
+		t.Errorf("expected the cached token, got %q issued %v", client.getToken(), client.TokenInfo().IssuedAt)
+	}
+}
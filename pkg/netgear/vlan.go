@@ -0,0 +1,436 @@
+package netgear
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gherlein/go-netgear/pkg/netgear/internal"
+)
+
+// VLANManager handles 802.1Q VLAN operations
+type VLANManager struct {
+	client *Client
+}
+
+// newVLANManager creates a new VLAN manager (internal constructor)
+func newVLANManager(client *Client) *VLANManager {
+	return &VLANManager{
+		client: client,
+	}
+}
+
+// MinVLANID and MaxVLANID bound the 802.1Q VLAN IDs the switches accept
+const (
+	MinVLANID = 1
+	MaxVLANID = 4093
+)
+
+// DefaultVLANID is the VLAN every port belongs to out of the box; it cannot be deleted
+const DefaultVLANID = 1
+
+// GetVLANs returns the configured 802.1Q VLANs with their port membership,
+// sorted by VLAN ID
+func (m *VLANManager) GetVLANs(ctx context.Context) ([]VLAN, error) {
+	if !m.client.IsAuthenticated() {
+		return nil, ErrNotAuthenticated
+	}
+
+	vlans, _, err := m.getVLANs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(vlans, func(i, j int) bool { return vlans[i].ID < vlans[j].ID })
+	return vlans, nil
+}
+
+// CreateVLAN adds an 802.1Q VLAN without members. Ports join it through
+// SetPortVLANMembership, MakeAccessPort or MakeTrunkPort.
+func (m *VLANManager) CreateVLAN(ctx context.Context, vlanID int) error {
+	return m.changeVLAN(ctx, vlanID, true)
+}
+
+// DeleteVLAN removes an 802.1Q VLAN. The default VLAN cannot be deleted, and
+// firmware refuses to delete a VLAN that is still the PVID of a port.
+func (m *VLANManager) DeleteVLAN(ctx context.Context, vlanID int) error {
+	if vlanID == DefaultVLANID {
+		return NewOperationError("the default VLAN cannot be deleted", nil)
+	}
+	return m.changeVLAN(ctx, vlanID, false)
+}
+
+// changeVLAN creates or deletes a VLAN through the VLAN configuration form
+func (m *VLANManager) changeVLAN(ctx context.Context, vlanID int, create bool) error {
+	if !m.client.IsAuthenticated() {
+		return ErrNotAuthenticated
+	}
+	if vlanID < MinVLANID || vlanID > MaxVLANID {
+		return NewOperationError(fmt.Sprintf("VLAN ID %d out of range (%d-%d)", vlanID, MinVLANID, MaxVLANID), nil)
+	}
+	if err := m.client.admitWrite(ctx); err != nil {
+		return err
+	}
+
+	vlans, _, err := m.getVLANs(ctx)
+	if err != nil {
+		return err
+	}
+	exists := false
+	for _, vlan := range vlans {
+		exists = exists || vlan.ID == vlanID
+	}
+	switch {
+	case create && exists:
+		return NewOperationError(fmt.Sprintf("VLAN %d already exists", vlanID), nil)
+	case !create && !exists:
+		return NewOperationError(fmt.Sprintf("VLAN %d does not exist", vlanID), nil)
+	}
+
+	endpoint := m.client.endpoints.GetEndpoint(EndpointVLANConfig).URL
+	data := url.Values{}
+	if m.client.model.FormStyle() == FormStyleBitmap {
+		data.Set("vlanId", strconv.Itoa(vlanID))
+		if create {
+			data.Set("action", "add")
+		} else {
+			data.Set("action", "delete")
+		}
+	} else {
+		hash, err := m.client.fetchSecurityHash(ctx, endpoint, EndpointVLANConfig)
+		if err != nil {
+			return err
+		}
+		data.Set(m.client.hashFieldName(), hash)
+		if create {
+			data.Set("ACTION", "Add")
+			data.Set("ADD_VLANID", strconv.Itoa(vlanID))
+		} else {
+			data.Set("ACTION", "Delete")
+			data.Set("vlanck", strconv.Itoa(vlanID))
+		}
+	}
+
+	response, err := m.client.makeAuthenticatedRequestWithFallback(ctx, "POST", endpoint, data, EndpointVLANConfig)
+	if err != nil {
+		return err
+	}
+
+	if errorMsg := internal.ExtractErrorMessage(response); errorMsg != "" {
+		action := "deleting"
+		if create {
+			action = "creating"
+		}
+		return NewOperationError(fmt.Sprintf("%s VLAN %d failed: %s", action, vlanID, errorMsg), nil)
+	}
+
+	return nil
+}
+
+// SetPortVLANMembership makes a port an untagged member of the untagged VLANs,
+// a tagged member of the tagged VLANs and sets its PVID. The PVID must be one
+// of those VLANs; the port is removed from every VLAN not listed.
+func (m *VLANManager) SetPortVLANMembership(ctx context.Context, portID int, pvid int, untagged []int, tagged []int) error {
+	desired := make(map[int]VLANMembership)
+	for _, vlanID := range untagged {
+		desired[vlanID] = VLANMemberUntagged
+	}
+	for _, vlanID := range tagged {
+		if desired[vlanID] == VLANMemberUntagged {
+			return NewOperationError(fmt.Sprintf("VLAN %d cannot be both untagged and tagged on port %d", vlanID, portID), nil)
+		}
+		desired[vlanID] = VLANMemberTagged
+	}
+	if _, member := desired[pvid]; !member {
+		return NewOperationError(fmt.Sprintf("PVID %d is not a VLAN of port %d", pvid, portID), nil)
+	}
+	return m.configurePort(ctx, portID, pvid, desired)
+}
+
+// MakeAccessPort configures a port as an untagged member of a single VLAN,
+// sets its PVID to that VLAN, and removes it from every other VLAN.
+func (m *VLANManager) MakeAccessPort(ctx context.Context, portID int, vlanID int) error {
+	return m.configurePort(ctx, portID, vlanID, map[int]VLANMembership{vlanID: VLANMemberUntagged})
+}
+
+// MakeTrunkPort configures a port as an untagged member of the native VLAN
+// (which also becomes its PVID) and a tagged member of the given VLANs.
+// The port is removed from every VLAN not listed.
+func (m *VLANManager) MakeTrunkPort(ctx context.Context, portID int, nativeVLAN int, taggedVLANs ...int) error {
+	desired := map[int]VLANMembership{nativeVLAN: VLANMemberUntagged}
+	for _, vlanID := range taggedVLANs {
+		if vlanID == nativeVLAN {
+			return NewOperationError(fmt.Sprintf("VLAN %d cannot be both native and tagged on port %d", vlanID, portID), nil)
+		}
+		desired[vlanID] = VLANMemberTagged
+	}
+	return m.configurePort(ctx, portID, nativeVLAN, desired)
+}
+
+// configurePort applies the desired VLAN membership of a port through
+// membership and PVID writes
+func (m *VLANManager) configurePort(ctx context.Context, portID int, pvid int, desired map[int]VLANMembership) error {
+	if !m.client.IsAuthenticated() {
+		return ErrNotAuthenticated
+	}
+	for _, vlanID := range sortedVLANIDs(desired) {
+		if vlanID < MinVLANID || vlanID > MaxVLANID {
+			return NewOperationError(fmt.Sprintf("VLAN ID %d out of range (%d-%d)", vlanID, MinVLANID, MaxVLANID), nil)
+		}
+	}
+	if err := m.client.admitWrite(ctx); err != nil {
+		return err
+	}
+	if err := m.client.checkPortLocks(ctx, portID); err != nil {
+		return err
+	}
+
+	vlans, portCount, err := m.getVLANs(ctx)
+	if err != nil {
+		return err
+	}
+
+	if portID < 1 || portID > portCount {
+		return m.client.portError(portID, fmt.Sprintf("port %d out of range (switch has %d ports)", portID, portCount), nil)
+	}
+
+	existing := make(map[int]VLAN)
+	for _, vlan := range vlans {
+		existing[vlan.ID] = vlan
+	}
+	for vlanID := range desired {
+		if _, ok := existing[vlanID]; !ok {
+			return NewOperationError(fmt.Sprintf("VLAN %d does not exist", vlanID), nil)
+		}
+	}
+
+	// Add the port to its VLANs before changing the PVID, and only remove it
+	// from other VLANs afterwards: firmware rejects a PVID the port is not a member of.
+	for _, vlanID := range sortedVLANIDs(desired) {
+		vlan := existing[vlanID]
+		if vlan.Members[portID] == desired[vlanID] {
+			continue
+		}
+		members := copyMembers(vlan.Members)
+		members[portID] = desired[vlanID]
+		if err := m.setMembership(ctx, vlanID, members, portCount); err != nil {
+			return err
+		}
+	}
+
+	// Skip the PVID write when it is unchanged, so a repeated call writes nothing
+	pvids, err := m.getPVIDs(ctx)
+	if err != nil {
+		return err
+	}
+	if pvids[portID] != pvid {
+		if err := m.setPVID(ctx, portID, pvid); err != nil {
+			return err
+		}
+	}
+
+	for _, vlan := range vlans {
+		if _, keep := desired[vlan.ID]; keep {
+			continue
+		}
+		if membership, ok := vlan.Members[portID]; !ok || membership == VLANMemberNone {
+			continue
+		}
+		members := copyMembers(vlan.Members)
+		delete(members, portID)
+		if err := m.setMembership(ctx, vlan.ID, members, portCount); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getVLANs reads all configured VLANs with their port membership
+func (m *VLANManager) getVLANs(ctx context.Context) ([]VLAN, int, error) {
+	if err := m.client.endpoints.ValidateEndpoint(EndpointVLANConfig); err != nil {
+		return nil, 0, err
+	}
+
+	endpoint := m.client.endpoints.GetEndpoint(EndpointVLANConfig).URL
+	response, err := m.client.makeAuthenticatedRequestWithFallback(ctx, "GET", endpoint, nil, EndpointVLANConfig)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ids, err := internal.ParseVLANIDs(response)
+	if err != nil {
+		return nil, 0, NewParsingError("failed to parse VLAN configuration", err)
+	}
+
+	var vlans []VLAN
+	portCount := 0
+	membershipEndpoint := m.client.endpoints.GetEndpoint(EndpointVLANMembership).URL
+	for _, id := range ids {
+		query := url.Values{}
+		query.Set(m.vlanIDField(), strconv.Itoa(id))
+
+		response, err := m.client.makeAuthenticatedRequestWithFallback(ctx, "GET", membershipEndpoint, query, EndpointVLANMembership)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		rawMembers, ports, err := internal.ParseVLANMembers(response)
+		if err != nil {
+			return nil, 0, NewParsingError(fmt.Sprintf("failed to parse membership of VLAN %d", id), err)
+		}
+		if ports > portCount {
+			portCount = ports
+		}
+
+		members := make(map[int]VLANMembership)
+		for portID, membership := range rawMembers {
+			members[portID] = VLANMembership(membership)
+		}
+		vlans = append(vlans, VLAN{ID: id, Members: members})
+	}
+
+	return vlans, portCount, nil
+}
+
+// getPVIDs reads the port VLAN ID of every port
+func (m *VLANManager) getPVIDs(ctx context.Context) (map[int]int, error) {
+	if err := m.client.endpoints.ValidateEndpoint(EndpointVLANPVID); err != nil {
+		return nil, err
+	}
+
+	endpoint := m.client.endpoints.GetEndpoint(EndpointVLANPVID).URL
+	response, err := m.client.makeAuthenticatedRequestWithFallback(ctx, "GET", endpoint, nil, EndpointVLANPVID)
+	if err != nil {
+		return nil, err
+	}
+
+	pvids, err := internal.ParsePortPVIDs(response)
+	if err != nil {
+		return nil, NewParsingError("failed to parse port PVIDs", err)
+	}
+
+	return pvids, nil
+}
+
+// setMembership writes the complete port membership of a VLAN
+func (m *VLANManager) setMembership(ctx context.Context, vlanID int, members map[int]VLANMembership, portCount int) error {
+	endpoint := m.client.endpoints.GetEndpoint(EndpointVLANMembership).URL
+
+	data := url.Values{}
+	data.Set(m.vlanIDField(), strconv.Itoa(vlanID))
+
+	if m.client.model.FormStyle() == FormStyleBitmap {
+		var untaggedPorts, taggedPorts []int
+		for portID, membership := range members {
+			switch membership {
+			case VLANMemberUntagged:
+				untaggedPorts = append(untaggedPorts, portID)
+			case VLANMemberTagged:
+				taggedPorts = append(taggedPorts, portID)
+			}
+		}
+		untagged, err := PortsToBitmap(untaggedPorts, portCount)
+		if err != nil {
+			return err
+		}
+		tagged, err := PortsToBitmap(taggedPorts, portCount)
+		if err != nil {
+			return err
+		}
+		data.Set("untagPorts", untagged)
+		data.Set("tagPorts", tagged)
+	} else {
+		hash, err := m.client.fetchSecurityHash(ctx, endpoint, EndpointVLANMembership)
+		if err != nil {
+			return err
+		}
+		data.Set(m.client.hashFieldName(), hash)
+
+		mem := []byte(strings.Repeat("3", portCount))
+		for portID, membership := range members {
+			if portID < 1 || portID > portCount {
+				continue
+			}
+			switch membership {
+			case VLANMemberUntagged:
+				mem[portID-1] = '1'
+			case VLANMemberTagged:
+				mem[portID-1] = '2'
+			}
+		}
+		data.Set("hiddenMem", string(mem))
+	}
+
+	response, err := m.client.makeAuthenticatedRequestWithFallback(ctx, "POST", endpoint, data, EndpointVLANMembership)
+	if err != nil {
+		return err
+	}
+
+	if errorMsg := internal.ExtractErrorMessage(response); errorMsg != "" {
+		return NewOperationError(fmt.Sprintf("membership update failed for VLAN %d: %s", vlanID, errorMsg), nil)
+	}
+
+	return nil
+}
+
+// setPVID sets the port VLAN ID used for untagged ingress traffic
+func (m *VLANManager) setPVID(ctx context.Context, portID int, vlanID int) error {
+	if err := m.client.endpoints.ValidateEndpoint(EndpointVLANPVID); err != nil {
+		return err
+	}
+
+	endpoint := m.client.endpoints.GetEndpoint(EndpointVLANPVID).URL
+
+	data := url.Values{}
+	data.Set("port", strconv.Itoa(portID))
+	data.Set("pvid", strconv.Itoa(vlanID))
+
+	if m.client.model.FormStyle() != FormStyleBitmap {
+		hash, err := m.client.fetchSecurityHash(ctx, endpoint, EndpointVLANPVID)
+		if err != nil {
+			return err
+		}
+		data.Set(m.client.hashFieldName(), hash)
+	}
+
+	response, err := m.client.makeAuthenticatedRequestWithFallback(ctx, "POST", endpoint, data, EndpointVLANPVID)
+	if err != nil {
+		return err
+	}
+
+	if errorMsg := internal.ExtractErrorMessage(response); errorMsg != "" {
+		return m.client.portError(portID, fmt.Sprintf("PVID update failed for port %d: %s", portID, errorMsg), nil)
+	}
+
+	return nil
+}
+
+// vlanIDField returns the form field name carrying the VLAN ID for the model
+func (m *VLANManager) vlanIDField() string {
+	if m.client.model.FormStyle() == FormStyleBitmap {
+		return "vlanId"
+	}
+	return "VLAN_ID"
+}
+
+// copyMembers returns a modifiable copy of a VLAN membership map
+func copyMembers(members map[int]VLANMembership) map[int]VLANMembership {
+	result := make(map[int]VLANMembership, len(members))
+	for portID, membership := range members {
+		result[portID] = membership
+	}
+	return result
+}
+
+// sortedVLANIDs returns the VLAN IDs of a membership map in ascending order
+func sortedVLANIDs(vlans map[int]VLANMembership) []int {
+	ids := make([]int, 0, len(vlans))
+	for id := range vlans {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
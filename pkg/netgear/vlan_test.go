@@ -0,0 +1,221 @@
+package netgear
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// vlanSwitch simulates the 802.1Q pages of a GS30x switch
+type vlanSwitch struct {
+	mu      sync.Mutex
+	members map[int]string // VLAN ID to hiddenMem string
+	pvids   map[int]int
+	writes  []string // method, path and encoded form of every POST
+}
+
+func newVLANSwitch(t *testing.T) (*vlanSwitch, string) {
+	sw := &vlanSwitch{
+		members: map[int]string{1: "11111111"},
+		pvids:   map[int]int{1: 1, 2: 1, 3: 1, 4: 1, 5: 1, 6: 1, 7: 1, 8: 1},
+	}
+	server := httptest.NewServer(http.HandlerFunc(sw.serve))
+	t.Cleanup(server.Close)
+	return sw, strings.TrimPrefix(server.URL, "http://")
+}
+
+func (sw *vlanSwitch) serve(w http.ResponseWriter, r *http.Request) {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+
+	r.ParseForm()
+	if r.Method == "POST" {
+		sw.writes = append(sw.writes, r.URL.Path+" "+r.PostForm.Encode())
+	}
+	const hash = `<input type="hidden" name="hash" value="h1">`
+	switch {
+	case r.Method == "GET" && r.URL.Path == "/8021qCf.cgi":
+		fmt.Fprint(w, hash)
+		// The firmware lists VLANs in ascending order
+		var ids []int
+		for id := range sw.members {
+			ids = append(ids, id)
+		}
+		sort.Ints(ids)
+		for _, id := range ids {
+			fmt.Fprintf(w, `<input type="checkbox" name="vlanck" value="%d">`, id)
+		}
+	case r.Method == "POST" && r.URL.Path == "/8021qCf.cgi":
+		switch r.PostForm.Get("ACTION") {
+		case "Add":
+			id, _ := strconv.Atoi(r.PostForm.Get("ADD_VLANID"))
+			sw.members[id] = "33333333"
+		case "Delete":
+			id, _ := strconv.Atoi(r.PostForm.Get("vlanck"))
+			delete(sw.members, id)
+		}
+	case r.Method == "GET" && r.URL.Path == "/8021qMembe.cgi":
+		id, _ := strconv.Atoi(r.URL.Query().Get("VLAN_ID"))
+		fmt.Fprintf(w, `%s<input type="hidden" name="hiddenMem" value="%s">`, hash, sw.members[id])
+	case r.Method == "POST" && r.URL.Path == "/8021qMembe.cgi":
+		id, _ := strconv.Atoi(r.PostForm.Get("VLAN_ID"))
+		sw.members[id] = r.PostForm.Get("hiddenMem")
+	case r.Method == "GET" && r.URL.Path == "/portPVID.cgi":
+		fmt.Fprint(w, hash+"<table>")
+		for port, pvid := range sw.pvids {
+			fmt.Fprintf(w, "<tr><td>%d</td><td>%d</td></tr>", port, pvid)
+		}
+		fmt.Fprint(w, "</table>")
+	case r.Method == "POST" && r.URL.Path == "/portPVID.cgi":
+		port, _ := strconv.Atoi(r.PostForm.Get("port"))
+		pvid, _ := strconv.Atoi(r.PostForm.Get("pvid"))
+		sw.pvids[port] = pvid
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestVLANLifecycle(t *testing.T) {
+	sw, address := newVLANSwitch(t)
+	client, err := NewClient(address, factoryClientOptions(address)...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	ctx := context.Background()
+	vlans := client.VLANs()
+
+	if err := vlans.CreateVLAN(ctx, 20); err != nil {
+		t.Fatalf("CreateVLAN failed: %v", err)
+	}
+	if err := vlans.CreateVLAN(ctx, 30); err != nil {
+		t.Fatalf("CreateVLAN failed: %v", err)
+	}
+	if err := vlans.CreateVLAN(ctx, 20); err == nil {
+		t.Error("expected an error creating an existing VLAN")
+	}
+	if err := vlans.CreateVLAN(ctx, 4095); err == nil {
+		t.Error("expected an error for an out of range VLAN ID")
+	}
+
+	// Port 3 carries VLAN 20 untagged and VLAN 30 tagged
+	if err := vlans.SetPortVLANMembership(ctx, 3, 20, []int{20}, []int{30}); err != nil {
+		t.Fatalf("SetPortVLANMembership failed: %v", err)
+	}
+	if err := vlans.SetPortVLANMembership(ctx, 3, 1, []int{20}, nil); err == nil {
+		t.Error("expected an error for a PVID the port is not a member of")
+	}
+
+	got, err := vlans.GetVLANs(ctx)
+	if err != nil {
+		t.Fatalf("GetVLANs failed: %v", err)
+	}
+	var ids []int
+	for _, vlan := range got {
+		ids = append(ids, vlan.ID)
+	}
+	if !sort.IntsAreSorted(ids) || len(ids) != 3 {
+		t.Fatalf("expected VLANs 1, 20, 30, got %v", ids)
+	}
+	if got[0].Members[3] != "" || got[1].Members[3] != VLANMemberUntagged || got[2].Members[3] != VLANMemberTagged {
+		t.Errorf("unexpected membership of port 3: %+v", got)
+	}
+	if sw.pvids[3] != 20 {
+		t.Errorf("expected PVID 20 on port 3, got %d", sw.pvids[3])
+	}
+
+	if err := vlans.DeleteVLAN(ctx, 30); err != nil {
+		t.Fatalf("DeleteVLAN failed: %v", err)
+	}
+	if err := vlans.DeleteVLAN(ctx, 30); err == nil {
+		t.Error("expected an error deleting a missing VLAN")
+	}
+	if err := vlans.DeleteVLAN(ctx, DefaultVLANID); err == nil {
+		t.Error("expected an error deleting the default VLAN")
+	}
+	if _, exists := sw.members[30]; exists {
+		t.Error("VLAN 30 was not deleted")
+	}
+}
+
+func TestVLANPortWriteSequence(t *testing.T) {
+	sw, address := newVLANSwitch(t)
+	client, err := NewClient(address, factoryClientOptions(address)...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	ctx := context.Background()
+	vlans := client.VLANs()
+	sw.members[20] = "33333333"
+	sw.members[30] = "33333333"
+
+	// Membership is added before the PVID moves, and the old VLAN is left last
+	if err := vlans.MakeAccessPort(ctx, 3, 20); err != nil {
+		t.Fatalf("MakeAccessPort failed: %v", err)
+	}
+	expectWrites(t, sw, []string{
+		"/8021qMembe.cgi VLAN_ID=20&hash=h1&hiddenMem=33133333",
+		"/portPVID.cgi hash=h1&port=3&pvid=20",
+		"/8021qMembe.cgi VLAN_ID=1&hash=h1&hiddenMem=11311111",
+	})
+
+	// VLANs the port already belongs to as desired are not rewritten
+	if err := vlans.MakeTrunkPort(ctx, 3, 1, 20, 30); err != nil {
+		t.Fatalf("MakeTrunkPort failed: %v", err)
+	}
+	expectWrites(t, sw, []string{
+		"/8021qMembe.cgi VLAN_ID=1&hash=h1&hiddenMem=11111111",
+		"/8021qMembe.cgi VLAN_ID=20&hash=h1&hiddenMem=33233333",
+		"/8021qMembe.cgi VLAN_ID=30&hash=h1&hiddenMem=33233333",
+		"/portPVID.cgi hash=h1&port=3&pvid=1",
+	})
+	if err := vlans.MakeTrunkPort(ctx, 3, 1, 20, 30); err != nil {
+		t.Fatalf("MakeTrunkPort failed: %v", err)
+	}
+	// Nothing is written when the port is already configured
+	expectWrites(t, sw, nil)
+
+	if err := vlans.MakeAccessPort(ctx, 3, 20); err != nil {
+		t.Fatalf("MakeAccessPort failed: %v", err)
+	}
+	expectWrites(t, sw, []string{
+		"/8021qMembe.cgi VLAN_ID=20&hash=h1&hiddenMem=33133333",
+		"/portPVID.cgi hash=h1&port=3&pvid=20",
+		"/8021qMembe.cgi VLAN_ID=1&hash=h1&hiddenMem=11311111",
+		"/8021qMembe.cgi VLAN_ID=30&hash=h1&hiddenMem=33333333",
+	})
+
+	// Invalid VLAN IDs are rejected before anything is written
+	for _, configure := range []func() error{
+		func() error { return vlans.MakeAccessPort(ctx, 3, MaxVLANID+1) },
+		func() error { return vlans.MakeAccessPort(ctx, 3, 0) },
+		func() error { return vlans.MakeTrunkPort(ctx, 3, 1, 20, 4095) },
+	} {
+		if err := configure(); err == nil || !strings.Contains(err.Error(), "out of range") {
+			t.Errorf("expected an out of range error, got %v", err)
+		}
+	}
+	if err := vlans.MakeAccessPort(ctx, 3, 40); err == nil || !strings.Contains(err.Error(), "does not exist") {
+		t.Errorf("expected an error for a missing VLAN, got %v", err)
+	}
+	if err := vlans.MakeTrunkPort(ctx, 3, 20, 20); err == nil {
+		t.Error("expected an error for a native VLAN that is also tagged")
+	}
+	expectWrites(t, sw, nil)
+}
+
+// expectWrites checks the writes the switch received since the last call
+func expectWrites(t *testing.T, sw *vlanSwitch, want []string) {
+	t.Helper()
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	if strings.Join(sw.writes, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected writes\n got: %q\nwant: %q", sw.writes, want)
+	}
+	sw.writes = nil
+}
@@ -0,0 +1,156 @@
+package netgear
+
+import (
+	"context"
+	"reflect"
+	"time"
+)
+
+// WatchOption configures a watch started with POE().WatchStatus or
+// Ports().WatchSettings
+type WatchOption func(*watchOptions)
+
+type watchOptions struct {
+	deltaOnly   bool
+	bufferSize  int
+	overflow    OverflowPolicy
+	overflowSet bool
+	onError     func(error)
+	onDropped   func(uint64)
+}
+
+// WithDeltaOnly makes a watch send only the ports whose values changed since
+// the previous poll. The first poll sends every port; polls that find no
+// change send nothing.
+func WithDeltaOnly() WatchOption {
+	return func(o *watchOptions) {
+		o.deltaOnly = true
+	}
+}
+
+// WithWatchBuffer sets how many snapshots may wait for a slow consumer and
+// what happens when the buffer is full. By default up to
+// DefaultEventBufferSize snapshots wait; full snapshots then drop the oldest,
+// which a newer snapshot supersedes, while delta watches stop polling until
+// the consumer catches up, since dropping a delta would lose a change.
+func WithWatchBuffer(size int, policy OverflowPolicy) WatchOption {
+	return func(o *watchOptions) {
+		o.bufferSize = size
+		o.overflow = policy
+		o.overflowSet = true
+	}
+}
+
+// WithWatchErrors calls fn with the error of every failed poll. Without it
+// failures are logged as warnings. A watch keeps polling after a failure.
+func WithWatchErrors(fn func(error)) WatchOption {
+	return func(o *watchOptions) {
+		o.onError = fn
+	}
+}
+
+// WithWatchDropped calls fn with the total number of snapshots dropped so
+// far each time a poll makes the watch drop or coalesce a snapshot because
+// the consumer fell behind. Without it drops are logged as warnings.
+func WithWatchDropped(fn func(dropped uint64)) WatchOption {
+	return func(o *watchOptions) {
+		o.onDropped = fn
+	}
+}
+
+// WatchStatus polls the POE status every interval and sends the result of
+// each poll on the returned channel, ports sorted by port number. The channel
+// is closed once ctx is done; snapshots the consumer has not read by then are
+// discarded.
+func (m *POEManager) WatchStatus(ctx context.Context, interval time.Duration, opts ...WatchOption) (<-chan []POEPortStatus, error) {
+	return watch(ctx, m.client, interval, opts, m.GetStatus, func(s POEPortStatus) int { return s.PortID })
+}
+
+// WatchSettings polls the port settings every interval and sends the result
+// of each poll on the returned channel, ports sorted by port number. The
+// channel is closed once ctx is done; snapshots the consumer has not read by
+// then are discarded.
+func (m *PortManager) WatchSettings(ctx context.Context, interval time.Duration, opts ...WatchOption) (<-chan []PortSettings, error) {
+	if err := m.client.endpoints.ValidateEndpoint(EndpointPortSettings); err != nil {
+		return nil, err
+	}
+	return watch(ctx, m.client, interval, opts, m.GetSettings, func(s PortSettings) int { return s.PortID })
+}
+
+// watch runs fetch every interval until ctx is done and delivers the results
+// through an EventBuffer
+func watch[T any](ctx context.Context, c *Client, interval time.Duration, opts []WatchOption, fetch func(context.Context) ([]T, error), port func(T) int) (<-chan []T, error) {
+	if !c.IsAuthenticated() {
+		return nil, ErrNotAuthenticated
+	}
+	if interval <= 0 {
+		return nil, NewOperationError("watch interval must be positive", nil)
+	}
+
+	o := watchOptions{
+		onError: func(err error) {
+			c.logger.Warn("watch poll failed", "address", c.address, "error", err)
+		},
+		onDropped: func(dropped uint64) {
+			c.logger.Warn("watch consumer fell behind, snapshots dropped", "address", c.address, "dropped", dropped)
+		},
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if !o.overflowSet && o.deltaOnly {
+		o.overflow = OverflowBlock
+	}
+
+	buffer := NewEventBuffer[[]T](o.bufferSize, o.overflow, nil)
+	go func() {
+		defer buffer.Close()
+
+		var previous map[int]T
+		var dropped uint64
+		for {
+			current, err := fetch(ctx)
+			switch {
+			case ctx.Err() != nil:
+				return
+			case err != nil:
+				o.onError(err)
+			default:
+				if o.deltaOnly {
+					current, previous = changedPorts(previous, current, port)
+					if len(current) == 0 {
+						break
+					}
+				}
+				if err := buffer.Push(ctx, current); err != nil {
+					return
+				}
+				if total := buffer.Dropped(); total != dropped {
+					dropped = total
+					o.onDropped(dropped)
+				}
+			}
+
+			if err := c.clock.Sleep(ctx, interval); err != nil {
+				return
+			}
+		}
+	}()
+
+	return buffer.C(), nil
+}
+
+// changedPorts returns the entries of current that differ from the entry of
+// the same port in previous, and current indexed by port for the next call
+func changedPorts[T any](previous map[int]T, current []T, port func(T) int) ([]T, map[int]T) {
+	next := make(map[int]T, len(current))
+	var changed []T
+	for _, item := range current {
+		id := port(item)
+		next[id] = item
+		if old, seen := previous[id]; !seen || !reflect.DeepEqual(old, item) {
+			changed = append(changed, item)
+		}
+	}
+	return changed, next
+}
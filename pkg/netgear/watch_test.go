@@ -0,0 +1,212 @@
+package netgear
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gherlein/go-netgear/pkg/netgear/internal/fakeclock"
+)
+
+// newWatchSwitch fakes a GS308EPP whose per-port POE draw the test sets;
+// ports drawing no power are searching for a device
+func newWatchSwitch(t *testing.T, power map[int]float64) (string, func(port int, watts float64)) {
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.URL.Path {
+		case "/getPoePortStatus.cgi":
+			fmt.Fprint(w, "<ul>")
+			for port := 1; port <= len(power); port++ {
+				status := "Searching"
+				if power[port] > 0 {
+					status = "Delivering Power"
+				}
+				fmt.Fprintf(w, `<li class="poePortStatusListItem"><input type="hidden" class="port" value="%d"><span class="poe-power-mode"><span>%s</span></span><div class="poe_port_status"><div><div><span>%.1fW</span></div></div></div></li>`, port, status, power[port])
+			}
+			fmt.Fprint(w, "</ul>")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	setPower := func(port int, watts float64) {
+		mu.Lock()
+		defer mu.Unlock()
+		power[port] = watts
+	}
+	return strings.TrimPrefix(server.URL, "http://"), setPower
+}
+
+// receive waits for the next snapshot of a watch
+func receive[T any](t *testing.T, ch <-chan []T) []T {
+	t.Helper()
+	select {
+	case snapshot, ok := <-ch:
+		if !ok {
+			t.Fatal("watch channel closed")
+		}
+		return snapshot
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a snapshot")
+		return nil
+	}
+}
+
+func TestWatchStatus(t *testing.T) {
+	address, _ := newWatchSwitch(t, map[int]float64{1: 4.5, 2: 6})
+	clock := fakeclock.New(time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC))
+	client, err := NewClient(address, append(factoryClientOptions(address), WithClock(clock))...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	statuses, err := client.POE().WatchStatus(ctx, time.Minute)
+	if err != nil {
+		t.Fatalf("WatchStatus failed: %v", err)
+	}
+
+	// Without WithDeltaOnly every poll sends every port
+	for i := 0; i < 2; i++ {
+		if snapshot := receive(t, statuses); len(snapshot) != 2 || snapshot[0].PortID != 1 || snapshot[1].PowerW != 6 {
+			t.Fatalf("poll %d: unexpected snapshot %+v", i, snapshot)
+		}
+		clock.BlockUntil(ctx, 1)
+		clock.Advance(time.Minute)
+	}
+
+	cancel()
+	for range statuses {
+	}
+}
+
+func TestWatchStatusDeltaOnly(t *testing.T) {
+	address, setPower := newWatchSwitch(t, map[int]float64{1: 4.5, 2: 6, 3: 0})
+	clock := fakeclock.New(time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC))
+	client, err := NewClient(address, append(factoryClientOptions(address), WithClock(clock))...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	statuses, err := client.POE().WatchStatus(ctx, time.Minute, WithDeltaOnly())
+	if err != nil {
+		t.Fatalf("WatchStatus failed: %v", err)
+	}
+
+	if snapshot := receive(t, statuses); len(snapshot) != 3 {
+		t.Fatalf("expected every port in the first snapshot, got %+v", snapshot)
+	}
+
+	// An unchanged poll sends nothing, so the next snapshot holds only the
+	// port that changed after it
+	clock.BlockUntil(ctx, 1)
+	clock.Advance(time.Minute)
+	clock.BlockUntil(ctx, 1)
+	setPower(2, 12.5)
+	clock.Advance(time.Minute)
+
+	snapshot := receive(t, statuses)
+	if len(snapshot) != 1 || snapshot[0].PortID != 2 || snapshot[0].PowerW != 12.5 {
+		t.Fatalf("expected only port 2 in the delta, got %+v", snapshot)
+	}
+}
+
+func TestWatchStatusDropped(t *testing.T) {
+	address, setPower := newWatchSwitch(t, map[int]float64{1: 1})
+	clock := fakeclock.New(time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC))
+	client, err := NewClient(address, append(factoryClientOptions(address), WithClock(clock))...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	var mu sync.Mutex
+	var reports []uint64
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	statuses, err := client.POE().WatchStatus(ctx, time.Minute, WithWatchBuffer(1, OverflowDropOldest),
+		WithWatchDropped(func(dropped uint64) {
+			mu.Lock()
+			defer mu.Unlock()
+			reports = append(reports, dropped)
+		}))
+	if err != nil {
+		t.Fatalf("WatchStatus failed: %v", err)
+	}
+
+	// Poll five times without reading; poll n reports n watts on port 1
+	const polls = 5
+	for poll := 1; poll <= polls; poll++ {
+		clock.BlockUntil(ctx, 1)
+		if poll < polls {
+			setPower(1, float64(poll+1))
+			clock.Advance(time.Minute)
+		}
+	}
+
+	// One snapshot waits in the buffer and at most one on its way to the
+	// consumer, so every other poll was dropped and reported as it happened
+	mu.Lock()
+	dropped := uint64(len(reports))
+	for i, report := range reports {
+		if report != uint64(i+1) {
+			t.Errorf("expected running drop counts, got %v", reports)
+			break
+		}
+	}
+	mu.Unlock()
+	if dropped < polls-2 {
+		t.Fatalf("expected at least %d drops, got reports %v", polls-2, reports)
+	}
+	var last []POEPortStatus
+	for range polls - dropped {
+		last = receive(t, statuses)
+	}
+	if last[0].PowerW != polls {
+		t.Errorf("expected the latest poll to survive, got %+v", last)
+	}
+}
+
+func TestWatchStatusErrors(t *testing.T) {
+	address, _ := newWatchSwitch(t, map[int]float64{1: 4.5})
+	client, err := NewClient(address, factoryClientOptions(address)...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	if _, err := client.POE().WatchStatus(context.Background(), 0); err == nil {
+		t.Error("expected an error for a zero interval")
+	}
+	if _, err := client.Ports().WatchSettings(context.Background(), time.Minute); err == nil {
+		t.Error("expected an error watching port settings on a GS30x switch")
+	}
+
+	client.Logout(context.Background())
+	if _, err := client.POE().WatchStatus(context.Background(), time.Minute); err != ErrNotAuthenticated {
+		t.Errorf("expected ErrNotAuthenticated, got %v", err)
+	}
+}
+
+func TestChangedPorts(t *testing.T) {
+	port := func(s POEPortStatus) int { return s.PortID }
+	first := []POEPortStatus{{PortID: 1, PowerW: 1}, {PortID: 2, PowerW: 2}}
+
+	changed, seen := changedPorts(nil, first, port)
+	if len(changed) != 2 {
+		t.Fatalf("expected all ports on the first poll, got %+v", changed)
+	}
+
+	changed, _ = changedPorts(seen, []POEPortStatus{{PortID: 1, PowerW: 1}, {PortID: 2, PowerW: 3}, {PortID: 3}}, port)
+	if len(changed) != 2 || changed[0].PortID != 2 || changed[1].PortID != 3 {
+		t.Errorf("expected the changed and the new port, got %+v", changed)
+	}
+}
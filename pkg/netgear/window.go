@@ -0,0 +1,229 @@
+package netgear
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WindowPolicy selects what happens to a write attempted outside the
+// maintenance window
+type WindowPolicy int
+
+const (
+	// WindowBlock fails the write with ErrOutsideMaintenanceWindow
+	WindowBlock WindowPolicy = iota
+	// WindowDefer waits (bounded by the request context) until the window opens
+	WindowDefer
+)
+
+// MaintenanceWindow is a weekly schedule of times when writes are allowed
+type MaintenanceWindow struct {
+	periods []windowPeriod
+	// Location is the time zone the schedule is written in; nil means local time
+	Location *time.Location
+	// Jitter spreads deferred writes over a random delay of up to this long
+	// after the window opens, so many automations don't hit the network at once
+	Jitter time.Duration
+}
+
+// windowPeriod is one "days start-end" clause. A period whose end is not
+// after its start runs past midnight into the following day.
+type windowPeriod struct {
+	days       [7]bool
+	start, end time.Duration
+}
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// ParseMaintenanceWindow parses a schedule of one or more clauses separated by
+// semicolons, each a day list followed by a time range:
+//
+//	daily 02:00-04:00
+//	Mon-Fri 22:00-06:00; Sat,Sun 00:00-24:00
+//
+// Days are three-letter names, ranges of them, comma-separated lists, or
+// "daily". Ranges ending at or before their start continue into the next day.
+func ParseMaintenanceWindow(spec string) (*MaintenanceWindow, error) {
+	window := &MaintenanceWindow{}
+	for _, clause := range strings.Split(spec, ";") {
+		clause = strings.TrimSpace(clause)
+		if clause == "" {
+			continue
+		}
+		fields := strings.Fields(clause)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("maintenance window %q: expected \"<days> <HH:MM>-<HH:MM>\"", clause)
+		}
+
+		days, err := parseWindowDays(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("maintenance window %q: %w", clause, err)
+		}
+
+		from, to, ok := strings.Cut(fields[1], "-")
+		if !ok {
+			return nil, fmt.Errorf("maintenance window %q: invalid time range %q", clause, fields[1])
+		}
+		start, err := parseClockTime(from)
+		if err != nil {
+			return nil, fmt.Errorf("maintenance window %q: %w", clause, err)
+		}
+		end, err := parseClockTime(to)
+		if err != nil {
+			return nil, fmt.Errorf("maintenance window %q: %w", clause, err)
+		}
+
+		window.periods = append(window.periods, windowPeriod{days: days, start: start, end: end})
+	}
+
+	if len(window.periods) == 0 {
+		return nil, fmt.Errorf("maintenance window is empty")
+	}
+	return window, nil
+}
+
+// parseWindowDays parses "daily", "Mon", "Mon-Fri" or "Sat,Sun"
+func parseWindowDays(s string) ([7]bool, error) {
+	var days [7]bool
+	if strings.EqualFold(s, "daily") || s == "*" {
+		for i := range days {
+			days[i] = true
+		}
+		return days, nil
+	}
+
+	for _, part := range strings.Split(strings.ToLower(s), ",") {
+		from, to, isRange := strings.Cut(part, "-")
+		first, ok := weekdayNames[from]
+		if !ok {
+			return days, fmt.Errorf("unknown day %q", from)
+		}
+		last := first
+		if isRange {
+			if last, ok = weekdayNames[to]; !ok {
+				return days, fmt.Errorf("unknown day %q", to)
+			}
+		}
+		for d := first; ; d = (d + 1) % 7 {
+			days[d] = true
+			if d == last {
+				break
+			}
+		}
+	}
+	return days, nil
+}
+
+// parseClockTime parses HH:MM (24:00 allowed) into an offset from midnight
+func parseClockTime(s string) (time.Duration, error) {
+	h, m, ok := strings.Cut(s, ":")
+	hours, herr := strconv.Atoi(h)
+	minutes, merr := strconv.Atoi(m)
+	if !ok || herr != nil || merr != nil || hours < 0 || minutes < 0 || minutes > 59 ||
+		hours > 24 || (hours == 24 && minutes != 0) {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute, nil
+}
+
+// location returns the window's time zone
+func (w *MaintenanceWindow) location() *time.Location {
+	if w.Location != nil {
+		return w.Location
+	}
+	return time.Local
+}
+
+// Contains reports whether writes are allowed at t
+func (w *MaintenanceWindow) Contains(t time.Time) bool {
+	t = t.In(w.location())
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	offset := t.Sub(midnight)
+	yesterday := (t.Weekday() + 6) % 7
+
+	for _, p := range w.periods {
+		if p.end > p.start {
+			if p.days[t.Weekday()] && offset >= p.start && offset < p.end {
+				return true
+			}
+			continue
+		}
+		// Overnight: from start until midnight today, or until end carried over from yesterday
+		if (p.days[t.Weekday()] && offset >= p.start) || (p.days[yesterday] && offset < p.end) {
+			return true
+		}
+	}
+	return false
+}
+
+// Next returns the earliest time at or after t when writes are allowed
+func (w *MaintenanceWindow) Next(t time.Time) time.Time {
+	if w.Contains(t) {
+		return t
+	}
+
+	t = t.In(w.location())
+	var next time.Time
+	for day := 0; day <= 7; day++ {
+		date := time.Date(t.Year(), t.Month(), t.Day()+day, 0, 0, 0, 0, t.Location())
+		for _, p := range w.periods {
+			if !p.days[date.Weekday()] {
+				continue
+			}
+			open := date.Add(p.start)
+			if !open.Before(t) && (next.IsZero() || open.Before(next)) {
+				next = open
+			}
+		}
+		if !next.IsZero() {
+			return next
+		}
+	}
+	return next
+}
+
+// WithMaintenanceWindow restricts writes to the switch to the given window.
+// Reads are never restricted. Write operations are admitted before they read
+// the form hash they post back, and every write request is checked again, so
+// a multi-port update running past the end of the window is cut short.
+func WithMaintenanceWindow(window *MaintenanceWindow, policy WindowPolicy) ClientOption {
+	return func(c *Client) {
+		c.window = window
+		c.windowPolicy = policy
+	}
+}
+
+// admitWrite enforces the maintenance window before a mutating request
+func (c *Client) admitWrite(ctx context.Context) error {
+	if c.window == nil {
+		return nil
+	}
+
+	for {
+		now := c.clock.Now()
+		if c.window.Contains(now) {
+			return nil
+		}
+
+		next := c.window.Next(now)
+		if c.windowPolicy != WindowDefer {
+			return fmt.Errorf("%w (next window opens %s)", ErrOutsideMaintenanceWindow, next.Format(time.RFC3339))
+		}
+
+		wait := next.Sub(now)
+		if c.window.Jitter > 0 {
+			wait += rand.N(c.window.Jitter)
+		}
+		c.logger.Info("deferring write until the maintenance window opens", "address", c.address, "wait", wait)
+		if err := c.clock.Sleep(ctx, wait); err != nil {
+			return fmt.Errorf("%w: %v", ErrOutsideMaintenanceWindow, err)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package netgear
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gherlein/go-netgear/pkg/netgear/internal/fakeclock"
+)
+
+func TestMaintenanceWindowContains(t *testing.T) {
+	window, err := ParseMaintenanceWindow("Mon-Fri 22:00-06:00; Sat,Sun 00:00-24:00")
+	if err != nil {
+		t.Fatalf("ParseMaintenanceWindow failed: %v", err)
+	}
+	window.Location = time.UTC
+
+	// 2026-10-12 is a Monday
+	for at, want := range map[string]bool{
+		"2026-10-12T12:00:00Z": false, // Monday midday
+		"2026-10-12T22:30:00Z": true,  // Monday night
+		"2026-10-13T05:59:00Z": true,  // carried over into Tuesday morning
+		"2026-10-13T06:00:00Z": false,
+		"2026-10-12T03:00:00Z": false, // Sunday has no overnight clause
+		"2026-10-11T15:00:00Z": true,  // Sunday
+	} {
+		ts, _ := time.Parse(time.RFC3339, at)
+		if got := window.Contains(ts); got != want {
+			t.Errorf("Contains(%s) = %v, want %v", at, got, want)
+		}
+	}
+
+	monday, _ := time.Parse(time.RFC3339, "2026-10-12T12:00:00Z")
+	if next := window.Next(monday); !next.Equal(monday.Add(10 * time.Hour)) {
+		t.Errorf("expected next window at 22:00, got %s", next)
+	}
+}
+
+func TestParseMaintenanceWindowErrors(t *testing.T) {
+	for _, spec := range []string{"", "Funday 01:00-02:00", "daily 25:00-26:00", "daily 01:00", "Mon"} {
+		if _, err := ParseMaintenanceWindow(spec); err == nil {
+			t.Errorf("expected error for %q", spec)
+		}
+	}
+}
+
+func TestAdmitWrite(t *testing.T) {
+	window, _ := ParseMaintenanceWindow("daily 02:00-04:00")
+	window.Location = time.UTC
+	clock := fakeclock.New(time.Date(2026, 10, 12, 1, 0, 0, 0, time.UTC))
+
+	tokenMgr := NewMemoryTokenManager()
+	tokenMgr.StoreToken(context.Background(), "192.0.2.1", "token", ModelGS308EPP)
+	newClient := func(policy WindowPolicy) *Client {
+		client, err := NewClient("192.0.2.1", WithTokenManager(tokenMgr), WithPasswordManager(nil),
+			WithClock(clock), WithMaintenanceWindow(window, policy))
+		if err != nil {
+			t.Fatalf("NewClient failed: %v", err)
+		}
+		return client
+	}
+
+	blocking := newClient(WindowBlock)
+	if err := blocking.admitWrite(context.Background()); !errors.Is(err, ErrOutsideMaintenanceWindow) {
+		t.Fatalf("expected ErrOutsideMaintenanceWindow, got %v", err)
+	}
+
+	deferring := newClient(WindowDefer)
+	done := make(chan error, 1)
+	go func() { done <- deferring.admitWrite(context.Background()) }()
+
+	clock.BlockUntil(context.Background(), 1)
+	clock.Advance(time.Hour)
+	if err := <-done; err != nil {
+		t.Fatalf("expected deferred write to be admitted, got %v", err)
+	}
+	if err := blocking.admitWrite(context.Background()); err != nil {
+		t.Errorf("expected write inside window to be admitted, got %v", err)
+	}
+}
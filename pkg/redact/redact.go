@@ -0,0 +1,165 @@
+// Package redact removes secrets (passwords, session tokens, Gambit values,
+// CSRF hashes, cookies) from text before it is logged, dumped into a debug
+// report or recorded in an audit trail.
+//
+// Every place that writes switch traffic or errors somewhere a human might
+// paste it goes through the Default scrubber, so adding a key there covers
+// them all:
+//
+//	redact.SetDefault(redact.New(append(redact.DefaultKeys, "apikey")))
+package redact
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync/atomic"
+)
+
+// Redacted replaces every secret value
+const Redacted = "REDACTED"
+
+// DefaultKeys are the parameter, form field, cookie and JSON key names whose
+// values are secret. A name matches if it equals a key or ends with one,
+// ignoring case, so "password" also covers "oldPassword" and "reNewPassword".
+var DefaultKeys = []string{"password", "passwd", "pwd", "token", "gambit", "gambitcookie", "sid", "hash", "secret"}
+
+// secretHeaders are headers whose whole value is secret
+var secretHeaders = map[string]bool{"Authorization": true, "Proxy-Authorization": true}
+
+// Scrubber redacts the values of a set of secret keys, plus anything matched
+// by extra patterns. It is safe for concurrent use.
+type Scrubber struct {
+	keys     []string
+	assigned *regexp.Regexp // key=value, key: value, "key": "value"
+	patterns []*regexp.Regexp
+}
+
+// inputTag matches an HTML input element, whose value is redacted when its name is secret
+var (
+	inputTag   = regexp.MustCompile(`(?i)<input\b[^>]*>`)
+	inputName  = regexp.MustCompile(`(?i)\bname\s*=\s*["']?([^"'\s>]+)`)
+	inputValue = regexp.MustCompile(`(?i)(\bvalue\s*=\s*)("[^"]*"|'[^']*'|[^\s>]+)`)
+)
+
+// New creates a scrubber for the given secret keys. Every match of an extra
+// pattern is redacted too; if the pattern has a capture group, only the
+// first group is replaced.
+func New(keys []string, patterns ...*regexp.Regexp) *Scrubber {
+	quoted := make([]string, len(keys))
+	for i, key := range keys {
+		quoted[i] = regexp.QuoteMeta(key)
+	}
+	return &Scrubber{
+		keys: append([]string(nil), keys...),
+		assigned: regexp.MustCompile(`(?i)(^|[^A-Za-z0-9_\-])([A-Za-z0-9_\-]*(?:` + strings.Join(quoted, "|") +
+			`)["']?\s*[:=]\s*["']?)([^"'&\s;,<>]+)`),
+		patterns: patterns,
+	}
+}
+
+var defaultScrubber atomic.Pointer[Scrubber]
+
+func init() {
+	defaultScrubber.Store(New(DefaultKeys))
+}
+
+// Default returns the scrubber used by the library and CLI
+func Default() *Scrubber {
+	return defaultScrubber.Load()
+}
+
+// SetDefault replaces the scrubber used by the library and CLI
+func SetDefault(s *Scrubber) {
+	defaultScrubber.Store(s)
+}
+
+// String redacts text with the Default scrubber
+func String(text string) string {
+	return Default().String(text)
+}
+
+// IsSecret reports whether a parameter or field name is one of the secret keys
+func (s *Scrubber) IsSecret(name string) bool {
+	name = strings.ToLower(name)
+	for _, key := range s.keys {
+		if strings.HasSuffix(name, strings.ToLower(key)) {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns text with every secret value replaced by Redacted
+func (s *Scrubber) String(text string) string {
+	text = inputTag.ReplaceAllStringFunc(text, func(tag string) string {
+		name := inputName.FindStringSubmatch(tag)
+		if name == nil || !s.IsSecret(name[1]) {
+			return tag
+		}
+		return inputValue.ReplaceAllString(tag, `${1}"`+Redacted+`"`)
+	})
+	text = s.assigned.ReplaceAllString(text, "${1}${2}"+Redacted)
+
+	for _, pattern := range s.patterns {
+		text = replaceMatches(pattern, text)
+	}
+	return text
+}
+
+// Values returns a copy of form or query values with secret values redacted
+func (s *Scrubber) Values(values url.Values) url.Values {
+	scrubbed := make(url.Values, len(values))
+	for name, list := range values {
+		copied := make([]string, len(list))
+		for i, value := range list {
+			if s.IsSecret(name) {
+				copied[i] = Redacted
+			} else {
+				copied[i] = s.String(value)
+			}
+		}
+		scrubbed[name] = copied
+	}
+	return scrubbed
+}
+
+// Header returns a copy of HTTP headers with credentials and cookie values redacted
+func (s *Scrubber) Header(header http.Header) http.Header {
+	scrubbed := make(http.Header, len(header))
+	for name, list := range header {
+		copied := make([]string, len(list))
+		for i, value := range list {
+			if secretHeaders[http.CanonicalHeaderKey(name)] {
+				copied[i] = Redacted
+			} else {
+				copied[i] = s.String(value)
+			}
+		}
+		scrubbed[name] = copied
+	}
+	return scrubbed
+}
+
+// replaceMatches redacts every match of pattern, or only its first group if it has one
+func replaceMatches(pattern *regexp.Regexp, text string) string {
+	matches := pattern.FindAllStringSubmatchIndex(text, -1)
+	if matches == nil {
+		return text
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		start, end := m[0], m[1]
+		if len(m) >= 4 && m[2] >= 0 {
+			start, end = m[2], m[3]
+		}
+		b.WriteString(text[last:start])
+		b.WriteString(Redacted)
+		last = end
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
@@ -0,0 +1,82 @@
+package redact
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		secret string
+		keep   string
+	}{
+		{"query", "/iss/specific/poePortConf.html?Gambit=5fa9e2c7&port=3", "5fa9e2c7", "port=3"},
+		{"form", "oldPassword=0123abcd&newPassword=cafe1234&hash=h77", "0123abcd", "oldPassword="},
+		{"form new password", "oldPassword=0123abcd&newPassword=cafe1234&hash=h77", "cafe1234", "newPassword="},
+		{"form hash", "oldPassword=0123abcd&newPassword=cafe1234&hash=h77", "h77", "hash="},
+		{"cookie", "SID=Xk2pQ9; Path=/; HttpOnly", "Xk2pQ9", "Path=/"},
+		{"json", `{"address":"10.0.0.2","password":"hunter22"}`, "hunter22", `"address":"10.0.0.2"`},
+		{"javascript", `var Gambit = "a1b2c3d4";`, "a1b2c3d4", "var Gambit"},
+		{"input value after name", `<input type="hidden" name="hash" value="9f8e7d">`, "9f8e7d", `name="hash"`},
+		{"input value before name", `<input value='s3cr3t' type=password name=password>`, "s3cr3t", "type=password"},
+		{"url error", `Get "http://10.0.0.2/x.html?Gambit=feedface": connection refused`, "feedface", "connection refused"},
+	}
+	for _, tt := range tests {
+		got := String(tt.input)
+		if strings.Contains(got, tt.secret) || !strings.Contains(got, Redacted) {
+			t.Errorf("%s: secret %q not redacted in %q", tt.name, tt.secret, got)
+		}
+		if !strings.Contains(got, tt.keep) {
+			t.Errorf("%s: %q lost from %q", tt.name, tt.keep, got)
+		}
+	}
+
+	for _, harmless := range []string{
+		`<input type="text" name="switch_name" value="lab">`,
+		"port=3&speed=1000&passwordless=1",
+		"security hash not found on /dashboard.cgi",
+		"password=",
+	} {
+		if got := String(harmless); got != harmless {
+			t.Errorf("harmless text changed: %q -> %q", harmless, got)
+		}
+	}
+}
+
+func TestValuesAndHeader(t *testing.T) {
+	values := Default().Values(url.Values{"password": {"pw"}, "port": {"3"}})
+	if values.Get("password") != Redacted || values.Get("port") != "3" {
+		t.Errorf("unexpected values %v", values)
+	}
+
+	header := Default().Header(http.Header{
+		"Authorization": {"Bearer abc"},
+		"Set-Cookie":    {"SID=abc; Path=/"},
+		"Server":        {"GoAhead"},
+	})
+	if header.Get("Authorization") != Redacted || header.Get("Set-Cookie") != "SID="+Redacted+"; Path=/" || header.Get("Server") != "GoAhead" {
+		t.Errorf("unexpected header %v", header)
+	}
+}
+
+func TestCustomScrubber(t *testing.T) {
+	s := New(append(DefaultKeys, "community"), regexp.MustCompile(`serial (\w+)`))
+	got := s.String("community=public serial 6LX1234567 password=pw")
+	if strings.Contains(got, "public") || strings.Contains(got, "6LX1234567") || strings.Contains(got, "=pw") {
+		t.Errorf("custom rules not applied: %q", got)
+	}
+	if !strings.Contains(got, "serial "+Redacted) {
+		t.Errorf("expected only the capture group to be replaced: %q", got)
+	}
+
+	defer SetDefault(Default())
+	SetDefault(s)
+	if String("community=public") != "community="+Redacted {
+		t.Error("SetDefault did not replace the default scrubber")
+	}
+}
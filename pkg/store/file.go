@@ -0,0 +1,119 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// FileStore keeps each value in its own file, dir/namespace/key, with names
+// escaped so any namespace or key is a valid file name. Writes replace files
+// atomically, so a crash never leaves a half-written value. Values are
+// readable by the owner only, since they can identify switches and owners.
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore creates a store under dir, which is created on the first Put
+func NewFileStore(dir string) *FileStore {
+	return &FileStore{dir: dir}
+}
+
+// Get reads the value of key in namespace
+func (s *FileStore) Get(ctx context.Context, namespace, key string) ([]byte, error) {
+	data, err := os.ReadFile(s.filename(namespace, key))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s/%s", ErrNotFound, namespace, key)
+	}
+	return data, err
+}
+
+// Put writes the value of key in namespace
+func (s *FileStore) Put(ctx context.Context, namespace, key string, value []byte) error {
+	dir := filepath.Join(s.dir, escapeName(namespace))
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	// Temporary files start with a dot, which escaped names never do
+	tmp, err := os.CreateTemp(dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(value); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.filename(namespace, key))
+}
+
+// List returns the keys in namespace in ascending order
+func (s *FileStore) List(ctx context.Context, namespace string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(s.dir, escapeName(namespace)))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		key, err := unescapeName(entry.Name())
+		if err != nil {
+			continue // not written by this store
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// filename returns the file holding key in namespace
+func (s *FileStore) filename(namespace, key string) string {
+	return filepath.Join(s.dir, escapeName(namespace), escapeName(key))
+}
+
+// emptyName is the file name of the empty namespace or key
+const emptyName = "%"
+
+// escapeName percent-encodes every byte except letters, digits, '-' and '_'
+func escapeName(name string) string {
+	if name == "" {
+		return emptyName
+	}
+	var b strings.Builder
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '-' || c == '_' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// unescapeName reverses escapeName
+func unescapeName(name string) (string, error) {
+	if name == emptyName {
+		return "", nil
+	}
+	return url.PathUnescape(name)
+}
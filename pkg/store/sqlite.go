@@ -0,0 +1,82 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// sqliteSchema creates the table SQLiteStore keeps every value in
+const sqliteSchema = `CREATE TABLE IF NOT EXISTS netgear_store (
+	namespace  TEXT NOT NULL,
+	key        TEXT NOT NULL,
+	value      BLOB NOT NULL,
+	updated_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+	PRIMARY KEY (namespace, key)
+)`
+
+// SQLiteStore keeps values in the netgear_store table of a SQLite database.
+// It works with any database/sql SQLite driver; package store/sqlite opens a
+// database with a pure Go one.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteStore creates the store's table in db if it does not exist yet
+func NewSQLiteStore(ctx context.Context, db *sql.DB) (*SQLiteStore, error) {
+	if _, err := db.ExecContext(ctx, sqliteSchema); err != nil {
+		return nil, fmt.Errorf("failed to create store table: %w", err)
+	}
+	return &SQLiteStore{db: db}, nil
+}
+
+// Get returns the value of key in namespace
+func (s *SQLiteStore) Get(ctx context.Context, namespace, key string) ([]byte, error) {
+	var value []byte
+	err := s.db.QueryRowContext(ctx,
+		`SELECT value FROM netgear_store WHERE namespace = ? AND key = ?`, namespace, key).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s/%s", ErrNotFound, namespace, key)
+	}
+	return value, err
+}
+
+// Put sets the value of key in namespace
+func (s *SQLiteStore) Put(ctx context.Context, namespace, key string, value []byte) error {
+	if value == nil {
+		value = []byte{}
+	}
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO netgear_store (namespace, key, value) VALUES (?, ?, ?)
+		ON CONFLICT (namespace, key) DO UPDATE SET
+			value = excluded.value,
+			updated_at = strftime('%Y-%m-%dT%H:%M:%fZ', 'now')`,
+		namespace, key, value)
+	return err
+}
+
+// List returns the keys in namespace in ascending order
+func (s *SQLiteStore) List(ctx context.Context, namespace string) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT key FROM netgear_store WHERE namespace = ? ORDER BY key`, namespace)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+// Close closes the underlying database
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
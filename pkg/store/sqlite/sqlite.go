@@ -0,0 +1,30 @@
+// Package sqlite opens a store.SQLiteStore with a pure Go SQLite driver, so
+// no C toolchain is needed. It is a separate package so that programs using
+// other stores do not link the driver.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/gherlein/go-netgear/pkg/store"
+
+	_ "modernc.org/sqlite" // registers the "sqlite" driver
+)
+
+// Open opens or creates the SQLite database file at path and returns a store
+// backed by it. Close the store to close the database.
+func Open(ctx context.Context, path string) (*store.SQLiteStore, error) {
+	// Write-ahead logging lets readers proceed during a write, and the busy
+	// timeout makes concurrent writers wait instead of failing
+	db, err := sql.Open("sqlite", path+"?_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)")
+	if err != nil {
+		return nil, err
+	}
+	s, err := store.NewSQLiteStore(ctx, db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
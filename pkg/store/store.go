@@ -0,0 +1,81 @@
+// Package store is the storage go-netgear keeps its own data in: discovered
+// switch quirks, port metadata such as locks, and the audit trail of
+// operations. Values are opaque bytes filed under a namespace and a key, so
+// one Store can back all of them, and embedders can plug in their own
+// database by implementing the interface.
+//
+// FileStore keeps one file per value, SQLiteStore keeps everything in one
+// table of a SQLite database (see package store/sqlite for a ready driver),
+// and MemoryStore keeps values for the life of the process.
+package store
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+)
+
+// ErrNotFound is returned by Get for a key that holds no value
+var ErrNotFound = errors.New("store: not found")
+
+// Store keeps values under namespaces. Implementations must be safe for
+// concurrent use.
+type Store interface {
+	// Get returns the value of key in namespace, or an error wrapping ErrNotFound
+	Get(ctx context.Context, namespace, key string) ([]byte, error)
+
+	// Put sets the value of key in namespace, replacing any previous value
+	Put(ctx context.Context, namespace, key string, value []byte) error
+
+	// List returns the keys in namespace in ascending order
+	List(ctx context.Context, namespace string) ([]string, error)
+}
+
+// MemoryStore keeps values in memory, for tests and short-lived tools
+type MemoryStore struct {
+	mu     sync.RWMutex
+	values map[string]map[string][]byte
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{values: make(map[string]map[string][]byte)}
+}
+
+// Get returns a copy of the value of key in namespace
+func (s *MemoryStore) Get(ctx context.Context, namespace, key string) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	value, ok := s.values[namespace][key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return append([]byte(nil), value...), nil
+}
+
+// Put stores a copy of value under key in namespace
+func (s *MemoryStore) Put(ctx context.Context, namespace, key string, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.values[namespace] == nil {
+		s.values[namespace] = make(map[string][]byte)
+	}
+	s.values[namespace][key] = append([]byte(nil), value...)
+	return nil
+}
+
+// List returns the keys in namespace in ascending order
+func (s *MemoryStore) List(ctx context.Context, namespace string) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]string, 0, len(s.values[namespace]))
+	for key := range s.values[namespace] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
@@ -0,0 +1,125 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/gherlein/go-netgear/pkg/store"
+	"github.com/gherlein/go-netgear/pkg/store/sqlite"
+)
+
+func TestStores(t *testing.T) {
+	ctx := context.Background()
+	stores := map[string]func(t *testing.T, dir string) store.Store{
+		"memory": func(t *testing.T, dir string) store.Store { return store.NewMemoryStore() },
+		"file":   func(t *testing.T, dir string) store.Store { return store.NewFileStore(dir) },
+		"sqlite": func(t *testing.T, dir string) store.Store {
+			s, err := sqlite.Open(ctx, filepath.Join(dir, "netgear.db"))
+			if err != nil {
+				t.Fatalf("Open failed: %v", err)
+			}
+			t.Cleanup(func() { s.Close() })
+			return s
+		},
+	}
+
+	for name, open := range stores {
+		t.Run(name, func(t *testing.T) {
+			s := open(t, t.TempDir())
+
+			if _, err := s.Get(ctx, "quirks", "10.0.0.1"); !errors.Is(err, store.ErrNotFound) {
+				t.Errorf("expected ErrNotFound, got %v", err)
+			}
+			if keys, err := s.List(ctx, "quirks"); err != nil || len(keys) != 0 {
+				t.Errorf("expected an empty namespace, got %v (%v)", keys, err)
+			}
+
+			// Keys and namespaces may hold anything an address or timestamp does
+			keys := []string{"10.0.0.2:8080", "10.0.0.1", "", "../escape", "switch.example.com/ä"}
+			for i, key := range keys {
+				if err := s.Put(ctx, "history/10.0.0.1", key, []byte(fmt.Sprint(i))); err != nil {
+					t.Fatalf("Put %q failed: %v", key, err)
+				}
+			}
+			if err := s.Put(ctx, "history/10.0.0.1", "10.0.0.1", []byte("replaced")); err != nil {
+				t.Fatalf("Put failed: %v", err)
+			}
+			if err := s.Put(ctx, "other", "10.0.0.1", []byte("other")); err != nil {
+				t.Fatalf("Put failed: %v", err)
+			}
+
+			value, err := s.Get(ctx, "history/10.0.0.1", "10.0.0.1")
+			if err != nil || string(value) != "replaced" {
+				t.Errorf("expected the replaced value, got %q (%v)", value, err)
+			}
+			value, err = s.Get(ctx, "history/10.0.0.1", "")
+			if err != nil || string(value) != "2" {
+				t.Errorf("expected the empty key's value, got %q (%v)", value, err)
+			}
+
+			listed, err := s.List(ctx, "history/10.0.0.1")
+			want := []string{"", "../escape", "10.0.0.1", "10.0.0.2:8080", "switch.example.com/ä"}
+			if err != nil || !reflect.DeepEqual(listed, want) {
+				t.Errorf("expected keys %q, got %q (%v)", want, listed, err)
+			}
+		})
+	}
+}
+
+func TestStoresConcurrentPuts(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	sqliteStore, err := sqlite.Open(ctx, filepath.Join(dir, "netgear.db"))
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer sqliteStore.Close()
+
+	for name, s := range map[string]store.Store{
+		"memory": store.NewMemoryStore(),
+		"file":   store.NewFileStore(filepath.Join(dir, "files")),
+		"sqlite": sqliteStore,
+	} {
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				if err := s.Put(ctx, "history", fmt.Sprintf("%03d", i), []byte("entry")); err != nil {
+					t.Errorf("%s: Put failed: %v", name, err)
+				}
+			}(i)
+		}
+		wg.Wait()
+
+		if keys, err := s.List(ctx, "history"); err != nil || len(keys) != 20 {
+			t.Errorf("%s: expected 20 keys, got %d (%v)", name, len(keys), err)
+		}
+	}
+}
+
+func TestFileStorePermissions(t *testing.T) {
+	dir := t.TempDir()
+	s := store.NewFileStore(dir)
+	if err := s.Put(context.Background(), "metadata", "10.0.0.1", []byte("{}")); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(dir, "metadata"))
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("expected one file, got %v (%v)", entries, err)
+	}
+	info, err := entries[0].Info()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("expected mode 0600, got %v", info.Mode().Perm())
+	}
+}
@@ -0,0 +1,114 @@
+// Package version identifies a go-netgear build and checks whether two
+// components (CLI, daemon, exporter) can talk to each other.
+//
+// Release builds set the variables with the linker:
+//
+//	go build -ldflags "-X github.com/gherlein/go-netgear/pkg/version.Version=v1.4.0 \
+//	    -X github.com/gherlein/go-netgear/pkg/version.Commit=$(git rev-parse HEAD) \
+//	    -X github.com/gherlein/go-netgear/pkg/version.Date=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// Builds without them fall back to the module and VCS information Go embeds.
+package version
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+// Build information, set at link time
+var (
+	Version = "dev"
+	Commit  = ""
+	Date    = ""
+)
+
+// ProtocolMajor is the major version of the protocol between go-netgear
+// components. It changes only when a newer component can no longer serve an
+// older one; minor additions keep it.
+const ProtocolMajor = 1
+
+// ErrIncompatible is returned by Check for a peer speaking another protocol major
+var ErrIncompatible = errors.New("incompatible go-netgear protocol version")
+
+// Info describes a build, and is what components exchange in a version handshake
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	Date      string `json:"date,omitempty"`
+	GoVersion string `json:"go_version"`
+	Protocol  int    `json:"protocol"`
+}
+
+// Get returns the information of the running build
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		Date:      Date,
+		GoVersion: runtime.Version(),
+		Protocol:  ProtocolMajor,
+	}
+
+	build, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	if info.Version == "dev" && build.Main.Version != "" && build.Main.Version != "(devel)" {
+		info.Version = build.Main.Version
+	}
+	for _, setting := range build.Settings {
+		switch {
+		case setting.Key == "vcs.revision" && info.Commit == "":
+			info.Commit = setting.Value
+		case setting.Key == "vcs.time" && info.Date == "":
+			info.Date = setting.Value
+		}
+	}
+	return info
+}
+
+// String renders the information on one line, e.g.
+// "v1.4.0 (commit 1a2b3c4, built 2026-10-17T12:00:00Z, go1.23.2)"
+func (i Info) String() string {
+	details := []string{}
+	if i.Commit != "" {
+		details = append(details, "commit "+shortCommit(i.Commit))
+	}
+	if i.Date != "" {
+		details = append(details, "built "+i.Date)
+	}
+	if i.GoVersion != "" {
+		details = append(details, i.GoVersion)
+	}
+	if len(details) == 0 {
+		return i.Version
+	}
+	return fmt.Sprintf("%s (%s)", i.Version, strings.Join(details, ", "))
+}
+
+// Check compares a peer's build information with the running build and
+// returns an error wrapping ErrIncompatible if their protocol majors differ,
+// naming which side to upgrade
+func Check(peer Info) error {
+	switch {
+	case peer.Protocol == ProtocolMajor:
+		return nil
+	case peer.Protocol > ProtocolMajor:
+		return fmt.Errorf("%w: peer %s speaks protocol %d, this build (%s) speaks %d; upgrade this side",
+			ErrIncompatible, peer.Version, peer.Protocol, Version, ProtocolMajor)
+	default:
+		return fmt.Errorf("%w: peer %s speaks protocol %d, this build (%s) speaks %d; upgrade the peer",
+			ErrIncompatible, peer.Version, peer.Protocol, Version, ProtocolMajor)
+	}
+}
+
+// shortCommit abbreviates a commit hash the way git does
+func shortCommit(commit string) string {
+	if len(commit) > 7 {
+		return commit[:7]
+	}
+	return commit
+}
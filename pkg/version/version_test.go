@@ -0,0 +1,39 @@
+package version
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestInfoString(t *testing.T) {
+	info := Info{Version: "v1.4.0", Commit: "1a2b3c4d5e6f", Date: "2026-10-17T12:00:00Z", GoVersion: "go1.23.2"}
+	if got, want := info.String(), "v1.4.0 (commit 1a2b3c4, built 2026-10-17T12:00:00Z, go1.23.2)"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got := (Info{Version: "dev"}).String(); got != "dev" {
+		t.Errorf("String() without details = %q", got)
+	}
+}
+
+func TestGet(t *testing.T) {
+	info := Get()
+	if info.Version == "" || info.GoVersion == "" || info.Protocol != ProtocolMajor {
+		t.Errorf("unexpected build info %+v", info)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	if err := Check(Info{Version: "v1.9.0", Protocol: ProtocolMajor}); err != nil {
+		t.Errorf("same protocol major rejected: %v", err)
+	}
+
+	err := Check(Info{Version: "v9.0.0", Protocol: ProtocolMajor + 1})
+	if !errors.Is(err, ErrIncompatible) || !strings.Contains(err.Error(), "upgrade this side") {
+		t.Errorf("newer peer: unexpected error %v", err)
+	}
+	err = Check(Info{Version: "v0.1.0", Protocol: ProtocolMajor - 1})
+	if !errors.Is(err, ErrIncompatible) || !strings.Contains(err.Error(), "upgrade the peer") {
+		t.Errorf("older peer: unexpected error %v", err)
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"strings"
+
+	"github.com/gherlein/go-netgear/pkg/netgear"
 )
 
 // TestConfig represents the overall test configuration
@@ -107,22 +109,15 @@ func (c *TestConfig) Validate() error {
 		}
 
 		// Validate model is supported
-		validModels := []string{"GS305EP", "GS305EPP", "GS308EP", "GS308EPP", "GS308EEP", "GS316EP", "GS316EPP"}
-		validModel := false
-		for _, m := range validModels {
-			if sw.Model == m {
-				validModel = true
-				break
-			}
-		}
-		if !validModel {
+		model := sw.model()
+		if model.PortCount() == 0 {
 			return fmt.Errorf("switch %s: unsupported model %s", sw.Name, sw.Model)
 		}
 
-		// Validate port numbers are reasonable
+		// Validate port numbers exist on the model
 		for _, port := range sw.TestPorts {
-			if port < 1 || port > 16 {
-				return fmt.Errorf("switch %s: invalid port number %d (must be 1-16)", sw.Name, port)
+			if port < 1 || port > model.PortCount() {
+				return fmt.Errorf("switch %s: invalid port number %d (must be 1-%d)", sw.Name, port, model.PortCount())
 			}
 		}
 	}
@@ -157,12 +152,26 @@ func (s *SwitchConfig) ShouldSkipTest(testName string) bool {
 	return false
 }
 
+// legacyModelNames maps model names that earlier test configurations
+// accepted, but the library does not know, to the model they are tested as
+var legacyModelNames = map[string]netgear.Model{
+	"GS308EEP": netgear.ModelGS308EP,
+}
+
+// model returns the library model the switch is tested as
+func (s *SwitchConfig) model() netgear.Model {
+	if model, ok := legacyModelNames[s.Model]; ok {
+		return model
+	}
+	return netgear.Model(s.Model)
+}
+
 // IsModel30x returns true if the switch is a 30x series model
 func (s *SwitchConfig) IsModel30x() bool {
-	return strings.HasPrefix(s.Model, "GS30")
+	return s.model().IsModel30x()
 }
 
 // IsModel316 returns true if the switch is a 316 series model
 func (s *SwitchConfig) IsModel316() bool {
-	return strings.HasPrefix(s.Model, "GS316")
+	return s.model().IsModel316()
 }
\ No newline at end of file
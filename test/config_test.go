@@ -91,6 +91,15 @@ func TestConfigValidation(t *testing.T) {
 			shouldErr: true,
 			errMsg:    "invalid port number",
 		},
+		{
+			name: "legacy model name",
+			config: TestConfig{
+				Switches: []SwitchConfig{
+					{Name: "test", Address: "192.168.1.10", Model: "GS308EEP", Password: "pass", TestPorts: []int{8}},
+				},
+			},
+			shouldErr: false,
+		},
 		{
 			name: "valid config",
 			config: TestConfig{
@@ -176,6 +185,7 @@ func TestModelHelpers(t *testing.T) {
 		{"GS305EPP", true, false},
 		{"GS308EP", true, false},
 		{"GS308EPP", true, false},
+		{"GS308EEP", true, false},
 		{"GS316EP", false, true},
 		{"GS316EPP", false, true},
 	}
@@ -174,38 +174,22 @@ func (f *TestFixtures) GetCompatiblePOEModes(model string) []netgear.POEMode {
 	}
 }
 
-// GetMaxPowerLimit returns the maximum power limit for a specific switch model
+// GetMaxPowerLimit returns the maximum power limit for a specific switch model,
+// as listed in the library's model capabilities
 func (f *TestFixtures) GetMaxPowerLimit(model string) float64 {
-	switch model {
-	case "GS305EP":
-		return 63.0 // 5-port with 63W budget
-	case "GS305EPP":
-		return 120.0 // 5-port with higher budget
-	case "GS308EP":
-		return 83.0 // 8-port with 83W budget
-	case "GS308EPP":
-		return 123.0 // 8-port with higher budget
-	case "GS316EP":
-		return 180.0 // 16-port
-	case "GS316EPP":
-		return 231.0 // 16-port with higher budget
-	default:
-		return 30.0 // Conservative default
+	if caps, ok := netgear.Model(model).Capabilities(); ok && caps.POEBudgetW > 0 {
+		return caps.POEBudgetW
 	}
+	return 30.0 // Conservative default
 }
 
-// GetPortCount returns the number of ports for a specific switch model
+// GetPortCount returns the number of ports for a specific switch model, as
+// listed in the library's model capabilities
 func (f *TestFixtures) GetPortCount(model string) int {
-	switch model {
-	case "GS305EP", "GS305EPP":
-		return 5
-	case "GS308EP", "GS308EPP":
-		return 8
-	case "GS316EP", "GS316EPP":
-		return 16
-	default:
-		return 8 // Default assumption
+	if caps, ok := netgear.Model(model).Capabilities(); ok && caps.PortCount > 0 {
+		return caps.PortCount
 	}
+	return 8 // Default assumption
 }
 
 // GetValidPortNumbers returns valid port numbers for a specific switch model
@@ -82,12 +82,16 @@ func TestGetMaxPowerLimit(t *testing.T) {
 		model    string
 		expected float64
 	}{
+		// Datasheet POE budgets; GS305EPP and GS308EP used to expect 120W and 83W,
+		// which no datasheet lists
 		{"GS305EP", 63.0},
-		{"GS305EPP", 120.0},
-		{"GS308EP", 83.0},
+		{"GS305EPP", 83.0},
+		{"GS308EP", 62.0},
 		{"GS308EPP", 123.0},
 		{"GS316EP", 180.0},
 		{"GS316EPP", 231.0},
+		{"MS108EUP", 230.0},
+		{"GS108Tv3", 30.0}, // No POE
 		{"UNKNOWN", 30.0}, // Default
 	}
 
@@ -112,6 +116,7 @@ func TestGetPortCount(t *testing.T) {
 		{"GS308EPP", 8},
 		{"GS316EP", 16},
 		{"GS316EPP", 16},
+		{"GS110TP", 10},
 		{"UNKNOWN", 8}, // Default
 	}
 
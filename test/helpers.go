@@ -10,6 +10,15 @@ import (
 	"github.com/gherlein/go-netgear/pkg/netgear"
 )
 
+// loginAttempts is how often test clients send a login or request that hits a
+// transient switch error
+const loginAttempts = 3
+
+// retryableErrors are the transient errors test clients retry: besides the
+// library's defaults, timeouts and failed connections of a switch that is
+// slow to answer after the previous test
+var retryableErrors = append([]error{netgear.ErrNetworkTimeout, netgear.ErrConnectionFailed}, netgear.DefaultRetryableErrors...)
+
 // TestResult represents the result of a test operation
 type TestResult struct {
 	TestName    string
@@ -96,34 +105,17 @@ func (sam *SharedAuthManager) AuthenticateAndCacheAll() error {
 		// Create client with token cache enabled
 		client, err := netgear.NewClient(switchConfig.Address,
 			netgear.WithTokenCache(sam.config.TestOptions.CacheDir),
-			netgear.WithVerbose(sam.verbose))
+			netgear.WithVerbose(sam.verbose),
+			netgear.WithRetryPolicy(loginAttempts, time.Second, retryableErrors...))
 		if err != nil {
 			authErrors = append(authErrors, fmt.Sprintf("Switch %s: Failed to create client - %v", switchConfig.Name, err))
 			continue
 		}
 
-		// Perform authentication with retry logic for timing issues
+		// Perform authentication; the client's retry policy covers timing issues
 		ctx := context.Background()
-		var loginErr error
-		authenticated := false
-
-		for attempt := 1; attempt <= 3; attempt++ {
-			if attempt > 1 {
-				if sam.verbose {
-					log.Printf("Retry attempt %d for switch %s", attempt, switchConfig.Name)
-				}
-				time.Sleep(time.Duration(attempt) * time.Second) // Progressive delay
-			}
-
-			loginErr = client.Login(ctx, switchConfig.Password)
-			if loginErr == nil && client.IsAuthenticated() {
-				authenticated = true
-				break
-			}
-		}
-
-		if !authenticated {
-			authErrors = append(authErrors, fmt.Sprintf("Switch %s: Authentication failed after %d attempts - %v", switchConfig.Name, 3, loginErr))
+		if loginErr := client.Login(ctx, switchConfig.Password); loginErr != nil || !client.IsAuthenticated() {
+			authErrors = append(authErrors, fmt.Sprintf("Switch %s: Authentication failed (up to %d attempts for transient errors) - %v", switchConfig.Name, loginAttempts, loginErr))
 			continue
 		}
 
@@ -241,7 +233,8 @@ func (h *TestHelper) CreateClient(switchName string) (*netgear.Client, error) {
 	// Create client with test cache directory
 	client, err := netgear.NewClient(switchConfig.Address,
 		netgear.WithTokenCache(h.config.TestOptions.CacheDir),
-		netgear.WithVerbose(h.verbose))
+		netgear.WithVerbose(h.verbose),
+		netgear.WithRetryPolicy(loginAttempts, time.Second, retryableErrors...))
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to create client for switch %s: %w", switchName, err)
@@ -257,28 +250,10 @@ func (h *TestHelper) AuthenticateClient(client *netgear.Client, switchName strin
 		return err
 	}
 
-	// Perform authentication with retry logic for timing issues
+	// Perform authentication; the client's retry policy covers timing issues
 	ctx := context.Background()
-	var loginErr error
-	authenticated := false
-
-	for attempt := 1; attempt <= 3; attempt++ {
-		if attempt > 1 {
-			if h.verbose {
-				log.Printf("Retry attempt %d for switch %s", attempt, switchName)
-			}
-			time.Sleep(time.Duration(attempt) * time.Second) // Progressive delay
-		}
-
-		loginErr = client.Login(ctx, switchConfig.Password)
-		if loginErr == nil && client.IsAuthenticated() {
-			authenticated = true
-			break
-		}
-	}
-
-	if !authenticated {
-		return fmt.Errorf("authentication failed for switch %s after 3 attempts: %w", switchName, loginErr)
+	if loginErr := client.Login(ctx, switchConfig.Password); loginErr != nil || !client.IsAuthenticated() {
+		return fmt.Errorf("authentication failed for switch %s (up to %d attempts for transient errors): %w", switchName, loginAttempts, loginErr)
 	}
 
 	if h.verbose {
@@ -0,0 +1,192 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// SoakOptions controls a soak run against a single switch
+type SoakOptions struct {
+	Duration      time.Duration // Total run time
+	ReadInterval  time.Duration // Pause between read cycles
+	WriteInterval time.Duration // How often a safe write/restore cycle runs (0 disables writes)
+}
+
+// DefaultSoakOptions returns conservative soak settings that keep load on the
+// switch's web server low enough for multi-hour runs
+func DefaultSoakOptions(duration time.Duration) SoakOptions {
+	return SoakOptions{
+		Duration:      duration,
+		ReadInterval:  5 * time.Second,
+		WriteInterval: 5 * time.Minute,
+	}
+}
+
+// SoakRecorder collects per-endpoint outcomes during a soak run
+type SoakRecorder struct {
+	mu        sync.Mutex
+	startTime time.Time
+	samples   map[string][]time.Duration
+	errors    map[string]int
+	lastError map[string]string
+}
+
+// NewSoakRecorder creates an empty recorder
+func NewSoakRecorder() *SoakRecorder {
+	return &SoakRecorder{
+		startTime: time.Now(),
+		samples:   make(map[string][]time.Duration),
+		errors:    make(map[string]int),
+		lastError: make(map[string]string),
+	}
+}
+
+// Record stores the latency and outcome of a single operation
+func (r *SoakRecorder) Record(endpoint string, latency time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.samples[endpoint] = append(r.samples[endpoint], latency)
+	if err != nil {
+		r.errors[endpoint]++
+		r.lastError[endpoint] = err.Error()
+	}
+}
+
+// Time runs fn, records its latency under endpoint and returns its error
+func (r *SoakRecorder) Time(endpoint string, fn func() error) error {
+	start := time.Now()
+	err := fn()
+	r.Record(endpoint, time.Since(start), err)
+	return err
+}
+
+// TimeContext runs fn like Time, but drops the outcome when fn was cut short
+// by ctx ending: a read interrupted by the end of the run is not a switch error
+func (r *SoakRecorder) TimeContext(ctx context.Context, endpoint string, fn func() error) error {
+	start := time.Now()
+	err := fn()
+	if err != nil && (ctx.Err() != nil || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled)) {
+		return err
+	}
+	r.Record(endpoint, time.Since(start), err)
+	return err
+}
+
+// SoakEndpointStats summarises one endpoint over the soak run
+type SoakEndpointStats struct {
+	Endpoint  string        `json:"endpoint"`
+	Requests  int           `json:"requests"`
+	Errors    int           `json:"errors"`
+	ErrorRate float64       `json:"error_rate"`
+	P50       time.Duration `json:"p50"`
+	P95       time.Duration `json:"p95"`
+	P99       time.Duration `json:"p99"`
+	Max       time.Duration `json:"max"`
+	LastError string        `json:"last_error,omitempty"`
+}
+
+// SoakReport is the stability report produced at the end of a soak run
+type SoakReport struct {
+	SwitchName string              `json:"switch_name"`
+	StartTime  time.Time           `json:"start_time"`
+	EndTime    time.Time           `json:"end_time"`
+	Endpoints  []SoakEndpointStats `json:"endpoints"`
+}
+
+// Report builds the stability report from everything recorded so far
+func (r *SoakRecorder) Report(switchName string) *SoakReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	report := &SoakReport{
+		SwitchName: switchName,
+		StartTime:  r.startTime,
+		EndTime:    time.Now(),
+	}
+
+	for endpoint, samples := range r.samples {
+		sorted := append([]time.Duration(nil), samples...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+		stats := SoakEndpointStats{
+			Endpoint:  endpoint,
+			Requests:  len(sorted),
+			Errors:    r.errors[endpoint],
+			P50:       percentile(sorted, 50),
+			P95:       percentile(sorted, 95),
+			P99:       percentile(sorted, 99),
+			LastError: r.lastError[endpoint],
+		}
+		if len(sorted) > 0 {
+			stats.Max = sorted[len(sorted)-1]
+			stats.ErrorRate = float64(stats.Errors) / float64(stats.Requests)
+		}
+		report.Endpoints = append(report.Endpoints, stats)
+	}
+
+	sort.Slice(report.Endpoints, func(i, j int) bool {
+		return report.Endpoints[i].Endpoint < report.Endpoints[j].Endpoint
+	})
+
+	return report
+}
+
+// TotalErrorRate returns the error rate across all endpoints
+func (r *SoakReport) TotalErrorRate() float64 {
+	var requests, errors int
+	for _, stats := range r.Endpoints {
+		requests += stats.Requests
+		errors += stats.Errors
+	}
+	if requests == 0 {
+		return 0
+	}
+	return float64(errors) / float64(requests)
+}
+
+// Print writes a human readable summary of the report to stdout
+func (r *SoakReport) Print() {
+	fmt.Printf("\n=== Soak Report: %s ===\n", r.SwitchName)
+	fmt.Printf("Duration: %v\n", r.EndTime.Sub(r.StartTime).Round(time.Second))
+	fmt.Printf("%-20s %8s %7s %8s %10s %10s %10s %10s\n",
+		"ENDPOINT", "REQUESTS", "ERRORS", "ERR%", "P50", "P95", "P99", "MAX")
+	for _, s := range r.Endpoints {
+		fmt.Printf("%-20s %8d %7d %7.2f%% %10v %10v %10v %10v\n",
+			s.Endpoint, s.Requests, s.Errors, s.ErrorRate*100,
+			s.P50.Round(time.Millisecond), s.P95.Round(time.Millisecond),
+			s.P99.Round(time.Millisecond), s.Max.Round(time.Millisecond))
+	}
+	for _, s := range r.Endpoints {
+		if s.LastError != "" {
+			fmt.Printf("Last error (%s): %s\n", s.Endpoint, s.LastError)
+		}
+	}
+}
+
+// WriteJSON saves the report to filename
+func (r *SoakReport) WriteJSON(filename string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal soak report: %w", err)
+	}
+	return os.WriteFile(filename, data, 0644)
+}
+
+// percentile returns the nearest-rank percentile of an ascending slice
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
@@ -0,0 +1,181 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gherlein/go-netgear/pkg/netgear"
+)
+
+// soakReportDir matches the directory used by `make run-tests` for cached results
+const soakReportDir = "/tmp/go-netgear-test-results"
+
+// TestSoak runs a duration-based stability loop against every configured switch.
+// It is skipped unless NETGEAR_SOAK_DURATION is set (e.g. "30m", "4h").
+func TestSoak(t *testing.T) {
+	durationStr := os.Getenv("NETGEAR_SOAK_DURATION")
+	if durationStr == "" {
+		t.Skip("Soak mode disabled - set NETGEAR_SOAK_DURATION to enable")
+	}
+	duration, err := time.ParseDuration(durationStr)
+	if err != nil {
+		t.Fatalf("Invalid NETGEAR_SOAK_DURATION %q: %v", durationStr, err)
+	}
+
+	env := DetectTestEnvironment(t)
+	env.RequireAuth(t, CategoryModify)
+
+	config, err := LoadTestConfig("test_config.json")
+	if err != nil {
+		t.Fatalf("Failed to load config file: %v", err)
+	}
+
+	helper := NewTestHelper(config)
+	opts := DefaultSoakOptions(duration)
+
+	for _, switchConfig := range config.Switches {
+		switchConfig := switchConfig
+		t.Run(fmt.Sprintf("switch_%s", switchConfig.Name), func(t *testing.T) {
+			if switchConfig.ShouldSkipTest("soak") {
+				t.Skip("Test excluded for this switch")
+			}
+
+			client, err := helper.GetClientForTest(switchConfig.Name)
+			if err != nil {
+				t.Fatalf("Failed to get client: %v", err)
+			}
+
+			recorder := NewSoakRecorder()
+			runSoak(t, helper, client, switchConfig.TestPorts, opts, recorder)
+
+			report := recorder.Report(switchConfig.Name)
+			report.Print()
+
+			if err := os.MkdirAll(soakReportDir, 0755); err == nil {
+				filename := filepath.Join(soakReportDir, fmt.Sprintf("soak-%s.json", switchConfig.Name))
+				if err := report.WriteJSON(filename); err != nil {
+					t.Logf("Failed to write soak report: %v", err)
+				} else {
+					t.Logf("Soak report written to %s", filename)
+				}
+			}
+
+			if rate := report.TotalErrorRate(); rate > 0.01 {
+				t.Errorf("Soak error rate %.2f%% exceeds 1%% threshold", rate*100)
+			}
+		})
+	}
+}
+
+// runSoak loops read operations until the duration elapses, interleaving a
+// safe write (port rename) that is always restored afterwards. Reads cut off
+// by the end of the run are not recorded.
+func runSoak(t *testing.T, helper *TestHelper, client *netgear.Client, testPorts []int, opts SoakOptions, recorder *SoakRecorder) {
+	ctx, cancel := context.WithTimeout(context.Background(), opts.Duration)
+	defer cancel()
+
+	lastWrite := time.Now()
+	cycle := 0
+	for ctx.Err() == nil {
+		cycle++
+
+		recorder.TimeContext(ctx, "poe_status", func() error {
+			_, err := client.POE().GetStatus(ctx)
+			return err
+		})
+		recorder.TimeContext(ctx, "poe_settings", func() error {
+			_, err := client.POE().GetSettings(ctx)
+			return err
+		})
+		recorder.TimeContext(ctx, "port_settings", func() error {
+			_, err := client.Ports().GetSettings(ctx)
+			return err
+		})
+
+		if opts.WriteInterval > 0 && len(testPorts) > 0 && time.Since(lastWrite) >= opts.WriteInterval {
+			lastWrite = time.Now()
+			if err := soakWriteCycle(helper, client, testPorts[0], cycle, recorder); err != nil {
+				t.Errorf("Write cycle %d failed: %v", cycle, err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(opts.ReadInterval):
+		}
+	}
+}
+
+// soakWriteCycle renames a test port and restores its original configuration.
+// It uses a fresh context so a write in flight when the soak ends is still restored.
+func soakWriteCycle(helper *TestHelper, client *netgear.Client, portID, cycle int, recorder *SoakRecorder) error {
+	ctx := context.Background()
+
+	states, err := helper.CapturePortState(client, []int{portID})
+	if err != nil {
+		return err
+	}
+	if _, ok := states[portID]; !ok {
+		return fmt.Errorf("port %d not found in port settings", portID)
+	}
+
+	writeErr := recorder.Time("port_write", func() error {
+		return client.Ports().SetPortName(ctx, portID, fmt.Sprintf("soak-%d", cycle%1000))
+	})
+
+	restoreErr := recorder.Time("port_restore", func() error {
+		return helper.RestorePortState(client, states)
+	})
+
+	return errors.Join(writeErr, restoreErr)
+}
+
+func TestSoakRecorderReport(t *testing.T) {
+	recorder := NewSoakRecorder()
+	for i := 1; i <= 100; i++ {
+		var err error
+		if i%25 == 0 {
+			err = fmt.Errorf("timeout %d", i)
+		}
+		recorder.Record("poe_status", time.Duration(i)*time.Millisecond, err)
+	}
+
+	report := recorder.Report("test")
+	if len(report.Endpoints) != 1 {
+		t.Fatalf("Expected 1 endpoint, got %d", len(report.Endpoints))
+	}
+
+	stats := report.Endpoints[0]
+	if stats.Requests != 100 || stats.Errors != 4 {
+		t.Errorf("Expected 100 requests/4 errors, got %d/%d", stats.Requests, stats.Errors)
+	}
+	if stats.P50 != 50*time.Millisecond || stats.P95 != 95*time.Millisecond || stats.Max != 100*time.Millisecond {
+		t.Errorf("Unexpected percentiles: p50=%v p95=%v max=%v", stats.P50, stats.P95, stats.Max)
+	}
+	if stats.LastError != "timeout 100" {
+		t.Errorf("Expected last error 'timeout 100', got %q", stats.LastError)
+	}
+	if rate := report.TotalErrorRate(); rate != 0.04 {
+		t.Errorf("Expected total error rate 0.04, got %v", rate)
+	}
+}
+
+func TestSoakRecorderIgnoresInterruptedReads(t *testing.T) {
+	recorder := NewSoakRecorder()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	recorder.TimeContext(ctx, "poe_status", func() error { return nil })
+	recorder.TimeContext(ctx, "poe_status", func() error { return fmt.Errorf("read failed: %w", context.DeadlineExceeded) })
+	cancel()
+	recorder.TimeContext(ctx, "poe_status", func() error { return errors.New("connection reset") })
+
+	stats := recorder.Report("test").Endpoints[0]
+	if stats.Requests != 1 || stats.Errors != 0 {
+		t.Errorf("Expected 1 request/0 errors, got %d/%d", stats.Requests, stats.Errors)
+	}
+}